@@ -78,7 +78,7 @@ func initAce() (*app.Ace, func(), error) {
 	giteaApp := gitea.NewApp()
 	grafanaApp := grafana.NewApp(locale)
 	kafkaApp := kafka.NewApp(locale)
-	logger, cleanup, err := bootstrap.NewLogger(config)
+	logger, cleanup, err := bootstrap.NewLogger(config, db)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -100,7 +100,7 @@ func initAce() (*app.Ace, func(), error) {
 	phpmyadminApp := phpmyadmin.NewApp(config, locale, databaseServerRepo)
 	podmanApp := podman.NewApp()
 	postgresqlApp := postgresql.NewApp(locale, config, databaseServerRepo, settingRepo, taskRepo)
-	prometheusApp := prometheus.NewApp(config, locale, taskRepo)
+	prometheusApp := prometheus.NewApp(config, locale, settingRepo, taskRepo)
 	pureftpdApp := pureftpd.NewApp(locale)
 	redisApp := redis.NewApp(locale, databaseServerRepo, taskRepo)
 	rocketmqApp := rocketmq.NewApp(locale)
@@ -115,8 +115,9 @@ func initAce() (*app.Ace, func(), error) {
 		return nil, nil, err
 	}
 	appRepo := data.NewAppRepo(config, db, locale, slogLogger)
+	userRepo := data.NewUserRepo(db, locale)
 	userTokenRepo := data.NewUserTokenRepo(config, db, locale)
-	middlewares, err := middleware.NewMiddlewares(config, locale, manager, appRepo, userTokenRepo)
+	middlewares, err := middleware.NewMiddlewares(config, locale, manager, appRepo, userRepo, userTokenRepo)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
@@ -133,25 +134,35 @@ func initAce() (*app.Ace, func(), error) {
 	cacheRepo := data.NewCacheRepo(db)
 	appUsecase := biz.NewAppUsecase(locale, appRepo, cacheRepo, taskRepo)
 	cacheUsecase := biz.NewCacheUsecase(cacheRepo)
-	settingUsecase := biz.NewSettingUsecase(locale, slogLogger, settingRepo, taskRepo)
+	firewallGeoRepo := data.NewFirewallGeoRepo(db, locale)
+	settingUsecase := biz.NewSettingUsecase(locale, slogLogger, settingRepo, taskRepo, firewallGeoRepo)
 	appService := service.NewAppService(loader, appUsecase, cacheUsecase, settingUsecase, locale)
+	log := bootstrap.NewAudit(logger)
+	auditUsecase := biz.NewAuditUsecase(locale, slogLogger, log, userRepo, settingRepo)
+	auditService := service.NewAuditService(auditUsecase)
 	backupRepo := data.NewBackupRepo(config, db, locale, slogLogger, settingRepo, websiteRepo)
 	backupUsecase := biz.NewBackupUsecase(notifyUsecase, locale, slogLogger, backupRepo)
-	taskUsecase := biz.NewTaskUsecase(taskRepo)
+	taskUsecase := biz.NewTaskUsecase(slogLogger, taskRepo, settingRepo)
 	backupService := service.NewBackupService(backupUsecase, taskUsecase, locale)
 	backupAccountRepo := data.NewBackupAccountRepo(db)
 	backupAccountUsecase := biz.NewBackupAccountUsecase(locale, slogLogger, backupAccountRepo, settingRepo)
 	backupStorageService := service.NewBackupStorageService(backupAccountUsecase, locale)
 	certRepo := data.NewCertRepo(db, locale, slogLogger)
-	certUsecase := biz.NewCertUsecase(locale, slogLogger, certRepo, settingRepo)
+	migrationRemoteRepo := data.NewMigrationRemoteRepo(locale)
+	certDeployRepo := data.NewCertDeployRepo(db, locale, settingRepo, migrationRemoteRepo)
+	certUsecase := biz.NewCertUsecase(locale, slogLogger, certRepo, certDeployRepo, settingRepo)
 	certService := service.NewCertService(certUsecase, locale)
 	certAccountRepo := data.NewCertAccountRepo(db, locale, slogLogger)
-	userRepo := data.NewUserRepo(db, locale)
 	certAccountUsecase := biz.NewCertAccountUsecase(locale, slogLogger, certAccountRepo, userRepo)
 	certAccountService := service.NewCertAccountService(certAccountUsecase)
 	certDNSRepo := data.NewCertDNSRepo(db)
-	certDNSUsecase := biz.NewCertDNSUsecase(certDNSRepo, slogLogger)
+	certDNSUsecase := biz.NewCertDNSUsecase(locale, certDNSRepo, slogLogger)
 	certDNSService := service.NewCertDNSService(certDNSUsecase)
+	certDeployUsecase := biz.NewCertDeployUsecase(locale, slogLogger, certDeployRepo, certRepo)
+	certDeployService := service.NewCertDeployService(certDeployUsecase)
+	certMonitorRepo := data.NewCertMonitorRepo(db, locale)
+	certMonitorUsecase := biz.NewCertMonitorUsecase(locale, slogLogger, certMonitorRepo)
+	certMonitorService := service.NewCertMonitorService(certMonitorUsecase)
 	containerUsecase := biz.NewContainerUsecase(locale, containerRepo, settingRepo, taskRepo)
 	containerService := service.NewContainerService(containerUsecase)
 	containerComposeRepo := data.NewContainerComposeRepo()
@@ -167,7 +178,7 @@ func initAce() (*app.Ace, func(), error) {
 	containerVolumeUsecase := biz.NewContainerVolumeUsecase(containerVolumeRepo, settingRepo)
 	containerVolumeService := service.NewContainerVolumeService(containerVolumeUsecase)
 	cronRepo := data.NewCronRepo(db, locale)
-	cronUsecase := biz.NewCronUsecase(cronRepo, slogLogger)
+	cronUsecase := biz.NewCronUsecase(locale, slogLogger, cronRepo, taskRepo, settingRepo)
 	cronService := service.NewCronService(cronUsecase)
 	databaseUserRepo := data.NewDatabaseUserRepo(db)
 	databaseUserUsecase := biz.NewDatabaseUserUsecase(slogLogger, databaseServerRepo, databaseUserRepo)
@@ -183,7 +194,12 @@ func initAce() (*app.Ace, func(), error) {
 	databaseServerUsecase := biz.NewDatabaseServerUsecase(locale, slogLogger, databaseServerRepo)
 	databaseServerService := service.NewDatabaseServerService(databaseServerUsecase)
 	databaseUserService := service.NewDatabaseUserService(databaseUserUsecase)
+	projectRepo := data.NewProjectRepo(db, locale, websiteRepo)
+	deploymentRepo := data.NewDeploymentRepo(db, locale, projectRepo, websiteRepo)
+	webHookRepo := data.NewWebHookRepo(db, locale)
 	environmentRepo := data.NewEnvironmentRepo(config, locale)
+	deploymentUsecase := biz.NewDeploymentUsecase(locale, slogLogger, deploymentRepo, taskRepo, webHookRepo, environmentRepo)
+	deploymentService := service.NewDeploymentService(deploymentUsecase)
 	environmentUsecase := biz.NewEnvironmentUsecase(locale, cacheRepo, environmentRepo, taskRepo)
 	environmentService := service.NewEnvironmentService(environmentUsecase, taskUsecase, locale)
 	environmentDotnetService := service.NewEnvironmentDotnetService(environmentUsecase, locale)
@@ -202,7 +218,8 @@ func initAce() (*app.Ace, func(), error) {
 	fileShareRepo := data.NewFileShareRepo(db, locale)
 	fileShareUsecase := biz.NewFileShareUsecase(slogLogger, fileShareRepo)
 	fileShareService := service.NewFileShareService(fileShareUsecase, locale)
-	firewallService := service.NewFirewallService(locale)
+	firewallGeoUsecase := biz.NewFirewallGeoUsecase(settingUsecase, locale, slogLogger, firewallGeoRepo)
+	firewallService := service.NewFirewallService(locale, firewallGeoUsecase)
 	scanEventRepo, err := data.NewScanEventRepo()
 	if err != nil {
 		cleanup()
@@ -210,8 +227,7 @@ func initAce() (*app.Ace, func(), error) {
 	}
 	scanEventUsecase := biz.NewScanEventUsecase(scanEventRepo, settingRepo)
 	firewallScanService := service.NewFirewallScanService(scanEventUsecase)
-	projectRepo := data.NewProjectRepo(db, locale)
-	projectUsecase := biz.NewProjectUsecase(locale, slogLogger, projectRepo)
+	projectUsecase := biz.NewProjectUsecase(locale, slogLogger, projectRepo, taskRepo, websiteRepo)
 	websiteStatRepo, err := data.NewWebsiteStatRepo()
 	if err != nil {
 		cleanup()
@@ -223,6 +239,8 @@ func initAce() (*app.Ace, func(), error) {
 	logRepo := data.NewLogRepo(db)
 	logUsecase := biz.NewLogUsecase(logRepo)
 	logService := service.NewLogService(logUsecase, locale)
+	aggregator := websitestat.NewAggregator()
+	metricsService := service.NewMetricsService(locale, alertUsecase, scanEventUsecase, settingUsecase, tamperUsecase, taskUsecase, aggregator)
 	monitorRepo, err := data.NewMonitorRepo()
 	if err != nil {
 		cleanup()
@@ -231,8 +249,14 @@ func initAce() (*app.Ace, func(), error) {
 	monitorUsecase := biz.NewMonitorUsecase(monitorRepo, settingRepo)
 	monitorService := service.NewMonitorService(monitorUsecase, settingUsecase)
 	notifyService := service.NewNotifyService(notifyUsecase)
+	probeRepo := data.NewProbeRepo(db, locale)
+	probeUsecase := biz.NewProbeUsecase(locale, slogLogger, probeRepo)
+	probeService := service.NewProbeService(probeUsecase)
 	processService := service.NewProcessService()
 	projectService := service.NewProjectService(projectUsecase, settingUsecase)
+	roleRepo := data.NewRoleRepo(db)
+	roleUsecase := biz.NewRoleUsecase(locale, slogLogger, roleRepo)
+	roleService := service.NewRoleService(roleUsecase)
 	safeRepo := data.NewSafeRepo()
 	safeUsecase := biz.NewSafeUsecase(safeRepo, slogLogger)
 	safeService := service.NewSafeService(safeUsecase)
@@ -246,11 +270,13 @@ func initAce() (*app.Ace, func(), error) {
 	templateRepo := data.NewTemplateRepo(slogLogger)
 	templateUsecase := biz.NewTemplateUsecase(locale, cacheRepo, templateRepo)
 	templateService := service.NewTemplateService(settingUsecase, templateUsecase, locale)
+	terminalRecordingRepo := data.NewTerminalRecordingRepo(db, locale)
+	terminalRecordingUsecase := biz.NewTerminalRecordingUsecase(locale, slogLogger, terminalRecordingRepo, userRepo, settingRepo)
+	terminalRecordingService := service.NewTerminalRecordingService(terminalRecordingUsecase)
 	toolboxBenchmarkService := service.NewToolboxBenchmarkService(locale)
 	toolboxDiskService := service.NewToolboxDiskService(locale)
 	toolboxLogService := service.NewToolboxLogService(containerImageUsecase, settingUsecase, db, locale)
 	migrationSourceRepo := data.NewMigrationSourceRepo(locale)
-	migrationRemoteRepo := data.NewMigrationRemoteRepo(locale)
 	migrationArchiveRepo := data.NewMigrationArchiveRepo()
 	toolboxMigrationUsecase := biz.NewToolboxMigrationUsecase(locale, slogLogger, migrationSourceRepo, migrationRemoteRepo, migrationArchiveRepo, settingUsecase, websiteUsecase, databaseUsecase, databaseServerUsecase, databaseUserUsecase, backupUsecase, projectUsecase, appUsecase, environmentUsecase)
 	toolboxMigrationService := service.NewToolboxMigrationService(toolboxMigrationUsecase, config, locale, slogLogger)
@@ -262,23 +288,24 @@ func initAce() (*app.Ace, func(), error) {
 	userPasskeyRepo := data.NewUserPasskeyRepo(db)
 	userPasskeyUsecase := biz.NewUserPasskeyUsecase(userPasskeyRepo)
 	userPasskeyService := service.NewUserPasskeyService(notifyUsecase, userPasskeyUsecase, userUsecase, config, locale, manager)
-	userTokenUsecase := biz.NewUserTokenUsecase(userTokenRepo)
+	userTokenUsecase := biz.NewUserTokenUsecase(locale, userTokenRepo)
 	userTokenService := service.NewUserTokenService(userTokenUsecase, locale)
-	webHookRepo := data.NewWebHookRepo(db, locale)
 	webHookUsecase := biz.NewWebHookUsecase(locale, slogLogger, webHookRepo)
 	webHookService := service.NewWebHookService(webHookUsecase)
 	websiteService := service.NewWebsiteService(settingUsecase, websiteUsecase, locale)
-	aggregator := websitestat.NewAggregator()
 	websiteStatService := service.NewWebsiteStatService(settingUsecase, websiteStatUsecase, websiteUsecase, aggregator)
-	wsService := service.NewWsService(backupUsecase, certUsecase, sshUsecase, settingUsecase, taskUsecase, config, locale, slogLogger)
+	wsService := service.NewWsService(backupUsecase, certUsecase, sshUsecase, settingUsecase, taskUsecase, terminalRecordingUsecase, config, locale, slogLogger)
 	services := &route.Services{
 		Alert:                 alertService,
 		App:                   appService,
+		Audit:                 auditService,
 		Backup:                backupService,
 		BackupStorage:         backupStorageService,
 		Cert:                  certService,
 		CertAccount:           certAccountService,
 		CertDNS:               certDNSService,
+		CertDeploy:            certDeployService,
+		CertMonitor:           certMonitorService,
 		Container:             containerService,
 		ContainerCompose:      containerComposeService,
 		ContainerImage:        containerImageService,
@@ -290,6 +317,7 @@ func initAce() (*app.Ace, func(), error) {
 		DatabaseRedis:         databaseRedisService,
 		DatabaseServer:        databaseServerService,
 		DatabaseUser:          databaseUserService,
+		Deployment:            deploymentService,
 		Environment:           environmentService,
 		EnvironmentDotnet:     environmentDotnetService,
 		EnvironmentGo:         environmentGoService,
@@ -303,10 +331,13 @@ func initAce() (*app.Ace, func(), error) {
 		FirewallScan:          firewallScanService,
 		Home:                  homeService,
 		Log:                   logService,
+		Metrics:               metricsService,
 		Monitor:               monitorService,
 		Notify:                notifyService,
+		Probe:                 probeService,
 		Process:               processService,
 		Project:               projectService,
+		Role:                  roleService,
 		Safe:                  safeService,
 		Setting:               settingService,
 		SSH:                   sshService,
@@ -314,6 +345,7 @@ func initAce() (*app.Ace, func(), error) {
 		Tamper:                tamperService,
 		Task:                  taskService,
 		Template:              templateService,
+		TerminalRecording:     terminalRecordingService,
 		ToolboxBenchmark:      toolboxBenchmarkService,
 		ToolboxDisk:           toolboxDiskService,
 		ToolboxLog:            toolboxLogService,
@@ -337,17 +369,23 @@ func initAce() (*app.Ace, func(), error) {
 	}
 	dependencies := &job.Dependencies{
 		Alert:       alertUsecase,
+		Audit:       auditUsecase,
 		Backup:      backupUsecase,
 		Cache:       cacheUsecase,
 		Cert:        certUsecase,
 		CertAccount: certAccountUsecase,
+		CertMonitor: certMonitorUsecase,
+		Cron:        cronUsecase,
 		FileShare:   fileShareUsecase,
+		FirewallGeo: firewallGeoUsecase,
 		Monitor:     monitorUsecase,
 		Notify:      notifyUsecase,
+		Probe:       probeUsecase,
 		ScanEvent:   scanEventUsecase,
 		Setting:     settingUsecase,
 		Tamper:      tamperUsecase,
 		Task:        taskUsecase,
+		Terminal:    terminalRecordingUsecase,
 		Website:     websiteUsecase,
 		WebsiteStat: websiteStatUsecase,
 		Conf:        config,
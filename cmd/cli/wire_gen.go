@@ -31,7 +31,7 @@ func initCli() (*app.Cli, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	logger, cleanup, err := bootstrap.NewLogger(config)
+	logger, cleanup, err := bootstrap.NewLogger(config, db)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -44,20 +44,31 @@ func initCli() (*app.Cli, func(), error) {
 	taskRunner := bootstrap.NewRunner(notifyUsecase, db, locale, slogLogger)
 	taskRepo := data.NewTaskRepo(db, locale, slogLogger, taskRunner)
 	appUsecase := biz.NewAppUsecase(locale, appRepo, cacheRepo, taskRepo)
+	log := bootstrap.NewAudit(logger)
+	userRepo := data.NewUserRepo(db, locale)
+	auditUsecase := biz.NewAuditUsecase(locale, slogLogger, log, userRepo, settingRepo)
 	websiteRepo := data.NewWebsiteRepo(db, locale, settingRepo)
 	backupRepo := data.NewBackupRepo(config, db, locale, slogLogger, settingRepo, websiteRepo)
 	backupUsecase := biz.NewBackupUsecase(notifyUsecase, locale, slogLogger, backupRepo)
 	cacheUsecase := biz.NewCacheUsecase(cacheRepo)
 	certAccountRepo := data.NewCertAccountRepo(db, locale, slogLogger)
-	userRepo := data.NewUserRepo(db, locale)
 	certAccountUsecase := biz.NewCertAccountUsecase(locale, slogLogger, certAccountRepo, userRepo)
 	certRepo := data.NewCertRepo(db, locale, slogLogger)
-	certUsecase := biz.NewCertUsecase(locale, slogLogger, certRepo, settingRepo)
+	migrationRemoteRepo := data.NewMigrationRemoteRepo(locale)
+	certDeployRepo := data.NewCertDeployRepo(db, locale, settingRepo, migrationRemoteRepo)
+	certUsecase := biz.NewCertUsecase(locale, slogLogger, certRepo, certDeployRepo, settingRepo)
 	cronRepo := data.NewCronRepo(db, locale)
-	cronUsecase := biz.NewCronUsecase(cronRepo, slogLogger)
+	cronUsecase := biz.NewCronUsecase(locale, slogLogger, cronRepo, taskRepo, settingRepo)
 	databaseServerRepo := data.NewDatabaseServerRepo(db)
 	databaseServerUsecase := biz.NewDatabaseServerUsecase(locale, slogLogger, databaseServerRepo)
-	settingUsecase := biz.NewSettingUsecase(locale, slogLogger, settingRepo, taskRepo)
+	projectRepo := data.NewProjectRepo(db, locale, websiteRepo)
+	deploymentRepo := data.NewDeploymentRepo(db, locale, projectRepo, websiteRepo)
+	webHookRepo := data.NewWebHookRepo(db, locale)
+	environmentRepo := data.NewEnvironmentRepo(config, locale)
+	deploymentUsecase := biz.NewDeploymentUsecase(locale, slogLogger, deploymentRepo, taskRepo, webHookRepo, environmentRepo)
+	projectUsecase := biz.NewProjectUsecase(locale, slogLogger, projectRepo, taskRepo, websiteRepo)
+	firewallGeoRepo := data.NewFirewallGeoRepo(db, locale)
+	settingUsecase := biz.NewSettingUsecase(locale, slogLogger, settingRepo, taskRepo, firewallGeoRepo)
 	userPasskeyRepo := data.NewUserPasskeyRepo(db)
 	userPasskeyUsecase := biz.NewUserPasskeyUsecase(userPasskeyRepo)
 	userUsecase := biz.NewUserUsecase(locale, slogLogger, userRepo)
@@ -79,7 +90,7 @@ func initCli() (*app.Cli, func(), error) {
 	websiteStatUsecase := biz.NewWebsiteStatUsecase(websiteStatRepo)
 	websiteUsecase := biz.NewWebsiteUsecase(certAccountUsecase, certUsecase, databaseUsecase, databaseUserUsecase, tamperUsecase, websiteStatUsecase, locale, slogLogger, databaseServerRepo, websiteRepo)
 	validator := bootstrap.NewValidator(config, db)
-	cliService := service.NewCliService(appUsecase, backupUsecase, cacheUsecase, certAccountUsecase, certUsecase, cronUsecase, databaseServerUsecase, notifyUsecase, settingUsecase, userPasskeyUsecase, userUsecase, websiteUsecase, config, db, locale, validator)
+	cliService := service.NewCliService(appUsecase, auditUsecase, backupUsecase, cacheUsecase, certAccountUsecase, certUsecase, cronUsecase, databaseServerUsecase, deploymentUsecase, notifyUsecase, projectUsecase, settingUsecase, userPasskeyUsecase, userUsecase, websiteUsecase, config, db, locale, validator)
 	v := command.Commands(locale, cliService)
 	cliCommand := bootstrap.NewCli(locale, v)
 	gormigrate := bootstrap.NewMigrate(db)
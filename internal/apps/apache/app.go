@@ -1,6 +1,7 @@
 package apache
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -12,6 +13,8 @@ import (
 	"github.com/acepanel/panel/v3/internal/app"
 	"github.com/acepanel/panel/v3/internal/apps/confval"
 	"github.com/acepanel/panel/v3/internal/service"
+	"github.com/acepanel/panel/v3/pkg/configtest"
+	"github.com/acepanel/panel/v3/pkg/conftx"
 	"github.com/acepanel/panel/v3/pkg/io"
 	"github.com/acepanel/panel/v3/pkg/shell"
 	"github.com/acepanel/panel/v3/pkg/systemctl"
@@ -63,17 +66,14 @@ func (s *App) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = io.Write(app.Root+"/server/apache/conf/httpd.conf", req.Config, 0600); err != nil {
+	confPath := app.Root + "/server/apache/conf/httpd.conf"
+	if err = s.applyConfig([]string{confPath}, func() error {
+		return io.Write(confPath, req.Config, 0600)
+	}); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
-	if err = systemctl.Reload("apache"); err != nil {
-		_, err = shell.Execf("%s/server/apache/bin/apachectl configtest", app.Root)
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to reload apache: %v", err))
-		return
-	}
-
 	service.Success(w, nil)
 }
 
@@ -232,16 +232,33 @@ func (s *App) UpdateConfigTune(w http.ResponseWriter, r *http.Request) {
 	config = confval.Directive.Set(config, "MaxKeepAliveRequests", req.MaxKeepAliveRequests)
 	config = confval.Directive.Set(config, "KeepAliveTimeout", req.KeepAliveTimeout)
 
-	if err = io.Write(confPath, config, 0644); err != nil {
+	if err = s.applyConfig([]string{confPath}, func() error {
+		return io.Write(confPath, config, 0644)
+	}); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
-	if err = systemctl.Reload("apache"); err != nil {
-		out, _ := shell.Execf("%s/server/apache/bin/apachectl configtest", app.Root)
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to reload apache: %v %s", err, out))
-		return
+	service.Success(w, nil)
+}
+
+// applyConfig 修改配置后执行 apachectl configtest 并重载，任一步失败都将 paths 回滚到修改前
+func (s *App) applyConfig(paths []string, mutate func() error) error {
+	if err := conftx.Apply(paths, mutate, func() error {
+		if err := configtest.Apache(app.Root); err != nil {
+			return err
+		}
+		// 服务未运行时无需重载，配置会在下次启动时生效
+		if running, _ := systemctl.Status("apache"); !running {
+			return nil
+		}
+		if err := systemctl.Reload("apache"); err != nil {
+			return errors.New(s.t.Get("failed to reload apache: %v", err))
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("%s: %w", s.t.Get("configuration rolled back"), err)
 	}
 
-	service.Success(w, nil)
+	return nil
 }
@@ -656,6 +656,7 @@ func (s *App) RunMaintenance(w http.ResponseWriter, r *http.Request) {
 	task.Key = "mysql:maintenance"
 	task.Name = s.t.Get("Run %s on %d tables", req.Operation, len(req.Tables))
 	task.Status = biz.TaskStatusWaiting
+	task.Resource = "mysql"
 	task.Shell = cmd
 	if err = s.taskRepo.Push(task); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
@@ -1,6 +1,7 @@
 package nginx
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -14,6 +15,8 @@ import (
 	"github.com/acepanel/panel/v3/internal/app"
 	"github.com/acepanel/panel/v3/internal/apps/confval"
 	"github.com/acepanel/panel/v3/internal/service"
+	"github.com/acepanel/panel/v3/pkg/configtest"
+	"github.com/acepanel/panel/v3/pkg/conftx"
 	"github.com/acepanel/panel/v3/pkg/io"
 	"github.com/acepanel/panel/v3/pkg/shell"
 	"github.com/acepanel/panel/v3/pkg/systemctl"
@@ -73,17 +76,14 @@ func (s *App) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = io.Write(app.Root+"/server/nginx/conf/nginx.conf", req.Config, 0600); err != nil {
+	confPath := app.Root + "/server/nginx/conf/nginx.conf"
+	if err = s.applyConfig([]string{confPath}, func() error {
+		return io.Write(confPath, req.Config, 0600)
+	}); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
-	if err = systemctl.Reload("nginx"); err != nil {
-		_, err = shell.Execf("nginx -t")
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to reload nginx: %v", err))
-		return
-	}
-
 	service.Success(w, nil)
 }
 
@@ -262,16 +262,33 @@ func (s *App) UpdateConfigTune(w http.ResponseWriter, r *http.Request) {
 	config = confval.Nginx.Set(config, "zstd_types", req.ZstdTypes)
 	config = confval.Nginx.Set(config, "zstd_static", req.ZstdStatic)
 
-	if err = io.Write(confPath, config, 0600); err != nil {
+	if err = s.applyConfig([]string{confPath}, func() error {
+		return io.Write(confPath, config, 0600)
+	}); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
-	if err = systemctl.Reload("nginx"); err != nil {
-		_, err = shell.Execf("nginx -t")
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to reload nginx: %v", err))
-		return
+	service.Success(w, nil)
+}
+
+// applyConfig 修改配置后执行 nginx -t 并重载，任一步失败都将 paths 回滚到修改前
+func (s *App) applyConfig(paths []string, mutate func() error) error {
+	if err := conftx.Apply(paths, mutate, func() error {
+		if err := configtest.Nginx(); err != nil {
+			return err
+		}
+		// 服务未运行时无需重载，配置会在下次启动时生效
+		if running, _ := systemctl.Status("nginx"); !running {
+			return nil
+		}
+		if err := systemctl.Reload("nginx"); err != nil {
+			return errors.New(s.t.Get("failed to reload nginx: %v", err))
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("%s: %w", s.t.Get("configuration rolled back"), err)
 	}
 
-	service.Success(w, nil)
+	return nil
 }
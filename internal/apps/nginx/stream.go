@@ -15,7 +15,6 @@ import (
 
 	"github.com/acepanel/panel/v3/internal/app"
 	"github.com/acepanel/panel/v3/internal/service"
-	"github.com/acepanel/panel/v3/pkg/systemctl"
 	webserverNginx "github.com/acepanel/panel/v3/pkg/webserver/nginx"
 )
 
@@ -43,14 +42,13 @@ func (s *App) CreateStreamServer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = s.saveStreamServerConfig(configPath, req); err != nil {
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to write stream server config: %v", err))
-		return
-	}
-
-	if err = systemctl.Reload("nginx"); err != nil {
-		_ = os.Remove(configPath)
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to reload nginx: %v", err))
+	if err = s.applyConfig([]string{configPath}, func() error {
+		if err := s.saveStreamServerConfig(configPath, req); err != nil {
+			return errors.New(s.t.Get("failed to write stream server config: %v", err))
+		}
+		return nil
+	}); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
@@ -86,17 +84,16 @@ func (s *App) UpdateStreamServer(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err = s.saveStreamServerConfig(newConfigPath, req); err != nil {
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to write stream server config: %v", err))
-		return
-	}
-
-	if newConfigPath != configPath {
-		_ = os.Remove(configPath)
-	}
-
-	if err = systemctl.Reload("nginx"); err != nil {
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to reload nginx: %v", err))
+	if err = s.applyConfig([]string{configPath, newConfigPath}, func() error {
+		if err := s.saveStreamServerConfig(newConfigPath, req); err != nil {
+			return errors.New(s.t.Get("failed to write stream server config: %v", err))
+		}
+		if newConfigPath != configPath {
+			return os.Remove(configPath)
+		}
+		return nil
+	}); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
@@ -117,13 +114,13 @@ func (s *App) DeleteStreamServer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := os.Remove(configPath); err != nil {
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to delete stream server config: %v", err))
-		return
-	}
-
-	if err := systemctl.Reload("nginx"); err != nil {
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to reload nginx: %v", err))
+	if err := s.applyConfig([]string{configPath}, func() error {
+		if err := os.Remove(configPath); err != nil {
+			return errors.New(s.t.Get("failed to delete stream server config: %v", err))
+		}
+		return nil
+	}); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
@@ -154,14 +151,13 @@ func (s *App) CreateStreamUpstream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = s.saveStreamUpstreamConfig(configPath, req); err != nil {
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to write stream upstream config: %v", err))
-		return
-	}
-
-	if err = systemctl.Reload("nginx"); err != nil {
-		_ = os.Remove(configPath)
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to reload nginx: %v", err))
+	if err = s.applyConfig([]string{configPath}, func() error {
+		if err := s.saveStreamUpstreamConfig(configPath, req); err != nil {
+			return errors.New(s.t.Get("failed to write stream upstream config: %v", err))
+		}
+		return nil
+	}); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
@@ -197,17 +193,16 @@ func (s *App) UpdateStreamUpstream(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err = s.saveStreamUpstreamConfig(newConfigPath, req); err != nil {
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to write stream upstream config: %v", err))
-		return
-	}
-
-	if newConfigPath != configPath {
-		_ = os.Remove(configPath)
-	}
-
-	if err = systemctl.Reload("nginx"); err != nil {
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to reload nginx: %v", err))
+	if err = s.applyConfig([]string{configPath, newConfigPath}, func() error {
+		if err := s.saveStreamUpstreamConfig(newConfigPath, req); err != nil {
+			return errors.New(s.t.Get("failed to write stream upstream config: %v", err))
+		}
+		if newConfigPath != configPath {
+			return os.Remove(configPath)
+		}
+		return nil
+	}); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
@@ -228,13 +223,13 @@ func (s *App) DeleteStreamUpstream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := os.Remove(configPath); err != nil {
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to delete stream upstream config: %v", err))
-		return
-	}
-
-	if err := systemctl.Reload("nginx"); err != nil {
-		service.Error(w, http.StatusInternalServerError, s.t.Get("failed to reload nginx: %v", err))
+	if err := s.applyConfig([]string{configPath}, func() error {
+		if err := os.Remove(configPath); err != nil {
+			return errors.New(s.t.Get("failed to delete stream upstream config: %v", err))
+		}
+		return nil
+	}); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
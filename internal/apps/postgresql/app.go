@@ -393,6 +393,7 @@ func (s *App) InstallExtension(w http.ResponseWriter, r *http.Request) {
 	task.Key = "postgresql:extension:" + req.Slug
 	task.Name = s.t.Get("Install PostgreSQL extension %s", req.Slug)
 	task.Status = biz.TaskStatusWaiting
+	task.Resource = biz.TaskResourcePkg
 	task.Shell = cmd
 	if err = s.taskRepo.Push(task); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
@@ -421,6 +422,7 @@ func (s *App) UninstallExtension(w http.ResponseWriter, r *http.Request) {
 	task.Key = "postgresql:extension:" + req.Slug
 	task.Name = s.t.Get("Uninstall PostgreSQL extension %s", req.Slug)
 	task.Status = biz.TaskStatusWaiting
+	task.Resource = biz.TaskResourcePkg
 	task.Shell = cmd
 	if err = s.taskRepo.Push(task); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
@@ -754,6 +756,7 @@ func (s *App) RunMaintenance(w http.ResponseWriter, r *http.Request) {
 	task.Key = "postgresql:maintenance:" + req.Database
 	task.Name = s.t.Get("Run %s on %d tables in database %s", req.Operation, len(req.Tables), req.Database)
 	task.Status = biz.TaskStatusWaiting
+	task.Resource = "postgresql:" + req.Database
 	task.Shell = cmd
 	if err = s.taskRepo.Push(task); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
@@ -1,7 +1,9 @@
 package prometheus
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/leonelquinteros/gotext"
+	"github.com/libtnb/utils/str"
 	"github.com/samber/lo"
 	"github.com/spf13/cast"
 	"go.yaml.in/yaml/v4"
@@ -24,15 +27,25 @@ import (
 	"github.com/acepanel/panel/v3/pkg/types"
 )
 
+const (
+	// panelScrapeJob 面板指标在 Prometheus 中的抓取任务名
+	panelScrapeJob = "acepanel"
+	// panelTokenFile 面板指标令牌文件名，位于 Prometheus 目录下
+	panelTokenFile = "acepanel_token"
+	// panelMetricsPath 面板指标接口路径，不经过安全入口，抓取配置中不会出现入口
+	panelMetricsPath = "/metrics"
+)
+
 type App struct {
-	t        *gotext.Locale
-	conf     *config.Config
-	taskRepo biz.TaskRepo
+	t           *gotext.Locale
+	conf        *config.Config
+	settingRepo biz.SettingRepo
+	taskRepo    biz.TaskRepo
 }
 
-func NewApp(conf *config.Config, t *gotext.Locale, taskRepo biz.TaskRepo) *App {
+func NewApp(conf *config.Config, t *gotext.Locale, settingRepo biz.SettingRepo, taskRepo biz.TaskRepo) *App {
 
-	return &App{t: t, conf: conf, taskRepo: taskRepo}
+	return &App{t: t, conf: conf, settingRepo: settingRepo, taskRepo: taskRepo}
 }
 
 func (s *App) Route(r chi.Router) {
@@ -41,6 +54,9 @@ func (s *App) Route(r chi.Router) {
 	r.Post("/config", s.UpdateConfig)
 	r.Get("/config_tune", s.GetConfigTune)
 	r.Post("/config_tune", s.UpdateConfigTune)
+	// 面板指标
+	r.Get("/panel_metrics", s.GetPanelMetrics)
+	r.Post("/panel_metrics", s.UpdatePanelMetrics)
 	// Alertmanager 配置
 	r.Get("/alertmanager_config", s.GetAlertmanagerConfig)
 	r.Post("/alertmanager_config", s.UpdateAlertmanagerConfig)
@@ -187,6 +203,173 @@ func (s *App) UpdateConfigTune(w http.ResponseWriter, r *http.Request) {
 	service.Success(w, nil)
 }
 
+// GetPanelMetrics 获取面板指标接口状态
+func (s *App) GetPanelMetrics(w http.ResponseWriter, r *http.Request) {
+	token, _ := s.settingRepo.Get(biz.SettingKeyMetricsToken)
+
+	service.Success(w, PanelMetrics{
+		Enabled: token != "",
+		Token:   token,
+		Path:    panelMetricsPath,
+	})
+}
+
+// UpdatePanelMetrics 启用或停用面板指标接口，并同步 Prometheus 抓取目标
+func (s *App) UpdatePanelMetrics(w http.ResponseWriter, r *http.Request) {
+	req, err := service.Bind[PanelMetricsUpdate](r)
+	if err != nil {
+		service.Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	token := ""
+	if req.Enabled {
+		token, _ = s.settingRepo.Get(biz.SettingKeyMetricsToken)
+		if token == "" || req.Reset {
+			token = str.Random(32)
+		}
+	}
+
+	// 令牌单独写入仅 prometheus 用户可读的文件，避免出现在可被其他用户读取的主配置中
+	tokenPath := app.Root + "/server/prometheus/" + panelTokenFile
+	if req.Enabled {
+		if err = io.Write(tokenPath, token, 0600); err != nil {
+			service.Error(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		if err = io.Chown(tokenPath, "prometheus", "prometheus"); err != nil {
+			service.Error(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+	}
+
+	var job *panelScrapeConfig
+	if req.Enabled {
+		job = &panelScrapeConfig{
+			JobName:       panelScrapeJob,
+			MetricsPath:   panelMetricsPath,
+			Scheme:        lo.Ternary(s.conf.HTTP.IsHTTPS(), "https", "http"),
+			Authorization: panelScrapeAuthorization{CredentialsFile: tokenPath},
+			StaticConfigs: []panelScrapeTargets{{Targets: []string{fmt.Sprintf("127.0.0.1:%d", s.conf.HTTP.Port)}}},
+		}
+		// 面板证书通常为自签或签发给域名，本机抓取时跳过校验
+		if s.conf.HTTP.IsHTTPS() {
+			job.TLSConfig = &panelScrapeTLS{InsecureSkipVerify: true}
+		}
+	}
+
+	confPath := app.Root + "/server/prometheus/prometheus.yml"
+	raw, _ := io.Read(confPath)
+	data, err := setScrapeJob([]byte(raw), job)
+	if err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if err = io.Write(confPath, string(data), 0644); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if !req.Enabled {
+		_ = io.Remove(tokenPath)
+	}
+	if err = s.settingRepo.Set(biz.SettingKeyMetricsToken, token); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	if err = systemctl.Restart("prometheus"); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	service.Success(w, nil)
+}
+
+// panelScrapeConfig 面板指标抓取任务，字段顺序即写入配置文件的顺序
+type panelScrapeConfig struct {
+	JobName       string                   `yaml:"job_name"`
+	MetricsPath   string                   `yaml:"metrics_path"`
+	Scheme        string                   `yaml:"scheme"`
+	Authorization panelScrapeAuthorization `yaml:"authorization"`
+	TLSConfig     *panelScrapeTLS          `yaml:"tls_config,omitempty"`
+	StaticConfigs []panelScrapeTargets     `yaml:"static_configs"`
+}
+
+type panelScrapeAuthorization struct {
+	CredentialsFile string `yaml:"credentials_file"`
+}
+
+type panelScrapeTLS struct {
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+}
+
+type panelScrapeTargets struct {
+	Targets []string `yaml:"targets"`
+}
+
+// setScrapeJob 替换 scrape_configs 中的面板抓取任务，job 为 nil 时仅移除
+// 只修改 scrape_configs 节点，保留配置文件其余部分的注释和键顺序
+func setScrapeJob(raw []byte, job *panelScrapeConfig) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("prometheus.yml is not a mapping")
+	}
+
+	var jobs *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "scrape_configs" {
+			jobs = root.Content[i+1]
+			break
+		}
+	}
+	if jobs == nil {
+		jobs = &yaml.Node{Kind: yaml.SequenceNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "scrape_configs"}, jobs)
+	}
+	if jobs.Kind != yaml.SequenceNode {
+		// 空的 scrape_configs 解析为 null 标量
+		*jobs = yaml.Node{Kind: yaml.SequenceNode}
+	}
+
+	// 先移除旧的面板抓取任务，启用时按当前端口、入口和令牌文件重新生成
+	jobs.Content = lo.Reject(jobs.Content, func(node *yaml.Node, _ int) bool {
+		if node.Kind != yaml.MappingNode {
+			return false
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "job_name" {
+				return node.Content[i+1].Value == panelScrapeJob
+			}
+		}
+		return false
+	})
+	if job != nil {
+		var node yaml.Node
+		if err := node.Encode(job); err != nil {
+			return nil, err
+		}
+		jobs.Content = append(jobs.Content, &node)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // GetAlertmanagerConfig 获取 Alertmanager 配置
 func (s *App) GetAlertmanagerConfig(w http.ResponseWriter, r *http.Request) {
 	conf, _ := io.Read(app.Root + "/server/prometheus/alertmanager/alertmanager.yml")
@@ -247,6 +430,7 @@ func (s *App) InstallExporter(w http.ResponseWriter, r *http.Request) {
 	task.Key = "prometheus:exporter:" + req.Slug
 	task.Name = s.t.Get("Install Prometheus exporter %s", req.Slug)
 	task.Status = biz.TaskStatusWaiting
+	task.Resource = biz.TaskResourcePkg
 	task.Shell = cmd
 	if err = s.taskRepo.Push(task); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
@@ -275,6 +459,7 @@ func (s *App) UninstallExporter(w http.ResponseWriter, r *http.Request) {
 	task.Key = "prometheus:exporter:" + req.Slug
 	task.Name = s.t.Get("Uninstall Prometheus exporter %s", req.Slug)
 	task.Status = biz.TaskStatusWaiting
+	task.Resource = biz.TaskResourcePkg
 	task.Shell = cmd
 	if err = s.taskRepo.Push(task); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
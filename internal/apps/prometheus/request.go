@@ -30,3 +30,16 @@ type ExporterSlug struct {
 type ExporterConfig struct {
 	Config string `form:"config" json:"config" validate:"required"`
 }
+
+// PanelMetrics 面板指标接口状态
+type PanelMetrics struct {
+	Enabled bool   `json:"enabled"`
+	Token   string `json:"token"`
+	Path    string `json:"path"`
+}
+
+// PanelMetricsUpdate 面板指标接口开关
+type PanelMetricsUpdate struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+	Reset   bool `form:"reset" json:"reset"` // 重新生成令牌
+}
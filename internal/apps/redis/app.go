@@ -427,6 +427,7 @@ func (s *App) ScanBigKeys(w http.ResponseWriter, r *http.Request) {
 	task.Key = s.slug + ":bigkeys"
 	task.Name = s.t.Get("Scan %s big keys", s.name)
 	task.Status = biz.TaskStatusWaiting
+	task.Resource = s.slug
 	task.Shell = fmt.Sprintf("%s-cli%s --bigkeys", s.slug, withPassword)
 	if err = s.taskRepo.Push(task); err != nil {
 		service.Error(w, http.StatusInternalServerError, "%v", err)
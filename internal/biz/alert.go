@@ -22,6 +22,7 @@ import (
 	"github.com/acepanel/panel/v3/internal/app"
 	"github.com/acepanel/panel/v3/internal/request"
 	"github.com/acepanel/panel/v3/pkg/apploader"
+	pkgcert "github.com/acepanel/panel/v3/pkg/cert"
 	"github.com/acepanel/panel/v3/pkg/shell"
 	"github.com/acepanel/panel/v3/pkg/sshlog"
 	"github.com/acepanel/panel/v3/pkg/systemctl"
@@ -52,6 +53,11 @@ const (
 	AlertTypeDatabase      = "database"       // 数据库服务器不可达，目标为服务器名
 	AlertTypeCertExpire    = "cert_expire"    // 证书剩余天数，目标为域名
 	AlertTypeWebsiteExpire = "website_expire" // 网站剩余天数，目标为网站名
+	// 外部证书监控，目标为监控名
+	AlertTypeCertMonitor      = "cert_monitor"       // 外部证书剩余天数
+	AlertTypeCertMonitorError = "cert_monitor_error" // 外部证书异常：无法连接、链无效、域名不匹配或已吊销
+	AlertTypeProbe            = "probe"              // 可用性探测失败，目标为探测名
+	AlertTypeProbeLatency     = "probe_latency"      // 可用性探测响应时间 ms，目标为探测名
 )
 
 const (
@@ -112,6 +118,7 @@ type Alert struct {
 type AlertMetric struct {
 	Target string
 	Value  float64
+	Detail string // 附加说明，写入告警消息
 }
 
 type AlertRepo interface {
@@ -130,6 +137,10 @@ type AlertRepo interface {
 	ProjectNames() ([]string, error)
 	DatabaseServers() ([]*DatabaseServer, error)
 	WebsiteHourStats() ([]*WebsiteHourStat, error)
+	// CertMonitors 已检查过的启用外部证书监控
+	CertMonitors() ([]*CertMonitor, error)
+	// Probes 已探测过的启用可用性探测
+	Probes() ([]*Probe, error)
 }
 
 // WebsiteHourStat 网站当前小时的请求统计
@@ -278,6 +289,11 @@ func (uc *AlertUsecase) ListAlerts(page, limit uint) ([]*Alert, int64, error) {
 	return uc.repo.ListAlerts(page, limit)
 }
 
+// CertExpiry 各证书剩余有效天数
+func (uc *AlertUsecase) CertExpiry() ([]*AlertMetric, error) {
+	return uc.repo.CertExpiry()
+}
+
 func (uc *AlertUsecase) ClearAlerts() error {
 	return uc.repo.ClearAlerts()
 }
@@ -533,6 +549,42 @@ func (uc *AlertUsecase) collect(ctx context.Context, rule *AlertRule, info types
 
 	case AlertTypeWebsiteExpire:
 		return uc.repo.WebsiteExpiry()
+
+	case AlertTypeCertMonitor, AlertTypeCertMonitorError:
+		monitors, err := uc.repo.CertMonitors()
+		if err != nil {
+			return nil, err
+		}
+		metrics := make([]*AlertMetric, 0, len(monitors))
+		for _, item := range monitors {
+			if rule.Type == AlertTypeCertMonitor {
+				// 连接失败时没有证书信息，由异常规则负责
+				if item.NotAfter != nil {
+					metrics = append(metrics, &AlertMetric{Target: item.Name, Value: item.DaysLeft()})
+				}
+				continue
+			}
+			problem := uc.certMonitorProblem(item)
+			metrics = append(metrics, &AlertMetric{Target: item.Name, Value: uc.statusValue(problem == ""), Detail: problem})
+		}
+		return metrics, nil
+	case AlertTypeProbe, AlertTypeProbeLatency:
+		probes, err := uc.repo.Probes()
+		if err != nil {
+			return nil, err
+		}
+		metrics := make([]*AlertMetric, 0, len(probes))
+		for _, item := range probes {
+			if rule.Type == AlertTypeProbe {
+				metrics = append(metrics, &AlertMetric{Target: item.Name, Value: uc.statusValue(item.Status == ProbeStatusUp), Detail: item.Message})
+				continue
+			}
+			// 失败时的耗时是超时或出错前的时间，由探测失败规则负责
+			if item.Status == ProbeStatusUp {
+				metrics = append(metrics, &AlertMetric{Target: item.Name, Value: item.Latency})
+			}
+		}
+		return metrics, nil
 	}
 
 	return nil, fmt.Errorf("unsupported alert type: %s", rule.Type)
@@ -864,6 +916,12 @@ func (uc *AlertUsecase) buildMessage(rule *AlertRule, metric *AlertMetric) strin
 		return uc.t.Get("certificate %s expires in %s days", metric.Target, uc.formatValue(rule.Type, metric.Value))
 	case AlertTypeWebsiteExpire:
 		return uc.t.Get("website %s expires in %s days", metric.Target, uc.formatValue(rule.Type, metric.Value))
+	case AlertTypeCertMonitor:
+		return uc.t.Get("certificate of %s expires in %s days", metric.Target, uc.formatValue(rule.Type, metric.Value))
+	case AlertTypeCertMonitorError:
+		return uc.t.Get("certificate of %s is abnormal: %s", metric.Target, metric.Detail)
+	case AlertTypeProbe:
+		return uc.t.Get("probe %s is down: %s", metric.Target, metric.Detail)
 	}
 
 	return uc.t.Get("%s is %s, %s threshold %s", uc.metricLabel(rule.Type, metric.Target), uc.formatValue(rule.Type, metric.Value), uc.operatorLabel(rule.Operator), uc.formatValue(rule.Type, rule.Threshold))
@@ -914,6 +972,14 @@ func (uc *AlertUsecase) metricLabel(typ, target string) string {
 		label = uc.t.Get("certificate expiry")
 	case AlertTypeWebsiteExpire:
 		label = uc.t.Get("website expiry")
+	case AlertTypeCertMonitor:
+		label = uc.t.Get("external certificate expiry")
+	case AlertTypeCertMonitorError:
+		label = uc.t.Get("external certificate status")
+	case AlertTypeProbe:
+		label = uc.t.Get("probe status")
+	case AlertTypeProbeLatency:
+		label = uc.t.Get("probe response time")
 	default:
 		label = typ
 	}
@@ -944,8 +1010,14 @@ func (uc *AlertUsecase) formatValue(typ string, value float64) string {
 		return fmt.Sprintf("%.2f%%", value)
 	case AlertTypeNetIn, AlertTypeNetOut, AlertTypeDiskRead, AlertTypeDiskWrite:
 		return fmt.Sprintf("%.2f MB/s", value)
-	case AlertTypeCertExpire, AlertTypeWebsiteExpire, AlertTypeWebsite5xx:
+	case AlertTypeCertExpire, AlertTypeWebsiteExpire, AlertTypeWebsite5xx, AlertTypeCertMonitor:
 		return fmt.Sprintf("%.0f", value)
+	case AlertTypeProbeLatency:
+		return fmt.Sprintf("%.0f ms", value)
+	case AlertTypeCertMonitorError:
+		return uc.t.Get("abnormal")
+	case AlertTypeProbe:
+		return uc.t.Get("down")
 	}
 
 	if uc.isStatusType(typ) {
@@ -971,7 +1043,7 @@ func (uc *AlertUsecase) needsSystemInfo(typ string) bool {
 // isStatusType 状态类指标只有「运行/未运行」两种取值
 func (uc *AlertUsecase) isStatusType(typ string) bool {
 	switch typ {
-	case AlertTypeService, AlertTypeProject, AlertTypeContainer, AlertTypeApp, AlertTypeDatabase:
+	case AlertTypeService, AlertTypeProject, AlertTypeContainer, AlertTypeApp, AlertTypeDatabase, AlertTypeCertMonitorError, AlertTypeProbe:
 		return true
 	}
 
@@ -1011,6 +1083,29 @@ func (uc *AlertUsecase) matchThreshold(rule *AlertRule, value float64) bool {
 	}
 }
 
+// certMonitorProblem 描述外部证书的异常，正常时返回空
+func (uc *AlertUsecase) certMonitorProblem(monitor *CertMonitor) string {
+	if monitor.Status == CertMonitorStatusError {
+		return uc.t.Get("connection failed: %s", monitor.Error)
+	}
+
+	problems := make([]string, 0)
+	if monitor.NotAfter != nil && time.Now().After(*monitor.NotAfter) {
+		problems = append(problems, uc.t.Get("certificate has expired"))
+	}
+	if !monitor.ChainValid {
+		problems = append(problems, uc.t.Get("certificate chain is not trusted"))
+	}
+	if monitor.HostnameMismatch {
+		problems = append(problems, uc.t.Get("certificate does not match %s", monitor.ServerName()))
+	}
+	if monitor.OCSPStatus == pkgcert.OCSPRevoked {
+		problems = append(problems, uc.t.Get("certificate has been revoked"))
+	}
+
+	return strings.Join(problems, "; ")
+}
+
 func (uc *AlertUsecase) stateKey(ruleID uint, target string) string {
 	return fmt.Sprintf("%d:%s", ruleID, target)
 }
@@ -218,6 +218,7 @@ func (uc *AppUsecase) Install(channel, slug string) error {
 	task.Key = "app:" + slug
 	task.Name = uc.t.Get("Install app %s", item.Name)
 	task.Status = TaskStatusWaiting
+	task.Resource = TaskResourcePkg
 	task.Shell = script
 
 	return uc.task.Push(task)
@@ -259,6 +260,7 @@ func (uc *AppUsecase) UnInstall(slug string) error {
 	task.Key = "app:" + slug
 	task.Name = uc.t.Get("Uninstall app %s", item.Name)
 	task.Status = TaskStatusWaiting
+	task.Resource = TaskResourcePkg
 	task.Shell = script
 
 	return uc.task.Push(task)
@@ -302,6 +304,7 @@ func (uc *AppUsecase) Update(slug string) error {
 	task.Key = "app:" + slug
 	task.Name = uc.t.Get("Update app %s", item.Name)
 	task.Status = TaskStatusWaiting
+	task.Resource = TaskResourcePkg
 	task.Shell = script
 
 	return uc.task.Push(task)
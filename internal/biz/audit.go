@@ -0,0 +1,213 @@
+package biz
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/v3/pkg/audit"
+)
+
+const (
+	AuditExportCSV  = "csv"
+	AuditExportJSON = "json"
+)
+
+// AuditSetting 审计日志设置
+type AuditSetting struct {
+	Months uint `json:"months"` // 保留月数（含当月），0 为永久保留
+}
+
+// AuditUsecase 哈希链审计日志的校验、导出与转发
+// 审计记录由 slog 处理器在写操作日志时同步落盘，这里只负责读取与配置
+type AuditUsecase struct {
+	audit   *audit.Log
+	user    UserRepo
+	setting SettingRepo
+	t       *gotext.Locale
+	log     *slog.Logger
+}
+
+func NewAuditUsecase(t *gotext.Locale, log *slog.Logger, auditLog *audit.Log, user UserRepo, setting SettingRepo) *AuditUsecase {
+	return &AuditUsecase{
+		audit:   auditLog,
+		user:    user,
+		setting: setting,
+		t:       t,
+		log:     log,
+	}
+}
+
+// Verify 校验整条哈希链
+func (uc *AuditUsecase) Verify() (*audit.VerifyResult, error) {
+	result, err := uc.audit.Verify()
+	if err != nil {
+		return nil, errors.New(uc.t.Get("failed to verify audit log: %v", err))
+	}
+	switch result.Reason {
+	case audit.ReasonMalformed:
+		result.Reason = uc.t.Get("malformed entry")
+	case audit.ReasonHashMismatch:
+		result.Reason = uc.t.Get("entry hash mismatch")
+	case audit.ReasonPrevMismatch:
+		result.Reason = uc.t.Get("previous hash mismatch")
+	case audit.ReasonSeqMismatch:
+		result.Reason = uc.t.Get("sequence gap")
+	case audit.ReasonTruncated:
+		result.Reason = uc.t.Get("entries missing after the recorded head")
+	case audit.ReasonStartMissing:
+		result.Reason = uc.t.Get("entries missing before the recorded start")
+	}
+
+	return result, nil
+}
+
+// Export 按条件导出审计记录
+// json 为 JSON Lines，逐行保留落盘原文，接收方可独立校验哈希；csv 附带操作人用户名便于查阅
+func (uc *AuditUsecase) Export(w io.Writer, format string, filter audit.Filter) error {
+	if format == AuditExportJSON {
+		return uc.audit.Read(filter, func(_ *audit.Entry, raw []byte) error {
+			_, err := w.Write(append(raw, '\n'))
+			return err
+		})
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"seq", "time", "level", "type", "operator_id", "operator", "msg", "attrs", "hash"}); err != nil {
+		return err
+	}
+
+	names := make(map[uint64]string)
+	if err := uc.audit.Read(filter, func(e *audit.Entry, _ []byte) error {
+		name, ok := names[e.OperatorID]
+		if !ok {
+			if user, err := uc.user.Get(uint(e.OperatorID)); err == nil {
+				name = user.Username
+			}
+			names[e.OperatorID] = name
+		}
+
+		var attrs string
+		if len(e.Attrs) > 0 {
+			encoded, _ := json.Marshal(e.Attrs)
+			attrs = string(encoded)
+		}
+
+		return cw.Write([]string{
+			strconv.FormatUint(e.Seq, 10),
+			e.Time.Format(time.RFC3339),
+			e.Level,
+			e.Type,
+			strconv.FormatUint(e.OperatorID, 10),
+			name,
+			e.Msg,
+			attrs,
+			e.Hash,
+		})
+	}); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// GetSetting 获取审计日志设置
+func (uc *AuditUsecase) GetSetting() (*AuditSetting, error) {
+	months, _ := uc.setting.GetInt(SettingKeyAuditMonths, 0)
+	return &AuditSetting{
+		Months: uint(months),
+	}, nil
+}
+
+// SaveSetting 保存审计日志设置
+func (uc *AuditUsecase) SaveSetting(ctx context.Context, s *AuditSetting) error {
+	if err := uc.setting.Set(SettingKeyAuditMonths, cast.ToString(s.Months)); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("audit log setting updated", slog.String("type", OperationTypeSetting), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("months", uint64(s.Months)))
+
+	return nil
+}
+
+// ClearExpired 清理超过保留月数的审计文件，链首随之前移，只能经由此处推进
+func (uc *AuditUsecase) ClearExpired() (int, error) {
+	months, _ := uc.setting.GetInt(SettingKeyAuditMonths, 0)
+	if months <= 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	return uc.audit.Prune(time.Date(now.Year(), now.Month()-time.Month(months-1), 1, 0, 0, 0, 0, time.Local))
+}
+
+// GetForward 获取转发配置
+func (uc *AuditUsecase) GetForward() (*audit.ForwardConfig, error) {
+	conf := new(audit.ForwardConfig)
+	raw, err := uc.setting.Get(SettingKeyAuditForward)
+	if err != nil {
+		return nil, err
+	}
+	if raw != "" {
+		if err = json.Unmarshal([]byte(raw), conf); err != nil {
+			return nil, err
+		}
+	}
+
+	return conf, nil
+}
+
+// SaveForward 保存转发配置并立即生效
+func (uc *AuditUsecase) SaveForward(ctx context.Context, conf *audit.ForwardConfig) error {
+	forwarder, err := audit.NewForwarder(*conf)
+	if err != nil {
+		return errors.New(uc.t.Get("invalid audit forward config: %v", err))
+	}
+
+	encoded, err := json.Marshal(conf)
+	if err != nil {
+		return err
+	}
+	if err = uc.setting.Set(SettingKeyAuditForward, string(encoded)); err != nil {
+		if forwarder != nil {
+			_ = forwarder.Close()
+		}
+		return err
+	}
+	uc.audit.SetForwarder(forwarder)
+
+	// 记录日志
+	uc.log.Info("audit log forward updated", slog.String("type", OperationTypeSetting), slog.Uint64("operator_id", operatorID(ctx)), slog.String("forward_type", conf.Type), slog.String("network", conf.Network), slog.String("address", conf.Address), slog.String("url", conf.URL))
+
+	return nil
+}
+
+// ApplyForward 按已保存的配置启用转发，面板启动时调用
+func (uc *AuditUsecase) ApplyForward() error {
+	conf, err := uc.GetForward()
+	if err != nil {
+		return err
+	}
+	forwarder, err := audit.NewForwarder(*conf)
+	if err != nil {
+		return err
+	}
+
+	uc.audit.SetForwarder(forwarder)
+	return nil
+}
+
+// ForwardStatus 获取本次运行以来的转发统计
+func (uc *AuditUsecase) ForwardStatus() audit.ForwardStatus {
+	return uc.audit.ForwardStatus()
+}
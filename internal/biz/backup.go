@@ -13,23 +13,32 @@ import (
 type BackupType string
 
 const (
-	BackupTypePath       BackupType = "path"
-	BackupTypeWebsite    BackupType = "website"
-	BackupTypeMySQL      BackupType = "mysql"
-	BackupTypePostgres   BackupType = "postgresql"
-	BackupTypeClickHouse BackupType = "clickhouse"
-	BackupTypeRedis      BackupType = "redis"
-	BackupTypeValkey     BackupType = "valkey"
-	BackupTypePanel      BackupType = "panel"
+	BackupTypePath          BackupType = "path"
+	BackupTypeWebsite       BackupType = "website"
+	BackupTypeMySQL         BackupType = "mysql"
+	BackupTypePostgres      BackupType = "postgresql"
+	BackupTypeClickHouse    BackupType = "clickhouse"
+	BackupTypeRedis         BackupType = "redis"
+	BackupTypeValkey        BackupType = "valkey"
+	BackupTypeMongoDB       BackupType = "mongodb"
+	BackupTypeElasticsearch BackupType = "elasticsearch"
+	BackupTypePanel         BackupType = "panel"
 )
 
 type BackupRepo interface {
 	List(typ BackupType) ([]*types.BackupFile, error)
+	ListSnapshots(storage uint, typ BackupType) ([]*types.BackupFile, error)
+	ListStorage(storage uint, typ BackupType) ([]*types.BackupFile, error)
+	CheckArchive(storage uint, typ BackupType, name string) error
 	GetStorage(id uint) (*BackupStorage, error)
-	Create(ctx context.Context, typ BackupType, target string, account uint) error
+	Create(ctx context.Context, typ BackupType, target string, account uint, dedup bool) error
 	CreatePanel() error
 	Delete(typ BackupType, name string) error
 	Restore(typ BackupType, backup, target string) error
+	RestoreSnapshot(storage uint, typ BackupType, name, target string) error
+	Decrypt(typ BackupType, backup, identity string) (string, error)
+	Verify(storage uint, typ BackupType, name, identity string) (*types.BackupVerify, error)
+	TestRestore(storage uint, typ BackupType, target string) (string, error)
 	ClearExpired(path, prefix string, save uint) error
 	ClearStorageExpired(account uint, dir, prefix string, save uint) error
 	CutoffLog(path, target string) (string, error)
@@ -59,8 +68,22 @@ func (uc *BackupUsecase) List(typ BackupType) ([]*types.BackupFile, error) {
 	return uc.repo.List(typ)
 }
 
-func (uc *BackupUsecase) Create(ctx context.Context, typ BackupType, target string, account uint) error {
-	err := uc.repo.Create(ctx, typ, target, account)
+func (uc *BackupUsecase) ListSnapshots(storage uint, typ BackupType) ([]*types.BackupFile, error) {
+	return uc.repo.ListSnapshots(storage, typ)
+}
+
+func (uc *BackupUsecase) ListStorage(storage uint, typ BackupType) ([]*types.BackupFile, error) {
+	return uc.repo.ListStorage(storage, typ)
+}
+
+// CheckArchive 检查备份存储中存在可校验的备份压缩包
+func (uc *BackupUsecase) CheckArchive(storage uint, typ BackupType, name string) error {
+	return uc.repo.CheckArchive(storage, typ, name)
+}
+
+// Create 创建备份，dedup 为 true 时以去重快照方式备份，仅支持网站和目录
+func (uc *BackupUsecase) Create(ctx context.Context, typ BackupType, target string, account uint, dedup bool) error {
+	err := uc.repo.Create(ctx, typ, target, account, dedup)
 	if err == nil {
 		return nil
 	}
@@ -109,6 +132,58 @@ func (uc *BackupUsecase) Restore(ctx context.Context, typ BackupType, backup, ta
 	return nil
 }
 
+// Decrypt 解密备份到临时目录，返回解密后的文件路径
+func (uc *BackupUsecase) Decrypt(typ BackupType, backup, identity string) (string, error) {
+	return uc.repo.Decrypt(typ, backup, identity)
+}
+
+// RestoreSnapshot 从备份存储中的去重快照恢复
+func (uc *BackupUsecase) RestoreSnapshot(ctx context.Context, storage uint, typ BackupType, name, target string) error {
+	if err := uc.repo.RestoreSnapshot(storage, typ, name, target); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("backup restored",
+		slog.String("type", OperationTypeBackup),
+		slog.Uint64("operator_id", operatorID(ctx)),
+		slog.String("backup_type", string(typ)),
+		slog.String("target", target),
+		slog.Uint64("storage", uint64(storage)),
+	)
+
+	return nil
+}
+
+// Verify 重新下载备份，比对校验清单并试列压缩包内容
+func (uc *BackupUsecase) Verify(storage uint, typ BackupType, name, identity string) (*types.BackupVerify, error) {
+	return uc.repo.Verify(storage, typ, name, identity)
+}
+
+// TestRestore 将目标最新的数据库备份恢复到临时数据库，结果通过备份事件通知
+func (uc *BackupUsecase) TestRestore(ctx context.Context, storage uint, typ BackupType, target string) error {
+	name, err := uc.repo.TestRestore(storage, typ, target)
+
+	subject, title := uc.t.Get("[AcePanel] Backup Test Restore Succeeded"), uc.t.Get("backup test restore succeeded")
+	rows := [][2]string{
+		{uc.t.Get("Type"), string(typ)},
+		{uc.t.Get("Target"), target},
+		{uc.t.Get("Backup File"), name},
+	}
+	if err != nil {
+		subject, title = uc.t.Get("[AcePanel] Backup Test Restore Failed"), uc.t.Get("backup test restore failed")
+		rows = append(rows, [2]string{uc.t.Get("Error"), err.Error()})
+	}
+	rows = append(rows, [2]string{uc.t.Get("Time"), time.Now().Format(time.DateTime)})
+
+	// 同 Create，由 CLI 执行，必须同步发送
+	if sendErr := uc.notify.SendEventSync(ctx, NotifyEventBackup, subject, NotifyBody(title, rows)); sendErr != nil {
+		uc.log.Warn("failed to send backup test restore notification", slog.Any("err", sendErr))
+	}
+
+	return err
+}
+
 func (uc *BackupUsecase) ClearExpired(path, prefix string, save uint) error {
 	return uc.repo.ClearExpired(path, prefix, save)
 }
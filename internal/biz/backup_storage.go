@@ -2,9 +2,12 @@ package biz
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"strings"
 	"time"
 
+	"filippo.io/age"
 	"github.com/leonelquinteros/gotext"
 	"github.com/libtnb/utils/crypt"
 	"gorm.io/gorm"
@@ -38,6 +41,13 @@ func (r *BackupStorage) BeforeSave(tx *gorm.DB) error {
 		return err
 	}
 
+	if r.Info.Passphrase != "" {
+		r.Info.Passphrase, err = crypter.Encrypt([]byte(r.Info.Passphrase))
+		if err != nil {
+			return err
+		}
+	}
+
 	switch r.Type {
 	case BackupStorageTypeS3:
 		r.Info.AccessKey, err = crypter.Encrypt([]byte(r.Info.AccessKey))
@@ -87,6 +97,13 @@ func (r *BackupStorage) AfterFind(tx *gorm.DB) error {
 		return err
 	}
 
+	if r.Info.Passphrase != "" {
+		passphrase, err := crypter.Decrypt(r.Info.Passphrase)
+		if err == nil {
+			r.Info.Passphrase = string(passphrase)
+		}
+	}
+
 	switch r.Type {
 	case BackupStorageTypeS3:
 		accessKey, err := crypter.Decrypt(r.Info.AccessKey)
@@ -195,6 +212,10 @@ func (uc *BackupAccountUsecase) Get(id uint) (*BackupStorage, error) {
 }
 
 func (uc *BackupAccountUsecase) Create(ctx context.Context, req *request.BackupStorageCreate) (*BackupStorage, error) {
+	if err := uc.checkEncryption(req.Info); err != nil {
+		return nil, err
+	}
+
 	account := &BackupStorage{
 		Type: BackupStorageType(req.Type),
 		Name: req.Name,
@@ -211,6 +232,10 @@ func (uc *BackupAccountUsecase) Create(ctx context.Context, req *request.BackupS
 }
 
 func (uc *BackupAccountUsecase) Update(ctx context.Context, req *request.BackupStorageUpdate) error {
+	if err := uc.checkEncryption(req.Info); err != nil {
+		return err
+	}
+
 	account, err := uc.Get(req.ID)
 	if err != nil {
 		return err
@@ -229,6 +254,21 @@ func (uc *BackupAccountUsecase) Update(ctx context.Context, req *request.BackupS
 	return nil
 }
 
+// checkEncryption 校验加密配置，age 的口令加密不能与公钥同时使用
+func (uc *BackupAccountUsecase) checkEncryption(info types.BackupStorageInfo) error {
+	if info.PublicKey == "" {
+		return nil
+	}
+	if info.Passphrase != "" {
+		return errors.New(uc.t.Get("passphrase and public key cannot be used together"))
+	}
+	if _, err := age.ParseRecipients(strings.NewReader(info.PublicKey)); err != nil {
+		return errors.New(uc.t.Get("invalid public key: %v", err))
+	}
+
+	return nil
+}
+
 func (uc *BackupAccountUsecase) Delete(ctx context.Context, id uint) error {
 	if err := uc.repo.Delete(id); err != nil {
 		return err
@@ -3,15 +3,15 @@ package biz
 import "github.com/google/wire"
 
 var ProviderSet = wire.NewSet(
-	NewAlertUsecase, NewAppUsecase, NewBackupUsecase, NewBackupAccountUsecase,
+	NewAlertUsecase, NewAppUsecase, NewAuditUsecase, NewBackupUsecase, NewBackupAccountUsecase,
 	NewCacheUsecase, NewCertUsecase, NewCertAccountUsecase,
-	NewCertDNSUsecase, NewContainerUsecase, NewContainerComposeUsecase,
+	NewCertDNSUsecase, NewCertDeployUsecase, NewCertMonitorUsecase, NewContainerUsecase, NewContainerComposeUsecase,
 	NewContainerImageUsecase, NewContainerNetworkUsecase, NewContainerVolumeUsecase,
 	NewCronUsecase, NewDatabaseUsecase, NewDatabaseRedisUsecase,
-	NewDatabaseElasticsearchUsecase, NewDatabaseServerUsecase, NewDatabaseUserUsecase,
-	NewEnvironmentUsecase, NewFileShareUsecase, NewLogUsecase, NewMonitorUsecase,
-	NewNotifyUsecase, NewProjectUsecase, NewSafeUsecase, NewScanEventUsecase,
-	NewSettingUsecase, NewSSHUsecase, NewTamperUsecase, NewTaskUsecase,
+	NewDatabaseElasticsearchUsecase, NewDatabaseServerUsecase, NewDatabaseUserUsecase, NewDeploymentUsecase,
+	NewEnvironmentUsecase, NewFileShareUsecase, NewFirewallGeoUsecase, NewLogUsecase, NewMonitorUsecase, NewProbeUsecase,
+	NewNotifyUsecase, NewProjectUsecase, NewRoleUsecase, NewSafeUsecase, NewScanEventUsecase,
+	NewSettingUsecase, NewSSHUsecase, NewTamperUsecase, NewTaskUsecase, NewTerminalRecordingUsecase,
 	NewTemplateUsecase, NewUserUsecase, NewUserPasskeyUsecase,
 	NewUserTokenUsecase, NewWebHookUsecase, NewWebsiteUsecase,
 	NewWebsiteStatUsecase, NewToolboxMigrationUsecase,
@@ -71,14 +71,16 @@ type CertRepo interface {
 
 type CertUsecase struct {
 	repo    CertRepo
+	deploy  CertDeployRepo
 	setting SettingRepo
 	t       *gotext.Locale
 	log     *slog.Logger
 }
 
-func NewCertUsecase(t *gotext.Locale, log *slog.Logger, certRepo CertRepo, settingRepo SettingRepo) *CertUsecase {
+func NewCertUsecase(t *gotext.Locale, log *slog.Logger, certRepo CertRepo, certDeployRepo CertDeployRepo, settingRepo SettingRepo) *CertUsecase {
 	return &CertUsecase{
 		repo:    certRepo,
+		deploy:  certDeployRepo,
 		setting: settingRepo,
 		t:       t,
 		log:     log,
@@ -235,6 +237,7 @@ func (uc *CertUsecase) ObtainAutoWithProgressCallback(ctx context.Context, id ui
 	if err = uc.repo.Save(cert); err != nil {
 		return nil, err
 	}
+	uc.pushDeployTargets(ctx, cert, report)
 
 	if len(cert.Websites) > 0 {
 		report(uc.t.Get("deploying certificate to website"))
@@ -281,6 +284,7 @@ func (uc *CertUsecase) ObtainSelfSigned(id uint) error {
 	if err = uc.repo.Save(cert); err != nil {
 		return err
 	}
+	uc.pushDeployTargets(context.Background(), cert, nil)
 
 	if len(cert.Websites) > 0 {
 		return uc.Deploy(cert.ID, cert.WebsiteIDs(), false)
@@ -362,6 +366,7 @@ func (uc *CertUsecase) RenewWithProgressCallback(ctx context.Context, id uint, p
 	if err = uc.repo.Save(cert); err != nil {
 		return nil, err
 	}
+	uc.pushDeployTargets(ctx, cert, report)
 
 	if len(cert.Websites) > 0 {
 		report(uc.t.Get("deploying certificate to website"))
@@ -453,3 +458,24 @@ func (uc *CertUsecase) Deploy(id uint, websiteIDs []uint, enableHTTPS bool) erro
 func (uc *CertUsecase) Save(cert *Cert) error {
 	return uc.repo.Save(cert)
 }
+
+// pushDeployTargets 推送证书到自动部署的目标，单个目标失败不影响签发结果，结果记录在部署历史中
+func (uc *CertUsecase) pushDeployTargets(ctx context.Context, cert *Cert, report func(string)) {
+	targets, err := uc.deploy.List(cert.ID)
+	if err != nil {
+		uc.log.Warn("failed to list cert deploy targets", slog.String("type", OperationTypeCert), slog.Uint64("cert_id", uint64(cert.ID)), slog.Any("err", err))
+		return
+	}
+
+	for _, target := range targets {
+		if !target.Auto {
+			continue
+		}
+		if report != nil {
+			report(uc.t.Get("deploying certificate to %s", target.Name))
+		}
+		if err = pushCertDeploy(ctx, uc.deploy, uc.log, target, cert); err != nil && report != nil {
+			report(uc.t.Get("failed to deploy certificate to %s: %v", target.Name, err))
+		}
+	}
+}
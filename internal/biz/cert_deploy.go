@@ -0,0 +1,224 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/libtnb/utils/crypt"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+type CertDeployType string
+
+const (
+	CertDeployTypeSSH    CertDeployType = "ssh"    // 通过 SSH 写入远程主机
+	CertDeployTypePanel  CertDeployType = "panel"  // 推送到其他 AcePanel 面板
+	CertDeployTypeS3     CertDeployType = "s3"     // 上传到 S3 兼容存储（CDN 源站）
+	CertDeployTypeDocker CertDeployType = "docker" // 写入本机 Docker 容器或卷
+	CertDeployTypeApp    CertDeployType = "app"    // 部署到面板应用
+)
+
+// 支持部署证书的面板应用
+const (
+	CertDeployAppPureFTPd = "pureftpd"
+	CertDeployAppGitea    = "gitea"
+	CertDeployAppMinIO    = "minio"
+	CertDeployAppGrafana  = "grafana"
+)
+
+const (
+	CertDeployStatusSuccess = "success"
+	CertDeployStatusFailed  = "failed"
+)
+
+// CertDeployTarget 证书部署目标，证书签发或续签后自动推送
+type CertDeployTarget struct {
+	ID         uint                   `gorm:"primaryKey" json:"id"`
+	CertID     uint                   `gorm:"not null;default:0;index" json:"cert_id"`
+	Name       string                 `gorm:"not null;default:''" json:"name"`
+	Type       CertDeployType         `gorm:"not null;default:''" json:"type"`
+	Config     types.CertDeployConfig `gorm:"not null;default:'{}';serializer:json" json:"config"`
+	Auto       bool                   `gorm:"not null;default:true" json:"auto"` // 签发、续签后自动部署
+	Status     string                 `gorm:"not null;default:''" json:"status"` // 最近一次部署结果
+	Message    string                 `gorm:"not null;default:''" json:"message"`
+	DeployedAt *time.Time             `json:"deployed_at"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+func (r *CertDeployTarget) BeforeSave(tx *gorm.DB) error {
+	if r.Config.Token == "" {
+		return nil
+	}
+	crypter, err := crypt.NewXChacha20Poly1305([]byte(app.Key))
+	if err != nil {
+		return err
+	}
+
+	r.Config.Token, err = crypter.Encrypt([]byte(r.Config.Token))
+	return err
+}
+
+func (r *CertDeployTarget) AfterSave(tx *gorm.DB) error {
+	return r.AfterFind(tx)
+}
+
+func (r *CertDeployTarget) AfterFind(tx *gorm.DB) error {
+	if r.Config.Token == "" {
+		return nil
+	}
+	crypter, err := crypt.NewXChacha20Poly1305([]byte(app.Key))
+	if err != nil {
+		return err
+	}
+
+	token, err := crypter.Decrypt(r.Config.Token)
+	if err == nil {
+		r.Config.Token = string(token)
+	}
+
+	return nil
+}
+
+// CertDeployLog 部署目标的部署历史
+type CertDeployLog struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TargetID  uint      `gorm:"not null;default:0;index" json:"target_id"`
+	CertID    uint      `gorm:"not null;default:0" json:"cert_id"`
+	Success   bool      `gorm:"not null;default:false" json:"success"`
+	Message   string    `gorm:"not null;default:''" json:"message"`
+	Duration  int64     `gorm:"not null;default:0" json:"duration"` // 毫秒
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CertDeployRepo interface {
+	List(certID uint) ([]*CertDeployTarget, error)
+	Get(id uint) (*CertDeployTarget, error)
+	Create(req *request.CertDeployTargetCreate) (*CertDeployTarget, error)
+	Update(req *request.CertDeployTargetUpdate) error
+	Delete(id uint) error
+	Save(target *CertDeployTarget) error
+	// Push 将证书推送到部署目标
+	Push(ctx context.Context, target *CertDeployTarget, cert *Cert) error
+	AddLog(log *CertDeployLog) error
+	ListLogs(targetID uint, page, limit uint) ([]*CertDeployLog, int64, error)
+}
+
+type CertDeployUsecase struct {
+	repo CertDeployRepo
+	cert CertRepo
+	t    *gotext.Locale
+	log  *slog.Logger
+}
+
+func NewCertDeployUsecase(t *gotext.Locale, log *slog.Logger, repo CertDeployRepo, certRepo CertRepo) *CertDeployUsecase {
+	return &CertDeployUsecase{repo: repo, cert: certRepo, t: t, log: log}
+}
+
+func (uc *CertDeployUsecase) List(certID uint) ([]*CertDeployTarget, error) {
+	return uc.repo.List(certID)
+}
+
+func (uc *CertDeployUsecase) Create(ctx context.Context, req *request.CertDeployTargetCreate) (*CertDeployTarget, error) {
+	target, err := uc.repo.Create(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 记录日志
+	uc.log.Info("cert deploy target created", slog.String("type", OperationTypeCert), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(target.ID)), slog.Uint64("cert_id", uint64(req.CertID)), slog.String("target_type", req.Type))
+
+	return target, nil
+}
+
+func (uc *CertDeployUsecase) Update(ctx context.Context, req *request.CertDeployTargetUpdate) error {
+	if err := uc.repo.Update(req); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("cert deploy target updated", slog.String("type", OperationTypeCert), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(req.ID)))
+
+	return nil
+}
+
+func (uc *CertDeployUsecase) Delete(ctx context.Context, id uint) error {
+	target, err := uc.repo.Get(id)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.repo.Delete(id); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("cert deploy target deleted", slog.String("type", OperationTypeCert), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", target.Name))
+
+	return nil
+}
+
+// Run 手动部署到指定目标
+func (uc *CertDeployUsecase) Run(ctx context.Context, id uint) error {
+	target, err := uc.repo.Get(id)
+	if err != nil {
+		return err
+	}
+	cert, err := uc.cert.Get(target.CertID)
+	if err != nil {
+		return err
+	}
+	if cert.Cert == "" || cert.Key == "" {
+		return errors.New(uc.t.Get("this certificate has not been obtained successfully and cannot be deployed"))
+	}
+
+	// 记录日志
+	uc.log.Info("cert deploy target run", slog.String("type", OperationTypeCert), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.Uint64("cert_id", uint64(cert.ID)))
+
+	return pushCertDeploy(ctx, uc.repo, uc.log, target, cert)
+}
+
+func (uc *CertDeployUsecase) ListLogs(targetID uint, page, limit uint) ([]*CertDeployLog, int64, error) {
+	return uc.repo.ListLogs(targetID, page, limit)
+}
+
+// pushCertDeploy 推送证书到部署目标，并记录结果到部署历史
+func pushCertDeploy(ctx context.Context, repo CertDeployRepo, log *slog.Logger, target *CertDeployTarget, cert *Cert) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	err := repo.Push(ctx, target, cert)
+	now := time.Now()
+
+	entry := &CertDeployLog{
+		TargetID: target.ID,
+		CertID:   cert.ID,
+		Success:  err == nil,
+		Duration: now.Sub(start).Milliseconds(),
+	}
+	target.Status = CertDeployStatusSuccess
+	target.Message = ""
+	target.DeployedAt = &now
+	if err != nil {
+		entry.Message = err.Error()
+		target.Status = CertDeployStatusFailed
+		target.Message = err.Error()
+		log.Warn("cert deploy failed", slog.String("type", OperationTypeCert), slog.Uint64("id", uint64(target.ID)), slog.Uint64("cert_id", uint64(cert.ID)), slog.Any("err", err))
+	}
+	if logErr := repo.AddLog(entry); logErr != nil {
+		return errors.Join(err, logErr)
+	}
+	if saveErr := repo.Save(target); saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+
+	return err
+}
@@ -2,9 +2,13 @@ package biz
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net/url"
 	"time"
 
+	"github.com/leonelquinteros/gotext"
+
 	"github.com/acepanel/panel/v3/internal/request"
 	"github.com/acepanel/panel/v3/pkg/acme"
 )
@@ -30,11 +34,12 @@ type CertDNSRepo interface {
 
 type CertDNSUsecase struct {
 	repo CertDNSRepo
+	t    *gotext.Locale
 	log  *slog.Logger
 }
 
-func NewCertDNSUsecase(repo CertDNSRepo, log *slog.Logger) *CertDNSUsecase {
-	return &CertDNSUsecase{repo: repo, log: log}
+func NewCertDNSUsecase(t *gotext.Locale, repo CertDNSRepo, log *slog.Logger) *CertDNSUsecase {
+	return &CertDNSUsecase{repo: repo, t: t, log: log}
 }
 
 func (uc *CertDNSUsecase) List(page, limit uint) ([]*CertDNS, int64, error) {
@@ -46,6 +51,10 @@ func (uc *CertDNSUsecase) Get(id uint) (*CertDNS, error) {
 }
 
 func (uc *CertDNSUsecase) Create(ctx context.Context, req *request.CertDNSCreate) (*CertDNS, error) {
+	if err := uc.checkHook(ctx, req.Type, req.Data); err != nil {
+		return nil, err
+	}
+
 	certDNS, err := uc.repo.Create(req)
 	if err != nil {
 		return nil, err
@@ -58,6 +67,10 @@ func (uc *CertDNSUsecase) Create(ctx context.Context, req *request.CertDNSCreate
 }
 
 func (uc *CertDNSUsecase) Update(ctx context.Context, req *request.CertDNSUpdate) error {
+	if err := uc.checkHook(ctx, req.Type, req.Data); err != nil {
+		return err
+	}
+
 	if err := uc.repo.Update(req); err != nil {
 		return err
 	}
@@ -83,3 +96,21 @@ func (uc *CertDNSUsecase) Delete(ctx context.Context, id uint) error {
 
 	return nil
 }
+
+// checkHook 自定义 Hook 以 root 执行脚本并可请求任意地址，仅允许不受限的管理员配置
+func (uc *CertDNSUsecase) checkHook(ctx context.Context, typ acme.DnsType, param acme.DNSParam) error {
+	if typ != acme.Hook {
+		return nil
+	}
+	if !Unscoped(ctx) {
+		return errors.New(uc.t.Get("only administrators can configure hook DNS providers"))
+	}
+	if param.Endpoint != "" {
+		u, err := url.Parse(param.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New(uc.t.Get("hook URL must be an http or https address"))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package biz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leonelquinteros/gotext"
+
+	"github.com/acepanel/panel/v3/pkg/acme"
+)
+
+func TestCertDNSCheckHook(t *testing.T) {
+	uc := &CertDNSUsecase{t: gotext.NewLocale("", "en")}
+	param := acme.DNSParam{Script: "echo ok"}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+	}{
+		{name: "admin session", ctx: context.Background()},
+		// 管理员的令牌不写入资源范围，仅凭受限标记拦截
+		{name: "restricted admin token", ctx: WithRestrictedToken(context.Background()), wantErr: true},
+		{name: "scoped user", ctx: WithScopes(context.Background(), map[string][]uint{}), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.checkHook(tt.ctx, acme.Hook, param)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkHook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
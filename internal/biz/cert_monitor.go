@@ -0,0 +1,246 @@
+package biz
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	lop "github.com/samber/lo/parallel"
+
+	"github.com/acepanel/panel/v3/internal/request"
+	pkgcert "github.com/acepanel/panel/v3/pkg/cert"
+)
+
+// 外部证书检查结果
+const (
+	CertMonitorStatusOK      = "ok"      // 证书正常
+	CertMonitorStatusProblem = "problem" // 可连接但证书存在问题（过期、链无效、域名不匹配、已吊销）
+	CertMonitorStatusError   = "error"   // 无法连接或握手失败
+)
+
+// certMonitorCheckDays 检查历史保留天数
+const certMonitorCheckDays = 90
+
+// CertMonitor 外部证书监控，定期探测不由面板管理的 TLS 端点
+type CertMonitor struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Name     string `gorm:"not null;default:''" json:"name"`
+	Host     string `gorm:"not null;default:''" json:"host"`
+	Port     uint   `gorm:"not null;default:443" json:"port"`
+	SNI      string `gorm:"not null;default:''" json:"sni"`      // 为空时使用 Host
+	Interval uint   `gorm:"not null;default:60" json:"interval"` // 检查间隔（分钟）
+	Enabled  bool   `gorm:"not null;default:true" json:"enabled"`
+	// 最近一次检查结果
+	Status           string              `gorm:"not null;default:''" json:"status"`
+	Error            string              `gorm:"not null;default:''" json:"error"`
+	Issuer           string              `gorm:"not null;default:''" json:"issuer"`
+	NotAfter         *time.Time          `json:"not_after"`
+	ChainValid       bool                `gorm:"not null;default:false" json:"chain_valid"`
+	HostnameMismatch bool                `gorm:"not null;default:false" json:"hostname_mismatch"`
+	OCSPStatus       string              `gorm:"not null;default:''" json:"ocsp_status"`
+	Chain            []pkgcert.ChainItem `gorm:"not null;default:'[]';serializer:json" json:"chain"`
+	CheckedAt        *time.Time          `json:"checked_at"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+}
+
+// ServerName 握手使用的 SNI
+func (r *CertMonitor) ServerName() string {
+	if r.SNI != "" {
+		return r.SNI
+	}
+	return r.Host
+}
+
+// DaysLeft 证书剩余天数
+func (r *CertMonitor) DaysLeft() float64 {
+	if r.NotAfter == nil {
+		return 0
+	}
+	return time.Until(*r.NotAfter).Hours() / 24
+}
+
+// CertMonitorCheck 外部证书检查历史
+type CertMonitorCheck struct {
+	ID               uint                `gorm:"primaryKey" json:"id"`
+	MonitorID        uint                `gorm:"not null;default:0;index" json:"monitor_id"`
+	Status           string              `gorm:"not null;default:''" json:"status"`
+	Error            string              `gorm:"not null;default:''" json:"error"`
+	Issuer           string              `gorm:"not null;default:''" json:"issuer"`
+	NotAfter         *time.Time          `json:"not_after"`
+	ChainValid       bool                `gorm:"not null;default:false" json:"chain_valid"`
+	ChainError       string              `gorm:"not null;default:''" json:"chain_error"`
+	HostnameMismatch bool                `gorm:"not null;default:false" json:"hostname_mismatch"`
+	OCSPStatus       string              `gorm:"not null;default:''" json:"ocsp_status"`
+	Chain            []pkgcert.ChainItem `gorm:"not null;default:'[]';serializer:json" json:"chain"`
+	Duration         int64               `gorm:"not null;default:0" json:"duration"` // 毫秒
+	CreatedAt        time.Time           `gorm:"index" json:"created_at"`
+}
+
+type CertMonitorRepo interface {
+	List(page, limit uint) ([]*CertMonitor, int64, error)
+	Get(id uint) (*CertMonitor, error)
+	Create(req *request.CertMonitorCreate) (*CertMonitor, error)
+	Update(req *request.CertMonitorUpdate) error
+	Delete(id uint) error
+	Save(monitor *CertMonitor) error
+	// Due 返回已到检查时间的启用监控
+	Due(now time.Time) ([]*CertMonitor, error)
+	// Probe 探测监控端点的证书
+	Probe(ctx context.Context, monitor *CertMonitor) (*pkgcert.ProbeResult, error)
+	AddCheck(check *CertMonitorCheck) error
+	ListChecks(monitorID uint, page, limit uint) ([]*CertMonitorCheck, int64, error)
+	ClearChecksBefore(t time.Time) error
+}
+
+type CertMonitorUsecase struct {
+	repo CertMonitorRepo
+	t    *gotext.Locale
+	log  *slog.Logger
+
+	cleanedAt time.Time // 上次清理检查历史的时间
+}
+
+func NewCertMonitorUsecase(t *gotext.Locale, log *slog.Logger, repo CertMonitorRepo) *CertMonitorUsecase {
+	return &CertMonitorUsecase{repo: repo, t: t, log: log}
+}
+
+func (uc *CertMonitorUsecase) List(page, limit uint) ([]*CertMonitor, int64, error) {
+	return uc.repo.List(page, limit)
+}
+
+func (uc *CertMonitorUsecase) Get(id uint) (*CertMonitor, error) {
+	return uc.repo.Get(id)
+}
+
+func (uc *CertMonitorUsecase) Create(ctx context.Context, req *request.CertMonitorCreate) (*CertMonitor, error) {
+	monitor, err := uc.repo.Create(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 记录日志
+	uc.log.Info("cert monitor created", slog.String("type", OperationTypeCert), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(monitor.ID)), slog.String("host", req.Host), slog.Uint64("port", uint64(req.Port)))
+
+	// 创建后立即检查一次，便于直接看到结果
+	if monitor.Enabled {
+		uc.check(ctx, monitor)
+	}
+
+	return monitor, nil
+}
+
+func (uc *CertMonitorUsecase) Update(ctx context.Context, req *request.CertMonitorUpdate) error {
+	if err := uc.repo.Update(req); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("cert monitor updated", slog.String("type", OperationTypeCert), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(req.ID)))
+
+	return nil
+}
+
+func (uc *CertMonitorUsecase) Delete(ctx context.Context, id uint) error {
+	monitor, err := uc.repo.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = uc.repo.Delete(id); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("cert monitor deleted", slog.String("type", OperationTypeCert), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", monitor.Name))
+
+	return nil
+}
+
+// Check 立即检查指定监控
+func (uc *CertMonitorUsecase) Check(ctx context.Context, id uint) (*CertMonitor, error) {
+	monitor, err := uc.repo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.check(ctx, monitor)
+	return monitor, nil
+}
+
+func (uc *CertMonitorUsecase) ListChecks(monitorID uint, page, limit uint) ([]*CertMonitorCheck, int64, error) {
+	return uc.repo.ListChecks(monitorID, page, limit)
+}
+
+// Run 检查所有到期的监控，并清理过期的检查历史
+func (uc *CertMonitorUsecase) Run(ctx context.Context) error {
+	monitors, err := uc.repo.Due(time.Now())
+	if err != nil {
+		return err
+	}
+
+	// 各端点互不影响，并发探测
+	lop.ForEach(monitors, func(monitor *CertMonitor, _ int) {
+		uc.check(ctx, monitor)
+	})
+
+	if time.Since(uc.cleanedAt) > 6*time.Hour {
+		uc.cleanedAt = time.Now()
+		if err = uc.repo.ClearChecksBefore(time.Now().AddDate(0, 0, -certMonitorCheckDays)); err != nil {
+			uc.log.Warn("failed to clear expired cert monitor checks", slog.Any("err", err))
+		}
+	}
+
+	return nil
+}
+
+// check 探测并记录结果，失败只记入历史不返回错误
+func (uc *CertMonitorUsecase) check(ctx context.Context, monitor *CertMonitor) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	result, err := uc.repo.Probe(ctx, monitor)
+	now := time.Now()
+
+	entry := &CertMonitorCheck{
+		MonitorID: monitor.ID,
+		Duration:  now.Sub(start).Milliseconds(),
+		Chain:     []pkgcert.ChainItem{},
+	}
+	if err != nil {
+		entry.Status = CertMonitorStatusError
+		entry.Error = err.Error()
+	} else {
+		leaf := result.Leaf()
+		entry.Issuer = leaf.Issuer
+		entry.NotAfter = &leaf.NotAfter
+		entry.ChainValid = result.ChainValid
+		entry.ChainError = result.ChainError
+		entry.HostnameMismatch = result.HostnameMismatch
+		entry.OCSPStatus = result.OCSPStatus
+		entry.Chain = result.Chain
+		entry.Status = CertMonitorStatusOK
+		if !result.ChainValid || result.HostnameMismatch || result.OCSPStatus == pkgcert.OCSPRevoked || now.After(leaf.NotAfter) {
+			entry.Status = CertMonitorStatusProblem
+			entry.Error = result.ChainError
+		}
+	}
+
+	monitor.Status = entry.Status
+	monitor.Error = entry.Error
+	monitor.Issuer = entry.Issuer
+	monitor.NotAfter = entry.NotAfter
+	monitor.ChainValid = entry.ChainValid
+	monitor.HostnameMismatch = entry.HostnameMismatch
+	monitor.OCSPStatus = entry.OCSPStatus
+	monitor.Chain = entry.Chain
+	monitor.CheckedAt = &now
+
+	if err = uc.repo.AddCheck(entry); err != nil {
+		uc.log.Warn("failed to save cert monitor check", slog.Uint64("id", uint64(monitor.ID)), slog.Any("err", err))
+	}
+	if err = uc.repo.Save(monitor); err != nil {
+		uc.log.Warn("failed to save cert monitor", slog.Uint64("id", uint64(monitor.ID)), slog.Any("err", err))
+	}
+}
@@ -90,6 +90,7 @@ func (uc *ContainerUsecase) CreateBackground(req *request.ContainerCreate) error
 	}
 	task.Name = uc.t.Get("Create container %s", target)
 	task.Status = TaskStatusWaiting
+	task.Resource = "container:" + target
 	task.Shell = shell
 
 	return uc.task.Push(task)
@@ -126,6 +127,7 @@ func (uc *ContainerUsecase) UpdateBackground(id string, req *request.ContainerCr
 	task.Key = "container:update:" + id
 	task.Name = uc.t.Get("Update container %s", target)
 	task.Status = TaskStatusWaiting
+	task.Resource = "container:" + id
 	task.Shell = shell
 
 	return uc.task.Push(task)
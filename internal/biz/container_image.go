@@ -57,8 +57,10 @@ func (uc *ContainerImageUsecase) PullBackground(req *request.ContainerImagePull)
 	task.Key = "container:image:pull:" + req.Name
 	task.Name = uc.t.Get("Pull image %s", req.Name)
 	task.Status = TaskStatusWaiting
+	task.Resource = "image:" + req.Name
 	task.Shell = shell
 	task.CancelShell = cancelShell
+	task.MaxRetries = 2
 
 	return uc.task.Push(task)
 }
@@ -2,13 +2,27 @@ package biz
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"math"
+	"os"
+	"slices"
 	"time"
 
+	"github.com/leonelquinteros/gotext"
+
 	"github.com/acepanel/panel/v3/internal/request"
 	"github.com/acepanel/panel/v3/pkg/types"
 )
 
+const (
+	CronOverlapAllow = "allow" // 允许并行执行
+	CronOverlapSkip  = "skip"  // 上次未结束时跳过本次
+	CronOverlapQueue = "queue" // 排队等待上次结束
+)
+
 type Cron struct {
 	ID        uint             `gorm:"primaryKey" json:"id"`
 	Name      string           `gorm:"not null;default:'';unique" json:"name"`
@@ -35,17 +49,33 @@ type CronRepo interface {
 	Dos2Unix(path string) error
 	AddToSystem(cron *Cron) error
 	DeleteFromSystem(cron *Cron) error
+	// RefreshSystem 重新生成 crons 的 wrapper 脚本与 crontab 条目，只重启一次 cron 服务
+	RefreshSystem(crons []*Cron) error
 	RemoveScriptFiles(shellPath string) error
+	// Exec 按重叠策略与超时限制执行一次任务脚本，输出同时写入 out 与任务日志
+	// 返回退出码；skip 策略下上次未结束时不执行并返回 skipped
+	Exec(ctx context.Context, cron *Cron, out io.Writer) (code int, skipped bool, err error)
+	// ListRuns 执行记录列表，不含输出
+	ListRuns(cronID, page, limit uint) ([]*CronRun, int64, error)
+	CreateRun(run *CronRun) error
+	GetRun(cronID, runID uint) (*CronRun, error)
+	RunStats(cronID uint) (*CronRunStats, error)
+	// PruneRuns 只保留任务最近的 keep 条执行记录
+	PruneRuns(cronID, keep uint) error
+	ClearRunsBefore(t time.Time) (int64, error)
 }
 
 // CronUsecase 计划任务业务逻辑
 type CronUsecase struct {
-	repo CronRepo
-	log  *slog.Logger
+	t       *gotext.Locale
+	log     *slog.Logger
+	repo    CronRepo
+	task    TaskRepo
+	setting SettingRepo
 }
 
-func NewCronUsecase(repo CronRepo, log *slog.Logger) *CronUsecase {
-	return &CronUsecase{repo: repo, log: log}
+func NewCronUsecase(t *gotext.Locale, log *slog.Logger, repo CronRepo, task TaskRepo, setting SettingRepo) *CronUsecase {
+	return &CronUsecase{t: t, log: log, repo: repo, task: task, setting: setting}
 }
 
 func (uc *CronUsecase) Count() (int64, error) {
@@ -61,19 +91,28 @@ func (uc *CronUsecase) Get(id uint) (*Cron, error) {
 }
 
 func (uc *CronUsecase) Create(ctx context.Context, req *request.CronCreate) error {
+	if err := uc.checkChain(0, req.OnSuccess, req.OnFailure); err != nil {
+		return err
+	}
+
 	config := types.CronConfig{
-		Type:     req.SubType,
-		Flock:    req.Flock,
-		Targets:  req.Targets,
-		Storage:  req.Storage,
-		Keep:     req.Keep,
-		URL:      req.URL,
-		Method:   req.Method,
-		Headers:  req.Headers,
-		Body:     req.Body,
-		Timeout:  req.Timeout,
-		Insecure: req.Insecure,
-		Retries:  req.Retries,
+		Type:        req.SubType,
+		Dedup:       req.Dedup,
+		TestRestore: req.TestRestore,
+		Targets:     req.Targets,
+		Storage:     req.Storage,
+		Keep:        req.Keep,
+		URL:         req.URL,
+		Method:      req.Method,
+		Headers:     req.Headers,
+		Body:        req.Body,
+		Timeout:     req.Timeout,
+		Insecure:    req.Insecure,
+		Retries:     req.Retries,
+		Overlap:     req.Overlap,
+		MaxRuntime:  req.MaxRuntime,
+		OnSuccess:   req.OnSuccess,
+		OnFailure:   req.OnFailure,
 	}
 	script := uc.repo.GenerateScript(req.Type, config, req.Script)
 
@@ -109,6 +148,9 @@ func (uc *CronUsecase) Update(ctx context.Context, req *request.CronUpdate) erro
 	if err != nil {
 		return err
 	}
+	if err = uc.checkChain(cron.ID, req.OnSuccess, req.OnFailure); err != nil {
+		return err
+	}
 
 	cron.Time = req.Time
 	cron.Name = req.Name
@@ -116,18 +158,23 @@ func (uc *CronUsecase) Update(ctx context.Context, req *request.CronUpdate) erro
 	// 根据类型重新生成脚本
 	if req.Type != "shell" {
 		config := types.CronConfig{
-			Type:     req.SubType,
-			Flock:    req.Flock,
-			Targets:  req.Targets,
-			Storage:  req.Storage,
-			Keep:     req.Keep,
-			URL:      req.URL,
-			Method:   req.Method,
-			Headers:  req.Headers,
-			Body:     req.Body,
-			Timeout:  req.Timeout,
-			Insecure: req.Insecure,
-			Retries:  req.Retries,
+			Type:        req.SubType,
+			Dedup:       req.Dedup,
+			TestRestore: req.TestRestore,
+			Targets:     req.Targets,
+			Storage:     req.Storage,
+			Keep:        req.Keep,
+			URL:         req.URL,
+			Method:      req.Method,
+			Headers:     req.Headers,
+			Body:        req.Body,
+			Timeout:     req.Timeout,
+			Insecure:    req.Insecure,
+			Retries:     req.Retries,
+			Overlap:     req.Overlap,
+			MaxRuntime:  req.MaxRuntime,
+			OnSuccess:   req.OnSuccess,
+			OnFailure:   req.OnFailure,
 		}
 		cron.Config = config
 		script := uc.repo.GenerateScript(req.Type, config, "")
@@ -135,7 +182,11 @@ func (uc *CronUsecase) Update(ctx context.Context, req *request.CronUpdate) erro
 			return err
 		}
 	} else {
-		cron.Config.Flock = req.Flock
+		cron.Config.Flock = false
+		cron.Config.Overlap = req.Overlap
+		cron.Config.MaxRuntime = req.MaxRuntime
+		cron.Config.OnSuccess = req.OnSuccess
+		cron.Config.OnFailure = req.OnFailure
 		if err = uc.repo.WriteScript(cron.Shell, req.Script); err != nil {
 			return err
 		}
@@ -169,6 +220,9 @@ func (uc *CronUsecase) Delete(ctx context.Context, id uint) error {
 	if err != nil {
 		return err
 	}
+	if uc.task.HasRunningTask(fmt.Sprintf("cron:%d", cron.ID)) {
+		return errors.New(uc.t.Get("cron task %s is running, please wait for it to finish or cancel it first", cron.Name))
+	}
 
 	if err = uc.repo.DeleteFromSystem(cron); err != nil {
 		return err
@@ -180,6 +234,9 @@ func (uc *CronUsecase) Delete(ctx context.Context, id uint) error {
 	if err = uc.repo.Delete(cron); err != nil {
 		return err
 	}
+	if err = uc.unlinkChain(id); err != nil {
+		return err
+	}
 
 	// 记录日志
 	uc.log.Info("cron deleted", slog.String("type", OperationTypeCron), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", cron.Name))
@@ -206,3 +263,149 @@ func (uc *CronUsecase) Status(id uint, status bool) error {
 
 	return uc.repo.Save(cron)
 }
+
+// RefreshWrappers 按当前模板重新生成全部已启用任务的 wrapper 脚本，旧版本生成的脚本不会记录执行
+func (uc *CronUsecase) RefreshWrappers() error {
+	crons, _, err := uc.repo.List(1, math.MaxUint32)
+	if err != nil {
+		return err
+	}
+
+	enabled := slices.DeleteFunc(crons, func(cron *Cron) bool { return !cron.Status })
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	return uc.repo.RefreshSystem(enabled)
+}
+
+// Run 将计划任务加入任务队列立即执行一次，返回的任务已带日志路径供前端跟踪输出
+func (uc *CronUsecase) Run(ctx context.Context, id uint) (*Task, error) {
+	cron, err := uc.repo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := uc.push(cron, CronRunTriggerManual)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = createTaskLog(uc.task, task); err != nil {
+		return nil, err
+	}
+
+	// 记录日志
+	uc.log.Info("cron run", slog.String("type", OperationTypeCron), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", cron.Name))
+
+	return task, nil
+}
+
+// Execute 执行一次计划任务并记录，随后按结果触发后续任务
+// 因重叠策略被跳过时返回 nil
+func (uc *CronUsecase) Execute(ctx context.Context, cron *Cron, trigger string, out io.Writer) (*CronRun, error) {
+	var offset int64
+	if info, err := os.Stat(cron.Log); err == nil {
+		offset = info.Size()
+	}
+
+	run := &CronRun{CronID: cron.ID, Trigger: trigger, StartedAt: time.Now()}
+	code, skipped, err := uc.repo.Exec(ctx, cron, out)
+	if err != nil || skipped {
+		return nil, err
+	}
+	run.EndedAt = time.Now()
+	run.ExitCode = code
+
+	// 记录失败不影响后续任务
+	err = uc.RecordRun(run, cron.Log, offset)
+	uc.Chain(cron, code)
+
+	return run, err
+}
+
+// Chain 按执行结果将后续任务依次加入任务队列
+func (uc *CronUsecase) Chain(cron *Cron, code int) {
+	next := cron.Config.OnSuccess
+	if code != 0 {
+		next = cron.Config.OnFailure
+	}
+
+	for _, id := range next {
+		target, err := uc.repo.Get(id)
+		if err == nil {
+			_, err = uc.push(target, CronRunTriggerChain)
+		}
+		if err != nil {
+			uc.log.Warn("failed to trigger chained cron", slog.Uint64("id", uint64(cron.ID)), slog.Uint64("next", uint64(id)), slog.Any("err", err))
+		}
+	}
+}
+
+// push 将计划任务的一次执行加入任务队列，同一任务排队或运行中时拒绝重复加入
+func (uc *CronUsecase) push(cron *Cron, trigger string) (*Task, error) {
+	task := &Task{
+		Key:      fmt.Sprintf("cron:run:%d", cron.ID),
+		Name:     uc.t.Get("Run cron task %s", cron.Name),
+		Status:   TaskStatusWaiting,
+		Resource: fmt.Sprintf("cron:%d", cron.ID), // 不同计划任务之间互不阻塞
+		Shell:    fmt.Sprintf("export PATH=/bin:/sbin:/usr/bin:/usr/sbin:/usr/local/bin:/usr/local/sbin:$PATH\nacepanel cron run -i %d -t %s", cron.ID, trigger),
+	}
+	if err := uc.task.Push(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// checkChain 校验后续任务存在，且沿触发关系不会回到任务自身
+// id 为 0 表示新建任务，不可能被已有任务引用，只需校验存在
+func (uc *CronUsecase) checkChain(id uint, onSuccess, onFailure []uint) error {
+	next := slices.Concat(onSuccess, onFailure)
+	for _, nid := range next {
+		if _, err := uc.repo.Get(nid); err != nil {
+			return errors.New(uc.t.Get("chained cron task %d not found", nid))
+		}
+	}
+
+	seen := make(map[uint]bool)
+	for len(next) > 0 {
+		nid := next[0]
+		next = next[1:]
+		if nid == id {
+			return errors.New(uc.t.Get("chained cron tasks cannot form a cycle"))
+		}
+		if seen[nid] {
+			continue
+		}
+		seen[nid] = true
+		// 间接引用的任务可能已被删除，跳过即可
+		if cron, err := uc.repo.Get(nid); err == nil {
+			next = append(next, cron.Config.OnSuccess...)
+			next = append(next, cron.Config.OnFailure...)
+		}
+	}
+
+	return nil
+}
+
+// unlinkChain 删除任务后清理其他任务指向它的触发关系
+func (uc *CronUsecase) unlinkChain(id uint) error {
+	crons, _, err := uc.repo.List(1, math.MaxUint32)
+	if err != nil {
+		return err
+	}
+
+	for _, cron := range crons {
+		if !slices.Contains(cron.Config.OnSuccess, id) && !slices.Contains(cron.Config.OnFailure, id) {
+			continue
+		}
+		cron.Config.OnSuccess = slices.DeleteFunc(cron.Config.OnSuccess, func(n uint) bool { return n == id })
+		cron.Config.OnFailure = slices.DeleteFunc(cron.Config.OnFailure, func(n uint) bool { return n == id })
+		if err = uc.repo.Save(cron); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+const (
+	CronRunTriggerSchedule = "schedule" // 系统 crontab 调度
+	CronRunTriggerManual   = "manual"   // 面板手动执行
+	CronRunTriggerChain    = "chain"    // 前置任务执行后触发
+)
+
+// CronRunOutputLimit 单次执行保存的输出上限，超出时保留开头与结尾
+const CronRunOutputLimit = 256 << 10
+
+// cronRunOutputHead 输出超限时保留的开头部分，其余留给结尾
+const cronRunOutputHead = 64 << 10
+
+// CronRun 计划任务的一次执行记录
+type CronRun struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	CronID     uint      `gorm:"not null;default:0;index" json:"cron_id"`
+	Trigger    string    `gorm:"not null;default:''" json:"trigger"`
+	StartedAt  time.Time `gorm:"not null;index" json:"started_at"`
+	EndedAt    time.Time `gorm:"not null" json:"ended_at"`
+	Duration   int64     `gorm:"not null;default:0" json:"duration"` // 毫秒
+	ExitCode   int       `gorm:"not null;default:0" json:"exit_code"`
+	Output     string    `gorm:"type:text;serializer:zstd" json:"-"`
+	OutputSize int64     `gorm:"not null;default:0" json:"output_size"` // 截断前的输出大小
+	Truncated  bool      `gorm:"not null;default:false" json:"truncated"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CronRunStats 计划任务执行统计，基于保留的执行记录
+type CronRunStats struct {
+	Total       int64      `json:"total"`
+	Success     int64      `json:"success"`
+	Failed      int64      `json:"failed"`
+	SuccessRate float64    `json:"success_rate"` // %，无记录时为 0
+	AvgDuration float64    `json:"avg_duration"` // 毫秒
+	MinDuration int64      `json:"min_duration"` // 毫秒
+	MaxDuration int64      `json:"max_duration"` // 毫秒
+	LastRunAt   *time.Time `json:"last_run_at"`
+	LastCode    int        `json:"last_code"`
+}
+
+// CronRunSetting 执行记录保留设置
+type CronRunSetting struct {
+	Days uint `json:"days"` // 保留天数，0 为不按时间清理
+	Keep uint `json:"keep"` // 每个任务保留条数，0 为不按条数清理
+}
+
+// ListRuns 执行记录列表，不含输出
+func (uc *CronUsecase) ListRuns(cronID, page, limit uint) ([]*CronRun, int64, error) {
+	return uc.repo.ListRuns(cronID, page, limit)
+}
+
+// RunOutput 获取一次执行的输出
+func (uc *CronUsecase) RunOutput(cronID, runID uint) (string, error) {
+	run, err := uc.repo.GetRun(cronID, runID)
+	if err != nil {
+		return "", err
+	}
+
+	return run.Output, nil
+}
+
+func (uc *CronUsecase) RunStats(cronID uint) (*CronRunStats, error) {
+	return uc.repo.RunStats(cronID)
+}
+
+// RecordRun 保存一次执行记录，输出从 path 的 offset 处读取到文件末尾
+func (uc *CronUsecase) RecordRun(run *CronRun, path string, offset int64) error {
+	output, size, err := readRunOutput(path, offset)
+	if err != nil {
+		return err
+	}
+	if run.EndedAt.Before(run.StartedAt) {
+		run.EndedAt = run.StartedAt
+	}
+	run.Duration = run.EndedAt.Sub(run.StartedAt).Milliseconds()
+	run.Output = string(output)
+	run.OutputSize = size
+	run.Truncated = size > CronRunOutputLimit
+
+	if err = uc.repo.CreateRun(run); err != nil {
+		return err
+	}
+
+	setting, _ := uc.GetRunSetting()
+	if setting.Keep > 0 {
+		return uc.repo.PruneRuns(run.CronID, setting.Keep)
+	}
+
+	return nil
+}
+
+func (uc *CronUsecase) GetRunSetting() (*CronRunSetting, error) {
+	days, _ := uc.setting.GetInt(SettingKeyCronRunDays, 30)
+	keep, _ := uc.setting.GetInt(SettingKeyCronRunKeep, 100)
+	return &CronRunSetting{
+		Days: uint(days),
+		Keep: uint(keep),
+	}, nil
+}
+
+func (uc *CronUsecase) SaveRunSetting(ctx context.Context, s *CronRunSetting) error {
+	if err := uc.setting.Set(SettingKeyCronRunDays, cast.ToString(s.Days)); err != nil {
+		return err
+	}
+	if err := uc.setting.Set(SettingKeyCronRunKeep, cast.ToString(s.Keep)); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("cron run setting updated", slog.String("type", OperationTypeSetting), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("days", uint64(s.Days)), slog.Uint64("keep", uint64(s.Keep)))
+
+	return nil
+}
+
+// ClearExpiredRuns 清理超过保留天数的执行记录
+func (uc *CronUsecase) ClearExpiredRuns() (int64, error) {
+	days, _ := uc.setting.GetInt(SettingKeyCronRunDays, 30)
+	if days <= 0 {
+		return 0, nil
+	}
+
+	return uc.repo.ClearRunsBefore(time.Now().AddDate(0, 0, -days))
+}
+
+// readRunOutput 读取本次执行的输出，超过 CronRunOutputLimit 时只保留开头与结尾
+// 返回保存的内容与原始大小；日志在执行期间被清空等导致 offset 越界时视为无输出
+func readRunOutput(path string, offset int64) ([]byte, int64, error) {
+	if path == "" {
+		return nil, 0, nil
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func(file *os.File) { _ = file.Close() }(file)
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	offset = max(offset, 0)
+	size := info.Size() - offset
+	if size <= 0 {
+		return nil, 0, nil
+	}
+	if size <= CronRunOutputLimit {
+		output := make([]byte, size)
+		_, err = io.ReadFull(io.NewSectionReader(file, offset, size), output)
+		return output, size, err
+	}
+
+	head := make([]byte, cronRunOutputHead)
+	if _, err = io.ReadFull(io.NewSectionReader(file, offset, cronRunOutputHead), head); err != nil {
+		return nil, 0, err
+	}
+	tail := make([]byte, CronRunOutputLimit-cronRunOutputHead)
+	if _, err = io.ReadFull(io.NewSectionReader(file, info.Size()-int64(len(tail)), int64(len(tail))), tail); err != nil {
+		return nil, 0, err
+	}
+
+	output := append(head, fmt.Appendf(nil, "\n... %d bytes truncated ...\n", size-CronRunOutputLimit)...)
+	return append(output, tail...), size, nil
+}
@@ -66,6 +66,9 @@ func (uc *DatabaseUsecase) List(ctx context.Context, page, limit uint, typ strin
 
 	database := make([]*Database, 0)
 	for _, server := range servers {
+		if !InScope(ctx, ScopeDatabaseServer, server.ID) {
+			continue
+		}
 		databases, err := uc.repo.DatabasesOf(ctx, server)
 		if err != nil {
 			continue
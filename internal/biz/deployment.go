@@ -0,0 +1,574 @@
+package biz
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/libtnb/utils/crypt"
+	"github.com/libtnb/utils/str"
+	"golang.org/x/crypto/ssh"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/request"
+)
+
+// 部署目标，与资源范围类型一致
+const (
+	DeploymentTargetProject = ScopeProject
+	DeploymentTargetWebsite = ScopeWebsite
+)
+
+// deploymentSCPRepo scp 风格的 SSH 仓库地址，如 git@github.com:owner/repo.git
+var deploymentSCPRepo = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*@[A-Za-z0-9][A-Za-z0-9.-]*:[^\s]+$`)
+
+const (
+	DeploymentTriggerManual  = "manual"  // 面板手动部署
+	DeploymentTriggerWebhook = "webhook" // WebHook 触发
+)
+
+const (
+	DeploymentReleasePending = "pending" // 排队中
+	DeploymentReleaseRunning = "running" // 构建中
+	DeploymentReleaseSuccess = "success" // 已发布
+	DeploymentReleaseFailed  = "failed"  // 失败
+	DeploymentReleaseSkipped = "skipped" // WebHook 触发但提交未变化
+)
+
+// Deployment 项目或网站的 Git 部署配置
+// 每次部署检出到 {Path}.releases 下以时间戳命名的目录，构建完成后原子切换 Path 软链接
+type Deployment struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	Target     string    `gorm:"not null;default:'';uniqueIndex:idx_deployment_target" json:"target"`
+	TargetID   uint      `gorm:"not null;default:0;uniqueIndex:idx_deployment_target" json:"target_id"`
+	Name       string    `gorm:"not null;default:''" json:"name"` // 部署目标名称
+	Path       string    `gorm:"not null;default:''" json:"path"` // 发布目录，部署后为指向当前版本的软链接
+	Repo       string    `gorm:"not null;default:''" json:"repo"`
+	Branch     string    `gorm:"not null;default:''" json:"branch"`
+	Runtime    string    `gorm:"not null;default:''" json:"runtime"`                  // 构建使用的运行环境，为空时使用系统 PATH
+	Version    string    `gorm:"not null;default:''" json:"version"`                  // 运行环境版本标识
+	Build      string    `gorm:"not null;default:''" json:"build"`                    // 构建命令，在新版本目录中执行
+	Shared     []string  `gorm:"not null;default:'[]';serializer:json" json:"shared"` // 跨版本共享的相对路径，如 .env、storage
+	Keep       uint      `gorm:"not null;default:5" json:"keep"`                      // 保留的版本数
+	User       string    `gorm:"not null;default:''" json:"user"`                     // 构建与文件属主用户，为空时为目标的运行用户
+	PrivateKey string    `gorm:"not null;default:''" json:"-"`
+	PublicKey  string    `gorm:"not null;default:''" json:"public_key"` // 部署公钥，添加到仓库的 Deploy Keys
+	WebHookID  uint      `gorm:"not null;default:0" json:"webhook_id"`
+	ReleaseID  uint      `gorm:"not null;default:0" json:"release_id"` // 当前版本
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	WebHookKey string `gorm:"-:all" json:"webhook_key"` // 仅显示
+}
+
+func (r *Deployment) BeforeSave(tx *gorm.DB) error {
+	crypter, err := crypt.NewXChacha20Poly1305([]byte(app.Key))
+	if err != nil {
+		return err
+	}
+
+	r.PrivateKey, err = crypter.Encrypt([]byte(r.PrivateKey))
+	return err
+}
+
+func (r *Deployment) AfterSave(tx *gorm.DB) error {
+	return r.AfterFind(tx)
+}
+
+func (r *Deployment) AfterFind(tx *gorm.DB) error {
+	crypter, err := crypt.NewXChacha20Poly1305([]byte(app.Key))
+	if err != nil {
+		return err
+	}
+
+	key, err := crypter.Decrypt(r.PrivateKey)
+	if err == nil {
+		r.PrivateKey = string(key)
+	}
+
+	return nil
+}
+
+// DeploymentRelease 一次部署产生的版本
+type DeploymentRelease struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	DeploymentID uint      `gorm:"not null;default:0;index" json:"deployment_id"`
+	Name         string    `gorm:"not null;default:''" json:"name"` // 版本目录名，开始构建时生成
+	Commit       string    `gorm:"not null;default:''" json:"commit"`
+	Message      string    `gorm:"not null;default:''" json:"message"` // 提交说明
+	Trigger      string    `gorm:"not null;default:''" json:"trigger"`
+	Status       string    `gorm:"not null;default:'pending'" json:"status"`
+	TaskID       uint      `gorm:"not null;default:0" json:"task_id"`
+	Pruned       bool      `gorm:"not null;default:false" json:"pruned"` // 目录已超出保留数量被清理，无法回滚
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	Current bool `gorm:"-:all" json:"current"` // 仅显示
+}
+
+type DeploymentRepo interface {
+	Get(id uint) (*Deployment, error)
+	GetByTarget(target string, targetID uint) (*Deployment, error)
+	// TargetInfo 返回部署目标的名称、目录与运行用户
+	TargetInfo(target string, targetID uint) (string, string, string, error)
+	Create(deployment *Deployment) error
+	Save(deployment *Deployment) error
+	// Delete 删除部署配置与缓存的仓库，已发布的版本与软链接保留
+	Delete(deployment *Deployment) error
+	ListReleases(deploymentID, page, limit uint) ([]*DeploymentRelease, int64, error)
+	GetRelease(id uint) (*DeploymentRelease, error)
+	CreateRelease(release *DeploymentRelease) error
+	UpdateRelease(release *DeploymentRelease) error
+	// WaitingRelease 任务仍在排队的版本，没有时返回 nil
+	WaitingRelease(deploymentID uint) (*DeploymentRelease, error)
+	// Execute 拉取代码、构建并切换到新版本，输出写入 out
+	Execute(ctx context.Context, deployment *Deployment, release *DeploymentRelease, out io.Writer) error
+	// Activate 将软链接切换到已有版本并重启目标
+	Activate(deployment *Deployment, release *DeploymentRelease, out io.Writer) error
+}
+
+type DeploymentUsecase struct {
+	repo        DeploymentRepo
+	task        TaskRepo
+	webhook     WebHookRepo
+	environment EnvironmentRepo
+	log         *slog.Logger
+	t           *gotext.Locale
+}
+
+func NewDeploymentUsecase(t *gotext.Locale, log *slog.Logger, repo DeploymentRepo, task TaskRepo, webhook WebHookRepo, environment EnvironmentRepo) *DeploymentUsecase {
+	return &DeploymentUsecase{
+		repo:        repo,
+		task:        task,
+		webhook:     webhook,
+		environment: environment,
+		log:         log,
+		t:           t,
+	}
+}
+
+// GetByTarget 获取目标的部署配置，未配置时返回 nil
+func (uc *DeploymentUsecase) GetByTarget(ctx context.Context, target string, targetID uint) (*Deployment, error) {
+	if !InScope(ctx, target, targetID) {
+		return nil, errors.New(uc.t.Get("deployment not found"))
+	}
+	deployment, err := uc.repo.GetByTarget(target, targetID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return deployment, uc.fillWebHookKey(deployment)
+}
+
+func (uc *DeploymentUsecase) Get(ctx context.Context, id uint) (*Deployment, error) {
+	deployment, err := uc.repo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !InScope(ctx, deployment.Target, deployment.TargetID) {
+		return nil, errors.New(uc.t.Get("deployment not found"))
+	}
+
+	return deployment, uc.fillWebHookKey(deployment)
+}
+
+func (uc *DeploymentUsecase) Create(ctx context.Context, req *request.DeploymentCreate) (*Deployment, error) {
+	if !InScope(ctx, req.Target, req.TargetID) {
+		return nil, errors.New(uc.t.Get("deployment target not found"))
+	}
+	if _, err := uc.repo.GetByTarget(req.Target, req.TargetID); err == nil {
+		return nil, errors.New(uc.t.Get("deployment already exists"))
+	}
+	name, path, runUser, err := uc.repo.TargetInfo(req.Target, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" || path == "/" {
+		return nil, errors.New(uc.t.Get("deployment target has no directory"))
+	}
+	if err = uc.checkRepo(req.Repo); err != nil {
+		return nil, err
+	}
+	if err = uc.checkRuntime(req.Runtime, req.Version); err != nil {
+		return nil, err
+	}
+	user, err := uc.buildUser(ctx, req.User, runUser)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, publicKey, err := newDeployKey("acepanel-deploy-" + name)
+	if err != nil {
+		return nil, err
+	}
+	deployment := &Deployment{
+		Target:     req.Target,
+		TargetID:   req.TargetID,
+		Name:       name,
+		Path:       strings.TrimSuffix(path, "/"),
+		Repo:       req.Repo,
+		Branch:     req.Branch,
+		Runtime:    req.Runtime,
+		Version:    req.Version,
+		Build:      req.Build,
+		Shared:     normalizeShared(req.Shared),
+		Keep:       req.Keep,
+		User:       user,
+		PrivateKey: privateKey,
+		PublicKey:  publicKey,
+	}
+	if err = uc.repo.Create(deployment); err != nil {
+		return nil, err
+	}
+	if err = uc.syncWebHook(deployment, req.Webhook); err != nil {
+		return nil, err
+	}
+
+	// 记录日志
+	uc.log.Info("deployment created", slog.String("type", deployment.Target), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(deployment.ID)), slog.String("name", name), slog.String("repo", req.Repo))
+
+	return deployment, nil
+}
+
+func (uc *DeploymentUsecase) Update(ctx context.Context, req *request.DeploymentUpdate) error {
+	deployment, err := uc.Get(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	_, _, runUser, err := uc.repo.TargetInfo(deployment.Target, deployment.TargetID)
+	if err != nil {
+		return err
+	}
+	if err = uc.checkRepo(req.Repo); err != nil {
+		return err
+	}
+	if err = uc.checkRuntime(req.Runtime, req.Version); err != nil {
+		return err
+	}
+	user, err := uc.buildUser(ctx, req.User, runUser)
+	if err != nil {
+		return err
+	}
+
+	deployment.Repo = req.Repo
+	deployment.Branch = req.Branch
+	deployment.Runtime = req.Runtime
+	deployment.Version = req.Version
+	deployment.Build = req.Build
+	deployment.Shared = normalizeShared(req.Shared)
+	deployment.Keep = req.Keep
+	deployment.User = user
+	if err = uc.syncWebHook(deployment, req.Webhook); err != nil {
+		return err
+	}
+	if err = uc.repo.Save(deployment); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("deployment updated", slog.String("type", deployment.Target), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(deployment.ID)), slog.String("name", deployment.Name), slog.String("repo", req.Repo))
+
+	return nil
+}
+
+func (uc *DeploymentUsecase) Delete(ctx context.Context, id uint) error {
+	deployment, err := uc.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.syncWebHook(deployment, false); err != nil {
+		return err
+	}
+	if err = uc.repo.Delete(deployment); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("deployment deleted", slog.String("type", deployment.Target), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", deployment.Name))
+
+	return nil
+}
+
+// ResetKey 重新生成部署密钥，需同步更新仓库中的公钥
+func (uc *DeploymentUsecase) ResetKey(ctx context.Context, id uint) (*Deployment, error) {
+	deployment, err := uc.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if deployment.PrivateKey, deployment.PublicKey, err = newDeployKey("acepanel-deploy-" + deployment.Name); err != nil {
+		return nil, err
+	}
+	if err = uc.repo.Save(deployment); err != nil {
+		return nil, err
+	}
+
+	// 记录日志
+	uc.log.Info("deployment key reset", slog.String("type", deployment.Target), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", deployment.Name))
+
+	return deployment, nil
+}
+
+func (uc *DeploymentUsecase) ListReleases(ctx context.Context, id, page, limit uint) ([]*DeploymentRelease, int64, error) {
+	deployment, err := uc.Get(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	releases, total, err := uc.repo.ListReleases(id, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, release := range releases {
+		release.Current = release.ID == deployment.ReleaseID
+	}
+
+	return releases, total, nil
+}
+
+// Deploy 将一次部署加入任务队列，已有排队中的部署时直接返回该任务，届时会拉取最新提交
+func (uc *DeploymentUsecase) Deploy(ctx context.Context, id uint, trigger string) (*Task, error) {
+	deployment, err := uc.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	waiting, err := uc.repo.WaitingRelease(deployment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if waiting != nil {
+		return uc.task.Get(waiting.TaskID)
+	}
+
+	release := &DeploymentRelease{
+		DeploymentID: deployment.ID,
+		Trigger:      trigger,
+		Status:       DeploymentReleasePending,
+	}
+	if err = uc.repo.CreateRelease(release); err != nil {
+		return nil, err
+	}
+
+	task := &Task{
+		Key:      fmt.Sprintf("deploy:%d:%d", deployment.ID, release.ID),
+		Name:     uc.t.Get("Deploy %s", deployment.Name),
+		Status:   TaskStatusWaiting,
+		Resource: fmt.Sprintf("%s:%d", deployment.Target, deployment.TargetID), // 与同一目标的部署、重启依次执行
+		Shell:    fmt.Sprintf("export PATH=/bin:/sbin:/usr/bin:/usr/sbin:/usr/local/bin:/usr/local/sbin:$PATH\nacepanel deploy run -i %d", release.ID),
+	}
+	if err = uc.task.Push(task); err != nil {
+		return nil, err
+	}
+	if err = createTaskLog(uc.task, task); err != nil {
+		return nil, err
+	}
+	release.TaskID = task.ID
+	if err = uc.repo.UpdateRelease(release); err != nil {
+		return nil, err
+	}
+
+	// 记录日志
+	uc.log.Info("deployment triggered", slog.String("type", deployment.Target), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", deployment.Name), slog.String("trigger", trigger))
+
+	return task, nil
+}
+
+// Execute 执行排队的版本，由部署任务调用
+func (uc *DeploymentUsecase) Execute(ctx context.Context, releaseID uint, out io.Writer) error {
+	release, err := uc.repo.GetRelease(releaseID)
+	if err != nil {
+		return err
+	}
+	if release.Status != DeploymentReleasePending {
+		return errors.New(uc.t.Get("release %d has already been executed", releaseID))
+	}
+	deployment, err := uc.repo.Get(release.DeploymentID)
+	if err != nil {
+		return err
+	}
+	// 旧版本未指定用户的配置按目标的运行用户构建，不再以 root 执行
+	if deployment.User == "" {
+		_, _, runUser, err := uc.repo.TargetInfo(deployment.Target, deployment.TargetID)
+		if err != nil {
+			return err
+		}
+		deployment.User = defaultBuildUser(runUser)
+	}
+
+	return uc.repo.Execute(ctx, deployment, release, out)
+}
+
+// Rollback 切换回保留的历史版本，无需重新构建
+func (uc *DeploymentUsecase) Rollback(ctx context.Context, id, releaseID uint) error {
+	deployment, err := uc.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	release, err := uc.repo.GetRelease(releaseID)
+	if err != nil {
+		return err
+	}
+	if release.DeploymentID != deployment.ID || release.Status != DeploymentReleaseSuccess || release.Pruned {
+		return errors.New(uc.t.Get("release %d is not available for rollback", releaseID))
+	}
+	if release.ID == deployment.ReleaseID {
+		return errors.New(uc.t.Get("release %d is already the current release", releaseID))
+	}
+
+	var out strings.Builder
+	if err = uc.repo.Activate(deployment, release, &out); err != nil {
+		return fmt.Errorf("%w: %s", err, out.String())
+	}
+
+	// 记录日志
+	uc.log.Info("deployment rolled back", slog.String("type", deployment.Target), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", deployment.Name), slog.String("release", release.Name), slog.String("commit", release.Commit))
+
+	return nil
+}
+
+// syncWebHook 按需创建或删除触发部署的 WebHook，复用 WebHook 的密钥、启停与调用统计
+func (uc *DeploymentUsecase) syncWebHook(deployment *Deployment, enabled bool) error {
+	if enabled && deployment.WebHookID == 0 {
+		script := fmt.Sprintf("export PATH=/bin:/sbin:/usr/bin:/usr/sbin:/usr/local/bin:/usr/local/sbin:$PATH\nacepanel deploy trigger -i %d -t %s\n", deployment.ID, DeploymentTriggerWebhook)
+		webhook := &WebHook{
+			Name:   uc.t.Get("Deploy %s", deployment.Name),
+			Key:    str.Random(32),
+			Script: script,
+			User:   "root",
+			Status: true,
+		}
+		if err := uc.webhook.CreateWithScript(webhook, script); err != nil {
+			return err
+		}
+		deployment.WebHookID = webhook.ID
+		return uc.repo.Save(deployment)
+	}
+
+	if !enabled && deployment.WebHookID != 0 {
+		if webhook, err := uc.webhook.Get(deployment.WebHookID); err == nil {
+			_ = uc.webhook.RemoveScript(webhook.Key)
+			if err = uc.webhook.Delete(webhook.ID); err != nil {
+				return err
+			}
+		}
+		deployment.WebHookID = 0
+		deployment.WebHookKey = ""
+		return uc.repo.Save(deployment)
+	}
+
+	return nil
+}
+
+func (uc *DeploymentUsecase) fillWebHookKey(deployment *Deployment) error {
+	if deployment.WebHookID == 0 {
+		return nil
+	}
+	webhook, err := uc.webhook.Get(deployment.WebHookID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// WebHook 在工具箱中被删除
+		deployment.WebHookID = 0
+		return uc.repo.Save(deployment)
+	}
+	if err != nil {
+		return err
+	}
+	deployment.WebHookKey = webhook.Key
+
+	return nil
+}
+
+// buildUser 确定构建用户，默认为目标的运行用户，指定 root 或其他用户仅限管理员
+func (uc *DeploymentUsecase) buildUser(ctx context.Context, user, runUser string) (string, error) {
+	runUser = defaultBuildUser(runUser)
+	if user == "" || user == runUser {
+		return runUser, nil
+	}
+	if !Unscoped(ctx) {
+		return "", errors.New(uc.t.Get("only administrators can run builds as %s", user))
+	}
+
+	return user, nil
+}
+
+// checkRepo 仓库以 root 拉取，只接受远程地址，避免读取本机其他仓库或被当作 git 参数
+func (uc *DeploymentUsecase) checkRepo(repo string) error {
+	if u, err := url.Parse(repo); err == nil && (u.Scheme == "https" || u.Scheme == "ssh") && u.Host != "" && !strings.HasPrefix(u.Host, "-") {
+		return nil
+	}
+	if deploymentSCPRepo.MatchString(repo) {
+		return nil
+	}
+
+	return errors.New(uc.t.Get("repository must be an https://, ssh:// or user@host:path address"))
+}
+
+// checkRuntime 运行环境版本会拼入构建的 PATH，必须是已安装的版本
+func (uc *DeploymentUsecase) checkRuntime(runtime, version string) error {
+	if runtime == "" {
+		return nil
+	}
+	if strings.ContainsAny(version, `/\`) || version == "." || version == ".." || !uc.environment.IsInstalled(runtime, version) {
+		return errors.New(uc.t.Get("runtime %s %s is not installed", runtime, version))
+	}
+
+	return nil
+}
+
+// defaultBuildUser 目标以 root 运行或未指定用户时，构建降级为 www
+func defaultBuildUser(runUser string) string {
+	if runUser == "" || runUser == "root" {
+		return "www"
+	}
+	return runUser
+}
+
+// normalizeShared 清理共享路径，统一为版本目录内的相对路径并去重
+func normalizeShared(paths []string) []string {
+	shared := make([]string, 0, len(paths))
+	for _, path := range paths {
+		// 以根目录为基准清理，.. 无法越出版本目录
+		path = strings.TrimPrefix(filepath.Clean("/"+strings.TrimSpace(path)), "/")
+		if path == "" || slices.Contains(shared, path) {
+			continue
+		}
+		shared = append(shared, path)
+	}
+
+	return shared
+}
+
+// newDeployKey 生成 ed25519 部署密钥，返回 OpenSSH 格式的私钥与 authorized_keys 格式的公钥
+func newDeployKey(comment string) (string, string, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	block, err := ssh.MarshalPrivateKey(priv, comment)
+	if err != nil {
+		return "", "", err
+	}
+	publicKey, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(pem.EncodeToMemory(block)), strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))) + " " + comment, nil
+}
@@ -0,0 +1,34 @@
+package biz
+
+import (
+	"testing"
+
+	"github.com/leonelquinteros/gotext"
+)
+
+func TestDeploymentCheckRepo(t *testing.T) {
+	uc := &DeploymentUsecase{t: gotext.NewLocale("", "en")}
+
+	tests := []struct {
+		repo    string
+		wantErr bool
+	}{
+		{repo: "https://github.com/acepanel/panel.git"},
+		{repo: "ssh://git@github.com:22/acepanel/panel.git"},
+		{repo: "git@github.com:acepanel/panel.git"},
+		{repo: "--upload-pack=touch /tmp/pwned", wantErr: true},
+		{repo: "-x@host:path", wantErr: true},
+		{repo: "ssh://-oProxyCommand=id/repo", wantErr: true},
+		{repo: "file:///opt/ace/server/deployment/1/repo.git", wantErr: true},
+		{repo: "/opt/ace/server/deployment/1/repo.git", wantErr: true},
+		{repo: "http://example.com/repo.git", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			if err := uc.checkRepo(tt.repo); (err != nil) != tt.wantErr {
+				t.Fatalf("checkRepo(%q) error = %v, wantErr %v", tt.repo, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -159,6 +159,7 @@ func (uc *EnvironmentUsecase) do(typ, slug, action string) error {
 	task.Key = fmt.Sprintf("environment:%s:%s", typ, slug)
 	task.Name = name
 	task.Status = TaskStatusWaiting
+	task.Resource = TaskResourcePkg
 	task.Shell = cmd
 
 	return uc.task.Push(task)
@@ -0,0 +1,188 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/pkg/firewall"
+	"github.com/acepanel/panel/v3/pkg/geoip"
+)
+
+// FirewallGeoStrategyOnly 仅允许该地区访问端口，其余来源丢弃
+const FirewallGeoStrategyOnly = "only"
+
+// FirewallGeoRule 防火墙地区规则，按 IPDB 中的国家/地区编译为 IP 集合
+type FirewallGeoRule struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Country   string    `gorm:"not null;default:''" json:"country"`   // 国家/地区代码或名称，与 IPDB 中的记录匹配
+	PortStart uint      `gorm:"not null;default:0" json:"port_start"` // 为 0 表示所有端口
+	PortEnd   uint      `gorm:"not null;default:0" json:"port_end"`
+	Protocol  string    `gorm:"not null;default:''" json:"protocol"`
+	Strategy  string    `gorm:"not null;default:''" json:"strategy"`
+	Networks  uint      `gorm:"not null;default:0" json:"networks"` // 最近一次同步的网段数量
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type FirewallGeoRepo interface {
+	List() ([]*FirewallGeoRule, error)
+	Get(id uint) (*FirewallGeoRule, error)
+	Create(req *request.FirewallGeoRule) (*FirewallGeoRule, error)
+	Delete(id uint) error
+	UpdateNetworks(id uint, networks uint) error
+}
+
+// FirewallGeoUsecase 防火墙地区规则业务逻辑
+type FirewallGeoUsecase struct {
+	repo    FirewallGeoRepo
+	setting *SettingUsecase
+	t       *gotext.Locale
+	log     *slog.Logger
+}
+
+func NewFirewallGeoUsecase(settingUsecase *SettingUsecase, t *gotext.Locale, log *slog.Logger, firewallGeoRepo FirewallGeoRepo) *FirewallGeoUsecase {
+	return &FirewallGeoUsecase{
+		repo:    firewallGeoRepo,
+		setting: settingUsecase,
+		t:       t,
+		log:     log,
+	}
+}
+
+func (uc *FirewallGeoUsecase) List() ([]*FirewallGeoRule, error) {
+	return uc.repo.List()
+}
+
+func (uc *FirewallGeoUsecase) Create(ctx context.Context, req *request.FirewallGeoRule) (*FirewallGeoRule, error) {
+	if uc.setting.IPDBPath() == "" {
+		return nil, errors.New(uc.t.Get("geo rules require an IP database, please configure it in settings first"))
+	}
+	if req.Strategy == FirewallGeoStrategyOnly && req.PortStart == 0 {
+		return nil, errors.New(uc.t.Get("only allow mode requires a port"))
+	}
+
+	rule, err := uc.repo.Create(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.Sync(); err != nil {
+		_ = uc.repo.Delete(rule.ID)
+		_ = uc.Sync()
+		return nil, err
+	}
+
+	// 记录日志
+	uc.log.Info("firewall geo rule created", slog.String("type", OperationTypeFirewall), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(rule.ID)), slog.String("country", rule.Country), slog.String("strategy", rule.Strategy))
+
+	return uc.repo.Get(rule.ID)
+}
+
+func (uc *FirewallGeoUsecase) Delete(ctx context.Context, id uint) error {
+	rule, err := uc.repo.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = uc.repo.Delete(id); err != nil {
+		return err
+	}
+	if err = uc.Sync(); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("firewall geo rule deleted", slog.String("type", OperationTypeFirewall), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("country", rule.Country))
+
+	return nil
+}
+
+// Sync 从 IPDB 重新编译全部地区规则并应用到防火墙
+func (uc *FirewallGeoUsecase) Sync() error {
+	compiled, err := compileGeoRules(uc.repo, uc.setting.IPDBPath(), uc.t)
+	if err != nil {
+		return err
+	}
+
+	if err = firewall.NewFirewall().SyncGeoRules(compiled); err != nil {
+		return errors.New(uc.t.Get("failed to apply geo rules: %v", err))
+	}
+
+	// 记录本次同步使用的 IPDB，供定时任务判断是否需要刷新
+	return uc.setting.Set(SettingKeyFirewallGeoIPDB, uc.ipdbVersion())
+}
+
+// Refresh IPDB 文件变化后重新同步地区规则
+func (uc *FirewallGeoUsecase) Refresh() error {
+	rules, err := uc.repo.List()
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+
+	synced, _ := uc.setting.Get(SettingKeyFirewallGeoIPDB)
+	if version := uc.ipdbVersion(); version == "" || version == synced {
+		return nil
+	}
+
+	return uc.Sync()
+}
+
+// ipdbVersion 返回当前 IPDB 文件路径和修改时间，文件不可用时返回空
+func (uc *FirewallGeoUsecase) ipdbVersion() string {
+	path := uc.setting.IPDBPath()
+	if path == "" {
+		return ""
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s|%d", path, info.ModTime().Unix())
+}
+
+// compileGeoRules 从 IPDB 编译全部地区规则，并更新各规则最近一次同步的网段数量
+func compileGeoRules(repo FirewallGeoRepo, path string, t *gotext.Locale) ([]firewall.GeoRule, error) {
+	rules, err := repo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := make([]firewall.GeoRule, 0, len(rules))
+	if len(rules) == 0 {
+		return compiled, nil
+	}
+	if path == "" {
+		return nil, errors.New(t.Get("geo rules require an IP database, please configure it in settings first"))
+	}
+	db, err := geoip.NewGeoIP(path)
+	if err != nil {
+		return nil, errors.New(t.Get("failed to load IP database: %v", err))
+	}
+	defer func(db *geoip.GeoIP) { _ = db.Close() }(db)
+
+	for _, rule := range rules {
+		networks, err := db.Networks(rule.Country)
+		if err != nil {
+			return nil, err
+		}
+		if err = repo.UpdateNetworks(rule.ID, uint(len(networks))); err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, firewall.GeoRule{
+			ID:        rule.ID,
+			Networks:  networks,
+			PortStart: rule.PortStart,
+			PortEnd:   rule.PortEnd,
+			Protocol:  firewall.Protocol(rule.Protocol),
+			Strategy:  firewall.Strategy(rule.Strategy),
+			Only:      rule.Strategy == FirewallGeoStrategyOnly,
+		})
+	}
+
+	return compiled, nil
+}
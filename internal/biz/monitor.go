@@ -1,6 +1,7 @@
 package biz
 
 import (
+	"sync"
 	"time"
 
 	"github.com/spf13/cast"
@@ -9,24 +10,72 @@ import (
 	"github.com/acepanel/panel/v3/pkg/types"
 )
 
-type Monitor struct {
-	ID        uint              `gorm:"primaryKey" json:"id"`
-	Info      types.CurrentInfo `gorm:"not null;default:'{}';serializer:zstd" json:"info"`
-	CreatedAt time.Time         `gorm:"index:idx_monitors_created_at" json:"created_at"`
-	UpdatedAt time.Time         `json:"updated_at"`
+// 监控数据分辨率，单位秒，原始采样为 0
+const (
+	MonitorResolutionRaw  uint = 0
+	MonitorResolution5Min uint = 300
+	MonitorResolutionHour uint = 3600
+	MonitorResolutionDay  uint = 86400
+)
+
+// monitorRollups 逐级压缩链：原始 → 5 分钟 → 1 小时 → 1 天
+var monitorRollups = [][2]uint{
+	{MonitorResolutionRaw, MonitorResolution5Min},
+	{MonitorResolution5Min, MonitorResolutionHour},
+	{MonitorResolutionHour, MonitorResolutionDay},
+}
+
+// monitorRetention 各分辨率的最长保留天数，同时受监控保留天数限制，天级数据只受后者限制
+var monitorRetention = map[uint]int{
+	MonitorResolutionRaw:  3,
+	MonitorResolution5Min: 31,
+	MonitorResolutionHour: 400,
+}
+
+// monitorMaxPoints 单张图表的最大点数，超过时改用更粗的分辨率
+const monitorMaxPoints = 2500
+
+// MonitorSeries 监控指标序列，如 cpu、mem_used、net_rx:eth0
+type MonitorSeries struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `gorm:"not null;default:'';uniqueIndex" json:"name"`
+}
+
+// MonitorPoint 监控数据点，每个指标单独一行，聚合点记录桶内的平均、最小、最大值和样本数
+type MonitorPoint struct {
+	Resolution uint    `gorm:"primaryKey;autoIncrement:false" json:"resolution"`
+	Time       int64   `gorm:"primaryKey;autoIncrement:false" json:"time"` // Unix 秒，聚合点为桶起始时间
+	SeriesID   uint    `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	Avg        float64 `gorm:"not null;default:0" json:"avg"`
+	Min        float64 `gorm:"not null;default:0" json:"min"`
+	Max        float64 `gorm:"not null;default:0" json:"max"`
+	Count      uint    `gorm:"not null;default:1" json:"count"`
+	Series     string  `gorm:"->;-:migration" json:"series"` // 查询时关联 MonitorSeries.Name
+}
+
+// MonitorProcess 原始采样的 Top 进程快照，随原始数据一起过期
+type MonitorProcess struct {
+	Time      int64              `gorm:"primaryKey;autoIncrement:false" json:"time"`
+	Processes types.TopProcesses `gorm:"not null;default:'{}';serializer:zstd" json:"processes"`
 }
 
 type MonitorRepo interface {
-	Create(monitor *Monitor) error
-	ClearBefore(t time.Time) error
+	Insert(at time.Time, values map[string]float64, processes types.TopProcesses) error
+	Latest(resolution uint) (int64, error)
+	Rollup(from, to uint, start, end int64, offset int64) error
+	ClearBefore(resolution uint, t time.Time) error
 	Clear() error
-	List(start, end time.Time) ([]*Monitor, error)
+	List(resolution uint, start, end time.Time) ([]*MonitorPoint, error)
+	ListProcesses(start, end time.Time) ([]*MonitorProcess, error)
 	VacuumDB() error
 }
 
 type MonitorUsecase struct {
 	repo    MonitorRepo
 	setting SettingRepo
+
+	mu   sync.Mutex
+	prev *types.CurrentInfo // 上一次采样，用于计算网络和磁盘速率
 }
 
 func NewMonitorUsecase(repo MonitorRepo, setting SettingRepo) *MonitorUsecase {
@@ -75,21 +124,168 @@ func (uc *MonitorUsecase) UpdateSetting(setting *request.MonitorSetting) error {
 }
 
 func (uc *MonitorUsecase) Clear() error {
+	uc.mu.Lock()
+	uc.prev = nil
+	uc.mu.Unlock()
+
 	return uc.repo.Clear()
 }
 
-func (uc *MonitorUsecase) Create(monitor *Monitor) error {
-	return uc.repo.Create(monitor)
+// Record 记录一次采样，拆分为各指标的原始数据点
+func (uc *MonitorUsecase) Record(info types.CurrentInfo) error {
+	uc.mu.Lock()
+	values := MonitorValues(info, uc.prev)
+	uc.prev = &info
+	uc.mu.Unlock()
+
+	return uc.repo.Insert(info.Time, values, info.TopProcesses)
+}
+
+// Compact 将已结束的时间桶逐级压缩到更粗的分辨率
+// 每级从目标分辨率最新的桶之后开始，到当前未结束的桶之前为止
+func (uc *MonitorUsecase) Compact(now time.Time) error {
+	_, offset := now.Zone() // 天级桶按本地日期对齐
+	for _, rollup := range monitorRollups {
+		from, to := rollup[0], rollup[1]
+		latest, err := uc.repo.Latest(to)
+		if err != nil {
+			return err
+		}
+		start := int64(0)
+		if latest > 0 {
+			start = latest + int64(to)
+		}
+		end := monitorBucket(now.Unix(), to, int64(offset))
+		if start >= end {
+			continue
+		}
+		if err = uc.repo.Rollup(from, to, start, end, int64(offset)); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (uc *MonitorUsecase) ClearBefore(t time.Time) error {
-	return uc.repo.ClearBefore(t)
+// Cleanup 按各分辨率的保留天数清理过期数据
+func (uc *MonitorUsecase) Cleanup(days int, now time.Time) error {
+	for _, resolution := range []uint{MonitorResolutionRaw, MonitorResolution5Min, MonitorResolutionHour, MonitorResolutionDay} {
+		if err := uc.repo.ClearBefore(resolution, now.AddDate(0, 0, -monitorRetentionDays(resolution, days))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// List 查询时间范围内的数据点，自动选择分辨率
+func (uc *MonitorUsecase) List(start, end time.Time) (uint, []*MonitorPoint, error) {
+	days, _ := uc.setting.GetInt(SettingKeyMonitorDays, 30)
+	interval, _ := uc.setting.GetInt(SettingKeyMonitorInterval, 1)
+	resolution := MonitorPickResolution(start, end, time.Now(), days, max(interval, 1))
+
+	points, err := uc.repo.List(resolution, start, end)
+	return resolution, points, err
 }
 
-func (uc *MonitorUsecase) List(start, end time.Time) ([]*Monitor, error) {
-	return uc.repo.List(start, end)
+// ListProcesses 查询时间范围内的 Top 进程快照，仅原始分辨率有数据
+func (uc *MonitorUsecase) ListProcesses(start, end time.Time) ([]*MonitorProcess, error) {
+	return uc.repo.ListProcesses(start, end)
 }
 
 func (uc *MonitorUsecase) VacuumDB() error {
 	return uc.repo.VacuumDB()
 }
+
+// MonitorPickResolution 选择能覆盖起始时间且点数不超过上限的最细分辨率
+func MonitorPickResolution(start, end, now time.Time, days, interval int) uint {
+	span := end.Sub(start).Seconds()
+	for _, resolution := range []uint{MonitorResolutionRaw, MonitorResolution5Min, MonitorResolutionHour} {
+		step := float64(resolution)
+		if resolution == MonitorResolutionRaw {
+			step = float64(interval * 60)
+		}
+		if start.Before(now.AddDate(0, 0, -monitorRetentionDays(resolution, days))) {
+			continue
+		}
+		if span/step <= monitorMaxPoints {
+			return resolution
+		}
+	}
+
+	return MonitorResolutionDay
+}
+
+// MonitorValues 将一次采样拆分为各指标的值，网络和磁盘速率需要上一次采样
+func MonitorValues(info types.CurrentInfo, prev *types.CurrentInfo) map[string]float64 {
+	values := map[string]float64{
+		"cpu": info.Percent,
+	}
+	if info.Load != nil {
+		values["load1"] = info.Load.Load1
+		values["load5"] = info.Load.Load5
+		values["load15"] = info.Load.Load15
+	}
+	if info.Mem != nil {
+		values["mem_total"] = float64(info.Mem.Total)
+		values["mem_used"] = float64(info.Mem.Used)
+		values["mem_available"] = float64(info.Mem.Available)
+	}
+	if info.Swap != nil {
+		values["swap_total"] = float64(info.Swap.Total)
+		values["swap_used"] = float64(info.Swap.Used)
+		values["swap_free"] = float64(info.Swap.Free)
+	}
+
+	elapsed := 0.0
+	if prev != nil {
+		elapsed = info.Time.Sub(prev.Time).Seconds()
+	}
+	prevNet := make(map[string][2]uint64)
+	prevDisk := make(map[string][2]uint64)
+	if elapsed > 0 {
+		for _, item := range prev.Net {
+			prevNet[item.Name] = [2]uint64{item.BytesSent, item.BytesRecv}
+		}
+		for _, item := range prev.DiskIO {
+			prevDisk[item.Name] = [2]uint64{item.ReadBytes, item.WriteBytes}
+		}
+	}
+
+	for _, item := range info.Net {
+		if item.Name == "lo" {
+			continue
+		}
+		values["net_sent:"+item.Name] = float64(item.BytesSent)
+		values["net_recv:"+item.Name] = float64(item.BytesRecv)
+		// 计数器回绕或重启后归零时不记录速率
+		if p, ok := prevNet[item.Name]; ok && item.BytesSent >= p[0] && item.BytesRecv >= p[1] {
+			values["net_tx:"+item.Name] = float64(item.BytesSent-p[0]) / elapsed
+			values["net_rx:"+item.Name] = float64(item.BytesRecv-p[1]) / elapsed
+		}
+	}
+	for _, item := range info.DiskIO {
+		values["disk_read:"+item.Name] = float64(item.ReadBytes)
+		values["disk_write:"+item.Name] = float64(item.WriteBytes)
+		if p, ok := prevDisk[item.Name]; ok && item.ReadBytes >= p[0] && item.WriteBytes >= p[1] {
+			values["disk_read_speed:"+item.Name] = float64(item.ReadBytes-p[0]) / elapsed
+			values["disk_write_speed:"+item.Name] = float64(item.WriteBytes-p[1]) / elapsed
+		}
+	}
+
+	return values
+}
+
+// monitorRetentionDays 分辨率的实际保留天数
+func monitorRetentionDays(resolution uint, days int) int {
+	if limit, ok := monitorRetention[resolution]; ok {
+		return min(limit, days)
+	}
+	return days
+}
+
+// monitorBucket 计算时间所在桶的起始时间，offset 为时区偏移秒数
+func monitorBucket(t int64, resolution uint, offset int64) int64 {
+	res := int64(resolution)
+	return (t+offset)/res*res - offset
+}
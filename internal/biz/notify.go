@@ -25,7 +25,7 @@ import (
 type NotifyChannel struct {
 	ID        uint            `gorm:"primaryKey" json:"id"`
 	Name      string          `gorm:"not null;default:''" json:"name"`
-	Type      string          `gorm:"not null;default:''" json:"type"`   // smtp
+	Type      string          `gorm:"not null;default:''" json:"type"`   // smtp / webhook / telegram / dingtalk / feishu / wecom / slack / discord
 	Config    json.RawMessage `gorm:"not null;default:''" json:"config"` // 渠道配置，含凭据，落库前整体加密
 	Enabled   bool            `gorm:"not null;default:true" json:"enabled"`
 	CreatedAt time.Time       `json:"created_at"`
@@ -65,7 +65,7 @@ type NotifyEvent string
 
 const (
 	NotifyEventCertRenew     NotifyEvent = "cert_renew"     // 证书续签失败
-	NotifyEventBackup        NotifyEvent = "backup"         // 备份失败
+	NotifyEventBackup        NotifyEvent = "backup"         // 备份失败、备份恢复测试结果
 	NotifyEventTaskFailed    NotifyEvent = "task_failed"    // 后台任务失败
 	NotifyEventCronFailed    NotifyEvent = "cron_failed"    // 计划任务执行失败
 	NotifyEventWebsiteExpire NotifyEvent = "website_expire" // 网站到期关停
@@ -0,0 +1,302 @@
+package biz
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	lop "github.com/samber/lo/parallel"
+
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/pkg/types"
+	"github.com/acepanel/panel/v3/pkg/uptime"
+)
+
+const (
+	ProbeTypeHTTP = "http" // 目标为 URL
+	ProbeTypeTCP  = "tcp"  // 目标为 host:port
+	ProbeTypePing = "ping" // 目标为主机名或 IP
+)
+
+const (
+	ProbeStatusUp   = "up"
+	ProbeStatusDown = "down"
+)
+
+const (
+	// probeRecordDays 探测记录保留天数
+	probeRecordDays = 30
+	// probeMaxPoints 响应时间图表的最大点数，超过时按更大的时间桶聚合
+	probeMaxPoints = 720
+)
+
+// Probe 可用性探测，按各自间隔检查网站或端口是否可访问
+type Probe struct {
+	ID       uint              `gorm:"primaryKey" json:"id"`
+	Name     string            `gorm:"not null;default:''" json:"name"`
+	Type     string            `gorm:"not null;default:''" json:"type"`
+	Target   string            `gorm:"not null;default:''" json:"target"`
+	Interval uint              `gorm:"not null;default:1" json:"interval"` // 探测间隔（分钟）
+	Timeout  uint              `gorm:"not null;default:10" json:"timeout"` // 超时（秒）
+	Config   types.ProbeConfig `gorm:"not null;default:'{}';serializer:json" json:"config"`
+	Enabled  bool              `gorm:"not null;default:true" json:"enabled"`
+	// 最近一次探测结果
+	Status    string     `gorm:"not null;default:''" json:"status"`
+	Latency   float64    `gorm:"not null;default:0" json:"latency"` // 毫秒
+	Message   string     `gorm:"not null;default:''" json:"message"`
+	CheckedAt *time.Time `json:"checked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	Uptime float64 `gorm:"-" json:"uptime"` // 最近 24 小时可用率 %，列表查询时填充
+}
+
+// ProbeRecord 单次探测记录
+type ProbeRecord struct {
+	ID         uint    `gorm:"primaryKey" json:"id"`
+	ProbeID    uint    `gorm:"not null;default:0;index:idx_probe_records_probe_time" json:"probe_id"`
+	Time       int64   `gorm:"not null;default:0;index:idx_probe_records_probe_time;index" json:"time"` // Unix 秒
+	Success    bool    `gorm:"not null;default:false" json:"success"`
+	Latency    float64 `gorm:"not null;default:0" json:"latency"` // 毫秒
+	StatusCode int     `gorm:"not null;default:0" json:"status_code"`
+	Message    string  `gorm:"not null;default:''" json:"message"`
+}
+
+// ProbePoint 按时间桶聚合的探测记录，响应时间只统计成功的探测
+type ProbePoint struct {
+	Time  int64   `json:"time"` // 桶起始时间，Unix 秒
+	Avg   float64 `json:"avg"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Up    int64   `json:"up"`
+	Total int64   `json:"total"`
+}
+
+// ProbeUptime 时间范围内的可用率统计
+type ProbeUptime struct {
+	Up      int64   `json:"up"`
+	Total   int64   `json:"total"`
+	Uptime  float64 `json:"uptime"`  // %，无记录时为 0
+	Latency float64 `json:"latency"` // 成功探测的平均响应时间，毫秒
+}
+
+// ProbeStats 探测统计，包含各时间窗口的可用率与响应时间序列
+type ProbeStats struct {
+	Uptime24h ProbeUptime   `json:"uptime_24h"`
+	Uptime7d  ProbeUptime   `json:"uptime_7d"`
+	Uptime30d ProbeUptime   `json:"uptime_30d"`
+	Range     ProbeUptime   `json:"range"`  // 查询范围内
+	Bucket    int64         `json:"bucket"` // 序列的时间桶，秒
+	Points    []*ProbePoint `json:"points"`
+}
+
+type ProbeRepo interface {
+	List(page, limit uint) ([]*Probe, int64, error)
+	Get(id uint) (*Probe, error)
+	Create(req *request.ProbeCreate) (*Probe, error)
+	Update(req *request.ProbeUpdate) error
+	Delete(id uint) error
+	Save(probe *Probe) error
+	// Due 返回已到探测时间的启用探测
+	Due(now time.Time) ([]*Probe, error)
+	// Check 执行一次探测
+	Check(ctx context.Context, probe *Probe) uptime.Result
+	AddRecord(record *ProbeRecord) error
+	ListRecords(probeID uint, page, limit uint) ([]*ProbeRecord, int64, error)
+	// Uptime 统计 [start, end) 内的可用率，时间为 Unix 秒
+	Uptime(probeID uint, start, end int64) (ProbeUptime, error)
+	// Series 按 bucket 秒聚合 [start, end) 内的记录
+	Series(probeID uint, start, end, bucket int64) ([]*ProbePoint, error)
+	ClearRecordsBefore(t int64) error
+}
+
+type ProbeUsecase struct {
+	repo ProbeRepo
+	t    *gotext.Locale
+	log  *slog.Logger
+
+	cleanedAt time.Time // 上次清理探测记录的时间
+}
+
+func NewProbeUsecase(t *gotext.Locale, log *slog.Logger, repo ProbeRepo) *ProbeUsecase {
+	return &ProbeUsecase{repo: repo, t: t, log: log}
+}
+
+func (uc *ProbeUsecase) List(page, limit uint) ([]*Probe, int64, error) {
+	probes, total, err := uc.repo.List(page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now().Unix()
+	for _, probe := range probes {
+		stat, err := uc.repo.Uptime(probe.ID, now-86400, now+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		probe.Uptime = stat.Uptime
+	}
+
+	return probes, total, nil
+}
+
+func (uc *ProbeUsecase) Get(id uint) (*Probe, error) {
+	return uc.repo.Get(id)
+}
+
+func (uc *ProbeUsecase) Create(ctx context.Context, req *request.ProbeCreate) (*Probe, error) {
+	probe, err := uc.repo.Create(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 记录日志
+	uc.log.Info("probe created", slog.String("type", OperationTypeMonitor), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(probe.ID)), slog.String("probe_type", req.Type), slog.String("target", req.Target))
+
+	// 创建后立即探测一次，便于直接看到结果
+	if probe.Enabled {
+		uc.check(ctx, probe)
+	}
+
+	return probe, nil
+}
+
+func (uc *ProbeUsecase) Update(ctx context.Context, req *request.ProbeUpdate) error {
+	if err := uc.repo.Update(req); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("probe updated", slog.String("type", OperationTypeMonitor), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(req.ID)))
+
+	return nil
+}
+
+func (uc *ProbeUsecase) Delete(ctx context.Context, id uint) error {
+	probe, err := uc.repo.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = uc.repo.Delete(id); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("probe deleted", slog.String("type", OperationTypeMonitor), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", probe.Name))
+
+	return nil
+}
+
+// Check 立即探测一次
+func (uc *ProbeUsecase) Check(ctx context.Context, id uint) (*Probe, error) {
+	probe, err := uc.repo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.check(ctx, probe)
+	return probe, nil
+}
+
+func (uc *ProbeUsecase) ListRecords(probeID uint, page, limit uint) ([]*ProbeRecord, int64, error) {
+	return uc.repo.ListRecords(probeID, page, limit)
+}
+
+// Stats 查询探测统计，start、end 为空时查询最近 24 小时
+func (uc *ProbeUsecase) Stats(probeID uint, start, end time.Time) (*ProbeStats, error) {
+	now := time.Now()
+	if end.IsZero() || end.After(now) {
+		end = now
+	}
+	if start.IsZero() || !start.Before(end) {
+		start = end.Add(-24 * time.Hour)
+	}
+
+	var err error
+	stats := new(ProbeStats)
+	to := now.Unix() + 1
+	if stats.Uptime24h, err = uc.repo.Uptime(probeID, to-86400, to); err != nil {
+		return nil, err
+	}
+	if stats.Uptime7d, err = uc.repo.Uptime(probeID, to-7*86400, to); err != nil {
+		return nil, err
+	}
+	if stats.Uptime30d, err = uc.repo.Uptime(probeID, to-30*86400, to); err != nil {
+		return nil, err
+	}
+	if stats.Range, err = uc.repo.Uptime(probeID, start.Unix(), end.Unix()+1); err != nil {
+		return nil, err
+	}
+
+	stats.Bucket = ProbeBucket(start, end)
+	if stats.Points, err = uc.repo.Series(probeID, start.Unix(), end.Unix()+1, stats.Bucket); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// Run 执行所有到期的探测，并清理过期的探测记录
+func (uc *ProbeUsecase) Run(ctx context.Context) error {
+	probes, err := uc.repo.Due(time.Now())
+	if err != nil {
+		return err
+	}
+
+	// 各探测互不影响，并发执行
+	lop.ForEach(probes, func(probe *Probe, _ int) {
+		uc.check(ctx, probe)
+	})
+
+	if time.Since(uc.cleanedAt) > 6*time.Hour {
+		uc.cleanedAt = time.Now()
+		if err = uc.repo.ClearRecordsBefore(time.Now().AddDate(0, 0, -probeRecordDays).Unix()); err != nil {
+			uc.log.Warn("failed to clear expired probe records", slog.Any("err", err))
+		}
+	}
+
+	return nil
+}
+
+// check 探测并记录结果，失败只记入记录不返回错误
+func (uc *ProbeUsecase) check(ctx context.Context, probe *Probe) {
+	result := uc.repo.Check(ctx, probe)
+	now := time.Now()
+
+	record := &ProbeRecord{
+		ProbeID:    probe.ID,
+		Time:       now.Unix(),
+		Success:    result.Success,
+		Latency:    float64(result.Latency.Microseconds()) / 1000,
+		StatusCode: result.StatusCode,
+		Message:    result.Message,
+	}
+
+	probe.Status = ProbeStatusUp
+	if !result.Success {
+		probe.Status = ProbeStatusDown
+	}
+	probe.Latency = record.Latency
+	probe.Message = record.Message
+	probe.CheckedAt = &now
+
+	if err := uc.repo.AddRecord(record); err != nil {
+		uc.log.Warn("failed to save probe record", slog.Uint64("id", uint64(probe.ID)), slog.Any("err", err))
+	}
+	if err := uc.repo.Save(probe); err != nil {
+		uc.log.Warn("failed to save probe", slog.Uint64("id", uint64(probe.ID)), slog.Any("err", err))
+	}
+}
+
+// ProbeBucket 选择能使点数不超过上限的最小时间桶，最小为 1 分钟
+func ProbeBucket(start, end time.Time) int64 {
+	span := int64(end.Sub(start).Seconds())
+	for _, bucket := range []int64{60, 300, 900, 3600, 21600} {
+		if span/bucket <= probeMaxPoints {
+			return bucket
+		}
+	}
+
+	return 86400
+}
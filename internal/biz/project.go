@@ -3,6 +3,8 @@ package biz
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"path/filepath"
 	"strings"
@@ -12,43 +14,65 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/pkg/systemctl"
 	"github.com/acepanel/panel/v3/pkg/types"
+	webtypes "github.com/acepanel/panel/v3/pkg/webserver/types"
 )
 
 type Project struct {
-	ID        uint              `gorm:"primaryKey" json:"id"`
-	Name      string            `gorm:"not null;unique" json:"name"`                  // 项目名称
-	Type      types.ProjectType `gorm:"not null;index;default:'general'" json:"type"` // 项目类型
-	Path      string            `gorm:"not null;default:''" json:"path"`              // 项目路径
-	CreatedAt time.Time         `json:"created_at"`
-	UpdatedAt time.Time         `json:"updated_at"`
+	ID        uint                   `gorm:"primaryKey" json:"id"`
+	Name      string                 `gorm:"not null;unique" json:"name"`                             // 项目名称
+	Type      types.ProjectType      `gorm:"not null;index;default:'general'" json:"type"`            // 项目类型
+	Path      string                 `gorm:"not null;default:''" json:"path"`                         // 项目路径
+	BlueGreen types.ProjectBlueGreen `gorm:"not null;default:'{}';serializer:json" json:"blue_green"` // 蓝绿重启配置
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// InstanceUnit 蓝绿实例的 systemd unit 名，由 {name}@.service 模板按端口实例化
+func (p *Project) InstanceUnit(port uint) string {
+	return fmt.Sprintf("%s@%d", p.Name, port)
+}
+
+// ServingUnit 当前提供服务的 systemd unit，未进行过蓝绿重启时为主 unit
+func (p *Project) ServingUnit() string {
+	if p.BlueGreen.Enabled && p.BlueGreen.ActivePort != 0 {
+		return p.InstanceUnit(p.BlueGreen.ActivePort)
+	}
+	return p.Name
 }
 
 type ProjectRepo interface {
 	Count() (int64, error)
-	List(typ types.ProjectType, page, limit uint) ([]*Project, int64, error)
+	List(ctx context.Context, typ types.ProjectType, page, limit uint) ([]*Project, int64, error)
 	GetEntity(id uint) (*Project, error)
 	ParseDetail(project *Project) (*types.ProjectDetail, error)
 	NameExists(name string) (bool, error)
 	Create(project *Project, req *request.ProjectCreate) error
 	Save(project *Project) error
 	Delete(project *Project) error
-	RenameUnitFile(old, new string) error
-	RemoveUnitFile(name string) error
+	RenameUnitFile(project *Project, name string) error
+	RemoveUnitFile(project *Project) error
 	UpdateUnitFile(name string, req *request.ProjectUpdate) error
+	// BlueGreenRestart 在另一端口启动新实例，健康检查通过后切换网站上游，等待旧实例处理完请求后停止
+	BlueGreenRestart(project *Project, out io.Writer) error
 }
 
 type ProjectUsecase struct {
-	repo ProjectRepo
-	log  *slog.Logger
-	t    *gotext.Locale
+	repo    ProjectRepo
+	task    TaskRepo
+	website WebsiteRepo
+	log     *slog.Logger
+	t       *gotext.Locale
 }
 
-func NewProjectUsecase(t *gotext.Locale, log *slog.Logger, projectRepo ProjectRepo) *ProjectUsecase {
+func NewProjectUsecase(t *gotext.Locale, log *slog.Logger, projectRepo ProjectRepo, taskRepo TaskRepo, websiteRepo WebsiteRepo) *ProjectUsecase {
 	return &ProjectUsecase{
-		repo: projectRepo,
-		log:  log,
-		t:    t,
+		repo:    projectRepo,
+		task:    taskRepo,
+		website: websiteRepo,
+		log:     log,
+		t:       t,
 	}
 }
 
@@ -56,8 +80,8 @@ func (uc *ProjectUsecase) Count() (int64, error) {
 	return uc.repo.Count()
 }
 
-func (uc *ProjectUsecase) List(typ types.ProjectType, page, limit uint) ([]*types.ProjectDetail, int64, error) {
-	projects, total, err := uc.repo.List(typ, page, limit)
+func (uc *ProjectUsecase) List(ctx context.Context, typ types.ProjectType, page, limit uint) ([]*types.ProjectDetail, int64, error) {
+	projects, total, err := uc.repo.List(ctx, typ, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -120,13 +144,20 @@ func (uc *ProjectUsecase) Update(ctx context.Context, req *request.ProjectUpdate
 
 	// 如果名称变更，需要重命名 unit 文件
 	if req.Name != project.Name {
-		if err := uc.repo.RenameUnitFile(project.Name, req.Name); err != nil {
+		// 蓝绿重启按名称操作实例，执行中改名会使其停止错误的 unit
+		if uc.task.HasRunningTask(fmt.Sprintf("%s:%d", ScopeProject, project.ID)) {
+			return errors.New(uc.t.Get("project %s has a running task, please wait for it to finish or cancel it first", project.Name))
+		}
+		if err := uc.repo.RenameUnitFile(project, req.Name); err != nil {
 			return err
 		}
 		project.Name = req.Name
 	}
 
 	project.Path = lo.If(!strings.HasPrefix(req.RootDir, "/"), filepath.Join("/", req.RootDir)).Else(req.RootDir)
+	if err := uc.applyBlueGreen(ctx, project, req.BlueGreen); err != nil {
+		return err
+	}
 	if err := uc.repo.Save(project); err != nil {
 		return err
 	}
@@ -143,9 +174,13 @@ func (uc *ProjectUsecase) Delete(ctx context.Context, id uint) error {
 	if err != nil {
 		return err
 	}
+	// 部署与蓝绿重启占用项目资源，执行中删除会留下孤立的实例
+	if uc.task.HasRunningTask(fmt.Sprintf("%s:%d", ScopeProject, project.ID)) {
+		return errors.New(uc.t.Get("project %s has a running task, please wait for it to finish or cancel it first", project.Name))
+	}
 
-	// 删除 systemd unit 文件
-	if err := uc.repo.RemoveUnitFile(project.Name); err != nil {
+	// 停止所有实例并删除 systemd unit 文件
+	if err := uc.repo.RemoveUnitFile(project); err != nil {
 		return err
 	}
 
@@ -158,3 +193,89 @@ func (uc *ProjectUsecase) Delete(ctx context.Context, id uint) error {
 
 	return nil
 }
+
+// Restart 将一次蓝绿重启加入任务队列
+func (uc *ProjectUsecase) Restart(ctx context.Context, id uint) (*Task, error) {
+	project, err := uc.repo.GetEntity(id)
+	if err != nil {
+		return nil, err
+	}
+	if !project.BlueGreen.Enabled {
+		return nil, errors.New(uc.t.Get("blue/green restart is not enabled for project %s", project.Name))
+	}
+
+	task := &Task{
+		Key:      fmt.Sprintf("project-restart:%d", project.ID),
+		Name:     uc.t.Get("Blue/green restart %s", project.Name),
+		Status:   TaskStatusWaiting,
+		Resource: fmt.Sprintf("%s:%d", ScopeProject, project.ID), // 与项目的部署依次执行
+		Shell:    fmt.Sprintf("export PATH=/bin:/sbin:/usr/bin:/usr/sbin:/usr/local/bin:/usr/local/sbin:$PATH\nacepanel project restart -i %d", project.ID),
+	}
+	if err = uc.task.Push(task); err != nil {
+		return nil, err
+	}
+	if err = createTaskLog(uc.task, task); err != nil {
+		return nil, err
+	}
+
+	// 记录日志
+	uc.log.Info("project restart queued", slog.String("type", OperationTypeProject), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", project.Name))
+
+	return task, nil
+}
+
+// RunRestart 执行蓝绿重启，由重启任务调用
+func (uc *ProjectUsecase) RunRestart(id uint, out io.Writer) error {
+	project, err := uc.repo.GetEntity(id)
+	if err != nil {
+		return err
+	}
+	if !project.BlueGreen.Enabled {
+		return errors.New(uc.t.Get("blue/green restart is not enabled for project %s", project.Name))
+	}
+
+	return uc.repo.BlueGreenRestart(project, out)
+}
+
+// applyBlueGreen 校验并应用蓝绿重启配置，当前提供服务的端口由重启流程维护，不接受客户端修改
+func (uc *ProjectUsecase) applyBlueGreen(ctx context.Context, project *Project, cfg types.ProjectBlueGreen) error {
+	cfg.ActivePort = project.BlueGreen.ActivePort
+
+	// 实例仍在运行时不能关闭蓝绿重启或改掉它的端口，否则实例将脱离面板管理
+	if cfg.ActivePort != 0 && (!cfg.Enabled || (cfg.ActivePort != cfg.BluePort && cfg.ActivePort != cfg.GreenPort)) {
+		if running, _ := systemctl.Status(project.InstanceUnit(cfg.ActivePort)); running {
+			return errors.New(uc.t.Get("instance %s is still running, stop it before disabling blue/green restart or changing its ports", project.InstanceUnit(cfg.ActivePort)))
+		}
+		cfg.ActivePort = 0
+	}
+	if !cfg.Enabled {
+		project.BlueGreen = cfg
+		return nil
+	}
+
+	if cfg.BluePort == 0 || cfg.BluePort > 65535 || cfg.GreenPort == 0 || cfg.GreenPort > 65535 || cfg.BluePort == cfg.GreenPort {
+		return errors.New(uc.t.Get("blue/green restart requires two different ports between 1 and 65535"))
+	}
+	if !strings.HasPrefix(cfg.HealthCheck, "/") {
+		return errors.New(uc.t.Get("health check path must start with /"))
+	}
+	if cfg.HealthTimeout == 0 {
+		return errors.New(uc.t.Get("health check timeout must be greater than 0"))
+	}
+	if !InScope(ctx, ScopeWebsite, cfg.WebsiteID) {
+		return errors.New(uc.t.Get("website not found"))
+	}
+	website, err := uc.website.Get(cfg.WebsiteID)
+	if err != nil {
+		return err
+	}
+	if website.Type != string(WebsiteTypeProxy) {
+		return errors.New(uc.t.Get("website %s is not a reverse proxy", website.Name))
+	}
+	if !lo.ContainsBy(website.Upstreams, func(upstream webtypes.Upstream) bool { return upstream.Name == cfg.Upstream }) {
+		return errors.New(uc.t.Get("upstream %s not found in website %s", cfg.Upstream, website.Name))
+	}
+
+	project.BlueGreen = cfg
+	return nil
+}
@@ -0,0 +1,372 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+
+	"github.com/acepanel/panel/v3/internal/request"
+)
+
+// RoleAccess 角色对某个权限标签的访问级别
+type RoleAccess string
+
+const (
+	RoleAccessNone  RoleAccess = "none"  // 禁止访问
+	RoleAccessRead  RoleAccess = "read"  // 只读，仅允许 GET 且无副作用的端点
+	RoleAccessWrite RoleAccess = "write" // 读写
+)
+
+// 内置角色，ID 由迁移固定写入
+const (
+	RoleIDAdmin    uint = 1
+	RoleIDOperator uint = 2
+	RoleIDReadonly uint = 3
+)
+
+// PermissionAll 通配权限标签，未单独配置的标签取此值
+const PermissionAll = "*"
+
+// PermissionTags 可分配的权限标签，与路由端点声明的 Tags 一一对应
+var PermissionTags = []string{
+	"首页", "网站", "网站统计", "项目", "部署", "数据库", "数据库服务器", "数据库用户", "Redis", "Elasticsearch",
+	"证书", "备份", "备份存储", "应用", "运行环境", "容器", "容器编排", "容器镜像", "容器网络", "容器存储卷",
+	"文件", "计划任务", "进程", "防火墙", "SSH", "系统服务", "终端", "工具箱", "防篡改", "任务", "日志",
+	"监控", "告警", "通知", "WebHook", "模板", "安全", "设置", "用户", "用户令牌", "通行密钥", "角色",
+}
+
+// RootEquivalentTags 写权限等同于以 root 执行任意命令，或可抹除审计痕迹的标签
+// 如计划任务与 WebHook 的脚本、部署的构建命令、项目的启动命令、特权容器与挂载
+var RootEquivalentTags = []string{
+	"文件", "SSH", "终端", "计划任务", "WebHook", "部署", "项目", "容器", "容器编排", "模板", "工具箱", "日志",
+}
+
+// Scope 用户可访问的资源范围类型
+const (
+	ScopeWebsite        = "website"
+	ScopeProject        = "project"
+	ScopeDatabaseServer = "database_server"
+)
+
+// ScopeKinds 支持按资源限定范围的类型
+var ScopeKinds = []string{ScopeWebsite, ScopeProject, ScopeDatabaseServer}
+
+type Role struct {
+	ID          uint                  `gorm:"primaryKey" json:"id"`
+	Name        string                `gorm:"not null;default:'';unique" json:"name"`
+	Description string                `gorm:"not null;default:''" json:"description"`
+	Builtin     bool                  `gorm:"not null;default:false" json:"builtin"` // 内置角色不可修改和删除
+	Permissions map[string]RoleAccess `gorm:"not null;default:'{}';serializer:json" json:"permissions"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+// IsAdmin 是否为管理员角色，管理员不受权限与范围限制
+func (r *Role) IsAdmin() bool {
+	return r != nil && r.ID == RoleIDAdmin
+}
+
+// Access 返回角色对标签的访问级别，单独配置优先于通配
+func (r *Role) Access(tag string) RoleAccess {
+	if r == nil {
+		return RoleAccessNone
+	}
+	if r.IsAdmin() {
+		return RoleAccessWrite
+	}
+
+	return accessOf(r.Permissions, tag)
+}
+
+// Allowed 判断角色能否访问带有给定标签的端点，多个标签需全部满足
+func (r *Role) Allowed(tags []string, write bool) bool {
+	if r == nil {
+		return false
+	}
+	if r.IsAdmin() {
+		return true
+	}
+
+	return allowed(r.Permissions, tags, write)
+}
+
+// Readable 返回角色至少可读的标签
+func (r *Role) Readable() []string {
+	tags := make([]string, 0, len(PermissionTags))
+	for _, tag := range PermissionTags {
+		if r.Access(tag) != RoleAccessNone {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// accessOf 返回权限表中标签的访问级别，单独配置优先于通配
+func accessOf(permissions map[string]RoleAccess, tag string) RoleAccess {
+	if access, ok := permissions[tag]; ok {
+		return access
+	}
+	if access, ok := permissions[PermissionAll]; ok {
+		return access
+	}
+
+	return RoleAccessNone
+}
+
+// allowed 判断权限表能否访问带有给定标签的端点，多个标签需全部满足
+func allowed(permissions map[string]RoleAccess, tags []string, write bool) bool {
+	if len(tags) == 0 {
+		return false
+	}
+
+	for _, tag := range tags {
+		switch accessOf(permissions, tag) {
+		case RoleAccessWrite:
+		case RoleAccessRead:
+			if write {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// parsePermissions 校验权限配置，只接受已知标签与访问级别
+func parsePermissions(t *gotext.Locale, raw map[string]string) (map[string]RoleAccess, error) {
+	permissions := make(map[string]RoleAccess, len(raw))
+	for tag, access := range raw {
+		if tag != PermissionAll && !slices.Contains(PermissionTags, tag) {
+			return nil, errors.New(t.Get("unknown permission: %s", tag))
+		}
+		switch RoleAccess(access) {
+		case RoleAccessNone, RoleAccessRead, RoleAccessWrite:
+			permissions[tag] = RoleAccess(access)
+		default:
+			return nil, errors.New(t.Get("invalid access level for %s: %s", tag, access))
+		}
+	}
+
+	return permissions, nil
+}
+
+// BuiltinRoles 内置角色定义，迁移时写入
+func BuiltinRoles() []*Role {
+	return []*Role{
+		{
+			ID:          RoleIDAdmin,
+			Name:        "admin",
+			Description: "Full access to everything",
+			Builtin:     true,
+			Permissions: map[string]RoleAccess{PermissionAll: RoleAccessWrite},
+		},
+		{
+			ID:          RoleIDOperator,
+			Name:        "operator",
+			Description: "Manage websites, databases, apps and services, but not files, shells, scripts, containers, users or panel settings",
+			Builtin:     true,
+			// 可执行任意命令的模块等同于 root 权限，运维角色只能查看，文件、终端与 SSH 不开放
+			Permissions: map[string]RoleAccess{
+				PermissionAll: RoleAccessWrite,
+				"文件":          RoleAccessNone,
+				"SSH":         RoleAccessNone,
+				"终端":          RoleAccessNone,
+				"计划任务":        RoleAccessRead,
+				"WebHook":     RoleAccessRead,
+				"部署":          RoleAccessRead,
+				"项目":          RoleAccessRead,
+				"容器":          RoleAccessRead,
+				"容器编排":        RoleAccessRead,
+				"模板":          RoleAccessRead,
+				"工具箱":         RoleAccessRead,
+				"日志":          RoleAccessRead,
+				"设置":          RoleAccessRead,
+				"安全":          RoleAccessRead,
+				"用户":          RoleAccessNone,
+				"用户令牌":        RoleAccessNone,
+				"通行密钥":        RoleAccessNone,
+				"角色":          RoleAccessNone,
+			},
+		},
+		{
+			ID:          RoleIDReadonly,
+			Name:        "readonly",
+			Description: "View status and configuration without making changes",
+			Builtin:     true,
+			Permissions: map[string]RoleAccess{
+				PermissionAll: RoleAccessRead,
+				"文件":          RoleAccessNone,
+				"SSH":         RoleAccessNone,
+				"终端":          RoleAccessNone,
+				"设置":          RoleAccessNone,
+				"用户":          RoleAccessNone,
+				"用户令牌":        RoleAccessNone,
+				"通行密钥":        RoleAccessNone,
+				"角色":          RoleAccessNone,
+			},
+		},
+	}
+}
+
+// userScopesKey 当前用户资源范围在 context 中的键
+type userScopesKey struct{}
+
+// WithScopes 将用户资源范围写入 context，供数据层过滤列表
+func WithScopes(ctx context.Context, scopes map[string][]uint) context.Context {
+	return context.WithValue(ctx, userScopesKey{}, scopes)
+}
+
+// ScopeIDs 返回 context 中用户对某类资源的可访问 ID，restricted 为 false 表示不受限
+func ScopeIDs(ctx context.Context, kind string) (ids []uint, restricted bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	scopes, ok := ctx.Value(userScopesKey{}).(map[string][]uint)
+	if !ok {
+		return nil, false
+	}
+	ids, restricted = scopes[kind]
+
+	return ids, restricted
+}
+
+// restrictedTokenKey 请求来自受限 API 令牌的标记在 context 中的键
+type restrictedTokenKey struct{}
+
+// WithRestrictedToken 标记请求来自限定了访问范围的 API 令牌
+func WithRestrictedToken(ctx context.Context) context.Context {
+	return context.WithValue(ctx, restrictedTokenKey{}, true)
+}
+
+// Unscoped 判断 context 中的用户是否不受角色与资源范围限制，管理员和后台任务均不受限
+// 管理员的受限令牌仍视为受限，不能借此执行仅限管理员的操作
+func Unscoped(ctx context.Context) bool {
+	if ctx == nil {
+		return true
+	}
+	if restricted, _ := ctx.Value(restrictedTokenKey{}).(bool); restricted {
+		return false
+	}
+	_, ok := ctx.Value(userScopesKey{}).(map[string][]uint)
+	return !ok
+}
+
+// InScope 判断资源 ID 是否在 context 中用户的可访问范围内
+func InScope(ctx context.Context, kind string, id uint) bool {
+	ids, restricted := ScopeIDs(ctx, kind)
+	return !restricted || slices.Contains(ids, id)
+}
+
+type RoleRepo interface {
+	List(page, limit uint) ([]*Role, int64, error)
+	All() ([]*Role, error)
+	Get(id uint) (*Role, error)
+	Create(role *Role) error
+	Update(role *Role) error
+	Delete(id uint) error
+	UserCount(id uint) (int64, error)
+}
+
+type RoleUsecase struct {
+	repo RoleRepo
+	log  *slog.Logger
+	t    *gotext.Locale
+}
+
+func NewRoleUsecase(t *gotext.Locale, log *slog.Logger, roleRepo RoleRepo) *RoleUsecase {
+	return &RoleUsecase{
+		repo: roleRepo,
+		log:  log,
+		t:    t,
+	}
+}
+
+func (uc *RoleUsecase) List(page, limit uint) ([]*Role, int64, error) {
+	return uc.repo.List(page, limit)
+}
+
+func (uc *RoleUsecase) All() ([]*Role, error) {
+	return uc.repo.All()
+}
+
+func (uc *RoleUsecase) Get(id uint) (*Role, error) {
+	return uc.repo.Get(id)
+}
+
+func (uc *RoleUsecase) Create(ctx context.Context, req *request.RoleCreate) (*Role, error) {
+	permissions, err := parsePermissions(uc.t, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: permissions,
+	}
+	if err = uc.repo.Create(role); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("role created", slog.String("type", OperationTypeUser), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(role.ID)), slog.String("name", role.Name))
+
+	return role, nil
+}
+
+func (uc *RoleUsecase) Update(ctx context.Context, req *request.RoleUpdate) error {
+	role, err := uc.repo.Get(req.ID)
+	if err != nil {
+		return err
+	}
+	if role.Builtin {
+		return errors.New(uc.t.Get("built-in roles cannot be modified"))
+	}
+
+	permissions, err := parsePermissions(uc.t, req.Permissions)
+	if err != nil {
+		return err
+	}
+
+	role.Name = req.Name
+	role.Description = req.Description
+	role.Permissions = permissions
+	if err = uc.repo.Update(role); err != nil {
+		return err
+	}
+
+	uc.log.Info("role updated", slog.String("type", OperationTypeUser), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(role.ID)), slog.String("name", role.Name))
+
+	return nil
+}
+
+func (uc *RoleUsecase) Delete(ctx context.Context, id uint) error {
+	role, err := uc.repo.Get(id)
+	if err != nil {
+		return err
+	}
+	if role.Builtin {
+		return errors.New(uc.t.Get("built-in roles cannot be deleted"))
+	}
+	count, err := uc.repo.UserCount(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New(uc.t.Get("role is still assigned to %d users", count))
+	}
+
+	if err = uc.repo.Delete(id); err != nil {
+		return err
+	}
+
+	uc.log.Info("role deleted", slog.String("type", OperationTypeUser), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("name", role.Name))
+
+	return nil
+}
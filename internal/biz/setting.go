@@ -64,14 +64,27 @@ const (
 	SettingKeyIPDBType                  SettingKey = "ipdb_type" // "" / "custom" / "subscribe"
 	SettingKeyIPDBURL                   SettingKey = "ipdb_url"  // 订阅链接
 	SettingKeyIPDBPath                  SettingKey = "ipdb_path"
-	SettingKeyInfoRan                   SettingKey = "info_ran" // info 命令是否已运行过
+	SettingKeyFirewallGeoIPDB           SettingKey = "firewall_geo_ipdb" // 地区规则最近一次同步使用的 IPDB
+	SettingKeyInfoRan                   SettingKey = "info_ran"          // info 命令是否已运行过
 	SettingKeyTamperEnabled             SettingKey = "tamper_enabled"
-	SettingKeyTamperMode                SettingKey = "tamper_mode"           // chattr / ebpf
-	SettingKeyTamperBlockNew            SettingKey = "tamper_block_new"      // 新建受保护类型文件时删除拦截
-	SettingKeyTamperLogDays             SettingKey = "tamper_log_days"       // 拦截日志保留天数
-	SettingKeyNotifyEvents              SettingKey = "notify_event_types"    // 订阅的系统事件类型，JSON 数组
-	SettingKeyNotifyEventChannels       SettingKey = "notify_event_channels" // 接收系统事件的渠道 ID，JSON 数组
-	SettingKeyAlertLogDays              SettingKey = "alert_log_days"        // 告警记录保留天数
+	SettingKeyTamperMode                SettingKey = "tamper_mode"               // chattr / ebpf
+	SettingKeyTamperBlockNew            SettingKey = "tamper_block_new"          // 新建受保护类型文件时删除拦截
+	SettingKeyTamperLogDays             SettingKey = "tamper_log_days"           // 拦截日志保留天数
+	SettingKeyNotifyEvents              SettingKey = "notify_event_types"        // 订阅的系统事件类型，JSON 数组
+	SettingKeyNotifyEventChannels       SettingKey = "notify_event_channels"     // 接收系统事件的渠道 ID，JSON 数组
+	SettingKeyAlertLogDays              SettingKey = "alert_log_days"            // 告警记录保留天数
+	SettingKeyMetricsToken              SettingKey = "metrics_token"             // 指标接口 Bearer 令牌，为空表示未启用
+	SettingKeyTerminalRecord            SettingKey = "terminal_record"           // 是否录制终端会话
+	SettingKeyTerminalRecordInput       SettingKey = "terminal_record_input"     // 是否录制终端输入
+	SettingKeyTerminalRecordMandatory   SettingKey = "terminal_record_mandatory" // 强制录制，无法录制时拒绝打开终端
+	SettingKeyTerminalRecordDays        SettingKey = "terminal_record_days"      // 终端录像保留天数
+	SettingKeyAuditForward              SettingKey = "audit_forward"             // 审计日志转发配置（JSON）
+	SettingKeyAuditAnchor               SettingKey = "audit_anchor"              // 审计链尾序号与哈希，格式 seq:hash
+	SettingKeyAuditStart                SettingKey = "audit_start"               // 清理后审计链首的序号与哈希，格式 seq:hash
+	SettingKeyAuditMonths               SettingKey = "audit_months"              // 审计日志保留月数，0 表示永久保留
+	SettingKeyCronRunDays               SettingKey = "cron_run_days"             // 计划任务执行记录保留天数
+	SettingKeyCronRunKeep               SettingKey = "cron_run_keep"             // 每个计划任务保留的执行记录数
+	SettingKeyTaskWorkers               SettingKey = "task_workers"              // 后台任务同时执行数
 )
 
 type Setting struct {
@@ -96,14 +109,16 @@ type SettingRepo interface {
 type SettingUsecase struct {
 	repo SettingRepo
 	task TaskRepo
+	geo  FirewallGeoRepo
 	t    *gotext.Locale
 	log  *slog.Logger
 }
 
-func NewSettingUsecase(t *gotext.Locale, log *slog.Logger, settingRepo SettingRepo, taskRepo TaskRepo) *SettingUsecase {
+func NewSettingUsecase(t *gotext.Locale, log *slog.Logger, settingRepo SettingRepo, taskRepo TaskRepo, firewallGeoRepo FirewallGeoRepo) *SettingUsecase {
 	return &SettingUsecase{
 		repo: settingRepo,
 		task: taskRepo,
+		geo:  firewallGeoRepo,
 		t:    t,
 		log:  log,
 	}
@@ -137,6 +152,20 @@ func (uc *SettingUsecase) Delete(key SettingKey) error {
 	return uc.repo.Delete(key)
 }
 
+// IPDBPath 根据设置返回 IPDB 文件路径，未启用时返回空
+func (uc *SettingUsecase) IPDBPath() string {
+	ipdbType, _ := uc.repo.Get(SettingKeyIPDBType)
+	switch ipdbType {
+	case "subscribe":
+		return filepath.Join(app.Root, "panel/storage/geo.ipdb")
+	case "custom":
+		path, _ := uc.repo.Get(SettingKeyIPDBPath)
+		return path
+	default:
+		return ""
+	}
+}
+
 func (uc *SettingUsecase) GetPanel() (*request.SettingPanel, error) {
 	return uc.repo.GetPanel()
 }
@@ -257,28 +286,20 @@ func (uc *SettingUsecase) UpdatePanel(ctx context.Context, req *request.SettingP
 		return false, err
 	}
 
-	if req.Port != conf.HTTP.Port {
-		if os.TCPPortInUse(req.Port) {
-			return false, errors.New(uc.t.Get("port is already in use"))
-		}
-		// 放行端口
-		fw := firewall.NewFirewall()
-		if ok, _ := fw.Status(); ok {
-			err = fw.Port(firewall.FireInfo{
-				Type:      firewall.TypeNormal,
-				PortStart: req.Port,
-				PortEnd:   req.Port,
-				Protocol:  firewall.ProtocolTCPUDP,
-				Strategy:  firewall.StrategyAccept,
-				Direction: firewall.DirectionIn,
-			}, firewall.OperationAdd)
-			if err != nil {
-				return false, err
-			}
+	if req.Port != conf.HTTP.Port && os.TCPPortInUse(req.Port) {
+		return false, errors.New(uc.t.Get("port is already in use"))
+	}
+	// 地区规则的 IP 集合需在新后端重建，先编译好再切换
+	var geo []firewall.GeoRule
+	if req.Firewall != conf.App.Firewall {
+		if geo, err = compileGeoRules(uc.geo, uc.IPDBPath(), uc.t); err != nil {
+			return false, err
 		}
 	}
 
+	oldPort, oldFirewall := conf.HTTP.Port, conf.App.Firewall
 	conf.App.Locale = req.Locale
+	conf.App.Firewall = req.Firewall
 	conf.HTTP.Port = req.Port
 	conf.HTTP.Entrance = req.Entrance
 	conf.HTTP.EntranceError = req.EntranceError
@@ -297,7 +318,38 @@ func (uc *SettingUsecase) UpdatePanel(ctx context.Context, req *request.SettingP
 		}
 		restartFlag = true
 	}
+
+	if req.Port != oldPort {
+		// 放行端口
+		fw := firewall.NewFirewall()
+		if ok, _ := fw.Status(); ok {
+			err = fw.Port(firewall.FireInfo{
+				Type:      firewall.TypeNormal,
+				PortStart: req.Port,
+				PortEnd:   req.Port,
+				Protocol:  firewall.ProtocolTCPUDP,
+				Strategy:  firewall.StrategyAccept,
+				Direction: firewall.DirectionIn,
+			}, firewall.OperationAdd)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	// 切换防火墙后端，原有规则与地区规则导入新后端并放行面板端口，原后端随之停用
+	if req.Firewall != oldFirewall {
+		if err = firewall.Switch(firewall.Backend(req.Firewall), geo, req.Port); err != nil {
+			return false, errors.New(uc.t.Get("failed to switch firewall backend: %v", err))
+		}
+	}
 	if err = config.Save(conf); err != nil {
+		// 配置未保存，重启后仍会使用原后端，切回原后端保持一致
+		if req.Firewall != oldFirewall {
+			if switchErr := firewall.Switch(firewall.Backend(oldFirewall), geo, oldPort); switchErr != nil {
+				uc.log.Warn("failed to restore previous firewall backend", slog.String("firewall", oldFirewall), slog.Any("err", switchErr))
+			}
+		}
 		return false, err
 	}
 
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/leonelquinteros/gotext"
@@ -76,6 +77,7 @@ type TamperUsecase struct {
 	bufMu     sync.Mutex
 	notifyAt  time.Time
 	drainC    chan struct{}
+	blocked   atomic.Uint64 // 启动以来的拦截事件数
 }
 
 func NewTamperUsecase(notifyUsecase *NotifyUsecase, settingUsecase *SettingUsecase, t *gotext.Locale, log *slog.Logger, tamperRepo TamperRepo) *TamperUsecase {
@@ -260,6 +262,7 @@ func (uc *TamperUsecase) drain(mgr *tamper.Manager, done chan struct{}) {
 			if !ok {
 				return
 			}
+			uc.blocked.Add(1)
 			uc.bufMu.Lock()
 			uc.buf = append(uc.buf, &TamperLog{
 				Path:      ev.Path,
@@ -347,6 +350,11 @@ func (uc *TamperUsecase) Stats() tamper.Stats {
 	return uc.mgr.Stats()
 }
 
+// Blocked 面板启动以来的拦截事件数
+func (uc *TamperUsecase) Blocked() uint64 {
+	return uc.blocked.Load()
+}
+
 // enabledRules 取启用规则并转为 tamper.Rule
 func (uc *TamperUsecase) enabledRules() ([]*TamperRule, []tamper.Rule, error) {
 	rules, err := uc.repo.ListRules()
@@ -1,6 +1,17 @@
 package biz
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/v3/internal/app"
+)
 
 type TaskStatus string
 
@@ -12,39 +23,97 @@ const (
 	TaskStatusCanceled TaskStatus = "canceled"
 )
 
+const (
+	TaskPriorityLow    = -10
+	TaskPriorityNormal = 0
+	TaskPriorityHigh   = 10
+)
+
+// TaskWorkersDefault 默认同时执行的任务数
+const TaskWorkersDefault = 2
+
+// TaskResourcePkg 应用与运行环境的安装、卸载、更新共用的资源，避免包管理器与编译并发
+const TaskResourcePkg = "pkg"
+
 type Task struct {
 	ID          uint       `gorm:"primaryKey" json:"id"`
 	Key         string     `gorm:"not null;default:'';index" json:"-"` // 任务标识（域:动作:标的），用于防重
 	Name        string     `gorm:"not null;default:'';index" json:"name"`
 	Status      TaskStatus `gorm:"not null;default:'waiting'" json:"status"`
 	Shell       string     `gorm:"not null;default:''" json:"-"`
-	CancelShell string     `gorm:"not null;default:''" json:"-"` // 运行中被取消后执行的清理命令（可选）
+	CancelShell string     `gorm:"not null;default:''" json:"-"`          // 运行中被取消后执行的清理命令（可选）
+	Resource    string     `gorm:"not null;default:''" json:"resource"`   // 占用的资源，相同资源的任务串行执行，为空的任务之间也串行
+	Priority    int        `gorm:"not null;default:0" json:"priority"`    // 优先级，越大越先执行
+	Timeout     uint       `gorm:"not null;default:0" json:"timeout"`     // 单次执行超时时间（秒），0 为不限制
+	MaxRetries  uint       `gorm:"not null;default:0" json:"max_retries"` // 失败后最多重试次数
+	Attempts    uint       `gorm:"not null;default:0" json:"attempts"`    // 已重试次数
+	RetryAt     *time.Time `json:"retry_at"`                              // 重试退避期间此前不执行
 	Log         string     `gorm:"not null;default:''" json:"log"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
+// TaskLogPath 任务的输出日志路径
+func TaskLogPath(id uint) string {
+	return filepath.Join(app.Root, "panel/storage/logs/task", fmt.Sprintf("%d.log", id))
+}
+
+// createTaskLog 先建好任务的空日志，任务排队期间前端也能开始跟踪
+func createTaskLog(repo TaskRepo, task *Task) error {
+	task.Log = TaskLogPath(task.ID)
+	if err := os.MkdirAll(filepath.Dir(task.Log), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(task.Log, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	_ = f.Close()
+
+	return repo.UpdateLog(task.ID, task.Log)
+}
+
 type TaskRepo interface {
-	HasRunningTask() bool
+	// HasRunningTask 是否有运行中或即将执行的任务，重试退避中的不计入；resources 不为空时只看占用这些资源的任务
+	HasRunningTask(resources ...string) bool
+	CountByStatus() (map[TaskStatus]int64, error)
 	List(page, limit uint) ([]*Task, int64, error)
 	Get(id uint) (*Task, error)
 	Delete(id uint) error
 	Cancel(id uint) error
+	// CancelWaiting 取消等待中的任务，resource 不为空时只取消占用该资源的任务，返回取消数量
+	CancelWaiting(resource string) (int64, error)
+	// SetPriority 调整等待中任务的优先级
+	SetPriority(id uint, priority int) error
 	UpdateStatus(id uint, status TaskStatus) error
 	UpdateLog(id uint, log string) error
 	Push(task *Task) error
 }
 
+// TaskSetting 任务队列设置
+type TaskSetting struct {
+	Workers uint `json:"workers"` // 同时执行的任务数
+}
+
 type TaskUsecase struct {
-	repo TaskRepo
+	log     *slog.Logger
+	repo    TaskRepo
+	setting SettingRepo
 }
 
-func NewTaskUsecase(repo TaskRepo) *TaskUsecase {
-	return &TaskUsecase{repo: repo}
+func NewTaskUsecase(log *slog.Logger, repo TaskRepo, setting SettingRepo) *TaskUsecase {
+	return &TaskUsecase{log: log, repo: repo, setting: setting}
 }
 
-func (uc *TaskUsecase) HasRunningTask() bool {
-	return uc.repo.HasRunningTask()
+// HasRunningTask 是否有运行中或即将执行的任务，resources 不为空时只看占用这些资源的任务
+// 重启面板会中断全部任务，此时不指定资源
+func (uc *TaskUsecase) HasRunningTask(resources ...string) bool {
+	return uc.repo.HasRunningTask(resources...)
+}
+
+// CountByStatus 按状态统计任务数
+func (uc *TaskUsecase) CountByStatus() (map[TaskStatus]int64, error) {
+	return uc.repo.CountByStatus()
 }
 
 func (uc *TaskUsecase) List(page, limit uint) ([]*Task, int64, error) {
@@ -63,6 +132,42 @@ func (uc *TaskUsecase) Cancel(id uint) error {
 	return uc.repo.Cancel(id)
 }
 
+func (uc *TaskUsecase) CancelWaiting(ctx context.Context, resource string) (int64, error) {
+	count, err := uc.repo.CancelWaiting(resource)
+	if err != nil {
+		return 0, err
+	}
+
+	// 记录日志
+	uc.log.Info("waiting tasks canceled", slog.String("type", OperationTypePanel), slog.Uint64("operator_id", operatorID(ctx)), slog.String("resource", resource), slog.Int64("count", count))
+
+	return count, nil
+}
+
+func (uc *TaskUsecase) SetPriority(id uint, priority int) error {
+	return uc.repo.SetPriority(id, priority)
+}
+
+func (uc *TaskUsecase) GetSetting() (*TaskSetting, error) {
+	workers, err := uc.setting.GetInt(SettingKeyTaskWorkers, TaskWorkersDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskSetting{Workers: uint(workers)}, nil
+}
+
+func (uc *TaskUsecase) SaveSetting(ctx context.Context, s *TaskSetting) error {
+	if err := uc.setting.Set(SettingKeyTaskWorkers, cast.ToString(s.Workers)); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("task queue setting updated", slog.String("type", OperationTypeSetting), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("workers", uint64(s.Workers)))
+
+	return nil
+}
+
 func (uc *TaskUsecase) UpdateStatus(id uint, status TaskStatus) error {
 	return uc.repo.UpdateStatus(id, status)
 }
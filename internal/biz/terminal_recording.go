@@ -0,0 +1,242 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/v3/pkg/asciicast"
+)
+
+const (
+	TerminalRecordingTypePTY       = "pty"       // 本机终端，目标为执行的命令
+	TerminalRecordingTypeSSH       = "ssh"       // SSH 主机，目标为 user@host:port
+	TerminalRecordingTypeContainer = "container" // 容器终端，目标为容器 ID
+)
+
+// TerminalRecording 终端会话录像，内容以 asciicast v2 格式压缩存储在文件中
+type TerminalRecording struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;default:0;index" json:"user_id"`
+	Username  string     `gorm:"not null;default:''" json:"username"` // 冗余保存，用户删除后仍可审计
+	IP        string     `gorm:"not null;default:''" json:"ip"`
+	Type      string     `gorm:"not null;default:''" json:"type"`
+	Target    string     `gorm:"not null;default:''" json:"target"`
+	Input     bool       `gorm:"not null;default:false" json:"input"` // 是否录制了输入
+	Path      string     `gorm:"not null;default:''" json:"-"`
+	Size      int64      `gorm:"not null;default:0" json:"size"`     // 压缩后大小
+	Duration  float64    `gorm:"not null;default:0" json:"duration"` // 秒
+	EndedAt   *time.Time `json:"ended_at"`                           // 为空表示会话进行中或面板异常退出
+	CreatedAt time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TerminalRecordingSetting 终端录像设置
+type TerminalRecordingSetting struct {
+	Enabled   bool `json:"enabled"`
+	Input     bool `json:"input"`     // 同时录制输入，可能包含密码等敏感内容
+	Mandatory bool `json:"mandatory"` // 强制录制，无法录制时拒绝打开终端
+	Days      uint `json:"days"`      // 保留天数，0 为永久保留
+}
+
+type TerminalRecordingRepo interface {
+	List(typ string, page, limit uint) ([]*TerminalRecording, int64, error)
+	Get(id uint) (*TerminalRecording, error)
+	// Create 保存记录并分配录像文件路径
+	Create(recording *TerminalRecording) error
+	Save(recording *TerminalRecording) error
+	// Delete 删除记录及录像文件
+	Delete(id uint) error
+	// ClearBefore 删除指定时间之前的记录及录像文件
+	ClearBefore(t time.Time) (int64, error)
+}
+
+type TerminalRecordingUsecase struct {
+	repo    TerminalRecordingRepo
+	user    UserRepo
+	setting SettingRepo
+	t       *gotext.Locale
+	log     *slog.Logger
+}
+
+func NewTerminalRecordingUsecase(t *gotext.Locale, log *slog.Logger, repo TerminalRecordingRepo, user UserRepo, setting SettingRepo) *TerminalRecordingUsecase {
+	return &TerminalRecordingUsecase{
+		repo:    repo,
+		user:    user,
+		setting: setting,
+		t:       t,
+		log:     log,
+	}
+}
+
+func (uc *TerminalRecordingUsecase) List(typ string, page, limit uint) ([]*TerminalRecording, int64, error) {
+	return uc.repo.List(typ, page, limit)
+}
+
+func (uc *TerminalRecordingUsecase) Get(id uint) (*TerminalRecording, error) {
+	return uc.repo.Get(id)
+}
+
+// Open 打开录像，返回解压后的 asciicast 内容
+func (uc *TerminalRecordingUsecase) Open(id uint) (*TerminalRecording, io.ReadCloser, error) {
+	recording, err := uc.repo.Get(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := asciicast.Open(recording.Path)
+	if err != nil {
+		return nil, nil, errors.New(uc.t.Get("failed to open recording: %v", err))
+	}
+
+	return recording, reader, nil
+}
+
+func (uc *TerminalRecordingUsecase) Delete(ctx context.Context, id uint) error {
+	recording, err := uc.repo.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = uc.repo.Delete(id); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("terminal recording deleted", slog.String("type", OperationTypeSSH), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.String("username", recording.Username), slog.String("target", recording.Target))
+
+	return nil
+}
+
+func (uc *TerminalRecordingUsecase) GetSetting() (*TerminalRecordingSetting, error) {
+	enabled, _ := uc.setting.GetBool(SettingKeyTerminalRecord)
+	input, _ := uc.setting.GetBool(SettingKeyTerminalRecordInput)
+	mandatory, _ := uc.setting.GetBool(SettingKeyTerminalRecordMandatory)
+	days, _ := uc.setting.GetInt(SettingKeyTerminalRecordDays, 90)
+	return &TerminalRecordingSetting{
+		Enabled:   enabled,
+		Input:     input,
+		Mandatory: mandatory,
+		Days:      uint(days),
+	}, nil
+}
+
+func (uc *TerminalRecordingUsecase) SaveSetting(ctx context.Context, s *TerminalRecordingSetting) error {
+	if err := uc.setting.Set(SettingKeyTerminalRecord, cast.ToString(s.Enabled)); err != nil {
+		return err
+	}
+	if err := uc.setting.Set(SettingKeyTerminalRecordInput, cast.ToString(s.Input)); err != nil {
+		return err
+	}
+	if err := uc.setting.Set(SettingKeyTerminalRecordMandatory, cast.ToString(s.Mandatory)); err != nil {
+		return err
+	}
+	if err := uc.setting.Set(SettingKeyTerminalRecordDays, cast.ToString(s.Days)); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("terminal recording setting updated", slog.String("type", OperationTypeSetting), slog.Uint64("operator_id", operatorID(ctx)), slog.Bool("enabled", s.Enabled), slog.Bool("input", s.Input), slog.Bool("mandatory", s.Mandatory), slog.Uint64("days", uint64(s.Days)))
+
+	return nil
+}
+
+// Start 开始录制终端会话，未启用录制时返回 nil
+// 强制录制时无法录制会返回错误，调用方应拒绝打开终端；否则只记录警告日志
+// 强制录制时录制中途失败会调用 stop 结束会话，不允许在没有录像的情况下继续操作
+func (uc *TerminalRecordingUsecase) Start(ctx context.Context, typ, target, ip string, stop func(error)) (*TerminalRecording, *asciicast.Recorder, error) {
+	setting, _ := uc.GetSetting()
+	if !setting.Enabled && !setting.Mandatory {
+		return nil, nil, nil
+	}
+
+	recording, rec, err := uc.start(ctx, typ, target, ip, setting.Input)
+	if err != nil {
+		if setting.Mandatory {
+			return nil, nil, errors.New(uc.t.Get("terminal recording is mandatory but failed to start: %v", err))
+		}
+		uc.log.Warn("failed to start terminal recording", slog.String("type", typ), slog.String("target", target), slog.Any("err", err))
+		return nil, nil, nil
+	}
+
+	go func() {
+		select {
+		case <-rec.Failed():
+			uc.log.Warn("terminal recording failed", slog.Uint64("id", uint64(recording.ID)), slog.Bool("mandatory", setting.Mandatory), slog.Any("err", rec.Err()))
+			if setting.Mandatory {
+				stop(errors.New(uc.t.Get("terminal recording is mandatory but failed: %v", rec.Err())))
+			}
+		case <-ctx.Done():
+		}
+	}()
+
+	// 记录日志
+	uc.log.Info("terminal session started", slog.String("type", OperationTypeSSH), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("recording_id", uint64(recording.ID)), slog.String("session_type", typ), slog.String("target", target), slog.String("ip", ip))
+
+	return recording, rec, nil
+}
+
+func (uc *TerminalRecordingUsecase) start(ctx context.Context, typ, target, ip string, input bool) (*TerminalRecording, *asciicast.Recorder, error) {
+	recording := &TerminalRecording{
+		UserID: uint(operatorID(ctx)),
+		IP:     ip,
+		Type:   typ,
+		Target: target,
+		Input:  input,
+	}
+	if user, err := uc.user.Get(recording.UserID); err == nil {
+		recording.Username = user.Username
+	}
+	if err := uc.repo.Create(recording); err != nil {
+		return nil, nil, err
+	}
+
+	rec, err := asciicast.Create(recording.Path, asciicast.Header{
+		Title: target,
+		Env:   map[string]string{"TERM": "xterm-256color"},
+	}, input)
+	if err != nil {
+		_ = uc.repo.Delete(recording.ID)
+		return nil, nil, err
+	}
+
+	return recording, rec, nil
+}
+
+// Finish 结束录制，保存时长与文件大小
+func (uc *TerminalRecordingUsecase) Finish(recording *TerminalRecording, rec *asciicast.Recorder) {
+	if recording == nil {
+		return
+	}
+
+	if err := rec.Close(); err != nil {
+		uc.log.Warn("failed to close terminal recording", slog.Uint64("id", uint64(recording.ID)), slog.Any("err", err))
+	}
+
+	now := time.Now()
+	recording.EndedAt = &now
+	recording.Duration = rec.Duration().Seconds()
+	if info, err := os.Stat(recording.Path); err == nil {
+		recording.Size = info.Size()
+	}
+	if err := uc.repo.Save(recording); err != nil {
+		uc.log.Warn("failed to save terminal recording", slog.Uint64("id", uint64(recording.ID)), slog.Any("err", err))
+	}
+
+	// 记录日志
+	uc.log.Info("terminal session ended", slog.String("type", OperationTypeSSH), slog.Uint64("operator_id", uint64(recording.UserID)), slog.Uint64("recording_id", uint64(recording.ID)), slog.Float64("duration", recording.Duration))
+}
+
+// ClearExpired 清理超过保留天数的录像
+func (uc *TerminalRecordingUsecase) ClearExpired() (int64, error) {
+	days, _ := uc.setting.GetInt(SettingKeyTerminalRecordDays, 90)
+	if days <= 0 {
+		return 0, nil
+	}
+
+	return uc.repo.ClearBefore(time.Now().AddDate(0, 0, -days))
+}
@@ -25,7 +25,7 @@ import (
 func (uc *ToolboxMigrationUsecase) checkConflicts(ctx context.Context, items []types.MigrationItem) {
 	websitePath, _ := uc.setting.Get(SettingKeyWebsitePath, filepath.Join(app.Root, "sites"))
 	projectPath, _ := uc.setting.Get(SettingKeyProjectPath, filepath.Join(app.Root, "projects"))
-	projects, _, _ := uc.project.List("", 1, 10000)
+	projects, _, _ := uc.project.List(ctx, "", 1, 10000)
 	databases, _, _ := uc.database.List(ctx, 1, 10000, "")
 	servers, _, _ := uc.databaseServer.List(ctx, 1, 10000, "")
 
@@ -254,7 +254,7 @@ func (uc *ToolboxMigrationUsecase) importWebsite(ctx context.Context, detail *ty
 		_ = uc.website.UpdateExpireAt(created.ID, website.ExpireAt)
 	}
 	if !website.Enabled {
-		_ = uc.website.UpdateStatus(created.ID, false)
+		_ = uc.website.UpdateStatus(ctx, created.ID, false)
 	}
 	return warnings, nil
 }
@@ -384,7 +384,7 @@ func (uc *ToolboxMigrationUsecase) projectWebsite(ctx context.Context, detail *t
 		return []string{uc.t.Get("the project was migrated, but its reverse proxy website could not be created: %v", err)}
 	}
 	if !project.Running {
-		_ = uc.website.UpdateStatus(website.ID, false)
+		_ = uc.website.UpdateStatus(ctx, website.ID, false)
 	}
 	return nil
 }
@@ -47,7 +47,7 @@ func (uc *ToolboxMigrationUsecase) probeRemote(ctx context.Context, conn *reques
 
 // localItems 列出本地可推送到目标面板的资源
 func (uc *ToolboxMigrationUsecase) localItems(ctx context.Context) ([]types.MigrationItem, error) {
-	websites, _, err := uc.website.List("all", 1, 10000)
+	websites, _, err := uc.website.List(ctx, "all", 1, 10000)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +59,7 @@ func (uc *ToolboxMigrationUsecase) localItems(ctx context.Context) ([]types.Migr
 	if err != nil {
 		return nil, err
 	}
-	projects, _, err := uc.project.List("", 1, 10000)
+	projects, _, err := uc.project.List(ctx, "", 1, 10000)
 	if err != nil {
 		return nil, err
 	}
@@ -203,11 +203,11 @@ func (uc *ToolboxMigrationUsecase) pushWebsite(
 	// 备份期间停站避免文件不一致，备份落盘后立即恢复
 	stopped := stopSource && item.Status == "running"
 	if stopped {
-		_ = uc.website.UpdateStatus(id, false)
+		_ = uc.website.UpdateStatus(ctx, id, false)
 	}
 	backup, err := uc.createBackup(ctx, BackupTypeWebsite, item.Name)
 	if stopped {
-		_ = uc.website.UpdateStatus(id, true)
+		_ = uc.website.UpdateStatus(ctx, id, true)
 	}
 	if err != nil {
 		return nil, errors.New(uc.t.Get("website backup failed: %v", err))
@@ -330,7 +330,7 @@ func (uc *ToolboxMigrationUsecase) pushProject(
 
 // createBackup 在本地生成备份并返回文件路径
 func (uc *ToolboxMigrationUsecase) createBackup(ctx context.Context, typ BackupType, target string) (string, error) {
-	if err := uc.backup.Create(ctx, typ, target, 0); err != nil {
+	if err := uc.backup.Create(ctx, typ, target, 0, false); err != nil {
 		return "", err
 	}
 	files, err := uc.backup.List(typ)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"image"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/leonelquinteros/gotext"
@@ -12,15 +13,18 @@ import (
 )
 
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Username  string         `gorm:"not null;default:'';unique" json:"username"`
-	Password  string         `gorm:"not null;default:''" json:"password"`
-	Email     string         `gorm:"not null;default:''" json:"email"`
-	TwoFA     string         `gorm:"not null;default:''" json:"two_fa"` // 2FA secret，为空表示未开启
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
-
+	ID        uint              `gorm:"primaryKey" json:"id"`
+	Username  string            `gorm:"not null;default:'';unique" json:"username"`
+	Password  string            `gorm:"not null;default:''" json:"password"`
+	Email     string            `gorm:"not null;default:''" json:"email"`
+	TwoFA     string            `gorm:"not null;default:''" json:"two_fa"` // 2FA secret，为空表示未开启
+	RoleID    uint              `gorm:"not null;default:0;index" json:"role_id"`
+	Scopes    map[string][]uint `gorm:"not null;default:'{}';serializer:json" json:"scopes"` // 资源范围，存在的键表示该类资源仅可访问列出的 ID
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	DeletedAt gorm.DeletedAt    `gorm:"index" json:"deleted_at"`
+
+	Role   *Role        `gorm:"foreignKey:RoleID" json:"role,omitempty"`
 	Tokens []*UserToken `gorm:"foreignKey:UserID" json:"-"`
 }
 
@@ -28,10 +32,12 @@ type UserRepo interface {
 	List(page, limit uint) ([]*User, int64, error)
 	Get(id uint) (*User, error)
 	Count() (int64, error)
-	Create(username, password, email string) (*User, error)
+	Create(username, password, email string, roleID uint, scopes map[string][]uint) (*User, error)
 	UpdateUsername(id uint, username string) error
 	UpdatePassword(id uint, password string) error
 	UpdateEmail(id uint, email string) error
+	UpdateRole(id, roleID uint, scopes map[string][]uint) error
+	CountByRole(roleID uint) (int64, error)
 	Delete(id uint) (string, error)
 	CheckPassword(username, password string) (*User, error)
 	IsTwoFA(username string) (bool, error)
@@ -61,14 +67,18 @@ func (uc *UserUsecase) Get(id uint) (*User, error) {
 	return uc.repo.Get(id)
 }
 
-func (uc *UserUsecase) Create(ctx context.Context, username, password, email string) (*User, error) {
-	user, err := uc.repo.Create(username, password, email)
+func (uc *UserUsecase) Create(ctx context.Context, username, password, email string, roleID uint, scopes map[string][]uint) (*User, error) {
+	if err := uc.checkScopes(scopes); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.repo.Create(username, password, email, roleID, scopes)
 	if err != nil {
 		return nil, err
 	}
 
 	// 记录日志
-	uc.log.Info("user created", slog.String("type", OperationTypeUser), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(user.ID)), slog.String("username", username))
+	uc.log.Info("user created", slog.String("type", OperationTypeUser), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(user.ID)), slog.String("username", username), slog.Uint64("role_id", uint64(roleID)))
 
 	return user, nil
 }
@@ -106,6 +116,25 @@ func (uc *UserUsecase) UpdateEmail(ctx context.Context, id uint, email string) e
 	return nil
 }
 
+// UpdateRole 修改用户角色与资源范围
+func (uc *UserUsecase) UpdateRole(ctx context.Context, id, roleID uint, scopes map[string][]uint) error {
+	if err := uc.checkScopes(scopes); err != nil {
+		return err
+	}
+	if err := uc.keepAdmin(id, roleID); err != nil {
+		return err
+	}
+
+	if err := uc.repo.UpdateRole(id, roleID, scopes); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("user role updated", slog.String("type", OperationTypeUser), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(id)), slog.Uint64("role_id", uint64(roleID)))
+
+	return nil
+}
+
 func (uc *UserUsecase) Delete(ctx context.Context, id uint) error {
 	count, err := uc.repo.Count()
 	if err != nil {
@@ -114,6 +143,9 @@ func (uc *UserUsecase) Delete(ctx context.Context, id uint) error {
 	if count <= 1 {
 		return errors.New(uc.t.Get("please don't do this"))
 	}
+	if err = uc.keepAdmin(id, 0); err != nil {
+		return err
+	}
 
 	username, err := uc.repo.Delete(id)
 	if err != nil {
@@ -141,3 +173,38 @@ func (uc *UserUsecase) GenerateTwoFA(id uint) (image.Image, string, string, erro
 func (uc *UserUsecase) UpdateTwoFA(id uint, code, secret string) error {
 	return uc.repo.UpdateTwoFA(id, code, secret)
 }
+
+// keepAdmin 确保修改后至少保留一个管理员，否则面板将无人可以管理用户
+func (uc *UserUsecase) keepAdmin(id, roleID uint) error {
+	if roleID == RoleIDAdmin {
+		return nil
+	}
+	user, err := uc.repo.Get(id)
+	if err != nil {
+		return err
+	}
+	if user.RoleID != RoleIDAdmin {
+		return nil
+	}
+
+	count, err := uc.repo.CountByRole(RoleIDAdmin)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return errors.New(uc.t.Get("at least one administrator must be kept"))
+	}
+
+	return nil
+}
+
+// checkScopes 校验资源范围类型
+func (uc *UserUsecase) checkScopes(scopes map[string][]uint) error {
+	for kind := range scopes {
+		if !slices.Contains(ScopeKinds, kind) {
+			return errors.New(uc.t.Get("unknown scope: %s", kind))
+		}
+	}
+
+	return nil
+}
@@ -1,23 +1,92 @@
 package biz
 
 import (
+	"errors"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/leonelquinteros/gotext"
+
 	"github.com/libtnb/utils/crypt"
 	"gorm.io/gorm"
 
 	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/request"
 )
 
+// UserToken API 令牌
+// Scopes 与 Endpoints 均为空时令牌拥有所属用户的全部权限，否则仅能访问二者之一允许的端点
 type UserToken struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	UserID    uint      `gorm:"index" json:"user_id"`
-	Token     string    `gorm:"not null;default:'';unique" json:"-"`
-	IPs       []string  `gorm:"not null;default:'[]';serializer:json" json:"ips"`
-	ExpiredAt time.Time `json:"expired_at"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uint                  `gorm:"primaryKey" json:"id"`
+	UserID     uint                  `gorm:"index" json:"user_id"`
+	Token      string                `gorm:"not null;default:'';unique" json:"-"`
+	IPs        []string              `gorm:"not null;default:'[]';serializer:json" json:"ips"`
+	Scopes     map[string]RoleAccess `gorm:"not null;default:'{}';serializer:json" json:"scopes"`    // 权限标签 => 访问级别
+	Endpoints  []string              `gorm:"not null;default:'[]';serializer:json" json:"endpoints"` // 端点白名单，如 "POST /api/website/*"
+	RateLimit  uint                  `gorm:"not null;default:0" json:"rate_limit"`                   // 每分钟请求数，0 为不限
+	LastUsedAt *time.Time            `json:"last_used_at"`
+	LastUsedIP string                `gorm:"not null;default:''" json:"last_used_ip"`
+	ExpiredAt  time.Time             `json:"expired_at"`
+	CreatedAt  time.Time             `json:"created_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+// Restricted 令牌是否限定了访问范围
+func (r *UserToken) Restricted() bool {
+	return len(r.Scopes) > 0 || len(r.Endpoints) > 0
+}
+
+// Allowed 判断令牌能否访问端点，pattern 为路由模式，path 为实际请求路径
+// 端点白名单与权限标签满足其一即可，所属用户的角色权限另行校验
+func (r *UserToken) Allowed(method, pattern, path string, tags []string, write bool) bool {
+	if !r.Restricted() {
+		return true
+	}
+	for _, endpoint := range r.Endpoints {
+		if matchEndpoint(endpoint, method, pattern, path) {
+			return true
+		}
+	}
+
+	return len(r.Scopes) > 0 && allowed(r.Scopes, tags, write)
+}
+
+// matchEndpoint 匹配 "METHOD /path" 形式的端点规则，METHOD 可为 *，路径以 * 结尾时按前缀匹配
+func matchEndpoint(rule, method, pattern, path string) bool {
+	ruleMethod, rulePath, ok := strings.Cut(strings.TrimSpace(rule), " ")
+	if !ok {
+		return false
+	}
+	if ruleMethod != "*" && !strings.EqualFold(ruleMethod, method) {
+		return false
+	}
+
+	rulePath = strings.TrimSpace(rulePath)
+	if prefix, ok := strings.CutSuffix(rulePath, "*"); ok {
+		return strings.HasPrefix(path, prefix) || (pattern != "" && strings.HasPrefix(pattern, prefix))
+	}
+
+	return rulePath == path || rulePath == pattern
+}
+
+// parseEndpoints 校验端点白名单格式
+func parseEndpoints(t *gotext.Locale, raw []string) ([]string, error) {
+	endpoints := make([]string, 0, len(raw))
+	for _, item := range raw {
+		method, path, ok := strings.Cut(strings.TrimSpace(item), " ")
+		method = strings.ToUpper(method)
+		path = strings.TrimSpace(path)
+		valid := ok && strings.HasPrefix(path, "/api/") &&
+			(method == "*" || slices.Contains([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}, method))
+		if !valid {
+			return nil, errors.New(t.Get("invalid endpoint: %s, expected format is \"METHOD /api/path\"", item))
+		}
+		endpoints = append(endpoints, method+" "+path)
+	}
+
+	return endpoints, nil
 }
 
 func (r *UserToken) BeforeSave(tx *gorm.DB) error {
@@ -50,27 +119,45 @@ func (r *UserToken) AfterFind(tx *gorm.DB) error {
 
 type UserTokenRepo interface {
 	List(userID, page, limit uint) ([]*UserToken, int64, error)
-	Create(userID uint, ips []string, expired time.Time) (*UserToken, error)
+	Create(userToken *UserToken) error
 	Get(id uint) (*UserToken, error)
 	Delete(id uint) error
-	Update(id uint, ips []string, expired time.Time) (*UserToken, error)
-	ValidateReq(req *http.Request) (uint, error)
+	Update(userToken *UserToken) error
+	ValidateReq(req *http.Request) (*UserToken, error)
 }
 
 type UserTokenUsecase struct {
 	repo UserTokenRepo
+	t    *gotext.Locale
 }
 
-func NewUserTokenUsecase(repo UserTokenRepo) *UserTokenUsecase {
-	return &UserTokenUsecase{repo: repo}
+func NewUserTokenUsecase(t *gotext.Locale, repo UserTokenRepo) *UserTokenUsecase {
+	return &UserTokenUsecase{repo: repo, t: t}
 }
 
 func (uc *UserTokenUsecase) List(userID, page, limit uint) ([]*UserToken, int64, error) {
 	return uc.repo.List(userID, page, limit)
 }
 
-func (uc *UserTokenUsecase) Create(userID uint, ips []string, expired time.Time) (*UserToken, error) {
-	return uc.repo.Create(userID, ips, expired)
+func (uc *UserTokenUsecase) Create(req *request.UserTokenCreate, expired time.Time) (*UserToken, error) {
+	scopes, endpoints, err := uc.restrictions(req.Scopes, req.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	userToken := &UserToken{
+		UserID:    req.UserID,
+		IPs:       req.IPs,
+		Scopes:    scopes,
+		Endpoints: endpoints,
+		RateLimit: req.RateLimit,
+		ExpiredAt: expired,
+	}
+	if err = uc.repo.Create(userToken); err != nil {
+		return nil, err
+	}
+
+	return userToken, nil
 }
 
 func (uc *UserTokenUsecase) Get(id uint) (*UserToken, error) {
@@ -81,10 +168,43 @@ func (uc *UserTokenUsecase) Delete(id uint) error {
 	return uc.repo.Delete(id)
 }
 
-func (uc *UserTokenUsecase) Update(id uint, ips []string, expired time.Time) (*UserToken, error) {
-	return uc.repo.Update(id, ips, expired)
+func (uc *UserTokenUsecase) Update(req *request.UserTokenUpdate, expired time.Time) (*UserToken, error) {
+	scopes, endpoints, err := uc.restrictions(req.Scopes, req.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	userToken, err := uc.repo.Get(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userToken.IPs = req.IPs
+	userToken.Scopes = scopes
+	userToken.Endpoints = endpoints
+	userToken.RateLimit = req.RateLimit
+	userToken.ExpiredAt = expired
+	if err = uc.repo.Update(userToken); err != nil {
+		return nil, err
+	}
+
+	return userToken, nil
 }
 
-func (uc *UserTokenUsecase) ValidateReq(req *http.Request) (uint, error) {
+func (uc *UserTokenUsecase) ValidateReq(req *http.Request) (*UserToken, error) {
 	return uc.repo.ValidateReq(req)
 }
+
+// restrictions 校验令牌的权限标签与端点白名单
+func (uc *UserTokenUsecase) restrictions(rawScopes map[string]string, rawEndpoints []string) (map[string]RoleAccess, []string, error) {
+	scopes, err := parsePermissions(uc.t, rawScopes)
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoints, err := parseEndpoints(uc.t, rawEndpoints)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return scopes, endpoints, nil
+}
@@ -52,7 +52,7 @@ type WebsiteRepo interface {
 	Count() (int64, error)
 	Get(id uint) (*types.WebsiteSetting, error)
 	GetByName(name string) (*types.WebsiteSetting, error)
-	List(typ string, page, limit uint) ([]*Website, int64, error)
+	List(ctx context.Context, typ string, page, limit uint) ([]*Website, int64, error)
 	Create(req *request.WebsiteCreate) (*Website, error)
 	Update(req *request.WebsiteUpdate) (*Website, error)
 	SwitchType(req *request.WebsiteSwitchType) (*Website, error)
@@ -65,6 +65,16 @@ type WebsiteRepo interface {
 	UpdateStatus(id uint, status bool) error
 	UpdateExpireAt(id uint, expireAt *time.Time) error
 	UpdateCert(req *request.WebsiteUpdateCert) error
+	// ListRevisions 配置版本列表，不含文件内容
+	ListRevisions(websiteID, page, limit uint) ([]*WebsiteRevision, int64, error)
+	GetRevision(websiteID, revisionID uint) (*WebsiteRevision, error)
+	// ConfigFiles 读取当前配置文件，格式同 WebsiteRevision.Files
+	ConfigFiles(websiteID uint) (map[string]string, error)
+	RestoreRevision(websiteID, revisionID uint) error
+	// RecordRevision 记录当前配置为新版本，内容未变化时跳过
+	RecordRevision(websiteID, operatorID uint, action string) error
+	// SwitchUpstream 将上游整体改写为指向 server，供项目蓝绿重启切换流量
+	SwitchUpstream(id uint, name, server string) error
 }
 
 type WebsiteUsecase struct {
@@ -115,8 +125,8 @@ func (uc *WebsiteUsecase) GetByName(name string) (*types.WebsiteSetting, error)
 	return uc.repo.GetByName(name)
 }
 
-func (uc *WebsiteUsecase) List(typ string, page, limit uint) ([]*Website, int64, error) {
-	return uc.repo.List(typ, page, limit)
+func (uc *WebsiteUsecase) List(ctx context.Context, typ string, page, limit uint) ([]*Website, int64, error) {
+	return uc.repo.List(ctx, typ, page, limit)
 }
 
 func (uc *WebsiteUsecase) Create(ctx context.Context, req *request.WebsiteCreate) (*Website, error) {
@@ -132,6 +142,7 @@ func (uc *WebsiteUsecase) Create(ctx context.Context, req *request.WebsiteCreate
 	if err = uc.repo.ReloadWebServer(); err != nil {
 		return nil, err
 	}
+	uc.recordRevision(ctx, w.ID, WebsiteRevisionCreate)
 
 	// 创建数据库
 	name := "local_" + req.DBType
@@ -165,7 +176,8 @@ func (uc *WebsiteUsecase) Update(ctx context.Context, req *request.WebsiteUpdate
 	// 记录日志
 	uc.log.Info("website updated", slog.String("type", OperationTypeWebsite), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(req.ID)), slog.String("name", website.Name))
 
-	return uc.repo.ReloadWebServer()
+	uc.recordRevision(ctx, req.ID, WebsiteRevisionUpdate)
+	return nil
 }
 
 func (uc *WebsiteUsecase) SwitchType(ctx context.Context, req *request.WebsiteSwitchType) error {
@@ -176,6 +188,7 @@ func (uc *WebsiteUsecase) SwitchType(ctx context.Context, req *request.WebsiteSw
 
 	uc.log.Info("website type switched", slog.String("type", OperationTypeWebsite), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(req.ID)), slog.String("name", website.Name), slog.String("website_type", req.Type))
 
+	uc.recordRevision(ctx, req.ID, WebsiteRevisionSwitchType)
 	return nil
 }
 
@@ -226,12 +239,22 @@ func (uc *WebsiteUsecase) UpdateRemark(id uint, remark string) error {
 	return uc.repo.UpdateRemark(id, remark)
 }
 
-func (uc *WebsiteUsecase) ResetConfig(id uint) error {
-	return uc.repo.ResetConfig(id)
+func (uc *WebsiteUsecase) ResetConfig(ctx context.Context, id uint) error {
+	if err := uc.repo.ResetConfig(id); err != nil {
+		return err
+	}
+
+	uc.recordRevision(ctx, id, WebsiteRevisionReset)
+	return nil
 }
 
-func (uc *WebsiteUsecase) UpdateStatus(id uint, status bool) error {
-	return uc.repo.UpdateStatus(id, status)
+func (uc *WebsiteUsecase) UpdateStatus(ctx context.Context, id uint, status bool) error {
+	if err := uc.repo.UpdateStatus(id, status); err != nil {
+		return err
+	}
+
+	uc.recordRevision(ctx, id, WebsiteRevisionStatus)
+	return nil
 }
 
 func (uc *WebsiteUsecase) UpdateExpireAt(id uint, expireAt *time.Time) error {
@@ -0,0 +1,166 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/samber/lo"
+
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+// WebsiteRevisionLimit 每个网站保留的配置版本数
+const WebsiteRevisionLimit = 50
+
+// 配置版本的变更来源，证书不纳入版本，更换证书不产生新版本
+const (
+	WebsiteRevisionCreate     = "create"
+	WebsiteRevisionUpdate     = "update"
+	WebsiteRevisionSwitchType = "switch_type"
+	WebsiteRevisionReset      = "reset"
+	WebsiteRevisionStatus     = "status"
+	WebsiteRevisionRestore    = "restore"
+	WebsiteRevisionUpstream   = "upstream" // 蓝绿重启切换上游
+)
+
+// websiteRevisionSettingFile diff 中结构化设置对应的虚拟文件名
+const websiteRevisionSettingFile = "setting.json"
+
+// WebsiteRevision 网站配置版本，每次配置变更通过检查并重载成功后记录
+type WebsiteRevision struct {
+	ID           uint                  `gorm:"primaryKey" json:"id"`
+	WebsiteID    uint                  `gorm:"not null;default:0;index" json:"website_id"`
+	OperatorID   uint                  `gorm:"not null;default:0" json:"operator_id"` // 0 为系统任务
+	OperatorName string                `gorm:"-:all" json:"operator_name,omitempty"`
+	Action       string                `gorm:"not null;default:''" json:"action"`
+	Files        map[string]string     `gorm:"not null;default:'{}';serializer:json" json:"files,omitempty"` // 相对配置目录的路径 => 内容，不含证书与私钥
+	Setting      *types.WebsiteSetting `gorm:"serializer:json" json:"setting,omitempty"`                     // 结构化设置，不含证书与私钥，早期版本为空
+	Hash         string                `gorm:"not null;default:''" json:"hash"`
+	CreatedAt    time.Time             `json:"created_at"`
+}
+
+func (uc *WebsiteUsecase) ListRevisions(websiteID, page, limit uint) ([]*WebsiteRevision, int64, error) {
+	return uc.repo.ListRevisions(websiteID, page, limit)
+}
+
+// DiffRevisions 生成两个版本之间的统一 diff，to 为 0 时与当前配置比较
+// 渲染后的配置文件与结构化设置（setting.json）一并比较
+func (uc *WebsiteUsecase) DiffRevisions(websiteID, from, to uint) (string, error) {
+	fromRevision, err := uc.repo.GetRevision(websiteID, from)
+	if err != nil {
+		return "", err
+	}
+
+	toRevision := &WebsiteRevision{}
+	toLabel := "current"
+	if to > 0 {
+		if toRevision, err = uc.repo.GetRevision(websiteID, to); err != nil {
+			return "", err
+		}
+		toLabel = "revision-" + strconv.FormatUint(uint64(to), 10)
+	} else {
+		if toRevision.Files, err = uc.repo.ConfigFiles(websiteID); err != nil {
+			return "", err
+		}
+		if toRevision.Setting, err = uc.repo.Get(websiteID); err != nil {
+			return "", err
+		}
+	}
+
+	fromFiles, err := revisionDiffFiles(fromRevision)
+	if err != nil {
+		return "", err
+	}
+	toFiles, err := revisionDiffFiles(toRevision)
+	if err != nil {
+		return "", err
+	}
+
+	return diffFiles(fromFiles, toFiles, "revision-"+strconv.FormatUint(uint64(from), 10), toLabel)
+}
+
+// RestoreRevision 按指定版本的结构化设置重新渲染网站配置，检查或重载失败时自动回滚
+func (uc *WebsiteUsecase) RestoreRevision(ctx context.Context, websiteID, revisionID uint) error {
+	if err := uc.repo.RestoreRevision(websiteID, revisionID); err != nil {
+		return err
+	}
+
+	// 记录日志
+	uc.log.Info("website config restored", slog.String("type", OperationTypeWebsite), slog.Uint64("operator_id", operatorID(ctx)), slog.Uint64("id", uint64(websiteID)), slog.Uint64("revision", uint64(revisionID)))
+
+	uc.recordRevision(ctx, websiteID, WebsiteRevisionRestore)
+	return nil
+}
+
+// recordRevision 记录当前配置为新版本
+// 变更此时已生效，记录失败只告警，不影响本次操作结果
+func (uc *WebsiteUsecase) recordRevision(ctx context.Context, websiteID uint, action string) {
+	if err := uc.repo.RecordRevision(websiteID, uint(operatorID(ctx)), action); err != nil {
+		uc.log.Warn("failed to record website revision", slog.Uint64("id", uint64(websiteID)), slog.String("action", action), slog.Any("err", err))
+	}
+}
+
+// RevisionSetting 去除证书与私钥等由证书模块管理的内容，得到可留存在版本中的设置
+func RevisionSetting(setting *types.WebsiteSetting) *types.WebsiteSetting {
+	if setting == nil {
+		return nil
+	}
+	stripped := *setting
+	stripped.SSLCert = ""
+	stripped.SSLKey = ""
+	stripped.SSLNotBefore = ""
+	stripped.SSLNotAfter = ""
+	stripped.SSLDNSNames = nil
+	stripped.SSLIssuer = ""
+	stripped.SSLOCSPServer = nil
+	return &stripped
+}
+
+// revisionDiffFiles 将结构化设置格式化为虚拟文件，与配置文件一起参与 diff
+func revisionDiffFiles(revision *WebsiteRevision) (map[string]string, error) {
+	files := make(map[string]string, len(revision.Files)+1)
+	for name, content := range revision.Files {
+		files[name] = content
+	}
+	if revision.Setting != nil {
+		encoded, err := json.MarshalIndent(RevisionSetting(revision.Setting), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		files[websiteRevisionSettingFile] = string(encoded) + "\n"
+	}
+
+	return files, nil
+}
+
+// diffFiles 逐文件生成统一 diff，新增或删除的文件与空内容比较
+func diffFiles(from, to map[string]string, fromLabel, toLabel string) (string, error) {
+	names := lo.Union(lo.Keys(from), lo.Keys(to))
+	slices.Sort(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		if from[name] == to[name] {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(from[name]),
+			B:        difflib.SplitLines(to[name]),
+			FromFile: fromLabel + "/" + name,
+			ToFile:   toLabel + "/" + name,
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(diff)
+	}
+
+	return b.String(), nil
+}
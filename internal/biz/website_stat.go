@@ -39,6 +39,19 @@ type WebsiteErrorLog struct {
 	CreatedAt time.Time `gorm:"index:idx_werr_site_time" json:"created_at"`
 }
 
+// WebsiteWAFLog WAF 拦截日志
+type WebsiteWAFLog struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Site      string    `gorm:"not null;index:idx_wwaf_site_time" json:"site"`
+	Rule      string    `gorm:"not null;index" json:"rule"` // 命中的规则类别，如 sqli、xss、cc、ip_deny
+	URI       string    `gorm:"not null;serializer:zstd" json:"uri"`
+	Method    string    `gorm:"not null" json:"method"`
+	Status    int       `gorm:"not null" json:"status"`
+	IP        string    `gorm:"not null" json:"ip"`
+	UA        string    `gorm:"not null;serializer:zstd" json:"ua"`
+	CreatedAt time.Time `gorm:"index:idx_wwaf_site_time" json:"created_at"`
+}
+
 // WebsiteStatSpider 蜘蛛统计（site, date, spider 唯一）
 type WebsiteStatSpider struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
@@ -156,6 +169,12 @@ type WebsiteStatURIRank struct {
 	RequestTimeCount uint64 `json:"request_time_count"`
 }
 
+// WebsiteWAFRuleRank WAF 规则命中排名
+type WebsiteWAFRuleRank struct {
+	Rule     string `json:"rule"`
+	Requests uint64 `json:"requests"`
+}
+
 // WebsiteStatSiteItem 网站维度汇总
 type WebsiteStatSiteItem struct {
 	Site             string `json:"site"`
@@ -193,7 +212,7 @@ type WebsiteStatRepo interface {
 	ClearErrorsBefore(t time.Time) error
 	// Clear 清空所有统计数据
 	Clear() error
-	// DeleteBySite 清空指定站点在六张统计表中的全部数据
+	// DeleteBySite 清空指定站点在各统计表中的全部数据
 	DeleteBySite(site string) error
 	VacuumDB() error
 
@@ -222,6 +241,12 @@ type WebsiteStatRepo interface {
 	// 错误日志查询
 	ListErrors(start, end string, sites []string, status int, page, limit uint) ([]*WebsiteErrorLog, uint, error)
 
+	// WAF 拦截日志
+	InsertWAFLogs(logs []*WebsiteWAFLog) error
+	ListWAFLogs(start, end string, sites []string, rule string, page, limit uint) ([]*WebsiteWAFLog, uint, error)
+	TopWAFRules(start, end string, sites []string) ([]*WebsiteWAFRuleRank, error)
+	ClearWAFLogsBefore(t time.Time) error
+
 	// 网站维度汇总
 	ListSiteStats(start, end string, sites []string) ([]*WebsiteStatSiteItem, error)
 }
@@ -334,6 +359,22 @@ func (uc *WebsiteStatUsecase) ListErrors(start, end string, sites []string, stat
 	return uc.repo.ListErrors(start, end, sites, status, page, limit)
 }
 
+func (uc *WebsiteStatUsecase) InsertWAFLogs(logs []*WebsiteWAFLog) error {
+	return uc.repo.InsertWAFLogs(logs)
+}
+
+func (uc *WebsiteStatUsecase) ListWAFLogs(start, end string, sites []string, rule string, page, limit uint) ([]*WebsiteWAFLog, uint, error) {
+	return uc.repo.ListWAFLogs(start, end, sites, rule, page, limit)
+}
+
+func (uc *WebsiteStatUsecase) TopWAFRules(start, end string, sites []string) ([]*WebsiteWAFRuleRank, error) {
+	return uc.repo.TopWAFRules(start, end, sites)
+}
+
+func (uc *WebsiteStatUsecase) ClearWAFLogsBefore(t time.Time) error {
+	return uc.repo.ClearWAFLogsBefore(t)
+}
+
 func (uc *WebsiteStatUsecase) ListSiteStats(start, end string, sites []string) ([]*WebsiteStatSiteItem, error) {
 	return uc.repo.ListSiteStats(start, end, sites)
 }
@@ -13,6 +13,7 @@ var ProviderSet = wire.NewSet(
 	NewT,
 	NewLogger,
 	NewSlog,
+	NewAudit,
 	NewDB,
 	NewMigrate,
 	NewSession,
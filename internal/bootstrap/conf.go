@@ -7,6 +7,7 @@ import (
 
 	"github.com/acepanel/panel/v3/internal/app"
 	"github.com/acepanel/panel/v3/pkg/config"
+	"github.com/acepanel/panel/v3/pkg/firewall"
 )
 
 func NewConf() (*config.Config, error) {
@@ -33,6 +34,7 @@ func InitGlobal(conf *config.Config) error {
 		app.Root = "/opt/ace"
 	}
 	app.Locale = conf.App.Locale
+	firewall.SetBackend(firewall.Backend(conf.App.Firewall))
 
 	// 初始化时区
 	loc, err := time.LoadLocation(conf.App.Timezone)
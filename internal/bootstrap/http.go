@@ -33,17 +33,18 @@ func NewRouter(loader *apploader.Loader, conf *config.Config, t *gotext.Locale,
 	// 供 service.Bind / route.SpecJSON 使用
 	validator.SetDefault(v)
 
-	// 数据驱动的登录白名单
+	// 数据驱动的登录白名单与端点权限表
 	public := route.PublicPaths(endpoints)
+	permissions := route.Permissions(endpoints)
 
 	r := chi.NewRouter()
-	r.Use(mws.Globals(t, r, public)...)
+	r.Use(mws.Globals(t, r, permissions, public)...)
 
 	// 注册各域路由
-	route.HTTP(conf, endpoints, r)
+	route.HTTP(conf, mws, endpoints, r)
 
 	// 动态应用子路由
-	r.Route("/api/apps", func(r chi.Router) {
+	r.With(mws.Permission(middleware.AppPermission)).Route("/api/apps", func(r chi.Router) {
 		loader.Register(r)
 	})
 
@@ -1,22 +1,29 @@
 package bootstrap
 
 import (
+	"fmt"
 	"log/slog"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/libtnb/logrotate"
+	"github.com/spf13/cast"
+	"gorm.io/gorm"
 
 	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/pkg/audit"
 	"github.com/acepanel/panel/v3/pkg/config"
 )
 
 type Logger struct {
 	*slog.Logger
+	Audit *audit.Log
 }
 
 // NewLogger 构建写入轮转文件的应用日志。
-func NewLogger(conf *config.Config) (*Logger, func(), error) {
+func NewLogger(conf *config.Config, db *gorm.DB) (*Logger, func(), error) {
 	w, err := logrotate.New(filepath.Join(app.Root, "panel/storage/logs/app.log"),
 		logrotate.WithMaxSize(10*logrotate.MB),
 		logrotate.WithMaxAge(30*logrotate.Day),
@@ -33,16 +40,77 @@ func NewLogger(conf *config.Config) (*Logger, func(), error) {
 		level = slog.LevelDebug
 	}
 
-	log := slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+	// 带操作人的日志同时写入哈希链审计日志，链尾记录在数据库中
+	auditLog, err := audit.Open(filepath.Join(app.Root, "panel/storage/audit"), []byte(app.Key), &auditAnchor{db: db})
+	if err != nil {
+		_ = w.Close()
+		return nil, nil, err
+	}
+
+	log := slog.New(audit.NewHandler(slog.NewJSONHandler(w, &slog.HandlerOptions{
 		Level: level,
-	}))
+	}), auditLog))
 	slog.SetDefault(log)
 
-	cleanup := func() { _ = w.Close() }
-	return &Logger{Logger: log}, cleanup, nil
+	cleanup := func() {
+		_ = auditLog.Close()
+		_ = w.Close()
+	}
+	return &Logger{Logger: log, Audit: auditLog}, cleanup, nil
 }
 
 // NewSlog 解包出纯 *slog.Logger 供应用其余部分使用。
 func NewSlog(logger *Logger) *slog.Logger {
 	return logger.Logger
 }
+
+// NewAudit 取出审计日志供业务层校验、导出与配置转发。
+func NewAudit(logger *Logger) *audit.Log {
+	return logger.Audit
+}
+
+// auditAnchor 把审计链首与链尾保存在设置表中，与日志目录分开存放
+type auditAnchor struct {
+	db *gorm.DB
+}
+
+func (a *auditAnchor) Load() (uint64, string, error) {
+	return a.load(biz.SettingKeyAuditAnchor)
+}
+
+func (a *auditAnchor) Store(seq uint64, hash string) error {
+	return a.store(biz.SettingKeyAuditAnchor, seq, hash)
+}
+
+func (a *auditAnchor) LoadStart() (uint64, string, error) {
+	return a.load(biz.SettingKeyAuditStart)
+}
+
+func (a *auditAnchor) StoreStart(seq uint64, hash string) error {
+	return a.store(biz.SettingKeyAuditStart, seq, hash)
+}
+
+func (a *auditAnchor) load(key biz.SettingKey) (uint64, string, error) {
+	var setting biz.Setting
+	if err := a.db.Where("key = ?", key).Limit(1).Find(&setting).Error; err != nil {
+		return 0, "", err
+	}
+	seq, hash, _ := strings.Cut(setting.Value, ":")
+	return cast.ToUint64(seq), hash, nil
+}
+
+func (a *auditAnchor) store(key biz.SettingKey, seq uint64, hash string) error {
+	// 面板与 CLI 进程都会写入，在事务内比较序号，只前进不后退
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		var setting biz.Setting
+		if err := tx.Where("key = ?", key).Limit(1).Find(&setting).Error; err != nil {
+			return err
+		}
+		if old, _, _ := strings.Cut(setting.Value, ":"); setting.ID != 0 && cast.ToUint64(old) >= seq {
+			return nil
+		}
+		setting.Key = key
+		setting.Value = fmt.Sprintf("%d:%s", seq, hash)
+		return tx.Save(&setting).Error
+	})
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/urfave/cli/v3"
+
+	"github.com/acepanel/panel/v3/internal/service"
+)
+
+// AuditCommand 审计日志命令组
+func AuditCommand(t *gotext.Locale, cliService *service.CliService) *cli.Command {
+
+	return &cli.Command{
+		Name:  "audit",
+		Usage: t.Get("Audit log"),
+		Commands: []*cli.Command{
+			{
+				Name:  "verify",
+				Usage: t.Get("Verify the audit log hash chain"),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return cliService.AuditVerify(ctx, cmd)
+				},
+			},
+			{
+				Name:  "export",
+				Usage: t.Get("Export audit log"),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"f"},
+						Usage:   t.Get("Export format (csv, json)"),
+						Value:   "csv",
+					},
+					&cli.StringFlag{
+						Name:    "start",
+						Aliases: []string{"s"},
+						Usage:   t.Get("Start date (YYYY-MM-DD)"),
+					},
+					&cli.StringFlag{
+						Name:    "end",
+						Aliases: []string{"e"},
+						Usage:   t.Get("End date (YYYY-MM-DD)"),
+					},
+					&cli.UintFlag{
+						Name:  "operator",
+						Usage: t.Get("Operator user ID"),
+					},
+					&cli.StringFlag{
+						Name:    "type",
+						Aliases: []string{"t"},
+						Usage:   t.Get("Operation type"),
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   t.Get("Output file (standard output if not filled)"),
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return cliService.AuditExport(ctx, cmd)
+				},
+			},
+		},
+	}
+}
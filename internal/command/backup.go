@@ -23,9 +23,14 @@ func BackupCommand(t *gotext.Locale, cliService *service.CliService) *cli.Comman
 					&cli.StringFlag{
 						Name:     "type",
 						Aliases:  []string{"t"},
-						Usage:    t.Get("Backup type (website, path, panel, mysql, postgresql, clickhouse, redis, valkey)"),
+						Usage:    t.Get("Backup type (website, path, panel, mysql, postgresql, clickhouse, redis, valkey, mongodb, elasticsearch)"),
 						Required: true,
 					},
+					&cli.UintFlag{
+						Name:    "storage",
+						Aliases: []string{"s"},
+						Usage:   t.Get("Storage ID, lists deduplicated snapshots in the storage (local backups if not filled)"),
+					},
 				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					return cliService.BackupList(ctx, cmd)
@@ -46,6 +51,11 @@ func BackupCommand(t *gotext.Locale, cliService *service.CliService) *cli.Comman
 						Aliases: []string{"s"},
 						Usage:   t.Get("Storage ID (local storage if not filled)"),
 					},
+					&cli.BoolFlag{
+						Name:    "dedup",
+						Aliases: []string{"d"},
+						Usage:   t.Get("Deduplicated incremental backup, only uploads changed chunks"),
+					},
 				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					return cliService.BackupWebsite(ctx, cmd)
@@ -58,7 +68,7 @@ func BackupCommand(t *gotext.Locale, cliService *service.CliService) *cli.Comman
 					&cli.StringFlag{
 						Name:     "type",
 						Aliases:  []string{"t"},
-						Usage:    t.Get("Database type (mysql, postgresql, clickhouse, redis, valkey)"),
+						Usage:    t.Get("Database type (mysql, postgresql, clickhouse, redis, valkey, mongodb, elasticsearch)"),
 						Required: true,
 					},
 					&cli.StringFlag{
@@ -92,6 +102,11 @@ func BackupCommand(t *gotext.Locale, cliService *service.CliService) *cli.Comman
 						Aliases: []string{"s"},
 						Usage:   t.Get("Storage ID (local storage if not filled)"),
 					},
+					&cli.BoolFlag{
+						Name:    "dedup",
+						Aliases: []string{"d"},
+						Usage:   t.Get("Deduplicated incremental backup, only uploads changed chunks"),
+					},
 				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					return cliService.BackupPath(ctx, cmd)
@@ -105,6 +120,63 @@ func BackupCommand(t *gotext.Locale, cliService *service.CliService) *cli.Comman
 					return cliService.BackupPanel(ctx, cmd)
 				},
 			},
+			{
+				Name:  "verify",
+				Usage: t.Get("Verify backup integrity"),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "type",
+						Aliases:  []string{"t"},
+						Usage:    t.Get("Backup type (website, path, mysql, postgresql, clickhouse, redis, valkey, mongodb, elasticsearch)"),
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "file",
+						Aliases:  []string{"f"},
+						Usage:    t.Get("Backup file name"),
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:    "storage",
+						Aliases: []string{"s"},
+						Usage:   t.Get("Storage ID (local storage if not filled)"),
+					},
+					&cli.StringFlag{
+						Name:    "identity",
+						Aliases: []string{"i"},
+						Usage:   t.Get("age identity file to decrypt backups encrypted with a public key"),
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return cliService.BackupVerify(ctx, cmd)
+				},
+			},
+			{
+				Name:  "test-restore",
+				Usage: t.Get("Restore the latest database backup into a temporary database"),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "type",
+						Aliases:  []string{"t"},
+						Usage:    t.Get("Database type (mysql, postgresql)"),
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "name",
+						Aliases:  []string{"n"},
+						Usage:    t.Get("Database name"),
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:    "storage",
+						Aliases: []string{"s"},
+						Usage:   t.Get("Storage ID (local storage if not filled)"),
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return cliService.BackupTestRestore(ctx, cmd)
+				},
+			},
 			{
 				Name:  "clear",
 				Usage: t.Get("Clear backups"),
@@ -112,7 +184,7 @@ func BackupCommand(t *gotext.Locale, cliService *service.CliService) *cli.Comman
 					&cli.StringFlag{
 						Name:     "type",
 						Aliases:  []string{"t"},
-						Usage:    t.Get("Backup type (website, path, mysql, postgresql, clickhouse, redis, valkey)"),
+						Usage:    t.Get("Backup type (website, path, mysql, postgresql, clickhouse, redis, valkey, mongodb, elasticsearch)"),
 						Required: true,
 					},
 					&cli.StringFlag{
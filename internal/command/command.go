@@ -39,7 +39,10 @@ func Commands(t *gotext.Locale, cliService *service.CliService) []*cli.Command {
 		RestoreCommand(t, cliService),
 		CutoffCommand(t, cliService),
 		CronCommand(t, cliService),
+		DeployCommand(t, cliService),
+		ProjectCommand(t, cliService),
 		AppCommand(t, cliService),
+		AuditCommand(t, cliService),
 		SettingCommand(t, cliService),
 	}
 }
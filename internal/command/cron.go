@@ -6,6 +6,7 @@ import (
 	"github.com/leonelquinteros/gotext"
 	"github.com/urfave/cli/v3"
 
+	"github.com/acepanel/panel/v3/internal/biz"
 	"github.com/acepanel/panel/v3/internal/service"
 )
 
@@ -33,6 +34,12 @@ func CronCommand(t *gotext.Locale, cliService *service.CliService) *cli.Command
 						Usage:    t.Get("Cron task ID"),
 						Required: true,
 					},
+					&cli.StringFlag{
+						Name:    "trigger",
+						Aliases: []string{"t"},
+						Usage:   t.Get("Trigger of the run"),
+						Value:   biz.CronRunTriggerManual,
+					},
 				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					return cliService.CronRun(ctx, cmd)
@@ -57,6 +64,55 @@ func CronCommand(t *gotext.Locale, cliService *service.CliService) *cli.Command
 					return cliService.CronStatus(ctx, cmd)
 				},
 			},
+			{
+				Name:  "report",
+				Usage: t.Get("Record a cron task run, called by the task wrapper script"),
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:     "id",
+						Aliases:  []string{"i"},
+						Usage:    t.Get("Cron task ID"),
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:    "code",
+						Aliases: []string{"c"},
+						Usage:   t.Get("Exit code"),
+					},
+					&cli.Int64Flag{
+						Name:    "start",
+						Aliases: []string{"s"},
+						Usage:   t.Get("Start time in Unix milliseconds"),
+					},
+					&cli.Int64Flag{
+						Name:    "end",
+						Aliases: []string{"e"},
+						Usage:   t.Get("End time in Unix milliseconds"),
+					},
+					&cli.StringFlag{
+						Name:    "trigger",
+						Aliases: []string{"t"},
+						Usage:   t.Get("Trigger of the run"),
+						Value:   biz.CronRunTriggerSchedule,
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   t.Get("File containing the run output"),
+					},
+					&cli.Int64Flag{
+						Name:  "offset",
+						Usage: t.Get("Offset in the output file where the run output starts"),
+					},
+					&cli.StringFlag{
+						Name:  "batch",
+						Usage: t.Get("File listing several runs, one \"start end code output\" per line"),
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return cliService.CronReport(ctx, cmd)
+				},
+			},
 			{
 				Name:  "failed",
 				Usage: t.Get("Report a failed cron task, called by the task wrapper script"),
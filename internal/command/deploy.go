@@ -0,0 +1,57 @@
+package command
+
+import (
+	"context"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/urfave/cli/v3"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/service"
+)
+
+// DeployCommand Git 部署命令组
+func DeployCommand(t *gotext.Locale, cliService *service.CliService) *cli.Command {
+	return &cli.Command{
+		Name:  "deploy",
+		Usage: t.Get("Git deployment"),
+		Commands: []*cli.Command{
+			{
+				Name:  "trigger",
+				Usage: t.Get("Queue a deployment of a project or website"),
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:     "id",
+						Aliases:  []string{"i"},
+						Usage:    t.Get("Deployment ID"),
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "trigger",
+						Aliases: []string{"t"},
+						Usage:   t.Get("Trigger of the deployment"),
+						Value:   biz.DeploymentTriggerManual,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return cliService.DeployTrigger(ctx, cmd)
+				},
+			},
+			{
+				Name:  "run",
+				Usage: t.Get("Run a queued release, called by the deployment task"),
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:     "id",
+						Aliases:  []string{"i"},
+						Usage:    t.Get("Release ID"),
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return cliService.DeployRun(ctx, cmd)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package command
+
+import (
+	"context"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/urfave/cli/v3"
+
+	"github.com/acepanel/panel/v3/internal/service"
+)
+
+// ProjectCommand 项目命令组
+func ProjectCommand(t *gotext.Locale, cliService *service.CliService) *cli.Command {
+	return &cli.Command{
+		Name:  "project",
+		Usage: t.Get("Project management"),
+		Commands: []*cli.Command{
+			{
+				Name:  "restart",
+				Usage: t.Get("Blue/green restart a project, called by the restart task"),
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:     "id",
+						Aliases:  []string{"i"},
+						Usage:    t.Get("Project ID"),
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return cliService.ProjectRestart(ctx, cmd)
+				},
+			},
+		},
+	}
+}
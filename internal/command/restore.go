@@ -32,11 +32,46 @@ func RestoreCommand(t *gotext.Locale, cliService *service.CliService) *cli.Comma
 						Usage:    t.Get("Backup file (absolute path or filename under default backup path)"),
 						Required: true,
 					},
+					&cli.UintFlag{
+						Name:    "storage",
+						Aliases: []string{"s"},
+						Usage:   t.Get("Storage ID of the deduplicated snapshot (local storage if not filled)"),
+					},
+					&cli.StringFlag{
+						Name:    "identity",
+						Aliases: []string{"i"},
+						Usage:   t.Get("age identity file to decrypt backups encrypted with a public key"),
+					},
 				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					return cliService.RestoreWebsite(ctx, cmd)
 				},
 			},
+			{
+				Name:  "path",
+				Usage: t.Get("Restore directory from deduplicated snapshot"),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "path",
+						Aliases: []string{"p"},
+						Usage:   t.Get("Directory path (original directory of the snapshot if not filled)"),
+					},
+					&cli.StringFlag{
+						Name:     "file",
+						Aliases:  []string{"f"},
+						Usage:    t.Get("Snapshot name"),
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:    "storage",
+						Aliases: []string{"s"},
+						Usage:   t.Get("Storage ID (local storage if not filled)"),
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return cliService.RestorePath(ctx, cmd)
+				},
+			},
 			{
 				Name:  "database",
 				Usage: t.Get("Restore database backup"),
@@ -44,7 +79,7 @@ func RestoreCommand(t *gotext.Locale, cliService *service.CliService) *cli.Comma
 					&cli.StringFlag{
 						Name:     "type",
 						Aliases:  []string{"t"},
-						Usage:    t.Get("Database type (mysql, postgresql, clickhouse, redis, valkey)"),
+						Usage:    t.Get("Database type (mysql, postgresql, clickhouse, redis, valkey, mongodb, elasticsearch)"),
 						Required: true,
 					},
 					&cli.StringFlag{
@@ -59,6 +94,11 @@ func RestoreCommand(t *gotext.Locale, cliService *service.CliService) *cli.Comma
 						Usage:    t.Get("Backup file (absolute path or filename under default backup path)"),
 						Required: true,
 					},
+					&cli.StringFlag{
+						Name:    "identity",
+						Aliases: []string{"i"},
+						Usage:   t.Get("age identity file to decrypt backups encrypted with a public key"),
+					},
 				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					return cliService.RestoreDatabase(ctx, cmd)
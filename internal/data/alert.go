@@ -180,3 +180,15 @@ func (r *alertRepo) WebsiteExpiry() ([]*biz.AlertMetric, error) {
 
 	return metrics, nil
 }
+
+func (r *alertRepo) CertMonitors() ([]*biz.CertMonitor, error) {
+	monitors := make([]*biz.CertMonitor, 0)
+	err := r.db.Where("enabled = ? AND checked_at IS NOT NULL", true).Find(&monitors).Error
+	return monitors, err
+}
+
+func (r *alertRepo) Probes() ([]*biz.Probe, error) {
+	probes := make([]*biz.Probe, 0)
+	err := r.db.Where("enabled = ? AND checked_at IS NOT NULL", true).Find(&probes).Error
+	return probes, err
+}
@@ -3,6 +3,8 @@ package data
 import (
 	"archive/tar"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	stdio "io"
@@ -11,10 +13,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"sync/atomic"
+	"syscall"
 	"time"
 
+	"filippo.io/age"
 	"github.com/leonelquinteros/gotext"
 	"github.com/samber/lo"
 	"github.com/spf13/cast"
@@ -24,6 +29,7 @@ import (
 	"github.com/acepanel/panel/v3/internal/biz"
 	"github.com/acepanel/panel/v3/pkg/config"
 	"github.com/acepanel/panel/v3/pkg/db"
+	"github.com/acepanel/panel/v3/pkg/dedup"
 	"github.com/acepanel/panel/v3/pkg/io"
 	"github.com/acepanel/panel/v3/pkg/shell"
 	"github.com/acepanel/panel/v3/pkg/storage"
@@ -80,48 +86,175 @@ func (r *backupRepo) List(typ biz.BackupType) ([]*types.BackupFile, error) {
 
 	list := make([]*types.BackupFile, 0)
 	for _, file := range files {
+		if strings.HasSuffix(file.Name(), storage.ChecksumExt) {
+			continue
+		}
 		info, err := file.Info()
 		if err != nil {
 			continue
 		}
 		list = append(list, &types.BackupFile{
-			Name: file.Name(),
-			Path: filepath.Join(path, file.Name()),
-			Size: tools.FormatBytes(float64(info.Size())),
-			Time: info.ModTime(),
+			Name:      file.Name(),
+			Path:      filepath.Join(path, file.Name()),
+			Size:      tools.FormatBytes(float64(info.Size())),
+			Time:      info.ModTime(),
+			Encrypted: strings.HasSuffix(file.Name(), storage.EncryptedExt),
 		})
 	}
 
+	// 本地去重快照
+	if typ == biz.BackupTypeWebsite || typ == biz.BackupTypePath {
+		snapshots, err := r.ListSnapshots(0, typ)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, snapshots...)
+	}
+
 	return list, nil
 }
 
-// Create 创建备份
-// typ 备份类型
-// target 目标名称
-// storage 备份存储ID
-func (r *backupRepo) Create(ctx context.Context, typ biz.BackupType, target string, storage uint) error {
-	// 取备份存储，0 为本地备份
-	var backupStorage *biz.BackupStorage
-	if storage != 0 {
-		var err error
-		backupStorage, err = r.GetStorage(storage)
+// ListSnapshots 列出备份存储中的去重快照
+// storage 备份存储ID，0 为本地存储
+func (r *backupRepo) ListSnapshots(storage uint, typ biz.BackupType) ([]*types.BackupFile, error) {
+	backupStorage, client, err := r.storageClient(storage, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := r.dedupRepo(client, typ, backupStorage.Info.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := repo.Snapshots("")
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.BackupFile, 0, len(snapshots))
+	for _, info := range snapshots {
+		size := "-"
+		if snapshot, err := repo.Snapshot(info.Name); err == nil {
+			size = tools.FormatBytes(float64(snapshot.Size))
+		}
+		list = append(list, &types.BackupFile{
+			Name:      info.Name + dedup.SnapshotExt,
+			Path:      info.Name + dedup.SnapshotExt,
+			Size:      size,
+			Time:      info.Time,
+			Encrypted: repo.Encrypted(),
+		})
+	}
+
+	return list, nil
+}
+
+// ListStorage 列出备份存储中的备份，0 为本地存储
+func (r *backupRepo) ListStorage(account uint, typ biz.BackupType) ([]*types.BackupFile, error) {
+	if account == 0 {
+		return r.List(typ)
+	}
+
+	_, client, err := r.storageClient(account, typ)
+	if err != nil {
+		return nil, err
+	}
+	files, err := client.List(string(typ))
+	if err != nil {
+		if !client.Exists(string(typ)) {
+			return make([]*types.BackupFile, 0), nil
+		}
+		return nil, err
+	}
+
+	list := make([]*types.BackupFile, 0, len(files))
+	for _, file := range files {
+		if !r.isBackupArchive(file) {
+			continue
+		}
+		remote := filepath.Join(string(typ), file)
+		size, err := client.Size(remote)
+		if err != nil {
+			continue
+		}
+		modified, _ := client.LastModified(remote)
+		list = append(list, &types.BackupFile{
+			Name:      file,
+			Path:      remote,
+			Size:      tools.FormatBytes(float64(size)),
+			Time:      modified,
+			Encrypted: strings.HasSuffix(file, storage.EncryptedExt),
+		})
+	}
+
+	if typ == biz.BackupTypeWebsite || typ == biz.BackupTypePath {
+		snapshots, err := r.ListSnapshots(account, typ)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, snapshots...)
+	}
+
+	return list, nil
+}
+
+// CheckArchive 检查备份存储中存在可校验的备份压缩包或去重快照
+// 文件名只能是备份目录下的文件，且为已知的压缩包后缀
+func (r *backupRepo) CheckArchive(storage uint, typ biz.BackupType, name string) error {
+	backupStorage, client, err := r.storageClient(storage, typ)
+	if err != nil {
+		return err
+	}
+
+	return r.checkArchive(backupStorage, client, typ, name)
+}
+
+func (r *backupRepo) checkArchive(backupStorage *biz.BackupStorage, client storage.Storage, typ biz.BackupType, name string) error {
+	// 去重快照位于去重仓库中，能读出清单即存在
+	if strings.HasSuffix(name, dedup.SnapshotExt) {
+		repo, err := r.dedupRepo(client, typ, backupStorage.Info.Passphrase)
 		if err != nil {
 			return err
 		}
-	} else {
-		backupStorage = &biz.BackupStorage{
-			Name: r.t.Get("Local Storage"),
-			Type: biz.BackupStorageTypeLocal,
-			Info: types.BackupStorageInfo{
-				Path: filepath.Dir(r.GetDefaultPath(typ)), // 需要取根目录
-			},
+		if _, err = repo.Snapshot(name); err != nil {
+			return errors.New(r.t.Get("backup file %s not found", name))
 		}
+		return nil
+	}
+	if name != filepath.Base(name) || name == "." || name == ".." || !r.isBackupArchive(name) {
+		return errors.New(r.t.Get("invalid backup file: %s", name))
+	}
+	if !client.Exists(filepath.Join(string(typ), name)) {
+		return errors.New(r.t.Get("backup file %s not found", name))
 	}
 
-	client, err := r.getStorage(*backupStorage)
+	return nil
+}
+
+// Create 创建备份
+// typ 备份类型
+// target 目标名称
+// account 备份存储ID
+// dedup 是否以去重快照方式备份，仅支持网站和目录
+func (r *backupRepo) Create(ctx context.Context, typ biz.BackupType, target string, account uint, dedup bool) error {
+	if dedup && typ != biz.BackupTypeWebsite && typ != biz.BackupTypePath {
+		return errors.New(r.t.Get("deduplicated backups only support website and path"))
+	}
+
+	backupStorage, client, err := r.storageClient(account, typ)
 	if err != nil {
 		return err
 	}
+	// 去重仓库自行加密，只支持口令；归档在上传前整体加密
+	if dedup && backupStorage.Info.PublicKey != "" {
+		return errors.New(r.t.Get("deduplicated backups can only be encrypted with a passphrase"))
+	}
+	if !dedup {
+		// 先加密再计算校验清单，清单记录存储中的实际内容
+		if client, err = r.encryptedClient(backupStorage, storage.NewChecksum(client)); err != nil {
+			return err
+		}
+	}
 
 	start := time.Now()
 	namePrefix := target
@@ -136,25 +269,36 @@ func (r *backupRepo) Create(ctx context.Context, typ biz.BackupType, target stri
 		fmt.Println(r.t.Get("|-Backup type: %s", string(typ)))
 		fmt.Println(r.t.Get("|-Backup storage: %s", backupStorage.Name))
 		fmt.Println(r.t.Get("|-Backup target: %s", target))
+		if backupStorage.Info.Passphrase != "" || backupStorage.Info.PublicKey != "" {
+			fmt.Println(r.t.Get("|-Encryption: enabled"))
+		}
 	}
 
-	switch typ {
-	case biz.BackupTypeWebsite:
-		err = r.createWebsite(name, client, target)
-	case biz.BackupTypeMySQL:
-		err = r.createMySQL(name, client, target)
-	case biz.BackupTypePostgres:
-		err = r.createPostgres(name, client, target)
-	case biz.BackupTypeClickHouse:
-		err = r.createClickHouse(name, client, target)
-	case biz.BackupTypeRedis:
-		err = r.createRedisLike(name, client, "redis")
-	case biz.BackupTypeValkey:
-		err = r.createRedisLike(name, client, "valkey")
-	case biz.BackupTypePath:
-		err = r.createPath(name, client, target)
-	default:
-		return errors.New(r.t.Get("unknown backup type"))
+	if dedup {
+		err = r.createSnapshot(name, client, typ, target, backupStorage.Info.Passphrase)
+	} else {
+		switch typ {
+		case biz.BackupTypeWebsite:
+			err = r.createWebsite(name, client, target)
+		case biz.BackupTypeMySQL:
+			err = r.createMySQL(name, client, target)
+		case biz.BackupTypePostgres:
+			err = r.createPostgres(name, client, target)
+		case biz.BackupTypeClickHouse:
+			err = r.createClickHouse(name, client, target)
+		case biz.BackupTypeRedis:
+			err = r.createRedisLike(name, client, "redis")
+		case biz.BackupTypeValkey:
+			err = r.createRedisLike(name, client, "valkey")
+		case biz.BackupTypeMongoDB:
+			err = r.createMongoDB(name, client, target)
+		case biz.BackupTypeElasticsearch:
+			err = r.createElasticsearch(name, client, target)
+		case biz.BackupTypePath:
+			err = r.createPath(name, client, target)
+		default:
+			return errors.New(r.t.Get("unknown backup type"))
+		}
 	}
 
 	if app.IsCli {
@@ -237,12 +381,32 @@ func (r *backupRepo) CreatePanel() error {
 
 // Delete 删除备份
 func (r *backupRepo) Delete(typ biz.BackupType, name string) error {
+	// 本地去重快照，删除清单后回收不再引用的数据块
+	if strings.HasSuffix(name, dedup.SnapshotExt) {
+		_, client, err := r.storageClient(0, typ)
+		if err != nil {
+			return err
+		}
+		repo, err := r.dedupRepo(client, typ, "")
+		if err != nil {
+			return err
+		}
+		if err = repo.Delete(name); err != nil {
+			return err
+		}
+		_, err = repo.GC()
+		return err
+	}
+
 	path := r.GetDefaultPath(typ)
 
 	file := filepath.Join(path, name)
 	if err := io.Remove(file); err != nil {
 		return err
 	}
+	if io.Exists(file + storage.ChecksumExt) {
+		return io.Remove(file + storage.ChecksumExt)
+	}
 
 	return nil
 }
@@ -252,6 +416,9 @@ func (r *backupRepo) Delete(typ biz.BackupType, name string) error {
 // backup 备份压缩包，可以是绝对路径或者相对路径
 // target 目标名称
 func (r *backupRepo) Restore(typ biz.BackupType, backup, target string) error {
+	if strings.HasSuffix(backup, dedup.SnapshotExt) && !io.Exists(backup) {
+		return r.RestoreSnapshot(0, typ, backup, target)
+	}
 	if !io.Exists(backup) {
 		backup = filepath.Join(r.GetDefaultPath(typ), backup)
 	}
@@ -259,6 +426,16 @@ func (r *backupRepo) Restore(typ biz.BackupType, backup, target string) error {
 		return errors.New(r.t.Get("backup file not exists"))
 	}
 
+	// 加密备份先解密到临时目录
+	if strings.HasSuffix(backup, storage.EncryptedExt) {
+		decrypted, err := r.Decrypt(typ, backup, "")
+		if err != nil {
+			return err
+		}
+		defer func(path string) { _ = os.RemoveAll(path) }(filepath.Dir(decrypted))
+		backup = decrypted
+	}
+
 	start := time.Now()
 	if app.IsCli {
 		fmt.Println(r.hr)
@@ -283,6 +460,10 @@ func (r *backupRepo) Restore(typ biz.BackupType, backup, target string) error {
 		err = r.restoreRedisLike(backup, "redis")
 	case biz.BackupTypeValkey:
 		err = r.restoreRedisLike(backup, "valkey")
+	case biz.BackupTypeMongoDB:
+		err = r.restoreMongoDB(backup, target)
+	case biz.BackupTypeElasticsearch:
+		err = r.restoreElasticsearch(backup, target)
 	case biz.BackupTypePanel:
 		err = r.restorePanel(backup)
 	default:
@@ -306,6 +487,415 @@ func (r *backupRepo) Restore(typ biz.BackupType, backup, target string) error {
 	return err
 }
 
+// Decrypt 解密备份到临时目录，返回解密后的文件路径，调用方负责删除其所在目录
+// backup 备份文件，可以是绝对路径或者相对路径
+// identity age 私钥文件，为空时只尝试各备份存储的加密口令
+func (r *backupRepo) Decrypt(typ biz.BackupType, backup, identity string) (string, error) {
+	if !io.Exists(backup) {
+		backup = filepath.Join(r.GetDefaultPath(typ), backup)
+	}
+	if !io.Exists(backup) {
+		return "", errors.New(r.t.Get("backup file not exists"))
+	}
+
+	var identities []age.Identity
+	if identity != "" {
+		file, err := os.Open(identity)
+		if err != nil {
+			return "", err
+		}
+		parsed, err := age.ParseIdentities(file)
+		_ = file.Close()
+		if err != nil {
+			return "", errors.New(r.t.Get("invalid identity file: %v", err))
+		}
+		identities = append(identities, parsed...)
+	}
+	var storages []*biz.BackupStorage
+	if err := r.db.Find(&storages).Error; err != nil {
+		return "", err
+	}
+	for _, item := range storages {
+		if item.Info.Passphrase == "" {
+			continue
+		}
+		if scrypt, err := age.NewScryptIdentity(item.Info.Passphrase); err == nil {
+			identities = append(identities, scrypt)
+		}
+	}
+	if len(identities) == 0 {
+		return "", errors.New(r.t.Get("backup is encrypted and no passphrase or identity is available to decrypt it"))
+	}
+
+	in, err := os.Open(backup)
+	if err != nil {
+		return "", err
+	}
+	defer func(in *os.File) { _ = in.Close() }(in)
+	reader, err := age.Decrypt(in, identities...)
+	if err != nil {
+		return "", errors.New(r.t.Get("failed to decrypt backup: %v", err))
+	}
+
+	tmpDir, err := r.tmpDir()
+	if err != nil {
+		return "", err
+	}
+	decrypted := filepath.Join(tmpDir, strings.TrimSuffix(filepath.Base(backup), storage.EncryptedExt))
+	out, err := os.OpenFile(decrypted, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err == nil {
+		_, err = stdio.Copy(out, reader)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return "", errors.New(r.t.Get("failed to decrypt backup: %v", err))
+	}
+
+	return decrypted, nil
+}
+
+// RestoreSnapshot 从去重快照恢复
+// storage 备份存储ID，0 为本地存储
+// name 快照名称
+// target 网站名称或目录，目录为空时恢复到快照的原目录
+func (r *backupRepo) RestoreSnapshot(storage uint, typ biz.BackupType, name, target string) error {
+	backupStorage, client, err := r.storageClient(storage, typ)
+	if err != nil {
+		return err
+	}
+	repo, err := r.dedupRepo(client, typ, backupStorage.Info.Passphrase)
+	if err != nil {
+		return err
+	}
+	snapshot, err := repo.Snapshot(name)
+	if err != nil {
+		return err
+	}
+	if target == "" {
+		target = snapshot.Target
+	}
+
+	start := time.Now()
+	if app.IsCli {
+		fmt.Println(r.hr)
+		fmt.Println(r.t.Get("★ Start restore [%s]", start.Format(time.DateTime)))
+		fmt.Println(r.hr)
+		fmt.Println(r.t.Get("|-Restore type: %s", string(typ)))
+		fmt.Println(r.t.Get("|-Restore target: %s", target))
+		fmt.Println(r.t.Get("|-Backup storage: %s", backupStorage.Name))
+		fmt.Println(r.t.Get("|-Backup file: %s", snapshot.Name+dedup.SnapshotExt))
+	}
+
+	switch typ {
+	case biz.BackupTypeWebsite:
+		err = r.restoreWebsiteSnapshot(repo, snapshot.Name, target)
+	case biz.BackupTypePath:
+		err = r.restorePathSnapshot(repo, snapshot.Name, target)
+	default:
+		if app.IsCli {
+			fmt.Println(r.hr)
+		}
+		return errors.New(r.t.Get("deduplicated backups only support website and path"))
+	}
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Restore time: %s", time.Since(start).String()))
+		fmt.Println(r.hr)
+		if err != nil {
+			fmt.Println(r.t.Get("☆ Restore failed: %v [%s]", err, time.Now().Format(time.DateTime)))
+		} else {
+			fmt.Println(r.t.Get("☆ Restore completed [%s]", time.Now().Format(time.DateTime)))
+		}
+		fmt.Println(r.hr)
+	}
+
+	return err
+}
+
+// Verify 从备份存储重新下载备份，比对校验清单并试列压缩包内容
+// storage 备份存储ID，0 为本地存储
+// name 备份文件名
+// identity age 私钥文件，公钥加密的备份需要提供才能试列内容
+func (r *backupRepo) Verify(storage uint, typ biz.BackupType, name, identity string) (*types.BackupVerify, error) {
+	backupStorage, client, err := r.storageClient(storage, typ)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.checkArchive(backupStorage, client, typ, name); err != nil {
+		return nil, err
+	}
+	if app.IsCli {
+		fmt.Println(r.hr)
+		fmt.Println(r.t.Get("★ Start verify [%s]", time.Now().Format(time.DateTime)))
+		fmt.Println(r.hr)
+		fmt.Println(r.t.Get("|-Backup storage: %s", backupStorage.Name))
+		fmt.Println(r.t.Get("|-Backup file: %s", name))
+	}
+
+	var result *types.BackupVerify
+	if strings.HasSuffix(name, dedup.SnapshotExt) {
+		result, err = r.verifySnapshot(client, typ, name, backupStorage.Info.Passphrase)
+	} else {
+		result, err = r.verify(client, typ, name, identity)
+	}
+	if app.IsCli {
+		fmt.Println(r.hr)
+		if err != nil {
+			fmt.Println(r.t.Get("☆ Verify failed: %v [%s]", err, time.Now().Format(time.DateTime)))
+		} else {
+			fmt.Println(r.t.Get("☆ Verify completed [%s]", time.Now().Format(time.DateTime)))
+		}
+		fmt.Println(r.hr)
+	}
+
+	return result, err
+}
+
+// verify 下载并校验备份，校验通过的本地副本随即删除
+func (r *backupRepo) verify(client storage.Storage, typ biz.BackupType, name, identity string) (*types.BackupVerify, error) {
+	_, cleanup, result, err := r.download(client, typ, name, identity)
+	if err != nil {
+		return result, err
+	}
+	cleanup()
+
+	return result, nil
+}
+
+// verifySnapshot 读取去重快照引用的全部数据块并逐块校验哈希
+func (r *backupRepo) verifySnapshot(client storage.Storage, typ biz.BackupType, name, passphrase string) (*types.BackupVerify, error) {
+	result := &types.BackupVerify{Name: name}
+	repo, err := r.dedupRepo(client, typ, passphrase)
+	if err != nil {
+		return result, err
+	}
+	snapshot, err := repo.Verify(name)
+	if err != nil {
+		return result, errors.New(r.t.Get("failed to verify snapshot: %v", err))
+	}
+
+	result.Size = tools.FormatBytes(float64(snapshot.Size))
+	result.Entries = snapshot.Files
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-File size: %s", result.Size))
+		fmt.Println(r.t.Get("|-Verified chunks: %d", len(snapshot.Chunks)))
+		fmt.Println(r.t.Get("|-Archive entries: %d", result.Entries))
+	}
+
+	return result, nil
+}
+
+// download 下载备份到临时目录并校验，返回可直接恢复的本地文件（加密备份已解密）
+// 调用方需在使用完文件后调用 cleanup
+func (r *backupRepo) download(client storage.Storage, typ biz.BackupType, name, identity string) (string, func(), *types.BackupVerify, error) {
+	result := &types.BackupVerify{Name: name}
+	remote := filepath.Join(string(typ), name)
+
+	expected, err := storage.ReadChecksum(client, remote)
+	switch {
+	case err == nil:
+		result.Expected = expected
+	case client.Exists(remote + storage.ChecksumExt):
+		return "", nil, result, err
+	default:
+		// 旧版本创建或手动上传的备份没有校验清单，只能试列内容
+		if app.IsCli {
+			fmt.Println(r.t.Get("|-No checksum manifest, skipping hash check"))
+		}
+	}
+
+	tmpDir, err := r.tmpDir()
+	if err != nil {
+		return "", nil, result, err
+	}
+	cleanup := func() { _ = os.RemoveAll(tmpDir) }
+	fail := func(err error) (string, func(), *types.BackupVerify, error) {
+		cleanup()
+		return "", nil, result, err
+	}
+
+	// 下载时同步计算哈希
+	reader, err := client.Get(remote)
+	if err != nil {
+		return fail(err)
+	}
+	local := filepath.Join(tmpDir, name)
+	out, err := os.OpenFile(local, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		_ = reader.Close()
+		return fail(err)
+	}
+	hash := sha256.New()
+	size, err := stdio.Copy(stdio.MultiWriter(out, hash), reader)
+	_ = reader.Close()
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fail(errors.New(r.t.Get("failed to download backup: %v", err)))
+	}
+	result.Size = tools.FormatBytes(float64(size))
+	result.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-File size: %s", result.Size))
+		fmt.Println(r.t.Get("|-SHA-256: %s", result.SHA256))
+	}
+	if result.Expected != "" && result.Expected != result.SHA256 {
+		return fail(errors.New(r.t.Get("checksum mismatch: expected %s, got %s", result.Expected, result.SHA256)))
+	}
+
+	// 加密备份解密后再试列
+	if strings.HasSuffix(local, storage.EncryptedExt) {
+		decrypted, err := r.Decrypt(typ, local, identity)
+		if err != nil {
+			return fail(err)
+		}
+		cleanup = func() {
+			_ = os.RemoveAll(tmpDir)
+			_ = os.RemoveAll(filepath.Dir(decrypted))
+		}
+		local = decrypted
+	}
+
+	entries, err := io.ListCompress(local)
+	if err != nil {
+		return fail(errors.New(r.t.Get("failed to list backup archive: %v", err)))
+	}
+	entries = slices.DeleteFunc(entries, func(entry string) bool { return strings.TrimSpace(entry) == "" })
+	if len(entries) == 0 {
+		return fail(errors.New(r.t.Get("backup archive is empty")))
+	}
+	result.Entries = len(entries)
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Archive entries: %d", result.Entries))
+	}
+
+	return local, cleanup, result, nil
+}
+
+// TestRestore 将备份存储中目标最新的数据库备份恢复到同一服务器上的临时数据库，完成后删除临时库
+// 返回所用的备份文件名
+func (r *backupRepo) TestRestore(storage uint, typ biz.BackupType, target string) (string, error) {
+	if typ != biz.BackupTypeMySQL && typ != biz.BackupTypePostgres {
+		return "", errors.New(r.t.Get("test restore only supports mysql and postgresql"))
+	}
+
+	backupStorage, client, err := r.storageClient(storage, typ)
+	if err != nil {
+		return "", err
+	}
+	name, err := r.latestBackup(client, typ, target)
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	if app.IsCli {
+		fmt.Println(r.hr)
+		fmt.Println(r.t.Get("★ Start test restore [%s]", start.Format(time.DateTime)))
+		fmt.Println(r.hr)
+		fmt.Println(r.t.Get("|-Restore type: %s", string(typ)))
+		fmt.Println(r.t.Get("|-Backup storage: %s", backupStorage.Name))
+		fmt.Println(r.t.Get("|-Backup file: %s", name))
+	}
+
+	err = r.testRestore(client, typ, name)
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Restore time: %s", time.Since(start).String()))
+		fmt.Println(r.hr)
+		if err != nil {
+			fmt.Println(r.t.Get("☆ Test restore failed: %v [%s]", err, time.Now().Format(time.DateTime)))
+		} else {
+			fmt.Println(r.t.Get("☆ Test restore completed [%s]", time.Now().Format(time.DateTime)))
+		}
+		fmt.Println(r.hr)
+	}
+
+	return name, err
+}
+
+func (r *backupRepo) testRestore(client storage.Storage, typ biz.BackupType, name string) error {
+	file, cleanup, _, err := r.download(client, typ, name, "")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	var operator db.Operator
+	switch typ {
+	case biz.BackupTypeMySQL:
+		rootPassword, err := r.setting.Get(biz.SettingKeyMySQLRootPassword)
+		if err != nil {
+			return err
+		}
+		operator, err = db.NewMySQL(context.Background(), "root", rootPassword, db.MySQLSocket(app.Root), "unix")
+		if err != nil {
+			return err
+		}
+	case biz.BackupTypePostgres:
+		postgresPassword, err := r.setting.Get(biz.SettingKeyPostgresPassword)
+		if err != nil {
+			return err
+		}
+		operator, err = db.NewPostgres(context.Background(), "postgres", postgresPassword, "127.0.0.1", db.PostgresPort(app.Root))
+		if err != nil {
+			return err
+		}
+	}
+	defer operator.Close()
+
+	// 临时库名不含目标库名，避免超出长度限制
+	database := "ace_test_restore_" + time.Now().Format("20060102150405")
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Temporary database: %s", database))
+	}
+	if err = operator.DatabaseCreate(database); err != nil {
+		return err
+	}
+	defer func() {
+		if dropErr := operator.DatabaseDrop(database); dropErr != nil {
+			r.log.Warn("failed to drop test restore database", slog.String("database", database), slog.Any("err", dropErr))
+		}
+	}()
+
+	if typ == biz.BackupTypeMySQL {
+		return r.restoreMySQL(file, database)
+	}
+	return r.restorePostgres(file, database)
+}
+
+// latestBackup 备份存储中目标最新的备份文件名，备份名格式为 <目标>_<时间>.<扩展名>
+func (r *backupRepo) latestBackup(client storage.Storage, typ biz.BackupType, target string) (string, error) {
+	files, err := client.List(string(typ))
+	if err != nil {
+		return "", err
+	}
+
+	var latest string
+	var latestTime time.Time
+	for _, file := range files {
+		stamp, ok := strings.CutPrefix(file, target+"_")
+		if !ok || len(stamp) < 15 || stamp[14] != '.' || !r.isBackupArchive(file) {
+			continue
+		}
+		created, err := time.ParseInLocation("20060102150405", stamp[:14], time.Local)
+		if err != nil {
+			continue
+		}
+		if latest == "" || created.After(latestTime) {
+			latest, latestTime = file, created
+		}
+	}
+	if latest == "" {
+		return "", errors.New(r.t.Get("no backup found for %s", target))
+	}
+
+	return latest, nil
+}
+
 // GetDefaultPath 获取默认备份路径
 func (r *backupRepo) GetDefaultPath(typ biz.BackupType) string {
 	backupPath, err := r.setting.Get(biz.SettingKeyBackupPath)
@@ -385,6 +975,17 @@ func (r *backupRepo) CutoffUpload(account uint, typ biz.BackupType, name string,
 // prefix 目标文件前缀
 // save 保存份数
 func (r *backupRepo) ClearExpired(path, prefix string, save uint) error {
+	// 网站和目录备份还需清理去重快照，仓库位于备份根目录下
+	if typ := biz.BackupType(filepath.Base(path)); typ == biz.BackupTypeWebsite || typ == biz.BackupTypePath {
+		client, err := storage.NewLocal(filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		if err = r.pruneSnapshots(client, typ, "", prefix, save); err != nil {
+			return err
+		}
+	}
+
 	files, err := os.ReadDir(path)
 	if err != nil {
 		return err
@@ -425,6 +1026,9 @@ func (r *backupRepo) ClearExpired(path, prefix string, save uint) error {
 		if err = os.Remove(filePath); err != nil {
 			return errors.New(r.t.Get("Cleanup failed: %v", err))
 		}
+		if err = os.Remove(filePath + storage.ChecksumExt); err != nil && !os.IsNotExist(err) {
+			return errors.New(r.t.Get("Cleanup failed: %v", err))
+		}
 	}
 
 	return nil
@@ -434,8 +1038,8 @@ func (r *backupRepo) ClearExpired(path, prefix string, save uint) error {
 // dir 存储器内的目标目录，备份为 <类型>，切割日志为 cutoff/<类型>/<目标>
 // prefix 目标文件前缀
 // save 保存份数
-func (r *backupRepo) ClearStorageExpired(storage uint, dir, prefix string, save uint) error {
-	backupStorage, err := r.GetStorage(storage)
+func (r *backupRepo) ClearStorageExpired(account uint, dir, prefix string, save uint) error {
+	backupStorage, err := r.GetStorage(account)
 	if err != nil {
 		return err
 	}
@@ -445,6 +1049,13 @@ func (r *backupRepo) ClearStorageExpired(storage uint, dir, prefix string, save
 		return err
 	}
 
+	// 公钥加密的存储不支持去重快照
+	if typ := biz.BackupType(dir); (typ == biz.BackupTypeWebsite || typ == biz.BackupTypePath) && backupStorage.Info.PublicKey == "" {
+		if err = r.pruneSnapshots(client, typ, backupStorage.Info.Passphrase, prefix, save); err != nil {
+			return err
+		}
+	}
+
 	files, err := client.List(dir)
 	if err != nil {
 		return err
@@ -479,17 +1090,102 @@ func (r *backupRepo) ClearStorageExpired(storage uint, dir, prefix string, save
 		return nil
 	}
 
-	// 切片保留 save 份，删除剩余
-	toDelete := filtered[save:]
-	for _, file := range toDelete {
-		filePath := filepath.Join(dir, file.name)
-		if app.IsCli {
-			fmt.Println(r.t.Get("|-Cleaning expired file: %s", filePath))
-		}
-		if err = client.Delete(filePath); err != nil {
-			return errors.New(r.t.Get("Cleanup failed: %v", err))
+	// 切片保留 save 份，删除剩余
+	toDelete := filtered[save:]
+	for _, file := range toDelete {
+		filePath := filepath.Join(dir, file.name)
+		if app.IsCli {
+			fmt.Println(r.t.Get("|-Cleaning expired file: %s", filePath))
+		}
+		if err = client.Delete(filePath); err != nil {
+			return errors.New(r.t.Get("Cleanup failed: %v", err))
+		}
+		if client.Exists(filePath + storage.ChecksumExt) {
+			if err = client.Delete(filePath + storage.ChecksumExt); err != nil {
+				return errors.New(r.t.Get("Cleanup failed: %v", err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// storageClient 获取备份存储及其存储器，0 为本地存储
+func (r *backupRepo) storageClient(id uint, typ biz.BackupType) (*biz.BackupStorage, storage.Storage, error) {
+	var backupStorage *biz.BackupStorage
+	if id != 0 {
+		var err error
+		backupStorage, err = r.GetStorage(id)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		backupStorage = &biz.BackupStorage{
+			Name: r.t.Get("Local Storage"),
+			Type: biz.BackupStorageTypeLocal,
+			Info: types.BackupStorageInfo{
+				Path: filepath.Dir(r.GetDefaultPath(typ)), // 需要取根目录
+			},
+		}
+	}
+
+	client, err := r.getStorage(*backupStorage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return backupStorage, client, nil
+}
+
+// dedupRepo 获取存储器中指定类型的去重仓库，位于 dedup/<类型>
+// passphrase 为存储的加密口令，为空时为明文仓库
+func (r *backupRepo) dedupRepo(client storage.Storage, typ biz.BackupType, passphrase string) (*dedup.Repository, error) {
+	repo, err := dedup.Open(client, filepath.Join("dedup", string(typ)), passphrase)
+	if errors.Is(err, dedup.ErrWrongPassphrase) {
+		return nil, errors.New(r.t.Get("backup storage passphrase does not match the existing snapshots"))
+	}
+
+	return repo, err
+}
+
+// encryptedClient 存储配置了加密时返回上传前加密的存储器
+func (r *backupRepo) encryptedClient(backupStorage *biz.BackupStorage, client storage.Storage) (storage.Storage, error) {
+	var recipients []age.Recipient
+	switch {
+	case backupStorage.Info.Passphrase != "":
+		recipient, err := age.NewScryptRecipient(backupStorage.Info.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	case backupStorage.Info.PublicKey != "":
+		parsed, err := age.ParseRecipients(strings.NewReader(backupStorage.Info.PublicKey))
+		if err != nil {
+			return nil, errors.New(r.t.Get("invalid public key: %v", err))
+		}
+		recipients = append(recipients, parsed...)
+	default:
+		return client, nil
+	}
+
+	return storage.NewEncrypted(client, recipients...), nil
+}
+
+// pruneSnapshots 保留目标最新的 save 个快照并回收数据块
+func (r *backupRepo) pruneSnapshots(client storage.Storage, typ biz.BackupType, passphrase, prefix string, save uint) error {
+	repo, err := r.dedupRepo(client, typ, passphrase)
+	if err != nil {
+		return errors.New(r.t.Get("Cleanup failed: %v", err))
+	}
+	removed, err := repo.Prune(prefix, save)
+	if app.IsCli {
+		for _, name := range removed {
+			fmt.Println(r.t.Get("|-Cleaning expired snapshot: %s", name))
 		}
 	}
+	if err != nil {
+		return errors.New(r.t.Get("Cleanup failed: %v", err))
+	}
 
 	return nil
 }
@@ -573,6 +1269,42 @@ func (r *backupRepo) createWebsite(name string, storage storage.Storage, target
 	return nil
 }
 
+// createSnapshot 创建去重快照备份，只上传存储中不存在的数据块
+func (r *backupRepo) createSnapshot(name string, storage storage.Storage, typ biz.BackupType, target, passphrase string) error {
+	root := target
+	if typ == biz.BackupTypeWebsite {
+		website, err := r.website.GetByName(target)
+		if err != nil {
+			return err
+		}
+		root = website.Path
+	} else {
+		if !io.Exists(target) {
+			return errors.New(r.t.Get("path does not exist: %s", target))
+		}
+		if !io.IsDir(target) {
+			return errors.New(r.t.Get("path is not a directory: %s", target))
+		}
+	}
+
+	repo, err := r.dedupRepo(storage, typ, passphrase)
+	if err != nil {
+		return err
+	}
+	snapshot, stats, err := repo.Backup(string(typ), target, name, root)
+	if err != nil {
+		return err
+	}
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Backup file: %s", name+dedup.SnapshotExt))
+		fmt.Println(r.t.Get("|-Files: %d, size: %s", snapshot.Files, tools.FormatBytes(float64(snapshot.Size))))
+		fmt.Println(r.t.Get("|-Chunks: %d, new: %d, uploaded: %s", stats.Chunks, stats.NewChunks, tools.FormatBytes(float64(stats.NewSize))))
+	}
+
+	return nil
+}
+
 // createMySQL 创建 MySQL 备份
 func (r *backupRepo) createMySQL(name string, storage storage.Storage, target string) error {
 	rootPassword, err := r.setting.Get(biz.SettingKeyMySQLRootPassword)
@@ -816,6 +1548,155 @@ func (r *backupRepo) clickHouseTables(conn, database string, onlyView bool) ([]s
 	return tables, nil
 }
 
+// mongoArgs 本地 MongoDB 的连接参数，密码写入 0600 的配置文件，避免出现在进程列表中
+func (r *backupRepo) mongoArgs(dir string) ([]string, error) {
+	password, err := r.setting.Get(biz.SettingKeyMongoDBAdminPassword)
+	if err != nil {
+		return nil, err
+	}
+	config := filepath.Join(dir, "mongo.yml")
+	if err = os.WriteFile(config, []byte("password: "+strconv.Quote(password)+"\n"), 0600); err != nil {
+		return nil, err
+	}
+
+	return []string{
+		"--config=" + config,
+		"--host=127.0.0.1",
+		fmt.Sprintf("--port=%d", db.MongoDBPort(app.Root)),
+		"--username=admin",
+		"--authenticationDatabase=admin",
+	}, nil
+}
+
+// createMongoDB 创建 MongoDB 备份，使用 mongodump 导出单个数据库的归档
+func (r *backupRepo) createMongoDB(name string, storage storage.Storage, target string) error {
+	password, err := r.setting.Get(biz.SettingKeyMongoDBAdminPassword)
+	if err != nil {
+		return err
+	}
+	mongo, err := db.NewMongoDB(context.Background(), "admin", password, fmt.Sprintf("127.0.0.1:%d", db.MongoDBPort(app.Root)))
+	if err != nil {
+		return err
+	}
+	databases, err := mongo.Databases()
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(databases, func(item db.MongoDatabase) bool { return item.Name == target }) {
+		return errors.New(r.t.Get("database does not exist: %s", target))
+	}
+
+	// 创建用于压缩的临时目录
+	tmpDir, err := r.tmpDir()
+	if err != nil {
+		return err
+	}
+	defer func(path string) { _ = os.RemoveAll(path) }(tmpDir)
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Temporary directory: %s", tmpDir))
+	}
+
+	// 导出数据库
+	args, err := r.mongoArgs(tmpDir)
+	if err != nil {
+		return err
+	}
+	name += ".archive"
+	if _, err = shell.Execf("mongodump %s --db='%s' --archive='%s'", strings.Join(args, " "), target, filepath.Join(tmpDir, name)); err != nil {
+		return err
+	}
+
+	// 压缩备份文件
+	if err = io.Compress(tmpDir, []string{name}, filepath.Join(tmpDir, name+r.backupExt())); err != nil {
+		return err
+	}
+
+	// 上传备份文件到存储器
+	name += r.backupExt()
+	file, err := os.Open(filepath.Join(tmpDir, name))
+	if err != nil {
+		return err
+	}
+	defer func(file *os.File) { _ = file.Close() }(file)
+
+	if err = storage.Put(filepath.Join(string(biz.BackupTypeMongoDB), name), file); err != nil {
+		return err
+	}
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Backup file: %s", name))
+	}
+
+	return nil
+}
+
+// elasticsearch 连接本地 Elasticsearch
+func (r *backupRepo) elasticsearch() (*db.Elasticsearch, error) {
+	return db.NewElasticsearch(context.Background(), fmt.Sprintf("127.0.0.1:%d", db.ElasticsearchPort(app.Root)), "", "")
+}
+
+// createElasticsearch 创建 Elasticsearch 备份，以 scroll 方式把索引导出为 NDJSON
+// 快照仓库需要在 elasticsearch.yml 中配置 path.repo 并重启，scroll 导出无需改动服务
+func (r *backupRepo) createElasticsearch(name string, storage storage.Storage, target string) error {
+	es, err := r.elasticsearch()
+	if err != nil {
+		return err
+	}
+	defer es.Close()
+
+	// 创建用于压缩的临时目录
+	tmpDir, err := r.tmpDir()
+	if err != nil {
+		return err
+	}
+	defer func(path string) { _ = os.RemoveAll(path) }(tmpDir)
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Temporary directory: %s", tmpDir))
+	}
+
+	// 导出索引
+	name += ".ndjson"
+	out, err := os.OpenFile(filepath.Join(tmpDir, name), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	count, err := es.Export(target, out)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Exported documents: %d", count))
+	}
+
+	// 压缩备份文件
+	if err = io.Compress(tmpDir, []string{name}, filepath.Join(tmpDir, name+r.backupExt())); err != nil {
+		return err
+	}
+
+	// 上传备份文件到存储器
+	name += r.backupExt()
+	file, err := os.Open(filepath.Join(tmpDir, name))
+	if err != nil {
+		return err
+	}
+	defer func(file *os.File) { _ = file.Close() }(file)
+
+	if err = storage.Put(filepath.Join(string(biz.BackupTypeElasticsearch), name), file); err != nil {
+		return err
+	}
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Backup file: %s", name))
+	}
+
+	return nil
+}
+
 // createPath 创建目录备份
 func (r *backupRepo) createPath(name string, storage storage.Storage, target string) error {
 	if !io.Exists(target) {
@@ -902,29 +1783,111 @@ func (r *backupRepo) restoreWebsite(backup, target string) error {
 		return err
 	}
 
+	return r.replaceWebsite(website.Path, content)
+}
+
+// restoreWebsiteSnapshot 从去重快照恢复网站
+func (r *backupRepo) restoreWebsiteSnapshot(repo *dedup.Repository, name, target string) error {
+	website, err := r.website.GetByName(target)
+	if err != nil {
+		return err
+	}
+
+	// 与归档恢复一致，先还原到同级暂存目录再替换
+	stage, err := os.MkdirTemp(filepath.Dir(website.Path), ".ace-restore-*")
+	if err != nil {
+		return err
+	}
+	defer func(path string) { _ = os.RemoveAll(path) }(stage)
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Website path: %s", website.Path))
+		fmt.Println(r.t.Get("|-Rebuilding snapshot..."))
+	}
+	if err = repo.Restore(name, stage); err != nil {
+		return err
+	}
+
+	return r.replaceWebsite(website.Path, stage)
+}
+
+// replaceWebsite 用 content 目录替换网站目录并修复权限
+func (r *backupRepo) replaceWebsite(path, content string) error {
 	if app.IsCli {
 		fmt.Println(r.t.Get("|-Replacing website files..."))
 	}
-	if err = io.Remove(website.Path); err != nil {
+	if err := io.Remove(path); err != nil {
 		return err
 	}
-	if err = os.Rename(content, website.Path); err != nil {
+	if err := os.Rename(content, path); err != nil {
 		return err
 	}
 
 	if app.IsCli {
 		fmt.Println(r.t.Get("|-Fixing file permissions..."))
 	}
-	if err = io.Chmod(website.Path, 0755); err != nil {
+	if err := io.Chmod(path, 0755); err != nil {
 		return err
 	}
-	if err = io.Chown(website.Path, "www", "www"); err != nil {
+	if err := io.Chown(path, "www", "www"); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// restorePathSnapshot 从去重快照恢复目录
+func (r *backupRepo) restorePathSnapshot(repo *dedup.Repository, name, target string) error {
+	if !filepath.IsAbs(target) {
+		return errors.New(r.t.Get("path must be absolute: %s", target))
+	}
+	target = filepath.Clean(target)
+	info, err := os.Stat(target)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if info != nil && !info.IsDir() {
+		return errors.New(r.t.Get("path is not a directory: %s", target))
+	}
+	if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	stage, err := os.MkdirTemp(filepath.Dir(target), ".ace-restore-*")
+	if err != nil {
+		return err
+	}
+	defer func(path string) { _ = os.RemoveAll(path) }(stage)
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Rebuilding snapshot..."))
+	}
+	if err = repo.Restore(name, stage); err != nil {
+		return err
+	}
+
+	// 快照不含根目录自身属性，沿用原目录的权限与属主
+	mode := os.FileMode(0755)
+	if info != nil {
+		mode = info.Mode().Perm()
+		if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+			_ = os.Lchown(stage, int(stat.Uid), int(stat.Gid))
+		}
+	}
+	if err = os.Chmod(stage, mode); err != nil {
+		return err
+	}
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Replacing files..."))
+	}
+	if err = io.Remove(target); err != nil {
+		return err
+	}
+
+	return os.Rename(stage, target)
+}
+
 // importFile 把备份文件喂给数据库客户端 stdin，并按秒级周期打印大致进度
 // pipe 有背压，已写入字节数 ≈ 客户端已消费字节数，足以作为进度参考
 func (r *backupRepo) importFile(path, name string, env, args []string) error {
@@ -1037,6 +2000,72 @@ func (r *backupRepo) restorePostgres(backup, target string) error {
 		[]string{"-h", "127.0.0.1", "-p", cast.ToString(port), "-U", "postgres", "-v", "ON_ERROR_STOP=1", "--single-transaction", "--dbname=" + target})
 }
 
+// restoreMongoDB 恢复 MongoDB 备份，归档中的数据库整体改名为目标库并覆盖同名集合
+func (r *backupRepo) restoreMongoDB(backup, target string) error {
+	backup, cleanDir, err := r.prepareDatabaseBackup(backup, target)
+	if err != nil {
+		return err
+	}
+	if cleanDir != "" {
+		defer func() { _ = os.RemoveAll(cleanDir) }()
+		if app.IsCli {
+			fmt.Println(r.t.Get("|-Uncompressing backup..."))
+		}
+	}
+
+	tmpDir, err := r.tmpDir()
+	if err != nil {
+		return err
+	}
+	defer func(path string) { _ = os.RemoveAll(path) }(tmpDir)
+	args, err := r.mongoArgs(tmpDir)
+	if err != nil {
+		return err
+	}
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Importing archive into database..."))
+	}
+	// 不经过 shell，$db$ 等为 mongorestore 的命名空间变量
+	return r.importFile(backup, "mongorestore", nil, append(args, "--archive", "--drop", "--nsFrom=$db$.$coll$", "--nsTo="+target+".$coll$"))
+}
+
+// restoreElasticsearch 恢复 Elasticsearch 备份，目标索引会被重建
+func (r *backupRepo) restoreElasticsearch(backup, target string) error {
+	backup, cleanDir, err := r.prepareDatabaseBackup(backup, target)
+	if err != nil {
+		return err
+	}
+	if cleanDir != "" {
+		defer func() { _ = os.RemoveAll(cleanDir) }()
+		if app.IsCli {
+			fmt.Println(r.t.Get("|-Uncompressing backup..."))
+		}
+	}
+
+	es, err := r.elasticsearch()
+	if err != nil {
+		return err
+	}
+	defer es.Close()
+
+	file, err := os.Open(backup)
+	if err != nil {
+		return err
+	}
+	defer func(file *os.File) { _ = file.Close() }(file)
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Importing documents into index..."))
+	}
+	count, err := es.Import(target, file)
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Imported documents: %d", count))
+	}
+
+	return err
+}
+
 // restoreClickHouse 恢复 ClickHouse 备份
 func (r *backupRepo) restoreClickHouse(backup, target string) error {
 	password, err := r.setting.Get(biz.SettingKeyClickHouseDefaultPassword)
@@ -1466,7 +2495,7 @@ func (r *backupRepo) selectDatabaseBackup(root, target string) (string, error) {
 	candidates := make([]string, 0, len(files))
 	for _, file := range files {
 		lower := strings.ToLower(file)
-		if strings.HasSuffix(lower, ".sql") || strings.HasSuffix(lower, ".dump") || strings.HasSuffix(lower, ".backup") || strings.HasSuffix(lower, ".bak") || r.databaseArchiveExt(file) != "" || r.postgresArchive(file) {
+		if strings.HasSuffix(lower, ".sql") || strings.HasSuffix(lower, ".archive") || strings.HasSuffix(lower, ".ndjson") || strings.HasSuffix(lower, ".dump") || strings.HasSuffix(lower, ".backup") || strings.HasSuffix(lower, ".bak") || r.databaseArchiveExt(file) != "" || r.postgresArchive(file) {
 			candidates = append(candidates, file)
 		}
 	}
@@ -1948,6 +2977,7 @@ func (r *backupRepo) backupExt() string {
 
 // isBackupArchive 判断文件名是否是已知的备份压缩包后缀
 func (r *backupRepo) isBackupArchive(name string) bool {
+	name = strings.TrimSuffix(name, storage.EncryptedExt)
 	for _, ext := range []string{".tar.xz", ".tar.gz", ".tar.zst", ".zip", ".7z"} {
 		if strings.HasSuffix(name, ext) {
 			return true
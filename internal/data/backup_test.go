@@ -0,0 +1,244 @@
+package data
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/leonelquinteros/gotext"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/pkg/storage"
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+func newBackupRepoForTest(t *testing.T) *backupRepo {
+	t.Helper()
+	db := newDBForTest(t)
+	if err := db.AutoMigrate(&biz.BackupStorage{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TMPDIR", t.TempDir())
+	return &backupRepo{t: gotext.NewLocale("", "en"), db: db}
+}
+
+// 口令以 app.Key 加密落库，备份用口令加密上传，恢复时自动用已配置的口令解密
+func TestBackupPassphraseEncryption(t *testing.T) {
+	repo := newBackupRepoForTest(t)
+	backupStorage := &biz.BackupStorage{Type: biz.BackupStorageTypeLocal, Name: "remote", Info: types.BackupStorageInfo{Passphrase: "s3cret"}}
+	if err := repo.db.Create(backupStorage).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var stored string
+	if err := repo.db.Raw("SELECT info FROM backup_storages WHERE id = ?", backupStorage.ID).Scan(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(stored, "s3cret") {
+		t.Fatalf("passphrase stored in plaintext: %s", stored)
+	}
+
+	got, err := repo.GetStorage(backupStorage.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	local, err := storage.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := repo.encryptedClient(got, local)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte("CREATE TABLE users (id int);")
+	if err = client.Put("mysql/app_20260101000000.sql.gz", bytes.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+	encrypted := filepath.Join(dir, "mysql/app_20260101000000.sql.gz"+storage.EncryptedExt)
+	raw, err := os.ReadFile(encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, content) {
+		t.Fatal("backup uploaded in plaintext")
+	}
+
+	decrypted, err := repo.Decrypt(biz.BackupTypeMySQL, encrypted, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(filepath.Dir(decrypted)) }()
+	if filepath.Base(decrypted) != "app_20260101000000.sql.gz" {
+		t.Fatalf("unexpected decrypted name: %s", decrypted)
+	}
+	plain, err := os.ReadFile(decrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plain, content) {
+		t.Fatalf("decrypted content mismatch: %q", plain)
+	}
+}
+
+// 公钥加密的备份面板无法自行解密，需要提供私钥文件
+func TestBackupPublicKeyEncryption(t *testing.T) {
+	repo := newBackupRepoForTest(t)
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 另一个存储的口令不应影响公钥备份的解密
+	other := &biz.BackupStorage{Type: biz.BackupStorageTypeLocal, Name: "other", Info: types.BackupStorageInfo{Passphrase: "other"}}
+	if err = repo.db.Create(other).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	local, err := storage.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := repo.encryptedClient(&biz.BackupStorage{Info: types.BackupStorageInfo{PublicKey: identity.Recipient().String()}}, local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Put("website/site_20260101000000.tar.zst", strings.NewReader("site")); err != nil {
+		t.Fatal(err)
+	}
+	encrypted := filepath.Join(dir, "website/site_20260101000000.tar.zst"+storage.EncryptedExt)
+
+	if _, err = repo.Decrypt(biz.BackupTypeWebsite, encrypted, ""); err == nil {
+		t.Fatal("decrypting without the identity should fail")
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "key.txt")
+	if err = os.WriteFile(keyFile, []byte(identity.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := repo.Decrypt(biz.BackupTypeWebsite, encrypted, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(filepath.Dir(decrypted)) }()
+	plain, err := os.ReadFile(decrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != "site" {
+		t.Fatalf("decrypted content mismatch: %q", plain)
+	}
+}
+
+// 上传时记录校验清单，校验时重新下载比对哈希并试列压缩包
+func TestBackupVerify(t *testing.T) {
+	repo := newBackupRepoForTest(t)
+	dir := t.TempDir()
+	local, err := storage.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	content := []byte("<?php echo 1;")
+	if err = tw.WriteHeader(&tar.Header{Name: "index.php", Mode: 0644, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err = tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	name := "site_20260101000000.tar.gz"
+	if err = storage.NewChecksum(local).Put("website/"+name, bytes.NewReader(archive.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	result, err := repo.verify(local, biz.BackupTypeWebsite, name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Expected == "" || result.Expected != result.SHA256 || result.Entries != 1 {
+		t.Fatalf("unexpected verify result: %+v", result)
+	}
+
+	// 篡改内容后哈希不再匹配
+	corrupted := archive.Bytes()
+	corrupted[len(corrupted)-1] ^= 0xff
+	if err = os.WriteFile(filepath.Join(dir, "website", name), corrupted, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = repo.verify(local, biz.BackupTypeWebsite, name, ""); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+}
+
+// 校验只接受备份目录下已存在的压缩包或去重快照，不接受路径与非备份后缀
+func TestBackupCheckArchive(t *testing.T) {
+	repo := newBackupRepoForTest(t)
+	local, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = local.Put("website/site_20260101000000.tar.gz", strings.NewReader("archive")); err != nil {
+		t.Fatal(err)
+	}
+	if err = local.Put("website/x';reboot;'.tar.gz", strings.NewReader("archive")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = repo.checkArchive(&biz.BackupStorage{}, local, biz.BackupTypeWebsite, "site_20260101000000.tar.gz"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		"site_20260102000000.tar.gz", // 不存在
+		"../website/site.tar.gz",     // 路径穿越
+		"site_20260101000000.sh",     // 非备份后缀
+		"site_20260101000000.snap",   // 不存在的去重快照
+		"site_20260101000000.tar.gz.sha256",
+	} {
+		if err = repo.checkArchive(&biz.BackupStorage{}, local, biz.BackupTypeWebsite, name); err == nil {
+			t.Fatalf("%s should be rejected", name)
+		}
+	}
+
+	// 含引号的文件名由调用方转义后传给命令行，这里只确认其确实存在
+	if err = repo.checkArchive(&biz.BackupStorage{}, local, biz.BackupTypeWebsite, "x';reboot;'.tar.gz"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 去重快照逐块校验
+	root := t.TempDir()
+	if err = os.WriteFile(filepath.Join(root, "index.html"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	snapshots, err := repo.dedupRepo(local, biz.BackupTypeWebsite, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = snapshots.Backup("website", "site", "site_20260101000000", root); err != nil {
+		t.Fatal(err)
+	}
+	if err = repo.checkArchive(&biz.BackupStorage{}, local, biz.BackupTypeWebsite, "site_20260101000000.snap"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := repo.verifySnapshot(local, biz.BackupTypeWebsite, "site_20260101000000.snap", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Entries != 1 {
+		t.Fatalf("expected 1 file, got %d", result.Entries)
+	}
+}
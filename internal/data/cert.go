@@ -145,6 +145,12 @@ func (r *certRepo) Delete(id uint) error {
 		if err := tx.Model(&biz.Website{}).Where("cert_id = ?", id).Update("cert_id", 0).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("cert_id = ?", id).Delete(&biz.CertDeployLog{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("cert_id = ?", id).Delete(&biz.CertDeployTarget{}).Error; err != nil {
+			return err
+		}
 		return tx.Delete(&biz.Cert{ID: id}).Error
 	})
 }
@@ -0,0 +1,584 @@
+package data
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/client"
+	"github.com/pkg/sftp"
+	cryptossh "golang.org/x/crypto/ssh"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/apps/confval"
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+	pkgio "github.com/acepanel/panel/v3/pkg/io"
+	pkgssh "github.com/acepanel/panel/v3/pkg/ssh"
+	"github.com/acepanel/panel/v3/pkg/storage"
+	"github.com/acepanel/panel/v3/pkg/systemctl"
+)
+
+type certDeployRepo struct {
+	t       *gotext.Locale
+	db      *gorm.DB
+	setting biz.SettingRepo
+	remote  biz.MigrationRemoteRepo
+}
+
+func NewCertDeployRepo(db *gorm.DB, t *gotext.Locale, setting biz.SettingRepo, remote biz.MigrationRemoteRepo) biz.CertDeployRepo {
+	return &certDeployRepo{
+		t:       t,
+		db:      db,
+		setting: setting,
+		remote:  remote,
+	}
+}
+
+func (r *certDeployRepo) List(certID uint) ([]*biz.CertDeployTarget, error) {
+	targets := make([]*biz.CertDeployTarget, 0)
+	err := r.db.Where("cert_id = ?", certID).Order("id asc").Find(&targets).Error
+	return targets, err
+}
+
+func (r *certDeployRepo) Get(id uint) (*biz.CertDeployTarget, error) {
+	target := new(biz.CertDeployTarget)
+	if err := r.db.Where("id = ?", id).First(target).Error; err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+func (r *certDeployRepo) Create(req *request.CertDeployTargetCreate) (*biz.CertDeployTarget, error) {
+	target := &biz.CertDeployTarget{
+		CertID: req.CertID,
+		Name:   req.Name,
+		Type:   biz.CertDeployType(req.Type),
+		Config: req.Config,
+		Auto:   req.Auto,
+	}
+	if err := r.check(target); err != nil {
+		return nil, err
+	}
+	if err := r.db.Create(target).Error; err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+func (r *certDeployRepo) Update(req *request.CertDeployTargetUpdate) error {
+	target, err := r.Get(req.ID)
+	if err != nil {
+		return err
+	}
+
+	// 类型变化后远程证书 ID 不再有效
+	if target.Type != biz.CertDeployType(req.Type) || target.Config.URL != req.Config.URL {
+		req.Config.RemoteCertID = 0
+	}
+	target.Name = req.Name
+	target.Type = biz.CertDeployType(req.Type)
+	target.Config = req.Config
+	target.Auto = req.Auto
+	if err = r.check(target); err != nil {
+		return err
+	}
+
+	return r.db.Save(target).Error
+}
+
+func (r *certDeployRepo) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("target_id = ?", id).Delete(&biz.CertDeployLog{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&biz.CertDeployTarget{}, id).Error
+	})
+}
+
+func (r *certDeployRepo) Save(target *biz.CertDeployTarget) error {
+	return r.db.Save(target).Error
+}
+
+func (r *certDeployRepo) AddLog(log *biz.CertDeployLog) error {
+	return r.db.Create(log).Error
+}
+
+func (r *certDeployRepo) ListLogs(targetID uint, page, limit uint) ([]*biz.CertDeployLog, int64, error) {
+	logs := make([]*biz.CertDeployLog, 0)
+	var total int64
+	err := r.db.Model(&biz.CertDeployLog{}).Where("target_id = ?", targetID).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&logs).Error
+	return logs, total, err
+}
+
+func (r *certDeployRepo) Push(ctx context.Context, target *biz.CertDeployTarget, cert *biz.Cert) error {
+	switch target.Type {
+	case biz.CertDeployTypeSSH:
+		return r.pushSSH(ctx, target, cert)
+	case biz.CertDeployTypePanel:
+		return r.pushPanel(ctx, target, cert)
+	case biz.CertDeployTypeS3:
+		return r.pushS3(target, cert)
+	case biz.CertDeployTypeDocker:
+		return r.pushDocker(ctx, target, cert)
+	case biz.CertDeployTypeApp:
+		return r.pushApp(target, cert)
+	default:
+		return errors.New(r.t.Get("unsupported deploy target type: %s", target.Type))
+	}
+}
+
+// check 检查各类型的必填配置
+func (r *certDeployRepo) check(target *biz.CertDeployTarget) error {
+	conf := target.Config
+	switch target.Type {
+	case biz.CertDeployTypeSSH:
+		if conf.SSHID == 0 || conf.CertPath == "" || conf.KeyPath == "" {
+			return errors.New(r.t.Get("ssh host, certificate path and key path are required"))
+		}
+		if err := r.db.Where("id = ?", conf.SSHID).First(&biz.SSH{}).Error; err != nil {
+			return errors.New(r.t.Get("ssh host not found"))
+		}
+	case biz.CertDeployTypePanel:
+		if conf.URL == "" || conf.TokenID == 0 || conf.Token == "" {
+			return errors.New(r.t.Get("panel url, token id and token are required"))
+		}
+	case biz.CertDeployTypeS3:
+		if conf.StorageID == 0 || conf.CertPath == "" || conf.KeyPath == "" {
+			return errors.New(r.t.Get("storage, certificate path and key path are required"))
+		}
+		storage := new(biz.BackupStorage)
+		if err := r.db.Where("id = ?", conf.StorageID).First(storage).Error; err != nil {
+			return errors.New(r.t.Get("storage not found"))
+		}
+		if storage.Type != biz.BackupStorageTypeS3 {
+			return errors.New(r.t.Get("only s3 storage can be used as a deploy target"))
+		}
+	case biz.CertDeployTypeDocker:
+		if (conf.Container == "") == (conf.Volume == "") {
+			return errors.New(r.t.Get("either a container or a volume is required"))
+		}
+		if conf.CertPath == "" || conf.KeyPath == "" {
+			return errors.New(r.t.Get("certificate path and key path are required"))
+		}
+		if conf.Volume != "" && (filepath.IsAbs(conf.CertPath) || filepath.IsAbs(conf.KeyPath) || strings.Contains(conf.CertPath, "..") || strings.Contains(conf.KeyPath, "..")) {
+			return errors.New(r.t.Get("paths in a volume must be relative"))
+		}
+		if conf.Volume != "" && (conf.Command != "" || conf.Restart) {
+			return errors.New(r.t.Get("command and restart are only available for containers"))
+		}
+	case biz.CertDeployTypeApp:
+		if conf.App == "" {
+			return errors.New(r.t.Get("app is required"))
+		}
+	}
+
+	return nil
+}
+
+// pushSSH 通过 SFTP 写入远程主机，然后执行重载命令
+func (r *certDeployRepo) pushSSH(ctx context.Context, target *biz.CertDeployTarget, cert *biz.Cert) error {
+	host := new(biz.SSH)
+	if err := r.db.Where("id = ?", target.Config.SSHID).First(host).Error; err != nil {
+		return errors.New(r.t.Get("ssh host not found"))
+	}
+
+	sshClient, err := pkgssh.NewSSHClient(host.Config)
+	if err != nil {
+		return errors.New(r.t.Get("failed to connect to %s: %v", host.Name, err))
+	}
+	defer func(sshClient *cryptossh.Client) { _ = sshClient.Close() }(sshClient)
+	// 超时后主动断开，避免卡住
+	stop := context.AfterFunc(ctx, func() { _ = sshClient.Close() })
+	defer stop()
+
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		return errors.New(r.t.Get("failed to open sftp session on %s: %v", host.Name, err))
+	}
+	defer func(sftpClient *sftp.Client) { _ = sftpClient.Close() }(sftpClient)
+
+	files := []struct {
+		path    string
+		content string
+		mode    os.FileMode
+	}{
+		{target.Config.CertPath, cert.Cert, 0644},
+		{target.Config.KeyPath, cert.Key, 0600},
+	}
+	for _, file := range files {
+		if err = sftpClient.MkdirAll(path.Dir(file.path)); err != nil {
+			return errors.New(r.t.Get("failed to create directory %s: %v", path.Dir(file.path), err))
+		}
+		f, err := sftpClient.OpenFile(file.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+		if err != nil {
+			return errors.New(r.t.Get("failed to write %s: %v", file.path, err))
+		}
+		// 先收紧权限再写入内容，私钥不会以默认权限出现在远程主机上
+		if err = f.Chmod(file.mode); err == nil {
+			_, err = f.Write([]byte(file.content))
+		}
+		_ = f.Close()
+		if err != nil {
+			return errors.New(r.t.Get("failed to write %s: %v", file.path, err))
+		}
+	}
+
+	if target.Config.Command == "" {
+		return nil
+	}
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return err
+	}
+	defer func(session *cryptossh.Session) { _ = session.Close() }(session)
+	output, err := session.CombinedOutput(target.Config.Command)
+	if err != nil {
+		return errors.New(r.t.Get("failed to run command: %v, output: %s", err, strings.TrimSpace(string(output))))
+	}
+
+	return nil
+}
+
+// pushPanel 推送到其他 AcePanel 面板，首次上传新证书，之后更新同一证书并部署到其绑定的网站
+func (r *certDeployRepo) pushPanel(ctx context.Context, target *biz.CertDeployTarget, cert *biz.Cert) error {
+	conn := &request.ToolboxMigrationConnection{
+		URL:     target.Config.URL,
+		TokenID: target.Config.TokenID,
+		Token:   target.Config.Token,
+	}
+
+	var remote biz.Cert
+	if target.Config.RemoteCertID != 0 {
+		body, err := r.remote.Request(ctx, conn, http.MethodGet, fmt.Sprintf("/api/cert/cert/%d", target.Config.RemoteCertID), nil)
+		if err == nil {
+			err = r.unwrap(body, &remote)
+		}
+		if err != nil {
+			// 远程证书已被删除时重新上传
+			target.Config.RemoteCertID = 0
+		}
+	}
+
+	if target.Config.RemoteCertID == 0 {
+		body, err := r.remote.Request(ctx, conn, http.MethodPost, "/api/cert/cert/upload", request.CertUpload{Cert: cert.Cert, Key: cert.Key})
+		if err != nil {
+			return errors.New(r.t.Get("failed to upload certificate to remote panel: %v", err))
+		}
+		if err = r.unwrap(body, &remote); err != nil {
+			return err
+		}
+		target.Config.RemoteCertID = remote.ID
+		return nil
+	}
+
+	update := request.CertUpdate{
+		ID:          remote.ID,
+		Type:        "upload",
+		Domains:     remote.Domains,
+		Alias:       remote.Alias,
+		Cert:        cert.Cert,
+		Key:         cert.Key,
+		Script:      remote.Script,
+		AutoRenewal: false,
+		WebsiteIDs:  remote.WebsiteIDs(),
+	}
+	if len(update.Domains) == 0 {
+		update.Domains = cert.Domains
+	}
+	if _, err := r.remote.Request(ctx, conn, http.MethodPut, fmt.Sprintf("/api/cert/cert/%d", remote.ID), update); err != nil {
+		return errors.New(r.t.Get("failed to update certificate on remote panel: %v", err))
+	}
+	if len(update.WebsiteIDs) > 0 {
+		deploy := request.CertDeploy{ID: remote.ID, WebsiteIDs: update.WebsiteIDs}
+		if _, err := r.remote.Request(ctx, conn, http.MethodPost, fmt.Sprintf("/api/cert/cert/%d/deploy", remote.ID), deploy); err != nil {
+			return errors.New(r.t.Get("failed to deploy certificate on remote panel: %v", err))
+		}
+	}
+
+	return nil
+}
+
+// unwrap 解析面板 API 的响应数据
+func (r *certDeployRepo) unwrap(body []byte, out any) error {
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+
+	return json.Unmarshal(response.Data, out)
+}
+
+// pushS3 上传到 S3 兼容存储，供 CDN 回源使用
+func (r *certDeployRepo) pushS3(target *biz.CertDeployTarget, cert *biz.Cert) error {
+	backupStorage := new(biz.BackupStorage)
+	if err := r.db.Where("id = ?", target.Config.StorageID).First(backupStorage).Error; err != nil {
+		return errors.New(r.t.Get("storage not found"))
+	}
+	if backupStorage.Type != biz.BackupStorageTypeS3 {
+		return errors.New(r.t.Get("only s3 storage can be used as a deploy target"))
+	}
+
+	client := storage.NewS3(storage.S3Config{
+		Region:          backupStorage.Info.Region,
+		Bucket:          backupStorage.Info.Bucket,
+		AccessKey:       backupStorage.Info.AccessKey,
+		SecretKey:       backupStorage.Info.SecretKey,
+		Endpoint:        backupStorage.Info.Endpoint,
+		Scheme:          backupStorage.Info.Scheme,
+		BasePath:        backupStorage.Info.Path,
+		AddressingStyle: storage.S3AddressingStyle(backupStorage.Info.Style),
+	})
+	if err := client.Put(target.Config.CertPath, strings.NewReader(cert.Cert)); err != nil {
+		return errors.New(r.t.Get("failed to upload %s: %v", target.Config.CertPath, err))
+	}
+	if err := client.Put(target.Config.KeyPath, strings.NewReader(cert.Key)); err != nil {
+		return errors.New(r.t.Get("failed to upload %s: %v", target.Config.KeyPath, err))
+	}
+
+	return nil
+}
+
+// pushDocker 写入本机 Docker 容器或卷
+func (r *certDeployRepo) pushDocker(ctx context.Context, target *biz.CertDeployTarget, cert *biz.Cert) error {
+	sock, _ := r.setting.Get(biz.SettingKeyContainerSock)
+	if sock == "" {
+		sock = "/var/run/docker.sock"
+	}
+	if !strings.Contains(sock, "://") {
+		sock = "unix://" + sock
+	}
+	apiClient, err := getDockerClient(sock)
+	if err != nil {
+		return err
+	}
+	defer func(apiClient *client.Client) { _ = apiClient.Close() }(apiClient)
+
+	conf := target.Config
+	if conf.Volume != "" {
+		volume, err := apiClient.VolumeInspect(ctx, conf.Volume, client.VolumeInspectOptions{})
+		if err != nil {
+			return errors.New(r.t.Get("failed to inspect volume %s: %v", conf.Volume, err))
+		}
+		if err = pkgio.Write(filepath.Join(volume.Volume.Mountpoint, conf.CertPath), cert.Cert, 0644); err != nil {
+			return err
+		}
+		return pkgio.Write(filepath.Join(volume.Volume.Mountpoint, conf.KeyPath), cert.Key, 0600)
+	}
+
+	for _, file := range []struct {
+		path    string
+		content string
+		mode    int64
+	}{
+		{conf.CertPath, cert.Cert, 0644},
+		{conf.KeyPath, cert.Key, 0600},
+	} {
+		archive, err := r.tarFile(path.Base(file.path), file.content, file.mode)
+		if err != nil {
+			return err
+		}
+		if _, err = apiClient.CopyToContainer(ctx, conf.Container, client.CopyToContainerOptions{
+			DestinationPath: path.Dir(file.path),
+			Content:         archive,
+		}); err != nil {
+			return errors.New(r.t.Get("failed to copy %s to container %s: %v", file.path, conf.Container, err))
+		}
+	}
+
+	if conf.Command != "" {
+		if err = r.dockerExec(ctx, apiClient, conf.Container, conf.Command); err != nil {
+			return err
+		}
+	}
+	if conf.Restart {
+		if _, err = apiClient.ContainerRestart(ctx, conf.Container, client.ContainerRestartOptions{}); err != nil {
+			return errors.New(r.t.Get("failed to restart container %s: %v", conf.Container, err))
+		}
+	}
+
+	return nil
+}
+
+// tarFile 将单个文件打包为 tar，CopyToContainer 只接受 tar 流
+func (r *certDeployRepo) tarFile(name, content string, mode int64) (io.Reader, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    mode,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+// dockerExec 在容器内执行命令并检查退出码
+func (r *certDeployRepo) dockerExec(ctx context.Context, apiClient *client.Client, container, command string) error {
+	exec, err := apiClient.ExecCreate(ctx, container, client.ExecCreateOptions{
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          []string{"sh", "-c", command},
+	})
+	if err != nil {
+		return err
+	}
+	hijack, err := apiClient.ExecAttach(ctx, exec.ID, client.ExecAttachOptions{})
+	if err != nil {
+		return err
+	}
+	defer hijack.Close()
+
+	var output bytes.Buffer
+	if _, err = stdcopy.StdCopy(&output, &output, hijack.Reader); err != nil {
+		return err
+	}
+	inspect, err := apiClient.ExecInspect(ctx, exec.ID, client.ExecInspectOptions{})
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return errors.New(r.t.Get("failed to run command: exit code %d, output: %s", inspect.ExitCode, strings.TrimSpace(output.String())))
+	}
+
+	return nil
+}
+
+// pushApp 部署到面板应用，写入证书、开启 TLS 并重启服务
+func (r *certDeployRepo) pushApp(target *biz.CertDeployTarget, cert *biz.Cert) error {
+	switch target.Config.App {
+	case biz.CertDeployAppPureFTPd:
+		// pure-ftpd 使用证书与私钥合并的 PEM
+		pem := filepath.Join(app.Root, "server/pure-ftpd/etc/pure-ftpd.pem")
+		if err := pkgio.Write(pem, strings.TrimSpace(cert.Cert)+"\n"+strings.TrimSpace(cert.Key)+"\n", 0600); err != nil {
+			return err
+		}
+		return r.updateAppConfig(filepath.Join(app.Root, "server/pure-ftpd/etc/pure-ftpd.conf"), "pure-ftpd", func(config string) string {
+			config = confval.FTP.Set(config, "CertFile", pem)
+			if confval.FTP.Get(config, "TLS") == "" || confval.FTP.Get(config, "TLS") == "0" {
+				config = confval.FTP.Set(config, "TLS", "1")
+			}
+			return config
+		})
+	case biz.CertDeployAppGitea:
+		certPath, keyPath, err := r.writeAppCert(filepath.Join(app.Root, "server/gitea"), "gitea", cert)
+		if err != nil {
+			return err
+		}
+		return r.updateAppConfig(filepath.Join(app.Root, "server/gitea/app.ini"), "gitea", func(config string) string {
+			config = confval.SectionINI.SetIn(config, "server", "PROTOCOL", "https")
+			config = confval.SectionINI.SetIn(config, "server", "CERT_FILE", certPath)
+			return confval.SectionINI.SetIn(config, "server", "KEY_FILE", keyPath)
+		})
+	case biz.CertDeployAppGrafana:
+		certPath, keyPath, err := r.writeAppCert(filepath.Join(app.Root, "server/grafana/conf"), "grafana", cert)
+		if err != nil {
+			return err
+		}
+		return r.updateAppConfig(filepath.Join(app.Root, "server/grafana/conf/defaults.ini"), "grafana", func(config string) string {
+			config = confval.SectionINI.SetIn(config, "server", "protocol", "https")
+			config = confval.SectionINI.SetIn(config, "server", "cert_file", certPath)
+			return confval.SectionINI.SetIn(config, "server", "cert_key", keyPath)
+		})
+	case biz.CertDeployAppMinIO:
+		// MinIO 从证书目录读取固定文件名 public.crt 和 private.key
+		dir := filepath.Join(app.Root, "server/minio/certs")
+		if err := pkgio.Write(filepath.Join(dir, "public.crt"), cert.Cert, 0644); err != nil {
+			return err
+		}
+		if err := r.writeAppKey(filepath.Join(dir, "private.key"), "minio", cert.Key); err != nil {
+			return err
+		}
+		return r.updateAppConfig("/etc/default/minio", "minio", func(config string) string {
+			opts := strings.Trim(confval.Properties.Get(config, "MINIO_OPTS"), `"'`)
+			if strings.Contains(opts, "--certs-dir") {
+				return config
+			}
+			return confval.Properties.Set(config, "MINIO_OPTS", `"`+strings.TrimSpace(opts+" --certs-dir "+dir)+`"`)
+		})
+	default:
+		return errors.New(r.t.Get("unsupported app: %s", target.Config.App))
+	}
+}
+
+// writeAppCert 写入应用目录下的 cert.pem 和 key.pem
+func (r *certDeployRepo) writeAppCert(dir, service string, cert *biz.Cert) (string, string, error) {
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	if err := pkgio.Write(certPath, cert.Cert, 0644); err != nil {
+		return "", "", err
+	}
+	if err := r.writeAppKey(keyPath, service, cert.Key); err != nil {
+		return "", "", err
+	}
+
+	return certPath, keyPath, nil
+}
+
+// writeAppKey 写入私钥并交给服务的运行用户，否则以非 root 运行的应用无法读取
+func (r *certDeployRepo) writeAppKey(path, service, key string) error {
+	if err := pkgio.Write(path, key, 0600); err != nil {
+		return err
+	}
+	user, group, err := systemctl.User(service)
+	if err != nil {
+		return errors.New(r.t.Get("failed to get the user of %s: %v", service, err))
+	}
+	if user == "" || user == "root" {
+		return nil
+	}
+	// 未配置用户组时 chown 使用用户的登录组
+	if err = pkgio.Chown(path, user, group); err != nil {
+		return errors.New(r.t.Get("failed to change the owner of %s: %v", path, err))
+	}
+
+	return nil
+}
+
+// updateAppConfig 修改应用配置并重启服务
+func (r *certDeployRepo) updateAppConfig(file, service string, update func(config string) string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return errors.New(r.t.Get("%s is not installed", service))
+	}
+	config, err := pkgio.Read(file)
+	if err != nil {
+		return err
+	}
+	if updated := update(config); updated != config {
+		if err = pkgio.Write(file, updated, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if err = systemctl.Restart(service); err != nil {
+		return errors.New(r.t.Get("failed to restart %s: %v", service, err))
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leonelquinteros/gotext"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+func TestCertDeployPushPanel(t *testing.T) {
+	var calls []string
+	var updated map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Timestamp") == "" || r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/cert/cert/upload":
+			_, _ = w.Write([]byte(`{"msg":"success","data":{"id":7}}`))
+		case "GET /api/cert/cert/7":
+			_, _ = w.Write([]byte(`{"msg":"success","data":{"id":7,"domains":["example.com"],"script":"echo ok","websites":[{"id":3}]}}`))
+		case "PUT /api/cert/cert/7":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			_, _ = w.Write([]byte(`{"msg":"success","data":null}`))
+		case "POST /api/cert/cert/7/deploy":
+			_, _ = w.Write([]byte(`{"msg":"success","data":null}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t1 := gotext.NewLocale("", "en")
+	repo := &certDeployRepo{t: t1, remote: NewMigrationRemoteRepo(t1)}
+	target := &biz.CertDeployTarget{
+		Type:   biz.CertDeployTypePanel,
+		Config: types.CertDeployConfig{URL: server.URL, TokenID: 1, Token: "secret"},
+	}
+	cert := &biz.Cert{ID: 1, Domains: []string{"example.com"}, Cert: "CERT", Key: "KEY"}
+
+	// 首次部署上传新证书并记住远程证书 ID
+	if err := repo.Push(context.Background(), target, cert); err != nil {
+		t.Fatalf("first push: %v", err)
+	}
+	if target.Config.RemoteCertID != 7 {
+		t.Fatalf("remote cert id = %d, want 7", target.Config.RemoteCertID)
+	}
+
+	// 之后更新同一证书并部署到远程绑定的网站
+	if err := repo.Push(context.Background(), target, cert); err != nil {
+		t.Fatalf("second push: %v", err)
+	}
+	want := []string{"POST /api/cert/cert/upload", "GET /api/cert/cert/7", "PUT /api/cert/cert/7", "POST /api/cert/cert/7/deploy"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("calls = %v, want %v", calls, want)
+		}
+	}
+	if updated["type"] != "upload" || updated["cert"] != "CERT" || updated["script"] != "echo ok" {
+		t.Fatalf("unexpected update body: %v", updated)
+	}
+}
+
+func TestCertDeployTarFile(t *testing.T) {
+	repo := &certDeployRepo{t: gotext.NewLocale("", "en")}
+	archive, err := repo.tarFile("key.pem", "KEY", 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tar.NewReader(archive)
+	header, err := tr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(tr)
+	if header.Name != "key.pem" || header.Mode != 0600 || string(content) != "KEY" {
+		t.Fatalf("unexpected entry: %s %o %q", header.Name, header.Mode, content)
+	}
+}
+
+func TestCertDeployCheckDocker(t *testing.T) {
+	repo := &certDeployRepo{t: gotext.NewLocale("", "en")}
+	cases := []struct {
+		conf types.CertDeployConfig
+		ok   bool
+	}{
+		{types.CertDeployConfig{Container: "nginx", CertPath: "/etc/nginx/cert.pem", KeyPath: "/etc/nginx/key.pem", Restart: true}, true},
+		{types.CertDeployConfig{Volume: "certs", CertPath: "cert.pem", KeyPath: "key.pem"}, true},
+		{types.CertDeployConfig{Container: "nginx", Volume: "certs", CertPath: "cert.pem", KeyPath: "key.pem"}, false},
+		{types.CertDeployConfig{Volume: "certs", CertPath: "../cert.pem", KeyPath: "key.pem"}, false},
+		{types.CertDeployConfig{Volume: "certs", CertPath: "cert.pem", KeyPath: "key.pem", Command: "nginx -s reload"}, false},
+	}
+	for _, c := range cases {
+		err := repo.check(&biz.CertDeployTarget{Type: biz.CertDeployTypeDocker, Config: c.conf})
+		if (err == nil) != c.ok {
+			t.Errorf("check(%+v) = %v, want ok=%v", c.conf, err, c.ok)
+		}
+	}
+}
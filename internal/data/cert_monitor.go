@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+	pkgcert "github.com/acepanel/panel/v3/pkg/cert"
+)
+
+type certMonitorRepo struct {
+	t  *gotext.Locale
+	db *gorm.DB
+}
+
+func NewCertMonitorRepo(db *gorm.DB, t *gotext.Locale) biz.CertMonitorRepo {
+	return &certMonitorRepo{
+		t:  t,
+		db: db,
+	}
+}
+
+func (r *certMonitorRepo) List(page, limit uint) ([]*biz.CertMonitor, int64, error) {
+	monitors := make([]*biz.CertMonitor, 0)
+	var total int64
+	err := r.db.Model(&biz.CertMonitor{}).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&monitors).Error
+	return monitors, total, err
+}
+
+func (r *certMonitorRepo) Get(id uint) (*biz.CertMonitor, error) {
+	monitor := new(biz.CertMonitor)
+	if err := r.db.Where("id = ?", id).First(monitor).Error; err != nil {
+		return nil, err
+	}
+
+	return monitor, nil
+}
+
+func (r *certMonitorRepo) Create(req *request.CertMonitorCreate) (*biz.CertMonitor, error) {
+	monitor := &biz.CertMonitor{
+		Name:     req.Name,
+		Host:     req.Host,
+		Port:     req.Port,
+		SNI:      req.SNI,
+		Interval: req.Interval,
+		Enabled:  req.Enabled,
+	}
+	if err := r.db.Create(monitor).Error; err != nil {
+		return nil, err
+	}
+
+	return monitor, nil
+}
+
+func (r *certMonitorRepo) Update(req *request.CertMonitorUpdate) error {
+	monitor, err := r.Get(req.ID)
+	if err != nil {
+		return err
+	}
+
+	// 端点变化后立即重新检查
+	if monitor.Host != req.Host || monitor.Port != req.Port || monitor.SNI != req.SNI {
+		monitor.CheckedAt = nil
+	}
+	monitor.Name = req.Name
+	monitor.Host = req.Host
+	monitor.Port = req.Port
+	monitor.SNI = req.SNI
+	monitor.Interval = req.Interval
+	monitor.Enabled = req.Enabled
+
+	return r.db.Save(monitor).Error
+}
+
+func (r *certMonitorRepo) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("monitor_id = ?", id).Delete(&biz.CertMonitorCheck{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&biz.CertMonitor{}, id).Error
+	})
+}
+
+func (r *certMonitorRepo) Save(monitor *biz.CertMonitor) error {
+	return r.db.Save(monitor).Error
+}
+
+func (r *certMonitorRepo) Due(now time.Time) ([]*biz.CertMonitor, error) {
+	monitors := make([]*biz.CertMonitor, 0)
+	if err := r.db.Where("enabled = ?", true).Find(&monitors).Error; err != nil {
+		return nil, err
+	}
+
+	due := make([]*biz.CertMonitor, 0, len(monitors))
+	for _, monitor := range monitors {
+		if monitor.CheckedAt == nil || !now.Before(monitor.CheckedAt.Add(time.Duration(monitor.Interval)*time.Minute)) {
+			due = append(due, monitor)
+		}
+	}
+
+	return due, nil
+}
+
+func (r *certMonitorRepo) Probe(ctx context.Context, monitor *biz.CertMonitor) (*pkgcert.ProbeResult, error) {
+	addr := net.JoinHostPort(monitor.Host, strconv.Itoa(int(monitor.Port)))
+	return pkgcert.Probe(ctx, addr, monitor.ServerName(), nil)
+}
+
+func (r *certMonitorRepo) AddCheck(check *biz.CertMonitorCheck) error {
+	return r.db.Create(check).Error
+}
+
+func (r *certMonitorRepo) ListChecks(monitorID uint, page, limit uint) ([]*biz.CertMonitorCheck, int64, error) {
+	checks := make([]*biz.CertMonitorCheck, 0)
+	var total int64
+	err := r.db.Model(&biz.CertMonitorCheck{}).Where("monitor_id = ?", monitorID).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&checks).Error
+	return checks, total, err
+}
+
+func (r *certMonitorRepo) ClearChecksBefore(t time.Time) error {
+	return r.db.Where("created_at < ?", t).Delete(&biz.CertMonitorCheck{}).Error
+}
@@ -0,0 +1,67 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libtnb/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+func TestCertMonitorDue(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&biz.CertMonitor{}, &biz.CertMonitorCheck{}); err != nil {
+		t.Fatal(err)
+	}
+	repo := &certMonitorRepo{db: db}
+
+	now := time.Now()
+	recent := now.Add(-10 * time.Minute)
+	stale := now.Add(-2 * time.Hour)
+	monitors := []*biz.CertMonitor{
+		{Name: "never", Host: "a.example.com", Port: 443, Interval: 60, Enabled: true},
+		{Name: "recent", Host: "b.example.com", Port: 443, Interval: 60, Enabled: true, CheckedAt: &recent},
+		{Name: "stale", Host: "c.example.com", Port: 443, Interval: 60, Enabled: true, CheckedAt: &stale},
+		{Name: "disabled", Host: "d.example.com", Port: 443, Interval: 60, Enabled: true},
+	}
+	for _, monitor := range monitors {
+		if err = db.Create(monitor).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 默认值为 true，需单独更新才能关闭
+	if err = db.Model(monitors[3]).Update("enabled", false).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	due, err := repo.Due(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, monitor := range due {
+		names[monitor.Name] = true
+	}
+	if len(due) != 2 || !names["never"] || !names["stale"] {
+		t.Fatalf("unexpected due monitors: %v", names)
+	}
+
+	if err = repo.AddCheck(&biz.CertMonitorCheck{MonitorID: monitors[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if err = repo.Delete(monitors[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	_, total, err := repo.ListChecks(monitors[0].ID, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 {
+		t.Fatalf("checks not removed with monitor, got %d", total)
+	}
+}
@@ -1,11 +1,17 @@
 package data
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	stdio "io"
+	stdos "os"
+	"os/exec"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/leonelquinteros/gotext"
 	"github.com/libtnb/utils/str"
@@ -66,6 +72,10 @@ func (r *cronRepo) Save(cron *biz.Cron) error {
 }
 
 func (r *cronRepo) Delete(cron *biz.Cron) error {
+	if err := r.db.Where("cron_id = ?", cron.ID).Delete(&biz.CronRun{}).Error; err != nil {
+		return err
+	}
+
 	return r.db.Delete(cron).Error
 }
 
@@ -114,13 +124,70 @@ func (r *cronRepo) RemoveScriptFiles(shellPath string) error {
 // AddToSystem 添加到系统
 // 统一经 wrapper 脚本执行，以便捕获退出码并上报失败
 func (r *cronRepo) AddToSystem(cron *biz.Cron) error {
-	cmd := cron.Shell
-	if cron.Config.Flock {
-		lockFile := strings.TrimSuffix(cron.Shell, ".sh") + ".lock"
-		// -E 指定未抢到锁时的退出码，与脚本自身失败区分，避免正常跳过被误报
-		cmd = fmt.Sprintf("flock -xn -E %d %s %s", cronLockSkipCode, lockFile, cron.Shell)
+	entry, err := r.writeWrapper(cron)
+	if err != nil {
+		return err
+	}
+	if _, err = shell.Execf(`( crontab -l; echo "%s" ) | sort - | uniq - | crontab -`, entry); err != nil {
+		return err
+	}
+
+	return r.restartCron()
+}
+
+// RefreshSystem 重新生成 crons 的 wrapper 脚本，再一次性替换它们在 crontab 中的条目并重启 cron 服务
+func (r *cronRepo) RefreshSystem(crons []*biz.Cron) error {
+	entries := make([]string, 0, len(crons))
+	for _, cron := range crons {
+		entry, err := r.writeWrapper(cron)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cron.Name, err)
+		}
+		entries = append(entries, entry)
 	}
 
+	// 没有 crontab 时 crontab -l 返回错误，视为空
+	current, _ := shell.Execf("crontab -l")
+	lines := make([]string, 0)
+	for line := range strings.Lines(current) {
+		line = strings.TrimRight(line, "\n")
+		owned := false
+		for _, cron := range crons {
+			// 同时清理旧版本直接调用脚本的条目
+			if strings.Contains(line, r.wrapperPath(cron)) || strings.Contains(line, fmt.Sprintf("%s >> %s 2>&1", cron.Shell, cron.Log)) {
+				owned = true
+				break
+			}
+		}
+		if !owned && line != "" {
+			lines = append(lines, line)
+		}
+	}
+	lines = append(lines, entries...)
+	slices.Sort(lines)
+	lines = slices.Compact(lines)
+
+	file, err := stdos.CreateTemp("", "acepanel-crontab-*")
+	if err != nil {
+		return err
+	}
+	defer func(name string) { _ = stdos.Remove(name) }(file.Name())
+	_, err = file.WriteString(strings.Join(lines, "\n") + "\n")
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if out, err := shell.Execf("crontab %s", file.Name()); err != nil {
+		return errors.New(out)
+	}
+
+	return r.restartCron()
+}
+
+// writeWrapper 写入任务的 wrapper 脚本，返回对应的 crontab 条目
+func (r *cronRepo) writeWrapper(cron *biz.Cron) (string, error) {
 	// 秒级任务由每分钟触发的 wrapper 内部循环模拟
 	spec := cron.Time
 	seconds := r.parseSeconds(cron.Time)
@@ -128,21 +195,84 @@ func (r *cronRepo) AddToSystem(cron *biz.Cron) error {
 		spec = "* * * * *"
 	}
 
-	wrapperPath := strings.TrimSuffix(cron.Shell, ".sh") + "_wrapper.sh"
-	if err := io.Write(wrapperPath, r.generateWrapper(cron.ID, cmd, cron.Log, seconds), 0700); err != nil {
-		return err
+	lock := ""
+	if cron.Config.Overlap == biz.CronOverlapSkip {
+		lock = r.lockPath(cron)
 	}
-	if _, err := shell.Execf(`( crontab -l; echo "%s %s" ) | sort - | uniq - | crontab -`, spec, wrapperPath); err != nil {
-		return err
+	wrapperPath := r.wrapperPath(cron)
+	if err := io.Write(wrapperPath, r.generateWrapper(cron.ID, r.command(cron), cron.Log, lock, seconds), 0700); err != nil {
+		return "", err
 	}
 
-	return r.restartCron()
+	return spec + " " + wrapperPath, nil
+}
+
+func (r *cronRepo) wrapperPath(cron *biz.Cron) string {
+	return strings.TrimSuffix(cron.Shell, ".sh") + "_wrapper.sh"
+}
+
+func (r *cronRepo) lockPath(cron *biz.Cron) string {
+	return strings.TrimSuffix(cron.Shell, ".sh") + ".lock"
+}
+
+// Exec 按重叠策略与超时限制执行一次任务脚本，输出同时写入 out 与任务日志
+// 脚本在当前进程组内执行，任务队列取消时随之一并结束
+func (r *cronRepo) Exec(ctx context.Context, cron *biz.Cron, out stdio.Writer) (int, bool, error) {
+	if cron.Config.Overlap == biz.CronOverlapSkip {
+		// 由本进程持有锁直到脚本结束，未抢到锁即为跳过，不占用脚本的退出码
+		lock, err := stdos.OpenFile(r.lockPath(cron), stdos.O_CREATE|stdos.O_WRONLY, 0o600)
+		if err != nil {
+			return 0, false, err
+		}
+		defer func(lock *stdos.File) { _ = lock.Close() }(lock)
+		if err = syscall.Flock(int(lock.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); errors.Is(err, syscall.EWOULDBLOCK) {
+			return 0, true, nil
+		} else if err != nil {
+			return 0, false, err
+		}
+	}
+
+	if err := stdos.MkdirAll(filepath.Dir(cron.Log), 0o700); err != nil {
+		return 0, false, err
+	}
+	f, err := stdos.OpenFile(cron.Log, stdos.O_CREATE|stdos.O_WRONLY|stdos.O_APPEND, 0o644)
+	if err != nil {
+		return 0, false, err
+	}
+	defer func(f *stdos.File) { _ = f.Close() }(f)
+
+	cmd := exec.CommandContext(ctx, "bash", "-c", r.command(cron))
+	shell.ApplyEnv(cmd)
+	cmd.Stdout = stdio.MultiWriter(out, f)
+	cmd.Stderr = cmd.Stdout
+
+	var exitErr *exec.ExitError
+	if err = cmd.Run(); errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), false, nil
+	}
+
+	return 0, false, err
+}
+
+// command 按超时限制与排队策略组装任务脚本的执行命令，crontab wrapper 与立即执行共用
+// skip 策略的锁由调用方在执行前获取，以便与脚本自身的退出码区分
+func (r *cronRepo) command(cron *biz.Cron) string {
+	cmd := cron.Shell
+	if cron.Config.MaxRuntime > 0 {
+		// 超时先发 TERM，10 秒后仍未退出再 KILL，超时退出码为 124
+		cmd = fmt.Sprintf("timeout -k 10 %d %s", cron.Config.MaxRuntime, cmd)
+	}
+	if cron.Config.Overlap == biz.CronOverlapQueue {
+		cmd = fmt.Sprintf("flock -x %s %s", r.lockPath(cron), cmd)
+	}
+
+	return cmd
 }
 
 // DeleteFromSystem 从系统中删除
 func (r *cronRepo) DeleteFromSystem(cron *biz.Cron) error {
 	// 清理秒级任务的 wrapper 条目和脚本
-	wrapperPath := strings.TrimSuffix(cron.Shell, ".sh") + "_wrapper.sh"
+	wrapperPath := r.wrapperPath(cron)
 	_, _ = shell.Execf(`( crontab -l | grep -v -F "%s" ) | crontab -`, wrapperPath)
 	_ = io.Remove(wrapperPath)
 
@@ -178,26 +308,35 @@ func (r *cronRepo) GenerateScript(typ string, config types.CronConfig, rawScript
 
 	switch typ {
 	case "backup":
+		dedup := ""
+		if config.Dedup {
+			dedup = " --dedup"
+		}
 		for _, target := range config.Targets {
 			switch config.Type {
 			case "website":
-				_, _ = fmt.Fprintf(&sb, "acepanel backup website -n '%s' -s '%d'\n", target, config.Storage)
-			case "mysql", "postgresql", "clickhouse", "redis", "valkey":
+				_, _ = fmt.Fprintf(&sb, "acepanel backup website -n '%s' -s '%d'%s\n", target, config.Storage, dedup)
+			case "mysql", "postgresql", "clickhouse", "redis", "valkey", "mongodb", "elasticsearch":
 				_, _ = fmt.Fprintf(&sb, "acepanel backup database -t '%s' -n '%s' -s '%d'\n", config.Type, target, config.Storage)
 			case "path":
-				_, _ = fmt.Fprintf(&sb, "acepanel backup path -p '%s' -s '%d'\n", target, config.Storage)
+				_, _ = fmt.Fprintf(&sb, "acepanel backup path -p '%s' -s '%d'%s\n", target, config.Storage, dedup)
 			}
 		}
 		for _, target := range config.Targets {
 			switch config.Type {
 			case "website":
 				_, _ = fmt.Fprintf(&sb, "acepanel backup clear -t website -f '%s' -k '%d' -s '%d'\n", target, config.Keep, config.Storage)
-			case "mysql", "postgresql", "clickhouse", "redis", "valkey":
+			case "mysql", "postgresql", "clickhouse", "redis", "valkey", "mongodb", "elasticsearch":
 				_, _ = fmt.Fprintf(&sb, "acepanel backup clear -t '%s' -f '%s' -k '%d' -s '%d'\n", config.Type, target, config.Keep, config.Storage)
 			case "path":
 				_, _ = fmt.Fprintf(&sb, "acepanel backup clear -t path -f '%s' -k '%d' -s '%d'\n", filepath.Base(target), config.Keep, config.Storage)
 			}
 		}
+		if config.TestRestore && (config.Type == "mysql" || config.Type == "postgresql") {
+			for _, target := range config.Targets {
+				_, _ = fmt.Fprintf(&sb, "acepanel backup test-restore -t '%s' -n '%s' -s '%d'\n", config.Type, target, config.Storage)
+			}
+		}
 	case "cutoff":
 		for _, target := range config.Targets {
 			switch config.Type {
@@ -276,45 +415,56 @@ func (r *cronRepo) parseSeconds(time string) int {
 	return 0
 }
 
-const (
-	// wrapperPathEnv crontab 环境的 PATH 极简，需补全以便调用 acepanel
-	wrapperPathEnv = "export PATH=/bin:/sbin:/usr/bin:/usr/sbin:/usr/local/bin:/usr/local/sbin:$PATH"
-	// cronLockSkipCode flock 未抢到锁时的退出码，属正常跳过而非失败
-	cronLockSkipCode = 200
-)
+// wrapperPathEnv crontab 环境的 PATH 极简，需补全以便调用 acepanel
+const wrapperPathEnv = "export PATH=/bin:/sbin:/usr/bin:/usr/sbin:/usr/local/bin:/usr/local/sbin:$PATH"
 
-// generateWrapper 生成任务的 wrapper 脚本，捕获退出码并上报失败
+// generateWrapper 生成任务的 wrapper 脚本，记录每次执行并上报失败
+// lock 不为空时为 skip 策略，wrapper 自行持有锁，未抢到锁时直接退出且不上报
 // seconds 大于 0 时为秒级任务，用每分钟触发 + 循环 sleep 模拟
-func (r *cronRepo) generateWrapper(id uint, cmd, logFile string, seconds int) string {
+func (r *cronRepo) generateWrapper(id uint, cmd, logFile, lock string, seconds int) string {
+	acquire := ""
+	if lock != "" {
+		acquire = fmt.Sprintf("exec 9>>%s\nflock -xn 9 || exit 0\n", lock)
+	}
+
 	if seconds <= 0 {
+		// 输出仍直接追加到日志保证实时可见，执行记录按执行前的日志大小截取本次输出
 		return fmt.Sprintf(`#!/bin/bash
 %s
 
+%sSTART=$(date +%%s%%3N)
+OFFSET=$(stat -c %%s %s 2>/dev/null || echo 0)
 %s >> %s 2>&1
 code=$?
-if [ $code -ne 0 ] && [ $code -ne %d ]; then
-    acepanel cron failed -i %d -c $code >/dev/null 2>&1
-fi
+acepanel cron report -i %d -c $code -s $START -e $(date +%%s%%3N) -o %s --offset $OFFSET >/dev/null 2>&1
 exit $code
-`, wrapperPathEnv, cmd, logFile, cronLockSkipCode, id)
+`, wrapperPathEnv, acquire, logFile, cmd, logFile, id, logFile)
 	}
 
-	// 并发执行拿不到子进程退出码，用标记文件汇总本分钟是否出错
+	// 并发执行时输出会交错，每次执行先写入批次目录再追加到日志
+	// 每次执行各自成为一条执行记录，一分钟内的执行汇总后只调用一次上报
 	count := 60 / seconds
 	return fmt.Sprintf(`#!/bin/bash
 %s
 
 INTERVAL=%d
 COUNT=%d
-FLAG=$(mktemp)
+BATCH=$(mktemp -d)
 for i in $(seq 1 $COUNT); do
-    ( %s >> %s 2>&1; c=$?; [ $c -ne 0 ] && [ $c -ne %d ] && echo $c >> "$FLAG" ) &
+    (
+        %sOUT="$BATCH/$i.out"
+        START=$(date +%%s%%3N)
+        %s > "$OUT" 2>&1
+        c=$?
+        cat "$OUT" >> %s
+        echo "$START $(date +%%s%%3N) $c $OUT" >> "$BATCH/runs"
+    ) &
     [ $i -lt $COUNT ] && sleep $INTERVAL
 done
 wait
-if [ -s "$FLAG" ]; then
-    acepanel cron failed -i %d -c "$(tail -n 1 "$FLAG")" >/dev/null 2>&1
+if [ -s "$BATCH/runs" ]; then
+    acepanel cron report -i %d --batch "$BATCH/runs" >/dev/null 2>&1
 fi
-rm -f "$FLAG"
-`, wrapperPathEnv, seconds, count, cmd, logFile, cronLockSkipCode, id)
+rm -rf "$BATCH"
+`, wrapperPathEnv, seconds, count, strings.ReplaceAll(acquire, "\n", "\n        "), cmd, logFile, id)
 }
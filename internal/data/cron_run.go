@@ -0,0 +1,83 @@
+package data
+
+import (
+	"time"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+func (r *cronRepo) ListRuns(cronID, page, limit uint) ([]*biz.CronRun, int64, error) {
+	runs := make([]*biz.CronRun, 0)
+	var total int64
+	err := r.db.Model(&biz.CronRun{}).Omit("output").Where("cron_id = ?", cronID).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&runs).Error
+	return runs, total, err
+}
+
+func (r *cronRepo) CreateRun(run *biz.CronRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *cronRepo) GetRun(cronID, runID uint) (*biz.CronRun, error) {
+	run := new(biz.CronRun)
+	if err := r.db.Where("id = ? AND cron_id = ?", runID, cronID).First(run).Error; err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (r *cronRepo) RunStats(cronID uint) (*biz.CronRunStats, error) {
+	var row struct {
+		Total   int64
+		Success int64
+		Avg     float64
+		Min     int64
+		Max     int64
+	}
+	err := r.db.Model(&biz.CronRun{}).
+		Select("COUNT(*) AS total, COALESCE(SUM(CASE WHEN exit_code = 0 THEN 1 ELSE 0 END), 0) AS success, COALESCE(AVG(duration), 0) AS avg, COALESCE(MIN(duration), 0) AS min, COALESCE(MAX(duration), 0) AS max").
+		Where("cron_id = ?", cronID).
+		Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &biz.CronRunStats{
+		Total:       row.Total,
+		Success:     row.Success,
+		Failed:      row.Total - row.Success,
+		AvgDuration: row.Avg,
+		MinDuration: row.Min,
+		MaxDuration: row.Max,
+	}
+	if row.Total == 0 {
+		return stats, nil
+	}
+	stats.SuccessRate = float64(row.Success) / float64(row.Total) * 100
+
+	last := new(biz.CronRun)
+	if err = r.db.Omit("output").Where("cron_id = ?", cronID).Order("id desc").First(last).Error; err != nil {
+		return nil, err
+	}
+	stats.LastRunAt = &last.StartedAt
+	stats.LastCode = last.ExitCode
+
+	return stats, nil
+}
+
+func (r *cronRepo) PruneRuns(cronID, keep uint) error {
+	var expired []uint
+	if err := r.db.Model(&biz.CronRun{}).Where("cron_id = ?", cronID).Order("id desc").Offset(int(keep)).Pluck("id", &expired).Error; err != nil {
+		return err
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	return r.db.Where("id IN ?", expired).Delete(&biz.CronRun{}).Error
+}
+
+func (r *cronRepo) ClearRunsBefore(t time.Time) (int64, error) {
+	result := r.db.Where("started_at < ?", t).Delete(&biz.CronRun{})
+	return result.RowsAffected, result.Error
+}
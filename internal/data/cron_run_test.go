@@ -0,0 +1,162 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libtnb/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+func TestCronRuns(t *testing.T) {
+	if err := registerZstdSerializer(); err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&biz.Cron{}, &biz.CronRun{}); err != nil {
+		t.Fatal(err)
+	}
+	repo := &cronRepo{db: db}
+
+	now := time.Now()
+	output := strings.Repeat("backup ok\n", 1000)
+	runs := []*biz.CronRun{
+		{CronID: 1, StartedAt: now.AddDate(0, 0, -40), Duration: 100, ExitCode: 0},
+		{CronID: 1, StartedAt: now.Add(-2 * time.Hour), Duration: 300, ExitCode: 1, Output: "error"},
+		{CronID: 1, StartedAt: now.Add(-time.Hour), Duration: 200, ExitCode: 0, Output: output},
+		{CronID: 2, StartedAt: now, Duration: 50, ExitCode: 0},
+	}
+	for _, run := range runs {
+		if err = repo.CreateRun(run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, total, err := repo.ListRuns(1, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || list[0].ID != runs[2].ID || list[0].Output != "" {
+		t.Fatalf("unexpected list: total=%d, output should be omitted", total)
+	}
+	run, err := repo.GetRun(1, runs[2].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Output != output {
+		t.Fatal("output should round trip through compression")
+	}
+	if _, err = repo.GetRun(2, runs[2].ID); err == nil {
+		t.Fatal("run of another cron should not be readable")
+	}
+
+	stats, err := repo.RunStats(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Total != 3 || stats.Success != 2 || stats.Failed != 1 || stats.MinDuration != 100 || stats.MaxDuration != 300 || stats.AvgDuration != 200 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if stats.LastCode != 0 || stats.LastRunAt == nil || !stats.LastRunAt.Equal(runs[2].StartedAt) {
+		t.Fatalf("unexpected last run: %+v", stats)
+	}
+
+	count, err := repo.ClearRunsBefore(now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 run cleared, got %d", count)
+	}
+	if err = repo.PruneRuns(1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if list, total, err = repo.ListRuns(1, 1, 10); err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || list[0].ID != runs[2].ID {
+		t.Fatalf("expected only the latest run kept, got %d", total)
+	}
+
+	// 删除任务时一并删除执行记录
+	if err = repo.Delete(&biz.Cron{ID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, total, err = repo.ListRuns(2, 1, 10); err != nil || total != 0 {
+		t.Fatalf("runs of deleted cron should be removed, got %d", total)
+	}
+}
+
+func TestCronGenerateWrapper(t *testing.T) {
+	repo := &cronRepo{}
+	for _, seconds := range []int{0, 10} {
+		for _, lock := range []string{"", "/opt/ace/server/cron/a.lock"} {
+			script := repo.generateWrapper(1, "/opt/ace/server/cron/a.sh", "/opt/ace/server/cron/logs/a.log", lock, seconds)
+			// 秒级任务每分钟汇总上报一次
+			if strings.Count(script, "acepanel cron report -i 1 ") != 1 {
+				t.Fatalf("wrapper should report once per trigger:\n%s", script)
+			}
+			if lock != "" && !strings.Contains(script, "flock -xn 9 || exit 0") {
+				t.Fatalf("wrapper should skip without reporting when locked:\n%s", script)
+			}
+			if out, err := exec.Command("bash", "-n", "-c", script).CombinedOutput(); err != nil {
+				t.Fatalf("invalid wrapper script: %v\n%s", err, out)
+			}
+		}
+	}
+}
+
+func TestCronExec(t *testing.T) {
+	repo := &cronRepo{}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "a.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/bash\necho hello\nsleep ${SLEEP:-0}\nexit 3\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	cron := &biz.Cron{ID: 1, Shell: script, Log: filepath.Join(dir, "logs", "a.log")}
+
+	var out bytes.Buffer
+	code, skipped, err := repo.Exec(context.Background(), cron, &out)
+	if err != nil || skipped || code != 3 {
+		t.Fatalf("code = %d, err = %v", code, err)
+	}
+	logged, _ := os.ReadFile(cron.Log)
+	if out.String() != "hello\n" || string(logged) != "hello\n" {
+		t.Fatalf("output %q, log %q", out.String(), logged)
+	}
+
+	// 超时退出码为 124
+	cron.Config.MaxRuntime = 1
+	t.Setenv("SLEEP", "5")
+	if code, _, err = repo.Exec(context.Background(), cron, &out); err != nil || code != 124 {
+		t.Fatalf("timeout code = %d, err = %v", code, err)
+	}
+
+	// skip 策略下锁被占用时跳过，脚本自身的任何退出码都不会被当作跳过
+	cron.Config.MaxRuntime = 0
+	cron.Config.Overlap = biz.CronOverlapSkip
+	t.Setenv("SLEEP", "0")
+	if code, skipped, err = repo.Exec(context.Background(), cron, &out); err != nil || skipped || code != 3 {
+		t.Fatalf("unlocked code = %d, skipped = %v, err = %v", code, skipped, err)
+	}
+	lock := exec.Command("flock", "-x", strings.TrimSuffix(script, ".sh")+".lock", "sleep", "3")
+	if err = lock.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = lock.Process.Kill(); _ = lock.Wait() }()
+	time.Sleep(200 * time.Millisecond)
+	if _, skipped, err = repo.Exec(context.Background(), cron, &out); err != nil || !skipped {
+		t.Fatalf("skipped = %v, err = %v", skipped, err)
+	}
+}
@@ -5,14 +5,14 @@ import "github.com/google/wire"
 var ProviderSet = wire.NewSet(
 	NewAlertRepo, NewAppRepo, NewBackupRepo, NewBackupAccountRepo,
 	NewCacheRepo, NewCertRepo, NewCertAccountRepo,
-	NewCertDNSRepo, NewContainerRepo, NewContainerComposeRepo,
+	NewCertDNSRepo, NewCertDeployRepo, NewCertMonitorRepo, NewContainerRepo, NewContainerComposeRepo,
 	NewContainerImageRepo, NewContainerNetworkRepo, NewContainerVolumeRepo,
 	NewCronRepo, NewDatabaseRepo, NewDatabaseRedisRepo,
-	NewDatabaseElasticsearchRepo, NewDatabaseServerRepo, NewDatabaseUserRepo,
-	NewEnvironmentRepo, NewFileShareRepo, NewLogRepo, NewMonitorRepo,
+	NewDatabaseElasticsearchRepo, NewDatabaseServerRepo, NewDatabaseUserRepo, NewDeploymentRepo,
+	NewEnvironmentRepo, NewFileShareRepo, NewFirewallGeoRepo, NewLogRepo, NewMonitorRepo, NewProbeRepo,
 	NewNotifyChannelRepo,
-	NewProjectRepo, NewSafeRepo, NewScanEventRepo,
-	NewSettingRepo, NewSSHRepo, NewTamperRepo, NewTaskRepo,
+	NewProjectRepo, NewRoleRepo, NewSafeRepo, NewScanEventRepo,
+	NewSettingRepo, NewSSHRepo, NewTamperRepo, NewTaskRepo, NewTerminalRecordingRepo,
 	NewTemplateRepo, NewUserRepo, NewUserPasskeyRepo,
 	NewUserTokenRepo, NewWebHookRepo, NewWebsiteRepo,
 	NewWebsiteStatRepo,
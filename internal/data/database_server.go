@@ -35,7 +35,7 @@ func (r *databaseServerRepo) Count() (int64, error) {
 func (r *databaseServerRepo) List(ctx context.Context, page, limit uint, typ string) ([]*biz.DatabaseServer, int64, error) {
 	databaseServer := make([]*biz.DatabaseServer, 0)
 	var total int64
-	query := r.db.Model(&biz.DatabaseServer{}).Order("id desc")
+	query := scoped(ctx, r.db.Model(&biz.DatabaseServer{}), biz.ScopeDatabaseServer, "id").Order("id desc")
 	if typ != "" {
 		query = query.Where("type = ?", typ)
 	}
@@ -34,7 +34,7 @@ func (r *databaseUserRepo) Count() (int64, error) {
 func (r *databaseUserRepo) List(ctx context.Context, page, limit uint, typ string) ([]*biz.DatabaseUser, int64, error) {
 	user := make([]*biz.DatabaseUser, 0)
 	var total int64
-	query := r.db.Model(&biz.DatabaseUser{}).Preload("Server").Order("id desc")
+	query := scoped(ctx, r.db.Model(&biz.DatabaseUser{}), biz.ScopeDatabaseServer, "database_users.server_id").Preload("Server").Order("id desc")
 	if typ != "" {
 		query = query.Joins("JOIN database_servers ON database_servers.id = database_users.server_id").Where("database_servers.type = ?", typ)
 	}
@@ -50,7 +50,8 @@ func (r *databaseUserRepo) List(ctx context.Context, page, limit uint, typ strin
 
 func (r *databaseUserRepo) Get(ctx context.Context, id uint) (*biz.DatabaseUser, error) {
 	user := new(biz.DatabaseUser)
-	if err := r.db.Preload("Server").Where("id = ?", id).First(user).Error; err != nil {
+	// 受限用户只能访问范围内服务器上的用户
+	if err := scoped(ctx, r.db, biz.ScopeDatabaseServer, "server_id").Preload("Server").Where("id = ?", id).First(user).Error; err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,576 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdio "io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/pkg/chattr"
+	"github.com/acepanel/panel/v3/pkg/io"
+	"github.com/acepanel/panel/v3/pkg/shell"
+	"github.com/acepanel/panel/v3/pkg/systemctl"
+)
+
+type deploymentRepo struct {
+	t       *gotext.Locale
+	db      *gorm.DB
+	project biz.ProjectRepo
+	website biz.WebsiteRepo
+}
+
+func NewDeploymentRepo(db *gorm.DB, t *gotext.Locale, projectRepo biz.ProjectRepo, websiteRepo biz.WebsiteRepo) biz.DeploymentRepo {
+	return &deploymentRepo{
+		t:       t,
+		db:      db,
+		project: projectRepo,
+		website: websiteRepo,
+	}
+}
+
+func (r *deploymentRepo) Get(id uint) (*biz.Deployment, error) {
+	deployment := new(biz.Deployment)
+	if err := r.db.Where("id = ?", id).First(deployment).Error; err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+func (r *deploymentRepo) GetByTarget(target string, targetID uint) (*biz.Deployment, error) {
+	deployment := new(biz.Deployment)
+	if err := r.db.Where("target = ? AND target_id = ?", target, targetID).First(deployment).Error; err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+func (r *deploymentRepo) TargetInfo(target string, targetID uint) (string, string, string, error) {
+	switch target {
+	case biz.DeploymentTargetProject:
+		project := new(biz.Project)
+		if err := r.db.Where("id = ?", targetID).First(project).Error; err != nil {
+			return "", "", "", err
+		}
+		// 运行用户取自服务单元，单元缺失时按未指定处理
+		user := ""
+		if detail, err := r.project.ParseDetail(project); err == nil {
+			user = detail.User
+		}
+		return project.Name, project.Path, user, nil
+	case biz.DeploymentTargetWebsite:
+		website := new(biz.Website)
+		if err := r.db.Where("id = ?", targetID).First(website).Error; err != nil {
+			return "", "", "", err
+		}
+		return website.Name, website.Path, "www", nil
+	}
+
+	return "", "", "", errors.New(r.t.Get("unsupported deployment target: %s", target))
+}
+
+func (r *deploymentRepo) Create(deployment *biz.Deployment) error {
+	return r.db.Create(deployment).Error
+}
+
+func (r *deploymentRepo) Save(deployment *biz.Deployment) error {
+	return r.db.Save(deployment).Error
+}
+
+func (r *deploymentRepo) Delete(deployment *biz.Deployment) error {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("deployment_id = ?", deployment.ID).Delete(&biz.DeploymentRelease{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(deployment).Error
+	}); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(deploymentCacheDir(deployment.ID))
+}
+
+func (r *deploymentRepo) ListReleases(deploymentID, page, limit uint) ([]*biz.DeploymentRelease, int64, error) {
+	if err := r.expireReleases(deploymentID); err != nil {
+		return nil, 0, err
+	}
+
+	releases := make([]*biz.DeploymentRelease, 0)
+	var total int64
+	err := r.db.Model(&biz.DeploymentRelease{}).Where("deployment_id = ?", deploymentID).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&releases).Error
+	return releases, total, err
+}
+
+func (r *deploymentRepo) GetRelease(id uint) (*biz.DeploymentRelease, error) {
+	release := new(biz.DeploymentRelease)
+	if err := r.db.Where("id = ?", id).First(release).Error; err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+func (r *deploymentRepo) CreateRelease(release *biz.DeploymentRelease) error {
+	return r.db.Create(release).Error
+}
+
+func (r *deploymentRepo) UpdateRelease(release *biz.DeploymentRelease) error {
+	return r.db.Save(release).Error
+}
+
+func (r *deploymentRepo) WaitingRelease(deploymentID uint) (*biz.DeploymentRelease, error) {
+	if err := r.expireReleases(deploymentID); err != nil {
+		return nil, err
+	}
+
+	var releases []*biz.DeploymentRelease
+	if err := r.db.Where("deployment_id = ? AND status = ?", deploymentID, biz.DeploymentReleasePending).
+		Where("task_id IN (?)", r.db.Model(&biz.Task{}).Select("id").Where("status = ?", biz.TaskStatusWaiting)).
+		Order("id desc").Limit(1).Find(&releases).Error; err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		return nil, nil
+	}
+
+	return releases[0], nil
+}
+
+// expireReleases 任务已结束（被取消、面板重启等）但仍处于排队或构建中的版本标记为失败
+// 刚创建尚未关联任务的版本留出一分钟
+func (r *deploymentRepo) expireReleases(deploymentID uint) error {
+	active := r.db.Model(&biz.Task{}).Select("id").Where("status IN ?", []biz.TaskStatus{biz.TaskStatusWaiting, biz.TaskStatusRunning})
+	return r.db.Model(&biz.DeploymentRelease{}).
+		Where("deployment_id = ? AND status IN ?", deploymentID, []string{biz.DeploymentReleasePending, biz.DeploymentReleaseRunning}).
+		Where("(task_id <> 0 AND task_id NOT IN (?)) OR (task_id = 0 AND created_at < ?)", active, time.Now().Add(-time.Minute)).
+		Update("status", biz.DeploymentReleaseFailed).Error
+}
+
+func (r *deploymentRepo) Execute(ctx context.Context, deployment *biz.Deployment, release *biz.DeploymentRelease, out stdio.Writer) (err error) {
+	// 附带版本 ID，同一秒内的多次部署也不会共用目录
+	release.Name = fmt.Sprintf("%s-%d", time.Now().Format("20060102150405"), release.ID)
+	release.Status = biz.DeploymentReleaseRunning
+	if err = r.UpdateRelease(release); err != nil {
+		return err
+	}
+
+	releaseDir := filepath.Join(deploymentReleasesDir(deployment), release.Name)
+	defer func() {
+		if err != nil {
+			release.Status = biz.DeploymentReleaseFailed
+			_ = io.Remove(releaseDir)
+		}
+		if updateErr := r.UpdateRelease(release); updateErr != nil && err == nil {
+			err = updateErr
+		}
+	}()
+
+	_, _ = fmt.Fprintln(out, r.t.Get("|-Fetching %s (%s)", deployment.Repo, deployment.Branch))
+	if err = r.fetch(ctx, deployment, out); err != nil {
+		return err
+	}
+	if release.Commit, release.Message, err = r.resolve(ctx, deployment); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, r.t.Get("|-Commit %s: %s", release.Commit, release.Message))
+
+	// WebHook 可能由无关分支的推送触发，提交未变化时无需重新发布
+	if release.Trigger == biz.DeploymentTriggerWebhook && deployment.ReleaseID != 0 {
+		if current, err := r.GetRelease(deployment.ReleaseID); err == nil && current.Commit == release.Commit {
+			_, _ = fmt.Fprintln(out, r.t.Get("|-Commit unchanged, skipped"))
+			release.Status = biz.DeploymentReleaseSkipped
+			return nil
+		}
+	}
+
+	_, _ = fmt.Fprintln(out, r.t.Get("|-Checking out to %s", releaseDir))
+	if err = r.checkout(ctx, deployment, release.Commit, releaseDir, out); err != nil {
+		return err
+	}
+	if err = r.linkShared(deployment, releaseDir); err != nil {
+		return err
+	}
+	// 在目录交给构建用户前写入，构建命令无法预先放置软链接
+	if deployment.Target == biz.DeploymentTargetWebsite {
+		if err = r.openBasedir(deployment, releaseDir); err != nil {
+			return err
+		}
+	}
+	if deployment.User != "" && deployment.User != "root" {
+		if err = io.Chown(releaseDir, deployment.User, deployment.User); err != nil {
+			return err
+		}
+		if len(deployment.Shared) > 0 {
+			if err = io.Chown(deploymentSharedDir(deployment), deployment.User, deployment.User); err != nil {
+				return err
+			}
+		}
+	}
+
+	if strings.TrimSpace(deployment.Build) != "" {
+		_, _ = fmt.Fprintln(out, r.t.Get("|-Building"))
+		if err = r.build(ctx, deployment, releaseDir, out); err != nil {
+			return errors.New(r.t.Get("build failed: %v", err))
+		}
+	}
+
+	release.Status = biz.DeploymentReleaseSuccess
+	if err = r.UpdateRelease(release); err != nil {
+		return err
+	}
+	if err = r.Activate(deployment, release, out); err != nil {
+		return err
+	}
+	r.prune(deployment, out)
+
+	_, _ = fmt.Fprintln(out, r.t.Get("|-Release %s deployed", release.Name))
+	return nil
+}
+
+func (r *deploymentRepo) Activate(deployment *biz.Deployment, release *biz.DeploymentRelease, out stdio.Writer) error {
+	releaseDir := filepath.Join(deploymentReleasesDir(deployment), release.Name)
+	if !io.IsDir(releaseDir) {
+		return errors.New(r.t.Get("release directory %s not found", releaseDir))
+	}
+
+	_, _ = fmt.Fprintln(out, r.t.Get("|-Switching %s to release %s", deployment.Path, release.Name))
+	if err := r.switchLink(deployment.Path, releaseDir, out); err != nil {
+		return err
+	}
+	if err := r.db.Model(&biz.Deployment{}).Where("id = ?", deployment.ID).Update("release_id", release.ID).Error; err != nil {
+		return err
+	}
+	deployment.ReleaseID = release.ID
+
+	// 新版本已生效，重启失败只提示不回退
+	if err := r.restart(deployment, out); err != nil {
+		_, _ = fmt.Fprintln(out, r.t.Get("|-Failed to restart %s: %v", deployment.Name, err))
+	}
+
+	return nil
+}
+
+// deploymentGitProtocols 拉取仓库允许的传输协议，禁止 file 等本地协议读取本机其他仓库
+var deploymentGitProtocols = "https:ssh"
+
+// gitEnv 使用部署密钥访问仓库，首次连接自动信任主机密钥
+func (r *deploymentRepo) gitEnv(deployment *biz.Deployment) ([]string, error) {
+	dir := deploymentCacheDir(deployment.ID)
+	key := filepath.Join(dir, "id_ed25519")
+	if err := io.Write(key, deployment.PrivateKey, 0o600); err != nil {
+		return nil, err
+	}
+
+	return []string{
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_ALLOW_PROTOCOL=" + deploymentGitProtocols,
+		fmt.Sprintf("GIT_SSH_COMMAND=ssh -i %s -o IdentitiesOnly=yes -o StrictHostKeyChecking=accept-new -o UserKnownHostsFile=%s", key, filepath.Join(dir, "known_hosts")),
+	}, nil
+}
+
+// fetch 将远程仓库同步到本地镜像，后续部署只拉取增量
+func (r *deploymentRepo) fetch(ctx context.Context, deployment *biz.Deployment, out stdio.Writer) error {
+	env, err := r.gitEnv(deployment)
+	if err != nil {
+		return err
+	}
+
+	mirror := filepath.Join(deploymentCacheDir(deployment.ID), "repo.git")
+	if !io.Exists(mirror) {
+		return r.git(ctx, env, out, "clone", "--mirror", "--", deployment.Repo, mirror)
+	}
+	if err = r.git(ctx, env, out, "--git-dir", mirror, "remote", "set-url", "--", "origin", deployment.Repo); err != nil {
+		return err
+	}
+
+	return r.git(ctx, env, out, "--git-dir", mirror, "fetch", "--prune", "origin")
+}
+
+// resolve 分支最新的提交与说明
+func (r *deploymentRepo) resolve(ctx context.Context, deployment *biz.Deployment) (string, string, error) {
+	mirror := filepath.Join(deploymentCacheDir(deployment.ID), "repo.git")
+	commit, err := exec.CommandContext(ctx, "git", "--git-dir", mirror, "rev-parse", "--verify", "refs/heads/"+deployment.Branch+"^{commit}").Output()
+	if err != nil {
+		return "", "", errors.New(r.t.Get("branch %s not found", deployment.Branch))
+	}
+	message, err := exec.CommandContext(ctx, "git", "--git-dir", mirror, "log", "-1", "--format=%s", strings.TrimSpace(string(commit))).Output()
+	if err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(string(commit)), strings.TrimSpace(string(message)), nil
+}
+
+// checkout 导出提交的文件到版本目录，不含 .git
+func (r *deploymentRepo) checkout(ctx context.Context, deployment *biz.Deployment, commit, dir string, out stdio.Writer) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	mirror := filepath.Join(deploymentCacheDir(deployment.ID), "repo.git")
+	archive := exec.CommandContext(ctx, "git", "--git-dir", mirror, "archive", "--format=tar", commit)
+	extract := exec.CommandContext(ctx, "tar", "-x", "-C", dir)
+	pipe, err := archive.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	archive.Stderr = out
+	extract.Stdin = pipe
+	extract.Stdout = out
+	extract.Stderr = out
+
+	if err = archive.Start(); err != nil {
+		return err
+	}
+	if err = extract.Run(); err != nil {
+		_ = archive.Wait()
+		return err
+	}
+
+	return archive.Wait()
+}
+
+// linkShared 将共享路径链接到版本目录，共享内容不存在时以仓库中的同名内容初始化
+func (r *deploymentRepo) linkShared(deployment *biz.Deployment, dir string) error {
+	for _, path := range deployment.Shared {
+		shared := filepath.Join(deploymentSharedDir(deployment), path)
+		target := filepath.Join(dir, path)
+		if !io.Exists(shared) {
+			if err := os.MkdirAll(filepath.Dir(shared), 0o755); err != nil {
+				return err
+			}
+			if io.Exists(target) {
+				if err := os.Rename(target, shared); err != nil {
+					return err
+				}
+			} else if err := os.MkdirAll(shared, 0o755); err != nil {
+				return err
+			}
+		}
+
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.Symlink(shared, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// build 在版本目录中以部署用户执行构建命令
+func (r *deploymentRepo) build(ctx context.Context, deployment *biz.Deployment, dir string, out stdio.Writer) error {
+	script := "set -e\n"
+	if deployment.Runtime != "" {
+		script += fmt.Sprintf("export PATH=%s:$PATH\n", filepath.Join(app.Root, "server", deployment.Runtime, deployment.Version, "bin"))
+	}
+	script += fmt.Sprintf("cd '%s'\n%s", dir, deployment.Build)
+
+	var cmd *exec.Cmd
+	if deployment.User == "" || deployment.User == "root" {
+		cmd = exec.CommandContext(ctx, "bash", "-c", script)
+	} else {
+		cmd = exec.CommandContext(ctx, "su", "-s", "/bin/bash", "-c", script, deployment.User)
+	}
+	shell.ApplyEnv(cmd)
+	cmd.Dir = dir
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	return cmd.Run()
+}
+
+// openBasedir 网站开启防跨站时，为新版本写入包含版本与共享目录的 open_basedir
+// PHP 按软链接解析后的真实路径校验，仅允许网站目录会拦截版本目录中的文件
+func (r *deploymentRepo) openBasedir(deployment *biz.Deployment, dir string) error {
+	setting, err := r.website.Get(deployment.TargetID)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(strings.TrimSuffix(setting.Path, "/"), strings.TrimSuffix(setting.Root, "/"))
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil
+	}
+	if !io.Exists(filepath.Join(deployment.Path, rel, ".user.ini")) {
+		return nil
+	}
+
+	// 仓库内容不可信，逐级确认运行目录不是软链接，避免写到版本目录之外
+	root := dir
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if part == "" || part == "." {
+			continue
+		}
+		root = filepath.Join(root, part)
+		info, err := os.Lstat(root)
+		if os.IsNotExist(err) {
+			if err = os.Mkdir(root, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return errors.New(r.t.Get("%s is not a directory", root))
+		}
+	}
+
+	// 仓库中自带的同名文件（包括软链接）直接替换
+	userIni := filepath.Join(root, ".user.ini")
+	if err = os.Remove(userIni); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	f, err := os.OpenFile(userIni, os.O_CREATE|os.O_EXCL|os.O_WRONLY|syscall.O_NOFOLLOW, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func(f *os.File) { _ = f.Close() }(f)
+	if _, err = fmt.Fprintf(f, "open_basedir=%s/:%s/:%s/:/tmp/", deployment.Path, deploymentReleasesDir(deployment), deploymentSharedDir(deployment)); err != nil {
+		return err
+	}
+
+	_ = chattr.SetAttr(f, chattr.FS_IMMUTABLE_FL)
+
+	return nil
+}
+
+// switchLink 原子切换软链接，首次部署时原目录改名保留
+func (r *deploymentRepo) switchLink(path, dir string, out stdio.Writer) error {
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink == 0 {
+		backup := path + ".predeploy"
+		if io.Exists(backup) {
+			backup += "-" + time.Now().Format("20060102150405")
+		}
+		if err = os.Rename(path, backup); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, r.t.Get("|-Moved the existing directory to %s", backup))
+	}
+
+	tmp := path + ".deploying"
+	_ = os.Remove(tmp)
+	if err := os.Symlink(dir, tmp); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// restart 新版本生效后重启项目，PHP 网站重载 PHP-FPM 清理路径缓存
+func (r *deploymentRepo) restart(deployment *biz.Deployment, out stdio.Writer) error {
+	switch deployment.Target {
+	case biz.DeploymentTargetProject:
+		project := new(biz.Project)
+		if err := r.db.Where("id = ?", deployment.TargetID).First(project).Error; err != nil {
+			return err
+		}
+		if active, _ := systemctl.Status(project.ServingUnit()); !active {
+			return nil
+		}
+		// 开启蓝绿重启的项目切换到新实例，不中断服务
+		if project.BlueGreen.Enabled {
+			return r.project.BlueGreenRestart(project, out)
+		}
+		_, _ = fmt.Fprintln(out, r.t.Get("|-Restarting %s", project.Name))
+		return systemctl.Restart(project.Name)
+	case biz.DeploymentTargetWebsite:
+		setting, err := r.website.Get(deployment.TargetID)
+		if err != nil || setting.PHP == 0 {
+			return err
+		}
+		name := fmt.Sprintf("php-fpm-%d", setting.PHP)
+		_, _ = fmt.Fprintln(out, r.t.Get("|-Reloading %s", name))
+		return systemctl.Reload(name)
+	}
+
+	return nil
+}
+
+// prune 清理超出保留数量的版本目录，当前版本始终保留
+func (r *deploymentRepo) prune(deployment *biz.Deployment, out stdio.Writer) {
+	var releases []*biz.DeploymentRelease
+	if err := r.db.Where("deployment_id = ? AND status = ? AND pruned = ?", deployment.ID, biz.DeploymentReleaseSuccess, false).Order("id desc").Find(&releases).Error; err != nil {
+		return
+	}
+
+	kept := uint(0)
+	for _, release := range releases {
+		if release.ID == deployment.ReleaseID || kept < deployment.Keep {
+			kept++
+			continue
+		}
+		if err := io.Remove(filepath.Join(deploymentReleasesDir(deployment), release.Name)); err != nil {
+			_, _ = fmt.Fprintln(out, r.t.Get("|-Failed to remove release %s: %v", release.Name, err))
+			continue
+		}
+		release.Pruned = true
+		_ = r.UpdateRelease(release)
+	}
+}
+
+func (r *deploymentRepo) git(ctx context.Context, env []string, out stdio.Writer, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	shell.ApplyEnv(cmd, env...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
+
+// deleteDeployments 删除目标的部署配置及其 WebHook，随项目、网站删除调用
+func deleteDeployments(tx *gorm.DB, target string, targetID uint) error {
+	var deployments []*biz.Deployment
+	if err := tx.Where("target = ? AND target_id = ?", target, targetID).Find(&deployments).Error; err != nil {
+		return err
+	}
+
+	for _, deployment := range deployments {
+		if deployment.WebHookID != 0 {
+			webhook := new(biz.WebHook)
+			if err := tx.Where("id = ?", deployment.WebHookID).First(webhook).Error; err == nil {
+				_ = os.Remove((&webhookRepo{}).scriptPath(webhook.Key))
+				if err = tx.Delete(webhook).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if err := tx.Where("deployment_id = ?", deployment.ID).Delete(&biz.DeploymentRelease{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(deployment).Error; err != nil {
+			return err
+		}
+		_ = os.RemoveAll(deploymentCacheDir(deployment.ID))
+	}
+
+	return nil
+}
+
+// deploymentCacheDir 部署密钥与仓库镜像目录
+func deploymentCacheDir(id uint) string {
+	return filepath.Join(app.Root, "server", "deploy", fmt.Sprintf("%d", id))
+}
+
+// deploymentReleasesDir 版本目录，与发布目录同级
+func deploymentReleasesDir(deployment *biz.Deployment) string {
+	return deployment.Path + ".releases"
+}
+
+// deploymentSharedDir 共享文件目录，与发布目录同级
+func deploymentSharedDir(deployment *biz.Deployment) string {
+	return deployment.Path + ".shared"
+}
@@ -0,0 +1,216 @@
+package data
+
+import (
+	"context"
+	stdio "io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/libtnb/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/pkg/io"
+)
+
+func TestDeploymentExecute(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&biz.Deployment{}, &biz.DeploymentRelease{}, &biz.Task{}, &biz.Project{}); err != nil {
+		t.Fatal(err)
+	}
+	root, key, protocols := app.Root, app.Key, deploymentGitProtocols
+	app.Root = t.TempDir()
+	app.Key = "0123456789abcdef0123456789abcdef"
+	// 测试使用本地仓库
+	deploymentGitProtocols = "file"
+	t.Cleanup(func() { app.Root, app.Key, deploymentGitProtocols = root, key, protocols })
+
+	// 本地仓库，每次提交修改 version 文件
+	source := filepath.Join(app.Root, "source")
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", source, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	commit := func(version string) {
+		if err := os.WriteFile(filepath.Join(source, "version"), []byte(version), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "-A")
+		git("commit", "-m", "release "+version)
+	}
+	if err = os.MkdirAll(filepath.Join(source, "storage"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(source, "storage", "seed"), []byte("seed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("init", "-b", "main")
+	commit("v1")
+
+	path := filepath.Join(app.Root, "projects", "demo")
+	if err = os.MkdirAll(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(path, "old"), []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project := &biz.Project{Name: "acepanel-test-deployment", Path: path}
+	if err = db.Create(project).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &deploymentRepo{t: gotext.NewLocale("", ""), db: db}
+	deployment := &biz.Deployment{
+		Target:     biz.DeploymentTargetProject,
+		TargetID:   project.ID,
+		Name:       project.Name,
+		Path:       path,
+		Repo:       source,
+		Branch:     "main",
+		Build:      "cat version > built",
+		Shared:     []string{"storage"},
+		Keep:       2,
+		PrivateKey: "key",
+	}
+	if err = repo.Create(deployment); err != nil {
+		t.Fatal(err)
+	}
+	deploy := func(trigger string) (*biz.DeploymentRelease, error) {
+		release := &biz.DeploymentRelease{DeploymentID: deployment.ID, Trigger: trigger, Status: biz.DeploymentReleasePending}
+		if err := repo.CreateRelease(release); err != nil {
+			t.Fatal(err)
+		}
+		return release, repo.Execute(context.Background(), deployment, release, stdio.Discard)
+	}
+	current := func() string {
+		data, err := os.ReadFile(filepath.Join(path, "built"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	first, err := deploy(biz.DeploymentTriggerManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current() != "v1" || first.Status != biz.DeploymentReleaseSuccess || deployment.ReleaseID != first.ID {
+		t.Fatalf("first release not active: %+v", first)
+	}
+	if data, _ := os.ReadFile(filepath.Join(path+".predeploy", "old")); string(data) != "old" {
+		t.Fatal("existing directory should be kept aside on the first deployment")
+	}
+	if data, _ := os.ReadFile(filepath.Join(path+".shared", "storage", "seed")); string(data) != "seed" {
+		t.Fatal("shared path should be seeded from the repository")
+	}
+	if link, _ := os.Readlink(filepath.Join(path, "storage")); link != filepath.Join(path+".shared", "storage") {
+		t.Fatalf("shared path links to %q", link)
+	}
+
+	// WebHook 触发但分支没有新提交时跳过
+	skipped, err := deploy(biz.DeploymentTriggerWebhook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped.Status != biz.DeploymentReleaseSkipped || deployment.ReleaseID != first.ID {
+		t.Fatalf("unchanged webhook deployment should be skipped, got %q", skipped.Status)
+	}
+
+	commit("v2")
+	second, err := deploy(biz.DeploymentTriggerWebhook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit("v3")
+	if _, err = deploy(biz.DeploymentTriggerManual); err != nil {
+		t.Fatal(err)
+	}
+	if current() != "v3" {
+		t.Fatalf("current release = %q, want v3", current())
+	}
+
+	// 超出保留数量的版本被清理
+	if first, err = repo.GetRelease(first.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !first.Pruned || io.Exists(filepath.Join(path+".releases", first.Name)) {
+		t.Fatal("oldest release should be pruned")
+	}
+
+	// 回滚只切换软链接
+	if err = repo.Activate(deployment, second, stdio.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if current() != "v2" || deployment.ReleaseID != second.ID {
+		t.Fatalf("rollback current release = %q", current())
+	}
+
+	// 构建失败不切换版本，并清理失败的版本目录
+	deployment.Build = "exit 3"
+	failed, err := deploy(biz.DeploymentTriggerManual)
+	if err == nil || failed.Status != biz.DeploymentReleaseFailed {
+		t.Fatalf("failed build should fail the release, got %q", failed.Status)
+	}
+	if current() != "v2" || io.Exists(filepath.Join(path+".releases", failed.Name)) {
+		t.Fatal("failed release should not be activated")
+	}
+}
+
+func TestDeploymentWaitingRelease(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&biz.DeploymentRelease{}, &biz.Task{}); err != nil {
+		t.Fatal(err)
+	}
+	repo := &deploymentRepo{t: gotext.NewLocale("", ""), db: db}
+
+	waiting := &biz.Task{Name: "deploy", Status: biz.TaskStatusWaiting}
+	canceled := &biz.Task{Name: "deploy", Status: biz.TaskStatusCanceled}
+	if err = db.Create(waiting).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Create(canceled).Error; err != nil {
+		t.Fatal(err)
+	}
+	stale := &biz.DeploymentRelease{DeploymentID: 1, Status: biz.DeploymentReleasePending, TaskID: canceled.ID}
+	queued := &biz.DeploymentRelease{DeploymentID: 1, Status: biz.DeploymentReleasePending, TaskID: waiting.ID}
+	if err = repo.CreateRelease(stale); err != nil {
+		t.Fatal(err)
+	}
+	if err = repo.CreateRelease(queued); err != nil {
+		t.Fatal(err)
+	}
+
+	release, err := repo.WaitingRelease(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release == nil || release.ID != queued.ID {
+		t.Fatalf("waiting release = %+v, want %d", release, queued.ID)
+	}
+	// 任务已取消的版本标记为失败
+	if stale, err = repo.GetRelease(stale.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stale.Status != biz.DeploymentReleaseFailed {
+		t.Fatalf("stale release status = %q", stale.Status)
+	}
+
+	if err = db.Model(waiting).Update("status", biz.TaskStatusRunning).Error; err != nil {
+		t.Fatal(err)
+	}
+	if release, err = repo.WaitingRelease(1); err != nil || release != nil {
+		t.Fatalf("running release is not waiting: %+v, %v", release, err)
+	}
+}
@@ -0,0 +1,83 @@
+package data
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+)
+
+type firewallGeoRepo struct {
+	t  *gotext.Locale
+	db *gorm.DB
+}
+
+func NewFirewallGeoRepo(db *gorm.DB, t *gotext.Locale) biz.FirewallGeoRepo {
+	return &firewallGeoRepo{
+		t:  t,
+		db: db,
+	}
+}
+
+func (r *firewallGeoRepo) List() ([]*biz.FirewallGeoRule, error) {
+	rules := make([]*biz.FirewallGeoRule, 0)
+	err := r.db.Order("id asc").Find(&rules).Error
+	return rules, err
+}
+
+func (r *firewallGeoRepo) Get(id uint) (*biz.FirewallGeoRule, error) {
+	rule := new(biz.FirewallGeoRule)
+	if err := r.db.Where("id = ?", id).First(rule).Error; err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (r *firewallGeoRepo) Create(req *request.FirewallGeoRule) (*biz.FirewallGeoRule, error) {
+	rule := &biz.FirewallGeoRule{
+		Country:   strings.TrimSpace(req.Country),
+		PortStart: req.PortStart,
+		PortEnd:   req.PortEnd,
+		Protocol:  req.Protocol,
+		Strategy:  req.Strategy,
+	}
+	if rule.PortEnd == 0 {
+		rule.PortEnd = rule.PortStart
+	}
+	if rule.PortStart > rule.PortEnd {
+		return nil, errors.New(r.t.Get("invalid port range"))
+	}
+	// 所有端口时不区分协议
+	if rule.PortStart == 0 {
+		rule.Protocol = ""
+	} else if rule.Protocol == "" {
+		rule.Protocol = "tcp/udp"
+	}
+
+	var count int64
+	if err := r.db.Model(&biz.FirewallGeoRule{}).Where("country = ? AND port_start = ? AND port_end = ? AND protocol = ? AND strategy = ?", rule.Country, rule.PortStart, rule.PortEnd, rule.Protocol, rule.Strategy).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.New(r.t.Get("geo rule already exists"))
+	}
+
+	if err := r.db.Create(rule).Error; err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (r *firewallGeoRepo) Delete(id uint) error {
+	return r.db.Delete(&biz.FirewallGeoRule{}, id).Error
+}
+
+func (r *firewallGeoRepo) UpdateNetworks(id uint, networks uint) error {
+	return r.db.Model(&biz.FirewallGeoRule{}).Where("id = ?", id).Update("networks", networks).Error
+}
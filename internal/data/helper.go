@@ -13,6 +13,7 @@ import (
 	"gorm.io/gorm/clause"
 
 	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
 )
 
 // upsert 分批大小
@@ -118,3 +119,16 @@ func vacuumDB(db *gorm.DB) error {
 	}
 	return db.Exec("PRAGMA optimize").Error
 }
+
+// scoped 按 context 中用户的资源范围过滤查询，不受限时原样返回
+func scoped(ctx context.Context, query *gorm.DB, kind, column string) *gorm.DB {
+	ids, restricted := biz.ScopeIDs(ctx, kind)
+	if !restricted {
+		return query
+	}
+	if len(ids) == 0 {
+		return query.Where("1 = 0")
+	}
+
+	return query.Where(column+" IN ?", ids)
+}
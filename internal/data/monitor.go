@@ -1,17 +1,23 @@
 package data
 
 import (
+	"sync"
 	"time"
 
 	"github.com/go-gormigrate/gormigrate/v2"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/acepanel/panel/v3/internal/biz"
 	"github.com/acepanel/panel/v3/internal/migration"
+	"github.com/acepanel/panel/v3/pkg/types"
 )
 
 type monitorRepo struct {
 	db *gorm.DB
+
+	mu     sync.Mutex
+	series map[string]uint // 指标名 → 序列 ID 缓存
 }
 
 func NewMonitorRepo() (biz.MonitorRepo, error) {
@@ -23,30 +29,123 @@ func NewMonitorRepo() (biz.MonitorRepo, error) {
 		return nil, err
 	}
 
-	return &monitorRepo{db: monitorDB}, nil
+	return &monitorRepo{db: monitorDB, series: make(map[string]uint)}, nil
 }
 
-func (r *monitorRepo) Create(monitor *biz.Monitor) error {
-	return r.db.Create(monitor).Error
+func (r *monitorRepo) Insert(at time.Time, values map[string]float64, processes types.TopProcesses) error {
+	ids, err := r.seriesIDs(values)
+	if err != nil {
+		return err
+	}
+
+	points := make([]*biz.MonitorPoint, 0, len(values))
+	for name, value := range values {
+		points = append(points, &biz.MonitorPoint{
+			Resolution: biz.MonitorResolutionRaw,
+			Time:       at.Unix(),
+			SeriesID:   ids[name],
+			Avg:        value,
+			Min:        value,
+			Max:        value,
+			Count:      1,
+		})
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err = tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(points, 200).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&biz.MonitorProcess{Time: at.Unix(), Processes: processes}).Error
+	})
 }
 
-func (r *monitorRepo) ClearBefore(t time.Time) error {
-	return r.db.Where("created_at < ?", t).Delete(&biz.Monitor{}).Error
+func (r *monitorRepo) Latest(resolution uint) (int64, error) {
+	var latest *int64
+	if err := r.db.Model(&biz.MonitorPoint{}).Where("resolution = ?", resolution).Select("MAX(time)").Scan(&latest).Error; err != nil {
+		return 0, err
+	}
+	if latest == nil {
+		return 0, nil
+	}
+
+	return *latest, nil
 }
 
-func (r *monitorRepo) Clear() error {
-	return r.db.Where("1 = 1").Delete(&biz.Monitor{}).Error
+// Rollup 将 [start, end) 内 from 分辨率的数据按 to 分辨率分桶聚合
+func (r *monitorRepo) Rollup(from, to uint, start, end int64, offset int64) error {
+	return r.db.Exec(`INSERT INTO monitor_points (resolution, time, series_id, avg, min, max, count)
+SELECT ?, (time + ?) / ? * ? - ?, series_id, SUM(avg * count) / SUM(count), MIN(min), MAX(max), SUM(count)
+FROM monitor_points
+WHERE resolution = ? AND time >= ? AND time < ?
+GROUP BY (time + ?) / ?, series_id
+ON CONFLICT DO NOTHING`,
+		to, offset, to, to, offset,
+		from, start, end,
+		offset, to,
+	).Error
 }
 
-func (r *monitorRepo) List(start, end time.Time) ([]*biz.Monitor, error) {
-	monitors := make([]*biz.Monitor, 0)
-	if err := r.db.Where("created_at BETWEEN ? AND ?", start, end).Find(&monitors).Error; err != nil {
-		return nil, err
+func (r *monitorRepo) ClearBefore(resolution uint, t time.Time) error {
+	if err := r.db.Where("resolution = ? AND time < ?", resolution, t.Unix()).Delete(&biz.MonitorPoint{}).Error; err != nil {
+		return err
+	}
+	if resolution == biz.MonitorResolutionRaw {
+		return r.db.Where("time < ?", t.Unix()).Delete(&biz.MonitorProcess{}).Error
 	}
 
-	return monitors, nil
+	return nil
+}
+
+func (r *monitorRepo) Clear() error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&biz.MonitorPoint{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("1 = 1").Delete(&biz.MonitorProcess{}).Error
+	})
+}
+
+func (r *monitorRepo) List(resolution uint, start, end time.Time) ([]*biz.MonitorPoint, error) {
+	points := make([]*biz.MonitorPoint, 0)
+	err := r.db.Model(&biz.MonitorPoint{}).
+		Select("monitor_points.*, monitor_series.name AS series").
+		Joins("JOIN monitor_series ON monitor_series.id = monitor_points.series_id").
+		Where("monitor_points.resolution = ? AND monitor_points.time BETWEEN ? AND ?", resolution, start.Unix(), end.Unix()).
+		Order("monitor_points.time ASC").
+		Find(&points).Error
+
+	return points, err
+}
+
+func (r *monitorRepo) ListProcesses(start, end time.Time) ([]*biz.MonitorProcess, error) {
+	processes := make([]*biz.MonitorProcess, 0)
+	err := r.db.Where("time BETWEEN ? AND ?", start.Unix(), end.Unix()).Order("time ASC").Find(&processes).Error
+
+	return processes, err
 }
 
 func (r *monitorRepo) VacuumDB() error {
 	return vacuumDB(r.db)
 }
+
+// seriesIDs 取指标对应的序列 ID，不存在时创建
+func (r *monitorRepo) seriesIDs(values map[string]float64) (map[string]uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make(map[string]uint, len(values))
+	for name := range values {
+		if id, ok := r.series[name]; ok {
+			ids[name] = id
+			continue
+		}
+		series := &biz.MonitorSeries{Name: name}
+		if err := r.db.Where(series).FirstOrCreate(series).Error; err != nil {
+			return nil, err
+		}
+		r.series[name] = series.ID
+		ids[name] = series.ID
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,183 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/libtnb/sqlite"
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/shirou/gopsutil/v4/net"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/migration"
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+func newMonitorDBForTest(t *testing.T) *gorm.DB {
+	t.Helper()
+	if err := registerZstdSerializer(); err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func newMonitorRepoForTest(t *testing.T) *monitorRepo {
+	t.Helper()
+	db := newMonitorDBForTest(t)
+	if err := gormigrate.New(db, nil, migration.MonitorMigrations).Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	return &monitorRepo{db: db, series: make(map[string]uint)}
+}
+
+func monitorPoints(t *testing.T, repo *monitorRepo, resolution uint, series string) []*biz.MonitorPoint {
+	t.Helper()
+	points, err := repo.List(resolution, time.Unix(0, 0), time.Now().AddDate(1, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := make([]*biz.MonitorPoint, 0)
+	for _, point := range points {
+		if point.Series == series {
+			result = append(result, point)
+		}
+	}
+	return result
+}
+
+func TestMonitorCompact(t *testing.T) {
+	repo := newMonitorRepoForTest(t)
+	uc := biz.NewMonitorUsecase(repo, nil)
+
+	// 两小时每分钟一次采样，CPU 依次为 0..119，网卡每秒发送 1000 字节
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 120 {
+		at := start.Add(time.Duration(i) * time.Minute)
+		info := types.CurrentInfo{
+			Percent: float64(i),
+			Load:    &load.AvgStat{Load1: 1},
+			Net:     []net.IOCountersStat{{Name: "eth0", BytesSent: uint64(i) * 60000}},
+			Time:    at,
+		}
+		if err := uc.Record(info); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := start.Add(25 * time.Hour)
+	for range 2 {
+		if err := uc.Compact(now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := len(monitorPoints(t, repo, biz.MonitorResolutionRaw, "cpu")); got != 120 {
+		t.Fatalf("raw points = %d, want 120", got)
+	}
+
+	fiveMin := monitorPoints(t, repo, biz.MonitorResolution5Min, "cpu")
+	if len(fiveMin) != 24 {
+		t.Fatalf("5min points = %d, want 24", len(fiveMin))
+	}
+	if p := fiveMin[0]; p.Time != start.Unix() || p.Avg != 2 || p.Min != 0 || p.Max != 4 || p.Count != 5 {
+		t.Fatalf("unexpected 5min point: %+v", p)
+	}
+
+	hour := monitorPoints(t, repo, biz.MonitorResolutionHour, "cpu")
+	if len(hour) != 2 || hour[1].Avg != 89.5 || hour[1].Count != 60 {
+		t.Fatalf("unexpected hour points: %+v", hour)
+	}
+
+	day := monitorPoints(t, repo, biz.MonitorResolutionDay, "cpu")
+	if len(day) != 1 || day[0].Avg != 59.5 || day[0].Min != 0 || day[0].Max != 119 || day[0].Count != 120 {
+		t.Fatalf("unexpected day points: %+v", day)
+	}
+
+	// 首个采样没有速率
+	tx := monitorPoints(t, repo, biz.MonitorResolutionDay, "net_tx:eth0")
+	if len(tx) != 1 || tx[0].Avg != 1000 || tx[0].Count != 119 {
+		t.Fatalf("unexpected tx points: %+v", tx)
+	}
+
+	// 原始数据超过保留期后删除，聚合数据保留
+	if err := uc.Cleanup(30, start.AddDate(0, 0, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(monitorPoints(t, repo, biz.MonitorResolutionRaw, "cpu")); got != 0 {
+		t.Fatalf("raw points after cleanup = %d, want 0", got)
+	}
+	if got := len(monitorPoints(t, repo, biz.MonitorResolution5Min, "cpu")); got != 24 {
+		t.Fatalf("5min points after cleanup = %d, want 24", got)
+	}
+	processes, err := repo.ListProcesses(start, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(processes) != 0 {
+		t.Fatalf("processes after cleanup = %d, want 0", len(processes))
+	}
+}
+
+func TestMonitorPickResolution(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		start time.Duration
+		days  int
+		want  uint
+	}{
+		{24 * time.Hour, 30, biz.MonitorResolutionRaw},
+		{7 * 24 * time.Hour, 30, biz.MonitorResolution5Min},
+		{30 * 24 * time.Hour, 90, biz.MonitorResolutionHour},
+		{90 * 24 * time.Hour, 400, biz.MonitorResolutionHour},
+		{365 * 24 * time.Hour, 400, biz.MonitorResolutionDay},
+		{3 * 365 * 24 * time.Hour, 1500, biz.MonitorResolutionDay},
+	}
+	for _, c := range cases {
+		if got := biz.MonitorPickResolution(now.Add(-c.start), now, now, c.days, 1); got != c.want {
+			t.Errorf("span %v days %d: got %d, want %d", c.start, c.days, got, c.want)
+		}
+	}
+}
+
+type legacyMonitorForTest struct {
+	ID        uint              `gorm:"primaryKey"`
+	Info      types.CurrentInfo `gorm:"serializer:zstd"`
+	CreatedAt time.Time
+}
+
+func (legacyMonitorForTest) TableName() string {
+	return "monitors"
+}
+
+func TestMonitorMigrateLegacy(t *testing.T) {
+	db := newMonitorDBForTest(t)
+	if err := gormigrate.New(db, nil, migration.MonitorMigrations[:1]).Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		row := &legacyMonitorForTest{Info: types.CurrentInfo{Percent: float64(i)}, CreatedAt: start.Add(time.Duration(i) * time.Minute)}
+		if err := db.Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := gormigrate.New(db, nil, migration.MonitorMigrations).Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	if db.Migrator().HasTable("monitors") {
+		t.Fatal("legacy table should be dropped")
+	}
+
+	repo := &monitorRepo{db: db, series: make(map[string]uint)}
+	points := monitorPoints(t, repo, biz.MonitorResolutionRaw, "cpu")
+	if len(points) != 3 || points[2].Avg != 2 || points[2].Time != start.Add(2*time.Minute).Unix() {
+		t.Fatalf("unexpected migrated points: %+v", points)
+	}
+}
@@ -0,0 +1,224 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/pkg/types"
+	"github.com/acepanel/panel/v3/pkg/uptime"
+)
+
+type probeRepo struct {
+	t  *gotext.Locale
+	db *gorm.DB
+}
+
+func NewProbeRepo(db *gorm.DB, t *gotext.Locale) biz.ProbeRepo {
+	return &probeRepo{
+		t:  t,
+		db: db,
+	}
+}
+
+func (r *probeRepo) List(page, limit uint) ([]*biz.Probe, int64, error) {
+	probes := make([]*biz.Probe, 0)
+	var total int64
+	err := r.db.Model(&biz.Probe{}).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&probes).Error
+	return probes, total, err
+}
+
+func (r *probeRepo) Get(id uint) (*biz.Probe, error) {
+	probe := new(biz.Probe)
+	if err := r.db.Where("id = ?", id).First(probe).Error; err != nil {
+		return nil, err
+	}
+
+	return probe, nil
+}
+
+func (r *probeRepo) Create(req *request.ProbeCreate) (*biz.Probe, error) {
+	if err := r.check(req.Type, req.Target, req.Config); err != nil {
+		return nil, err
+	}
+
+	probe := &biz.Probe{
+		Name:     req.Name,
+		Type:     req.Type,
+		Target:   strings.TrimSpace(req.Target),
+		Interval: req.Interval,
+		Timeout:  req.Timeout,
+		Config:   req.Config,
+		Enabled:  req.Enabled,
+	}
+	if err := r.db.Create(probe).Error; err != nil {
+		return nil, err
+	}
+
+	return probe, nil
+}
+
+func (r *probeRepo) Update(req *request.ProbeUpdate) error {
+	if err := r.check(req.Type, req.Target, req.Config); err != nil {
+		return err
+	}
+
+	probe, err := r.Get(req.ID)
+	if err != nil {
+		return err
+	}
+
+	// 目标变化后立即重新探测
+	if probe.Type != req.Type || probe.Target != strings.TrimSpace(req.Target) {
+		probe.CheckedAt = nil
+	}
+	probe.Name = req.Name
+	probe.Type = req.Type
+	probe.Target = strings.TrimSpace(req.Target)
+	probe.Interval = req.Interval
+	probe.Timeout = req.Timeout
+	probe.Config = req.Config
+	probe.Enabled = req.Enabled
+
+	return r.db.Save(probe).Error
+}
+
+func (r *probeRepo) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("probe_id = ?", id).Delete(&biz.ProbeRecord{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&biz.Probe{}, id).Error
+	})
+}
+
+func (r *probeRepo) Save(probe *biz.Probe) error {
+	return r.db.Save(probe).Error
+}
+
+func (r *probeRepo) Due(now time.Time) ([]*biz.Probe, error) {
+	probes := make([]*biz.Probe, 0)
+	if err := r.db.Where("enabled = ?", true).Find(&probes).Error; err != nil {
+		return nil, err
+	}
+
+	// 任务每分钟执行一次，留出几秒余量避免执行耗时导致整轮被跳过
+	due := make([]*biz.Probe, 0, len(probes))
+	for _, probe := range probes {
+		if probe.CheckedAt == nil || !now.Before(probe.CheckedAt.Add(time.Duration(probe.Interval)*time.Minute-5*time.Second)) {
+			due = append(due, probe)
+		}
+	}
+
+	return due, nil
+}
+
+func (r *probeRepo) Check(ctx context.Context, probe *biz.Probe) uptime.Result {
+	timeout := time.Duration(max(probe.Timeout, 1)) * time.Second
+	switch probe.Type {
+	case biz.ProbeTypeHTTP:
+		return uptime.HTTP(ctx, probe.Target, probe.Config, timeout)
+	case biz.ProbeTypeTCP:
+		return uptime.TCP(ctx, probe.Target, timeout)
+	case biz.ProbeTypePing:
+		return uptime.Ping(ctx, probe.Target, timeout)
+	}
+
+	return uptime.Result{Message: r.t.Get("unsupported probe type: %s", probe.Type)}
+}
+
+func (r *probeRepo) AddRecord(record *biz.ProbeRecord) error {
+	return r.db.Create(record).Error
+}
+
+func (r *probeRepo) ListRecords(probeID uint, page, limit uint) ([]*biz.ProbeRecord, int64, error) {
+	records := make([]*biz.ProbeRecord, 0)
+	var total int64
+	err := r.db.Model(&biz.ProbeRecord{}).Where("probe_id = ?", probeID).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&records).Error
+	return records, total, err
+}
+
+func (r *probeRepo) Uptime(probeID uint, start, end int64) (biz.ProbeUptime, error) {
+	var row struct {
+		Up      int64
+		Total   int64
+		Latency float64
+	}
+	err := r.db.Model(&biz.ProbeRecord{}).
+		Select("COALESCE(SUM(CASE WHEN success THEN 1 ELSE 0 END), 0) AS up, COUNT(*) AS total, COALESCE(AVG(CASE WHEN success THEN latency END), 0) AS latency").
+		Where("probe_id = ? AND time >= ? AND time < ?", probeID, start, end).
+		Scan(&row).Error
+	if err != nil {
+		return biz.ProbeUptime{}, err
+	}
+
+	stat := biz.ProbeUptime{Up: row.Up, Total: row.Total, Latency: row.Latency}
+	if row.Total > 0 {
+		stat.Uptime = float64(row.Up) / float64(row.Total) * 100
+	}
+
+	return stat, nil
+}
+
+func (r *probeRepo) Series(probeID uint, start, end, bucket int64) ([]*biz.ProbePoint, error) {
+	// 时间桶按本地时区对齐，天级桶从本地零点开始
+	_, offset := time.Now().Zone()
+	points := make([]*biz.ProbePoint, 0)
+	err := r.db.Raw(`SELECT (time + ?) / ? * ? - ? AS time,
+COALESCE(AVG(CASE WHEN success THEN latency END), 0) AS avg,
+COALESCE(MIN(CASE WHEN success THEN latency END), 0) AS min,
+COALESCE(MAX(CASE WHEN success THEN latency END), 0) AS max,
+SUM(CASE WHEN success THEN 1 ELSE 0 END) AS up, COUNT(*) AS total
+FROM probe_records
+WHERE probe_id = ? AND time >= ? AND time < ?
+GROUP BY (time + ?) / ?
+ORDER BY 1`,
+		offset, bucket, bucket, offset,
+		probeID, start, end,
+		offset, bucket,
+	).Scan(&points).Error
+
+	return points, err
+}
+
+func (r *probeRepo) ClearRecordsBefore(t int64) error {
+	return r.db.Where("time < ?", t).Delete(&biz.ProbeRecord{}).Error
+}
+
+// check 按探测类型校验目标与配置
+func (r *probeRepo) check(typ, target string, config types.ProbeConfig) error {
+	target = strings.TrimSpace(target)
+	switch typ {
+	case biz.ProbeTypeHTTP:
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New(r.t.Get("target must be a http or https URL"))
+		}
+		if _, err = uptime.MatchStatus(config.ExpectStatus, 200); err != nil {
+			return errors.New(r.t.Get("invalid expected status: %s", config.ExpectStatus))
+		}
+	case biz.ProbeTypeTCP:
+		host, port, err := net.SplitHostPort(target)
+		if err != nil || host == "" {
+			return errors.New(r.t.Get("target must be in host:port format"))
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return errors.New(r.t.Get("target must be in host:port format"))
+		}
+	case biz.ProbeTypePing:
+		if strings.ContainsAny(target, " /:") && net.ParseIP(target) == nil {
+			return errors.New(r.t.Get("target must be a hostname or IP address"))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libtnb/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+func newProbeRepoForTest(t *testing.T) *probeRepo {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&biz.Probe{}, &biz.ProbeRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	return &probeRepo{db: db}
+}
+
+func TestProbeUptimeAndSeries(t *testing.T) {
+	repo := newProbeRepoForTest(t)
+
+	_, offset := time.Now().Zone()
+	// 对齐到本地整点，便于断言桶边界
+	base := (time.Now().Unix()+int64(offset))/3600*3600 - int64(offset) - 7200
+	records := []*biz.ProbeRecord{
+		{ProbeID: 1, Time: base, Success: true, Latency: 10},
+		{ProbeID: 1, Time: base + 60, Success: true, Latency: 30},
+		{ProbeID: 1, Time: base + 120, Success: false, Latency: 5000, Message: "timeout"},
+		{ProbeID: 1, Time: base + 3600, Success: true, Latency: 20},
+		{ProbeID: 2, Time: base, Success: false},
+	}
+	for _, record := range records {
+		if err := repo.AddRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stat, err := repo.Uptime(1, base, base+7200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.Total != 4 || stat.Up != 3 || stat.Uptime != 75 || stat.Latency != 20 {
+		t.Fatalf("unexpected uptime: %+v", stat)
+	}
+
+	empty, err := repo.Uptime(3, base, base+7200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty.Total != 0 || empty.Uptime != 0 {
+		t.Fatalf("unexpected empty uptime: %+v", empty)
+	}
+
+	points, err := repo.Series(1, base, base+7200, 3600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(points))
+	}
+	// 失败的探测计入总数，但不计入响应时间
+	first := points[0]
+	if first.Time != base || first.Total != 3 || first.Up != 2 || first.Avg != 20 || first.Min != 10 || first.Max != 30 {
+		t.Fatalf("unexpected first point: %+v", first)
+	}
+	if points[1].Time != base+3600 || points[1].Total != 1 || points[1].Avg != 20 {
+		t.Fatalf("unexpected second point: %+v", points[1])
+	}
+
+	if err = repo.ClearRecordsBefore(base + 3600); err != nil {
+		t.Fatal(err)
+	}
+	_, total, err := repo.ListRecords(1, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("expected 1 record after clear, got %d", total)
+	}
+}
+
+func TestProbeDue(t *testing.T) {
+	repo := newProbeRepoForTest(t)
+
+	now := time.Now()
+	recent := now.Add(-30 * time.Second)
+	stale := now.Add(-58 * time.Second) // 留有余量，接近一个间隔即视为到期
+	probes := []*biz.Probe{
+		{Name: "never", Type: biz.ProbeTypeTCP, Target: "127.0.0.1:80", Interval: 1, Enabled: true},
+		{Name: "recent", Type: biz.ProbeTypeTCP, Target: "127.0.0.1:80", Interval: 1, Enabled: true, CheckedAt: &recent},
+		{Name: "stale", Type: biz.ProbeTypeTCP, Target: "127.0.0.1:80", Interval: 1, Enabled: true, CheckedAt: &stale},
+	}
+	for _, probe := range probes {
+		if err := repo.db.Create(probe).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	due, err := repo.Due(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, probe := range due {
+		names[probe.Name] = true
+	}
+	if len(due) != 2 || !names["never"] || !names["stale"] {
+		t.Fatalf("unexpected due probes: %v", names)
+	}
+}
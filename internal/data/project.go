@@ -2,6 +2,7 @@ package data
 
 import (
 	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -24,14 +25,16 @@ import (
 )
 
 type projectRepo struct {
-	t  *gotext.Locale
-	db *gorm.DB
+	t       *gotext.Locale
+	db      *gorm.DB
+	website biz.WebsiteRepo
 }
 
-func NewProjectRepo(db *gorm.DB, t *gotext.Locale) biz.ProjectRepo {
+func NewProjectRepo(db *gorm.DB, t *gotext.Locale, websiteRepo biz.WebsiteRepo) biz.ProjectRepo {
 	return &projectRepo{
-		t:  t,
-		db: db,
+		t:       t,
+		db:      db,
+		website: websiteRepo,
 	}
 }
 
@@ -43,11 +46,11 @@ func (r *projectRepo) Count() (int64, error) {
 	return count, nil
 }
 
-func (r *projectRepo) List(typ types.ProjectType, page, limit uint) ([]*biz.Project, int64, error) {
+func (r *projectRepo) List(ctx context.Context, typ types.ProjectType, page, limit uint) ([]*biz.Project, int64, error) {
 	var projects []*biz.Project
 	var total int64
 
-	query := r.db.Model(&biz.Project{})
+	query := scoped(ctx, r.db.Model(&biz.Project{}), biz.ScopeProject, "id")
 	if typ != "" && typ != "all" {
 		query = query.Where("type = ?", typ)
 	}
@@ -105,26 +108,97 @@ func (r *projectRepo) Save(project *biz.Project) error {
 }
 
 // RenameUnitFile 重命名 systemd unit 文件
-func (r *projectRepo) RenameUnitFile(old, new string) error {
-	oldPath := r.unitFilePath(old)
-	newPath := r.unitFilePath(new)
-	if err := os.Rename(oldPath, newPath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("%s: %w", r.t.Get("failed to rename systemd config"), err)
+func (r *projectRepo) RenameUnitFile(project *biz.Project, name string) error {
+	// 运行中的 unit 无法跟随文件改名，先停止并禁用，改名后按原状态恢复
+	running, enabled, err := r.releaseUnits(project)
+	if err != nil {
+		return err
+	}
+
+	// 蓝绿实例模板随主 unit 一起重命名
+	for _, suffix := range []string{"", "@"} {
+		if err = os.Rename(r.unitFilePath(project.Name+suffix), r.unitFilePath(name+suffix)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("%s: %w", r.t.Get("failed to rename systemd config"), err)
+		}
 	}
+	if err = systemctl.DaemonReload(); err != nil {
+		return err
+	}
+
+	renamed := *project
+	renamed.Name = name
+	for i, unitName := range r.units(&renamed) {
+		if enabled[i] {
+			if err = systemctl.Enable(unitName); err != nil {
+				return fmt.Errorf("%s: %w", r.t.Get("failed to enable %s", unitName), err)
+			}
+		}
+		if running[i] {
+			if err = systemctl.Start(unitName); err != nil {
+				return fmt.Errorf("%s: %w", r.t.Get("failed to start %s", unitName), err)
+			}
+		}
+	}
+
 	return nil
 }
 
 // RemoveUnitFile 删除 systemd unit 文件
-func (r *projectRepo) RemoveUnitFile(name string) error {
-	unitPath := r.unitFilePath(name)
-	if err := os.Remove(unitPath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("%s: %w", r.t.Get("failed to delete systemd config"), err)
+func (r *projectRepo) RemoveUnitFile(project *biz.Project) error {
+	// 删除模板前停止并禁用所有实例，否则它们将脱离面板管理继续运行
+	if _, _, err := r.releaseUnits(project); err != nil {
+		return err
 	}
-	return nil
+
+	for _, suffix := range []string{"", "@"} {
+		if err := os.Remove(r.unitFilePath(project.Name + suffix)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("%s: %w", r.t.Get("failed to delete systemd config"), err)
+		}
+	}
+
+	return systemctl.DaemonReload()
+}
+
+// units 返回项目的主 unit 与所有蓝绿实例 unit
+func (r *projectRepo) units(project *biz.Project) []string {
+	units := []string{project.Name}
+	for _, port := range lo.Uniq([]uint{project.BlueGreen.BluePort, project.BlueGreen.GreenPort, project.BlueGreen.ActivePort}) {
+		if port != 0 {
+			units = append(units, project.InstanceUnit(port))
+		}
+	}
+	return units
+}
+
+// releaseUnits 停止并禁用项目的所有 unit，返回各 unit 原先的运行与启用状态
+func (r *projectRepo) releaseUnits(project *biz.Project) (running, enabled []bool, err error) {
+	units := r.units(project)
+	running = make([]bool, len(units))
+	enabled = make([]bool, len(units))
+	for i, unitName := range units {
+		running[i], _ = systemctl.Status(unitName)
+		enabled[i], _ = systemctl.IsEnabled(unitName)
+		if running[i] {
+			if err = systemctl.Stop(unitName); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", r.t.Get("failed to stop %s", unitName), err)
+			}
+		}
+		if enabled[i] {
+			if err = systemctl.Disable(unitName); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", r.t.Get("failed to disable %s", unitName), err)
+			}
+		}
+	}
+	return running, enabled, nil
 }
 
 func (r *projectRepo) Delete(project *biz.Project) error {
-	return r.db.Delete(project).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(project).Error; err != nil {
+			return err
+		}
+		return deleteDeployments(tx, biz.DeploymentTargetProject, project.ID)
+	})
 }
 
 // unitFilePath 返回 systemd unit 文件路径
@@ -135,10 +209,12 @@ func (r *projectRepo) unitFilePath(name string) string {
 // ParseDetail 从数据库记录和 systemd unit 文件解析项目详情
 func (r *projectRepo) ParseDetail(project *biz.Project) (*types.ProjectDetail, error) {
 	detail := &types.ProjectDetail{
-		ID:      project.ID,
-		Name:    project.Name,
-		Type:    project.Type,
-		RootDir: project.Path,
+		ID:        project.ID,
+		Name:      project.Name,
+		Type:      project.Type,
+		RootDir:   project.Path,
+		BlueGreen: project.BlueGreen,
+		Unit:      project.ServingUnit(),
 	}
 
 	// 读取并解析 systemd unit 文件
@@ -167,8 +243,8 @@ func (r *projectRepo) ParseDetail(project *biz.Project) (*types.ProjectDetail, e
 		}
 	}
 
-	// 获取运行状态
-	if info, err := systemctl.GetServiceInfo(project.Name); err == nil {
+	// 获取运行状态，蓝绿重启后为当前实例的状态
+	if info, err := systemctl.GetServiceInfo(detail.Unit); err == nil {
 		detail.Status = info.Status
 		detail.PID = info.PID
 		detail.Memory = info.Memory
@@ -177,7 +253,7 @@ func (r *projectRepo) ParseDetail(project *biz.Project) (*types.ProjectDetail, e
 	}
 
 	// 获取是否自启动
-	if enabled, err := systemctl.IsEnabled(project.Name); err == nil {
+	if enabled, err := systemctl.IsEnabled(detail.Unit); err == nil {
 		detail.Enabled = enabled
 	}
 
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/unit"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/pkg/systemctl"
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+// BlueGreenRestart 蓝绿重启：新实例在另一端口启动并通过健康检查后，改写网站上游并重载 Web 服务器，
+// 随后等待旧实例处理完已有请求再停止。切换前任一步失败都会停止新实例，旧实例继续提供服务
+func (r *projectRepo) BlueGreenRestart(project *biz.Project, out io.Writer) error {
+	cfg := project.BlueGreen
+	oldUnit := project.ServingUnit()
+	port := cfg.BluePort
+	if cfg.ActivePort == cfg.BluePort {
+		port = cfg.GreenPort
+	}
+	newUnit := project.InstanceUnit(port)
+
+	// 每次重启都从主 unit 重新生成模板，使项目配置的修改对新实例生效
+	if err := r.writeInstanceTemplate(project.Name); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(out, r.t.Get("|-Starting %s on port %d", newUnit, port))
+	if err := systemctl.Restart(newUnit); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, r.t.Get("|-Waiting for http://127.0.0.1:%d%s", port, cfg.HealthCheck))
+	if err := r.waitHealthy(port, cfg, func() bool {
+		running, _ := systemctl.Status(newUnit)
+		return running
+	}); err != nil {
+		_ = systemctl.Stop(newUnit)
+		return err
+	}
+
+	_, _ = fmt.Fprintln(out, r.t.Get("|-Switching upstream %s to port %d", cfg.Upstream, port))
+	if err := r.website.SwitchUpstream(cfg.WebsiteID, cfg.Upstream, fmt.Sprintf("127.0.0.1:%d", port)); err != nil {
+		_ = systemctl.Stop(newUnit)
+		return err
+	}
+	project.BlueGreen.ActivePort = port
+	if err := r.db.Model(project).Select("blue_green").Updates(project).Error; err != nil {
+		return err
+	}
+
+	// 开机自启跟随当前实例
+	if enabled, _ := systemctl.IsEnabled(oldUnit); enabled {
+		if err := systemctl.Enable(newUnit); err != nil {
+			_, _ = fmt.Fprintln(out, r.t.Get("|-Failed to enable %s: %v", newUnit, err))
+		} else {
+			_ = systemctl.Disable(oldUnit)
+		}
+	}
+
+	if running, _ := systemctl.Status(oldUnit); running {
+		if cfg.DrainTimeout > 0 {
+			_, _ = fmt.Fprintln(out, r.t.Get("|-Draining %s for %d seconds", oldUnit, cfg.DrainTimeout))
+			time.Sleep(time.Duration(cfg.DrainTimeout) * time.Second)
+		}
+		_, _ = fmt.Fprintln(out, r.t.Get("|-Stopping %s", oldUnit))
+		if err := systemctl.Stop(oldUnit); err != nil {
+			// 流量已切换，旧实例停止失败不影响本次重启结果
+			_, _ = fmt.Fprintln(out, r.t.Get("|-Failed to stop %s: %v", oldUnit, err))
+		}
+	}
+
+	_, _ = fmt.Fprintln(out, r.t.Get("|-%s is now served by %s", project.Name, newUnit))
+	return nil
+}
+
+// waitHealthy 轮询新实例的健康检查地址直到返回 2xx/3xx，实例退出或超时视为失败
+func (r *projectRepo) waitHealthy(port uint, cfg types.ProjectBlueGreen, alive func() bool) error {
+	url := fmt.Sprintf("http://127.0.0.1:%d%s", port, cfg.HealthCheck)
+	client := &http.Client{
+		Timeout: 2 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	deadline := time.Now().Add(time.Duration(cfg.HealthTimeout) * time.Second)
+	for {
+		if resp, err := client.Get(url); err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode < http.StatusBadRequest {
+				return nil
+			}
+		}
+		if !alive() {
+			return errors.New(r.t.Get("new instance on port %d exited before becoming healthy", port))
+		}
+		if time.Now().After(deadline) {
+			return errors.New(r.t.Get("new instance on port %d did not pass the health check within %d seconds", port, cfg.HealthTimeout))
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// writeInstanceTemplate 由主 unit 生成 {name}@.service 模板，实例名即端口
+func (r *projectRepo) writeInstanceTemplate(name string) error {
+	file, err := os.Open(r.unitFilePath(name))
+	if err != nil {
+		return err
+	}
+	options, err := unit.DeserializeOptions(file)
+	_ = file.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", r.t.Get("failed to parse systemd config"), err)
+	}
+
+	content, err := io.ReadAll(unit.Serialize(instanceTemplateOptions(options)))
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(r.unitFilePath(name+"@"), content, 0644); err != nil {
+		return err
+	}
+
+	return systemctl.DaemonReload()
+}
+
+// instanceTemplateOptions 在主 unit 配置的基础上通过 PORT 环境变量传入实例端口
+// 放在所有 Environment 之后以覆盖项目自身配置的 PORT，ExecStart 中可用 ${PORT} 引用
+func instanceTemplateOptions(options []*unit.UnitOption) []*unit.UnitOption {
+	template := make([]*unit.UnitOption, 0, len(options)+1)
+	for _, opt := range options {
+		if opt.Section == "Unit" && opt.Name == "Description" {
+			opt = unit.NewUnitOption("Unit", "Description", opt.Value+" (port %i)")
+		}
+		template = append(template, opt)
+	}
+	template = append(template, unit.NewUnitOption("Service", "Environment", `"PORT=%i"`))
+
+	slices.SortStableFunc(template, func(a, b *unit.UnitOption) int {
+		return cmp.Compare(sectionOrder(a.Section), sectionOrder(b.Section))
+	})
+	return template
+}
@@ -0,0 +1,86 @@
+package data
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/coreos/go-systemd/v22/unit"
+	"github.com/leonelquinteros/gotext"
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+func TestInstanceTemplateOptions(t *testing.T) {
+	options, err := unit.DeserializeOptions(strings.NewReader(`[Unit]
+Description=demo
+After=network.target
+
+[Service]
+Type=simple
+ExecStart=/opt/demo/app --port ${PORT}
+Environment="PORT=3000"
+
+[Install]
+WantedBy=multi-user.target
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := io.ReadAll(unit.Serialize(instanceTemplateOptions(options)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := string(content)
+	if !strings.Contains(template, "Description=demo (port %i)") {
+		t.Fatalf("description should carry the instance port:\n%s", template)
+	}
+	// 实例端口须在项目自身的 PORT 之后以覆盖它，且不能落到 [Install] 段
+	service := template[strings.Index(template, "[Service]"):strings.Index(template, "[Install]")]
+	if strings.Index(service, `Environment="PORT=%i"`) < strings.Index(service, `Environment="PORT=3000"`) {
+		t.Fatalf("instance port should override the project port:\n%s", template)
+	}
+	if !strings.Contains(template, "WantedBy=multi-user.target") {
+		t.Fatalf("install section should be kept for autostart:\n%s", template)
+	}
+}
+
+func TestProjectWaitHealthy(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// 前两次请求模拟实例仍在启动
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := &projectRepo{t: gotext.NewLocale("", "")}
+	port := cast.ToUint(server.URL[strings.LastIndex(server.URL, ":")+1:])
+	alive := func() bool { return true }
+
+	if err := repo.waitHealthy(port, types.ProjectBlueGreen{HealthCheck: "/health", HealthTimeout: 10}, alive); err != nil {
+		t.Fatal(err)
+	}
+	if requests.Load() != 3 {
+		t.Fatalf("health check polled %d times, want 3", requests.Load())
+	}
+
+	if err := repo.waitHealthy(port, types.ProjectBlueGreen{HealthCheck: "/missing", HealthTimeout: 1}, alive); err == nil {
+		t.Fatal("unhealthy instance should time out")
+	}
+	if err := repo.waitHealthy(port, types.ProjectBlueGreen{HealthCheck: "/missing", HealthTimeout: 10}, func() bool { return false }); err == nil {
+		t.Fatal("exited instance should fail without waiting for the timeout")
+	}
+}
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+type roleRepo struct {
+	db *gorm.DB
+}
+
+func NewRoleRepo(db *gorm.DB) biz.RoleRepo {
+	return &roleRepo{
+		db: db,
+	}
+}
+
+func (r *roleRepo) List(page, limit uint) ([]*biz.Role, int64, error) {
+	roles := make([]*biz.Role, 0)
+	var total int64
+	err := r.db.Model(&biz.Role{}).Order("id asc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&roles).Error
+	return roles, total, err
+}
+
+func (r *roleRepo) All() ([]*biz.Role, error) {
+	roles := make([]*biz.Role, 0)
+	err := r.db.Order("id asc").Find(&roles).Error
+	return roles, err
+}
+
+func (r *roleRepo) Get(id uint) (*biz.Role, error) {
+	role := new(biz.Role)
+	if err := r.db.Where("id = ?", id).First(role).Error; err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *roleRepo) Create(role *biz.Role) error {
+	return r.db.Create(role).Error
+}
+
+func (r *roleRepo) Update(role *biz.Role) error {
+	return r.db.Save(role).Error
+}
+
+func (r *roleRepo) Delete(id uint) error {
+	return r.db.Where("id = ?", id).Delete(&biz.Role{}).Error
+}
+
+func (r *roleRepo) UserCount(id uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&biz.User{}).Where("role_id = ?", id).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -175,6 +175,7 @@ func (r *settingRepo) GetPanel() (*request.SettingPanel, error) {
 		Name:          name,
 		Channel:       channel,
 		Locale:        r.conf.App.Locale,
+		Firewall:      r.conf.App.Firewall,
 		Entrance:      r.conf.HTTP.Entrance,
 		EntranceError: r.conf.HTTP.EntranceError,
 		LoginCaptcha:  r.conf.HTTP.LoginCaptcha,
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/leonelquinteros/gotext"
 	"gorm.io/gorm"
@@ -28,12 +29,33 @@ func NewTaskRepo(db *gorm.DB, t *gotext.Locale, log *slog.Logger, runner types.T
 	}
 }
 
-func (r *taskRepo) HasRunningTask() bool {
+func (r *taskRepo) HasRunningTask(resources ...string) bool {
 	var count int64
-	r.db.Model(&biz.Task{}).Where("status = ?", biz.TaskStatusRunning).Or("status = ?", biz.TaskStatusWaiting).Count(&count)
+	// 重试退避中的任务短时间内不会执行，不算作占用
+	query := r.db.Model(&biz.Task{}).Where("status = ? OR (status = ? AND (retry_at IS NULL OR retry_at <= ?))", biz.TaskStatusRunning, biz.TaskStatusWaiting, time.Now())
+	if len(resources) > 0 {
+		query = query.Where("resource IN ?", resources)
+	}
+	query.Count(&count)
 	return count > 0
 }
 
+func (r *taskRepo) CountByStatus() (map[biz.TaskStatus]int64, error) {
+	var rows []struct {
+		Status biz.TaskStatus
+		Count  int64
+	}
+	if err := r.db.Model(&biz.Task{}).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[biz.TaskStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
 func (r *taskRepo) List(page, limit uint) ([]*biz.Task, int64, error) {
 	tasks := make([]*biz.Task, 0)
 	var total int64
@@ -89,6 +111,29 @@ func (r *taskRepo) Cancel(id uint) error {
 	return nil
 }
 
+func (r *taskRepo) CancelWaiting(resource string) (int64, error) {
+	query := r.db.Model(&biz.Task{}).Where("status = ?", biz.TaskStatusWaiting)
+	if resource != "" {
+		query = query.Where("resource = ?", resource)
+	}
+	result := query.Update("status", biz.TaskStatusCanceled)
+	return result.RowsAffected, result.Error
+}
+
+func (r *taskRepo) SetPriority(id uint, priority int) error {
+	// 只有等待中的任务调整优先级才有意义
+	result := r.db.Model(&biz.Task{}).Where("id = ? AND status = ?", id, biz.TaskStatusWaiting).Update("priority", priority)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(r.t.Get("task is not waiting"))
+	}
+
+	r.runner.Notify()
+	return nil
+}
+
 func (r *taskRepo) UpdateStatus(id uint, status biz.TaskStatus) error {
 	return r.db.Model(&biz.Task{}).Where("id = ?", id).Update("status", status).Error
 }
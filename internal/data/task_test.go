@@ -3,6 +3,7 @@ package data
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/leonelquinteros/gotext"
 	"github.com/libtnb/sqlite"
@@ -55,3 +56,38 @@ func TestTaskPushDedup(t *testing.T) {
 		t.Fatalf("Push after terminal status should pass: %v", err)
 	}
 }
+
+func TestTaskHasRunningAndCancelWaiting(t *testing.T) {
+	repo := newTaskRepoForTest(t)
+
+	later := time.Now().Add(time.Hour)
+	tasks := []*biz.Task{
+		{Key: "cron:run:1", Resource: "cron:1", Status: biz.TaskStatusRunning},
+		{Key: "project:restart:1", Resource: "project:1", Status: biz.TaskStatusWaiting},
+		{Key: "backup:website:a", Resource: "website:a", Status: biz.TaskStatusWaiting, RetryAt: &later},
+	}
+	for _, task := range tasks {
+		if err := repo.db.Create(task).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !repo.HasRunningTask() || !repo.HasRunningTask("cron:1") || !repo.HasRunningTask("project:1") {
+		t.Fatal("running and due waiting tasks should count")
+	}
+	// 重试退避中的任务与其他资源的任务都不算占用
+	if repo.HasRunningTask("website:a") || repo.HasRunningTask("cron:2") {
+		t.Fatal("tasks in retry backoff or on other resources should not count")
+	}
+
+	count, err := repo.CancelWaiting("website:a")
+	if err != nil || count != 1 {
+		t.Fatalf("count = %d, err = %v", count, err)
+	}
+	if task, _ := repo.Get(tasks[1].ID); task.Status != biz.TaskStatusWaiting {
+		t.Fatal("tasks on other resources should stay waiting")
+	}
+	if count, err = repo.CancelWaiting(""); err != nil || count != 1 {
+		t.Fatalf("count = %d, err = %v", count, err)
+	}
+}
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+type terminalRecordingRepo struct {
+	t   *gotext.Locale
+	db  *gorm.DB
+	dir string // 录像存储目录
+}
+
+func NewTerminalRecordingRepo(db *gorm.DB, t *gotext.Locale) biz.TerminalRecordingRepo {
+	return &terminalRecordingRepo{
+		t:   t,
+		db:  db,
+		dir: filepath.Join(app.Root, "panel/storage/recordings"),
+	}
+}
+
+func (r *terminalRecordingRepo) List(typ string, page, limit uint) ([]*biz.TerminalRecording, int64, error) {
+	recordings := make([]*biz.TerminalRecording, 0)
+	var total int64
+	query := r.db.Model(&biz.TerminalRecording{})
+	if typ != "" {
+		query = query.Where("type = ?", typ)
+	}
+	err := query.Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&recordings).Error
+	return recordings, total, err
+}
+
+func (r *terminalRecordingRepo) Get(id uint) (*biz.TerminalRecording, error) {
+	recording := new(biz.TerminalRecording)
+	if err := r.db.Where("id = ?", id).First(recording).Error; err != nil {
+		return nil, err
+	}
+
+	return recording, nil
+}
+
+func (r *terminalRecordingRepo) Create(recording *biz.TerminalRecording) error {
+	if err := r.db.Create(recording).Error; err != nil {
+		return err
+	}
+
+	// 按月分目录，避免单个目录下文件过多
+	recording.Path = filepath.Join(r.dir, recording.CreatedAt.Format("200601"), fmt.Sprintf("%d.cast.gz", recording.ID))
+	return r.db.Model(recording).Update("path", recording.Path).Error
+}
+
+func (r *terminalRecordingRepo) Save(recording *biz.TerminalRecording) error {
+	return r.db.Save(recording).Error
+}
+
+func (r *terminalRecordingRepo) Delete(id uint) error {
+	recording, err := r.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = r.db.Delete(recording).Error; err != nil {
+		return err
+	}
+	if err = os.Remove(recording.Path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *terminalRecordingRepo) ClearBefore(t time.Time) (int64, error) {
+	recordings := make([]*biz.TerminalRecording, 0)
+	// 未结束的会话可能仍在写入，多保留一天，超过后视为面板异常退出遗留的录像
+	if err := r.db.Where("created_at < ? AND (ended_at IS NOT NULL OR created_at < ?)", t, t.AddDate(0, 0, -1)).Find(&recordings).Error; err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, recording := range recordings {
+		if err := r.db.Delete(recording).Error; err != nil {
+			return count, err
+		}
+		_ = os.Remove(recording.Path)
+		count++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,68 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libtnb/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+func TestTerminalRecordingClearBefore(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&biz.TerminalRecording{}); err != nil {
+		t.Fatal(err)
+	}
+	repo := &terminalRecordingRepo{db: db, dir: t.TempDir()}
+
+	now := time.Now()
+	ended := now.AddDate(0, 0, -10)
+	recordings := []*biz.TerminalRecording{
+		{Type: biz.TerminalRecordingTypePTY, CreatedAt: now.AddDate(0, 0, -10), EndedAt: &ended}, // 过期
+		{Type: biz.TerminalRecordingTypeSSH, CreatedAt: now.Add(-180 * time.Hour)},               // 过期但可能仍在进行
+		{Type: biz.TerminalRecordingTypeSSH, CreatedAt: now.AddDate(0, 0, -20)},                  // 异常退出遗留
+		{Type: biz.TerminalRecordingTypePTY, CreatedAt: now, EndedAt: &now},
+	}
+	for _, recording := range recordings {
+		if err = repo.Create(recording); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.MkdirAll(filepath.Dir(recording.Path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(recording.Path, []byte("cast"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, total, err := repo.List(biz.TerminalRecordingTypePTY, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(list) != 2 || list[0].ID != recordings[3].ID {
+		t.Fatalf("unexpected list: total=%d len=%d", total, len(list))
+	}
+
+	count, err := repo.ClearBefore(now.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 recordings cleared, got %d", count)
+	}
+	for i, recording := range recordings {
+		_, statErr := os.Stat(recording.Path)
+		_, getErr := repo.Get(recording.ID)
+		kept := i == 1 || i == 3
+		if kept != (statErr == nil) || kept != (getErr == nil) {
+			t.Fatalf("recording %d: kept=%v file=%v row=%v", i, kept, statErr, getErr)
+		}
+	}
+}
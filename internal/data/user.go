@@ -10,6 +10,7 @@ import (
 	"github.com/pquerna/otp/totp"
 	"github.com/spf13/cast"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/acepanel/panel/v3/internal/biz"
 )
@@ -31,13 +32,13 @@ func NewUserRepo(db *gorm.DB, t *gotext.Locale) biz.UserRepo {
 func (r *userRepo) List(page, limit uint) ([]*biz.User, int64, error) {
 	users := make([]*biz.User, 0)
 	var total int64
-	err := r.db.Model(&biz.User{}).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&users).Error
+	err := r.db.Model(&biz.User{}).Preload("Role").Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&users).Error
 	return users, total, err
 }
 
 func (r *userRepo) Get(id uint) (*biz.User, error) {
 	user := new(biz.User)
-	if err := r.db.First(user, id).Error; err != nil {
+	if err := r.db.Preload("Role").First(user, id).Error; err != nil {
 		return nil, err
 	}
 
@@ -52,7 +53,7 @@ func (r *userRepo) Count() (int64, error) {
 	return count, nil
 }
 
-func (r *userRepo) Create(username, password, email string) (*biz.User, error) {
+func (r *userRepo) Create(username, password, email string, roleID uint, scopes map[string][]uint) (*biz.User, error) {
 	value, err := r.hasher.Make(password)
 	if err != nil {
 		return nil, err
@@ -62,6 +63,11 @@ func (r *userRepo) Create(username, password, email string) (*biz.User, error) {
 		Username: username,
 		Password: value,
 		Email:    email,
+		RoleID:   roleID,
+		Scopes:   scopes,
+	}
+	if user.Scopes == nil {
+		user.Scopes = make(map[string][]uint)
 	}
 	if err = r.db.Create(user).Error; err != nil {
 		return nil, err
@@ -77,7 +83,7 @@ func (r *userRepo) UpdateUsername(id uint, username string) error {
 	}
 
 	user.Username = username
-	if err = r.db.Save(user).Error; err != nil {
+	if err = r.db.Omit(clause.Associations).Save(user).Error; err != nil {
 		return err
 	}
 
@@ -96,7 +102,7 @@ func (r *userRepo) UpdatePassword(id uint, password string) error {
 	}
 
 	user.Password = value
-	if err = r.db.Save(user).Error; err != nil {
+	if err = r.db.Omit(clause.Associations).Save(user).Error; err != nil {
 		return err
 	}
 
@@ -110,13 +116,29 @@ func (r *userRepo) UpdateEmail(id uint, email string) error {
 	}
 
 	user.Email = email
-	if err = r.db.Save(user).Error; err != nil {
+	if err = r.db.Omit(clause.Associations).Save(user).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func (r *userRepo) UpdateRole(id, roleID uint, scopes map[string][]uint) error {
+	if scopes == nil {
+		scopes = make(map[string][]uint)
+	}
+
+	return r.db.Model(&biz.User{ID: id}).Select("RoleID", "Scopes").Updates(&biz.User{RoleID: roleID, Scopes: scopes}).Error
+}
+
+func (r *userRepo) CountByRole(roleID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&biz.User{}).Where("role_id = ?", roleID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (r *userRepo) Delete(id uint) (string, error) {
 	user := new(biz.User)
 	if err := r.db.Preload("Tokens").First(user, id).Error; err != nil {
@@ -199,5 +221,5 @@ func (r *userRepo) UpdateTwoFA(id uint, code, secret string) error {
 	}
 
 	user.TwoFA = secret
-	return r.db.Save(user).Error
+	return r.db.Omit(clause.Associations).Save(user).Error
 }
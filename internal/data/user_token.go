@@ -44,21 +44,16 @@ func (r userTokenRepo) List(userID, page, limit uint) ([]*biz.UserToken, int64,
 	return userTokens, total, err
 }
 
-func (r userTokenRepo) Create(userID uint, ips []string, expired time.Time) (*biz.UserToken, error) {
+func (r userTokenRepo) Create(userToken *biz.UserToken) error {
 	token := str.Random(32)
-	userToken := &biz.UserToken{
-		UserID:    userID,
-		Token:     token,
-		IPs:       ips,
-		ExpiredAt: expired,
-	}
+	userToken.Token = token
 	if err := r.db.Create(userToken).Error; err != nil {
-		return nil, err
+		return err
 	}
 
 	userToken.Token = token // 返回的值是加密的，这里覆盖为原始值
 
-	return userToken, nil
+	return nil
 }
 
 func (r userTokenRepo) Get(id uint) (*biz.UserToken, error) {
@@ -79,47 +74,35 @@ func (r userTokenRepo) Delete(id uint) error {
 	return r.db.Delete(userToken).Error
 }
 
-func (r userTokenRepo) Update(id uint, ips []string, expired time.Time) (*biz.UserToken, error) {
-	userToken := new(biz.UserToken)
-	if err := r.db.First(userToken, id).Error; err != nil {
-		return nil, err
-	}
-
-	userToken.IPs = ips
-	userToken.ExpiredAt = expired
-
-	if err := r.db.Save(userToken).Error; err != nil {
-		return nil, err
-	}
-
-	return userToken, nil
+func (r userTokenRepo) Update(userToken *biz.UserToken) error {
+	return r.db.Save(userToken).Error
 }
 
-func (r userTokenRepo) ValidateReq(req *http.Request) (uint, error) {
+func (r userTokenRepo) ValidateReq(req *http.Request) (*biz.UserToken, error) {
 	// Authorization: HMAC-SHA256 Credential=<token_id>, Signature=<signature>
 	var algorithm string
 	var id uint
 	var signature string
 	if _, err := fmt.Sscanf(req.Header.Get("Authorization"), "%s Credential=%d, Signature=%s", &algorithm, &id, &signature); err != nil {
-		return 0, errors.New(r.t.Get("invalid header: %v", err))
+		return nil, errors.New(r.t.Get("invalid header: %v", err))
 	}
 	if algorithm != "HMAC-SHA256" {
-		return 0, errors.New(r.t.Get("invalid signature"))
+		return nil, errors.New(r.t.Get("invalid signature"))
 	}
 
 	// 获取用户令牌
 	userToken, err := r.Get(id)
 	if err != nil {
-		return 0, errors.New(r.t.Get("invalid signature")) // 不应返回原始报错，防止猜测令牌ID
+		return nil, errors.New(r.t.Get("invalid signature")) // 不应返回原始报错，防止猜测令牌ID
 	}
 	if userToken.ExpiredAt.Before(time.Now()) {
-		return 0, errors.New(r.t.Get("token expired"))
+		return nil, errors.New(r.t.Get("token expired"))
 	}
 
 	// 步骤一：构造规范化请求
 	body, err := io.ReadAll(req.Body)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	req.Body = io.NopCloser(bytes.NewReader(body))
 	canonicalRequest := fmt.Sprintf("%s\n%s\n%s\n%s", req.Method, req.URL.Path, req.URL.Query().Encode(), str.SHA256(string(body)))
@@ -133,26 +116,25 @@ func (r userTokenRepo) ValidateReq(req *http.Request) (uint, error) {
 
 	// 步骤四：验证签名
 	if subtle.ConstantTimeCompare([]byte(signature), []byte(validSignature)) != 1 {
-		return 0, errors.New(r.t.Get("invalid signature"))
+		return nil, errors.New(r.t.Get("invalid signature"))
 	}
 
 	// 步骤五：验证时间戳
 	if timestamp == 0 || timestamp < (time.Now().Unix()-300) {
-		return 0, errors.New(r.t.Get("signature expired"))
+		return nil, errors.New(r.t.Get("signature expired"))
 	}
 
 	// 步骤六：验证IP
+	ip := req.RemoteAddr
+	ipHeader := r.conf.HTTP.IPHeader
+	if ipHeader != "" && req.Header.Get(ipHeader) != "" {
+		ip = strings.Split(req.Header.Get(ipHeader), ",")[0]
+	}
+	ip, _, err = net.SplitHostPort(strings.TrimSpace(ip))
+	if err != nil {
+		ip = req.RemoteAddr
+	}
 	if len(userToken.IPs) > 0 {
-		ip := req.RemoteAddr
-		ipHeader := r.conf.HTTP.IPHeader
-		if ipHeader != "" && req.Header.Get(ipHeader) != "" {
-			ip = strings.Split(req.Header.Get(ipHeader), ",")[0]
-		}
-		ip, _, err = net.SplitHostPort(strings.TrimSpace(ip))
-		if err != nil {
-			ip = req.RemoteAddr
-		}
-
 		allowed := false
 		requestIP := net.ParseIP(ip)
 		if requestIP != nil {
@@ -173,11 +155,21 @@ func (r userTokenRepo) ValidateReq(req *http.Request) (uint, error) {
 			}
 		}
 		if !allowed {
-			return 0, errors.New(r.t.Get("invalid request ip: %s", ip))
+			return nil, errors.New(r.t.Get("invalid request ip: %s", ip))
+		}
+	}
+
+	// 步骤七：记录最近使用，同一 IP 每分钟最多写一次
+	now := time.Now()
+	if userToken.LastUsedAt == nil || now.Sub(*userToken.LastUsedAt) > time.Minute || userToken.LastUsedIP != ip {
+		// UpdateColumns 跳过钩子，避免重复加密令牌
+		if err = r.db.Model(userToken).UpdateColumns(map[string]any{"last_used_at": now, "last_used_ip": ip}).Error; err == nil {
+			userToken.LastUsedAt = &now
+			userToken.LastUsedIP = ip
 		}
 	}
 
-	return userToken.UserID, nil
+	return userToken, nil
 }
 
 func (r userTokenRepo) hmacsha256(data string, secret string) string {
@@ -2,8 +2,10 @@ package data
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
 	"os"
 	"path/filepath"
@@ -15,11 +17,14 @@ import (
 	"github.com/leonelquinteros/gotext"
 	"github.com/samber/lo"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/acepanel/panel/v3/internal/app"
 	"github.com/acepanel/panel/v3/internal/biz"
 	"github.com/acepanel/panel/v3/internal/request"
 	"github.com/acepanel/panel/v3/pkg/cert"
+	"github.com/acepanel/panel/v3/pkg/configtest"
+	"github.com/acepanel/panel/v3/pkg/conftx"
 	"github.com/acepanel/panel/v3/pkg/embed"
 	"github.com/acepanel/panel/v3/pkg/io"
 	"github.com/acepanel/panel/v3/pkg/punycode"
@@ -264,6 +269,8 @@ func (r *websiteRepo) Get(id uint) (*types.WebsiteSetting, error) {
 	setting.RealIP = vhost.RealIP()
 	// 读取基本认证用户列表
 	setting.BasicAuth = r.readBasicAuthUsers(website.Name)
+	// WAF
+	setting.WAF = vhost.WAF()
 
 	// 自定义配置
 	configDir := filepath.Join(app.Root, "sites", website.Name, "config")
@@ -284,15 +291,15 @@ func (r *websiteRepo) GetByName(name string) (*types.WebsiteSetting, error) {
 	return r.Get(website.ID)
 }
 
-func (r *websiteRepo) List(typ string, page, limit uint) ([]*biz.Website, int64, error) {
+func (r *websiteRepo) List(ctx context.Context, typ string, page, limit uint) ([]*biz.Website, int64, error) {
 	websites := make([]*biz.Website, 0)
 	var total int64
 
-	if err := r.db.Model(&biz.Website{}).Count(&total).Error; err != nil {
+	if err := scoped(ctx, r.db.Model(&biz.Website{}), biz.ScopeWebsite, "id").Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	query := r.db
+	query := scoped(ctx, r.db, biz.ScopeWebsite, "id")
 	if typ != "" && typ != "all" {
 		query = query.Where("type = ?", typ)
 	}
@@ -585,7 +592,9 @@ func (r *websiteRepo) Update(req *request.WebsiteUpdate) (*biz.Website, error) {
 		return nil, err
 	}
 
-	if err := r.applyUpdate(req, website); err != nil {
+	if err := r.transact(website, func() error {
+		return r.applyUpdate(req, website)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -641,6 +650,7 @@ func (r *websiteRepo) SwitchType(req *request.WebsiteSwitchType) (*biz.Website,
 		RateLimit:     setting.RateLimit,
 		RealIP:        setting.RealIP,
 		BasicAuth:     setting.BasicAuth,
+		WAF:           setting.WAF,
 		CustomConfigs: customConfigs,
 	}
 	switch targetType {
@@ -768,6 +778,7 @@ func (r *websiteRepo) SwitchType(req *request.WebsiteSwitchType) (*biz.Website,
 	}
 
 	_ = io.Remove(backupDir)
+
 	return website, nil
 }
 
@@ -1009,6 +1020,21 @@ func (r *websiteRepo) applyUpdate(req *request.WebsiteUpdate, website *biz.Websi
 		}
 	}
 
+	// WAF，OpenResty 使用 Lua 引擎，原生 Nginx 使用 map 规则
+	if req.WAF != nil {
+		req.WAF.Engine = webservertypes.WAFEngineNginx
+		if installed, _ := r.isOpenResty(); installed {
+			req.WAF.Engine = webservertypes.WAFEngineLua
+		}
+		if err = vhost.SetWAF(req.WAF); err != nil {
+			return err
+		}
+	} else {
+		if err = vhost.ClearWAF(); err != nil {
+			return err
+		}
+	}
+
 	// 访问统计
 	webServer, _ := r.setting.Get(biz.SettingKeyWebserver)
 	if webServer == "nginx" {
@@ -1064,6 +1090,12 @@ func (r *websiteRepo) Delete(website *biz.Website) error {
 		if err := tx.Delete(website).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("website_id = ?", website.ID).Delete(&biz.WebsiteRevision{}).Error; err != nil {
+			return err
+		}
+		if err := deleteDeployments(tx, biz.DeploymentTargetWebsite, website.ID); err != nil {
+			return err
+		}
 
 		// HTTP 验证依赖网站，证书失去全部网站后无法继续自动续签
 		return tx.Model(&biz.Cert{}).
@@ -1146,67 +1178,64 @@ func (r *websiteRepo) ResetConfig(id uint) error {
 			return err
 		}
 	}
-	configDir := filepath.Join(app.Root, "sites", website.Name, "config")
-	if err = io.Remove(configDir); err != nil {
-		return err
-	}
-	if err = os.MkdirAll(filepath.Join(configDir, "site"), 0600); err != nil {
-		return err
-	}
-	if err = os.MkdirAll(filepath.Join(configDir, "shared"), 0600); err != nil {
-		return err
-	}
 
-	website.Status = true
-	if err = r.applyUpdate(update, website); err != nil {
-		return err
-	}
+	return r.transact(website, func() error {
+		configDir := filepath.Join(app.Root, "sites", website.Name, "config")
+		if err := io.Remove(configDir); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Join(configDir, "site"), 0600); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Join(configDir, "shared"), 0600); err != nil {
+			return err
+		}
 
-	vhost, err := r.getVhost(website)
-	if err != nil {
-		return err
-	}
-	if err = vhost.SetConfig("001-acme.conf", webservertypes.ScopeSite, ""); err != nil {
-		return err
-	}
-	var errorPageConfig string
-	switch webServer {
-	case "nginx":
-		errorPageConfig = `error_page 404 /404.html;`
-	case "apache":
-		errorPageConfig = `ErrorDocument 404 /404.html`
-	}
-	if err = vhost.SetConfig("010-error-404.conf", webservertypes.ScopeSite, errorPageConfig); err != nil {
-		return err
-	}
-	switch website.Type {
-	case biz.WebsiteTypePHP:
-		cacheConfig := nginxPHPCacheConfig
-		if webServer == "apache" {
-			cacheConfig = apachePHPCacheConfig
+		website.Status = true
+		if err := r.applyUpdate(update, website); err != nil {
+			return err
 		}
-		err = vhost.SetConfig("010-cache.conf", webservertypes.ScopeSite, cacheConfig)
-	case biz.WebsiteTypeStatic:
-		spaConfig := nginxSPAConfig
-		if webServer == "apache" {
-			spaConfig = apacheSPAConfig
+
+		vhost, err := r.getVhost(website)
+		if err != nil {
+			return err
+		}
+		if err = vhost.SetConfig("001-acme.conf", webservertypes.ScopeSite, ""); err != nil {
+			return err
+		}
+		var errorPageConfig string
+		switch webServer {
+		case "nginx":
+			errorPageConfig = `error_page 404 /404.html;`
+		case "apache":
+			errorPageConfig = `ErrorDocument 404 /404.html`
+		}
+		if err = vhost.SetConfig("010-error-404.conf", webservertypes.ScopeSite, errorPageConfig); err != nil {
+			return err
+		}
+		switch website.Type {
+		case biz.WebsiteTypePHP:
+			cacheConfig := nginxPHPCacheConfig
+			if webServer == "apache" {
+				cacheConfig = apachePHPCacheConfig
+			}
+			err = vhost.SetConfig("010-cache.conf", webservertypes.ScopeSite, cacheConfig)
+		case biz.WebsiteTypeStatic:
+			spaConfig := nginxSPAConfig
+			if webServer == "apache" {
+				spaConfig = apacheSPAConfig
+			}
+			err = vhost.SetRawConfig("800-spa.conf", webservertypes.ScopeSite, spaConfig)
+		}
+		if err != nil {
+			return err
+		}
+		if err = vhost.Save(); err != nil {
+			return err
 		}
-		err = vhost.SetRawConfig("800-spa.conf", webservertypes.ScopeSite, spaConfig)
-	}
-	if err != nil {
-		return err
-	}
-	if err = vhost.Save(); err != nil {
-		return err
-	}
-	if err = io.Chmod(filepath.Join(app.Root, "sites", website.Name, "config"), 0600); err != nil {
-		return err
-	}
-	if err = r.ReloadWebServer(); err != nil {
-		return err
-	}
 
-	return nil
+		return io.Chmod(configDir, 0600)
+	})
 }
 
 func (r *websiteRepo) UpdateStatus(id uint, status bool) error {
@@ -1215,23 +1244,57 @@ func (r *websiteRepo) UpdateStatus(id uint, status bool) error {
 		return err
 	}
 
-	vhost, err := r.getVhost(website)
-	if err != nil {
-		return err
-	}
-	if err = vhost.SetEnable(status); err != nil {
-		return err
-	}
-	if err = vhost.Save(); err != nil {
+	return r.transact(website, func() error {
+		vhost, err := r.getVhost(website)
+		if err != nil {
+			return err
+		}
+		if err = vhost.SetEnable(status); err != nil {
+			return err
+		}
+		if err = vhost.Save(); err != nil {
+			return err
+		}
+
+		website.Status = status
+		return r.db.Save(website).Error
+	})
+}
+
+// SwitchUpstream 将反向代理网站的上游整体改写为指向 server，参数沿用原有服务器，检查并重载失败时回滚
+func (r *websiteRepo) SwitchUpstream(id uint, name, server string) error {
+	website := new(biz.Website)
+	if err := r.db.Where("id", id).First(website).Error; err != nil {
 		return err
 	}
 
-	website.Status = status
-	if err = r.db.Save(website).Error; err != nil {
+	if err := r.transact(website, func() error {
+		vhost, err := r.getVhost(website)
+		if err != nil {
+			return err
+		}
+		proxyVhost, ok := vhost.(webservertypes.ProxyVhost)
+		if !ok {
+			return errors.New(r.t.Get("website %s is not a reverse proxy", website.Name))
+		}
+
+		upstreams := proxyVhost.Upstreams()
+		index := slices.IndexFunc(upstreams, func(upstream webservertypes.Upstream) bool { return upstream.Name == name })
+		if index == -1 {
+			return errors.New(r.t.Get("upstream %s not found in website %s", name, website.Name))
+		}
+		params := ""
+		if addresses := slices.Sorted(maps.Keys(upstreams[index].Servers)); len(addresses) > 0 {
+			params = upstreams[index].Servers[addresses[0]]
+		}
+		upstreams[index].Servers = map[string]string{server: params}
+
+		return proxyVhost.SetUpstreams(upstreams)
+	}); err != nil {
 		return err
 	}
 
-	return r.ReloadWebServer()
+	return r.RecordRevision(id, 0, biz.WebsiteRevisionUpstream)
 }
 
 func (r *websiteRepo) UpdateExpireAt(id uint, expireAt *time.Time) error {
@@ -1251,20 +1314,21 @@ func (r *websiteRepo) UpdateCert(req *request.WebsiteUpdateCert) error {
 		return errors.New(r.t.Get("failed to parse private key: %v", err))
 	}
 
-	certPath := filepath.Join(app.Root, "sites", website.Name, "config", "fullchain.pem")
-	keyPath := filepath.Join(app.Root, "sites", website.Name, "config", "private.key")
-	if err := io.Write(certPath, req.Cert, 0600); err != nil {
-		return err
-	}
-	if err := io.Write(keyPath, req.Key, 0600); err != nil {
-		return err
+	write := func() error {
+		certPath := filepath.Join(app.Root, "sites", website.Name, "config", "fullchain.pem")
+		keyPath := filepath.Join(app.Root, "sites", website.Name, "config", "private.key")
+		if err := io.Write(certPath, req.Cert, 0600); err != nil {
+			return err
+		}
+		return io.Write(keyPath, req.Key, 0600)
 	}
 
-	if website.SSL {
-		return r.ReloadWebServer()
+	// 未启用 HTTPS 时证书不会被加载，无需检查与重载
+	if !website.SSL {
+		return write()
 	}
 
-	return nil
+	return r.transact(website, write)
 }
 
 // customConfigStartNum 自定义配置起始序号
@@ -1426,29 +1490,44 @@ func (r *websiteRepo) getVhost(website *biz.Website) (webservertypes.Vhost, erro
 	return r.newVhost(webServer, website)
 }
 
+// ReloadWebServer 检查配置并重载 Web 服务器
 func (r *websiteRepo) ReloadWebServer() error {
 	webServer, err := r.setting.Get(biz.SettingKeyWebserver, "unknown")
 	if err != nil {
 		return err
 	}
-	var test string
-	switch webServer {
-	case "nginx":
-		test = "nginx -t 2>&1"
-	case "apache":
-		test = "apachectl configtest 2>&1"
-	default:
+	if webServer != "nginx" && webServer != "apache" {
 		return errors.New(r.t.Get("unsupported web server: %s", webServer))
 	}
 
+	// 服务未运行时同样检查，避免错误配置留到下次启动才暴露
+	if err = configtest.WebServer(app.Root, webServer); err != nil {
+		return err
+	}
+
 	// 服务未运行时无需重载，配置会在下次启动时生效
 	if running, _ := systemctl.Status(webServer); !running {
 		return nil
 	}
-
 	if err = systemctl.Reload(webServer); err != nil {
-		out, _ := shell.Execf(test)
-		return fmt.Errorf("failed to reload %s: %w; config test: %s", webServer, err, out)
+		return fmt.Errorf("failed to reload %s: %w", webServer, err)
+	}
+
+	return nil
+}
+
+// transact 快照网站配置目录与 htpasswd 后执行 fn，再检查并重载 Web 服务器
+// 任一步失败都回滚文件与数据库记录
+func (r *websiteRepo) transact(website *biz.Website, fn func() error) error {
+	siteDir := filepath.Join(app.Root, "sites", website.Name)
+	saved := *website
+	if err := conftx.Apply([]string{filepath.Join(siteDir, "config"), filepath.Join(siteDir, "htpasswd")}, fn, r.ReloadWebServer); err != nil {
+		// fn 可能已写入数据库，恢复为修改前的记录
+		if dbErr := r.db.Omit(clause.Associations).Save(&saved).Error; dbErr != nil {
+			err = errors.Join(err, dbErr)
+		}
+		*website = saved
+		return fmt.Errorf("%s: %w", r.t.Get("configuration rolled back"), err)
 	}
 
 	return nil
@@ -1516,6 +1595,15 @@ func (r *websiteRepo) writeBasicAuthUsers(htpasswdPath string, users map[string]
 	return io.Write(htpasswdPath, content, 0644) // 必须 0644，Nginx 在运行中以 www 用户读取
 }
 
+// isOpenResty 当前 nginx 是否由 OpenResty 提供
+func (r *websiteRepo) isOpenResty() (bool, error) {
+	var count int64
+	if err := r.db.Model(&biz.App{}).Where("slug = ?", "openresty").Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // enableStat 写入 nginx 访问统计配置（log_format + syslog access_log）
 func (r *websiteRepo) enableStat(vhost webservertypes.Vhost, name string) error {
 	// nginx 的 syslog tag 与 log_format 名只允许字母数字和下划线
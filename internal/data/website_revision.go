@@ -0,0 +1,282 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/samber/lo"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/pkg/io"
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+// revisionExcludes 不纳入版本的文件，证书与私钥由证书模块管理且不应明文留存在历史中
+var revisionExcludes = []string{"fullchain.pem", "private.key"}
+
+func (r *websiteRepo) ListRevisions(websiteID, page, limit uint) ([]*biz.WebsiteRevision, int64, error) {
+	revisions := make([]*biz.WebsiteRevision, 0)
+	var total int64
+	err := r.db.Model(&biz.WebsiteRevision{}).Omit("files", "setting").Where("website_id = ?", websiteID).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&revisions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 填充操作人用户名
+	ids := lo.Uniq(lo.FilterMap(revisions, func(revision *biz.WebsiteRevision, _ int) (uint, bool) {
+		return revision.OperatorID, revision.OperatorID > 0
+	}))
+	if len(ids) > 0 {
+		var users []biz.User
+		if err = r.db.Select("id", "username").Where("id IN ?", ids).Find(&users).Error; err != nil {
+			return nil, 0, err
+		}
+		names := lo.SliceToMap(users, func(user biz.User) (uint, string) {
+			return user.ID, user.Username
+		})
+		for _, revision := range revisions {
+			revision.OperatorName = names[revision.OperatorID]
+		}
+	}
+
+	return revisions, total, nil
+}
+
+func (r *websiteRepo) GetRevision(websiteID, revisionID uint) (*biz.WebsiteRevision, error) {
+	revision := new(biz.WebsiteRevision)
+	if err := r.db.Where("id = ? AND website_id = ?", revisionID, websiteID).First(revision).Error; err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+func (r *websiteRepo) ConfigFiles(websiteID uint) (map[string]string, error) {
+	website := new(biz.Website)
+	if err := r.db.Where("id", websiteID).First(website).Error; err != nil {
+		return nil, err
+	}
+
+	return readConfigFiles(filepath.Join(app.Root, "sites", website.Name, "config"))
+}
+
+func (r *websiteRepo) RestoreRevision(websiteID, revisionID uint) error {
+	website := new(biz.Website)
+	if err := r.db.Where("id", websiteID).First(website).Error; err != nil {
+		return err
+	}
+	revision, err := r.GetRevision(websiteID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	// 早期版本只有配置文件，按文件原样恢复
+	if revision.Setting == nil {
+		return r.restoreRevisionFiles(website, revision)
+	}
+	if revision.Setting.Type != string(website.Type) {
+		return errors.New(r.t.Get("revision was recorded for a %s website, please switch the website type first", revision.Setting.Type))
+	}
+
+	// 证书不纳入版本，沿用当前证书
+	current, err := r.Get(websiteID)
+	if err != nil {
+		return err
+	}
+	if revision.Setting.SSL && (current.SSLCert == "" || current.SSLKey == "") {
+		return errors.New(r.t.Get("website has no certificate, please set one before restoring an HTTPS revision"))
+	}
+
+	update := revisionUpdate(website.ID, revision.Setting)
+	update.SSLCert = current.SSLCert
+	update.SSLKey = current.SSLKey
+
+	return r.transact(website, func() error {
+		return r.applyUpdate(update, website)
+	})
+}
+
+// restoreRevisionFiles 将配置目录恢复为版本中的文件
+func (r *websiteRepo) restoreRevisionFiles(website *biz.Website, revision *biz.WebsiteRevision) error {
+	configDir := filepath.Join(app.Root, "sites", website.Name, "config")
+	return r.transact(website, func() error {
+		current, err := readConfigFiles(configDir)
+		if err != nil {
+			return err
+		}
+		for name := range current {
+			if _, ok := revision.Files[name]; !ok {
+				if err = os.Remove(filepath.Join(configDir, name)); err != nil {
+					return err
+				}
+			}
+		}
+		for name, content := range revision.Files {
+			if err = io.Write(filepath.Join(configDir, name), content, 0600); err != nil {
+				return err
+			}
+		}
+
+		// 启用状态与 HTTPS 由配置决定，同步回数据库
+		vhost, err := r.getVhost(website)
+		if err != nil {
+			return err
+		}
+		website.Status = vhost.Enable()
+		website.SSL = vhost.SSL()
+		return r.db.Save(website).Error
+	})
+}
+
+func (r *websiteRepo) RecordRevision(websiteID, operatorID uint, action string) error {
+	website := new(biz.Website)
+	if err := r.db.Where("id", websiteID).First(website).Error; err != nil {
+		return err
+	}
+	setting, err := r.Get(websiteID)
+	if err != nil {
+		return err
+	}
+
+	return r.recordRevision(website, biz.RevisionSetting(setting), operatorID, action)
+}
+
+// recordRevision 记录配置目录与设置为新版本，内容未变化时跳过，并清理超出保留数量的旧版本
+func (r *websiteRepo) recordRevision(website *biz.Website, setting *types.WebsiteSetting, operatorID uint, action string) error {
+	files, err := readConfigFiles(filepath.Join(app.Root, "sites", website.Name, "config"))
+	if err != nil {
+		return err
+	}
+	hash, err := hashRevision(files, setting)
+	if err != nil {
+		return err
+	}
+
+	// 内容未变化时不产生新版本
+	latest := new(biz.WebsiteRevision)
+	if err = r.db.Omit("files", "setting").Where("website_id = ?", website.ID).Order("id desc").Limit(1).Find(latest).Error; err != nil {
+		return err
+	}
+	if latest.ID != 0 && latest.Hash == hash {
+		return nil
+	}
+
+	if err = r.db.Create(&biz.WebsiteRevision{
+		WebsiteID:  website.ID,
+		OperatorID: operatorID,
+		Action:     action,
+		Files:      files,
+		Setting:    setting,
+		Hash:       hash,
+	}).Error; err != nil {
+		return err
+	}
+
+	var expired []uint
+	if err = r.db.Model(&biz.WebsiteRevision{}).Where("website_id = ?", website.ID).Order("id desc").Offset(biz.WebsiteRevisionLimit).Pluck("id", &expired).Error; err != nil {
+		return err
+	}
+	if len(expired) > 0 {
+		return r.db.Where("id IN ?", expired).Delete(&biz.WebsiteRevision{}).Error
+	}
+
+	return nil
+}
+
+// revisionUpdate 由版本中的结构化设置构造更新请求
+func revisionUpdate(id uint, setting *types.WebsiteSetting) *request.WebsiteUpdate {
+	return &request.WebsiteUpdate{
+		ID:           id,
+		Listens:      setting.Listens,
+		Domains:      setting.Domains,
+		Path:         setting.Path,
+		Root:         setting.Root,
+		Index:        setting.Index,
+		SSL:          setting.SSL,
+		HSTS:         setting.HSTS,
+		OCSP:         setting.OCSP,
+		HTTPRedirect: setting.HTTPRedirect,
+		SSLProtocols: setting.SSLProtocols,
+		PHP:          setting.PHP,
+		Rewrite:      setting.Rewrite,
+		OpenBasedir:  setting.OpenBasedir,
+		Upstreams:    setting.Upstreams,
+		Proxies:      setting.Proxies,
+		Redirects:    setting.Redirects,
+		StatEnabled:  setting.StatEnabled,
+		AccessLog:    setting.AccessLog,
+		ErrorLog:     setting.ErrorLog,
+		RateLimit:    setting.RateLimit,
+		RealIP:       setting.RealIP,
+		BasicAuth:    setting.BasicAuth,
+		WAF:          setting.WAF,
+		CustomConfigs: lo.Map(setting.CustomConfigs, func(config types.WebsiteCustomConfig, _ int) request.WebsiteCustomConfig {
+			return request.WebsiteCustomConfig{
+				Name:    config.Name,
+				Scope:   config.Scope,
+				Content: config.Content,
+			}
+		}),
+	}
+}
+
+// readConfigFiles 读取配置目录下的全部配置文件
+func readConfigFiles(configDir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.WalkDir(configDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == configDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.Type().IsRegular() || slices.Contains(revisionExcludes, d.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(configDir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[rel] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// hashRevision 计算配置文件与结构化设置的指纹
+func hashRevision(files map[string]string, setting *types.WebsiteSetting) (string, error) {
+	names := lo.Keys(files)
+	slices.Sort(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		// 以 NUL 分隔，避免路径与内容拼接产生歧义
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write([]byte(files[name]))
+		h.Write([]byte{0})
+	}
+	encoded, err := json.Marshal(setting)
+	if err != nil {
+		return "", err
+	}
+	h.Write(encoded)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libtnb/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+func TestWebsiteRecordRevision(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&biz.WebsiteRevision{}, &biz.User{}); err != nil {
+		t.Fatal(err)
+	}
+	root := app.Root
+	app.Root = t.TempDir()
+	t.Cleanup(func() { app.Root = root })
+
+	repo := &websiteRepo{db: db}
+	website := &biz.Website{ID: 1, Name: "example"}
+	configDir := filepath.Join(app.Root, "sites", website.Name, "config")
+	write := func(name, content string) {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(configDir, name)), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(configDir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("nginx.conf", "server { listen 80; }")
+	write("site/010-cache.conf", "expires 30d;")
+	write("private.key", "SECRET")
+	write("fullchain.pem", "CERT")
+	if err = db.Create(&biz.User{ID: 3, Username: "alice"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	setting := &types.WebsiteSetting{ID: website.ID, Name: website.Name, Type: "static", Domains: []string{"example.com"}}
+
+	if err = repo.recordRevision(website, setting, 3, biz.WebsiteRevisionCreate); err != nil {
+		t.Fatal(err)
+	}
+	// 内容未变化时不重复记录
+	if err = repo.recordRevision(website, setting, 3, biz.WebsiteRevisionUpdate); err != nil {
+		t.Fatal(err)
+	}
+	revisions, total, err := repo.ListRevisions(website.ID, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(revisions[0].Files) != 0 || revisions[0].Setting != nil {
+		t.Fatalf("revisions = %d, list should omit files and setting", total)
+	}
+	if revisions[0].OperatorName != "alice" || revisions[0].Action != biz.WebsiteRevisionCreate {
+		t.Fatalf("unexpected operator %q or action %q", revisions[0].OperatorName, revisions[0].Action)
+	}
+
+	// 仅结构化设置变化时也记录新版本
+	changed := *setting
+	changed.Domains = []string{"example.com", "www.example.com"}
+	if err = repo.recordRevision(website, &changed, 0, biz.WebsiteRevisionUpdate); err != nil {
+		t.Fatal(err)
+	}
+	if revisions, total, err = repo.ListRevisions(website.ID, 1, 10); err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || revisions[0].OperatorName != "" {
+		t.Fatalf("revisions = %d, system change should have no operator name", total)
+	}
+
+	revision, err := repo.GetRevision(website.ID, revisions[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revision.Files) != 2 || revision.Files["site/010-cache.conf"] != "expires 30d;" {
+		t.Fatalf("unexpected files: %v", revision.Files)
+	}
+	if revision.Setting == nil || len(revision.Setting.Domains) != 2 {
+		t.Fatalf("unexpected setting: %+v", revision.Setting)
+	}
+	if _, ok := revision.Files["private.key"]; ok {
+		t.Fatal("private key must not be recorded")
+	}
+
+	// 超出保留数量后清理最旧的版本
+	for i := range biz.WebsiteRevisionLimit + 5 {
+		write("nginx.conf", "server { listen "+string(rune('a'+i%26))+string(rune('a'+i/26))+"; }")
+		if err = repo.recordRevision(website, setting, 3, biz.WebsiteRevisionUpdate); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, total, err = repo.ListRevisions(website.ID, 1, 10); err != nil {
+		t.Fatal(err)
+	}
+	if total != biz.WebsiteRevisionLimit {
+		t.Fatalf("revisions = %d, want %d", total, biz.WebsiteRevisionLimit)
+	}
+	if _, err = repo.GetRevision(website.ID, revision.ID); err == nil {
+		t.Fatal("oldest revision should be pruned")
+	}
+}
@@ -121,6 +121,9 @@ func (r *websiteStatRepo) Clear() error {
 	if err := r.db.Where("1 = 1").Delete(&biz.WebsiteErrorLog{}).Error; err != nil {
 		return err
 	}
+	if err := r.db.Where("1 = 1").Delete(&biz.WebsiteWAFLog{}).Error; err != nil {
+		return err
+	}
 	if err := r.db.Where("1 = 1").Delete(&biz.WebsiteStatSpider{}).Error; err != nil {
 		return err
 	}
@@ -140,6 +143,9 @@ func (r *websiteStatRepo) DeleteBySite(site string) error {
 	if err := r.db.Where("site = ?", site).Delete(&biz.WebsiteErrorLog{}).Error; err != nil {
 		return err
 	}
+	if err := r.db.Where("site = ?", site).Delete(&biz.WebsiteWAFLog{}).Error; err != nil {
+		return err
+	}
 	if err := r.db.Where("site = ?", site).Delete(&biz.WebsiteStatSpider{}).Error; err != nil {
 		return err
 	}
@@ -408,6 +414,58 @@ func (r *websiteStatRepo) ListErrors(start, end string, sites []string, status i
 	return items, uint(total), err
 }
 
+// ========== WAF 拦截日志 ==========
+
+func (r *websiteStatRepo) InsertWAFLogs(logs []*biz.WebsiteWAFLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	for i := 0; i < len(logs); i += upsertBatchSize {
+		end := min(i+upsertBatchSize, len(logs))
+		if err := r.db.Create(logs[i:end]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *websiteStatRepo) ListWAFLogs(start, end string, sites []string, rule string, page, limit uint) ([]*biz.WebsiteWAFLog, uint, error) {
+	var total int64
+	q := r.db.Model(&biz.WebsiteWAFLog{}).
+		Where("created_at >= ? AND created_at < DATE(?, '+1 day')", start, end)
+	if len(sites) > 0 {
+		q = q.Where("site IN ?", sites)
+	}
+	if rule != "" {
+		q = q.Where("rule = ?", rule)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []*biz.WebsiteWAFLog
+	offset := (page - 1) * limit
+	err := q.Order("created_at DESC").Offset(int(offset)).Limit(int(limit)).Find(&items).Error
+	return items, uint(total), err
+}
+
+func (r *websiteStatRepo) TopWAFRules(start, end string, sites []string) ([]*biz.WebsiteWAFRuleRank, error) {
+	var items []*biz.WebsiteWAFRuleRank
+	q := r.db.Model(&biz.WebsiteWAFLog{}).
+		Select("rule, COUNT(*) as requests").
+		Where("created_at >= ? AND created_at < DATE(?, '+1 day')", start, end)
+	if len(sites) > 0 {
+		q = q.Where("site IN ?", sites)
+	}
+	err := q.Group("rule").Order("requests DESC").Scan(&items).Error
+	return items, err
+}
+
+func (r *websiteStatRepo) ClearWAFLogsBefore(t time.Time) error {
+	return r.db.Where("created_at < ?", t).Delete(&biz.WebsiteWAFLog{}).Error
+}
+
 // ========== 网站维度汇总 ==========
 
 func (r *websiteStatRepo) ListSiteStats(start, end string, sites []string) ([]*biz.WebsiteStatSiteItem, error) {
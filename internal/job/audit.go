@@ -0,0 +1,82 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/leonelquinteros/gotext"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+// Audit 审计日志任务:启动时恢复转发、定期校验哈希链并清理超过保留期的文件
+type Audit struct {
+	t          *gotext.Locale
+	log        *slog.Logger
+	auditRepo  *biz.AuditUsecase
+	notifyRepo *biz.NotifyUsecase
+	reconciled bool
+	broken     string // 已通知过的断链位置，避免每小时重复通知
+}
+
+// NewAudit 构造审计日志任务
+func NewAudit(audit *biz.AuditUsecase, notify *biz.NotifyUsecase, t *gotext.Locale, log *slog.Logger) Job {
+	return Job{
+		Spec: "0 * * * *",
+		// 启动后立即恢复转发，避免重启后的操作漏发
+		Immediate: true,
+		Task: &Audit{
+			t:          t,
+			log:        log,
+			auditRepo:  audit,
+			notifyRepo: notify,
+		},
+	}
+}
+
+func (r *Audit) Run(_ context.Context) error {
+	if app.Status != app.StatusNormal {
+		return nil
+	}
+
+	if !r.reconciled {
+		if err := r.auditRepo.ApplyForward(); err != nil {
+			r.log.Warn("failed to apply audit log forward", slog.Any("err", err))
+		}
+		r.reconciled = true
+	}
+
+	result, err := r.auditRepo.Verify()
+	if err != nil {
+		r.log.Warn("failed to verify audit log", slog.Any("err", err))
+		return nil
+	}
+	if result.Valid {
+		r.broken = ""
+		// 链首只在此处随清理前移，链损坏时不清理以保留现场
+		count, err := r.auditRepo.ClearExpired()
+		if err != nil {
+			r.log.Warn("failed to clear expired audit log", slog.Any("err", err))
+		}
+		if count > 0 {
+			r.log.Info("expired audit log cleared", slog.Int("count", count))
+		}
+		return nil
+	}
+
+	position := fmt.Sprintf("%s:%d", result.File, result.Line)
+	r.log.Error("audit log chain broken", slog.String("file", result.File), slog.Int("line", result.Line), slog.String("reason", result.Reason))
+	if position != r.broken {
+		r.broken = position
+		r.notifyRepo.SendEvent(biz.NotifyEventHealth, r.t.Get("[AcePanel] Audit Log Tampered"), biz.NotifyBody(r.t.Get("audit log hash chain broken"), [][2]string{
+			{r.t.Get("File"), result.File},
+			{r.t.Get("Line"), strconv.Itoa(result.Line)},
+			{r.t.Get("Reason"), result.Reason},
+		}))
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+// CertMonitor 外部证书检查任务，各监控按自身间隔探测
+type CertMonitor struct {
+	log             *slog.Logger
+	certMonitorRepo *biz.CertMonitorUsecase
+}
+
+// NewCertMonitor 构造外部证书检查任务
+func NewCertMonitor(certMonitorUsecase *biz.CertMonitorUsecase, log *slog.Logger) Job {
+	return Job{
+		Spec: "* * * * *",
+		Task: &CertMonitor{
+			log:             log,
+			certMonitorRepo: certMonitorUsecase,
+		},
+	}
+}
+
+func (r *CertMonitor) Run(ctx context.Context) error {
+	if app.Status != app.StatusNormal {
+		return nil
+	}
+
+	if err := r.certMonitorRepo.Run(ctx); err != nil {
+		r.log.Warn("failed to check cert monitors", slog.String("type", biz.OperationTypeCert), slog.Uint64("operator_id", 0), slog.Any("err", err))
+	}
+	return nil
+}
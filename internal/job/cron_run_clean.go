@@ -0,0 +1,53 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+// CronRunClean 过期计划任务执行记录清理任务，面板启动后先重新生成任务的 wrapper 脚本
+type CronRunClean struct {
+	log       *slog.Logger
+	cronRepo  *biz.CronUsecase
+	refreshed bool
+}
+
+// NewCronRunClean 构造过期计划任务执行记录清理任务
+func NewCronRunClean(cronUsecase *biz.CronUsecase, log *slog.Logger) Job {
+	return Job{
+		Spec: "40 * * * *",
+		// 启动后立即刷新 wrapper，升级前创建的任务才能开始记录执行
+		Immediate: true,
+		Task: &CronRunClean{
+			log:      log,
+			cronRepo: cronUsecase,
+		},
+	}
+}
+
+func (r *CronRunClean) Run(_ context.Context) error {
+	if app.Status != app.StatusNormal {
+		return nil
+	}
+
+	// 首次运行按当前模板重新生成 wrapper 脚本
+	if !r.refreshed {
+		if err := r.cronRepo.RefreshWrappers(); err != nil {
+			r.log.Warn("failed to refresh cron wrappers", slog.Any("err", err))
+		}
+		r.refreshed = true
+	}
+
+	count, err := r.cronRepo.ClearExpiredRuns()
+	if err != nil {
+		r.log.Warn("failed to clear expired cron runs", slog.Any("err", err))
+		return nil
+	}
+	if count > 0 {
+		r.log.Info("expired cron runs cleared", slog.Int64("count", count))
+	}
+	return nil
+}
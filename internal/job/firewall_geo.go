@@ -0,0 +1,37 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+// FirewallGeo 地区规则刷新任务，IPDB 订阅更新或更换后重新编译 IP 集合
+type FirewallGeo struct {
+	log                *slog.Logger
+	firewallGeoUsecase *biz.FirewallGeoUsecase
+}
+
+// NewFirewallGeo 构造地区规则刷新任务
+func NewFirewallGeo(firewallGeoUsecase *biz.FirewallGeoUsecase, log *slog.Logger) Job {
+	return Job{
+		Spec: "*/10 * * * *",
+		Task: &FirewallGeo{
+			log:                log,
+			firewallGeoUsecase: firewallGeoUsecase,
+		},
+	}
+}
+
+func (r *FirewallGeo) Run(_ context.Context) error {
+	if app.Status != app.StatusNormal {
+		return nil
+	}
+
+	if err := r.firewallGeoUsecase.Refresh(); err != nil {
+		r.log.Warn("failed to refresh firewall geo rules", slog.String("type", biz.OperationTypeFirewall), slog.Uint64("operator_id", 0), slog.Any("err", err))
+	}
+	return nil
+}
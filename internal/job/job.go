@@ -25,17 +25,23 @@ var ProviderSet = wire.NewSet(wire.Struct(new(Dependencies), "*"), NewJobs)
 // Dependencies 汇总定时任务依赖，Wire 会在生成期校验完整性。
 type Dependencies struct {
 	Alert       *biz.AlertUsecase
+	Audit       *biz.AuditUsecase
 	Backup      *biz.BackupUsecase
 	Cache       *biz.CacheUsecase
 	Cert        *biz.CertUsecase
 	CertAccount *biz.CertAccountUsecase
+	CertMonitor *biz.CertMonitorUsecase
+	Cron        *biz.CronUsecase
 	FileShare   *biz.FileShareUsecase
+	FirewallGeo *biz.FirewallGeoUsecase
 	Monitor     *biz.MonitorUsecase
 	Notify      *biz.NotifyUsecase
+	Probe       *biz.ProbeUsecase
 	ScanEvent   *biz.ScanEventUsecase
 	Setting     *biz.SettingUsecase
 	Tamper      *biz.TamperUsecase
 	Task        *biz.TaskUsecase
+	Terminal    *biz.TerminalRecordingUsecase
 	Website     *biz.WebsiteUsecase
 	WebsiteStat *biz.WebsiteStatUsecase
 	Conf        *config.Config
@@ -50,11 +56,17 @@ func NewJobs(d *Dependencies) []Job {
 		NewAlert(d.Alert, d.Log),
 		NewMonitoring(d.Setting, d.Monitor, d.Log),
 		NewFirewallScan(d.ScanEvent, d.Setting, d.Log),
+		NewFirewallGeo(d.FirewallGeo, d.Log),
+		NewCertMonitor(d.CertMonitor, d.Log),
+		NewProbe(d.Probe, d.Log),
 		NewCertRenew(d.CertAccount, d.Cert, d.Notify, d.Setting, d.Conf, d.DB, d.T, d.Log),
 		NewFileShareClean(d.FileShare, d.Log),
+		NewTerminalRecordingClean(d.Terminal, d.Log),
+		NewCronRunClean(d.Cron, d.Log),
 		NewPanelTask(d.Backup, d.Cache, d.Monitor, d.ScanEvent, d.Setting, d.Tamper, d.Task, d.WebsiteStat, d.Conf, d.DB, d.Log),
 		NewWebsiteStat(d.Setting, d.WebsiteStat, d.Log, d.Aggregator),
 		NewWebsiteExpire(d.Notify, d.Website, d.DB, d.T, d.Log),
 		NewTamper(d.Tamper, d.Log),
+		NewAudit(d.Audit, d.Notify, d.T, d.Log),
 	}
 }
@@ -64,11 +64,17 @@ func (r *Monitoring) Run(_ context.Context) error {
 		return nil
 	}
 
-	if err = r.monitorRepo.Create(&biz.Monitor{Info: info}); err != nil {
+	if err = r.monitorRepo.Record(info); err != nil {
 		r.log.Warn("failed to create monitor record", slog.String("type", biz.OperationTypeMonitor), slog.Uint64("operator_id", 0), slog.Any("err", err))
 		return nil
 	}
 
+	// 压缩已结束的时间桶，各级按水位线增量处理，开销很小
+	if err = r.monitorRepo.Compact(time.Now()); err != nil {
+		r.log.Warn("failed to compact monitor record", slog.String("type", biz.OperationTypeMonitor), slog.Uint64("operator_id", 0), slog.Any("err", err))
+		return nil
+	}
+
 	// 删除过期数据，按天过期故限流到 6 小时一次，避免每分钟一次 DELETE
 	if time.Since(r.cleanedAt) < 6*time.Hour {
 		return nil
@@ -83,7 +89,7 @@ func (r *Monitoring) Run(_ context.Context) error {
 	if day <= 0 || app.Status != app.StatusNormal {
 		return nil
 	}
-	if err = r.monitorRepo.ClearBefore(time.Now().AddDate(0, 0, -day)); err != nil {
+	if err = r.monitorRepo.Cleanup(day, time.Now()); err != nil {
 		r.log.Warn("failed to delete monitor record", slog.String("type", biz.OperationTypeMonitor), slog.Uint64("operator_id", 0), slog.Any("err", err))
 		return nil
 	}
@@ -0,0 +1,37 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+// Probe 可用性探测任务，各探测按自身间隔执行
+type Probe struct {
+	log       *slog.Logger
+	probeRepo *biz.ProbeUsecase
+}
+
+// NewProbe 构造可用性探测任务
+func NewProbe(probeUsecase *biz.ProbeUsecase, log *slog.Logger) Job {
+	return Job{
+		Spec: "* * * * *",
+		Task: &Probe{
+			log:       log,
+			probeRepo: probeUsecase,
+		},
+	}
+}
+
+func (r *Probe) Run(ctx context.Context) error {
+	if app.Status != app.StatusNormal {
+		return nil
+	}
+
+	if err := r.probeRepo.Run(ctx); err != nil {
+		r.log.Warn("failed to run probes", slog.String("type", biz.OperationTypeMonitor), slog.Uint64("operator_id", 0), slog.Any("err", err))
+	}
+	return nil
+}
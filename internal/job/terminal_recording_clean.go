@@ -0,0 +1,42 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+// TerminalRecordingClean 过期终端录像清理任务
+type TerminalRecordingClean struct {
+	log           *slog.Logger
+	recordingRepo *biz.TerminalRecordingUsecase
+}
+
+// NewTerminalRecordingClean 构造过期终端录像清理任务
+func NewTerminalRecordingClean(recordingUsecase *biz.TerminalRecordingUsecase, log *slog.Logger) Job {
+	return Job{
+		Spec: "30 * * * *",
+		Task: &TerminalRecordingClean{
+			log:           log,
+			recordingRepo: recordingUsecase,
+		},
+	}
+}
+
+func (r *TerminalRecordingClean) Run(_ context.Context) error {
+	if app.Status != app.StatusNormal {
+		return nil
+	}
+
+	count, err := r.recordingRepo.ClearExpired()
+	if err != nil {
+		r.log.Warn("failed to clear expired terminal recordings", slog.Any("err", err))
+		return nil
+	}
+	if count > 0 {
+		r.log.Info("expired terminal recordings cleared", slog.Int64("count", count))
+	}
+	return nil
+}
@@ -53,6 +53,7 @@ func (r *WebsiteStat) Run(_ context.Context) error {
 	r.geoIP, r.geoIPPath, r.geoIPModTime = refreshGeoIP(r.setting, r.geoIP, r.geoIPPath, r.geoIPModTime, r.log)
 	r.flush()
 	r.flushErrors()
+	r.flushWAF()
 	r.flushDetails()
 	r.cleanup()
 	return nil
@@ -107,6 +108,10 @@ func (r *WebsiteStat) readLoop(listener *websitestat.Listener) {
 			continue
 		}
 
+		if entry.WAF != "" {
+			r.aggregator.RecordWAF(entry)
+			continue
+		}
 		r.aggregator.Record(entry)
 	}
 }
@@ -225,6 +230,37 @@ func (r *WebsiteStat) flushErrors() {
 	app.Health.Clear(healthKeyStatDB)
 }
 
+// flushWAF 将 WAF 拦截事件写入数据库
+func (r *WebsiteStat) flushWAF() {
+	entries, commit := r.aggregator.DrainWAF()
+	if len(entries) == 0 {
+		return
+	}
+
+	now := time.Now()
+	logs := lo.Map(entries, func(e *websitestat.WAFEntry, _ int) *biz.WebsiteWAFLog {
+		return &biz.WebsiteWAFLog{
+			Site:      e.Site,
+			Rule:      e.Rule,
+			URI:       e.URI,
+			Method:    e.Method,
+			Status:    e.Status,
+			IP:        e.IP,
+			UA:        e.UA,
+			CreatedAt: now,
+		}
+	})
+
+	if err := r.statRepo.InsertWAFLogs(logs); err != nil {
+		r.log.Warn("failed to insert website waf logs", slog.Any("err", err))
+		app.Health.Report(healthKeyStatDB, app.HealthLevelError, err.Error())
+		commit()
+		return
+	}
+	commit()
+	app.Health.Clear(healthKeyStatDB)
+}
+
 // flushDetails 将详细统计增量写入数据库（蜘蛛/客户端/IP/URI）
 func (r *WebsiteStat) flushDetails() {
 	detailsByDate, commit := r.aggregator.DrainDetailStats()
@@ -349,6 +385,9 @@ func (r *WebsiteStat) cleanup() {
 	if err = r.statRepo.ClearErrorsBefore(errCutoff); err != nil {
 		r.log.Warn("failed to clear expired website error logs", slog.Any("err", err))
 	}
+	if err = r.statRepo.ClearWAFLogsBefore(errCutoff); err != nil {
+		r.log.Warn("failed to clear expired website waf logs", slog.Any("err", err))
+	}
 
 	// 清理详细统计表
 	if err = r.statRepo.ClearSpidersBefore(cutoff); err != nil {
@@ -111,8 +111,8 @@ func Entrance(t *gotext.Locale, conf *config.Config, session *sessions.Manager)
 				return
 			}
 
-			// 情况四：Webhook 与文件分享下载访问，跳过验证
-			if strings.HasPrefix(r.URL.Path, "/webhook/") || strings.HasPrefix(r.URL.Path, "/download/") {
+			// 情况四：Webhook、文件分享下载与指标抓取访问，跳过验证，指标接口由独立令牌鉴权
+			if strings.HasPrefix(r.URL.Path, "/webhook/") || strings.HasPrefix(r.URL.Path, "/download/") || r.URL.Path == "/metrics" {
 				next.ServeHTTP(w, r)
 				return
 			}
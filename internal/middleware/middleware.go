@@ -25,10 +25,11 @@ type Middlewares struct {
 	log       *slog.Logger
 	session   *sessions.Manager
 	appRepo   biz.AppRepo
+	user      biz.UserRepo
 	userToken biz.UserTokenRepo
 }
 
-func NewMiddlewares(conf *config.Config, t *gotext.Locale, session *sessions.Manager, appRepo biz.AppRepo, userTokenRepo biz.UserTokenRepo) (*Middlewares, error) {
+func NewMiddlewares(conf *config.Config, t *gotext.Locale, session *sessions.Manager, appRepo biz.AppRepo, userRepo biz.UserRepo, userTokenRepo biz.UserTokenRepo) (*Middlewares, error) {
 	// http 访问日志写入轮转文件
 	w, err := logrotate.New(filepath.Join(app.Root, "panel/storage/logs/http.log"),
 		logrotate.WithMaxSize(10*logrotate.MB),
@@ -47,12 +48,13 @@ func NewMiddlewares(conf *config.Config, t *gotext.Locale, session *sessions.Man
 		log:       slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo})),
 		session:   session,
 		appRepo:   appRepo,
+		user:      userRepo,
 		userToken: userTokenRepo,
 	}, nil
 }
 
-// Globals 全局中间件集合，应用到每个请求；whitelist 为登录白名单路径。
-func (r *Middlewares) Globals(t *gotext.Locale, mux *chi.Mux, whitelist []string) []func(http.Handler) http.Handler {
+// Globals 全局中间件集合，应用到每个请求；permissions 为端点权限表，whitelist 为登录白名单路径。
+func (r *Middlewares) Globals(t *gotext.Locale, mux *chi.Mux, permissions map[string]Permission, whitelist []string) []func(http.Handler) http.Handler {
 	compressor := chimiddleware.NewCompressor(6)
 
 	return []func(http.Handler) http.Handler{
@@ -74,7 +76,12 @@ func (r *Middlewares) Globals(t *gotext.Locale, mux *chi.Mux, whitelist []string
 		sessionmiddleware.StartSession(r.session),
 		Status(t),
 		Entrance(t, r.conf, r.session),
-		MustLogin(t, r.conf, r.session, r.userToken, whitelist),
+		MustLogin(t, r.conf, r.session, r.userToken, mux, permissions, whitelist),
 		MustInstall(t, r.appRepo),
 	}
 }
+
+// Permission 端点级权限中间件，由路由注册时按端点声明挂载
+func (r *Middlewares) Permission(perm Permission) func(http.Handler) http.Handler {
+	return MustPermission(r.t, r.user, perm)
+}
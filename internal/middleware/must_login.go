@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/leonelquinteros/gotext"
 	"github.com/libtnb/sessions"
 	"github.com/spf13/cast"
@@ -19,7 +20,10 @@ import (
 )
 
 // MustLogin 确保已登录
-func MustLogin(t *gotext.Locale, conf *config.Config, session *sessions.Manager, userToken biz.UserTokenRepo, whitelist []string) func(next http.Handler) http.Handler {
+// API 令牌请求按 permissions（"METHOD 路由模式" => 端点权限）校验令牌的访问范围与限流
+func MustLogin(t *gotext.Locale, conf *config.Config, session *sessions.Manager, userToken biz.UserTokenRepo, mux *chi.Mux, permissions map[string]Permission, whitelist []string) func(next http.Handler) http.Handler {
+	throttle := newTokenThrottle()
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			sess, err := session.GetSession(r)
@@ -42,10 +46,23 @@ func MustLogin(t *gotext.Locale, conf *config.Config, session *sessions.Manager,
 					return
 				}
 				// API 请求验证
-				if userID, err = userToken.ValidateReq(r); err != nil {
+				var token *biz.UserToken
+				if token, err = userToken.ValidateReq(r); err != nil {
 					Abort(w, http.StatusUnauthorized, "%v", err)
 					return
 				}
+				if !tokenAllowed(mux, permissions, token, r) {
+					Abort(w, http.StatusForbidden, t.Get("token is not allowed to access this endpoint"))
+					return
+				}
+				if !throttle.Allow(r.Context(), token) {
+					Abort(w, http.StatusTooManyRequests, t.Get("token rate limit exceeded"))
+					return
+				}
+				if token.Restricted() {
+					r = r.WithContext(biz.WithRestrictedToken(r.Context()))
+				}
+				userID = token.UserID
 			} else {
 				if sess.Missing("user_id") {
 					Abort(w, http.StatusUnauthorized, t.Get("session expired, please login again"))
@@ -101,3 +118,27 @@ func MustLogin(t *gotext.Locale, conf *config.Config, session *sessions.Manager,
 		})
 	}
 }
+
+// tokenAllowed 判断令牌能否访问当前请求的端点，未声明权限的端点对受限令牌一律拒绝
+// 受限令牌不能签发登录凭据，Self 端点也只放行只读请求
+func tokenAllowed(mux *chi.Mux, permissions map[string]Permission, token *biz.UserToken, r *http.Request) bool {
+	if !token.Restricted() {
+		return true
+	}
+
+	pattern := mux.Find(chi.NewRouteContext(), r.Method, r.URL.Path)
+	perm, ok := permissions[r.Method+" "+pattern]
+	if !ok && strings.HasPrefix(pattern, "/api/apps/") {
+		perm = AppPermission
+	}
+	if perm.Credential || perm.Admin {
+		return false
+	}
+
+	// 未声明权限的端点没有标签，只能通过端点白名单访问
+	write := perm.Write || (r.Method != http.MethodGet && r.Method != http.MethodHead)
+	if perm.Self && !write {
+		return true
+	}
+	return token.Allowed(r.Method, pattern, r.URL.Path, perm.Tags, write)
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+func TestTokenAllowed(t *testing.T) {
+	mux := chi.NewRouter()
+	noop := func(http.ResponseWriter, *http.Request) {}
+	mux.Get("/api/website", noop)
+	mux.Post("/api/website/{id}/status", noop)
+	mux.Get("/api/cert/cert/{id}", noop)
+	mux.Post("/api/file/save", noop)
+	mux.Post("/api/cron/{id}/status", noop)
+	mux.Get("/api/user/info", noop)
+	mux.Post("/api/user/passkey/register", noop)
+	mux.Get("/api/log/terminal/{id}/content", noop)
+	mux.Get("/api/ping", noop)
+	mux.Route("/api/apps", func(r chi.Router) {
+		r.Get("/nginx/config", noop)
+	})
+	permissions := map[string]Permission{
+		"GET /api/website":                   {Tags: []string{"网站"}},
+		"POST /api/website/{id}/status":      {Tags: []string{"网站"}},
+		"GET /api/cert/cert/{id}":            {Tags: []string{"证书"}},
+		"POST /api/file/save":                {Tags: []string{"文件"}},
+		"POST /api/cron/{id}/status":         {Tags: []string{"计划任务"}},
+		"GET /api/user/info":                 {Tags: []string{"用户"}, Self: true},
+		"POST /api/user/passkey/register":    {Tags: []string{"通行密钥"}, Self: true, Credential: true},
+		"GET /api/log/terminal/{id}/content": {Tags: []string{"日志"}, Admin: true},
+	}
+
+	deploy := &biz.UserToken{Scopes: map[string]biz.RoleAccess{"网站": biz.RoleAccessWrite, "证书": biz.RoleAccessRead}}
+	allowlist := &biz.UserToken{Endpoints: []string{"GET /api/cert/*", "POST /api/website/{id}/status"}}
+	apps := &biz.UserToken{Scopes: map[string]biz.RoleAccess{"应用": biz.RoleAccessRead}}
+	logs := &biz.UserToken{Scopes: map[string]biz.RoleAccess{"日志": biz.RoleAccessWrite}}
+	passkey := &biz.UserToken{Scopes: map[string]biz.RoleAccess{"通行密钥": biz.RoleAccessWrite}, Endpoints: []string{"POST /api/user/*"}}
+
+	tests := []struct {
+		name   string
+		token  *biz.UserToken
+		method string
+		path   string
+		want   bool
+	}{
+		{name: "unrestricted", token: &biz.UserToken{}, method: http.MethodPost, path: "/api/file/save", want: true},
+		{name: "scope write", token: deploy, method: http.MethodPost, path: "/api/website/1/status", want: true},
+		{name: "scope read", token: deploy, method: http.MethodGet, path: "/api/cert/cert/2", want: true},
+		{name: "file save denied", token: deploy, method: http.MethodPost, path: "/api/file/save", want: false},
+		{name: "cron denied", token: deploy, method: http.MethodPost, path: "/api/cron/3/status", want: false},
+		{name: "self endpoint", token: deploy, method: http.MethodGet, path: "/api/user/info", want: true},
+		{name: "undeclared endpoint", token: deploy, method: http.MethodGet, path: "/api/ping", want: false},
+		{name: "allowlist prefix", token: allowlist, method: http.MethodGet, path: "/api/cert/cert/2", want: true},
+		{name: "allowlist pattern", token: allowlist, method: http.MethodPost, path: "/api/website/9/status", want: true},
+		{name: "allowlist method", token: allowlist, method: http.MethodGet, path: "/api/website", want: false},
+		{name: "app read", token: apps, method: http.MethodGet, path: "/api/apps/nginx/config", want: true},
+		{name: "passkey register denied", token: deploy, method: http.MethodPost, path: "/api/user/passkey/register", want: false},
+		{name: "passkey register scoped denied", token: passkey, method: http.MethodPost, path: "/api/user/passkey/register", want: false},
+		{name: "admin only endpoint denied", token: logs, method: http.MethodGet, path: "/api/log/terminal/1/content", want: false},
+		{name: "passkey register unrestricted", token: &biz.UserToken{}, method: http.MethodPost, path: "/api/user/passkey/register", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if got := tokenAllowed(mux, permissions, tt.token, r); got != tt.want {
+				t.Fatalf("tokenAllowed(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenThrottle(t *testing.T) {
+	throttle := newTokenThrottle()
+	limited := &biz.UserToken{ID: 1, RateLimit: 2}
+	other := &biz.UserToken{ID: 2, RateLimit: 2}
+	unlimited := &biz.UserToken{ID: 3}
+
+	for i := 0; i < 2; i++ {
+		if !throttle.Allow(context.Background(), limited) {
+			t.Fatalf("request %d should be allowed", i+1)
+		}
+	}
+	if throttle.Allow(context.Background(), limited) {
+		t.Fatal("request over the limit should be denied")
+	}
+	if !throttle.Allow(context.Background(), other) {
+		t.Fatal("tokens must be counted separately")
+	}
+	for i := 0; i < 10; i++ {
+		if !throttle.Allow(context.Background(), unlimited) {
+			t.Fatal("token without rate limit should never be denied")
+		}
+	}
+}
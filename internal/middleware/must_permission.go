@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leonelquinteros/gotext"
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+// scopeBodyLimit 读取请求体解析资源 ID 的上限
+const scopeBodyLimit = 1 << 20
+
+// Permission 端点的权限声明，由路由表生成
+type Permission struct {
+	Tags     []string // 端点所属权限标签，需全部满足
+	Write    bool     // GET 但有副作用的端点，按写权限检查
+	Self     bool     // 任何已登录用户均可访问
+	Owner    bool     // 路径参数 id 为当前用户时放行
+	Scope    string   // 资源范围类型
+	ScopeKey string   // 资源 ID 参数名，依次从路径、查询、JSON 请求体读取
+	// Credential 签发或变更登录凭据的端点，受限令牌一律拒绝
+	Credential bool
+	// Admin 仅管理员可访问的端点，受限令牌一律拒绝
+	Admin bool
+}
+
+// AppPermission 动态应用子路由的权限声明
+var AppPermission = Permission{Tags: []string{"应用"}}
+
+// MustPermission 按当前用户角色校验端点权限，并将用户资源范围写入 context
+func MustPermission(t *gotext.Locale, userRepo biz.UserRepo, perm Permission) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := userRepo.Get(cast.ToUint(r.Context().Value("user_id")))
+			if err != nil {
+				Abort(w, http.StatusUnauthorized, t.Get("invalid user id, please login again"))
+				return
+			}
+			if user.Role.IsAdmin() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if perm.Admin {
+				Abort(w, http.StatusForbidden, t.Get("permission denied"))
+				return
+			}
+
+			r = r.WithContext(biz.WithScopes(r.Context(), user.Scopes))
+			if perm.Self || (perm.Owner && cast.ToUint(chi.URLParam(r, "id")) == user.ID) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			write := perm.Write || (r.Method != http.MethodGet && r.Method != http.MethodHead)
+			if !user.Role.Allowed(perm.Tags, write) {
+				Abort(w, http.StatusForbidden, t.Get("permission denied"))
+				return
+			}
+
+			if perm.Scope != "" {
+				if _, restricted := biz.ScopeIDs(r.Context(), perm.Scope); restricted {
+					id, ok := scopeID(r, perm.ScopeKey)
+					// 无法确定目标资源的写操作（如新建、全局配置）对受限用户一律拒绝
+					if (ok && !biz.InScope(r.Context(), perm.Scope, id)) || (!ok && write) {
+						Abort(w, http.StatusForbidden, t.Get("permission denied"))
+						return
+					}
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// scopeID 从路径参数、查询参数或 JSON 请求体中读取资源 ID，读取请求体后会还原
+func scopeID(r *http.Request, key string) (uint, bool) {
+	if key == "" {
+		return 0, false
+	}
+	if value := chi.URLParam(r, key); value != "" {
+		return cast.ToUint(value), true
+	}
+	if value := r.URL.Query().Get(key); value != "" {
+		return cast.ToUint(value), true
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Body == nil || mediaType != "application/json" {
+		return 0, false
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, scopeBodyLimit))
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return 0, false
+	}
+
+	var payload map[string]any
+	if err = json.Unmarshal(body, &payload); err != nil {
+		return 0, false
+	}
+	value, ok := payload[key]
+	if !ok {
+		return 0, false
+	}
+
+	return cast.ToUint(value), true
+}
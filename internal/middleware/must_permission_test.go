@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestScopeID(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		param  string
+		body   string
+		ctype  string
+		key    string
+		wantID uint
+		wantOK bool
+	}{
+		{name: "no key", method: http.MethodGet, target: "/api/website", wantOK: false},
+		{name: "url param", method: http.MethodGet, target: "/api/website/3", param: "3", key: "id", wantID: 3, wantOK: true},
+		{name: "query", method: http.MethodGet, target: "/api/database_redis/data?server_id=5", key: "server_id", wantID: 5, wantOK: true},
+		{name: "json body", method: http.MethodPost, target: "/api/database", body: `{"server_id":7,"name":"db"}`, ctype: "application/json; charset=utf-8", key: "server_id", wantID: 7, wantOK: true},
+		{name: "json body missing key", method: http.MethodPost, target: "/api/website", body: `{"name":"site"}`, ctype: "application/json", key: "id", wantOK: false},
+		{name: "form body ignored", method: http.MethodPost, target: "/api/database", body: `server_id=7`, ctype: "application/x-www-form-urlencoded", key: "server_id", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.ctype != "" {
+				r.Header.Set("Content-Type", tt.ctype)
+			}
+			rctx := chi.NewRouteContext()
+			if tt.param != "" {
+				rctx.URLParams.Add("id", tt.param)
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			gotID, gotOK := scopeID(r, tt.key)
+			if gotOK != tt.wantOK || gotID != tt.wantID {
+				t.Fatalf("scopeID = (%d, %v), want (%d, %v)", gotID, gotOK, tt.wantID, tt.wantOK)
+			}
+
+			// 读取后请求体必须还原，供后续 Bind 使用
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != tt.body {
+				t.Fatalf("body = %q, want %q", body, tt.body)
+			}
+		})
+	}
+}
@@ -1,12 +1,18 @@
 package middleware
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/sethvargo/go-limiter"
 	"github.com/sethvargo/go-limiter/httplimit"
 	"github.com/sethvargo/go-limiter/memorystore"
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/v3/internal/biz"
 )
 
 // Throttle 限流器
@@ -26,3 +32,39 @@ func Throttle(ipHeader string, tokens uint64, interval time.Duration) func(next
 
 	return limiter.Handle
 }
+
+// tokenThrottle API 令牌限流器，按令牌 ID 计数，相同限额的令牌共用一个存储
+type tokenThrottle struct {
+	mu     sync.Mutex
+	stores map[uint]limiter.Store
+}
+
+func newTokenThrottle() *tokenThrottle {
+	return &tokenThrottle{stores: make(map[uint]limiter.Store)}
+}
+
+// Allow 令牌本分钟内是否仍有请求额度
+func (l *tokenThrottle) Allow(ctx context.Context, token *biz.UserToken) bool {
+	if token.RateLimit == 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	store, ok := l.stores[token.RateLimit]
+	if !ok {
+		var err error
+		store, err = memorystore.New(&memorystore.Config{
+			Tokens:   uint64(token.RateLimit),
+			Interval: time.Minute,
+		})
+		if err != nil {
+			l.mu.Unlock()
+			return true
+		}
+		l.stores[token.RateLimit] = store
+	}
+	l.mu.Unlock()
+
+	_, _, _, ok, err := store.Take(ctx, cast.ToString(token.ID))
+	return err != nil || ok
+}
@@ -1,17 +1,79 @@
 package migration
 
 import (
+	"time"
+
 	"github.com/go-gormigrate/gormigrate/v2"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/pkg/types"
 )
 
+// legacyMonitor 旧版整条 JSON 存储的监控记录
+type legacyMonitor struct {
+	ID        uint              `gorm:"primaryKey"`
+	Info      types.CurrentInfo `gorm:"not null;default:'{}';serializer:zstd"`
+	CreatedAt time.Time         `gorm:"index:idx_monitors_created_at"`
+	UpdatedAt time.Time
+}
+
+func (legacyMonitor) TableName() string {
+	return "monitors"
+}
+
 var MonitorMigrations = []*gormigrate.Migration{
 	{
 		ID: "20260814-init-monitor",
 		Migrate: func(tx *gorm.DB) error {
-			return tx.AutoMigrate(&biz.Monitor{})
+			return tx.AutoMigrate(&legacyMonitor{})
+		},
+	},
+	{
+		ID: "20261017-monitor-rollups",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&biz.MonitorSeries{}, &biz.MonitorPoint{}, &biz.MonitorProcess{}); err != nil {
+				return err
+			}
+
+			// 旧数据转为原始数据点，由监控任务逐级压缩，Top 进程不迁移
+			series := make(map[string]uint)
+			var prev *types.CurrentInfo
+			var rows []*legacyMonitor
+			err := tx.Order("id ASC").FindInBatches(&rows, 500, func(batch *gorm.DB, _ int) error {
+				points := make([]*biz.MonitorPoint, 0)
+				for _, row := range rows {
+					row.Info.Time = row.CreatedAt
+					for name, value := range biz.MonitorValues(row.Info, prev) {
+						id, ok := series[name]
+						if !ok {
+							item := &biz.MonitorSeries{Name: name}
+							if err := batch.Where(item).FirstOrCreate(item).Error; err != nil {
+								return err
+							}
+							id = item.ID
+							series[name] = id
+						}
+						points = append(points, &biz.MonitorPoint{
+							Time:     row.CreatedAt.Unix(),
+							SeriesID: id,
+							Avg:      value,
+							Min:      value,
+							Max:      value,
+							Count:    1,
+						})
+					}
+					info := row.Info
+					prev = &info
+				}
+				return batch.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(points, 500).Error
+			}).Error
+			if err != nil {
+				return err
+			}
+
+			return tx.Migrator().DropTable(&legacyMonitor{})
 		},
 	},
 }
@@ -3,6 +3,7 @@ package migration
 import (
 	"github.com/go-gormigrate/gormigrate/v2"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/acepanel/panel/v3/internal/biz"
 )
@@ -202,4 +203,186 @@ func init() {
 			return tx.Migrator().DropColumn(&biz.Website{}, "cert_id")
 		},
 	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-add-roles",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&biz.Role{}, &biz.User{}); err != nil {
+				return err
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(biz.BuiltinRoles()).Error; err != nil {
+				return err
+			}
+
+			// 已有用户均为管理员
+			return tx.Exec("UPDATE users SET role_id = ? WHERE role_id = 0", biz.RoleIDAdmin).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&biz.User{}, "role_id"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&biz.User{}, "scopes"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&biz.Role{})
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-user-token-scopes",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.UserToken{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"scopes", "endpoints", "rate_limit", "last_used_at", "last_used_ip"} {
+				if err := tx.Migrator().DropColumn(&biz.UserToken{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-firewall-geo-rules",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.FirewallGeoRule{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.FirewallGeoRule{})
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-cert-deploy-targets",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.CertDeployTarget{}, &biz.CertDeployLog{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.CertDeployTarget{}, &biz.CertDeployLog{})
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-cert-monitors",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.CertMonitor{}, &biz.CertMonitorCheck{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.CertMonitor{}, &biz.CertMonitorCheck{})
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-probes",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.Probe{}, &biz.ProbeRecord{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.Probe{}, &biz.ProbeRecord{})
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-terminal-recordings",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.TerminalRecording{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.TerminalRecording{})
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-website-revisions",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.WebsiteRevision{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.WebsiteRevision{})
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-website-revision-operator",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.WebsiteRevision{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"operator_id", "action", "setting"} {
+				if err := tx.Migrator().DropColumn(&biz.WebsiteRevision{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-cron-runs",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.CronRun{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.CronRun{})
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-cron-overlap",
+		Migrate: func(tx *gorm.DB) error {
+			// 进程锁改为重叠策略，原开启进程锁的任务转为 skip
+			var crons []*biz.Cron
+			if err := tx.Find(&crons).Error; err != nil {
+				return err
+			}
+			for _, cron := range crons {
+				if !cron.Config.Flock {
+					continue
+				}
+				cron.Config.Flock = false
+				cron.Config.Overlap = biz.CronOverlapSkip
+				if err := tx.Model(cron).Select("config").Updates(cron).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return nil
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-task-queue",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.Task{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"resource", "priority", "timeout", "max_retries", "attempts", "retry_at"} {
+				if err := tx.Migrator().DropColumn(&biz.Task{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-deployments",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.Deployment{}, &biz.DeploymentRelease{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.Deployment{}, &biz.DeploymentRelease{})
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-project-blue-green",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.Project{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&biz.Project{}, "blue_green")
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261017-operator-role-permissions",
+		Migrate: func(tx *gorm.DB) error {
+			// 运维角色不再开放文件、终端与 SSH，可执行任意命令的模块改为只读
+			operator := biz.BuiltinRoles()[biz.RoleIDOperator-1]
+			return tx.Model(&biz.Role{}).Where("id = ?", biz.RoleIDOperator).
+				Select("description", "permissions").Updates(operator).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return nil
+		},
+	})
 }
@@ -45,4 +45,10 @@ var WebsiteStatMigrations = []*gormigrate.Migration{
 			return vacuumDB(tx)
 		},
 	},
+	{
+		ID: "20261017-website-waf-logs",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&biz.WebsiteWAFLog{})
+		},
+	},
 }
@@ -0,0 +1,25 @@
+package request
+
+// AuditExport 审计日志导出请求，日期为 YYYY-MM-DD，均包含当天，空表示不限制
+type AuditExport struct {
+	Format     string `json:"format" form:"format" query:"format" validate:"required && in:csv,json"`
+	Start      string `json:"start" form:"start" query:"start" validate:"datetime:2006-01-02"`
+	End        string `json:"end" form:"end" query:"end" validate:"datetime:2006-01-02"`
+	OperatorID uint   `json:"operator_id" form:"operator_id" query:"operator_id"`
+	Type       string `json:"type" form:"type" query:"type"`
+}
+
+// AuditForward 审计日志转发设置
+type AuditForward struct {
+	Type     string            `json:"type" form:"type" validate:"in:,syslog,http"`
+	Network  string            `json:"network" form:"network" validate:"required_if:Type,syslog && in:,udp,tcp,tls"`
+	Address  string            `json:"address" form:"address" validate:"required_if:Type,syslog"`
+	Insecure bool              `json:"insecure" form:"insecure"`
+	URL      string            `json:"url" form:"url" validate:"required_if:Type,http"`
+	Headers  map[string]string `json:"headers" form:"headers"`
+}
+
+// AuditSetting 审计日志设置
+type AuditSetting struct {
+	Months uint `json:"months" form:"months" validate:"max:1200"`
+}
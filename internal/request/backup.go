@@ -3,13 +3,15 @@ package request
 import "mime/multipart"
 
 type BackupList struct {
-	Type string `uri:"type" form:"type" validate:"required && in:path,website,mysql,postgresql,clickhouse,redis,valkey,panel"`
+	Type    string `uri:"type" form:"type" validate:"required && in:path,website,mysql,postgresql,clickhouse,redis,valkey,mongodb,elasticsearch,panel"`
+	Storage uint   `json:"storage" form:"storage" query:"storage"` // 备份存储 ID，0 为本地
 }
 
 type BackupCreate struct {
-	Type    string `uri:"type" form:"type" validate:"required && in:website,mysql,postgresql,clickhouse,redis,valkey,panel"`
+	Type    string `uri:"type" form:"type" validate:"required && in:website,mysql,postgresql,clickhouse,redis,valkey,mongodb,elasticsearch,panel"`
 	Target  string `json:"target" form:"target" validate:"required && regex:\"^[A-Za-z0-9_.-]{1,128}$\""`
 	Storage uint   `form:"storage" json:"storage"`
+	Dedup   bool   `form:"dedup" json:"dedup"` // 去重快照，仅网站支持
 }
 
 type BackupUpload struct {
@@ -18,10 +20,15 @@ type BackupUpload struct {
 }
 
 type BackupFile struct {
-	Type string `uri:"type" form:"type" validate:"required && in:website,mysql,postgresql,clickhouse,redis,valkey,panel"`
+	Type string `uri:"type" form:"type" validate:"required && in:website,mysql,postgresql,clickhouse,redis,valkey,mongodb,elasticsearch,panel"`
 	File string `json:"file" form:"file" validate:"required"`
 }
 
+type BackupVerify struct {
+	BackupFile
+	Storage uint `json:"storage" form:"storage"` // 备份存储 ID，0 为本地
+}
+
 type BackupRestore struct {
 	BackupFile
 	Target string `json:"target" form:"target" validate:"required && regex:\"^[A-Za-z0-9_.-]{1,128}$\""`
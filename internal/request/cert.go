@@ -1,5 +1,7 @@
 package request
 
+import "github.com/acepanel/panel/v3/pkg/types"
+
 type CertUpload struct {
 	Cert string `form:"cert" json:"cert" validate:"required"`
 	Key  string `form:"key" json:"key" validate:"required"`
@@ -34,3 +36,48 @@ type CertDeploy struct {
 	WebsiteIDs  []uint `form:"website_ids" json:"website_ids" validate:"required && unique"`
 	EnableHTTPS bool   `form:"enable_https" json:"enable_https"`
 }
+
+type CertDeployTargetCreate struct {
+	CertID uint                   `form:"cert_id" json:"cert_id" validate:"required && exists:certs,id"`
+	Name   string                 `form:"name" json:"name" validate:"required"`
+	Type   string                 `form:"type" json:"type" validate:"required && in:ssh,panel,s3,docker,app"`
+	Config types.CertDeployConfig `form:"config" json:"config"`
+	Auto   bool                   `form:"auto" json:"auto"`
+}
+
+type CertDeployTargetUpdate struct {
+	ID     uint                   `form:"id" json:"id" uri:"id" validate:"required && exists:cert_deploy_targets,id"`
+	Name   string                 `form:"name" json:"name" validate:"required"`
+	Type   string                 `form:"type" json:"type" validate:"required && in:ssh,panel,s3,docker,app"`
+	Config types.CertDeployConfig `form:"config" json:"config"`
+	Auto   bool                   `form:"auto" json:"auto"`
+}
+
+type CertDeployTargetLogs struct {
+	ID uint `json:"id" form:"id" uri:"id" validate:"required && exists:cert_deploy_targets,id"`
+	Paginate
+}
+
+type CertMonitorCreate struct {
+	Name     string `form:"name" json:"name" validate:"required"`
+	Host     string `form:"host" json:"host" validate:"required"`
+	Port     uint   `form:"port" json:"port" validate:"required && min:1 && max:65535"`
+	SNI      string `form:"sni" json:"sni"`
+	Interval uint   `form:"interval" json:"interval" validate:"required && min:5 && max:10080"`
+	Enabled  bool   `form:"enabled" json:"enabled"`
+}
+
+type CertMonitorUpdate struct {
+	ID       uint   `form:"id" json:"id" uri:"id" validate:"required && exists:cert_monitors,id"`
+	Name     string `form:"name" json:"name" validate:"required"`
+	Host     string `form:"host" json:"host" validate:"required"`
+	Port     uint   `form:"port" json:"port" validate:"required && min:1 && max:65535"`
+	SNI      string `form:"sni" json:"sni"`
+	Interval uint   `form:"interval" json:"interval" validate:"required && min:5 && max:10080"`
+	Enabled  bool   `form:"enabled" json:"enabled"`
+}
+
+type CertMonitorChecks struct {
+	ID uint `json:"id" form:"id" uri:"id" validate:"required && exists:cert_monitors,id"`
+	Paginate
+}
@@ -1,45 +1,70 @@
 package request
 
 type CronCreate struct {
-	Name     string            `form:"name" json:"name" validate:"required && not_exists:crons,name"`
-	Type     string            `form:"type" json:"type" validate:"required && in:shell,backup,cutoff,url,synctime"`
-	Time     string            `form:"time" json:"time" validate:"required && cron"`
-	Script   string            `form:"script" json:"script"`
-	SubType  string            `form:"sub_type" json:"sub_type" validate:"required_if:Type,backup,cutoff"`
-	Flock    bool              `form:"flock" json:"flock"`
-	Storage  uint              `form:"storage" json:"storage"`
-	Targets  []string          `form:"targets" json:"targets" validate:"required_if:Type,backup,cutoff && unique"`
-	Keep     uint              `form:"keep" json:"keep" validate:"required"`
-	URL      string            `form:"url" json:"url"`
-	Method   string            `form:"method" json:"method"`
-	Headers  map[string]string `form:"headers" json:"headers"`
-	Body     string            `form:"body" json:"body"`
-	Timeout  uint              `form:"timeout" json:"timeout"`
-	Insecure bool              `form:"insecure" json:"insecure"`
-	Retries  uint              `form:"retries" json:"retries"`
+	Name        string            `form:"name" json:"name" validate:"required && not_exists:crons,name"`
+	Type        string            `form:"type" json:"type" validate:"required && in:shell,backup,cutoff,url,synctime"`
+	Time        string            `form:"time" json:"time" validate:"required && cron"`
+	Script      string            `form:"script" json:"script"`
+	SubType     string            `form:"sub_type" json:"sub_type" validate:"required_if:Type,backup,cutoff"`
+	Dedup       bool              `form:"dedup" json:"dedup"`
+	TestRestore bool              `form:"test_restore" json:"test_restore"`
+	Storage     uint              `form:"storage" json:"storage"`
+	Targets     []string          `form:"targets" json:"targets" validate:"required_if:Type,backup,cutoff && unique"`
+	Keep        uint              `form:"keep" json:"keep" validate:"required"`
+	URL         string            `form:"url" json:"url"`
+	Method      string            `form:"method" json:"method"`
+	Headers     map[string]string `form:"headers" json:"headers"`
+	Body        string            `form:"body" json:"body"`
+	Timeout     uint              `form:"timeout" json:"timeout"`
+	Insecure    bool              `form:"insecure" json:"insecure"`
+	Retries     uint              `form:"retries" json:"retries"`
+	Overlap     string            `form:"overlap" json:"overlap" validate:"in:,allow,skip,queue"`
+	MaxRuntime  uint              `form:"max_runtime" json:"max_runtime"`
+	OnSuccess   []uint            `form:"on_success" json:"on_success" validate:"unique"`
+	OnFailure   []uint            `form:"on_failure" json:"on_failure" validate:"unique"`
 }
 
 type CronUpdate struct {
-	ID       uint              `form:"id" json:"id" validate:"required && exists:crons,id"`
-	Name     string            `form:"name" json:"name" validate:"required"`
-	Type     string            `form:"type" json:"type" validate:"required && in:shell,backup,cutoff,url,synctime"`
-	Time     string            `form:"time" json:"time" validate:"required && cron"`
-	Script   string            `form:"script" json:"script"`
-	SubType  string            `form:"sub_type" json:"sub_type"`
-	Flock    bool              `form:"flock" json:"flock"`
-	Storage  uint              `form:"storage" json:"storage"`
-	Targets  []string          `form:"targets" json:"targets"`
-	Keep     uint              `form:"keep" json:"keep"`
-	URL      string            `form:"url" json:"url"`
-	Method   string            `form:"method" json:"method"`
-	Headers  map[string]string `form:"headers" json:"headers"`
-	Body     string            `form:"body" json:"body"`
-	Timeout  uint              `form:"timeout" json:"timeout"`
-	Insecure bool              `form:"insecure" json:"insecure"`
-	Retries  uint              `form:"retries" json:"retries"`
+	ID          uint              `form:"id" json:"id" validate:"required && exists:crons,id"`
+	Name        string            `form:"name" json:"name" validate:"required"`
+	Type        string            `form:"type" json:"type" validate:"required && in:shell,backup,cutoff,url,synctime"`
+	Time        string            `form:"time" json:"time" validate:"required && cron"`
+	Script      string            `form:"script" json:"script"`
+	SubType     string            `form:"sub_type" json:"sub_type"`
+	Dedup       bool              `form:"dedup" json:"dedup"`
+	TestRestore bool              `form:"test_restore" json:"test_restore"`
+	Storage     uint              `form:"storage" json:"storage"`
+	Targets     []string          `form:"targets" json:"targets"`
+	Keep        uint              `form:"keep" json:"keep"`
+	URL         string            `form:"url" json:"url"`
+	Method      string            `form:"method" json:"method"`
+	Headers     map[string]string `form:"headers" json:"headers"`
+	Body        string            `form:"body" json:"body"`
+	Timeout     uint              `form:"timeout" json:"timeout"`
+	Insecure    bool              `form:"insecure" json:"insecure"`
+	Retries     uint              `form:"retries" json:"retries"`
+	Overlap     string            `form:"overlap" json:"overlap" validate:"in:,allow,skip,queue"`
+	MaxRuntime  uint              `form:"max_runtime" json:"max_runtime"`
+	OnSuccess   []uint            `form:"on_success" json:"on_success" validate:"unique"`
+	OnFailure   []uint            `form:"on_failure" json:"on_failure" validate:"unique"`
 }
 
 type CronStatus struct {
 	ID     uint `form:"id" json:"id" validate:"required && exists:crons,id"`
 	Status bool `form:"status" json:"status"`
 }
+
+type CronRunList struct {
+	ID uint `json:"id" form:"id" uri:"id" validate:"required && exists:crons,id"`
+	Paginate
+}
+
+type CronRun struct {
+	ID  uint `json:"id" form:"id" uri:"id" validate:"required && exists:crons,id"`
+	Run uint `json:"run" form:"run" uri:"run" validate:"required && min:1"`
+}
+
+type CronRunSetting struct {
+	Days uint `form:"days" json:"days"`                      // 0 为不按时间清理
+	Keep uint `form:"keep" json:"keep" validate:"max:10000"` // 0 为不按条数清理
+}
@@ -0,0 +1,43 @@
+package request
+
+type DeploymentTarget struct {
+	Target   string `json:"target" form:"target" query:"target" validate:"required && in:project,website"`
+	TargetID uint   `json:"target_id" form:"target_id" query:"target_id" validate:"required && min:1"`
+}
+
+type DeploymentCreate struct {
+	Target   string   `json:"target" form:"target" validate:"required && in:project,website"`
+	TargetID uint     `json:"target_id" form:"target_id" validate:"required && min:1"`
+	Repo     string   `json:"repo" form:"repo" validate:"required"`
+	Branch   string   `json:"branch" form:"branch" validate:"required"`
+	Runtime  string   `json:"runtime" form:"runtime" validate:"in:,php,nodejs,go,java,python,dotnet"`
+	Version  string   `json:"version" form:"version" validate:"required_with:Runtime"`
+	Build    string   `json:"build" form:"build"`
+	Shared   []string `json:"shared" form:"shared" validate:"unique"`
+	Keep     uint     `json:"keep" form:"keep" validate:"required && min:1 && max:100"`
+	User     string   `json:"user" form:"user"`
+	Webhook  bool     `json:"webhook" form:"webhook"`
+}
+
+type DeploymentUpdate struct {
+	ID      uint     `json:"id" form:"id" uri:"id" validate:"required && exists:deployments,id"`
+	Repo    string   `json:"repo" form:"repo" validate:"required"`
+	Branch  string   `json:"branch" form:"branch" validate:"required"`
+	Runtime string   `json:"runtime" form:"runtime" validate:"in:,php,nodejs,go,java,python,dotnet"`
+	Version string   `json:"version" form:"version" validate:"required_with:Runtime"`
+	Build   string   `json:"build" form:"build"`
+	Shared  []string `json:"shared" form:"shared" validate:"unique"`
+	Keep    uint     `json:"keep" form:"keep" validate:"required && min:1 && max:100"`
+	User    string   `json:"user" form:"user"`
+	Webhook bool     `json:"webhook" form:"webhook"`
+}
+
+type DeploymentReleaseList struct {
+	ID uint `json:"id" form:"id" uri:"id" validate:"required && exists:deployments,id"`
+	Paginate
+}
+
+type DeploymentRollback struct {
+	ID      uint `json:"id" form:"id" uri:"id" validate:"required && exists:deployments,id"`
+	Release uint `json:"release" form:"release" validate:"required && min:1"`
+}
@@ -34,3 +34,11 @@ type FirewallForward struct {
 	TargetIP   string `json:"target_ip" validate:"required && ip"`
 	TargetPort uint   `json:"target_port" validate:"required && min:1 && max:65535"`
 }
+
+type FirewallGeoRule struct {
+	Country   string `json:"country" validate:"required"`
+	PortStart uint   `json:"port_start" validate:"min:0 && max:65535"` // 为 0 表示所有端口
+	PortEnd   uint   `json:"port_end" validate:"min:0 && max:65535"`
+	Protocol  string `json:"protocol" validate:"in:tcp,udp,tcp/udp"`
+	Strategy  string `json:"strategy" validate:"required && in:accept,drop,reject,only"` // only 表示仅允许该地区访问端口
+}
@@ -4,7 +4,7 @@ import "encoding/json"
 
 type NotifyChannelCreate struct {
 	Name    string          `json:"name" form:"name" validate:"required"`
-	Type    string          `json:"type" form:"type" validate:"required && in:smtp"`
+	Type    string          `json:"type" form:"type" validate:"required && in:smtp,webhook,telegram,dingtalk,feishu,wecom,slack,discord"`
 	Config  json.RawMessage `json:"config" form:"config"`
 	Enabled bool            `json:"enabled" form:"enabled"`
 }
@@ -12,7 +12,7 @@ type NotifyChannelCreate struct {
 type NotifyChannelUpdate struct {
 	ID      uint            `json:"id" form:"id" uri:"id" validate:"required && exists:notify_channels,id"`
 	Name    string          `json:"name" form:"name" validate:"required"`
-	Type    string          `json:"type" form:"type" validate:"required && in:smtp"`
+	Type    string          `json:"type" form:"type" validate:"required && in:smtp,webhook,telegram,dingtalk,feishu,wecom,slack,discord"`
 	Config  json.RawMessage `json:"config" form:"config"`
 	Enabled bool            `json:"enabled" form:"enabled"`
 }
@@ -0,0 +1,35 @@
+package request
+
+import "github.com/acepanel/panel/v3/pkg/types"
+
+type ProbeCreate struct {
+	Name     string            `form:"name" json:"name" validate:"required"`
+	Type     string            `form:"type" json:"type" validate:"required && in:http,tcp,ping"`
+	Target   string            `form:"target" json:"target" validate:"required"`
+	Interval uint              `form:"interval" json:"interval" validate:"required && min:1 && max:1440"`
+	Timeout  uint              `form:"timeout" json:"timeout" validate:"required && min:1 && max:60"`
+	Config   types.ProbeConfig `form:"config" json:"config"`
+	Enabled  bool              `form:"enabled" json:"enabled"`
+}
+
+type ProbeUpdate struct {
+	ID       uint              `form:"id" json:"id" uri:"id" validate:"required && exists:probes,id"`
+	Name     string            `form:"name" json:"name" validate:"required"`
+	Type     string            `form:"type" json:"type" validate:"required && in:http,tcp,ping"`
+	Target   string            `form:"target" json:"target" validate:"required"`
+	Interval uint              `form:"interval" json:"interval" validate:"required && min:1 && max:1440"`
+	Timeout  uint              `form:"timeout" json:"timeout" validate:"required && min:1 && max:60"`
+	Config   types.ProbeConfig `form:"config" json:"config"`
+	Enabled  bool              `form:"enabled" json:"enabled"`
+}
+
+type ProbeStats struct {
+	ID    uint  `json:"id" form:"id" uri:"id" validate:"required && exists:probes,id"`
+	Start int64 `json:"start" form:"start" query:"start"`
+	End   int64 `json:"end" form:"end" query:"end"`
+}
+
+type ProbeRecords struct {
+	ID uint `json:"id" form:"id" uri:"id" validate:"required && exists:probes,id"`
+	Paginate
+}
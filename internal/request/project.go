@@ -48,4 +48,6 @@ type ProjectUpdate struct {
 	ProtectSystem   string   `form:"protect_system" json:"protect_system"`
 	ReadWritePaths  []string `form:"read_write_paths" json:"read_write_paths"`
 	ReadOnlyPaths   []string `form:"read_only_paths" json:"read_only_paths"`
+
+	BlueGreen types.ProjectBlueGreen `form:"blue_green" json:"blue_green"`
 }
@@ -0,0 +1,14 @@
+package request
+
+type RoleCreate struct {
+	Name        string            `json:"name" validate:"required && not_exists:roles,name"`
+	Description string            `json:"description"`
+	Permissions map[string]string `json:"permissions"` // 权限标签 => none/read/write
+}
+
+type RoleUpdate struct {
+	ID          uint              `json:"id" uri:"id" validate:"required && exists:roles,id"`
+	Name        string            `json:"name" validate:"required"`
+	Description string            `json:"description"`
+	Permissions map[string]string `json:"permissions"`
+}
@@ -27,6 +27,7 @@ type SettingPanel struct {
 	IPDBPath      string   `json:"ipdb_path"`                                      // IPDB 地理位置库路径
 	Port          uint     `json:"port" validate:"required && min:1 && max:65535"` // 面板端口
 	TLS           string   `json:"tls" validate:"in:off,acme,self-signed,custom"`  // 面板 TLS: off, acme, self-signed, custom
+	Firewall      string   `json:"firewall" validate:"in:firewalld,ufw,nftables"`  // 防火墙后端，为空时自动检测
 	PublicIP      []string `json:"public_ip"`
 	Cert          string   `json:"cert"`
 	Key           string   `json:"key"`
@@ -0,0 +1,15 @@
+package request
+
+type TaskPriority struct {
+	ID       uint `json:"id" form:"id" uri:"id" validate:"required && exists:tasks,id"`
+	Priority int  `json:"priority" form:"priority" validate:"min:-100 && max:100"`
+}
+
+// TaskCancelWaiting 取消等待中的任务，Resource 为空时取消全部
+type TaskCancelWaiting struct {
+	Resource string `json:"resource" form:"resource"`
+}
+
+type TaskSetting struct {
+	Workers uint `json:"workers" form:"workers" validate:"required && min:1 && max:16"`
+}
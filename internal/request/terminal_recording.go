@@ -0,0 +1,14 @@
+package request
+
+type TerminalRecordingList struct {
+	Type string `json:"type" form:"type" query:"type" validate:"in:,pty,ssh,container"`
+	Paginate
+}
+
+// TerminalRecordingSetting 终端录像设置
+type TerminalRecordingSetting struct {
+	Enabled   bool `json:"enabled" form:"enabled"`
+	Input     bool `json:"input" form:"input"`
+	Mandatory bool `json:"mandatory" form:"mandatory"`
+	Days      uint `json:"days" form:"days" validate:"max:3650"`
+}
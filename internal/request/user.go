@@ -17,9 +17,17 @@ type UserIsTwoFA struct {
 }
 
 type UserCreate struct {
-	Username string `json:"username" validate:"required && not_exists:users,username && regex:\"^[a-zA-Z0-9_-]+$\""`
-	Password string `json:"password" validate:"required && password"`
-	Email    string `json:"email" validate:"required && email"`
+	Username string            `json:"username" validate:"required && not_exists:users,username && regex:\"^[a-zA-Z0-9_-]+$\""`
+	Password string            `json:"password" validate:"required && password"`
+	Email    string            `json:"email" validate:"required && email"`
+	RoleID   uint              `json:"role_id" validate:"required && exists:roles,id"`
+	Scopes   map[string][]uint `json:"scopes"` // 资源范围，为空表示不限
+}
+
+type UserUpdateRole struct {
+	ID     uint              `json:"id" uri:"id" validate:"required && exists:users,id"`
+	RoleID uint              `json:"role_id" validate:"required && exists:roles,id"`
+	Scopes map[string][]uint `json:"scopes"`
 }
 
 type UserUpdateUsername struct {
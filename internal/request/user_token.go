@@ -6,13 +6,19 @@ type UserTokenList struct {
 }
 
 type UserTokenCreate struct {
-	UserID    uint     `json:"user_id" validate:"required && exists:users,id"`
-	IPs       []string `json:"ips" validate:"unique && dive && ipcidr"`
-	ExpiredAt int64    `json:"expired_at" validate:"required"`
+	UserID    uint              `json:"user_id" validate:"required && exists:users,id"`
+	IPs       []string          `json:"ips" validate:"unique && dive && ipcidr"`
+	Scopes    map[string]string `json:"scopes"`                      // 权限标签 => none/read/write
+	Endpoints []string          `json:"endpoints" validate:"unique"` // 端点白名单，如 "GET /api/cert/*"
+	RateLimit uint              `json:"rate_limit"`
+	ExpiredAt int64             `json:"expired_at" validate:"required"`
 }
 
 type UserTokenUpdate struct {
-	ID        uint     `uri:"id"`
-	IPs       []string `json:"ips" validate:"unique && dive && ipcidr"`
-	ExpiredAt int64    `json:"expired_at" validate:"required"`
+	ID        uint              `uri:"id"`
+	IPs       []string          `json:"ips" validate:"unique && dive && ipcidr"`
+	Scopes    map[string]string `json:"scopes"`                      // 权限标签 => none/read/write
+	Endpoints []string          `json:"endpoints" validate:"unique"` // 端点白名单，如 "GET /api/cert/*"
+	RateLimit uint              `json:"rate_limit"`
+	ExpiredAt int64             `json:"expired_at" validate:"required"`
 }
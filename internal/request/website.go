@@ -76,6 +76,7 @@ type WebsiteUpdate struct {
 	RateLimit   *types.RateLimit  `json:"rate_limit"`   // 限流限速配置
 	RealIP      *types.RealIP     `json:"real_ip"`      // 真实 IP 配置
 	BasicAuth   map[string]string `json:"basic_auth"`   // 基本认证配置
+	WAF         *types.WAF        `json:"waf"`          // Web 应用防火墙配置
 
 	// 自定义配置
 	CustomConfigs []WebsiteCustomConfig `json:"custom_configs"`
@@ -120,3 +121,19 @@ type WebsiteObtainCert struct {
 	ID    uint `json:"id" form:"id" uri:"id" validate:"required && exists:websites,id"`
 	DNSID uint `json:"dns_id" form:"dns_id"`
 }
+
+type WebsiteRevisionList struct {
+	ID uint `json:"id" form:"id" uri:"id" validate:"required && exists:websites,id"`
+	Paginate
+}
+
+type WebsiteRevision struct {
+	ID       uint `json:"id" form:"id" uri:"id" validate:"required && exists:websites,id"`
+	Revision uint `json:"revision" form:"revision" uri:"revision" validate:"required && min:1"`
+}
+
+type WebsiteRevisionDiff struct {
+	ID   uint `json:"id" form:"id" uri:"id" validate:"required && exists:websites,id"`
+	From uint `json:"from" form:"from" query:"from" validate:"required && min:1"`
+	To   uint `json:"to" form:"to" query:"to"` // 0 为当前配置
+}
@@ -99,3 +99,9 @@ type WebsiteStatErrors struct {
 	WebsiteStatPaginate
 	Status int `json:"status" form:"status" query:"status"`
 }
+
+// WebsiteStatWAF WAF 拦截日志查询参数
+type WebsiteStatWAF struct {
+	WebsiteStatPaginate
+	Rule string `json:"rule" form:"rule" query:"rule"`
+}
@@ -33,6 +33,6 @@ func AppRoutes(appService *service.AppService) Endpoints {
 		{Method: http.MethodPost, Path: "/api/app/custom", Handler: app.SaveCustom,
 			Summary: "保存自定义编译参数", Tags: []string{"应用"}, Request: request.AppCustomSave{}},
 		{Method: http.MethodGet, Path: "/api/app/update_cache", Handler: app.UpdateCache,
-			Summary: "更新应用缓存", Tags: []string{"应用"}},
+			Summary: "更新应用缓存", Tags: []string{"应用"}, Write: true},
 	}
 }
@@ -0,0 +1,24 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/internal/service"
+	"github.com/acepanel/panel/v3/pkg/audit"
+)
+
+// AuditRoutes 审计日志路由
+func AuditRoutes(auditService *service.AuditService) Endpoints {
+	svc := auditService
+
+	return Endpoints{
+		{Method: http.MethodGet, Path: "/api/log/audit/verify", Handler: svc.Verify, Summary: "校验审计日志哈希链", Tags: []string{"日志"}, Response: service.Envelope[audit.VerifyResult]{}},
+		{Method: http.MethodGet, Path: "/api/log/audit/export", Handler: svc.Export, Summary: "导出审计日志", Tags: []string{"日志"}, Request: request.AuditExport{}},
+		{Method: http.MethodGet, Path: "/api/log/audit/setting", Handler: svc.GetSetting, Summary: "获取审计日志设置", Tags: []string{"日志"}, Response: service.Envelope[biz.AuditSetting]{}, Admin: true},
+		{Method: http.MethodPost, Path: "/api/log/audit/setting", Handler: svc.SaveSetting, Summary: "保存审计日志设置", Tags: []string{"日志"}, Request: request.AuditSetting{}, Admin: true},
+		{Method: http.MethodGet, Path: "/api/log/audit/forward", Handler: svc.GetForward, Summary: "获取审计日志转发设置", Tags: []string{"日志"}, Admin: true},
+		{Method: http.MethodPost, Path: "/api/log/audit/forward", Handler: svc.SaveForward, Summary: "保存审计日志转发设置", Tags: []string{"日志"}, Request: request.AuditForward{}, Admin: true},
+	}
+}
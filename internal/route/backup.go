@@ -24,5 +24,7 @@ func BackupRoutes(backupService *service.BackupService) Endpoints {
 			Request: request.BackupFile{}},
 		{Method: http.MethodPost, Path: "/api/backup/{type}/restore", Handler: backup.Restore, Summary: "恢复备份", Tags: []string{"备份"},
 			Request: request.BackupRestore{}},
+		{Method: http.MethodPost, Path: "/api/backup/{type}/verify", Handler: backup.Verify, Summary: "校验备份", Tags: []string{"备份"},
+			Request: request.BackupVerify{}},
 	}
 }
@@ -10,9 +10,11 @@ import (
 )
 
 // CertRoutes 证书、DNS、账户路由
-func CertRoutes(certAccountService *service.CertAccountService, certDNSService *service.CertDNSService, certService *service.CertService) Endpoints {
+func CertRoutes(certAccountService *service.CertAccountService, certDNSService *service.CertDNSService, certDeployService *service.CertDeployService, certMonitorService *service.CertMonitorService, certService *service.CertService) Endpoints {
 	cert := certService
 	certDNS := certDNSService
+	certDeploy := certDeployService
+	certMonitor := certMonitorService
 	certAccount := certAccountService
 
 	return Endpoints{
@@ -41,6 +43,34 @@ func CertRoutes(certAccountService *service.CertAccountService, certDNSService *
 			Request: request.ID{}},
 		{Method: http.MethodPost, Path: "/api/cert/cert/{id}/deploy", Handler: cert.Deploy, Summary: "部署证书", Tags: []string{"证书"},
 			Request: request.CertDeploy{}},
+		{Method: http.MethodGet, Path: "/api/cert/cert/{id}/targets", Handler: certDeploy.List, Summary: "证书部署目标列表", Tags: []string{"证书"},
+			Request: request.ID{}, Response: service.Envelope[[]*biz.CertDeployTarget]{}},
+		// 部署目标
+		{Method: http.MethodPost, Path: "/api/cert/target", Handler: certDeploy.Create, Summary: "创建部署目标", Tags: []string{"证书"},
+			Request: request.CertDeployTargetCreate{}, Response: service.Envelope[biz.CertDeployTarget]{}},
+		{Method: http.MethodPut, Path: "/api/cert/target/{id}", Handler: certDeploy.Update, Summary: "更新部署目标", Tags: []string{"证书"},
+			Request: request.CertDeployTargetUpdate{}},
+		{Method: http.MethodDelete, Path: "/api/cert/target/{id}", Handler: certDeploy.Delete, Summary: "删除部署目标", Tags: []string{"证书"},
+			Request: request.ID{}},
+		{Method: http.MethodPost, Path: "/api/cert/target/{id}/run", Handler: certDeploy.Run, Summary: "执行部署", Tags: []string{"证书"},
+			Request: request.ID{}},
+		{Method: http.MethodGet, Path: "/api/cert/target/{id}/logs", Handler: certDeploy.Logs, Summary: "部署历史", Tags: []string{"证书"},
+			Request: request.CertDeployTargetLogs{}, Response: service.Envelope[service.Page[*biz.CertDeployLog]]{}},
+		// 外部证书监控
+		{Method: http.MethodGet, Path: "/api/cert/monitor", Handler: certMonitor.List, Summary: "证书监控列表", Tags: []string{"证书"},
+			Request: request.Paginate{}, Response: service.Envelope[service.Page[*biz.CertMonitor]]{}},
+		{Method: http.MethodPost, Path: "/api/cert/monitor", Handler: certMonitor.Create, Summary: "创建证书监控", Tags: []string{"证书"},
+			Request: request.CertMonitorCreate{}, Response: service.Envelope[biz.CertMonitor]{}},
+		{Method: http.MethodGet, Path: "/api/cert/monitor/{id}", Handler: certMonitor.Get, Summary: "获取证书监控", Tags: []string{"证书"},
+			Request: request.ID{}, Response: service.Envelope[biz.CertMonitor]{}},
+		{Method: http.MethodPut, Path: "/api/cert/monitor/{id}", Handler: certMonitor.Update, Summary: "更新证书监控", Tags: []string{"证书"},
+			Request: request.CertMonitorUpdate{}},
+		{Method: http.MethodDelete, Path: "/api/cert/monitor/{id}", Handler: certMonitor.Delete, Summary: "删除证书监控", Tags: []string{"证书"},
+			Request: request.ID{}},
+		{Method: http.MethodPost, Path: "/api/cert/monitor/{id}/check", Handler: certMonitor.Check, Summary: "立即检查证书", Tags: []string{"证书"},
+			Request: request.ID{}, Response: service.Envelope[biz.CertMonitor]{}},
+		{Method: http.MethodGet, Path: "/api/cert/monitor/{id}/checks", Handler: certMonitor.Checks, Summary: "证书检查历史", Tags: []string{"证书"},
+			Request: request.CertMonitorChecks{}, Response: service.Envelope[service.Page[*biz.CertMonitorCheck]]{}},
 		// DNS
 		{Method: http.MethodGet, Path: "/api/cert/dns", Handler: certDNS.List, Summary: "DNS 列表", Tags: []string{"证书"},
 			Request: request.Paginate{}, Response: service.Envelope[service.Page[*biz.CertDNS]]{}},
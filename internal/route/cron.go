@@ -31,5 +31,23 @@ func CronRoutes(cronService *service.CronService) Endpoints {
 		{Method: http.MethodPost, Path: "/api/cron/{id}/status", Handler: svc.Status,
 			Summary: "设置计划任务状态", Tags: []string{"计划任务"},
 			Request: request.CronStatus{}},
+		{Method: http.MethodPost, Path: "/api/cron/{id}/run", Handler: svc.Run,
+			Summary: "立即执行计划任务", Tags: []string{"计划任务"},
+			Request: request.ID{}, Response: service.Envelope[biz.Task]{}},
+		{Method: http.MethodGet, Path: "/api/cron/{id}/runs", Handler: svc.ListRuns,
+			Summary: "计划任务执行记录", Tags: []string{"计划任务"},
+			Request: request.CronRunList{}, Response: service.Envelope[service.Page[*biz.CronRun]]{}},
+		{Method: http.MethodGet, Path: "/api/cron/{id}/runs/stats", Handler: svc.RunStats,
+			Summary: "计划任务执行统计", Tags: []string{"计划任务"},
+			Request: request.ID{}, Response: service.Envelope[biz.CronRunStats]{}},
+		{Method: http.MethodGet, Path: "/api/cron/{id}/runs/{run}/output", Handler: svc.RunOutput,
+			Summary: "计划任务单次执行输出", Tags: []string{"计划任务"},
+			Request: request.CronRun{}, Response: service.Envelope[string]{}},
+		{Method: http.MethodGet, Path: "/api/cron/run_setting", Handler: svc.GetRunSetting,
+			Summary: "获取执行记录保留设置", Tags: []string{"计划任务"},
+			Response: service.Envelope[biz.CronRunSetting]{}},
+		{Method: http.MethodPost, Path: "/api/cron/run_setting", Handler: svc.SaveRunSetting,
+			Summary: "保存执行记录保留设置", Tags: []string{"计划任务"},
+			Request: request.CronRunSetting{}},
 	}
 }
@@ -17,7 +17,7 @@ func DatabaseUserRoutes(databaseUserService *service.DatabaseUserService) Endpoi
 			Summary: "获取用户列表", Tags: []string{"数据库用户"},
 			Request: request.DatabaseList{}, Response: service.Envelope[service.Page[*biz.DatabaseUser]]{}},
 		{Method: http.MethodPost, Path: "/api/database_user", Handler: svc.Create,
-			Summary: "创建用户", Tags: []string{"数据库用户"},
+			Summary: "创建用户", Tags: []string{"数据库用户"}, Scope: biz.ScopeDatabaseServer, ScopeKey: "server_id",
 			Request: request.DatabaseUserCreate{}},
 		{Method: http.MethodGet, Path: "/api/database_user/{id}", Handler: svc.Get,
 			Summary: "获取用户", Tags: []string{"数据库用户"},
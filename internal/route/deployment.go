@@ -0,0 +1,44 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/internal/service"
+)
+
+// DeploymentRoutes 项目与网站 Git 部署路由，资源范围在业务层按部署目标校验
+func DeploymentRoutes(deploymentService *service.DeploymentService) Endpoints {
+	svc := deploymentService
+
+	return Endpoints{
+		{Method: http.MethodGet, Path: "/api/deployment", Handler: svc.GetByTarget,
+			Summary: "获取项目或网站的部署配置", Tags: []string{"部署"},
+			Request: request.DeploymentTarget{}, Response: service.Envelope[biz.Deployment]{}},
+		{Method: http.MethodPost, Path: "/api/deployment", Handler: svc.Create,
+			Summary: "创建部署配置", Tags: []string{"部署"},
+			Request: request.DeploymentCreate{}, Response: service.Envelope[biz.Deployment]{}},
+		{Method: http.MethodGet, Path: "/api/deployment/{id}", Handler: svc.Get,
+			Summary: "获取部署配置", Tags: []string{"部署"},
+			Request: request.ID{}, Response: service.Envelope[biz.Deployment]{}},
+		{Method: http.MethodPut, Path: "/api/deployment/{id}", Handler: svc.Update,
+			Summary: "更新部署配置", Tags: []string{"部署"},
+			Request: request.DeploymentUpdate{}},
+		{Method: http.MethodDelete, Path: "/api/deployment/{id}", Handler: svc.Delete,
+			Summary: "删除部署配置", Tags: []string{"部署"},
+			Request: request.ID{}},
+		{Method: http.MethodPost, Path: "/api/deployment/{id}/key", Handler: svc.ResetKey,
+			Summary: "重新生成部署密钥", Tags: []string{"部署"},
+			Request: request.ID{}, Response: service.Envelope[biz.Deployment]{}},
+		{Method: http.MethodPost, Path: "/api/deployment/{id}/deploy", Handler: svc.Deploy,
+			Summary: "立即部署", Tags: []string{"部署"},
+			Request: request.ID{}, Response: service.Envelope[biz.Task]{}},
+		{Method: http.MethodGet, Path: "/api/deployment/{id}/releases", Handler: svc.ListReleases,
+			Summary: "部署版本列表", Tags: []string{"部署"},
+			Request: request.DeploymentReleaseList{}, Response: service.Envelope[service.Page[*biz.DeploymentRelease]]{}},
+		{Method: http.MethodPost, Path: "/api/deployment/{id}/rollback", Handler: svc.Rollback,
+			Summary: "回滚到历史版本", Tags: []string{"部署"},
+			Request: request.DeploymentRollback{}},
+	}
+}
@@ -23,6 +23,6 @@ func FileShareRoutes(fileShareService *service.FileShareService) Endpoints {
 			Summary: "取消文件分享", Tags: []string{"文件"},
 			Request: request.ID{}},
 		// 顶层免登录下载
-		{Method: http.MethodGet, Path: "/download/{token}", Handler: svc.Download},
+		{Method: http.MethodGet, Path: "/download/{token}", Handler: svc.Download, Public: true},
 	}
 }
@@ -45,6 +45,14 @@ func FirewallRoutes(firewallScanService *service.FirewallScanService, firewallSe
 		{Method: http.MethodDelete, Path: "/api/firewall/ip_rule", Handler: svc.DeleteIPRule,
 			Summary: "删除 IP 规则", Tags: []string{"防火墙"},
 			Request: request.FirewallIPRule{}},
+		{Method: http.MethodGet, Path: "/api/firewall/geo_rule", Handler: svc.GetGeoRules,
+			Summary: "获取地区规则", Tags: []string{"防火墙"}},
+		{Method: http.MethodPost, Path: "/api/firewall/geo_rule", Handler: svc.CreateGeoRule,
+			Summary: "创建地区规则", Tags: []string{"防火墙"},
+			Request: request.FirewallGeoRule{}},
+		{Method: http.MethodDelete, Path: "/api/firewall/geo_rule/{id}", Handler: svc.DeleteGeoRule,
+			Summary: "删除地区规则", Tags: []string{"防火墙"},
+			Request: request.ID{}},
 		{Method: http.MethodGet, Path: "/api/firewall/forward", Handler: svc.GetForwards,
 			Summary: "获取端口转发", Tags: []string{"防火墙"}},
 		{Method: http.MethodPost, Path: "/api/firewall/forward", Handler: svc.CreateForward,
@@ -0,0 +1,17 @@
+package route
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/acepanel/panel/v3/internal/service"
+)
+
+// MetricsRoutes 指标导出路由，使用独立令牌鉴权，不经过安全入口
+func MetricsRoutes(metricsService *service.MetricsService) Endpoints {
+	svc := metricsService
+
+	return Endpoints{
+		{Method: http.MethodGet, Path: "/metrics", Handler: svc.Metrics, Summary: "Prometheus 指标", Tags: []string{"监控"}, Public: true, Throttle: &ThrottleRule{Tokens: 60, Interval: time.Minute}},
+	}
+}
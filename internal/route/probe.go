@@ -0,0 +1,25 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/internal/service"
+)
+
+// ProbeRoutes 可用性探测路由
+func ProbeRoutes(probeService *service.ProbeService) Endpoints {
+	svc := probeService
+
+	return Endpoints{
+		{Method: http.MethodGet, Path: "/api/monitor/probe", Handler: svc.List, Summary: "可用性探测列表", Tags: []string{"监控"}, Request: request.Paginate{}, Response: service.Envelope[service.Page[*biz.Probe]]{}},
+		{Method: http.MethodPost, Path: "/api/monitor/probe", Handler: svc.Create, Summary: "创建可用性探测", Tags: []string{"监控"}, Request: request.ProbeCreate{}, Response: service.Envelope[biz.Probe]{}},
+		{Method: http.MethodGet, Path: "/api/monitor/probe/{id}", Handler: svc.Get, Summary: "获取可用性探测", Tags: []string{"监控"}, Request: request.ID{}, Response: service.Envelope[biz.Probe]{}},
+		{Method: http.MethodPut, Path: "/api/monitor/probe/{id}", Handler: svc.Update, Summary: "更新可用性探测", Tags: []string{"监控"}, Request: request.ProbeUpdate{}},
+		{Method: http.MethodDelete, Path: "/api/monitor/probe/{id}", Handler: svc.Delete, Summary: "删除可用性探测", Tags: []string{"监控"}, Request: request.ID{}},
+		{Method: http.MethodPost, Path: "/api/monitor/probe/{id}/check", Handler: svc.Check, Summary: "立即探测", Tags: []string{"监控"}, Request: request.ID{}, Response: service.Envelope[biz.Probe]{}},
+		{Method: http.MethodGet, Path: "/api/monitor/probe/{id}/stats", Handler: svc.Stats, Summary: "可用性探测统计", Tags: []string{"监控"}, Request: request.ProbeStats{}, Response: service.Envelope[biz.ProbeStats]{}},
+		{Method: http.MethodGet, Path: "/api/monitor/probe/{id}/records", Handler: svc.Records, Summary: "可用性探测记录", Tags: []string{"监控"}, Request: request.ProbeRecords{}, Response: service.Envelope[service.Page[*biz.ProbeRecord]]{}},
+	}
+}
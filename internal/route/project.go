@@ -3,6 +3,7 @@ package route
 import (
 	"net/http"
 
+	"github.com/acepanel/panel/v3/internal/biz"
 	"github.com/acepanel/panel/v3/internal/request"
 	"github.com/acepanel/panel/v3/internal/service"
 	"github.com/acepanel/panel/v3/pkg/types"
@@ -14,17 +15,20 @@ func ProjectRoutes(projectService *service.ProjectService) Endpoints {
 
 	return Endpoints{
 		{Method: http.MethodGet, Path: "/api/project", Handler: svc.List,
-			Summary: "项目列表", Tags: []string{"项目"},
+			Summary: "项目列表", Tags: []string{"项目"}, Scope: biz.ScopeProject, ScopeKey: "id",
 			Request: request.Paginate{}, Response: service.Envelope[service.Page[*types.ProjectDetail]]{}},
 		{Method: http.MethodPost, Path: "/api/project", Handler: svc.Create,
-			Summary: "创建项目", Tags: []string{"项目"},
+			Summary: "创建项目", Tags: []string{"项目"}, Scope: biz.ScopeProject, ScopeKey: "id",
 			Request: request.ProjectCreate{}, Response: service.Envelope[types.ProjectDetail]{}},
 		{Method: http.MethodGet, Path: "/api/project/{id}", Handler: svc.Get,
-			Summary: "获取项目详情", Tags: []string{"项目"},
+			Summary: "获取项目详情", Tags: []string{"项目"}, Scope: biz.ScopeProject, ScopeKey: "id",
 			Request: request.ID{}, Response: service.Envelope[types.ProjectDetail]{}},
 		{Method: http.MethodPut, Path: "/api/project/{id}", Handler: svc.Update,
-			Summary: "更新项目", Tags: []string{"项目"}, Request: request.ProjectUpdate{}},
+			Summary: "更新项目", Tags: []string{"项目"}, Scope: biz.ScopeProject, ScopeKey: "id", Request: request.ProjectUpdate{}},
 		{Method: http.MethodDelete, Path: "/api/project/{id}", Handler: svc.Delete,
-			Summary: "删除项目", Tags: []string{"项目"}, Request: request.ID{}},
+			Summary: "删除项目", Tags: []string{"项目"}, Scope: biz.ScopeProject, ScopeKey: "id", Request: request.ID{}},
+		{Method: http.MethodPost, Path: "/api/project/{id}/restart", Handler: svc.Restart,
+			Summary: "蓝绿重启项目", Tags: []string{"项目"}, Scope: biz.ScopeProject, ScopeKey: "id",
+			Request: request.ID{}, Response: service.Envelope[biz.Task]{}},
 	}
 }
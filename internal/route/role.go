@@ -0,0 +1,24 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/internal/service"
+)
+
+// RoleRoutes 角色路由
+func RoleRoutes(roleService *service.RoleService) Endpoints {
+	svc := roleService
+
+	return Endpoints{
+		{Method: http.MethodGet, Path: "/api/roles", Handler: svc.List, Summary: "角色列表", Tags: []string{"角色"}, Request: request.Paginate{}, Response: service.Envelope[service.Page[*biz.Role]]{}},
+		{Method: http.MethodGet, Path: "/api/roles/all", Handler: svc.All, Summary: "全部角色", Tags: []string{"角色"}, Response: service.Envelope[[]*biz.Role]{}},
+		{Method: http.MethodGet, Path: "/api/roles/permissions", Handler: svc.Permissions, Summary: "可分配的权限", Tags: []string{"角色"}},
+		{Method: http.MethodPost, Path: "/api/roles", Handler: svc.Create, Summary: "创建角色", Tags: []string{"角色"}, Request: request.RoleCreate{}, Response: service.Envelope[biz.Role]{}},
+		{Method: http.MethodGet, Path: "/api/roles/{id}", Handler: svc.Get, Summary: "获取角色", Tags: []string{"角色"}, Request: request.ID{}, Response: service.Envelope[biz.Role]{}},
+		{Method: http.MethodPut, Path: "/api/roles/{id}", Handler: svc.Update, Summary: "更新角色", Tags: []string{"角色"}, Request: request.RoleUpdate{}},
+		{Method: http.MethodDelete, Path: "/api/roles/{id}", Handler: svc.Delete, Summary: "删除角色", Tags: []string{"角色"}, Request: request.ID{}},
+	}
+}
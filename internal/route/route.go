@@ -20,11 +20,14 @@ var ProviderSet = wire.NewSet(wire.Struct(new(Services), "*"), NewEndpoints)
 type Services struct {
 	Alert                 *service.AlertService
 	App                   *service.AppService
+	Audit                 *service.AuditService
 	Backup                *service.BackupService
 	BackupStorage         *service.BackupStorageService
 	Cert                  *service.CertService
 	CertAccount           *service.CertAccountService
 	CertDNS               *service.CertDNSService
+	CertDeploy            *service.CertDeployService
+	CertMonitor           *service.CertMonitorService
 	Container             *service.ContainerService
 	ContainerCompose      *service.ContainerComposeService
 	ContainerImage        *service.ContainerImageService
@@ -36,6 +39,7 @@ type Services struct {
 	DatabaseRedis         *service.DatabaseRedisService
 	DatabaseServer        *service.DatabaseServerService
 	DatabaseUser          *service.DatabaseUserService
+	Deployment            *service.DeploymentService
 	Environment           *service.EnvironmentService
 	EnvironmentDotnet     *service.EnvironmentDotnetService
 	EnvironmentGo         *service.EnvironmentGoService
@@ -49,10 +53,13 @@ type Services struct {
 	FirewallScan          *service.FirewallScanService
 	Home                  *service.HomeService
 	Log                   *service.LogService
+	Metrics               *service.MetricsService
 	Monitor               *service.MonitorService
 	Notify                *service.NotifyService
+	Probe                 *service.ProbeService
 	Process               *service.ProcessService
 	Project               *service.ProjectService
+	Role                  *service.RoleService
 	Safe                  *service.SafeService
 	Setting               *service.SettingService
 	SSH                   *service.SSHService
@@ -60,6 +67,7 @@ type Services struct {
 	Tamper                *service.TamperService
 	Task                  *service.TaskService
 	Template              *service.TemplateService
+	TerminalRecording     *service.TerminalRecordingService
 	ToolboxBenchmark      *service.ToolboxBenchmarkService
 	ToolboxDisk           *service.ToolboxDiskService
 	ToolboxLog            *service.ToolboxLogService
@@ -81,18 +89,20 @@ func NewEndpoints(s *Services) []Endpoints {
 		UserRoutes(s.UserPasskey, s.User),
 		UserPasskeyRoutes(s.UserPasskey),
 		UserTokenRoutes(s.UserToken),
+		RoleRoutes(s.Role),
 		SafeRoutes(s.Safe),
 		TaskRoutes(s.Task),
 		HomeRoutes(s.Home),
 		WebsiteRoutes(s.Website),
 		WebsiteStatRoutes(s.WebsiteStat),
 		ProjectRoutes(s.Project),
+		DeploymentRoutes(s.Deployment),
 		DatabaseRoutes(s.Database),
 		DatabaseServerRoutes(s.DatabaseServer),
 		DatabaseUserRoutes(s.DatabaseUser),
 		DatabaseRedisRoutes(s.DatabaseRedis),
 		DatabaseElasticsearchRoutes(s.DatabaseElasticsearch),
-		CertRoutes(s.CertAccount, s.CertDNS, s.Cert),
+		CertRoutes(s.CertAccount, s.CertDNS, s.CertDeploy, s.CertMonitor, s.Cert),
 		BackupRoutes(s.Backup),
 		BackupStorageRoutes(s.BackupStorage),
 		AppRoutes(s.App),
@@ -107,7 +117,11 @@ func NewEndpoints(s *Services) []Endpoints {
 		SystemctlRoutes(s.Systemctl),
 		SettingRoutes(s.Setting),
 		LogRoutes(s.Log),
+		TerminalRecordingRoutes(s.TerminalRecording),
+		AuditRoutes(s.Audit),
 		MonitorRoutes(s.Monitor),
+		ProbeRoutes(s.Probe),
+		MetricsRoutes(s.Metrics),
 		WebHookRoutes(s.WebHook),
 		NotifyRoutes(s.Notify),
 		AlertRoutes(s.Alert),
@@ -143,22 +157,64 @@ type Endpoint struct {
 	Status   int
 	Public   bool          // 登录白名单（MustLogin 放行）
 	Throttle *ThrottleRule // 非 nil 时端点级限流
+	// 权限声明，非 Public 端点按 Tags 与用户角色校验
+	Write    bool   // GET 但有副作用（WebSocket、刷新缓存等），按写权限检查
+	Self     bool   // 任何已登录用户均可访问，如获取自身信息
+	Owner    bool   // 路径参数 id 为当前用户时放行，如修改自己的密码
+	Scope    string // 资源范围类型，见 biz.ScopeKinds
+	ScopeKey string // 资源 ID 参数名
+	// Credential 签发或变更登录凭据（密码、两步验证、通行密钥、令牌），受限令牌一律不可访问
+	Credential bool
+	// Admin 仅管理员可访问，用于关闭审计、读取他人输入等不应随标签授予的操作，受限令牌同样不可访问
+	Admin bool
 }
 
 // Endpoints 是一个模块对 HTTP 路由的贡献。
 type Endpoints []Endpoint
 
 // HTTP 将全部路由贡献注册到 r。
-func HTTP(conf *config.Config, groups []Endpoints, r chi.Router) {
+func HTTP(conf *config.Config, mws *middleware.Middlewares, groups []Endpoints, r chi.Router) {
 	for _, endpoints := range groups {
 		for _, e := range endpoints {
+			var chain []func(http.Handler) http.Handler
 			if e.Throttle != nil {
-				r.With(middleware.Throttle(conf.HTTP.IPHeader, e.Throttle.Tokens, e.Throttle.Interval)).Method(e.Method, e.Path, e.Handler)
-			} else {
-				r.Method(e.Method, e.Path, e.Handler)
+				chain = append(chain, middleware.Throttle(conf.HTTP.IPHeader, e.Throttle.Tokens, e.Throttle.Interval))
 			}
+			if !e.Public {
+				chain = append(chain, mws.Permission(e.Permission()))
+			}
+			r.With(chain...).Method(e.Method, e.Path, e.Handler)
+		}
+	}
+}
+
+// Permission 端点的权限声明。
+func (e Endpoint) Permission() middleware.Permission {
+	return middleware.Permission{
+		Tags:       e.Tags,
+		Write:      e.Write,
+		Self:       e.Self,
+		Owner:      e.Owner,
+		Scope:      e.Scope,
+		ScopeKey:   e.ScopeKey,
+		Credential: e.Credential,
+		Admin:      e.Admin,
+	}
+}
+
+// Permissions 以 "METHOD 路径" 为键收集非 Public 端点的权限声明，供 MustLogin 校验 API 令牌。
+func Permissions(groups []Endpoints) map[string]middleware.Permission {
+	permissions := make(map[string]middleware.Permission)
+	for _, endpoints := range groups {
+		for _, e := range endpoints {
+			if e.Public {
+				continue
+			}
+			permissions[e.Method+" "+e.Path] = e.Permission()
 		}
 	}
+
+	return permissions
 }
 
 // PublicPaths 收集去重后的登录白名单路径，供 MustLogin 中间件放行。
@@ -0,0 +1,124 @@
+package route
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+)
+
+// TestEndpointPermissions 非白名单端点必须声明已知的权限标签，否则除管理员外无人可访问
+func TestEndpointPermissions(t *testing.T) {
+	for _, endpoints := range NewEndpoints(&Services{}) {
+		for _, e := range endpoints {
+			if e.Public || e.Self {
+				continue
+			}
+			if len(e.Tags) == 0 {
+				t.Errorf("%s %s: missing permission tags", e.Method, e.Path)
+			}
+			for _, tag := range e.Tags {
+				if !slices.Contains(biz.PermissionTags, tag) {
+					t.Errorf("%s %s: unknown permission tag %q", e.Method, e.Path, tag)
+				}
+			}
+			if e.Scope != "" && !slices.Contains(biz.ScopeKinds, e.Scope) {
+				t.Errorf("%s %s: unknown scope %q", e.Method, e.Path, e.Scope)
+			}
+		}
+	}
+}
+
+// TestWebSocketEndpointsWrite WebSocket 端点均可执行操作，必须按写权限检查
+func TestWebSocketEndpointsWrite(t *testing.T) {
+	for _, e := range WsRoutes(nil, nil) {
+		if e.Method == http.MethodGet && !e.Write {
+			t.Errorf("%s %s: websocket endpoint must be marked as write", e.Method, e.Path)
+		}
+	}
+}
+
+// TestCredentialEndpoints 签发登录凭据的端点必须标记，受限令牌不能借此获得交互式登录
+func TestCredentialEndpoints(t *testing.T) {
+	credentials := []string{
+		"POST /api/user/passkey/register",
+		"PUT /api/user/passkey/register",
+		"POST /api/users/{id}/password",
+		"GET /api/users/{id}/2fa",
+		"POST /api/users/{id}/2fa",
+		"POST /api/user_tokens",
+		"PUT /api/user_tokens/{id}",
+		"DELETE /api/user_tokens/{id}",
+	}
+	permissions := Permissions(NewEndpoints(&Services{}))
+	for _, key := range credentials {
+		perm, ok := permissions[key]
+		if !ok {
+			t.Errorf("%s: endpoint not found", key)
+			continue
+		}
+		if !perm.Credential {
+			t.Errorf("%s: credential endpoint must be marked", key)
+		}
+	}
+}
+
+// TestAdminEndpoints 关闭审计或读取他人输入的端点只允许管理员访问，不随标签授予
+func TestAdminEndpoints(t *testing.T) {
+	admins := []string{
+		"POST /api/log/terminal/setting",
+		"GET /api/log/terminal/{id}/content",
+		"DELETE /api/log/terminal/{id}",
+		"GET /api/log/audit/setting",
+		"POST /api/log/audit/setting",
+		"GET /api/log/audit/forward",
+		"POST /api/log/audit/forward",
+	}
+	permissions := Permissions(NewEndpoints(&Services{}))
+	for _, key := range admins {
+		perm, ok := permissions[key]
+		if !ok {
+			t.Errorf("%s: endpoint not found", key)
+			continue
+		}
+		if !perm.Admin {
+			t.Errorf("%s: endpoint must be admin only", key)
+		}
+	}
+}
+
+// TestOperatorRootEquivalent 运维角色不能写入任何等同于 root 权限的端点
+func TestOperatorRootEquivalent(t *testing.T) {
+	var operator *biz.Role
+	for _, role := range biz.BuiltinRoles() {
+		if role.ID == biz.RoleIDOperator {
+			operator = role
+		}
+	}
+	if operator == nil {
+		t.Fatal("operator role not found")
+	}
+	for _, tag := range biz.RootEquivalentTags {
+		if !slices.Contains(biz.PermissionTags, tag) {
+			t.Errorf("unknown root equivalent tag %q", tag)
+		}
+	}
+
+	for _, endpoints := range NewEndpoints(&Services{}) {
+		for _, e := range endpoints {
+			if e.Public || e.Self {
+				continue
+			}
+			write := e.Write || (e.Method != http.MethodGet && e.Method != http.MethodHead)
+			if !write || !operator.Allowed(e.Tags, true) {
+				continue
+			}
+			for _, tag := range e.Tags {
+				if slices.Contains(biz.RootEquivalentTags, tag) {
+					t.Errorf("%s %s: operator can write root equivalent tag %q", e.Method, e.Path, tag)
+				}
+			}
+		}
+	}
+}
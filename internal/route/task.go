@@ -14,9 +14,13 @@ func TaskRoutes(taskService *service.TaskService) Endpoints {
 
 	return Endpoints{
 		{Method: http.MethodGet, Path: "/api/task/status", Handler: svc.Status, Summary: "获取任务运行状态", Tags: []string{"任务"}},
+		{Method: http.MethodGet, Path: "/api/task/setting", Handler: svc.GetSetting, Summary: "获取任务队列设置", Tags: []string{"任务"}, Response: service.Envelope[biz.TaskSetting]{}},
+		{Method: http.MethodPost, Path: "/api/task/setting", Handler: svc.SaveSetting, Summary: "保存任务队列设置", Tags: []string{"任务"}, Request: request.TaskSetting{}},
+		{Method: http.MethodPost, Path: "/api/task/cancel_waiting", Handler: svc.CancelWaiting, Summary: "取消等待中的任务", Tags: []string{"任务"}, Request: request.TaskCancelWaiting{}, Response: service.Envelope[int64]{}},
 		{Method: http.MethodGet, Path: "/api/task", Handler: svc.List, Summary: "获取任务列表", Tags: []string{"任务"}, Request: request.Paginate{}, Response: service.Envelope[service.Page[*biz.Task]]{}},
 		{Method: http.MethodGet, Path: "/api/task/{id}", Handler: svc.Get, Summary: "获取任务详情", Tags: []string{"任务"}, Request: request.ID{}, Response: service.Envelope[biz.Task]{}},
 		{Method: http.MethodDelete, Path: "/api/task/{id}", Handler: svc.Delete, Summary: "删除任务", Tags: []string{"任务"}, Request: request.ID{}},
 		{Method: http.MethodPost, Path: "/api/task/{id}/cancel", Handler: svc.Cancel, Summary: "取消任务", Tags: []string{"任务"}, Request: request.ID{}},
+		{Method: http.MethodPost, Path: "/api/task/{id}/priority", Handler: svc.Priority, Summary: "调整任务优先级", Tags: []string{"任务"}, Request: request.TaskPriority{}},
 	}
 }
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/internal/service"
+)
+
+// TerminalRecordingRoutes 终端录像路由
+func TerminalRecordingRoutes(terminalRecordingService *service.TerminalRecordingService) Endpoints {
+	svc := terminalRecordingService
+
+	return Endpoints{
+		{Method: http.MethodGet, Path: "/api/log/terminal", Handler: svc.List, Summary: "终端录像列表", Tags: []string{"日志"}, Request: request.TerminalRecordingList{}, Response: service.Envelope[service.Page[*biz.TerminalRecording]]{}},
+		{Method: http.MethodGet, Path: "/api/log/terminal/setting", Handler: svc.GetSetting, Summary: "获取终端录像设置", Tags: []string{"日志"}, Response: service.Envelope[biz.TerminalRecordingSetting]{}},
+		{Method: http.MethodPost, Path: "/api/log/terminal/setting", Handler: svc.SaveSetting, Summary: "保存终端录像设置", Tags: []string{"日志"}, Request: request.TerminalRecordingSetting{}, Admin: true},
+		{Method: http.MethodGet, Path: "/api/log/terminal/{id}", Handler: svc.Get, Summary: "获取终端录像", Tags: []string{"日志"}, Request: request.ID{}, Response: service.Envelope[biz.TerminalRecording]{}},
+		{Method: http.MethodGet, Path: "/api/log/terminal/{id}/content", Handler: svc.Content, Summary: "终端录像内容", Tags: []string{"日志"}, Request: request.ID{}, Admin: true},
+		{Method: http.MethodDelete, Path: "/api/log/terminal/{id}", Handler: svc.Delete, Summary: "删除终端录像", Tags: []string{"日志"}, Request: request.ID{}, Admin: true},
+	}
+}
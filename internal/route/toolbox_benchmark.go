@@ -11,6 +11,6 @@ func ToolboxBenchmarkRoutes(toolboxBenchmarkService *service.ToolboxBenchmarkSer
 	svc := toolboxBenchmarkService
 
 	return Endpoints{
-		{Method: http.MethodPost, Path: "/api/toolbox_benchmark/test", Handler: svc.Test},
+		{Method: http.MethodPost, Path: "/api/toolbox_benchmark/test", Handler: svc.Test, Tags: []string{"工具箱"}},
 	}
 }
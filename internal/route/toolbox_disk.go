@@ -11,24 +11,24 @@ func ToolboxDiskRoutes(toolboxDiskService *service.ToolboxDiskService) Endpoints
 	svc := toolboxDiskService
 
 	return Endpoints{
-		{Method: http.MethodGet, Path: "/api/toolbox_disk/list", Handler: svc.List},
-		{Method: http.MethodPost, Path: "/api/toolbox_disk/partitions", Handler: svc.GetPartitions},
-		{Method: http.MethodPost, Path: "/api/toolbox_disk/mount", Handler: svc.Mount},
-		{Method: http.MethodPost, Path: "/api/toolbox_disk/umount", Handler: svc.Umount},
-		{Method: http.MethodPost, Path: "/api/toolbox_disk/format", Handler: svc.Format},
-		{Method: http.MethodPost, Path: "/api/toolbox_disk/init", Handler: svc.Init},
-		{Method: http.MethodGet, Path: "/api/toolbox_disk/fstab", Handler: svc.GetFstab},
-		{Method: http.MethodDelete, Path: "/api/toolbox_disk/fstab", Handler: svc.DeleteFstab},
-		{Method: http.MethodGet, Path: "/api/toolbox_disk/lvm", Handler: svc.GetLVMInfo},
-		{Method: http.MethodPost, Path: "/api/toolbox_disk/lvm/pv", Handler: svc.CreatePV},
-		{Method: http.MethodDelete, Path: "/api/toolbox_disk/lvm/pv", Handler: svc.RemovePV},
-		{Method: http.MethodPost, Path: "/api/toolbox_disk/lvm/vg", Handler: svc.CreateVG},
-		{Method: http.MethodDelete, Path: "/api/toolbox_disk/lvm/vg", Handler: svc.RemoveVG},
-		{Method: http.MethodPost, Path: "/api/toolbox_disk/lvm/lv", Handler: svc.CreateLV},
-		{Method: http.MethodDelete, Path: "/api/toolbox_disk/lvm/lv", Handler: svc.RemoveLV},
-		{Method: http.MethodPost, Path: "/api/toolbox_disk/lvm/lv/extend", Handler: svc.ExtendLV},
-		{Method: http.MethodGet, Path: "/api/toolbox_disk/smart/disks", Handler: svc.GetSmartDisks},
-		{Method: http.MethodGet, Path: "/api/toolbox_disk/smart/info", Handler: svc.GetSmartInfo},
-		{Method: http.MethodGet, Path: "/api/toolbox_disk/raid/info", Handler: svc.GetRaidInfo},
+		{Method: http.MethodGet, Path: "/api/toolbox_disk/list", Handler: svc.List, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_disk/partitions", Handler: svc.GetPartitions, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_disk/mount", Handler: svc.Mount, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_disk/umount", Handler: svc.Umount, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_disk/format", Handler: svc.Format, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_disk/init", Handler: svc.Init, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_disk/fstab", Handler: svc.GetFstab, Tags: []string{"工具箱"}},
+		{Method: http.MethodDelete, Path: "/api/toolbox_disk/fstab", Handler: svc.DeleteFstab, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_disk/lvm", Handler: svc.GetLVMInfo, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_disk/lvm/pv", Handler: svc.CreatePV, Tags: []string{"工具箱"}},
+		{Method: http.MethodDelete, Path: "/api/toolbox_disk/lvm/pv", Handler: svc.RemovePV, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_disk/lvm/vg", Handler: svc.CreateVG, Tags: []string{"工具箱"}},
+		{Method: http.MethodDelete, Path: "/api/toolbox_disk/lvm/vg", Handler: svc.RemoveVG, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_disk/lvm/lv", Handler: svc.CreateLV, Tags: []string{"工具箱"}},
+		{Method: http.MethodDelete, Path: "/api/toolbox_disk/lvm/lv", Handler: svc.RemoveLV, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_disk/lvm/lv/extend", Handler: svc.ExtendLV, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_disk/smart/disks", Handler: svc.GetSmartDisks, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_disk/smart/info", Handler: svc.GetSmartInfo, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_disk/raid/info", Handler: svc.GetRaidInfo, Tags: []string{"工具箱"}},
 	}
 }
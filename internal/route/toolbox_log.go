@@ -11,7 +11,7 @@ func ToolboxLogRoutes(toolboxLogService *service.ToolboxLogService) Endpoints {
 	svc := toolboxLogService
 
 	return Endpoints{
-		{Method: http.MethodGet, Path: "/api/toolbox_log/scan", Handler: svc.Scan},
-		{Method: http.MethodPost, Path: "/api/toolbox_log/clean", Handler: svc.Clean},
+		{Method: http.MethodGet, Path: "/api/toolbox_log/scan", Handler: svc.Scan, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_log/clean", Handler: svc.Clean, Tags: []string{"工具箱"}},
 	}
 }
@@ -11,12 +11,12 @@ func ToolboxMigrationRoutes(toolboxMigrationService *service.ToolboxMigrationSer
 	svc := toolboxMigrationService
 
 	return Endpoints{
-		{Method: http.MethodGet, Path: "/api/toolbox_migration/status", Handler: svc.GetStatus},
-		{Method: http.MethodPost, Path: "/api/toolbox_migration/precheck", Handler: svc.PreCheck},
-		{Method: http.MethodGet, Path: "/api/toolbox_migration/items", Handler: svc.GetItems},
-		{Method: http.MethodPost, Path: "/api/toolbox_migration/start", Handler: svc.Start},
-		{Method: http.MethodPost, Path: "/api/toolbox_migration/reset", Handler: svc.Reset},
-		{Method: http.MethodGet, Path: "/api/toolbox_migration/log", Handler: svc.DownloadLog},
-		{Method: http.MethodPost, Path: "/api/toolbox_migration/exec", Handler: svc.Exec},
+		{Method: http.MethodGet, Path: "/api/toolbox_migration/status", Handler: svc.GetStatus, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_migration/precheck", Handler: svc.PreCheck, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_migration/items", Handler: svc.GetItems, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_migration/start", Handler: svc.Start, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_migration/reset", Handler: svc.Reset, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_migration/log", Handler: svc.DownloadLog, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_migration/exec", Handler: svc.Exec, Tags: []string{"工具箱"}},
 	}
 }
@@ -11,10 +11,10 @@ func ToolboxNetworkRoutes(toolboxNetworkService *service.ToolboxNetworkService)
 	svc := toolboxNetworkService
 
 	return Endpoints{
-		{Method: http.MethodGet, Path: "/api/toolbox_network/list", Handler: svc.List},
-		{Method: http.MethodGet, Path: "/api/toolbox_network/interfaces", Handler: svc.Interfaces},
-		{Method: http.MethodPost, Path: "/api/toolbox_network/interfaces", Handler: svc.UpdateInterface},
-		{Method: http.MethodPost, Path: "/api/toolbox_network/interfaces/confirm", Handler: svc.ConfirmInterface},
-		{Method: http.MethodPost, Path: "/api/toolbox_network/interfaces/rollback", Handler: svc.RollbackInterface},
+		{Method: http.MethodGet, Path: "/api/toolbox_network/list", Handler: svc.List, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_network/interfaces", Handler: svc.Interfaces, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_network/interfaces", Handler: svc.UpdateInterface, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_network/interfaces/confirm", Handler: svc.ConfirmInterface, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_network/interfaces/rollback", Handler: svc.RollbackInterface, Tags: []string{"工具箱"}},
 	}
 }
@@ -11,13 +11,13 @@ func ToolboxSSHRoutes(toolboxSSHService *service.ToolboxSSHService) Endpoints {
 	svc := toolboxSSHService
 
 	return Endpoints{
-		{Method: http.MethodGet, Path: "/api/toolbox_ssh/info", Handler: svc.GetInfo},
-		{Method: http.MethodPost, Path: "/api/toolbox_ssh/port", Handler: svc.UpdatePort},
-		{Method: http.MethodPost, Path: "/api/toolbox_ssh/password_auth", Handler: svc.UpdatePasswordAuth},
-		{Method: http.MethodPost, Path: "/api/toolbox_ssh/pubkey_auth", Handler: svc.UpdatePubKeyAuth},
-		{Method: http.MethodPost, Path: "/api/toolbox_ssh/root_login", Handler: svc.UpdateRootLogin},
-		{Method: http.MethodPost, Path: "/api/toolbox_ssh/root_password", Handler: svc.UpdateRootPassword},
-		{Method: http.MethodGet, Path: "/api/toolbox_ssh/root_key", Handler: svc.GetRootKey},
-		{Method: http.MethodPost, Path: "/api/toolbox_ssh/root_key", Handler: svc.GenerateRootKey},
+		{Method: http.MethodGet, Path: "/api/toolbox_ssh/info", Handler: svc.GetInfo, Tags: []string{"工具箱", "SSH"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_ssh/port", Handler: svc.UpdatePort, Tags: []string{"工具箱", "SSH"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_ssh/password_auth", Handler: svc.UpdatePasswordAuth, Tags: []string{"工具箱", "SSH"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_ssh/pubkey_auth", Handler: svc.UpdatePubKeyAuth, Tags: []string{"工具箱", "SSH"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_ssh/root_login", Handler: svc.UpdateRootLogin, Tags: []string{"工具箱", "SSH"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_ssh/root_password", Handler: svc.UpdateRootPassword, Tags: []string{"工具箱", "SSH"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_ssh/root_key", Handler: svc.GetRootKey, Tags: []string{"工具箱", "SSH"}, Write: true},
+		{Method: http.MethodPost, Path: "/api/toolbox_ssh/root_key", Handler: svc.GenerateRootKey, Tags: []string{"工具箱", "SSH"}},
 	}
 }
@@ -11,17 +11,17 @@ func ToolboxSystemRoutes(toolboxSystemService *service.ToolboxSystemService) End
 	svc := toolboxSystemService
 
 	return Endpoints{
-		{Method: http.MethodGet, Path: "/api/toolbox_system/swap", Handler: svc.GetSWAP},
-		{Method: http.MethodPost, Path: "/api/toolbox_system/swap", Handler: svc.UpdateSWAP},
-		{Method: http.MethodGet, Path: "/api/toolbox_system/timezone", Handler: svc.GetTimezone},
-		{Method: http.MethodPost, Path: "/api/toolbox_system/timezone", Handler: svc.UpdateTimezone},
-		{Method: http.MethodPost, Path: "/api/toolbox_system/time", Handler: svc.UpdateTime},
-		{Method: http.MethodPost, Path: "/api/toolbox_system/sync_time", Handler: svc.SyncTime},
-		{Method: http.MethodGet, Path: "/api/toolbox_system/ntp_servers", Handler: svc.GetNTPServers},
-		{Method: http.MethodPost, Path: "/api/toolbox_system/ntp_servers", Handler: svc.UpdateNTPServers},
-		{Method: http.MethodGet, Path: "/api/toolbox_system/hostname", Handler: svc.GetHostname},
-		{Method: http.MethodPost, Path: "/api/toolbox_system/hostname", Handler: svc.UpdateHostname},
-		{Method: http.MethodGet, Path: "/api/toolbox_system/hosts", Handler: svc.GetHosts},
-		{Method: http.MethodPost, Path: "/api/toolbox_system/hosts", Handler: svc.UpdateHosts},
+		{Method: http.MethodGet, Path: "/api/toolbox_system/swap", Handler: svc.GetSWAP, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_system/swap", Handler: svc.UpdateSWAP, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_system/timezone", Handler: svc.GetTimezone, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_system/timezone", Handler: svc.UpdateTimezone, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_system/time", Handler: svc.UpdateTime, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_system/sync_time", Handler: svc.SyncTime, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_system/ntp_servers", Handler: svc.GetNTPServers, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_system/ntp_servers", Handler: svc.UpdateNTPServers, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_system/hostname", Handler: svc.GetHostname, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_system/hostname", Handler: svc.UpdateHostname, Tags: []string{"工具箱"}},
+		{Method: http.MethodGet, Path: "/api/toolbox_system/hosts", Handler: svc.GetHosts, Tags: []string{"工具箱"}},
+		{Method: http.MethodPost, Path: "/api/toolbox_system/hosts", Handler: svc.UpdateHosts, Tags: []string{"工具箱"}},
 	}
 }
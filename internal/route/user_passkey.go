@@ -14,7 +14,7 @@ func UserPasskeyRoutes(userPasskeyService *service.UserPasskeyService) Endpoints
 
 	return Endpoints{
 		{Method: http.MethodGet, Path: "/api/user_passkeys", Handler: svc.List, Summary: "获取通行密钥列表", Tags: []string{"通行密钥"}, Request: request.UserPasskeyList{}, Response: service.Envelope[service.Page[*biz.UserPasskey]]{}},
-		{Method: http.MethodGet, Path: "/api/user_passkeys/supported", Handler: svc.Supported, Summary: "是否支持通行密钥", Tags: []string{"通行密钥"}},
+		{Method: http.MethodGet, Path: "/api/user_passkeys/supported", Handler: svc.Supported, Summary: "是否支持通行密钥", Tags: []string{"通行密钥"}, Self: true},
 		{Method: http.MethodDelete, Path: "/api/user_passkeys/{id}", Handler: svc.Delete, Summary: "删除通行密钥", Tags: []string{"通行密钥"}, Request: request.UserPasskeyDelete{}},
 	}
 }
@@ -14,8 +14,8 @@ func UserTokenRoutes(userTokenService *service.UserTokenService) Endpoints {
 
 	return Endpoints{
 		{Method: http.MethodGet, Path: "/api/user_tokens", Handler: svc.List, Summary: "获取用户令牌列表", Tags: []string{"用户令牌"}, Request: request.UserTokenList{}, Response: service.Envelope[service.Page[*biz.UserToken]]{}},
-		{Method: http.MethodPost, Path: "/api/user_tokens", Handler: svc.Create, Summary: "创建用户令牌", Tags: []string{"用户令牌"}, Request: request.UserTokenCreate{}, Response: service.Envelope[biz.UserToken]{}},
-		{Method: http.MethodPut, Path: "/api/user_tokens/{id}", Handler: svc.Update, Summary: "更新用户令牌", Tags: []string{"用户令牌"}, Request: request.UserTokenUpdate{}, Response: service.Envelope[biz.UserToken]{}},
-		{Method: http.MethodDelete, Path: "/api/user_tokens/{id}", Handler: svc.Delete, Summary: "删除用户令牌", Tags: []string{"用户令牌"}, Request: request.ID{}},
+		{Method: http.MethodPost, Path: "/api/user_tokens", Handler: svc.Create, Summary: "创建用户令牌", Tags: []string{"用户令牌"}, Request: request.UserTokenCreate{}, Response: service.Envelope[biz.UserToken]{}, Credential: true},
+		{Method: http.MethodPut, Path: "/api/user_tokens/{id}", Handler: svc.Update, Summary: "更新用户令牌", Tags: []string{"用户令牌"}, Request: request.UserTokenUpdate{}, Response: service.Envelope[biz.UserToken]{}, Credential: true},
+		{Method: http.MethodDelete, Path: "/api/user_tokens/{id}", Handler: svc.Delete, Summary: "删除用户令牌", Tags: []string{"用户令牌"}, Request: request.ID{}, Credential: true},
 	}
 }
@@ -19,7 +19,7 @@ func WebHookRoutes(webHookService *service.WebHookService) Endpoints {
 		{Method: http.MethodGet, Path: "/api/webhook/{id}", Handler: svc.Get, Summary: "获取 WebHook", Tags: []string{"WebHook"}, Request: request.ID{}, Response: service.Envelope[biz.WebHook]{}},
 		{Method: http.MethodDelete, Path: "/api/webhook/{id}", Handler: svc.Delete, Summary: "删除 WebHook", Tags: []string{"WebHook"}, Request: request.ID{}},
 		// 顶层回调
-		{Method: http.MethodGet, Path: "/webhook/{key}", Handler: svc.Call},
-		{Method: http.MethodPost, Path: "/webhook/{key}", Handler: svc.Call},
+		{Method: http.MethodGet, Path: "/webhook/{key}", Handler: svc.Call, Public: true},
+		{Method: http.MethodPost, Path: "/webhook/{key}", Handler: svc.Call, Public: true},
 	}
 }
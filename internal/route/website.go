@@ -15,40 +15,48 @@ func WebsiteRoutes(websiteService *service.WebsiteService) Endpoints {
 
 	return Endpoints{
 		{Method: http.MethodGet, Path: "/api/website/rewrites", Handler: svc.GetRewrites,
-			Summary: "获取伪静态规则", Tags: []string{"网站"}},
+			Summary: "获取伪静态规则", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id"},
 		{Method: http.MethodGet, Path: "/api/website/default_config", Handler: svc.GetDefaultConfig,
-			Summary: "获取默认配置", Tags: []string{"网站"}},
+			Summary: "获取默认配置", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id"},
 		{Method: http.MethodPost, Path: "/api/website/default_config", Handler: svc.UpdateDefaultConfig,
-			Summary: "保存默认配置", Tags: []string{"网站"}, Request: request.WebsiteDefaultConfig{}},
+			Summary: "保存默认配置", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteDefaultConfig{}},
 		{Method: http.MethodGet, Path: "/api/website/default_site", Handler: svc.GetDefaultSite,
-			Summary: "获取默认站点", Tags: []string{"网站"}},
+			Summary: "获取默认站点", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id"},
 		{Method: http.MethodPost, Path: "/api/website/default_site", Handler: svc.UpdateDefaultSite,
-			Summary: "设置默认站点", Tags: []string{"网站"}, Request: request.WebsiteDefaultSite{}},
+			Summary: "设置默认站点", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteDefaultSite{}},
 		{Method: http.MethodPost, Path: "/api/website/cert", Handler: svc.UpdateCert,
-			Summary: "更新证书", Tags: []string{"网站"}, Request: request.WebsiteUpdateCert{}},
+			Summary: "更新证书", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteUpdateCert{}},
 		{Method: http.MethodGet, Path: "/api/website", Handler: svc.List,
-			Summary: "网站列表", Tags: []string{"网站"},
+			Summary: "网站列表", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id",
 			Request: request.WebsiteList{}, Response: service.Envelope[service.Page[*biz.Website]]{}},
 		{Method: http.MethodPost, Path: "/api/website", Handler: svc.Create,
-			Summary: "创建网站", Tags: []string{"网站"}, Request: request.WebsiteCreate{}},
+			Summary: "创建网站", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteCreate{}},
 		{Method: http.MethodGet, Path: "/api/website/{id}", Handler: svc.Get,
-			Summary: "获取网站配置", Tags: []string{"网站"},
+			Summary: "获取网站配置", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id",
 			Request: request.ID{}, Response: service.Envelope[types.WebsiteSetting]{}},
 		{Method: http.MethodPut, Path: "/api/website/{id}", Handler: svc.Update,
-			Summary: "保存网站配置", Tags: []string{"网站"}, Request: request.WebsiteUpdate{}},
+			Summary: "保存网站配置", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteUpdate{}},
 		{Method: http.MethodPost, Path: "/api/website/{id}/switch_type", Handler: svc.SwitchType,
-			Summary: "切换网站类型", Tags: []string{"网站"}, Request: request.WebsiteSwitchType{}},
+			Summary: "切换网站类型", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteSwitchType{}},
 		{Method: http.MethodDelete, Path: "/api/website/{id}", Handler: svc.Delete,
-			Summary: "删除网站", Tags: []string{"网站"}, Request: request.WebsiteDelete{}},
+			Summary: "删除网站", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteDelete{}},
 		{Method: http.MethodPost, Path: "/api/website/{id}/update_remark", Handler: svc.UpdateRemark,
-			Summary: "更新备注", Tags: []string{"网站"}, Request: request.WebsiteUpdateRemark{}},
+			Summary: "更新备注", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteUpdateRemark{}},
 		{Method: http.MethodPost, Path: "/api/website/{id}/reset_config", Handler: svc.ResetConfig,
-			Summary: "重置配置", Tags: []string{"网站"}, Request: request.ID{}},
+			Summary: "重置配置", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.ID{}},
 		{Method: http.MethodPost, Path: "/api/website/{id}/status", Handler: svc.UpdateStatus,
-			Summary: "修改状态", Tags: []string{"网站"}, Request: request.WebsiteUpdateStatus{}},
+			Summary: "修改状态", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteUpdateStatus{}},
 		{Method: http.MethodPost, Path: "/api/website/{id}/expire_at", Handler: svc.UpdateExpireAt,
-			Summary: "修改到期时间", Tags: []string{"网站"}, Request: request.WebsiteUpdateExpireAt{}},
+			Summary: "修改到期时间", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteUpdateExpireAt{}},
 		{Method: http.MethodPost, Path: "/api/website/{id}/obtain_cert", Handler: svc.ObtainCert,
-			Summary: "签发证书", Tags: []string{"网站"}, Request: request.WebsiteObtainCert{}},
+			Summary: "签发证书", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteObtainCert{}},
+		{Method: http.MethodGet, Path: "/api/website/{id}/revisions", Handler: svc.ListRevisions,
+			Summary: "配置版本列表", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id",
+			Request: request.WebsiteRevisionList{}, Response: service.Envelope[service.Page[*biz.WebsiteRevision]]{}},
+		{Method: http.MethodGet, Path: "/api/website/{id}/revisions/diff", Handler: svc.DiffRevisions,
+			Summary: "配置版本之间的差异", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id",
+			Request: request.WebsiteRevisionDiff{}, Response: service.Envelope[string]{}},
+		{Method: http.MethodPost, Path: "/api/website/{id}/revisions/{revision}/restore", Handler: svc.RestoreRevision,
+			Summary: "恢复配置版本", Tags: []string{"网站"}, Scope: biz.ScopeWebsite, ScopeKey: "id", Request: request.WebsiteRevision{}},
 	}
 }
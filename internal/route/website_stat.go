@@ -32,6 +32,8 @@ func WebsiteStatRoutes(websiteStatService *service.WebsiteStatService) Endpoints
 			Summary: "慢请求 URI 统计", Tags: []string{"网站统计"}, Request: request.WebsiteStatSlowURIs{}},
 		{Method: http.MethodGet, Path: "/api/website/stat/errors", Handler: svc.ErrorStats,
 			Summary: "错误统计", Tags: []string{"网站统计"}, Request: request.WebsiteStatErrors{}},
+		{Method: http.MethodGet, Path: "/api/website/stat/waf", Handler: svc.WAFStats,
+			Summary: "WAF 拦截统计", Tags: []string{"网站统计"}, Request: request.WebsiteStatWAF{}},
 		{Method: http.MethodGet, Path: "/api/website/stat/setting", Handler: svc.GetSetting,
 			Summary: "获取统计设置", Tags: []string{"网站统计"}},
 		{Method: http.MethodPost, Path: "/api/website/stat/setting", Handler: svc.UpdateSetting,
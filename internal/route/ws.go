@@ -12,16 +12,16 @@ func WsRoutes(toolboxMigrationService *service.ToolboxMigrationService, wsServic
 	toolboxMigration := toolboxMigrationService
 
 	return Endpoints{
-		{Method: http.MethodGet, Path: "/api/ws/exec", Handler: ws.Exec},
-		{Method: http.MethodGet, Path: "/api/ws/pty", Handler: ws.PTY},
-		{Method: http.MethodGet, Path: "/api/ws/follow", Handler: ws.Follow},
-		{Method: http.MethodGet, Path: "/api/ws/ssh", Handler: ws.Session},
-		{Method: http.MethodGet, Path: "/api/ws/ssh/transfer", Handler: ws.SSHTransfer},
-		{Method: http.MethodGet, Path: "/api/ws/container/{id}", Handler: ws.ContainerTerminal},
-		{Method: http.MethodGet, Path: "/api/ws/container/image/pull", Handler: ws.ContainerImagePull},
-		{Method: http.MethodGet, Path: "/api/ws/migration/progress", Handler: toolboxMigration.Progress},
-		{Method: http.MethodGet, Path: "/api/ws/cert/obtain", Handler: ws.CertObtain},
-		{Method: http.MethodGet, Path: "/api/ws/cert/renew", Handler: ws.CertRenew},
-		{Method: http.MethodGet, Path: "/api/ws/panel/update", Handler: ws.PanelUpdate},
+		{Method: http.MethodGet, Path: "/api/ws/exec", Handler: ws.Exec, Tags: []string{"终端"}, Write: true},
+		{Method: http.MethodGet, Path: "/api/ws/pty", Handler: ws.PTY, Tags: []string{"终端"}, Write: true},
+		{Method: http.MethodGet, Path: "/api/ws/follow", Handler: ws.Follow, Tags: []string{"文件"}, Write: true},
+		{Method: http.MethodGet, Path: "/api/ws/ssh", Handler: ws.Session, Tags: []string{"SSH"}, Write: true},
+		{Method: http.MethodGet, Path: "/api/ws/ssh/transfer", Handler: ws.SSHTransfer, Tags: []string{"SSH"}, Write: true},
+		{Method: http.MethodGet, Path: "/api/ws/container/{id}", Handler: ws.ContainerTerminal, Tags: []string{"容器"}, Write: true},
+		{Method: http.MethodGet, Path: "/api/ws/container/image/pull", Handler: ws.ContainerImagePull, Tags: []string{"容器镜像"}, Write: true},
+		{Method: http.MethodGet, Path: "/api/ws/migration/progress", Handler: toolboxMigration.Progress, Tags: []string{"工具箱"}, Write: true},
+		{Method: http.MethodGet, Path: "/api/ws/cert/obtain", Handler: ws.CertObtain, Tags: []string{"证书"}, Write: true},
+		{Method: http.MethodGet, Path: "/api/ws/cert/renew", Handler: ws.CertRenew, Tags: []string{"证书"}, Write: true},
+		{Method: http.MethodGet, Path: "/api/ws/panel/update", Handler: ws.PanelUpdate, Tags: []string{"设置"}, Write: true},
 	}
 }
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/libtnb/chix/v2"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+	"github.com/acepanel/panel/v3/pkg/audit"
+)
+
+type AuditService struct {
+	auditRepo *biz.AuditUsecase
+}
+
+func NewAuditService(audit *biz.AuditUsecase) *AuditService {
+	return &AuditService{
+		auditRepo: audit,
+	}
+}
+
+func (s *AuditService) Verify(w http.ResponseWriter, r *http.Request) {
+	result, err := s.auditRepo.Verify()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, result)
+}
+
+// Export 按日期与操作人导出审计记录
+func (s *AuditService) Export(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.AuditExport](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	filter := audit.Filter{OperatorID: uint64(req.OperatorID), Type: req.Type}
+	if req.Start != "" {
+		filter.Start, _ = time.ParseInLocation(time.DateOnly, req.Start, time.Local)
+	}
+	if req.End != "" {
+		end, _ := time.ParseInLocation(time.DateOnly, req.End, time.Local)
+		filter.End = end.AddDate(0, 0, 1)
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if req.Format == biz.AuditExportJSON {
+		contentType = "application/x-ndjson"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="audit-%s.%s"`, time.Now().Format("20060102150405"), req.Format))
+	_ = s.auditRepo.Export(w, req.Format, filter)
+}
+
+func (s *AuditService) GetSetting(w http.ResponseWriter, r *http.Request) {
+	setting, err := s.auditRepo.GetSetting()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, setting)
+}
+
+func (s *AuditService) SaveSetting(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.AuditSetting](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.auditRepo.SaveSetting(r.Context(), &biz.AuditSetting{
+		Months: req.Months,
+	}); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *AuditService) GetForward(w http.ResponseWriter, r *http.Request) {
+	conf, err := s.auditRepo.GetForward()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"config": conf,
+		"status": s.auditRepo.ForwardStatus(),
+	})
+}
+
+func (s *AuditService) SaveForward(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.AuditForward](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.auditRepo.SaveForward(r.Context(), &audit.ForwardConfig{
+		Type:     req.Type,
+		Network:  req.Network,
+		Address:  req.Address,
+		Insecure: req.Insecure,
+		URL:      req.URL,
+		Headers:  req.Headers,
+	}); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
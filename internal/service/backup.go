@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 
 	"github.com/leonelquinteros/gotext"
@@ -16,8 +17,13 @@ import (
 	"github.com/acepanel/panel/v3/internal/biz"
 	"github.com/acepanel/panel/v3/internal/request"
 	"github.com/acepanel/panel/v3/pkg/io"
+	"github.com/acepanel/panel/v3/pkg/shell"
+	"github.com/acepanel/panel/v3/pkg/storage"
 )
 
+// backupTargetRe 备份文件名中时间戳之前的部分即备份目标
+var backupTargetRe = regexp.MustCompile(`^(.+)_\d{14}\.`)
+
 type BackupService struct {
 	t          *gotext.Locale
 	backupRepo *biz.BackupUsecase
@@ -39,7 +45,7 @@ func (s *BackupService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	list, err := s.backupRepo.List(biz.BackupType(req.Type))
+	list, err := s.backupRepo.ListStorage(req.Storage, biz.BackupType(req.Type))
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -65,6 +71,9 @@ func (s *BackupService) Create(w http.ResponseWriter, r *http.Request) {
 	var backupCmd string
 	if req.Type == "website" {
 		backupCmd = fmt.Sprintf("acepanel backup website -n '%s' -s '%d'", req.Target, req.Storage)
+		if req.Dedup {
+			backupCmd += " --dedup"
+		}
 	} else {
 		backupCmd = fmt.Sprintf("acepanel backup database -t '%s' -n '%s' -s '%d'", req.Type, req.Target, req.Storage)
 	}
@@ -81,8 +90,10 @@ exit $rc`, pathEnv, tmpDir, backupCmd)
 
 	task := &biz.Task{
 		Key:         fmt.Sprintf("backup:%s:%s", req.Type, req.Target),
+		Resource:    backupResource(req.Type, req.Target), // 同一目标的备份、恢复与校验依次执行
 		Name:        s.t.Get("Backup %s: %s", req.Type, req.Target),
 		Status:      biz.TaskStatusWaiting,
+		Priority:    biz.TaskPriorityLow,
 		Shell:       cmd,
 		CancelShell: fmt.Sprintf(`rm -rf "%s"`, tmpDir),
 	}
@@ -108,8 +119,8 @@ func (s *BackupService) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 只允许上传 .sql .zip .tar .gz .tgz .bz2 .xz .zst .7z
-	if !slices.Contains([]string{".sql", ".zip", ".tar", ".gz", ".tgz", ".bz2", ".xz", ".zst", ".7z"}, filepath.Ext(req.File.Filename)) {
+	// 只允许上传 .sql .zip .tar .gz .tgz .bz2 .xz .zst .7z 及加密的 .age
+	if !slices.Contains([]string{".sql", ".zip", ".tar", ".gz", ".tgz", ".bz2", ".xz", ".zst", ".7z", storage.EncryptedExt}, filepath.Ext(req.File.Filename)) {
 		Error(w, http.StatusForbidden, s.t.Get("unsupported file type"))
 		return
 	}
@@ -202,6 +213,7 @@ exit $rc`, pathEnv, tmpDir, restoreCmd)
 
 	task := &biz.Task{
 		Key:         fmt.Sprintf("restore:%s:%s", req.Type, req.Target),
+		Resource:    backupResource(req.Type, req.Target), // 同一目标的备份、恢复与校验依次执行
 		Name:        s.t.Get("Restore %s: %s", req.Type, req.Target),
 		Status:      biz.TaskStatusWaiting,
 		Shell:       cmd,
@@ -214,3 +226,55 @@ exit $rc`, pathEnv, tmpDir, restoreCmd)
 
 	Success(w, nil)
 }
+
+func (s *BackupService) Verify(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.BackupVerify](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+	if err = s.backupRepo.CheckArchive(req.Storage, biz.BackupType(req.Type), req.File); err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	// 校验需要完整下载并试列压缩包，提交到后台任务队列异步执行
+	pathEnv := "export PATH=/bin:/sbin:/usr/bin:/usr/sbin:/usr/local/bin:/usr/local/sbin:$PATH\n"
+	verifyCmd := fmt.Sprintf("acepanel backup verify -t %s -f %s -s %d", shell.Quote(req.Type), shell.Quote(req.File), req.Storage)
+
+	tmpDir := filepath.Join(app.Root, "tmp", "ace-verify-task-"+str.Random(16))
+	cmd := fmt.Sprintf(`%sexport TMPDIR="%s"
+mkdir -p "$TMPDIR"
+%s
+rc=$?
+rm -rf "$TMPDIR"
+exit $rc`, pathEnv, tmpDir, verifyCmd)
+
+	task := &biz.Task{
+		Key:         fmt.Sprintf("verify:%d:%s:%s", req.Storage, req.Type, req.File),
+		Resource:    backupResource(req.Type, backupTarget(req.File)),
+		Name:        s.t.Get("Verify backup %s: %s", req.Type, req.File),
+		Status:      biz.TaskStatusWaiting,
+		Shell:       cmd,
+		CancelShell: fmt.Sprintf(`rm -rf "%s"`, tmpDir),
+	}
+	if err = s.taskRepo.Push(task); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+// backupResource 备份任务占用的资源
+func backupResource(typ, target string) string {
+	return fmt.Sprintf("%s:%s", typ, target)
+}
+
+// backupTarget 从备份文件名解析备份目标，手动上传等不符合命名的文件以文件名作为目标
+func backupTarget(file string) string {
+	if match := backupTargetRe.FindStringSubmatch(file); match != nil {
+		return match[1]
+	}
+	return file
+}
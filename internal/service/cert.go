@@ -55,7 +55,7 @@ func (s *CertService) CAProviders(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *CertService) DNSProviders(w http.ResponseWriter, r *http.Request) {
-	Success(w, []types.LV{
+	providers := []types.LV{
 		{
 			Label: s.t.Get("Aliyun"),
 			Value: string(acme.AliYun),
@@ -92,7 +92,44 @@ func (s *CertService) DNSProviders(w http.ResponseWriter, r *http.Request) {
 			Label: s.t.Get("ClouDNS"),
 			Value: string(acme.ClouDNS),
 		},
-	})
+		{
+			Label: s.t.Get("DNSPod (Token)"),
+			Value: string(acme.DNSPod),
+		},
+		{
+			Label: s.t.Get("AWS Route53"),
+			Value: string(acme.Route53),
+		},
+		{
+			Label: s.t.Get("GoDaddy"),
+			Value: string(acme.GoDaddy),
+		},
+		{
+			Label: s.t.Get("DigitalOcean"),
+			Value: string(acme.DigitalOcean),
+		},
+		{
+			Label: s.t.Get("Hetzner"),
+			Value: string(acme.Hetzner),
+		},
+		{
+			Label: s.t.Get("PowerDNS"),
+			Value: string(acme.PowerDNS),
+		},
+		{
+			Label: s.t.Get("RFC2136 (BIND)"),
+			Value: string(acme.RFC2136),
+		},
+	}
+	// 自定义 Hook 仅管理员可配置
+	if biz.Unscoped(r.Context()) {
+		providers = append(providers, types.LV{
+			Label: s.t.Get("Custom Hook"),
+			Value: string(acme.Hook),
+		})
+	}
+
+	Success(w, providers)
 }
 
 func (s *CertService) Algorithms(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/libtnb/chix/v2"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+)
+
+type CertDeployService struct {
+	certDeployRepo *biz.CertDeployUsecase
+}
+
+func NewCertDeployService(certDeployUsecase *biz.CertDeployUsecase) *CertDeployService {
+	return &CertDeployService{
+		certDeployRepo: certDeployUsecase,
+	}
+}
+
+func (s *CertDeployService) List(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	targets, err := s.certDeployRepo.List(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, targets)
+}
+
+func (s *CertDeployService) Create(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.CertDeployTargetCreate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	target, err := s.certDeployRepo.Create(r.Context(), req)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, target)
+}
+
+func (s *CertDeployService) Update(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.CertDeployTargetUpdate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.certDeployRepo.Update(r.Context(), req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *CertDeployService) Delete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.certDeployRepo.Delete(r.Context(), req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *CertDeployService) Run(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.certDeployRepo.Run(r.Context(), req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *CertDeployService) Logs(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.CertDeployTargetLogs](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	logs, total, err := s.certDeployRepo.ListLogs(req.ID, req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": logs,
+	})
+}
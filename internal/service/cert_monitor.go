@@ -0,0 +1,136 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/libtnb/chix/v2"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+)
+
+type CertMonitorService struct {
+	certMonitorRepo *biz.CertMonitorUsecase
+}
+
+func NewCertMonitorService(certMonitorUsecase *biz.CertMonitorUsecase) *CertMonitorService {
+	return &CertMonitorService{
+		certMonitorRepo: certMonitorUsecase,
+	}
+}
+
+func (s *CertMonitorService) List(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.Paginate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	monitors, total, err := s.certMonitorRepo.List(req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": monitors,
+	})
+}
+
+func (s *CertMonitorService) Create(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.CertMonitorCreate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	monitor, err := s.certMonitorRepo.Create(r.Context(), req)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, monitor)
+}
+
+func (s *CertMonitorService) Get(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	monitor, err := s.certMonitorRepo.Get(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, monitor)
+}
+
+func (s *CertMonitorService) Update(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.CertMonitorUpdate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.certMonitorRepo.Update(r.Context(), req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *CertMonitorService) Delete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.certMonitorRepo.Delete(r.Context(), req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *CertMonitorService) Check(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	monitor, err := s.certMonitorRepo.Check(r.Context(), req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, monitor)
+}
+
+func (s *CertMonitorService) Checks(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.CertMonitorChecks](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	checks, total, err := s.certMonitorRepo.ListChecks(req.ID, req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": checks,
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/acepanel/panel/v3/internal/biz"
 	"github.com/acepanel/panel/v3/internal/request"
 	"github.com/acepanel/panel/v3/pkg/api"
+	"github.com/acepanel/panel/v3/pkg/audit"
 	"github.com/acepanel/panel/v3/pkg/cert"
 	"github.com/acepanel/panel/v3/pkg/config"
 	"github.com/acepanel/panel/v3/pkg/firewall"
@@ -37,6 +38,7 @@ import (
 	"github.com/acepanel/panel/v3/pkg/ntp"
 	"github.com/acepanel/panel/v3/pkg/os"
 	"github.com/acepanel/panel/v3/pkg/shell"
+	"github.com/acepanel/panel/v3/pkg/storage"
 	"github.com/acepanel/panel/v3/pkg/systemctl"
 	"github.com/acepanel/panel/v3/pkg/tools"
 	"github.com/acepanel/panel/v3/pkg/types"
@@ -52,6 +54,7 @@ type CliService struct {
 	conf               *config.Config
 	db                 *gorm.DB
 	appRepo            *biz.AppUsecase
+	auditRepo          *biz.AuditUsecase
 	cacheRepo          *biz.CacheUsecase
 	userRepo           *biz.UserUsecase
 	userPasskeyRepo    *biz.UserPasskeyUsecase
@@ -62,12 +65,14 @@ type CliService struct {
 	certRepo           *biz.CertUsecase
 	certAccountRepo    *biz.CertAccountUsecase
 	cronRepo           *biz.CronUsecase
+	deploymentRepo     *biz.DeploymentUsecase
 	notifyRepo         *biz.NotifyUsecase
+	projectRepo        *biz.ProjectUsecase
 	hash               hash.Hasher
 	validator          *validator.Validator
 }
 
-func NewCliService(appUsecase *biz.AppUsecase, backupUsecase *biz.BackupUsecase, cacheUsecase *biz.CacheUsecase, certAccountUsecase *biz.CertAccountUsecase, certUsecase *biz.CertUsecase, cronUsecase *biz.CronUsecase, databaseServerUsecase *biz.DatabaseServerUsecase, notifyUsecase *biz.NotifyUsecase, settingUsecase *biz.SettingUsecase, userPasskeyUsecase *biz.UserPasskeyUsecase, userUsecase *biz.UserUsecase, websiteUsecase *biz.WebsiteUsecase, conf *config.Config, db *gorm.DB, t *gotext.Locale, v *validator.Validator) *CliService {
+func NewCliService(appUsecase *biz.AppUsecase, auditUsecase *biz.AuditUsecase, backupUsecase *biz.BackupUsecase, cacheUsecase *biz.CacheUsecase, certAccountUsecase *biz.CertAccountUsecase, certUsecase *biz.CertUsecase, cronUsecase *biz.CronUsecase, databaseServerUsecase *biz.DatabaseServerUsecase, deploymentUsecase *biz.DeploymentUsecase, notifyUsecase *biz.NotifyUsecase, projectUsecase *biz.ProjectUsecase, settingUsecase *biz.SettingUsecase, userPasskeyUsecase *biz.UserPasskeyUsecase, userUsecase *biz.UserUsecase, websiteUsecase *biz.WebsiteUsecase, conf *config.Config, db *gorm.DB, t *gotext.Locale, v *validator.Validator) *CliService {
 	return &CliService{
 		hr:                 `+----------------------------------------------------`,
 		api:                api.NewAPI(app.Version, app.Locale),
@@ -76,6 +81,7 @@ func NewCliService(appUsecase *biz.AppUsecase, backupUsecase *biz.BackupUsecase,
 		db:                 db,
 		validator:          v,
 		appRepo:            appUsecase,
+		auditRepo:          auditUsecase,
 		cacheRepo:          cacheUsecase,
 		userRepo:           userUsecase,
 		userPasskeyRepo:    userPasskeyUsecase,
@@ -86,7 +92,9 @@ func NewCliService(appUsecase *biz.AppUsecase, backupUsecase *biz.BackupUsecase,
 		certRepo:           certUsecase,
 		certAccountRepo:    certAccountUsecase,
 		cronRepo:           cronUsecase,
+		deploymentRepo:     deploymentUsecase,
 		notifyRepo:         notifyUsecase,
+		projectRepo:        projectUsecase,
 		hash:               hash.NewArgon2id(),
 	}
 }
@@ -300,7 +308,7 @@ func (s *CliService) UserCreate(ctx context.Context, cmd *cli.Command) error {
 		email = username + "@example.com"
 	}
 
-	user, err := s.userRepo.Create(ctx, username, password, email)
+	user, err := s.userRepo.Create(ctx, username, password, email, biz.RoleIDAdmin, nil)
 	if err != nil {
 		return errors.New(s.t.Get("Failed to create user: %v", err))
 	}
@@ -838,7 +846,7 @@ func (s *CliService) FirewallPort(ctx context.Context, cmd *cli.Command) error {
 }
 
 func (s *CliService) WebsiteList(ctx context.Context, cmd *cli.Command) error {
-	websites, _, err := s.websiteRepo.List("all", 1, math.MaxUint32)
+	websites, _, err := s.websiteRepo.List(ctx, "all", 1, math.MaxUint32)
 	if err != nil {
 		return err
 	}
@@ -1111,7 +1119,13 @@ func (s *CliService) CertRenew(ctx context.Context, cmd *cli.Command) error {
 }
 
 func (s *CliService) BackupList(ctx context.Context, cmd *cli.Command) error {
-	files, err := s.backupRepo.List(biz.BackupType(cmd.String("type")))
+	var files []*types.BackupFile
+	var err error
+	if cmd.Uint("storage") != 0 {
+		files, err = s.backupRepo.ListSnapshots(cmd.Uint("storage"), biz.BackupType(cmd.String("type")))
+	} else {
+		files, err = s.backupRepo.List(biz.BackupType(cmd.String("type")))
+	}
 	if err != nil {
 		return err
 	}
@@ -1124,15 +1138,15 @@ func (s *CliService) BackupList(ctx context.Context, cmd *cli.Command) error {
 }
 
 func (s *CliService) BackupWebsite(ctx context.Context, cmd *cli.Command) error {
-	return s.backupRepo.Create(ctx, biz.BackupTypeWebsite, cmd.String("name"), cmd.Uint("storage"))
+	return s.backupRepo.Create(ctx, biz.BackupTypeWebsite, cmd.String("name"), cmd.Uint("storage"), cmd.Bool("dedup"))
 }
 
 func (s *CliService) BackupDatabase(ctx context.Context, cmd *cli.Command) error {
-	return s.backupRepo.Create(ctx, biz.BackupType(cmd.String("type")), cmd.String("name"), cmd.Uint("storage"))
+	return s.backupRepo.Create(ctx, biz.BackupType(cmd.String("type")), cmd.String("name"), cmd.Uint("storage"), false)
 }
 
 func (s *CliService) BackupPath(ctx context.Context, cmd *cli.Command) error {
-	return s.backupRepo.Create(ctx, biz.BackupTypePath, cmd.String("path"), cmd.Uint("storage"))
+	return s.backupRepo.Create(ctx, biz.BackupTypePath, cmd.String("path"), cmd.Uint("storage"), cmd.Bool("dedup"))
 }
 
 func (s *CliService) BackupPanel(ctx context.Context, cmd *cli.Command) error {
@@ -1149,6 +1163,15 @@ func (s *CliService) BackupPanel(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+func (s *CliService) BackupVerify(ctx context.Context, cmd *cli.Command) error {
+	_, err := s.backupRepo.Verify(cmd.Uint("storage"), biz.BackupType(cmd.String("type")), cmd.String("file"), cmd.String("identity"))
+	return err
+}
+
+func (s *CliService) BackupTestRestore(ctx context.Context, cmd *cli.Command) error {
+	return s.backupRepo.TestRestore(ctx, cmd.Uint("storage"), biz.BackupType(cmd.String("type")), cmd.String("name"))
+}
+
 func (s *CliService) BackupClear(ctx context.Context, cmd *cli.Command) error {
 	fmt.Println(s.hr)
 	fmt.Println(s.t.Get("★ Start cleaning [%s]", time.Now().Format(time.DateTime)))
@@ -1175,11 +1198,46 @@ func (s *CliService) BackupClear(ctx context.Context, cmd *cli.Command) error {
 }
 
 func (s *CliService) RestoreWebsite(ctx context.Context, cmd *cli.Command) error {
-	return s.backupRepo.Restore(ctx, biz.BackupTypeWebsite, cmd.String("file"), cmd.String("name"))
+	if cmd.Uint("storage") != 0 {
+		return s.backupRepo.RestoreSnapshot(ctx, cmd.Uint("storage"), biz.BackupTypeWebsite, cmd.String("file"), cmd.String("name"))
+	}
+	file, cleanup, err := s.decryptBackup(biz.BackupTypeWebsite, cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	return s.backupRepo.Restore(ctx, biz.BackupTypeWebsite, file, cmd.String("name"))
+}
+
+// RestorePath 从去重快照恢复目录，未指定目录时恢复到快照的原目录
+func (s *CliService) RestorePath(ctx context.Context, cmd *cli.Command) error {
+	return s.backupRepo.RestoreSnapshot(ctx, cmd.Uint("storage"), biz.BackupTypePath, cmd.String("file"), cmd.String("path"))
 }
 
 func (s *CliService) RestoreDatabase(ctx context.Context, cmd *cli.Command) error {
-	return s.backupRepo.Restore(ctx, biz.BackupType(cmd.String("type")), cmd.String("file"), cmd.String("name"))
+	file, cleanup, err := s.decryptBackup(biz.BackupType(cmd.String("type")), cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	return s.backupRepo.Restore(ctx, biz.BackupType(cmd.String("type")), file, cmd.String("name"))
+}
+
+// decryptBackup 指定了私钥文件时先用其解密公钥加密的备份，口令加密的备份由 Restore 自动解密
+func (s *CliService) decryptBackup(typ biz.BackupType, cmd *cli.Command) (string, func(), error) {
+	file := cmd.String("file")
+	if cmd.String("identity") == "" || !strings.HasSuffix(file, storage.EncryptedExt) {
+		return file, func() {}, nil
+	}
+
+	decrypted, err := s.backupRepo.Decrypt(typ, file, cmd.String("identity"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return decrypted, func() { _ = stdos.RemoveAll(filepath.Dir(decrypted)) }, nil
 }
 
 // RestorePanel 从面板备份恢复，恢复完成后面板会自行重启
@@ -1549,7 +1607,7 @@ func (s *CliService) Init(ctx context.Context, cmd *cli.Command) error {
 		return errors.New(s.t.Get("Initialization failed: %v", err))
 	}
 
-	_, err = s.userRepo.Create(ctx, "admin", value, str.Random(8)+"@yourdomain.com")
+	_, err = s.userRepo.Create(ctx, "admin", value, str.Random(8)+"@yourdomain.com", biz.RoleIDAdmin, nil)
 	if err != nil {
 		return errors.New(s.t.Get("Initialization failed: %v", err))
 	}
@@ -1616,7 +1674,8 @@ func (s *CliService) CronList(ctx context.Context, cmd *cli.Command) error {
 	})
 }
 
-// CronRun 立即执行一次计划任务，直接跑任务脚本，不经 wrapper 上报失败
+// CronRun 立即执行一次计划任务，按任务的重叠策略与超时限制执行并记录，输出实时打印
+// 面板的立即执行与链式触发都经任务队列调用此命令，失败由任务队列通知
 func (s *CliService) CronRun(ctx context.Context, cmd *cli.Command) error {
 	cron, err := s.cronRepo.Get(cmd.Uint("id"))
 	if err != nil {
@@ -1627,12 +1686,20 @@ func (s *CliService) CronRun(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	fmt.Println(s.t.Get("|-Running cron task: %s", cron.Name))
-	out, err := shell.Execf("bash %s", cron.Shell)
-	if out != "" {
-		fmt.Println(out)
+	run, err := s.cronRepo.Execute(ctx, cron, cmd.String("trigger"), stdos.Stdout)
+	if run == nil {
+		if err != nil {
+			return err
+		}
+		fmt.Println(s.t.Get("Cron task %s skipped, the previous run is still in progress", cron.Name))
+		return nil
 	}
 	if err != nil {
-		return errors.New(s.t.Get("Cron task %s failed: %v", cron.Name, err))
+		fmt.Println(s.t.Get("|-Failed to record cron task run: %v", err))
+	}
+
+	if run.ExitCode != 0 {
+		return errors.New(s.t.Get("Cron task %s failed with exit code %d", cron.Name, run.ExitCode))
 	}
 
 	fmt.Println(s.t.Get("Cron task %s executed successfully", cron.Name))
@@ -1654,13 +1721,90 @@ func (s *CliService) CronStatus(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-// CronFailed 上报计划任务执行失败，由任务 wrapper 脚本调用
+// CronReport 记录计划任务的一次执行，由任务 wrapper 脚本调用，执行失败时同时发送通知
+// 秒级任务每分钟通过 --batch 上报一次，其中每次执行各记录一条，失败只汇总通知一次
+func (s *CliService) CronReport(ctx context.Context, cmd *cli.Command) error {
+	cron, err := s.cronRepo.Get(cmd.Uint("id"))
+	if err != nil {
+		return err
+	}
+
+	if batch := cmd.String("batch"); batch != "" {
+		return s.cronReportBatch(ctx, cron, cmd.String("trigger"), batch)
+	}
+
+	run := &biz.CronRun{
+		CronID:    cron.ID,
+		Trigger:   cmd.String("trigger"),
+		StartedAt: time.UnixMilli(cmd.Int64("start")),
+		EndedAt:   time.UnixMilli(cmd.Int64("end")),
+		ExitCode:  cmd.Int("code"),
+	}
+	// 记录失败不能影响后续任务与失败通知
+	recordErr := s.cronRepo.RecordRun(run, cmd.String("output"), cmd.Int64("offset"))
+	s.cronRepo.Chain(cron, run.ExitCode)
+	if run.ExitCode == 0 {
+		return recordErr
+	}
+
+	return errors.Join(recordErr, s.notifyCronFailed(ctx, cron, run.ExitCode))
+}
+
+// cronReportBatch 逐条记录批次文件中的执行，后续任务按最后一次执行的结果触发
+func (s *CliService) cronReportBatch(ctx context.Context, cron *biz.Cron, trigger, batch string) error {
+	content, err := stdos.ReadFile(batch)
+	if err != nil {
+		return err
+	}
+
+	var runs []*biz.CronRun
+	var errs []error
+	for line := range strings.Lines(string(content)) {
+		// 每行为 开始毫秒 结束毫秒 退出码 输出文件
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		run := &biz.CronRun{
+			CronID:    cron.ID,
+			Trigger:   trigger,
+			StartedAt: time.UnixMilli(cast.ToInt64(fields[0])),
+			EndedAt:   time.UnixMilli(cast.ToInt64(fields[1])),
+			ExitCode:  cast.ToInt(fields[2]),
+		}
+		if err = s.cronRepo.RecordRun(run, fields[3], 0); err != nil {
+			errs = append(errs, err)
+		}
+		runs = append(runs, run)
+	}
+	if len(runs) == 0 {
+		return errors.Join(errs...)
+	}
+
+	// 并发执行的完成顺序不定，以最晚开始的一次作为本批结果
+	slices.SortFunc(runs, func(a, b *biz.CronRun) int { return a.StartedAt.Compare(b.StartedAt) })
+	last := runs[len(runs)-1]
+	s.cronRepo.Chain(cron, last.ExitCode)
+
+	// 一分钟内多次失败只通知一次，附带最后一次失败的退出码
+	if failed := slices.DeleteFunc(slices.Clone(runs), func(run *biz.CronRun) bool { return run.ExitCode == 0 }); len(failed) > 0 {
+		errs = append(errs, s.notifyCronFailed(ctx, cron, failed[len(failed)-1].ExitCode))
+	}
+
+	return errors.Join(errs...)
+}
+
+// CronFailed 上报计划任务执行失败，供尚未重新生成的旧 wrapper 脚本调用
 func (s *CliService) CronFailed(ctx context.Context, cmd *cli.Command) error {
 	cron, err := s.cronRepo.Get(cmd.Uint("id"))
 	if err != nil {
 		return err
 	}
 
+	return s.notifyCronFailed(ctx, cron, cmd.Int("code"))
+}
+
+func (s *CliService) notifyCronFailed(ctx context.Context, cron *biz.Cron, code int) error {
 	// 附带日志尾部，便于直接定位问题
 	tail, _ := shell.Execf("tail -n 20 %s", cron.Log)
 
@@ -1668,13 +1812,34 @@ func (s *CliService) CronFailed(ctx context.Context, cmd *cli.Command) error {
 		biz.NotifyBody(s.t.Get("cron task exited abnormally"), [][2]string{
 			{s.t.Get("Task"), cron.Name},
 			{s.t.Get("Schedule"), cron.Time},
-			{s.t.Get("Exit Code"), cast.ToString(cmd.Int("code"))},
+			{s.t.Get("Exit Code"), cast.ToString(code)},
 			{s.t.Get("Log"), cron.Log},
 			{s.t.Get("Output"), tail},
 			{s.t.Get("Time"), time.Now().Format(time.DateTime)},
 		}))
 }
 
+// DeployRun 执行排队的部署版本，由部署任务调用
+func (s *CliService) DeployRun(ctx context.Context, cmd *cli.Command) error {
+	return s.deploymentRepo.Execute(ctx, cmd.Uint("id"), stdos.Stdout)
+}
+
+// DeployTrigger 将一次部署加入任务队列，供 WebHook 脚本调用
+func (s *CliService) DeployTrigger(ctx context.Context, cmd *cli.Command) error {
+	task, err := s.deploymentRepo.Deploy(ctx, cmd.Uint("id"), cmd.String("trigger"))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(s.t.Get("Deployment queued as task %d", task.ID))
+	return nil
+}
+
+// ProjectRestart 执行项目的蓝绿重启，由重启任务调用
+func (s *CliService) ProjectRestart(ctx context.Context, cmd *cli.Command) error {
+	return s.projectRepo.RunRestart(cmd.Uint("id"), stdos.Stdout)
+}
+
 // validate 校验请求结构体，CLI 不走 HTTP 绑定，需要单独调用
 func (s *CliService) validate(ctx context.Context, req any) error {
 	vd := s.validator.Struct(req)
@@ -1686,6 +1851,75 @@ func (s *CliService) validate(ctx context.Context, req any) error {
 	return nil
 }
 
+// AuditVerify 校验审计日志哈希链，链断裂时返回错误以便脚本判断
+func (s *CliService) AuditVerify(ctx context.Context, cmd *cli.Command) error {
+	result, err := s.auditRepo.Verify()
+	if err != nil {
+		return err
+	}
+
+	if err = s.printList(cmd, result, func() {
+		fmt.Println(s.t.Get("Entries: %d, Sequence: %d-%d", result.Entries, result.FirstSeq, result.LastSeq))
+		if result.Head != "" {
+			fmt.Println(s.t.Get("Head hash: %s", result.Head))
+		}
+	}); err != nil {
+		return err
+	}
+	if !result.Valid {
+		return errors.New(s.t.Get("Audit log chain broken at %s line %d: %s", result.File, result.Line, result.Reason))
+	}
+
+	if !cmd.Bool("json") {
+		fmt.Println(s.t.Get("Audit log chain is intact"))
+	}
+	return nil
+}
+
+// AuditExport 导出审计日志到文件或标准输出
+func (s *CliService) AuditExport(ctx context.Context, cmd *cli.Command) error {
+	format := cmd.String("format")
+	if format != biz.AuditExportCSV && format != biz.AuditExportJSON {
+		return errors.New(s.t.Get("Unsupported export format: %s", format))
+	}
+
+	filter := audit.Filter{OperatorID: uint64(cmd.Uint("operator")), Type: cmd.String("type")}
+	if start := cmd.String("start"); start != "" {
+		t, err := time.ParseInLocation(time.DateOnly, start, time.Local)
+		if err != nil {
+			return errors.New(s.t.Get("Invalid date %s, expected YYYY-MM-DD", start))
+		}
+		filter.Start = t
+	}
+	if end := cmd.String("end"); end != "" {
+		t, err := time.ParseInLocation(time.DateOnly, end, time.Local)
+		if err != nil {
+			return errors.New(s.t.Get("Invalid date %s, expected YYYY-MM-DD", end))
+		}
+		filter.End = t.AddDate(0, 0, 1)
+	}
+
+	output := cmd.String("output")
+	if output == "" {
+		return s.auditRepo.Export(stdos.Stdout, format, filter)
+	}
+
+	f, err := stdos.OpenFile(output, stdos.O_CREATE|stdos.O_TRUNC|stdos.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if err = s.auditRepo.Export(f, format, filter); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Println(s.t.Get("Audit log exported to %s", output))
+	return nil
+}
+
 // printList 输出列表，--json 时输出原始数据，否则交给 plain 打印
 func (s *CliService) printList(cmd *cli.Command, data any, plain func()) error {
 	if !cmd.Bool("json") {
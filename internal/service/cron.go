@@ -113,3 +113,99 @@ func (s *CronService) Status(w http.ResponseWriter, r *http.Request) {
 
 	Success(w, nil)
 }
+
+// Run 经任务队列立即执行一次，返回的任务日志可通过 /api/ws/follow 实时跟踪
+func (s *CronService) Run(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	task, err := s.cronRepo.Run(r.Context(), req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, task)
+}
+
+func (s *CronService) ListRuns(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.CronRunList](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	runs, total, err := s.cronRepo.ListRuns(req.ID, req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": runs,
+	})
+}
+
+func (s *CronService) RunOutput(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.CronRun](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	output, err := s.cronRepo.RunOutput(req.ID, req.Run)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, output)
+}
+
+func (s *CronService) RunStats(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	stats, err := s.cronRepo.RunStats(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, stats)
+}
+
+func (s *CronService) GetRunSetting(w http.ResponseWriter, r *http.Request) {
+	setting, err := s.cronRepo.GetRunSetting()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, setting)
+}
+
+func (s *CronService) SaveRunSetting(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.CronRunSetting](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.cronRepo.SaveRunSetting(r.Context(), &biz.CronRunSetting{
+		Days: req.Days,
+		Keep: req.Keep,
+	}); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
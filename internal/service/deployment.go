@@ -0,0 +1,165 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/libtnb/chix/v2"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+)
+
+type DeploymentService struct {
+	deploymentRepo *biz.DeploymentUsecase
+}
+
+func NewDeploymentService(deploymentUsecase *biz.DeploymentUsecase) *DeploymentService {
+	return &DeploymentService{
+		deploymentRepo: deploymentUsecase,
+	}
+}
+
+// GetByTarget 获取项目或网站的部署配置，未配置时返回 null
+func (s *DeploymentService) GetByTarget(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.DeploymentTarget](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	deployment, err := s.deploymentRepo.GetByTarget(r.Context(), req.Target, req.TargetID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, deployment)
+}
+
+func (s *DeploymentService) Create(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.DeploymentCreate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	deployment, err := s.deploymentRepo.Create(r.Context(), req)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, deployment)
+}
+
+func (s *DeploymentService) Get(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	deployment, err := s.deploymentRepo.Get(r.Context(), req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, deployment)
+}
+
+func (s *DeploymentService) Update(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.DeploymentUpdate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.deploymentRepo.Update(r.Context(), req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *DeploymentService) Delete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.deploymentRepo.Delete(r.Context(), req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *DeploymentService) ResetKey(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	deployment, err := s.deploymentRepo.ResetKey(r.Context(), req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, deployment)
+}
+
+func (s *DeploymentService) Deploy(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	task, err := s.deploymentRepo.Deploy(r.Context(), req.ID, biz.DeploymentTriggerManual)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, task)
+}
+
+func (s *DeploymentService) ListReleases(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.DeploymentReleaseList](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	releases, total, err := s.deploymentRepo.ListReleases(r.Context(), req.ID, req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": releases,
+	})
+}
+
+func (s *DeploymentService) Rollback(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.DeploymentRollback](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.deploymentRepo.Rollback(r.Context(), req.ID, req.Release); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
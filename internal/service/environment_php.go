@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -22,9 +23,12 @@ import (
 	"github.com/acepanel/panel/v3/internal/biz"
 	"github.com/acepanel/panel/v3/internal/request"
 	"github.com/acepanel/panel/v3/pkg/config"
+	"github.com/acepanel/panel/v3/pkg/configtest"
+	"github.com/acepanel/panel/v3/pkg/conftx"
 	"github.com/acepanel/panel/v3/pkg/fastcgi"
 	"github.com/acepanel/panel/v3/pkg/io"
 	"github.com/acepanel/panel/v3/pkg/shell"
+	"github.com/acepanel/panel/v3/pkg/systemctl"
 	"github.com/acepanel/panel/v3/pkg/tools"
 	"github.com/acepanel/panel/v3/pkg/types"
 )
@@ -117,7 +121,10 @@ func (s *EnvironmentPHPService) UpdateConfig(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if err = io.Write(fmt.Sprintf("%s/server/php/%d/etc/php.ini", app.Root, req.Version), req.Config, 0644); err != nil {
+	iniPath := fmt.Sprintf("%s/server/php/%d/etc/php.ini", app.Root, req.Version)
+	if err = s.applyConfig(req.Version, []string{iniPath}, func() error {
+		return io.Write(iniPath, req.Config, 0644)
+	}); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -156,7 +163,10 @@ func (s *EnvironmentPHPService) UpdateFPMConfig(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	if err = io.Write(fmt.Sprintf("%s/server/php/%d/etc/php-fpm.conf", app.Root, req.Version), req.Config, 0644); err != nil {
+	fpmPath := fmt.Sprintf("%s/server/php/%d/etc/php-fpm.conf", app.Root, req.Version)
+	if err = s.applyConfig(req.Version, []string{fpmPath}, func() error {
+		return io.Write(fpmPath, req.Config, 0644)
+	}); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -316,6 +326,7 @@ func (s *EnvironmentPHPService) InstallModule(w http.ResponseWriter, r *http.Req
 	task.Key = fmt.Sprintf("php:module:%d:%s", req.Version, req.Slug)
 	task.Name = s.t.Get("Install PHP-%d %s module", req.Version, req.Slug)
 	task.Status = biz.TaskStatusWaiting
+	task.Resource = biz.TaskResourcePkg // 模块编译依赖运行环境，与环境安装卸载串行
 	task.Shell = cmd
 	if err = s.taskRepo.Push(task); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
@@ -351,6 +362,7 @@ func (s *EnvironmentPHPService) UninstallModule(w http.ResponseWriter, r *http.R
 	task.Key = fmt.Sprintf("php:module:%d:%s", req.Version, req.Slug)
 	task.Name = s.t.Get("Uninstall PHP-%d %s module", req.Version, req.Slug)
 	task.Status = biz.TaskStatusWaiting
+	task.Resource = biz.TaskResourcePkg // 模块编译依赖运行环境，与环境安装卸载串行
 	task.Shell = cmd
 	if err = s.taskRepo.Push(task); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
@@ -470,11 +482,12 @@ func (s *EnvironmentPHPService) UpdateConfigTune(w http.ResponseWriter, r *http.
 	fpm = confval.PHPINI.Set(fpm, "pm.min_spare_servers", req.PmMinSpareServers)
 	fpm = confval.PHPINI.Set(fpm, "pm.max_spare_servers", req.PmMaxSpareServers)
 
-	if err = io.Write(iniPath, ini, 0644); err != nil {
-		Error(w, http.StatusInternalServerError, "%v", err)
-		return
-	}
-	if err = io.Write(fpmPath, fpm, 0644); err != nil {
+	if err = s.applyConfig(req.Version, []string{iniPath, fpmPath}, func() error {
+		if err := io.Write(iniPath, ini, 0644); err != nil {
+			return err
+		}
+		return io.Write(fpmPath, fpm, 0644)
+	}); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -676,6 +689,7 @@ func (s *EnvironmentPHPService) InstallComposer(w http.ResponseWriter, r *http.R
 	task.Key = "php:composer"
 	task.Name = s.t.Get("Install Composer")
 	task.Status = biz.TaskStatusWaiting
+	task.Resource = biz.TaskResourcePkg
 	task.Shell = cmd
 	if err := s.taskRepo.Push(task); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
@@ -718,6 +732,28 @@ func (s *EnvironmentPHPService) SetComposerMirror(w http.ResponseWriter, r *http
 	Success(w, nil)
 }
 
+// applyConfig 修改配置后执行 php-fpm -t 并重载，任一步失败都将 paths 回滚到修改前
+func (s *EnvironmentPHPService) applyConfig(version uint, paths []string, mutate func() error) error {
+	if err := conftx.Apply(paths, mutate, func() error {
+		if err := configtest.PHPFPM(app.Root, version); err != nil {
+			return err
+		}
+		// 服务未运行时无需重载，配置会在下次启动时生效
+		name := fmt.Sprintf("php-fpm-%d", version)
+		if running, _ := systemctl.Status(name); !running {
+			return nil
+		}
+		if err := systemctl.Reload(name); err != nil {
+			return errors.New(s.t.Get("failed to reload %s: %v", name, err))
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("%s: %w", s.t.Get("configuration rolled back"), err)
+	}
+
+	return nil
+}
+
 func (s *EnvironmentPHPService) getModules(version uint) []types.EnvironmentPHPModule {
 	modules := []types.EnvironmentPHPModule{
 		{
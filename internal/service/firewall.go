@@ -15,24 +15,27 @@ import (
 	"github.com/spf13/cast"
 	"github.com/xuri/excelize/v2"
 
+	"github.com/acepanel/panel/v3/internal/biz"
 	"github.com/acepanel/panel/v3/internal/request"
 	"github.com/acepanel/panel/v3/pkg/firewall"
 	"github.com/acepanel/panel/v3/pkg/os"
 )
 
 type FirewallService struct {
-	t        *gotext.Locale
-	firewall firewall.Firewall
+	t          *gotext.Locale
+	firewall   firewall.Firewall
+	geoUsecase *biz.FirewallGeoUsecase
 }
 
 type firewallRuleOperator interface {
 	Port(rule firewall.FireInfo, operation firewall.Operation) error
 }
 
-func NewFirewallService(t *gotext.Locale) *FirewallService {
+func NewFirewallService(t *gotext.Locale, geo *biz.FirewallGeoUsecase) *FirewallService {
 	return &FirewallService{
-		t:        t,
-		firewall: firewall.NewFirewall(),
+		t:          t,
+		firewall:   firewall.NewFirewall(),
+		geoUsecase: geo,
 	}
 }
 
@@ -330,6 +333,25 @@ func (s *FirewallService) GetIPRules(w http.ResponseWriter, r *http.Request) {
 		}, true
 	})
 
+	// 地区规则
+	geoRules, err := s.geoUsecase.List()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	for _, rule := range geoRules {
+		filledRules = append(filledRules, map[string]any{
+			"id":         rule.ID,
+			"country":    rule.Country,
+			"port_start": rule.PortStart,
+			"port_end":   rule.PortEnd,
+			"protocol":   rule.Protocol,
+			"strategy":   rule.Strategy,
+			"direction":  firewall.DirectionIn,
+			"networks":   rule.Networks,
+		})
+	}
+
 	paged, total := Paginate(r, filledRules)
 
 	Success(w, chix.M{
@@ -372,6 +394,52 @@ func (s *FirewallService) DeleteIPRule(w http.ResponseWriter, r *http.Request) {
 	Success(w, nil)
 }
 
+func (s *FirewallService) GetGeoRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := s.geoUsecase.List()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	paged, total := Paginate(r, rules)
+
+	Success(w, chix.M{
+		"total": total,
+		"items": paged,
+	})
+}
+
+func (s *FirewallService) CreateGeoRule(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.FirewallGeoRule](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	rule, err := s.geoUsecase.Create(r.Context(), req)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, rule)
+}
+
+func (s *FirewallService) DeleteGeoRule(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.geoUsecase.Delete(r.Context(), req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
 func (s *FirewallService) GetForwards(w http.ResponseWriter, r *http.Request) {
 	forwards, err := s.firewall.ListForward()
 	if err != nil {
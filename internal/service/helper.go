@@ -42,7 +42,13 @@ type SuccessResponse struct {
 
 // ErrorResponse 通用错误响应
 type ErrorResponse struct {
-	Msg string `json:"msg"`
+	Msg  string `json:"msg"`
+	Data any    `json:"data,omitempty"`
+}
+
+// detailedError 可附带结构化信息的错误，如配置检查失败的文件与行号
+type detailedError interface {
+	Detail() any
 }
 
 // Success 响应成功
@@ -61,12 +67,19 @@ func Error(w http.ResponseWriter, code int, format string, args ...any) {
 	defer render.Release()
 	render.Header(chix.HeaderContentType, chix.MIMEApplicationJSONCharsetUTF8) // must before Status()
 	render.Status(code)
+	resp := new(ErrorResponse)
+	for _, arg := range args {
+		var detailed detailedError
+		if err, ok := arg.(error); ok && errors.As(err, &detailed) {
+			resp.Data = detailed.Detail()
+			break
+		}
+	}
 	if len(args) > 0 {
 		format = fmt.Sprintf(format, args...)
 	}
-	render.JSON(&ErrorResponse{
-		Msg: format,
-	})
+	resp.Msg = format
+	render.JSON(resp)
 }
 
 // ErrorSystem 响应系统错误
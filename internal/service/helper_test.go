@@ -1,9 +1,14 @@
 package service
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/acepanel/panel/v3/pkg/configtest"
 )
 
 // 代理头给裸 IP、RemoteAddr 带端口，两种形态都要归一到同一个 IP
@@ -37,3 +42,24 @@ func TestClientIP(t *testing.T) {
 		})
 	}
 }
+
+// 配置检查失败时错误响应附带文件、行号与指令
+func TestErrorDetail(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := fmt.Errorf("failed to update website: %w", &configtest.Error{Server: "nginx", File: "/opt/ace/sites/a/config/nginx.conf", Line: 3, Directive: "foo"})
+	Error(w, http.StatusInternalServerError, "%v", err)
+
+	var resp struct {
+		Msg  string           `json:"msg"`
+		Data configtest.Error `json:"data"`
+	}
+	if err = json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Line != 3 || resp.Data.Directive != "foo" {
+		t.Fatalf("unexpected detail: %+v", resp.Data)
+	}
+	if !strings.Contains(resp.Msg, "nginx.conf:3") {
+		t.Fatalf("unexpected msg: %s", resp.Msg)
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/samber/lo"
+
+	"github.com/acepanel/panel/v3/internal/app"
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/pkg/metrics"
+	"github.com/acepanel/panel/v3/pkg/tools"
+	"github.com/acepanel/panel/v3/pkg/websitestat"
+)
+
+type MetricsService struct {
+	t          *gotext.Locale
+	setting    *biz.SettingUsecase
+	task       *biz.TaskUsecase
+	alert      *biz.AlertUsecase
+	tamper     *biz.TamperUsecase
+	scanEvent  *biz.ScanEventUsecase
+	aggregator *websitestat.Aggregator
+}
+
+func NewMetricsService(t *gotext.Locale, alertUsecase *biz.AlertUsecase, scanEventUsecase *biz.ScanEventUsecase, settingUsecase *biz.SettingUsecase, tamperUsecase *biz.TamperUsecase, taskUsecase *biz.TaskUsecase, aggregator *websitestat.Aggregator) *MetricsService {
+	return &MetricsService{
+		t:          t,
+		setting:    settingUsecase,
+		task:       taskUsecase,
+		alert:      alertUsecase,
+		tamper:     tamperUsecase,
+		scanEvent:  scanEventUsecase,
+		aggregator: aggregator,
+	}
+}
+
+// Metrics 以 Prometheus 格式导出面板、主机和网站指标
+// 使用独立的 Bearer 令牌鉴权，便于 Prometheus 直接抓取
+func (s *MetricsService) Metrics(w http.ResponseWriter, r *http.Request) {
+	token, _ := s.setting.Get(biz.SettingKeyMetricsToken)
+	if token == "" {
+		Error(w, http.StatusNotFound, s.t.Get("metrics endpoint is not enabled"))
+		return
+	}
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+		Error(w, http.StatusUnauthorized, s.t.Get("invalid metrics token"))
+		return
+	}
+
+	mw := metrics.NewWriter(r.Header.Get("Accept"))
+	s.writePanel(mw)
+	s.writeHost(mw)
+	s.writeWebsites(mw)
+
+	w.Header().Set("Content-Type", mw.ContentType())
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(mw.Bytes())
+}
+
+// writePanel 面板自身指标：任务队列、证书、防篡改和扫描感知
+func (s *MetricsService) writePanel(mw *metrics.Writer) {
+	mw.Family("acepanel_info", metrics.TypeGauge, "AcePanel build information.")
+	mw.Gauge("acepanel_info", 1, "version", app.Version)
+
+	if counts, err := s.task.CountByStatus(); err == nil {
+		mw.Family("acepanel_tasks", metrics.TypeGauge, "Number of background tasks by status.")
+		for _, status := range []biz.TaskStatus{biz.TaskStatusWaiting, biz.TaskStatusRunning} {
+			mw.Gauge("acepanel_tasks", float64(counts[status]), "status", string(status))
+		}
+	}
+
+	if certs, err := s.alert.CertExpiry(); err == nil {
+		mw.Family("acepanel_cert_expiry_days", metrics.TypeGauge, "Days until the certificate expires.")
+		for _, item := range certs {
+			mw.Gauge("acepanel_cert_expiry_days", item.Value, "domains", item.Target)
+		}
+	}
+
+	stats := s.tamper.Stats()
+	mw.Family("acepanel_tamper_running", metrics.TypeGauge, "Whether tamper protection is running.")
+	mw.Gauge("acepanel_tamper_running", lo.Ternary(stats.Running, 1.0, 0.0), "mode", string(stats.Mode))
+	mw.Family("acepanel_tamper_protected", metrics.TypeGauge, "Number of paths under tamper protection.")
+	mw.Gauge("acepanel_tamper_protected", float64(stats.ProtectedFiles), "kind", "file")
+	mw.Gauge("acepanel_tamper_protected", float64(stats.ProtectedDirs), "kind", "dir")
+	mw.Family("acepanel_tamper_blocked", metrics.TypeCounter, "File operations blocked by tamper protection since panel start.")
+	mw.Counter("acepanel_tamper_blocked", float64(s.tamper.Blocked()))
+
+	today := time.Now().Format(time.DateOnly)
+	if summary, err := s.scanEvent.Summary(today, today); err == nil {
+		mw.Family("acepanel_scan_events_today", metrics.TypeGauge, "Port scan events detected today.")
+		mw.Gauge("acepanel_scan_events_today", float64(summary.TotalCount))
+		mw.Family("acepanel_scan_sources_today", metrics.TypeGauge, "Unique port scan source IPs detected today.")
+		mw.Gauge("acepanel_scan_sources_today", float64(summary.UniqueIPs))
+	}
+}
+
+// writeHost 主机指标
+func (s *MetricsService) writeHost(mw *metrics.Writer) {
+	info := tools.CurrentInfo(nil, nil)
+
+	mw.Family("acepanel_cpu_usage_percent", metrics.TypeGauge, "CPU usage percent.")
+	mw.Gauge("acepanel_cpu_usage_percent", info.Percent)
+	mw.Family("acepanel_cpu_cores", metrics.TypeGauge, "Number of logical CPU cores.")
+	mw.Gauge("acepanel_cpu_cores", float64(len(info.Percents)))
+
+	if info.Load != nil {
+		mw.Family("acepanel_load1", metrics.TypeGauge, "1-minute load average.")
+		mw.Gauge("acepanel_load1", info.Load.Load1)
+		mw.Family("acepanel_load5", metrics.TypeGauge, "5-minute load average.")
+		mw.Gauge("acepanel_load5", info.Load.Load5)
+		mw.Family("acepanel_load15", metrics.TypeGauge, "15-minute load average.")
+		mw.Gauge("acepanel_load15", info.Load.Load15)
+	}
+	if info.Host != nil {
+		mw.Family("acepanel_uptime_seconds", metrics.TypeGauge, "System uptime in seconds.")
+		mw.Gauge("acepanel_uptime_seconds", float64(info.Host.Uptime))
+	}
+
+	if info.Mem != nil {
+		mw.Family("acepanel_memory_total_bytes", metrics.TypeGauge, "Total memory in bytes.")
+		mw.Gauge("acepanel_memory_total_bytes", float64(info.Mem.Total))
+		mw.Family("acepanel_memory_used_bytes", metrics.TypeGauge, "Used memory in bytes.")
+		mw.Gauge("acepanel_memory_used_bytes", float64(info.Mem.Used))
+		mw.Family("acepanel_memory_available_bytes", metrics.TypeGauge, "Available memory in bytes.")
+		mw.Gauge("acepanel_memory_available_bytes", float64(info.Mem.Available))
+	}
+	if info.Swap != nil {
+		mw.Family("acepanel_swap_total_bytes", metrics.TypeGauge, "Total swap in bytes.")
+		mw.Gauge("acepanel_swap_total_bytes", float64(info.Swap.Total))
+		mw.Family("acepanel_swap_used_bytes", metrics.TypeGauge, "Used swap in bytes.")
+		mw.Gauge("acepanel_swap_used_bytes", float64(info.Swap.Used))
+	}
+
+	mw.Family("acepanel_disk_total_bytes", metrics.TypeGauge, "Filesystem size in bytes.")
+	for _, usage := range info.DiskUsage {
+		mw.Gauge("acepanel_disk_total_bytes", float64(usage.Total), "mountpoint", usage.Path, "fstype", usage.Fstype)
+	}
+	mw.Family("acepanel_disk_used_bytes", metrics.TypeGauge, "Filesystem used space in bytes.")
+	for _, usage := range info.DiskUsage {
+		mw.Gauge("acepanel_disk_used_bytes", float64(usage.Used), "mountpoint", usage.Path, "fstype", usage.Fstype)
+	}
+	mw.Family("acepanel_disk_read_bytes", metrics.TypeCounter, "Bytes read from disk.")
+	for _, io := range info.DiskIO {
+		mw.Counter("acepanel_disk_read_bytes", float64(io.ReadBytes), "device", io.Name)
+	}
+	mw.Family("acepanel_disk_written_bytes", metrics.TypeCounter, "Bytes written to disk.")
+	for _, io := range info.DiskIO {
+		mw.Counter("acepanel_disk_written_bytes", float64(io.WriteBytes), "device", io.Name)
+	}
+
+	mw.Family("acepanel_network_receive_bytes", metrics.TypeCounter, "Bytes received by network interface.")
+	for _, n := range info.Net {
+		mw.Counter("acepanel_network_receive_bytes", float64(n.BytesRecv), "interface", n.Name)
+	}
+	mw.Family("acepanel_network_transmit_bytes", metrics.TypeCounter, "Bytes transmitted by network interface.")
+	for _, n := range info.Net {
+		mw.Counter("acepanel_network_transmit_bytes", float64(n.BytesSent), "interface", n.Name)
+	}
+}
+
+// writeWebsites 网站访问指标，计数自面板启动起累计
+func (s *MetricsService) writeWebsites(mw *metrics.Writer) {
+	totals := s.aggregator.Totals()
+	sites := lo.Keys(totals)
+	slices.Sort(sites)
+
+	mw.Family("acepanel_website_requests", metrics.TypeCounter, "HTTP requests served by website.")
+	for _, site := range sites {
+		mw.Counter("acepanel_website_requests", float64(totals[site].Requests), "site", site)
+	}
+	mw.Family("acepanel_website_pageviews", metrics.TypeCounter, "Page views by website.")
+	for _, site := range sites {
+		mw.Counter("acepanel_website_pageviews", float64(totals[site].PV), "site", site)
+	}
+	mw.Family("acepanel_website_responses", metrics.TypeCounter, "HTTP responses by website and status class.")
+	for _, site := range sites {
+		st := totals[site]
+		mw.Counter("acepanel_website_responses", float64(st.Status2xx), "site", site, "class", "2xx")
+		mw.Counter("acepanel_website_responses", float64(st.Status3xx), "site", site, "class", "3xx")
+		mw.Counter("acepanel_website_responses", float64(st.Status4xx), "site", site, "class", "4xx")
+		mw.Counter("acepanel_website_responses", float64(st.Status5xx), "site", site, "class", "5xx")
+	}
+	mw.Family("acepanel_website_sent_bytes", metrics.TypeCounter, "Response bytes sent by website.")
+	for _, site := range sites {
+		mw.Counter("acepanel_website_sent_bytes", float64(totals[site].Bandwidth), "site", site)
+	}
+	mw.Family("acepanel_website_received_bytes", metrics.TypeCounter, "Request bytes received by website.")
+	for _, site := range sites {
+		mw.Counter("acepanel_website_received_bytes", float64(totals[site].BandwidthIn), "site", site)
+	}
+	mw.Family("acepanel_website_request_duration_seconds", metrics.TypeSummary, "Request processing time by website.")
+	for _, site := range sites {
+		st := totals[site]
+		mw.Summary("acepanel_website_request_duration_seconds", float64(st.RequestTimeSum)/1000, float64(st.RequestTimeCount), "site", site)
+	}
+	mw.Family("acepanel_website_waf_blocked", metrics.TypeCounter, "Requests blocked by the website WAF.")
+	for _, site := range sites {
+		mw.Counter("acepanel_website_waf_blocked", float64(totals[site].WAFBlocked), "site", site)
+	}
+}
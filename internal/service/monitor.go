@@ -3,6 +3,8 @@ package service
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/samber/lo"
@@ -65,136 +67,107 @@ func (s *MonitorService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	monitors, err := s.monitorRepo.List(time.UnixMilli(req.Start), time.UnixMilli(req.End))
+	start, end := time.UnixMilli(req.Start), time.UnixMilli(req.End)
+	resolution, points, err := s.monitorRepo.List(start, end)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
-	if len(monitors) == 0 {
+	if len(points) == 0 {
 		Success(w, types.MonitorDetail{})
 		return
 	}
 
-	var list types.MonitorDetail
-
-	// 用于存储每个网卡的累计流量
-	netDeviceData := make(map[string]*types.Network)
-	netDevicePrev := make(map[string]struct {
-		sent uint64
-		recv uint64
-	})
-
-	// 用于存储每个磁盘的IO数据
-	diskIOData := make(map[string]*types.DiskIO)
-	diskIOPrev := make(map[string]struct {
-		read  uint64
-		write uint64
-	})
-
-	// 初始化第一条数据的网卡和磁盘数据
-	for _, net := range monitors[0].Info.Net {
-		if net.Name == "lo" {
-			continue
+	// 按时间和指标分组，点已按时间升序
+	var times []int64
+	series := make(map[string]map[int64]*biz.MonitorPoint)
+	for _, point := range points {
+		if len(times) == 0 || times[len(times)-1] != point.Time {
+			times = append(times, point.Time)
 		}
-		netDevicePrev[net.Name] = struct {
-			sent uint64
-			recv uint64
-		}{sent: net.BytesSent, recv: net.BytesRecv}
-		netDeviceData[net.Name] = &types.Network{Name: net.Name}
+		if series[point.Series] == nil {
+			series[point.Series] = make(map[int64]*biz.MonitorPoint)
+		}
+		series[point.Series][point.Time] = point
 	}
-
-	for _, disk := range monitors[0].Info.DiskIO {
-		diskIOPrev[disk.Name] = struct {
-			read  uint64
-			write uint64
-		}{read: disk.ReadBytes, write: disk.WriteBytes}
-		diskIOData[disk.Name] = &types.DiskIO{Name: disk.Name}
+	// 平均值用于瞬时指标和速率，最大值用于累计计数
+	avg := func(name string, t int64) float64 {
+		if point, ok := series[name][t]; ok {
+			return point.Avg
+		}
+		return 0
 	}
-
-	for i, monitor := range monitors {
-		// 跳过第一条数据，因为第一条数据的流量为 0
-		if i == 0 {
-			// MB
-			list.Mem.Total = fmt.Sprintf("%.2f", float64(monitor.Info.Mem.Total)/1024/1024)
-			list.SWAP.Total = fmt.Sprintf("%.2f", float64(monitor.Info.Swap.Total)/1024/1024)
-			continue
+	last := func(name string, t int64) float64 {
+		if point, ok := series[name][t]; ok {
+			return point.Max
 		}
+		return 0
+	}
 
-		// 计算与上一条记录的时间差（秒），用于速率计算
-		elapsed := monitor.CreatedAt.Sub(monitors[i-1].CreatedAt).Seconds()
-		if elapsed < 1 {
-			elapsed = 1
+	// Top 进程仅原始分辨率有快照
+	processes := make(map[int64]types.TopProcesses)
+	if resolution == biz.MonitorResolutionRaw {
+		items, err := s.monitorRepo.ListProcesses(start, end)
+		if err != nil {
+			Error(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		for _, item := range items {
+			processes[item.Time] = item.Processes
 		}
+	}
 
-		// 处理网络数据
-		for _, net := range monitor.Info.Net {
-			if net.Name == "lo" {
-				continue
-			}
-
-			// 按网卡分组
-			if _, ok := netDeviceData[net.Name]; !ok {
-				netDeviceData[net.Name] = &types.Network{Name: net.Name}
-				netDevicePrev[net.Name] = struct {
-					sent uint64
-					recv uint64
-				}{sent: 0, recv: 0}
-			}
-			prev := netDevicePrev[net.Name]
-			device := netDeviceData[net.Name]
-			device.Sent = append(device.Sent, fmt.Sprintf("%.2f", float64(net.BytesSent)/1024/1024))
-			device.Recv = append(device.Recv, fmt.Sprintf("%.2f", float64(net.BytesRecv)/1024/1024))
-			device.Tx = append(device.Tx, fmt.Sprintf("%.2f", float64(net.BytesSent-prev.sent)/elapsed/1024/1024))
-			device.Rx = append(device.Rx, fmt.Sprintf("%.2f", float64(net.BytesRecv-prev.recv)/elapsed/1024/1024))
-			netDevicePrev[net.Name] = struct {
-				sent uint64
-				recv uint64
-			}{sent: net.BytesSent, recv: net.BytesRecv}
+	var netNames, diskNames []string
+	for name := range series {
+		if device, ok := strings.CutPrefix(name, "net_sent:"); ok {
+			netNames = append(netNames, device)
+		}
+		if device, ok := strings.CutPrefix(name, "disk_read:"); ok {
+			diskNames = append(diskNames, device)
+		}
+	}
+	slices.Sort(netNames)
+	slices.Sort(diskNames)
+
+	list := types.MonitorDetail{Resolution: resolution}
+	list.Net = lo.Map(netNames, func(name string, _ int) types.Network { return types.Network{Name: name} })
+	list.DiskIO = lo.Map(diskNames, func(name string, _ int) types.DiskIO { return types.DiskIO{Name: name} })
+	latest := times[len(times)-1]
+	// MB
+	list.Mem.Total = fmt.Sprintf("%.2f", last("mem_total", latest)/1024/1024)
+	list.SWAP.Total = fmt.Sprintf("%.2f", last("swap_total", latest)/1024/1024)
+
+	for _, t := range times {
+		list.Times = append(list.Times, time.Unix(t, 0).Format(time.DateTime))
+		list.Load.Load1 = append(list.Load.Load1, avg("load1", t))
+		list.Load.Load5 = append(list.Load.Load5, avg("load5", t))
+		list.Load.Load15 = append(list.Load.Load15, avg("load15", t))
+		list.CPU.Percent = append(list.CPU.Percent, fmt.Sprintf("%.2f", avg("cpu", t)))
+		list.Mem.Available = append(list.Mem.Available, fmt.Sprintf("%.2f", avg("mem_available", t)/1024/1024))
+		list.Mem.Used = append(list.Mem.Used, fmt.Sprintf("%.2f", avg("mem_used", t)/1024/1024))
+		list.SWAP.Used = append(list.SWAP.Used, fmt.Sprintf("%.2f", avg("swap_used", t)/1024/1024))
+		list.SWAP.Free = append(list.SWAP.Free, fmt.Sprintf("%.2f", avg("swap_free", t)/1024/1024))
+
+		// 网络流量 (MB) 和速率 (MB/s)
+		for i := range list.Net {
+			device := &list.Net[i]
+			device.Sent = append(device.Sent, fmt.Sprintf("%.2f", last("net_sent:"+device.Name, t)/1024/1024))
+			device.Recv = append(device.Recv, fmt.Sprintf("%.2f", last("net_recv:"+device.Name, t)/1024/1024))
+			device.Tx = append(device.Tx, fmt.Sprintf("%.2f", avg("net_tx:"+device.Name, t)/1024/1024))
+			device.Rx = append(device.Rx, fmt.Sprintf("%.2f", avg("net_rx:"+device.Name, t)/1024/1024))
 		}
 
-		// 处理磁盘IO数据
-		for _, disk := range monitor.Info.DiskIO {
-			if _, ok := diskIOData[disk.Name]; !ok {
-				diskIOData[disk.Name] = &types.DiskIO{Name: disk.Name}
-				diskIOPrev[disk.Name] = struct {
-					read  uint64
-					write uint64
-				}{read: 0, write: 0}
-			}
-			prev := diskIOPrev[disk.Name]
-			diskData := diskIOData[disk.Name]
-			diskData.ReadBytes = append(diskData.ReadBytes, fmt.Sprintf("%.2f", float64(disk.ReadBytes)/1024/1024))
-			diskData.WriteBytes = append(diskData.WriteBytes, fmt.Sprintf("%.2f", float64(disk.WriteBytes)/1024/1024))
-			// 根据实际时间差计算每秒速度 (KB/s)
-			diskData.ReadSpeed = append(diskData.ReadSpeed, fmt.Sprintf("%.2f", float64(disk.ReadBytes-prev.read)/elapsed/1024))
-			diskData.WriteSpeed = append(diskData.WriteSpeed, fmt.Sprintf("%.2f", float64(disk.WriteBytes-prev.write)/elapsed/1024))
-			diskIOPrev[disk.Name] = struct {
-				read  uint64
-				write uint64
-			}{read: disk.ReadBytes, write: disk.WriteBytes}
+		// 磁盘读写量 (MB) 和速度 (KB/s)
+		for i := range list.DiskIO {
+			disk := &list.DiskIO[i]
+			disk.ReadBytes = append(disk.ReadBytes, fmt.Sprintf("%.2f", last("disk_read:"+disk.Name, t)/1024/1024))
+			disk.WriteBytes = append(disk.WriteBytes, fmt.Sprintf("%.2f", last("disk_write:"+disk.Name, t)/1024/1024))
+			disk.ReadSpeed = append(disk.ReadSpeed, fmt.Sprintf("%.2f", avg("disk_read_speed:"+disk.Name, t)/1024))
+			disk.WriteSpeed = append(disk.WriteSpeed, fmt.Sprintf("%.2f", avg("disk_write_speed:"+disk.Name, t)/1024))
 		}
 
-		list.Times = append(list.Times, monitor.CreatedAt.Format(time.DateTime))
-		list.Load.Load1 = append(list.Load.Load1, monitor.Info.Load.Load1)
-		list.Load.Load5 = append(list.Load.Load5, monitor.Info.Load.Load5)
-		list.Load.Load15 = append(list.Load.Load15, monitor.Info.Load.Load15)
-		list.CPU.Percent = append(list.CPU.Percent, fmt.Sprintf("%.2f", monitor.Info.Percent))
-		list.Mem.Available = append(list.Mem.Available, fmt.Sprintf("%.2f", float64(monitor.Info.Mem.Available)/1024/1024))
-		list.Mem.Used = append(list.Mem.Used, fmt.Sprintf("%.2f", float64(monitor.Info.Mem.Used)/1024/1024))
-		list.SWAP.Used = append(list.SWAP.Used, fmt.Sprintf("%.2f", float64(monitor.Info.Swap.Used)/1024/1024))
-		list.SWAP.Free = append(list.SWAP.Free, fmt.Sprintf("%.2f", float64(monitor.Info.Swap.Free)/1024/1024))
-
-		// Top 5 进程数据
-		list.TopProcesses = append(list.TopProcesses, monitor.Info.TopProcesses)
+		list.TopProcesses = append(list.TopProcesses, processes[t])
 	}
 
-	// 将 map 转换为 slice
-	list.Net = lo.Map(lo.Values(netDeviceData), func(device *types.Network, _ int) types.Network {
-		return *device
-	})
-	list.DiskIO = lo.Map(lo.Values(diskIOData), func(disk *types.DiskIO, _ int) types.DiskIO {
-		return *disk
-	})
-
 	Success(w, list)
 }
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/libtnb/chix/v2"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+)
+
+type ProbeService struct {
+	probeRepo *biz.ProbeUsecase
+}
+
+func NewProbeService(probeUsecase *biz.ProbeUsecase) *ProbeService {
+	return &ProbeService{
+		probeRepo: probeUsecase,
+	}
+}
+
+func (s *ProbeService) List(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.Paginate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	probes, total, err := s.probeRepo.List(req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": probes,
+	})
+}
+
+func (s *ProbeService) Create(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ProbeCreate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	probe, err := s.probeRepo.Create(r.Context(), req)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, probe)
+}
+
+func (s *ProbeService) Get(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	probe, err := s.probeRepo.Get(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, probe)
+}
+
+func (s *ProbeService) Update(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ProbeUpdate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.probeRepo.Update(r.Context(), req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *ProbeService) Delete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.probeRepo.Delete(r.Context(), req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *ProbeService) Check(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	probe, err := s.probeRepo.Check(r.Context(), req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, probe)
+}
+
+func (s *ProbeService) Records(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ProbeRecords](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	records, total, err := s.probeRepo.ListRecords(req.ID, req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": records,
+	})
+}
+
+func (s *ProbeService) Stats(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ProbeStats](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	var start, end time.Time
+	if req.Start > 0 {
+		start = time.UnixMilli(req.Start)
+	}
+	if req.End > 0 {
+		end = time.UnixMilli(req.End)
+	}
+	stats, err := s.probeRepo.Stats(req.ID, start, end)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, stats)
+}
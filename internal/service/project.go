@@ -31,7 +31,7 @@ func (s *ProjectService) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	typ := types.ProjectType(r.URL.Query().Get("type"))
-	projects, total, err := s.projectRepo.List(typ, req.Page, req.Limit)
+	projects, total, err := s.projectRepo.List(r.Context(), typ, req.Page, req.Limit)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -109,3 +109,20 @@ func (s *ProjectService) Delete(w http.ResponseWriter, r *http.Request) {
 
 	Success(w, nil)
 }
+
+// Restart 将一次蓝绿重启加入任务队列
+func (s *ProjectService) Restart(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	task, err := s.projectRepo.Restart(r.Context(), req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, task)
+}
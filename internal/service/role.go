@@ -0,0 +1,119 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/libtnb/chix/v2"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+)
+
+type RoleService struct {
+	roleRepo *biz.RoleUsecase
+}
+
+func NewRoleService(roleUsecase *biz.RoleUsecase) *RoleService {
+	return &RoleService{
+		roleRepo: roleUsecase,
+	}
+}
+
+func (s *RoleService) List(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.Paginate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	roles, total, err := s.roleRepo.List(req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": roles,
+	})
+}
+
+func (s *RoleService) All(w http.ResponseWriter, r *http.Request) {
+	roles, err := s.roleRepo.All()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, roles)
+}
+
+// Permissions 可分配的权限标签与资源范围类型
+func (s *RoleService) Permissions(w http.ResponseWriter, r *http.Request) {
+	Success(w, chix.M{
+		"tags":   biz.PermissionTags,
+		"scopes": biz.ScopeKinds,
+	})
+}
+
+func (s *RoleService) Get(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	role, err := s.roleRepo.Get(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, role)
+}
+
+func (s *RoleService) Create(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.RoleCreate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	role, err := s.roleRepo.Create(r.Context(), req)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, role)
+}
+
+func (s *RoleService) Update(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.RoleUpdate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.roleRepo.Update(r.Context(), req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *RoleService) Delete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.roleRepo.Delete(r.Context(), req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
@@ -3,19 +3,19 @@ package service
 import "github.com/google/wire"
 
 var ProviderSet = wire.NewSet(
-	NewAlertService, NewAppService, NewBackupService, NewBackupStorageService,
-	NewCertService, NewCertAccountService, NewCertDNSService,
+	NewAlertService, NewAppService, NewAuditService, NewBackupService, NewBackupStorageService,
+	NewCertService, NewCertAccountService, NewCertDNSService, NewCertDeployService, NewCertMonitorService,
 	NewCliService, NewContainerService, NewContainerComposeService,
 	NewContainerImageService, NewContainerNetworkService, NewContainerVolumeService,
 	NewCronService, NewDatabaseService, NewDatabaseRedisService,
-	NewDatabaseElasticsearchService, NewDatabaseServerService, NewDatabaseUserService,
+	NewDatabaseElasticsearchService, NewDatabaseServerService, NewDatabaseUserService, NewDeploymentService,
 	NewEnvironmentService, NewEnvironmentGoService, NewEnvironmentJavaService,
 	NewEnvironmentNodejsService, NewEnvironmentPHPService, NewEnvironmentPythonService,
 	NewEnvironmentDotnetService, NewFileService, NewFileShareService, NewFirewallService,
-	NewFirewallScanService, NewHomeService, NewLogService,
-	NewMonitorService, NewNotifyService, NewProcessService, NewProjectService,
+	NewFirewallScanService, NewHomeService, NewLogService, NewMetricsService,
+	NewMonitorService, NewNotifyService, NewProbeService, NewProcessService, NewProjectService, NewRoleService,
 	NewSafeService, NewSettingService, NewSSHService,
-	NewSystemctlService, NewTamperService, NewTaskService, NewTemplateService,
+	NewSystemctlService, NewTamperService, NewTaskService, NewTemplateService, NewTerminalRecordingService,
 	NewUserService, NewUserPasskeyService, NewUserTokenService,
 	NewWebHookService, NewWebsiteService, NewWebsiteStatService,
 	NewToolboxNetworkService, NewToolboxSystemService, NewToolboxBenchmarkService,
@@ -90,3 +90,59 @@ func (s *TaskService) Cancel(w http.ResponseWriter, r *http.Request) {
 
 	Success(w, nil)
 }
+
+func (s *TaskService) CancelWaiting(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.TaskCancelWaiting](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	count, err := s.taskRepo.CancelWaiting(r.Context(), req.Resource)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, count)
+}
+
+func (s *TaskService) Priority(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.TaskPriority](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.taskRepo.SetPriority(req.ID, req.Priority); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *TaskService) GetSetting(w http.ResponseWriter, r *http.Request) {
+	setting, err := s.taskRepo.GetSetting()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, setting)
+}
+
+func (s *TaskService) SaveSetting(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.TaskSetting](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.taskRepo.SaveSetting(r.Context(), &biz.TaskSetting{Workers: req.Workers}); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
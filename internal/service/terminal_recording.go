@@ -0,0 +1,122 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/libtnb/chix/v2"
+
+	"github.com/acepanel/panel/v3/internal/biz"
+	"github.com/acepanel/panel/v3/internal/request"
+)
+
+type TerminalRecordingService struct {
+	recordingRepo *biz.TerminalRecordingUsecase
+}
+
+func NewTerminalRecordingService(recordingUsecase *biz.TerminalRecordingUsecase) *TerminalRecordingService {
+	return &TerminalRecordingService{
+		recordingRepo: recordingUsecase,
+	}
+}
+
+func (s *TerminalRecordingService) List(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.TerminalRecordingList](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	recordings, total, err := s.recordingRepo.List(req.Type, req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": recordings,
+	})
+}
+
+func (s *TerminalRecordingService) Get(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	recording, err := s.recordingRepo.Get(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, recording)
+}
+
+// Content 输出解压后的 asciicast 录像，可直接用 asciinema 播放
+func (s *TerminalRecordingService) Content(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	recording, reader, err := s.recordingRepo.Open(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	defer func(reader io.ReadCloser) { _ = reader.Close() }(reader)
+
+	w.Header().Set("Content-Type", "application/x-asciicast")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%d.cast"`, recording.Type, recording.ID))
+	_, _ = io.Copy(w, reader)
+}
+
+func (s *TerminalRecordingService) Delete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.recordingRepo.Delete(r.Context(), req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *TerminalRecordingService) GetSetting(w http.ResponseWriter, r *http.Request) {
+	setting, err := s.recordingRepo.GetSetting()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, setting)
+}
+
+func (s *TerminalRecordingService) SaveSetting(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.TerminalRecordingSetting](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.recordingRepo.SaveSetting(r.Context(), &biz.TerminalRecordingSetting{
+		Enabled:   req.Enabled,
+		Input:     req.Input,
+		Mandatory: req.Mandatory,
+		Days:      req.Days,
+	}); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
@@ -256,11 +256,24 @@ func (s *UserService) Info(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 前端按 role 过滤菜单，管理员保持 admin，其余用户为可读的权限标签
+	role := []string{"admin"}
+	roleName := ""
+	if user.Role != nil {
+		roleName = user.Role.Name
+	}
+	if !user.Role.IsAdmin() {
+		role = user.Role.Readable()
+	}
+
 	Success(w, chix.M{
-		"id":       user.ID,
-		"role":     []string{"admin"},
-		"username": user.Username,
-		"email":    user.Email,
+		"id":        user.ID,
+		"role":      role,
+		"role_id":   user.RoleID,
+		"role_name": roleName,
+		"scopes":    user.Scopes,
+		"username":  user.Username,
+		"email":     user.Email,
 	})
 }
 
@@ -290,7 +303,7 @@ func (s *UserService) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := s.userRepo.Create(r.Context(), req.Username, req.Password, req.Email)
+	user, err := s.userRepo.Create(r.Context(), req.Username, req.Password, req.Email, req.RoleID, req.Scopes)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -299,6 +312,21 @@ func (s *UserService) Create(w http.ResponseWriter, r *http.Request) {
 	Success(w, user)
 }
 
+func (s *UserService) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.UserUpdateRole](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.userRepo.UpdateRole(r.Context(), req.ID, req.RoleID, req.Scopes); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
 func (s *UserService) UpdateUsername(w http.ResponseWriter, r *http.Request) {
 	req, err := Bind[request.UserUpdateUsername](r)
 	if err != nil {
@@ -59,7 +59,7 @@ func (s *UserTokenService) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userToken, err := s.userTokenRepo.Create(req.UserID, req.IPs, expiredAt)
+	userToken, err := s.userTokenRepo.Create(req, expiredAt)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -67,13 +67,18 @@ func (s *UserTokenService) Create(w http.ResponseWriter, r *http.Request) {
 
 	// 手动组装响应，因为 Token 设置了 json:"-"
 	Success(w, chix.M{
-		"id":         userToken.ID,
-		"user_id":    userToken.UserID,
-		"token":      userToken.Token,
-		"ips":        userToken.IPs,
-		"expired_at": userToken.ExpiredAt,
-		"created_at": userToken.CreatedAt,
-		"updated_at": userToken.UpdatedAt,
+		"id":           userToken.ID,
+		"user_id":      userToken.UserID,
+		"token":        userToken.Token,
+		"ips":          userToken.IPs,
+		"scopes":       userToken.Scopes,
+		"endpoints":    userToken.Endpoints,
+		"rate_limit":   userToken.RateLimit,
+		"last_used_at": userToken.LastUsedAt,
+		"last_used_ip": userToken.LastUsedIP,
+		"expired_at":   userToken.ExpiredAt,
+		"created_at":   userToken.CreatedAt,
+		"updated_at":   userToken.UpdatedAt,
 	})
 }
 
@@ -94,7 +99,7 @@ func (s *UserTokenService) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userToken, err := s.userTokenRepo.Update(req.ID, req.IPs, expiredAt)
+	userToken, err := s.userTokenRepo.Update(req, expiredAt)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -154,7 +154,7 @@ func (s *WebsiteService) setWebsiteDefaultServer(name string, isDefault bool) er
 
 // GetDefaultSite 获取当前默认站点,0 表示面板内置默认页
 func (s *WebsiteService) GetDefaultSite(w http.ResponseWriter, r *http.Request) {
-	websites, _, err := s.websiteRepo.List("all", 1, 10000)
+	websites, _, err := s.websiteRepo.List(r.Context(), "all", 1, 10000)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -183,7 +183,7 @@ func (s *WebsiteService) UpdateDefaultSite(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	websites, _, err := s.websiteRepo.List("all", 1, 10000)
+	websites, _, err := s.websiteRepo.List(r.Context(), "all", 1, 10000)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -285,7 +285,7 @@ func (s *WebsiteService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	websites, total, err := s.websiteRepo.List(req.Type, req.Page, req.Limit)
+	websites, total, err := s.websiteRepo.List(r.Context(), req.Type, req.Page, req.Limit)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -400,7 +400,7 @@ func (s *WebsiteService) ResetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = s.websiteRepo.ResetConfig(req.ID); err != nil {
+	if err = s.websiteRepo.ResetConfig(r.Context(), req.ID); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -415,7 +415,7 @@ func (s *WebsiteService) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = s.websiteRepo.UpdateStatus(req.ID, req.Status); err != nil {
+	if err = s.websiteRepo.UpdateStatus(r.Context(), req.ID, req.Status); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -462,3 +462,53 @@ func (s *WebsiteService) ObtainCert(w http.ResponseWriter, r *http.Request) {
 
 	Success(w, nil)
 }
+
+func (s *WebsiteService) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.WebsiteRevisionList](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	revisions, total, err := s.websiteRepo.ListRevisions(req.ID, req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": revisions,
+	})
+}
+
+func (s *WebsiteService) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.WebsiteRevisionDiff](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	diff, err := s.websiteRepo.DiffRevisions(req.ID, req.From, req.To)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, diff)
+}
+
+func (s *WebsiteService) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.WebsiteRevision](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.websiteRepo.RestoreRevision(r.Context(), req.ID, req.Revision); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
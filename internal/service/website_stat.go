@@ -91,7 +91,7 @@ func (s *WebsiteStatService) Overview(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取所有网站列表（用于站点选择器）
-	websites, _, err := s.websiteRepo.List("all", 1, 10000)
+	websites, _, err := s.websiteRepo.List(r.Context(), "all", 1, 10000)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -373,6 +373,32 @@ func (s *WebsiteStatService) ErrorStats(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// WAFStats WAF 拦截日志（分页 + 规则过滤）及规则命中排名
+func (s *WebsiteStatService) WAFStats(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.WebsiteStatWAF](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	items, total, err := s.statRepo.ListWAFLogs(req.Start, req.End, req.SiteList(), req.Rule, req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	rules, err := s.statRepo.TopWAFRules(req.Start, req.End, req.SiteList())
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"items": items,
+		"total": total,
+		"rules": rules,
+	})
+}
+
 // Clear 清空所有统计数据
 func (s *WebsiteStatService) Clear(w http.ResponseWriter, r *http.Request) {
 	if err := s.statRepo.Clear(); err != nil {
@@ -31,28 +31,30 @@ import (
 )
 
 type WsService struct {
-	t           *gotext.Locale
-	conf        *config.Config
-	log         *slog.Logger
-	api         *api.API
-	sshRepo     *biz.SSHUsecase
-	settingRepo *biz.SettingUsecase
-	certRepo    *biz.CertUsecase
-	backupRepo  *biz.BackupUsecase
-	taskRepo    *biz.TaskUsecase
+	t             *gotext.Locale
+	conf          *config.Config
+	log           *slog.Logger
+	api           *api.API
+	sshRepo       *biz.SSHUsecase
+	settingRepo   *biz.SettingUsecase
+	certRepo      *biz.CertUsecase
+	backupRepo    *biz.BackupUsecase
+	taskRepo      *biz.TaskUsecase
+	recordingRepo *biz.TerminalRecordingUsecase
 }
 
-func NewWsService(backupUsecase *biz.BackupUsecase, certUsecase *biz.CertUsecase, sshUsecase *biz.SSHUsecase, settingUsecase *biz.SettingUsecase, taskUsecase *biz.TaskUsecase, conf *config.Config, t *gotext.Locale, log *slog.Logger) *WsService {
+func NewWsService(backupUsecase *biz.BackupUsecase, certUsecase *biz.CertUsecase, sshUsecase *biz.SSHUsecase, settingUsecase *biz.SettingUsecase, taskUsecase *biz.TaskUsecase, recordingUsecase *biz.TerminalRecordingUsecase, conf *config.Config, t *gotext.Locale, log *slog.Logger) *WsService {
 	return &WsService{
-		t:           t,
-		conf:        conf,
-		log:         log,
-		api:         api.NewAPI(app.Version, app.Locale),
-		sshRepo:     sshUsecase,
-		settingRepo: settingUsecase,
-		certRepo:    certUsecase,
-		backupRepo:  backupUsecase,
-		taskRepo:    taskUsecase,
+		t:             t,
+		conf:          conf,
+		log:           log,
+		api:           api.NewAPI(app.Version, app.Locale),
+		sshRepo:       sshUsecase,
+		settingRepo:   settingUsecase,
+		certRepo:      certUsecase,
+		backupRepo:    backupUsecase,
+		taskRepo:      taskUsecase,
+		recordingRepo: recordingUsecase,
 	}
 }
 
@@ -175,6 +177,14 @@ func (s *WsService) Follow(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// stopSession 提示原因后结束终端会话
+func (s *WsService) stopSession(ctx context.Context, ws *websocket.Conn, cancel context.CancelFunc) func(error) {
+	return func(err error) {
+		_ = ws.Write(ctx, websocket.MessageBinary, []byte("\r\n"+err.Error()+"\r\n"))
+		cancel()
+	}
+}
+
 // PTY 通用 PTY 命令执行
 // 前端发送第一条消息为要执行的命令，后端通过 PTY 执行并实时返回输出
 func (s *WsService) PTY(w http.ResponseWriter, r *http.Request) {
@@ -200,8 +210,16 @@ func (s *WsService) PTY(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 按设置录制会话，强制录制但无法录制时拒绝执行
+	recording, rec, err := s.recordingRepo.Start(ctx, biz.TerminalRecordingTypePTY, command, clientIP(r, s.conf.HTTP.IPHeader), s.stopSession(ctx, ws, cancel))
+	if err != nil {
+		_ = ws.Close(websocket.StatusNormalClosure, err.Error())
+		return
+	}
+	defer s.recordingRepo.Finish(recording, rec)
+
 	// PTY 执行命令
-	turn, err := shell.NewPTYTurn(ctx, ws, command)
+	turn, err := shell.NewPTYTurn(ctx, ws, rec, command)
 	if err != nil {
 		_ = ws.Write(ctx, websocket.MessageBinary, []byte("\r\n"+s.t.Get("Failed to start command: %v", err)+"\r\n"))
 		_ = ws.Close(websocket.StatusNormalClosure, "")
@@ -245,7 +263,14 @@ func (s *WsService) Session(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
 
-	turn, err := ssh.NewTurn(ctx, ws, sshClient)
+	recording, rec, err := s.recordingRepo.Start(ctx, biz.TerminalRecordingTypeSSH, fmt.Sprintf("%s@%s", info.Config.User, info.Config.Host), clientIP(r, s.conf.HTTP.IPHeader), s.stopSession(ctx, ws, cancel))
+	if err != nil {
+		_ = ws.Close(websocket.StatusNormalClosure, err.Error())
+		return
+	}
+	defer s.recordingRepo.Finish(recording, rec)
+
+	turn, err := ssh.NewTurn(ctx, ws, sshClient, rec)
 	if err != nil {
 		_ = ws.Close(websocket.StatusNormalClosure, err.Error())
 		return
@@ -354,8 +379,15 @@ func (s *WsService) ContainerTerminal(w http.ResponseWriter, r *http.Request) {
 
 	sock := s.getContainerSock()
 
+	recording, rec, err := s.recordingRepo.Start(ctx, biz.TerminalRecordingTypeContainer, req.ID, clientIP(r, s.conf.HTTP.IPHeader), s.stopSession(ctx, ws, cancel))
+	if err != nil {
+		_ = ws.Close(websocket.StatusNormalClosure, err.Error())
+		return
+	}
+	defer s.recordingRepo.Finish(recording, rec)
+
 	// 通过 /bin/sh 启动，自动尝试切换到 bash，不存在则留在 sh
-	turn, err := docker.NewTurn(ctx, ws, req.ID, []string{"/bin/sh", "-c", "exec bash 2>/dev/null || exec sh"}, sock)
+	turn, err := docker.NewTurn(ctx, ws, req.ID, []string{"/bin/sh", "-c", "exec bash 2>/dev/null || exec sh"}, sock, rec)
 	if err != nil {
 		_ = ws.Close(websocket.StatusNormalClosure, s.t.Get("failed to start container terminal: %v", err))
 		return
@@ -2,18 +2,19 @@ package taskqueue
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/leonelquinteros/gotext"
 	"gorm.io/gorm"
 
-	"github.com/acepanel/panel/v3/internal/app"
 	"github.com/acepanel/panel/v3/internal/biz"
 	"github.com/acepanel/panel/v3/pkg/shell"
 )
@@ -23,6 +24,21 @@ type Notifier interface {
 	SendEvent(event biz.NotifyEvent, subject, body string)
 }
 
+// 任务结束原因，区分用户取消与超时
+var (
+	errCanceled = errors.New("task canceled")
+	errTimeout  = errors.New("task timed out")
+)
+
+const (
+	// maxWorkers 同时执行任务数的上限
+	maxWorkers = 16
+	// retryBackoff 首次重试的等待时间，之后每次翻倍
+	retryBackoff = 30 * time.Second
+	// maxRetryBackoff 重试等待时间上限
+	maxRetryBackoff = 10 * time.Minute
+)
+
 type Runner struct {
 	db       *gorm.DB
 	log      *slog.Logger
@@ -30,9 +46,14 @@ type Runner struct {
 	t        *gotext.Locale
 	notify   chan struct{}
 
-	mu            sync.Mutex
-	currentID     uint               // 当前运行的任务 ID
-	currentCancel context.CancelFunc // 取消当前任务
+	mu      sync.Mutex
+	running map[uint]*running // 运行中的任务，供 Cancel 定位与资源互斥
+}
+
+// running 运行中的任务
+type running struct {
+	lane   string
+	cancel context.CancelCauseFunc
 }
 
 // NewRunner 创建任务运行器
@@ -43,6 +64,7 @@ func NewRunner(db *gorm.DB, log *slog.Logger, notifier Notifier, t *gotext.Local
 		notifier: notifier,
 		t:        t,
 		notify:   make(chan struct{}, 1),
+		running:  make(map[uint]*running),
 	}
 }
 
@@ -58,10 +80,11 @@ func (r *Runner) Notify() {
 func (r *Runner) Cancel(id uint) bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if r.currentID != id || r.currentCancel == nil {
+	task, ok := r.running[id]
+	if !ok {
 		return false
 	}
-	r.currentCancel()
+	task.cancel(errCanceled)
 	return true
 }
 
@@ -73,6 +96,7 @@ func (r *Runner) Run(ctx context.Context) {
 		// 启动时先尝试处理积压的 waiting 任务
 		r.Notify()
 
+		// 定时调度兜底处理重试退避到期与其他进程写入的任务
 		ticker := time.NewTicker(2 * time.Second)
 		defer ticker.Stop()
 
@@ -81,28 +105,94 @@ func (r *Runner) Run(ctx context.Context) {
 			case <-ctx.Done():
 				return
 			case <-r.notify:
-				r.drain(ctx)
+				r.dispatch(ctx)
 			case <-ticker.C:
-				r.drain(ctx)
+				r.dispatch(ctx)
 			}
 		}
 	}()
 }
 
-// drain 持续处理 waiting 任务直到队列为空或 ctx 取消
-func (r *Runner) drain(ctx context.Context) {
-	for {
-		select {
-		case <-ctx.Done():
+// dispatch 按优先级挑选可执行的 waiting 任务填满空闲的执行位
+// 与运行中任务占用相同资源的任务继续等待，不阻塞其他资源的任务
+func (r *Runner) dispatch(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	r.mu.Lock()
+	free := r.workers() - len(r.running)
+	busy := make(map[string]bool, len(r.running))
+	for _, task := range r.running {
+		busy[task.lane] = true
+	}
+	r.mu.Unlock()
+	if free <= 0 {
+		return
+	}
+
+	var tasks []*biz.Task
+	if err := r.db.Where("status = ? AND (retry_at IS NULL OR retry_at <= ?)", biz.TaskStatusWaiting, time.Now()).
+		Order("priority desc, id asc").Find(&tasks).Error; err != nil {
+		r.log.Error("failed to list waiting tasks", slog.Any("err", err))
+		return
+	}
+
+	for _, task := range tasks {
+		if free <= 0 {
 			return
-		default:
 		}
-		if !r.processNext(ctx) {
-			return
+		lane := task.Resource
+		if busy[lane] {
+			continue
 		}
+		// 同一资源只取优先级最高的一个，其余等它结束；未声明资源的任务共用一条串行通道
+		busy[lane] = true
+
+		// 原子抢占，任务可能在取出后被取消
+		result := r.db.Model(task).Where("status = ?", biz.TaskStatusWaiting).Update("status", biz.TaskStatusRunning)
+		if result.Error != nil {
+			r.log.Error("failed to update task status to running", slog.Any("task_id", task.ID), slog.Any("err", result.Error))
+			continue
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		taskCtx, cancel := context.WithCancelCause(ctx)
+		r.mu.Lock()
+		r.running[task.ID] = &running{lane: lane, cancel: cancel}
+		r.mu.Unlock()
+		free--
+
+		go func() {
+			defer func() {
+				r.mu.Lock()
+				delete(r.running, task.ID)
+				r.mu.Unlock()
+				cancel(nil)
+				// 腾出执行位后立即调度下一个任务
+				r.Notify()
+			}()
+			r.execute(ctx, taskCtx, task)
+		}()
 	}
 }
 
+// workers 同时执行的任务数，每次调度时读取以便设置即时生效
+func (r *Runner) workers() int {
+	var value string
+	if err := r.db.Model(&biz.Setting{}).Where("key = ?", biz.SettingKeyTaskWorkers).Select("value").Scan(&value).Error; err != nil {
+		return biz.TaskWorkersDefault
+	}
+	workers, err := strconv.Atoi(value)
+	if err != nil || workers <= 0 {
+		return biz.TaskWorkersDefault
+	}
+
+	return min(workers, maxWorkers)
+}
+
 // clearZombie 启动时将残留的 running 任务标记为 failed
 func (r *Runner) clearZombie() {
 	if err := r.db.Model(&biz.Task{}).Where("status = ?", biz.TaskStatusRunning).Update("status", biz.TaskStatusFailed).Error; err != nil {
@@ -110,75 +200,89 @@ func (r *Runner) clearZombie() {
 	}
 }
 
-// processNext 取一条 waiting 任务执行，返回是否有任务被处理
-func (r *Runner) processNext(ctx context.Context) bool {
-	task := new(biz.Task)
-	if err := r.db.Where("status = ?", biz.TaskStatusWaiting).Order("id asc").First(task).Error; err != nil {
-		return false
+// execute 执行单个任务，ctx 为运行器的生命周期，taskCtx 另可被取消
+func (r *Runner) execute(ctx, taskCtx context.Context, task *biz.Task) {
+	// 计算日志路径并保存
+	logFile := biz.TaskLogPath(task.ID)
+	_ = os.MkdirAll(filepath.Dir(logFile), 0o700)
+	if err := r.db.Model(task).Update("log", logFile).Error; err != nil {
+		r.log.Error("failed to update task log path", slog.Any("task_id", task.ID), slog.Any("err", err))
+		return
 	}
 
-	r.execute(ctx, task)
-	return true
-}
+	// 重试时追加到同一日志，保留之前的输出
+	flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if task.Attempts > 0 {
+		flag = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(logFile, flag, 0644)
+	if err != nil {
+		r.log.Error("failed to open task log", slog.Any("task_id", task.ID), slog.Any("err", err))
+		_ = r.db.Model(task).Update("status", biz.TaskStatusFailed).Error
+		return
+	}
+	defer func(f *os.File) { _ = f.Close() }(f)
+	if task.Attempts > 0 {
+		_, _ = fmt.Fprintf(f, "\n%s\n", r.t.Get("|-Retry %d/%d", task.Attempts, task.MaxRetries))
+	}
 
-// execute 执行单个任务
-func (r *Runner) execute(ctx context.Context, task *biz.Task) {
-	// 原子抢占，任务可能在取出后被取消
-	result := r.db.Model(task).Where("status = ?", biz.TaskStatusWaiting).Update("status", biz.TaskStatusRunning)
-	if result.Error != nil {
-		r.log.Error("failed to update task status to running", slog.Any("task_id", task.ID), slog.Any("err", result.Error))
+	if task.Timeout > 0 {
+		var cancel context.CancelFunc
+		taskCtx, cancel = context.WithTimeoutCause(taskCtx, time.Duration(task.Timeout)*time.Second, errTimeout)
+		defer cancel()
+	}
+
+	err = shell.ExecWithWriter(taskCtx, task.Shell, f)
+	if err == nil {
+		if err = r.db.Model(task).Update("status", biz.TaskStatusSuccess).Error; err != nil {
+			r.log.Error("failed to update task status to success", slog.Any("task_id", task.ID), slog.Any("err", err))
+		}
 		return
 	}
-	if result.RowsAffected == 0 {
+
+	// 面板停机保持 failed 由下次启动清理语义兜底，不重试
+	if ctx.Err() != nil {
+		r.log.Warn("background task interrupted by shutdown", slog.Any("task_id", task.ID), slog.Any("err", err))
+		_ = r.db.Model(task).Update("status", biz.TaskStatusFailed).Error
 		return
 	}
 
-	// 计算日志路径并保存
-	logDir := filepath.Join(app.Root, "panel/storage/logs/task")
-	_ = os.MkdirAll(logDir, 0o700)
-	logFile := filepath.Join(logDir, fmt.Sprintf("%d.log", task.ID))
-	if err := r.db.Model(task).Update("log", logFile).Error; err != nil {
-		r.log.Error("failed to update task log path", slog.Any("task_id", task.ID), slog.Any("err", err))
+	// 用户取消标记为 canceled，不算故障也不重试
+	if errors.Is(context.Cause(taskCtx), errCanceled) {
+		r.log.Warn("background task canceled", slog.Any("task_id", task.ID))
+		_ = r.db.Model(task).Update("status", biz.TaskStatusCanceled).Error
+		r.runCancelShell(task, logFile)
 		return
 	}
 
-	// 登记当前任务，供 Cancel 定位
-	taskCtx, cancel := context.WithCancel(ctx)
-	r.mu.Lock()
-	r.currentID, r.currentCancel = task.ID, cancel
-	r.mu.Unlock()
-	defer func() {
-		r.mu.Lock()
-		r.currentID, r.currentCancel = 0, nil
-		r.mu.Unlock()
-		cancel()
-	}()
+	if errors.Is(context.Cause(taskCtx), errTimeout) {
+		err = errors.New(r.t.Get("task timed out after %d seconds", task.Timeout))
+		r.runCancelShell(task, logFile)
+	}
+	_, _ = fmt.Fprintf(f, "\n%s\n", err.Error())
 
-	if err := shell.ExecWithLog(taskCtx, task.Shell, logFile); err != nil {
-		// 用户取消标记为 canceled，面板停机保持 failed 由下次启动清理语义兜底
-		status := biz.TaskStatusFailed
-		if taskCtx.Err() != nil && ctx.Err() == nil {
-			status = biz.TaskStatusCanceled
-			r.runCancelShell(task, logFile)
-		}
-		r.log.Warn("background task did not finish", slog.Any("task_id", task.ID), slog.Any("status", status), slog.Any("err", err))
-		_ = r.db.Model(task).Update("status", status).Error
-
-		// 用户主动取消不算故障
-		if status == biz.TaskStatusFailed {
-			r.notifier.SendEvent(biz.NotifyEventTaskFailed, r.t.Get("[AcePanel] Background Task Failed"), biz.NotifyBody(r.t.Get("background task failed"), [][2]string{
-				{r.t.Get("Task"), task.Name},
-				{r.t.Get("Log"), logFile},
-				{r.t.Get("Error"), err.Error()},
-				{r.t.Get("Time"), time.Now().Format(time.DateTime)},
-			}))
+	if task.Attempts < task.MaxRetries {
+		retryAt := time.Now().Add(min(retryBackoff<<task.Attempts, maxRetryBackoff))
+		r.log.Warn("background task failed, will retry", slog.Any("task_id", task.ID), slog.Any("attempts", task.Attempts), slog.Time("retry_at", retryAt), slog.Any("err", err))
+		_, _ = fmt.Fprintf(f, "%s\n", r.t.Get("|-Retrying at %s", retryAt.Format(time.DateTime)))
+		if err = r.db.Model(task).Updates(map[string]any{
+			"status":   biz.TaskStatusWaiting,
+			"attempts": task.Attempts + 1,
+			"retry_at": retryAt,
+		}).Error; err != nil {
+			r.log.Error("failed to schedule task retry", slog.Any("task_id", task.ID), slog.Any("err", err))
 		}
 		return
 	}
 
-	if err := r.db.Model(task).Update("status", biz.TaskStatusSuccess).Error; err != nil {
-		r.log.Error("failed to update task status to success", slog.Any("task_id", task.ID), slog.Any("err", err))
-	}
+	r.log.Warn("background task did not finish", slog.Any("task_id", task.ID), slog.Any("err", err))
+	_ = r.db.Model(task).Update("status", biz.TaskStatusFailed).Error
+	r.notifier.SendEvent(biz.NotifyEventTaskFailed, r.t.Get("[AcePanel] Background Task Failed"), biz.NotifyBody(r.t.Get("background task failed"), [][2]string{
+		{r.t.Get("Task"), task.Name},
+		{r.t.Get("Log"), logFile},
+		{r.t.Get("Error"), err.Error()},
+		{r.t.Get("Time"), time.Now().Format(time.DateTime)},
+	}))
 }
 
 // runCancelShell 任务被取消后执行清理命令，输出追加到任务日志
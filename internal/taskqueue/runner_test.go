@@ -11,11 +11,17 @@ import (
 	"github.com/libtnb/sqlite"
 	"gorm.io/gorm"
 
+	"github.com/acepanel/panel/v3/internal/app"
 	"github.com/acepanel/panel/v3/internal/biz"
 )
 
 func newRunnerForTest(t *testing.T) *Runner {
 	t.Helper()
+	// 任务日志写到 app.Root 下，指向临时目录以免污染源码树
+	root := app.Root
+	app.Root = t.TempDir()
+	t.Cleanup(func() { app.Root = root })
+
 	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{SkipDefaultTransaction: true})
 	if err != nil {
 		t.Fatal(err)
@@ -26,7 +32,7 @@ func newRunnerForTest(t *testing.T) *Runner {
 		t.Fatal(err)
 	}
 	sqlDB.SetMaxOpenConns(1)
-	if err = db.AutoMigrate(&biz.Task{}); err != nil {
+	if err = db.AutoMigrate(&biz.Task{}, &biz.Setting{}); err != nil {
 		t.Fatal(err)
 	}
 	return NewRunner(db, slog.New(slog.NewTextHandler(os.Stderr, nil)), stubNotifier{}, gotext.NewLocale("", "en"))
@@ -117,3 +123,111 @@ func TestRunnerWaitingCanceledNotExecuted(t *testing.T) {
 		t.Fatalf("canceled task should stay canceled, got %s", got.Status)
 	}
 }
+
+func TestRunnerLanes(t *testing.T) {
+	r := newRunnerForTest(t)
+	if err := r.db.Create(&biz.Setting{Key: biz.SettingKeyTaskWorkers, Value: "4"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 同资源任务串行，不同资源任务并行，未声明资源的任务之间也串行
+	a1 := &biz.Task{Key: "app:a", Resource: biz.TaskResourcePkg, Status: biz.TaskStatusWaiting, Shell: "sleep 1"}
+	a2 := &biz.Task{Key: "environment:php:84", Resource: biz.TaskResourcePkg, Status: biz.TaskStatusWaiting, Shell: "sleep 1"}
+	b1 := &biz.Task{Key: "deploy:website:1", Resource: "website:1", Status: biz.TaskStatusWaiting, Shell: "sleep 1"}
+	c1 := &biz.Task{Key: "c1", Status: biz.TaskStatusWaiting, Shell: "sleep 1"}
+	c2 := &biz.Task{Key: "c2", Status: biz.TaskStatusWaiting, Shell: "sleep 1"}
+	for _, task := range []*biz.Task{a1, a2, b1, c1, c2} {
+		if err := r.db.Create(task).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r.Run(t.Context())
+
+	waitStatus(t, r.db, a1.ID, biz.TaskStatusRunning, 3*time.Second)
+	waitStatus(t, r.db, b1.ID, biz.TaskStatusRunning, 3*time.Second)
+	waitStatus(t, r.db, c1.ID, biz.TaskStatusRunning, 3*time.Second)
+	for _, id := range []uint{a2.ID, c2.ID} {
+		got := new(biz.Task)
+		if err := r.db.First(got, id).Error; err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != biz.TaskStatusWaiting {
+			t.Fatalf("task %d in the same lane should wait, got %s", id, got.Status)
+		}
+	}
+	waitStatus(t, r.db, a2.ID, biz.TaskStatusSuccess, 5*time.Second)
+	waitStatus(t, r.db, c2.ID, biz.TaskStatusSuccess, 5*time.Second)
+}
+
+func TestRunnerPriority(t *testing.T) {
+	r := newRunnerForTest(t)
+	out := filepath.Join(t.TempDir(), "order")
+
+	// 单执行位下高优先级的任务先执行
+	low := &biz.Task{Status: biz.TaskStatusWaiting, Shell: "echo low >> " + out, Priority: biz.TaskPriorityLow}
+	high := &biz.Task{Status: biz.TaskStatusWaiting, Shell: "echo high >> " + out, Priority: biz.TaskPriorityHigh}
+	for _, task := range []*biz.Task{low, high} {
+		if err := r.db.Create(task).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.db.Create(&biz.Setting{Key: biz.SettingKeyTaskWorkers, Value: "1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r.Run(t.Context())
+
+	waitStatus(t, r.db, low.ID, biz.TaskStatusSuccess, 3*time.Second)
+	order, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(order) != "high\nlow\n" {
+		t.Fatalf("unexpected order %q", order)
+	}
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	r := newRunnerForTest(t)
+
+	task := &biz.Task{Name: "sleep", Status: biz.TaskStatusWaiting, Shell: "sleep 60", Timeout: 1}
+	if err := r.db.Create(task).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r.Run(t.Context())
+
+	waitStatus(t, r.db, task.ID, biz.TaskStatusFailed, 5*time.Second)
+}
+
+func TestRunnerRetry(t *testing.T) {
+	r := newRunnerForTest(t)
+	marker := filepath.Join(t.TempDir(), "attempted")
+
+	// 首次失败后进入退避等待，到期后重试成功
+	task := &biz.Task{Name: "flaky", Status: biz.TaskStatusWaiting, Shell: "test -e " + marker + " || { touch " + marker + "; exit 1; }", MaxRetries: 2}
+	if err := r.db.Create(task).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r.Run(t.Context())
+
+	deadline := time.Now().Add(3 * time.Second)
+	got := new(biz.Task)
+	for time.Now().Before(deadline) {
+		if err := r.db.First(got, task.ID).Error; err == nil && got.Attempts == 1 && got.Status == biz.TaskStatusWaiting {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if got.Attempts != 1 || got.RetryAt == nil || time.Until(*got.RetryAt) < 20*time.Second {
+		t.Fatalf("task should wait for backoff, attempts %d, retry at %v", got.Attempts, got.RetryAt)
+	}
+
+	if err := r.db.Model(got).Update("retry_at", time.Now()).Error; err != nil {
+		t.Fatal(err)
+	}
+	r.Notify()
+	waitStatus(t, r.db, task.ID, biz.TaskStatusSuccess, 3*time.Second)
+}
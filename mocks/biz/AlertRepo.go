@@ -182,6 +182,63 @@ func (_c *AlertRepo_CertExpiry_Call) RunAndReturn(run func() ([]*biz.AlertMetric
 	return _c
 }
 
+// CertMonitors provides a mock function with no fields
+func (_m *AlertRepo) CertMonitors() ([]*biz.CertMonitor, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CertMonitors")
+	}
+
+	var r0 []*biz.CertMonitor
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*biz.CertMonitor, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*biz.CertMonitor); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.CertMonitor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AlertRepo_CertMonitors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CertMonitors'
+type AlertRepo_CertMonitors_Call struct {
+	*mock.Call
+}
+
+// CertMonitors is a helper method to define mock.On call
+func (_e *AlertRepo_Expecter) CertMonitors() *AlertRepo_CertMonitors_Call {
+	return &AlertRepo_CertMonitors_Call{Call: _e.mock.On("CertMonitors")}
+}
+
+func (_c *AlertRepo_CertMonitors_Call) Run(run func()) *AlertRepo_CertMonitors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AlertRepo_CertMonitors_Call) Return(_a0 []*biz.CertMonitor, _a1 error) *AlertRepo_CertMonitors_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AlertRepo_CertMonitors_Call) RunAndReturn(run func() ([]*biz.CertMonitor, error)) *AlertRepo_CertMonitors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ClearAlerts provides a mock function with no fields
 func (_m *AlertRepo) ClearAlerts() error {
 	ret := _m.Called()
@@ -612,6 +669,63 @@ func (_c *AlertRepo_ListRules_Call) RunAndReturn(run func(uint, uint) ([]*biz.Al
 	return _c
 }
 
+// Probes provides a mock function with no fields
+func (_m *AlertRepo) Probes() ([]*biz.Probe, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Probes")
+	}
+
+	var r0 []*biz.Probe
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*biz.Probe, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*biz.Probe); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.Probe)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AlertRepo_Probes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Probes'
+type AlertRepo_Probes_Call struct {
+	*mock.Call
+}
+
+// Probes is a helper method to define mock.On call
+func (_e *AlertRepo_Expecter) Probes() *AlertRepo_Probes_Call {
+	return &AlertRepo_Probes_Call{Call: _e.mock.On("Probes")}
+}
+
+func (_c *AlertRepo_Probes_Call) Run(run func()) *AlertRepo_Probes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AlertRepo_Probes_Call) Return(_a0 []*biz.Probe, _a1 error) *AlertRepo_Probes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AlertRepo_Probes_Call) RunAndReturn(run func() ([]*biz.Probe, error)) *AlertRepo_Probes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ProjectNames provides a mock function with no fields
 func (_m *AlertRepo) ProjectNames() ([]string, error) {
 	ret := _m.Called()
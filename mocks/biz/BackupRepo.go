@@ -25,6 +25,54 @@ func (_m *BackupRepo) EXPECT() *BackupRepo_Expecter {
 	return &BackupRepo_Expecter{mock: &_m.Mock}
 }
 
+// CheckArchive provides a mock function with given fields: storage, typ, name
+func (_m *BackupRepo) CheckArchive(storage uint, typ biz.BackupType, name string) error {
+	ret := _m.Called(storage, typ, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckArchive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, biz.BackupType, string) error); ok {
+		r0 = rf(storage, typ, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BackupRepo_CheckArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckArchive'
+type BackupRepo_CheckArchive_Call struct {
+	*mock.Call
+}
+
+// CheckArchive is a helper method to define mock.On call
+//   - storage uint
+//   - typ biz.BackupType
+//   - name string
+func (_e *BackupRepo_Expecter) CheckArchive(storage interface{}, typ interface{}, name interface{}) *BackupRepo_CheckArchive_Call {
+	return &BackupRepo_CheckArchive_Call{Call: _e.mock.On("CheckArchive", storage, typ, name)}
+}
+
+func (_c *BackupRepo_CheckArchive_Call) Run(run func(storage uint, typ biz.BackupType, name string)) *BackupRepo_CheckArchive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(biz.BackupType), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_CheckArchive_Call) Return(_a0 error) *BackupRepo_CheckArchive_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BackupRepo_CheckArchive_Call) RunAndReturn(run func(uint, biz.BackupType, string) error) *BackupRepo_CheckArchive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ClearExpired provides a mock function with given fields: path, prefix, save
 func (_m *BackupRepo) ClearExpired(path string, prefix string, save uint) error {
 	ret := _m.Called(path, prefix, save)
@@ -122,17 +170,17 @@ func (_c *BackupRepo_ClearStorageExpired_Call) RunAndReturn(run func(uint, strin
 	return _c
 }
 
-// Create provides a mock function with given fields: ctx, typ, target, account
-func (_m *BackupRepo) Create(ctx context.Context, typ biz.BackupType, target string, account uint) error {
-	ret := _m.Called(ctx, typ, target, account)
+// Create provides a mock function with given fields: ctx, typ, target, account, dedup
+func (_m *BackupRepo) Create(ctx context.Context, typ biz.BackupType, target string, account uint, dedup bool) error {
+	ret := _m.Called(ctx, typ, target, account, dedup)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, biz.BackupType, string, uint) error); ok {
-		r0 = rf(ctx, typ, target, account)
+	if rf, ok := ret.Get(0).(func(context.Context, biz.BackupType, string, uint, bool) error); ok {
+		r0 = rf(ctx, typ, target, account, dedup)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -150,13 +198,14 @@ type BackupRepo_Create_Call struct {
 //   - typ biz.BackupType
 //   - target string
 //   - account uint
-func (_e *BackupRepo_Expecter) Create(ctx interface{}, typ interface{}, target interface{}, account interface{}) *BackupRepo_Create_Call {
-	return &BackupRepo_Create_Call{Call: _e.mock.On("Create", ctx, typ, target, account)}
+//   - dedup bool
+func (_e *BackupRepo_Expecter) Create(ctx interface{}, typ interface{}, target interface{}, account interface{}, dedup interface{}) *BackupRepo_Create_Call {
+	return &BackupRepo_Create_Call{Call: _e.mock.On("Create", ctx, typ, target, account, dedup)}
 }
 
-func (_c *BackupRepo_Create_Call) Run(run func(ctx context.Context, typ biz.BackupType, target string, account uint)) *BackupRepo_Create_Call {
+func (_c *BackupRepo_Create_Call) Run(run func(ctx context.Context, typ biz.BackupType, target string, account uint, dedup bool)) *BackupRepo_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(biz.BackupType), args[2].(string), args[3].(uint))
+		run(args[0].(context.Context), args[1].(biz.BackupType), args[2].(string), args[3].(uint), args[4].(bool))
 	})
 	return _c
 }
@@ -166,7 +215,7 @@ func (_c *BackupRepo_Create_Call) Return(_a0 error) *BackupRepo_Create_Call {
 	return _c
 }
 
-func (_c *BackupRepo_Create_Call) RunAndReturn(run func(context.Context, biz.BackupType, string, uint) error) *BackupRepo_Create_Call {
+func (_c *BackupRepo_Create_Call) RunAndReturn(run func(context.Context, biz.BackupType, string, uint, bool) error) *BackupRepo_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -322,6 +371,64 @@ func (_c *BackupRepo_CutoffUpload_Call) RunAndReturn(run func(uint, biz.BackupTy
 	return _c
 }
 
+// Decrypt provides a mock function with given fields: typ, backup, identity
+func (_m *BackupRepo) Decrypt(typ biz.BackupType, backup string, identity string) (string, error) {
+	ret := _m.Called(typ, backup, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decrypt")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(biz.BackupType, string, string) (string, error)); ok {
+		return rf(typ, backup, identity)
+	}
+	if rf, ok := ret.Get(0).(func(biz.BackupType, string, string) string); ok {
+		r0 = rf(typ, backup, identity)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(biz.BackupType, string, string) error); ok {
+		r1 = rf(typ, backup, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BackupRepo_Decrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrypt'
+type BackupRepo_Decrypt_Call struct {
+	*mock.Call
+}
+
+// Decrypt is a helper method to define mock.On call
+//   - typ biz.BackupType
+//   - backup string
+//   - identity string
+func (_e *BackupRepo_Expecter) Decrypt(typ interface{}, backup interface{}, identity interface{}) *BackupRepo_Decrypt_Call {
+	return &BackupRepo_Decrypt_Call{Call: _e.mock.On("Decrypt", typ, backup, identity)}
+}
+
+func (_c *BackupRepo_Decrypt_Call) Run(run func(typ biz.BackupType, backup string, identity string)) *BackupRepo_Decrypt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(biz.BackupType), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_Decrypt_Call) Return(_a0 string, _a1 error) *BackupRepo_Decrypt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BackupRepo_Decrypt_Call) RunAndReturn(run func(biz.BackupType, string, string) (string, error)) *BackupRepo_Decrypt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: typ, name
 func (_m *BackupRepo) Delete(typ biz.BackupType, name string) error {
 	ret := _m.Called(typ, name)
@@ -576,6 +683,124 @@ func (_c *BackupRepo_List_Call) RunAndReturn(run func(biz.BackupType) ([]*types.
 	return _c
 }
 
+// ListSnapshots provides a mock function with given fields: storage, typ
+func (_m *BackupRepo) ListSnapshots(storage uint, typ biz.BackupType) ([]*types.BackupFile, error) {
+	ret := _m.Called(storage, typ)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSnapshots")
+	}
+
+	var r0 []*types.BackupFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, biz.BackupType) ([]*types.BackupFile, error)); ok {
+		return rf(storage, typ)
+	}
+	if rf, ok := ret.Get(0).(func(uint, biz.BackupType) []*types.BackupFile); ok {
+		r0 = rf(storage, typ)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.BackupFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, biz.BackupType) error); ok {
+		r1 = rf(storage, typ)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BackupRepo_ListSnapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSnapshots'
+type BackupRepo_ListSnapshots_Call struct {
+	*mock.Call
+}
+
+// ListSnapshots is a helper method to define mock.On call
+//   - storage uint
+//   - typ biz.BackupType
+func (_e *BackupRepo_Expecter) ListSnapshots(storage interface{}, typ interface{}) *BackupRepo_ListSnapshots_Call {
+	return &BackupRepo_ListSnapshots_Call{Call: _e.mock.On("ListSnapshots", storage, typ)}
+}
+
+func (_c *BackupRepo_ListSnapshots_Call) Run(run func(storage uint, typ biz.BackupType)) *BackupRepo_ListSnapshots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(biz.BackupType))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_ListSnapshots_Call) Return(_a0 []*types.BackupFile, _a1 error) *BackupRepo_ListSnapshots_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BackupRepo_ListSnapshots_Call) RunAndReturn(run func(uint, biz.BackupType) ([]*types.BackupFile, error)) *BackupRepo_ListSnapshots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStorage provides a mock function with given fields: storage, typ
+func (_m *BackupRepo) ListStorage(storage uint, typ biz.BackupType) ([]*types.BackupFile, error) {
+	ret := _m.Called(storage, typ)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStorage")
+	}
+
+	var r0 []*types.BackupFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, biz.BackupType) ([]*types.BackupFile, error)); ok {
+		return rf(storage, typ)
+	}
+	if rf, ok := ret.Get(0).(func(uint, biz.BackupType) []*types.BackupFile); ok {
+		r0 = rf(storage, typ)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.BackupFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, biz.BackupType) error); ok {
+		r1 = rf(storage, typ)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BackupRepo_ListStorage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStorage'
+type BackupRepo_ListStorage_Call struct {
+	*mock.Call
+}
+
+// ListStorage is a helper method to define mock.On call
+//   - storage uint
+//   - typ biz.BackupType
+func (_e *BackupRepo_Expecter) ListStorage(storage interface{}, typ interface{}) *BackupRepo_ListStorage_Call {
+	return &BackupRepo_ListStorage_Call{Call: _e.mock.On("ListStorage", storage, typ)}
+}
+
+func (_c *BackupRepo_ListStorage_Call) Run(run func(storage uint, typ biz.BackupType)) *BackupRepo_ListStorage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(biz.BackupType))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_ListStorage_Call) Return(_a0 []*types.BackupFile, _a1 error) *BackupRepo_ListStorage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BackupRepo_ListStorage_Call) RunAndReturn(run func(uint, biz.BackupType) ([]*types.BackupFile, error)) *BackupRepo_ListStorage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Restore provides a mock function with given fields: typ, backup, target
 func (_m *BackupRepo) Restore(typ biz.BackupType, backup string, target string) error {
 	ret := _m.Called(typ, backup, target)
@@ -624,6 +849,113 @@ func (_c *BackupRepo_Restore_Call) RunAndReturn(run func(biz.BackupType, string,
 	return _c
 }
 
+// RestoreSnapshot provides a mock function with given fields: storage, typ, name, target
+func (_m *BackupRepo) RestoreSnapshot(storage uint, typ biz.BackupType, name string, target string) error {
+	ret := _m.Called(storage, typ, name, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, biz.BackupType, string, string) error); ok {
+		r0 = rf(storage, typ, name, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BackupRepo_RestoreSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSnapshot'
+type BackupRepo_RestoreSnapshot_Call struct {
+	*mock.Call
+}
+
+// RestoreSnapshot is a helper method to define mock.On call
+//   - storage uint
+//   - typ biz.BackupType
+//   - name string
+//   - target string
+func (_e *BackupRepo_Expecter) RestoreSnapshot(storage interface{}, typ interface{}, name interface{}, target interface{}) *BackupRepo_RestoreSnapshot_Call {
+	return &BackupRepo_RestoreSnapshot_Call{Call: _e.mock.On("RestoreSnapshot", storage, typ, name, target)}
+}
+
+func (_c *BackupRepo_RestoreSnapshot_Call) Run(run func(storage uint, typ biz.BackupType, name string, target string)) *BackupRepo_RestoreSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(biz.BackupType), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_RestoreSnapshot_Call) Return(_a0 error) *BackupRepo_RestoreSnapshot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BackupRepo_RestoreSnapshot_Call) RunAndReturn(run func(uint, biz.BackupType, string, string) error) *BackupRepo_RestoreSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TestRestore provides a mock function with given fields: storage, typ, target
+func (_m *BackupRepo) TestRestore(storage uint, typ biz.BackupType, target string) (string, error) {
+	ret := _m.Called(storage, typ, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TestRestore")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, biz.BackupType, string) (string, error)); ok {
+		return rf(storage, typ, target)
+	}
+	if rf, ok := ret.Get(0).(func(uint, biz.BackupType, string) string); ok {
+		r0 = rf(storage, typ, target)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, biz.BackupType, string) error); ok {
+		r1 = rf(storage, typ, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BackupRepo_TestRestore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TestRestore'
+type BackupRepo_TestRestore_Call struct {
+	*mock.Call
+}
+
+// TestRestore is a helper method to define mock.On call
+//   - storage uint
+//   - typ biz.BackupType
+//   - target string
+func (_e *BackupRepo_Expecter) TestRestore(storage interface{}, typ interface{}, target interface{}) *BackupRepo_TestRestore_Call {
+	return &BackupRepo_TestRestore_Call{Call: _e.mock.On("TestRestore", storage, typ, target)}
+}
+
+func (_c *BackupRepo_TestRestore_Call) Run(run func(storage uint, typ biz.BackupType, target string)) *BackupRepo_TestRestore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(biz.BackupType), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_TestRestore_Call) Return(_a0 string, _a1 error) *BackupRepo_TestRestore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BackupRepo_TestRestore_Call) RunAndReturn(run func(uint, biz.BackupType, string) (string, error)) *BackupRepo_TestRestore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePanel provides a mock function with given fields: version, url, checksum, progress
 func (_m *BackupRepo) UpdatePanel(version string, url string, checksum string, progress func(string)) error {
 	ret := _m.Called(version, url, checksum, progress)
@@ -673,6 +1005,67 @@ func (_c *BackupRepo_UpdatePanel_Call) RunAndReturn(run func(string, string, str
 	return _c
 }
 
+// Verify provides a mock function with given fields: storage, typ, name, identity
+func (_m *BackupRepo) Verify(storage uint, typ biz.BackupType, name string, identity string) (*types.BackupVerify, error) {
+	ret := _m.Called(storage, typ, name, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *types.BackupVerify
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, biz.BackupType, string, string) (*types.BackupVerify, error)); ok {
+		return rf(storage, typ, name, identity)
+	}
+	if rf, ok := ret.Get(0).(func(uint, biz.BackupType, string, string) *types.BackupVerify); ok {
+		r0 = rf(storage, typ, name, identity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BackupVerify)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, biz.BackupType, string, string) error); ok {
+		r1 = rf(storage, typ, name, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BackupRepo_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type BackupRepo_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - storage uint
+//   - typ biz.BackupType
+//   - name string
+//   - identity string
+func (_e *BackupRepo_Expecter) Verify(storage interface{}, typ interface{}, name interface{}, identity interface{}) *BackupRepo_Verify_Call {
+	return &BackupRepo_Verify_Call{Call: _e.mock.On("Verify", storage, typ, name, identity)}
+}
+
+func (_c *BackupRepo_Verify_Call) Run(run func(storage uint, typ biz.BackupType, name string, identity string)) *BackupRepo_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(biz.BackupType), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_Verify_Call) Return(_a0 *types.BackupVerify, _a1 error) *BackupRepo_Verify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BackupRepo_Verify_Call) RunAndReturn(run func(uint, biz.BackupType, string, string) (*types.BackupVerify, error)) *BackupRepo_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewBackupRepo creates a new instance of BackupRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewBackupRepo(t interface {
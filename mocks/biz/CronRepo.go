@@ -4,8 +4,15 @@ package biz
 
 import (
 	biz "github.com/acepanel/panel/v3/internal/biz"
+
+	context "context"
+
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/acepanel/panel/v3/pkg/types"
 )
 
@@ -68,6 +75,62 @@ func (_c *CronRepo_AddToSystem_Call) RunAndReturn(run func(*biz.Cron) error) *Cr
 	return _c
 }
 
+// ClearRunsBefore provides a mock function with given fields: t
+func (_m *CronRepo) ClearRunsBefore(t time.Time) (int64, error) {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearRunsBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(t)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CronRepo_ClearRunsBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearRunsBefore'
+type CronRepo_ClearRunsBefore_Call struct {
+	*mock.Call
+}
+
+// ClearRunsBefore is a helper method to define mock.On call
+//   - t time.Time
+func (_e *CronRepo_Expecter) ClearRunsBefore(t interface{}) *CronRepo_ClearRunsBefore_Call {
+	return &CronRepo_ClearRunsBefore_Call{Call: _e.mock.On("ClearRunsBefore", t)}
+}
+
+func (_c *CronRepo_ClearRunsBefore_Call) Run(run func(t time.Time)) *CronRepo_ClearRunsBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CronRepo_ClearRunsBefore_Call) Return(_a0 int64, _a1 error) *CronRepo_ClearRunsBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CronRepo_ClearRunsBefore_Call) RunAndReturn(run func(time.Time) (int64, error)) *CronRepo_ClearRunsBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Count provides a mock function with no fields
 func (_m *CronRepo) Count() (int64, error) {
 	ret := _m.Called()
@@ -169,6 +232,52 @@ func (_c *CronRepo_Create_Call) RunAndReturn(run func(*biz.Cron) error) *CronRep
 	return _c
 }
 
+// CreateRun provides a mock function with given fields: run
+func (_m *CronRepo) CreateRun(run *biz.CronRun) error {
+	ret := _m.Called(run)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*biz.CronRun) error); ok {
+		r0 = rf(run)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CronRepo_CreateRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRun'
+type CronRepo_CreateRun_Call struct {
+	*mock.Call
+}
+
+// CreateRun is a helper method to define mock.On call
+//   - run *biz.CronRun
+func (_e *CronRepo_Expecter) CreateRun(run interface{}) *CronRepo_CreateRun_Call {
+	return &CronRepo_CreateRun_Call{Call: _e.mock.On("CreateRun", run)}
+}
+
+func (_c *CronRepo_CreateRun_Call) Run(run func(run *biz.CronRun)) *CronRepo_CreateRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*biz.CronRun))
+	})
+	return _c
+}
+
+func (_c *CronRepo_CreateRun_Call) Return(_a0 error) *CronRepo_CreateRun_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CronRepo_CreateRun_Call) RunAndReturn(run func(*biz.CronRun) error) *CronRepo_CreateRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: cron
 func (_m *CronRepo) Delete(cron *biz.Cron) error {
 	ret := _m.Called(cron)
@@ -307,6 +416,71 @@ func (_c *CronRepo_Dos2Unix_Call) RunAndReturn(run func(string) error) *CronRepo
 	return _c
 }
 
+// Exec provides a mock function with given fields: ctx, cron, out
+func (_m *CronRepo) Exec(ctx context.Context, cron *biz.Cron, out io.Writer) (int, bool, error) {
+	ret := _m.Called(ctx, cron, out)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 int
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *biz.Cron, io.Writer) (int, bool, error)); ok {
+		return rf(ctx, cron, out)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *biz.Cron, io.Writer) int); ok {
+		r0 = rf(ctx, cron, out)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *biz.Cron, io.Writer) bool); ok {
+		r1 = rf(ctx, cron, out)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *biz.Cron, io.Writer) error); ok {
+		r2 = rf(ctx, cron, out)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CronRepo_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type CronRepo_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cron *biz.Cron
+//   - out io.Writer
+func (_e *CronRepo_Expecter) Exec(ctx interface{}, cron interface{}, out interface{}) *CronRepo_Exec_Call {
+	return &CronRepo_Exec_Call{Call: _e.mock.On("Exec", ctx, cron, out)}
+}
+
+func (_c *CronRepo_Exec_Call) Run(run func(ctx context.Context, cron *biz.Cron, out io.Writer)) *CronRepo_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*biz.Cron), args[2].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *CronRepo_Exec_Call) Return(_a0 int, _a1 bool, _a2 error) *CronRepo_Exec_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *CronRepo_Exec_Call) RunAndReturn(run func(context.Context, *biz.Cron, io.Writer) (int, bool, error)) *CronRepo_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GenerateScript provides a mock function with given fields: typ, config, rawScript
 func (_m *CronRepo) GenerateScript(typ string, config types.CronConfig, rawScript string) string {
 	ret := _m.Called(typ, config, rawScript)
@@ -413,6 +587,65 @@ func (_c *CronRepo_Get_Call) RunAndReturn(run func(uint) (*biz.Cron, error)) *Cr
 	return _c
 }
 
+// GetRun provides a mock function with given fields: cronID, runID
+func (_m *CronRepo) GetRun(cronID uint, runID uint) (*biz.CronRun, error) {
+	ret := _m.Called(cronID, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRun")
+	}
+
+	var r0 *biz.CronRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*biz.CronRun, error)); ok {
+		return rf(cronID, runID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *biz.CronRun); ok {
+		r0 = rf(cronID, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.CronRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(cronID, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CronRepo_GetRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRun'
+type CronRepo_GetRun_Call struct {
+	*mock.Call
+}
+
+// GetRun is a helper method to define mock.On call
+//   - cronID uint
+//   - runID uint
+func (_e *CronRepo_Expecter) GetRun(cronID interface{}, runID interface{}) *CronRepo_GetRun_Call {
+	return &CronRepo_GetRun_Call{Call: _e.mock.On("GetRun", cronID, runID)}
+}
+
+func (_c *CronRepo_GetRun_Call) Run(run func(cronID uint, runID uint)) *CronRepo_GetRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *CronRepo_GetRun_Call) Return(_a0 *biz.CronRun, _a1 error) *CronRepo_GetRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CronRepo_GetRun_Call) RunAndReturn(run func(uint, uint) (*biz.CronRun, error)) *CronRepo_GetRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function with given fields: page, limit
 func (_m *CronRepo) List(page uint, limit uint) ([]*biz.Cron, int64, error) {
 	ret := _m.Called(page, limit)
@@ -479,6 +712,166 @@ func (_c *CronRepo_List_Call) RunAndReturn(run func(uint, uint) ([]*biz.Cron, in
 	return _c
 }
 
+// ListRuns provides a mock function with given fields: cronID, page, limit
+func (_m *CronRepo) ListRuns(cronID uint, page uint, limit uint) ([]*biz.CronRun, int64, error) {
+	ret := _m.Called(cronID, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRuns")
+	}
+
+	var r0 []*biz.CronRun
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) ([]*biz.CronRun, int64, error)); ok {
+		return rf(cronID, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) []*biz.CronRun); ok {
+		r0 = rf(cronID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.CronRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint, uint) int64); ok {
+		r1 = rf(cronID, page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, uint, uint) error); ok {
+		r2 = rf(cronID, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CronRepo_ListRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRuns'
+type CronRepo_ListRuns_Call struct {
+	*mock.Call
+}
+
+// ListRuns is a helper method to define mock.On call
+//   - cronID uint
+//   - page uint
+//   - limit uint
+func (_e *CronRepo_Expecter) ListRuns(cronID interface{}, page interface{}, limit interface{}) *CronRepo_ListRuns_Call {
+	return &CronRepo_ListRuns_Call{Call: _e.mock.On("ListRuns", cronID, page, limit)}
+}
+
+func (_c *CronRepo_ListRuns_Call) Run(run func(cronID uint, page uint, limit uint)) *CronRepo_ListRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *CronRepo_ListRuns_Call) Return(_a0 []*biz.CronRun, _a1 int64, _a2 error) *CronRepo_ListRuns_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *CronRepo_ListRuns_Call) RunAndReturn(run func(uint, uint, uint) ([]*biz.CronRun, int64, error)) *CronRepo_ListRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneRuns provides a mock function with given fields: cronID, keep
+func (_m *CronRepo) PruneRuns(cronID uint, keep uint) error {
+	ret := _m.Called(cronID, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneRuns")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(cronID, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CronRepo_PruneRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneRuns'
+type CronRepo_PruneRuns_Call struct {
+	*mock.Call
+}
+
+// PruneRuns is a helper method to define mock.On call
+//   - cronID uint
+//   - keep uint
+func (_e *CronRepo_Expecter) PruneRuns(cronID interface{}, keep interface{}) *CronRepo_PruneRuns_Call {
+	return &CronRepo_PruneRuns_Call{Call: _e.mock.On("PruneRuns", cronID, keep)}
+}
+
+func (_c *CronRepo_PruneRuns_Call) Run(run func(cronID uint, keep uint)) *CronRepo_PruneRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *CronRepo_PruneRuns_Call) Return(_a0 error) *CronRepo_PruneRuns_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CronRepo_PruneRuns_Call) RunAndReturn(run func(uint, uint) error) *CronRepo_PruneRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshSystem provides a mock function with given fields: crons
+func (_m *CronRepo) RefreshSystem(crons []*biz.Cron) error {
+	ret := _m.Called(crons)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshSystem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*biz.Cron) error); ok {
+		r0 = rf(crons)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CronRepo_RefreshSystem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshSystem'
+type CronRepo_RefreshSystem_Call struct {
+	*mock.Call
+}
+
+// RefreshSystem is a helper method to define mock.On call
+//   - crons []*biz.Cron
+func (_e *CronRepo_Expecter) RefreshSystem(crons interface{}) *CronRepo_RefreshSystem_Call {
+	return &CronRepo_RefreshSystem_Call{Call: _e.mock.On("RefreshSystem", crons)}
+}
+
+func (_c *CronRepo_RefreshSystem_Call) Run(run func(crons []*biz.Cron)) *CronRepo_RefreshSystem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]*biz.Cron))
+	})
+	return _c
+}
+
+func (_c *CronRepo_RefreshSystem_Call) Return(_a0 error) *CronRepo_RefreshSystem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CronRepo_RefreshSystem_Call) RunAndReturn(run func([]*biz.Cron) error) *CronRepo_RefreshSystem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveScriptFiles provides a mock function with given fields: shellPath
 func (_m *CronRepo) RemoveScriptFiles(shellPath string) error {
 	ret := _m.Called(shellPath)
@@ -525,6 +918,64 @@ func (_c *CronRepo_RemoveScriptFiles_Call) RunAndReturn(run func(string) error)
 	return _c
 }
 
+// RunStats provides a mock function with given fields: cronID
+func (_m *CronRepo) RunStats(cronID uint) (*biz.CronRunStats, error) {
+	ret := _m.Called(cronID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunStats")
+	}
+
+	var r0 *biz.CronRunStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*biz.CronRunStats, error)); ok {
+		return rf(cronID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *biz.CronRunStats); ok {
+		r0 = rf(cronID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.CronRunStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(cronID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CronRepo_RunStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunStats'
+type CronRepo_RunStats_Call struct {
+	*mock.Call
+}
+
+// RunStats is a helper method to define mock.On call
+//   - cronID uint
+func (_e *CronRepo_Expecter) RunStats(cronID interface{}) *CronRepo_RunStats_Call {
+	return &CronRepo_RunStats_Call{Call: _e.mock.On("RunStats", cronID)}
+}
+
+func (_c *CronRepo_RunStats_Call) Run(run func(cronID uint)) *CronRepo_RunStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *CronRepo_RunStats_Call) Return(_a0 *biz.CronRunStats, _a1 error) *CronRepo_RunStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CronRepo_RunStats_Call) RunAndReturn(run func(uint) (*biz.CronRunStats, error)) *CronRepo_RunStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: cron
 func (_m *CronRepo) Save(cron *biz.Cron) error {
 	ret := _m.Called(cron)
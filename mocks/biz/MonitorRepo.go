@@ -7,6 +7,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
+
+	types "github.com/acepanel/panel/v3/pkg/types"
 )
 
 // MonitorRepo is an autogenerated mock type for the MonitorRepo type
@@ -67,17 +69,17 @@ func (_c *MonitorRepo_Clear_Call) RunAndReturn(run func() error) *MonitorRepo_Cl
 	return _c
 }
 
-// ClearBefore provides a mock function with given fields: t
-func (_m *MonitorRepo) ClearBefore(t time.Time) error {
-	ret := _m.Called(t)
+// ClearBefore provides a mock function with given fields: resolution, t
+func (_m *MonitorRepo) ClearBefore(resolution uint, t time.Time) error {
+	ret := _m.Called(resolution, t)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ClearBefore")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
-		r0 = rf(t)
+	if rf, ok := ret.Get(0).(func(uint, time.Time) error); ok {
+		r0 = rf(resolution, t)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -91,14 +93,15 @@ type MonitorRepo_ClearBefore_Call struct {
 }
 
 // ClearBefore is a helper method to define mock.On call
+//   - resolution uint
 //   - t time.Time
-func (_e *MonitorRepo_Expecter) ClearBefore(t interface{}) *MonitorRepo_ClearBefore_Call {
-	return &MonitorRepo_ClearBefore_Call{Call: _e.mock.On("ClearBefore", t)}
+func (_e *MonitorRepo_Expecter) ClearBefore(resolution interface{}, t interface{}) *MonitorRepo_ClearBefore_Call {
+	return &MonitorRepo_ClearBefore_Call{Call: _e.mock.On("ClearBefore", resolution, t)}
 }
 
-func (_c *MonitorRepo_ClearBefore_Call) Run(run func(t time.Time)) *MonitorRepo_ClearBefore_Call {
+func (_c *MonitorRepo_ClearBefore_Call) Run(run func(resolution uint, t time.Time)) *MonitorRepo_ClearBefore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(time.Time))
+		run(args[0].(uint), args[1].(time.Time))
 	})
 	return _c
 }
@@ -108,22 +111,22 @@ func (_c *MonitorRepo_ClearBefore_Call) Return(_a0 error) *MonitorRepo_ClearBefo
 	return _c
 }
 
-func (_c *MonitorRepo_ClearBefore_Call) RunAndReturn(run func(time.Time) error) *MonitorRepo_ClearBefore_Call {
+func (_c *MonitorRepo_ClearBefore_Call) RunAndReturn(run func(uint, time.Time) error) *MonitorRepo_ClearBefore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Create provides a mock function with given fields: monitor
-func (_m *MonitorRepo) Create(monitor *biz.Monitor) error {
-	ret := _m.Called(monitor)
+// Insert provides a mock function with given fields: at, values, processes
+func (_m *MonitorRepo) Insert(at time.Time, values map[string]float64, processes types.TopProcesses) error {
+	ret := _m.Called(at, values, processes)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for Insert")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*biz.Monitor) error); ok {
-		r0 = rf(monitor)
+	if rf, ok := ret.Get(0).(func(time.Time, map[string]float64, types.TopProcesses) error); ok {
+		r0 = rf(at, values, processes)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -131,52 +134,170 @@ func (_m *MonitorRepo) Create(monitor *biz.Monitor) error {
 	return r0
 }
 
-// MonitorRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MonitorRepo_Create_Call struct {
+// MonitorRepo_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type MonitorRepo_Insert_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
-//   - monitor *biz.Monitor
-func (_e *MonitorRepo_Expecter) Create(monitor interface{}) *MonitorRepo_Create_Call {
-	return &MonitorRepo_Create_Call{Call: _e.mock.On("Create", monitor)}
+// Insert is a helper method to define mock.On call
+//   - at time.Time
+//   - values map[string]float64
+//   - processes types.TopProcesses
+func (_e *MonitorRepo_Expecter) Insert(at interface{}, values interface{}, processes interface{}) *MonitorRepo_Insert_Call {
+	return &MonitorRepo_Insert_Call{Call: _e.mock.On("Insert", at, values, processes)}
 }
 
-func (_c *MonitorRepo_Create_Call) Run(run func(monitor *biz.Monitor)) *MonitorRepo_Create_Call {
+func (_c *MonitorRepo_Insert_Call) Run(run func(at time.Time, values map[string]float64, processes types.TopProcesses)) *MonitorRepo_Insert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*biz.Monitor))
+		run(args[0].(time.Time), args[1].(map[string]float64), args[2].(types.TopProcesses))
 	})
 	return _c
 }
 
-func (_c *MonitorRepo_Create_Call) Return(_a0 error) *MonitorRepo_Create_Call {
+func (_c *MonitorRepo_Insert_Call) Return(_a0 error) *MonitorRepo_Insert_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MonitorRepo_Create_Call) RunAndReturn(run func(*biz.Monitor) error) *MonitorRepo_Create_Call {
+func (_c *MonitorRepo_Insert_Call) RunAndReturn(run func(time.Time, map[string]float64, types.TopProcesses) error) *MonitorRepo_Insert_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// List provides a mock function with given fields: start, end
-func (_m *MonitorRepo) List(start time.Time, end time.Time) ([]*biz.Monitor, error) {
-	ret := _m.Called(start, end)
+// Latest provides a mock function with given fields: resolution
+func (_m *MonitorRepo) Latest(resolution uint) (int64, error) {
+	ret := _m.Called(resolution)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Latest")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (int64, error)); ok {
+		return rf(resolution)
+	}
+	if rf, ok := ret.Get(0).(func(uint) int64); ok {
+		r0 = rf(resolution)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(resolution)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MonitorRepo_Latest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Latest'
+type MonitorRepo_Latest_Call struct {
+	*mock.Call
+}
+
+// Latest is a helper method to define mock.On call
+//   - resolution uint
+func (_e *MonitorRepo_Expecter) Latest(resolution interface{}) *MonitorRepo_Latest_Call {
+	return &MonitorRepo_Latest_Call{Call: _e.mock.On("Latest", resolution)}
+}
+
+func (_c *MonitorRepo_Latest_Call) Run(run func(resolution uint)) *MonitorRepo_Latest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MonitorRepo_Latest_Call) Return(_a0 int64, _a1 error) *MonitorRepo_Latest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MonitorRepo_Latest_Call) RunAndReturn(run func(uint) (int64, error)) *MonitorRepo_Latest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: resolution, start, end
+func (_m *MonitorRepo) List(resolution uint, start time.Time, end time.Time) ([]*biz.MonitorPoint, error) {
+	ret := _m.Called(resolution, start, end)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
 	}
 
-	var r0 []*biz.Monitor
+	var r0 []*biz.MonitorPoint
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time) ([]*biz.Monitor, error)); ok {
+	if rf, ok := ret.Get(0).(func(uint, time.Time, time.Time) ([]*biz.MonitorPoint, error)); ok {
+		return rf(resolution, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Time, time.Time) []*biz.MonitorPoint); ok {
+		r0 = rf(resolution, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.MonitorPoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Time, time.Time) error); ok {
+		r1 = rf(resolution, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MonitorRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MonitorRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - resolution uint
+//   - start time.Time
+//   - end time.Time
+func (_e *MonitorRepo_Expecter) List(resolution interface{}, start interface{}, end interface{}) *MonitorRepo_List_Call {
+	return &MonitorRepo_List_Call{Call: _e.mock.On("List", resolution, start, end)}
+}
+
+func (_c *MonitorRepo_List_Call) Run(run func(resolution uint, start time.Time, end time.Time)) *MonitorRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MonitorRepo_List_Call) Return(_a0 []*biz.MonitorPoint, _a1 error) *MonitorRepo_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MonitorRepo_List_Call) RunAndReturn(run func(uint, time.Time, time.Time) ([]*biz.MonitorPoint, error)) *MonitorRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProcesses provides a mock function with given fields: start, end
+func (_m *MonitorRepo) ListProcesses(start time.Time, end time.Time) ([]*biz.MonitorProcess, error) {
+	ret := _m.Called(start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProcesses")
+	}
+
+	var r0 []*biz.MonitorProcess
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) ([]*biz.MonitorProcess, error)); ok {
 		return rf(start, end)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*biz.Monitor); ok {
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*biz.MonitorProcess); ok {
 		r0 = rf(start, end)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*biz.Monitor)
+			r0 = ret.Get(0).([]*biz.MonitorProcess)
 		}
 	}
 
@@ -189,31 +310,81 @@ func (_m *MonitorRepo) List(start time.Time, end time.Time) ([]*biz.Monitor, err
 	return r0, r1
 }
 
-// MonitorRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
-type MonitorRepo_List_Call struct {
+// MonitorRepo_ListProcesses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProcesses'
+type MonitorRepo_ListProcesses_Call struct {
 	*mock.Call
 }
 
-// List is a helper method to define mock.On call
+// ListProcesses is a helper method to define mock.On call
 //   - start time.Time
 //   - end time.Time
-func (_e *MonitorRepo_Expecter) List(start interface{}, end interface{}) *MonitorRepo_List_Call {
-	return &MonitorRepo_List_Call{Call: _e.mock.On("List", start, end)}
+func (_e *MonitorRepo_Expecter) ListProcesses(start interface{}, end interface{}) *MonitorRepo_ListProcesses_Call {
+	return &MonitorRepo_ListProcesses_Call{Call: _e.mock.On("ListProcesses", start, end)}
 }
 
-func (_c *MonitorRepo_List_Call) Run(run func(start time.Time, end time.Time)) *MonitorRepo_List_Call {
+func (_c *MonitorRepo_ListProcesses_Call) Run(run func(start time.Time, end time.Time)) *MonitorRepo_ListProcesses_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(time.Time), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MonitorRepo_List_Call) Return(_a0 []*biz.Monitor, _a1 error) *MonitorRepo_List_Call {
+func (_c *MonitorRepo_ListProcesses_Call) Return(_a0 []*biz.MonitorProcess, _a1 error) *MonitorRepo_ListProcesses_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MonitorRepo_List_Call) RunAndReturn(run func(time.Time, time.Time) ([]*biz.Monitor, error)) *MonitorRepo_List_Call {
+func (_c *MonitorRepo_ListProcesses_Call) RunAndReturn(run func(time.Time, time.Time) ([]*biz.MonitorProcess, error)) *MonitorRepo_ListProcesses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rollup provides a mock function with given fields: from, to, start, end, offset
+func (_m *MonitorRepo) Rollup(from uint, to uint, start int64, end int64, offset int64) error {
+	ret := _m.Called(from, to, start, end, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rollup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, int64, int64, int64) error); ok {
+		r0 = rf(from, to, start, end, offset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MonitorRepo_Rollup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rollup'
+type MonitorRepo_Rollup_Call struct {
+	*mock.Call
+}
+
+// Rollup is a helper method to define mock.On call
+//   - from uint
+//   - to uint
+//   - start int64
+//   - end int64
+//   - offset int64
+func (_e *MonitorRepo_Expecter) Rollup(from interface{}, to interface{}, start interface{}, end interface{}, offset interface{}) *MonitorRepo_Rollup_Call {
+	return &MonitorRepo_Rollup_Call{Call: _e.mock.On("Rollup", from, to, start, end, offset)}
+}
+
+func (_c *MonitorRepo_Rollup_Call) Run(run func(from uint, to uint, start int64, end int64, offset int64)) *MonitorRepo_Rollup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(int64), args[3].(int64), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MonitorRepo_Rollup_Call) Return(_a0 error) *MonitorRepo_Rollup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MonitorRepo_Rollup_Call) RunAndReturn(run func(uint, uint, int64, int64, int64) error) *MonitorRepo_Rollup_Call {
 	_c.Call.Return(run)
 	return _c
 }
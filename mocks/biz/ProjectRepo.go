@@ -3,7 +3,12 @@
 package biz
 
 import (
+	context "context"
+
 	biz "github.com/acepanel/panel/v3/internal/biz"
+
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
 	request "github.com/acepanel/panel/v3/internal/request"
@@ -24,6 +29,53 @@ func (_m *ProjectRepo) EXPECT() *ProjectRepo_Expecter {
 	return &ProjectRepo_Expecter{mock: &_m.Mock}
 }
 
+// BlueGreenRestart provides a mock function with given fields: project, out
+func (_m *ProjectRepo) BlueGreenRestart(project *biz.Project, out io.Writer) error {
+	ret := _m.Called(project, out)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlueGreenRestart")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*biz.Project, io.Writer) error); ok {
+		r0 = rf(project, out)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProjectRepo_BlueGreenRestart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlueGreenRestart'
+type ProjectRepo_BlueGreenRestart_Call struct {
+	*mock.Call
+}
+
+// BlueGreenRestart is a helper method to define mock.On call
+//   - project *biz.Project
+//   - out io.Writer
+func (_e *ProjectRepo_Expecter) BlueGreenRestart(project interface{}, out interface{}) *ProjectRepo_BlueGreenRestart_Call {
+	return &ProjectRepo_BlueGreenRestart_Call{Call: _e.mock.On("BlueGreenRestart", project, out)}
+}
+
+func (_c *ProjectRepo_BlueGreenRestart_Call) Run(run func(project *biz.Project, out io.Writer)) *ProjectRepo_BlueGreenRestart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*biz.Project), args[1].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *ProjectRepo_BlueGreenRestart_Call) Return(_a0 error) *ProjectRepo_BlueGreenRestart_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProjectRepo_BlueGreenRestart_Call) RunAndReturn(run func(*biz.Project, io.Writer) error) *ProjectRepo_BlueGreenRestart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Count provides a mock function with no fields
 func (_m *ProjectRepo) Count() (int64, error) {
 	ret := _m.Called()
@@ -230,9 +282,9 @@ func (_c *ProjectRepo_GetEntity_Call) RunAndReturn(run func(uint) (*biz.Project,
 	return _c
 }
 
-// List provides a mock function with given fields: typ, page, limit
-func (_m *ProjectRepo) List(typ types.ProjectType, page uint, limit uint) ([]*biz.Project, int64, error) {
-	ret := _m.Called(typ, page, limit)
+// List provides a mock function with given fields: ctx, typ, page, limit
+func (_m *ProjectRepo) List(ctx context.Context, typ types.ProjectType, page uint, limit uint) ([]*biz.Project, int64, error) {
+	ret := _m.Called(ctx, typ, page, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -241,25 +293,25 @@ func (_m *ProjectRepo) List(typ types.ProjectType, page uint, limit uint) ([]*bi
 	var r0 []*biz.Project
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(types.ProjectType, uint, uint) ([]*biz.Project, int64, error)); ok {
-		return rf(typ, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, types.ProjectType, uint, uint) ([]*biz.Project, int64, error)); ok {
+		return rf(ctx, typ, page, limit)
 	}
-	if rf, ok := ret.Get(0).(func(types.ProjectType, uint, uint) []*biz.Project); ok {
-		r0 = rf(typ, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, types.ProjectType, uint, uint) []*biz.Project); ok {
+		r0 = rf(ctx, typ, page, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*biz.Project)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(types.ProjectType, uint, uint) int64); ok {
-		r1 = rf(typ, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, types.ProjectType, uint, uint) int64); ok {
+		r1 = rf(ctx, typ, page, limit)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(types.ProjectType, uint, uint) error); ok {
-		r2 = rf(typ, page, limit)
+	if rf, ok := ret.Get(2).(func(context.Context, types.ProjectType, uint, uint) error); ok {
+		r2 = rf(ctx, typ, page, limit)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -273,16 +325,17 @@ type ProjectRepo_List_Call struct {
 }
 
 // List is a helper method to define mock.On call
+//   - ctx context.Context
 //   - typ types.ProjectType
 //   - page uint
 //   - limit uint
-func (_e *ProjectRepo_Expecter) List(typ interface{}, page interface{}, limit interface{}) *ProjectRepo_List_Call {
-	return &ProjectRepo_List_Call{Call: _e.mock.On("List", typ, page, limit)}
+func (_e *ProjectRepo_Expecter) List(ctx interface{}, typ interface{}, page interface{}, limit interface{}) *ProjectRepo_List_Call {
+	return &ProjectRepo_List_Call{Call: _e.mock.On("List", ctx, typ, page, limit)}
 }
 
-func (_c *ProjectRepo_List_Call) Run(run func(typ types.ProjectType, page uint, limit uint)) *ProjectRepo_List_Call {
+func (_c *ProjectRepo_List_Call) Run(run func(ctx context.Context, typ types.ProjectType, page uint, limit uint)) *ProjectRepo_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(types.ProjectType), args[1].(uint), args[2].(uint))
+		run(args[0].(context.Context), args[1].(types.ProjectType), args[2].(uint), args[3].(uint))
 	})
 	return _c
 }
@@ -292,7 +345,7 @@ func (_c *ProjectRepo_List_Call) Return(_a0 []*biz.Project, _a1 int64, _a2 error
 	return _c
 }
 
-func (_c *ProjectRepo_List_Call) RunAndReturn(run func(types.ProjectType, uint, uint) ([]*biz.Project, int64, error)) *ProjectRepo_List_Call {
+func (_c *ProjectRepo_List_Call) RunAndReturn(run func(context.Context, types.ProjectType, uint, uint) ([]*biz.Project, int64, error)) *ProjectRepo_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -411,17 +464,17 @@ func (_c *ProjectRepo_ParseDetail_Call) RunAndReturn(run func(*biz.Project) (*ty
 	return _c
 }
 
-// RemoveUnitFile provides a mock function with given fields: name
-func (_m *ProjectRepo) RemoveUnitFile(name string) error {
-	ret := _m.Called(name)
+// RemoveUnitFile provides a mock function with given fields: project
+func (_m *ProjectRepo) RemoveUnitFile(project *biz.Project) error {
+	ret := _m.Called(project)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RemoveUnitFile")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(*biz.Project) error); ok {
+		r0 = rf(project)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -435,14 +488,14 @@ type ProjectRepo_RemoveUnitFile_Call struct {
 }
 
 // RemoveUnitFile is a helper method to define mock.On call
-//   - name string
-func (_e *ProjectRepo_Expecter) RemoveUnitFile(name interface{}) *ProjectRepo_RemoveUnitFile_Call {
-	return &ProjectRepo_RemoveUnitFile_Call{Call: _e.mock.On("RemoveUnitFile", name)}
+//   - project *biz.Project
+func (_e *ProjectRepo_Expecter) RemoveUnitFile(project interface{}) *ProjectRepo_RemoveUnitFile_Call {
+	return &ProjectRepo_RemoveUnitFile_Call{Call: _e.mock.On("RemoveUnitFile", project)}
 }
 
-func (_c *ProjectRepo_RemoveUnitFile_Call) Run(run func(name string)) *ProjectRepo_RemoveUnitFile_Call {
+func (_c *ProjectRepo_RemoveUnitFile_Call) Run(run func(project *biz.Project)) *ProjectRepo_RemoveUnitFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(*biz.Project))
 	})
 	return _c
 }
@@ -452,22 +505,22 @@ func (_c *ProjectRepo_RemoveUnitFile_Call) Return(_a0 error) *ProjectRepo_Remove
 	return _c
 }
 
-func (_c *ProjectRepo_RemoveUnitFile_Call) RunAndReturn(run func(string) error) *ProjectRepo_RemoveUnitFile_Call {
+func (_c *ProjectRepo_RemoveUnitFile_Call) RunAndReturn(run func(*biz.Project) error) *ProjectRepo_RemoveUnitFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RenameUnitFile provides a mock function with given fields: old, new
-func (_m *ProjectRepo) RenameUnitFile(old string, new string) error {
-	ret := _m.Called(old, new)
+// RenameUnitFile provides a mock function with given fields: project, name
+func (_m *ProjectRepo) RenameUnitFile(project *biz.Project, name string) error {
+	ret := _m.Called(project, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RenameUnitFile")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(old, new)
+	if rf, ok := ret.Get(0).(func(*biz.Project, string) error); ok {
+		r0 = rf(project, name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -481,15 +534,15 @@ type ProjectRepo_RenameUnitFile_Call struct {
 }
 
 // RenameUnitFile is a helper method to define mock.On call
-//   - old string
-//   - new string
-func (_e *ProjectRepo_Expecter) RenameUnitFile(old interface{}, new interface{}) *ProjectRepo_RenameUnitFile_Call {
-	return &ProjectRepo_RenameUnitFile_Call{Call: _e.mock.On("RenameUnitFile", old, new)}
+//   - project *biz.Project
+//   - name string
+func (_e *ProjectRepo_Expecter) RenameUnitFile(project interface{}, name interface{}) *ProjectRepo_RenameUnitFile_Call {
+	return &ProjectRepo_RenameUnitFile_Call{Call: _e.mock.On("RenameUnitFile", project, name)}
 }
 
-func (_c *ProjectRepo_RenameUnitFile_Call) Run(run func(old string, new string)) *ProjectRepo_RenameUnitFile_Call {
+func (_c *ProjectRepo_RenameUnitFile_Call) Run(run func(project *biz.Project, name string)) *ProjectRepo_RenameUnitFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(*biz.Project), args[1].(string))
 	})
 	return _c
 }
@@ -499,7 +552,7 @@ func (_c *ProjectRepo_RenameUnitFile_Call) Return(_a0 error) *ProjectRepo_Rename
 	return _c
 }
 
-func (_c *ProjectRepo_RenameUnitFile_Call) RunAndReturn(run func(string, string) error) *ProjectRepo_RenameUnitFile_Call {
+func (_c *ProjectRepo_RenameUnitFile_Call) RunAndReturn(run func(*biz.Project, string) error) *ProjectRepo_RenameUnitFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -66,6 +66,119 @@ func (_c *TaskRepo_Cancel_Call) RunAndReturn(run func(uint) error) *TaskRepo_Can
 	return _c
 }
 
+// CancelWaiting provides a mock function with given fields: resource
+func (_m *TaskRepo) CancelWaiting(resource string) (int64, error) {
+	ret := _m.Called(resource)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelWaiting")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(resource)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(resource)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(resource)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskRepo_CancelWaiting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelWaiting'
+type TaskRepo_CancelWaiting_Call struct {
+	*mock.Call
+}
+
+// CancelWaiting is a helper method to define mock.On call
+//   - resource string
+func (_e *TaskRepo_Expecter) CancelWaiting(resource interface{}) *TaskRepo_CancelWaiting_Call {
+	return &TaskRepo_CancelWaiting_Call{Call: _e.mock.On("CancelWaiting", resource)}
+}
+
+func (_c *TaskRepo_CancelWaiting_Call) Run(run func(resource string)) *TaskRepo_CancelWaiting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *TaskRepo_CancelWaiting_Call) Return(_a0 int64, _a1 error) *TaskRepo_CancelWaiting_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskRepo_CancelWaiting_Call) RunAndReturn(run func(string) (int64, error)) *TaskRepo_CancelWaiting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByStatus provides a mock function with no fields
+func (_m *TaskRepo) CountByStatus() (map[biz.TaskStatus]int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByStatus")
+	}
+
+	var r0 map[biz.TaskStatus]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (map[biz.TaskStatus]int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() map[biz.TaskStatus]int64); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[biz.TaskStatus]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskRepo_CountByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByStatus'
+type TaskRepo_CountByStatus_Call struct {
+	*mock.Call
+}
+
+// CountByStatus is a helper method to define mock.On call
+func (_e *TaskRepo_Expecter) CountByStatus() *TaskRepo_CountByStatus_Call {
+	return &TaskRepo_CountByStatus_Call{Call: _e.mock.On("CountByStatus")}
+}
+
+func (_c *TaskRepo_CountByStatus_Call) Run(run func()) *TaskRepo_CountByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *TaskRepo_CountByStatus_Call) Return(_a0 map[biz.TaskStatus]int64, _a1 error) *TaskRepo_CountByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskRepo_CountByStatus_Call) RunAndReturn(run func() (map[biz.TaskStatus]int64, error)) *TaskRepo_CountByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: id
 func (_m *TaskRepo) Delete(id uint) error {
 	ret := _m.Called(id)
@@ -170,17 +283,23 @@ func (_c *TaskRepo_Get_Call) RunAndReturn(run func(uint) (*biz.Task, error)) *Ta
 	return _c
 }
 
-// HasRunningTask provides a mock function with no fields
-func (_m *TaskRepo) HasRunningTask() bool {
-	ret := _m.Called()
+// HasRunningTask provides a mock function with given fields: resources
+func (_m *TaskRepo) HasRunningTask(resources ...string) bool {
+	_va := make([]interface{}, len(resources))
+	for _i := range resources {
+		_va[_i] = resources[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for HasRunningTask")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func() bool); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(...string) bool); ok {
+		r0 = rf(resources...)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -194,13 +313,21 @@ type TaskRepo_HasRunningTask_Call struct {
 }
 
 // HasRunningTask is a helper method to define mock.On call
-func (_e *TaskRepo_Expecter) HasRunningTask() *TaskRepo_HasRunningTask_Call {
-	return &TaskRepo_HasRunningTask_Call{Call: _e.mock.On("HasRunningTask")}
+//   - resources ...string
+func (_e *TaskRepo_Expecter) HasRunningTask(resources ...interface{}) *TaskRepo_HasRunningTask_Call {
+	return &TaskRepo_HasRunningTask_Call{Call: _e.mock.On("HasRunningTask",
+		append([]interface{}{}, resources...)...)}
 }
 
-func (_c *TaskRepo_HasRunningTask_Call) Run(run func()) *TaskRepo_HasRunningTask_Call {
+func (_c *TaskRepo_HasRunningTask_Call) Run(run func(resources ...string)) *TaskRepo_HasRunningTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
 	})
 	return _c
 }
@@ -210,7 +337,7 @@ func (_c *TaskRepo_HasRunningTask_Call) Return(_a0 bool) *TaskRepo_HasRunningTas
 	return _c
 }
 
-func (_c *TaskRepo_HasRunningTask_Call) RunAndReturn(run func() bool) *TaskRepo_HasRunningTask_Call {
+func (_c *TaskRepo_HasRunningTask_Call) RunAndReturn(run func(...string) bool) *TaskRepo_HasRunningTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -327,6 +454,53 @@ func (_c *TaskRepo_Push_Call) RunAndReturn(run func(*biz.Task) error) *TaskRepo_
 	return _c
 }
 
+// SetPriority provides a mock function with given fields: id, priority
+func (_m *TaskRepo) SetPriority(id uint, priority int) error {
+	ret := _m.Called(id, priority)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPriority")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, int) error); ok {
+		r0 = rf(id, priority)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskRepo_SetPriority_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPriority'
+type TaskRepo_SetPriority_Call struct {
+	*mock.Call
+}
+
+// SetPriority is a helper method to define mock.On call
+//   - id uint
+//   - priority int
+func (_e *TaskRepo_Expecter) SetPriority(id interface{}, priority interface{}) *TaskRepo_SetPriority_Call {
+	return &TaskRepo_SetPriority_Call{Call: _e.mock.On("SetPriority", id, priority)}
+}
+
+func (_c *TaskRepo_SetPriority_Call) Run(run func(id uint, priority int)) *TaskRepo_SetPriority_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *TaskRepo_SetPriority_Call) Return(_a0 error) *TaskRepo_SetPriority_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TaskRepo_SetPriority_Call) RunAndReturn(run func(uint, int) error) *TaskRepo_SetPriority_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateLog provides a mock function with given fields: id, log
 func (_m *TaskRepo) UpdateLog(id uint, log string) error {
 	ret := _m.Called(id, log)
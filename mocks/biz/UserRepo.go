@@ -137,9 +137,65 @@ func (_c *UserRepo_Count_Call) RunAndReturn(run func() (int64, error)) *UserRepo
 	return _c
 }
 
-// Create provides a mock function with given fields: username, password, email
-func (_m *UserRepo) Create(username string, password string, email string) (*biz.User, error) {
-	ret := _m.Called(username, password, email)
+// CountByRole provides a mock function with given fields: roleID
+func (_m *UserRepo) CountByRole(roleID uint) (int64, error) {
+	ret := _m.Called(roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByRole")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (int64, error)); ok {
+		return rf(roleID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) int64); ok {
+		r0 = rf(roleID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_CountByRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByRole'
+type UserRepo_CountByRole_Call struct {
+	*mock.Call
+}
+
+// CountByRole is a helper method to define mock.On call
+//   - roleID uint
+func (_e *UserRepo_Expecter) CountByRole(roleID interface{}) *UserRepo_CountByRole_Call {
+	return &UserRepo_CountByRole_Call{Call: _e.mock.On("CountByRole", roleID)}
+}
+
+func (_c *UserRepo_CountByRole_Call) Run(run func(roleID uint)) *UserRepo_CountByRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *UserRepo_CountByRole_Call) Return(_a0 int64, _a1 error) *UserRepo_CountByRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_CountByRole_Call) RunAndReturn(run func(uint) (int64, error)) *UserRepo_CountByRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: username, password, email, roleID, scopes
+func (_m *UserRepo) Create(username string, password string, email string, roleID uint, scopes map[string][]uint) (*biz.User, error) {
+	ret := _m.Called(username, password, email, roleID, scopes)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -147,19 +203,19 @@ func (_m *UserRepo) Create(username string, password string, email string) (*biz
 
 	var r0 *biz.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (*biz.User, error)); ok {
-		return rf(username, password, email)
+	if rf, ok := ret.Get(0).(func(string, string, string, uint, map[string][]uint) (*biz.User, error)); ok {
+		return rf(username, password, email, roleID, scopes)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) *biz.User); ok {
-		r0 = rf(username, password, email)
+	if rf, ok := ret.Get(0).(func(string, string, string, uint, map[string][]uint) *biz.User); ok {
+		r0 = rf(username, password, email, roleID, scopes)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*biz.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(username, password, email)
+	if rf, ok := ret.Get(1).(func(string, string, string, uint, map[string][]uint) error); ok {
+		r1 = rf(username, password, email, roleID, scopes)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -176,13 +232,15 @@ type UserRepo_Create_Call struct {
 //   - username string
 //   - password string
 //   - email string
-func (_e *UserRepo_Expecter) Create(username interface{}, password interface{}, email interface{}) *UserRepo_Create_Call {
-	return &UserRepo_Create_Call{Call: _e.mock.On("Create", username, password, email)}
+//   - roleID uint
+//   - scopes map[string][]uint
+func (_e *UserRepo_Expecter) Create(username interface{}, password interface{}, email interface{}, roleID interface{}, scopes interface{}) *UserRepo_Create_Call {
+	return &UserRepo_Create_Call{Call: _e.mock.On("Create", username, password, email, roleID, scopes)}
 }
 
-func (_c *UserRepo_Create_Call) Run(run func(username string, password string, email string)) *UserRepo_Create_Call {
+func (_c *UserRepo_Create_Call) Run(run func(username string, password string, email string, roleID uint, scopes map[string][]uint)) *UserRepo_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(uint), args[4].(map[string][]uint))
 	})
 	return _c
 }
@@ -192,7 +250,7 @@ func (_c *UserRepo_Create_Call) Return(_a0 *biz.User, _a1 error) *UserRepo_Creat
 	return _c
 }
 
-func (_c *UserRepo_Create_Call) RunAndReturn(run func(string, string, string) (*biz.User, error)) *UserRepo_Create_Call {
+func (_c *UserRepo_Create_Call) RunAndReturn(run func(string, string, string, uint, map[string][]uint) (*biz.User, error)) *UserRepo_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -599,6 +657,54 @@ func (_c *UserRepo_UpdatePassword_Call) RunAndReturn(run func(uint, string) erro
 	return _c
 }
 
+// UpdateRole provides a mock function with given fields: id, roleID, scopes
+func (_m *UserRepo) UpdateRole(id uint, roleID uint, scopes map[string][]uint) error {
+	ret := _m.Called(id, roleID, scopes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, map[string][]uint) error); ok {
+		r0 = rf(id, roleID, scopes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type UserRepo_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - id uint
+//   - roleID uint
+//   - scopes map[string][]uint
+func (_e *UserRepo_Expecter) UpdateRole(id interface{}, roleID interface{}, scopes interface{}) *UserRepo_UpdateRole_Call {
+	return &UserRepo_UpdateRole_Call{Call: _e.mock.On("UpdateRole", id, roleID, scopes)}
+}
+
+func (_c *UserRepo_UpdateRole_Call) Run(run func(id uint, roleID uint, scopes map[string][]uint)) *UserRepo_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(map[string][]uint))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UpdateRole_Call) Return(_a0 error) *UserRepo_UpdateRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_UpdateRole_Call) RunAndReturn(run func(uint, uint, map[string][]uint) error) *UserRepo_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateTwoFA provides a mock function with given fields: id, code, secret
 func (_m *UserRepo) UpdateTwoFA(id uint, code string, secret string) error {
 	ret := _m.Called(id, code, secret)
@@ -8,8 +8,6 @@ import (
 	biz "github.com/acepanel/panel/v3/internal/biz"
 
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // UserTokenRepo is an autogenerated mock type for the UserTokenRepo type
@@ -25,34 +23,22 @@ func (_m *UserTokenRepo) EXPECT() *UserTokenRepo_Expecter {
 	return &UserTokenRepo_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: userID, ips, expired
-func (_m *UserTokenRepo) Create(userID uint, ips []string, expired time.Time) (*biz.UserToken, error) {
-	ret := _m.Called(userID, ips, expired)
+// Create provides a mock function with given fields: userToken
+func (_m *UserTokenRepo) Create(userToken *biz.UserToken) error {
+	ret := _m.Called(userToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
-	var r0 *biz.UserToken
-	var r1 error
-	if rf, ok := ret.Get(0).(func(uint, []string, time.Time) (*biz.UserToken, error)); ok {
-		return rf(userID, ips, expired)
-	}
-	if rf, ok := ret.Get(0).(func(uint, []string, time.Time) *biz.UserToken); ok {
-		r0 = rf(userID, ips, expired)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*biz.UserToken)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(uint, []string, time.Time) error); ok {
-		r1 = rf(userID, ips, expired)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*biz.UserToken) error); ok {
+		r0 = rf(userToken)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // UserTokenRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
@@ -61,26 +47,24 @@ type UserTokenRepo_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
-//   - userID uint
-//   - ips []string
-//   - expired time.Time
-func (_e *UserTokenRepo_Expecter) Create(userID interface{}, ips interface{}, expired interface{}) *UserTokenRepo_Create_Call {
-	return &UserTokenRepo_Create_Call{Call: _e.mock.On("Create", userID, ips, expired)}
+//   - userToken *biz.UserToken
+func (_e *UserTokenRepo_Expecter) Create(userToken interface{}) *UserTokenRepo_Create_Call {
+	return &UserTokenRepo_Create_Call{Call: _e.mock.On("Create", userToken)}
 }
 
-func (_c *UserTokenRepo_Create_Call) Run(run func(userID uint, ips []string, expired time.Time)) *UserTokenRepo_Create_Call {
+func (_c *UserTokenRepo_Create_Call) Run(run func(userToken *biz.UserToken)) *UserTokenRepo_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].([]string), args[2].(time.Time))
+		run(args[0].(*biz.UserToken))
 	})
 	return _c
 }
 
-func (_c *UserTokenRepo_Create_Call) Return(_a0 *biz.UserToken, _a1 error) *UserTokenRepo_Create_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *UserTokenRepo_Create_Call) Return(_a0 error) *UserTokenRepo_Create_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *UserTokenRepo_Create_Call) RunAndReturn(run func(uint, []string, time.Time) (*biz.UserToken, error)) *UserTokenRepo_Create_Call {
+func (_c *UserTokenRepo_Create_Call) RunAndReturn(run func(*biz.UserToken) error) *UserTokenRepo_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -256,34 +240,22 @@ func (_c *UserTokenRepo_List_Call) RunAndReturn(run func(uint, uint, uint) ([]*b
 	return _c
 }
 
-// Update provides a mock function with given fields: id, ips, expired
-func (_m *UserTokenRepo) Update(id uint, ips []string, expired time.Time) (*biz.UserToken, error) {
-	ret := _m.Called(id, ips, expired)
+// Update provides a mock function with given fields: userToken
+func (_m *UserTokenRepo) Update(userToken *biz.UserToken) error {
+	ret := _m.Called(userToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
-	var r0 *biz.UserToken
-	var r1 error
-	if rf, ok := ret.Get(0).(func(uint, []string, time.Time) (*biz.UserToken, error)); ok {
-		return rf(id, ips, expired)
-	}
-	if rf, ok := ret.Get(0).(func(uint, []string, time.Time) *biz.UserToken); ok {
-		r0 = rf(id, ips, expired)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*biz.UserToken)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(uint, []string, time.Time) error); ok {
-		r1 = rf(id, ips, expired)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*biz.UserToken) error); ok {
+		r0 = rf(userToken)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // UserTokenRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
@@ -292,47 +264,47 @@ type UserTokenRepo_Update_Call struct {
 }
 
 // Update is a helper method to define mock.On call
-//   - id uint
-//   - ips []string
-//   - expired time.Time
-func (_e *UserTokenRepo_Expecter) Update(id interface{}, ips interface{}, expired interface{}) *UserTokenRepo_Update_Call {
-	return &UserTokenRepo_Update_Call{Call: _e.mock.On("Update", id, ips, expired)}
+//   - userToken *biz.UserToken
+func (_e *UserTokenRepo_Expecter) Update(userToken interface{}) *UserTokenRepo_Update_Call {
+	return &UserTokenRepo_Update_Call{Call: _e.mock.On("Update", userToken)}
 }
 
-func (_c *UserTokenRepo_Update_Call) Run(run func(id uint, ips []string, expired time.Time)) *UserTokenRepo_Update_Call {
+func (_c *UserTokenRepo_Update_Call) Run(run func(userToken *biz.UserToken)) *UserTokenRepo_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].([]string), args[2].(time.Time))
+		run(args[0].(*biz.UserToken))
 	})
 	return _c
 }
 
-func (_c *UserTokenRepo_Update_Call) Return(_a0 *biz.UserToken, _a1 error) *UserTokenRepo_Update_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *UserTokenRepo_Update_Call) Return(_a0 error) *UserTokenRepo_Update_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *UserTokenRepo_Update_Call) RunAndReturn(run func(uint, []string, time.Time) (*biz.UserToken, error)) *UserTokenRepo_Update_Call {
+func (_c *UserTokenRepo_Update_Call) RunAndReturn(run func(*biz.UserToken) error) *UserTokenRepo_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ValidateReq provides a mock function with given fields: req
-func (_m *UserTokenRepo) ValidateReq(req *http.Request) (uint, error) {
+func (_m *UserTokenRepo) ValidateReq(req *http.Request) (*biz.UserToken, error) {
 	ret := _m.Called(req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateReq")
 	}
 
-	var r0 uint
+	var r0 *biz.UserToken
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+	if rf, ok := ret.Get(0).(func(*http.Request) (*biz.UserToken, error)); ok {
 		return rf(req)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+	if rf, ok := ret.Get(0).(func(*http.Request) *biz.UserToken); ok {
 		r0 = rf(req)
 	} else {
-		r0 = ret.Get(0).(uint)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.UserToken)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
@@ -362,12 +334,12 @@ func (_c *UserTokenRepo_ValidateReq_Call) Run(run func(req *http.Request)) *User
 	return _c
 }
 
-func (_c *UserTokenRepo_ValidateReq_Call) Return(_a0 uint, _a1 error) *UserTokenRepo_ValidateReq_Call {
+func (_c *UserTokenRepo_ValidateReq_Call) Return(_a0 *biz.UserToken, _a1 error) *UserTokenRepo_ValidateReq_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserTokenRepo_ValidateReq_Call) RunAndReturn(run func(*http.Request) (uint, error)) *UserTokenRepo_ValidateReq_Call {
+func (_c *UserTokenRepo_ValidateReq_Call) RunAndReturn(run func(*http.Request) (*biz.UserToken, error)) *UserTokenRepo_ValidateReq_Call {
 	_c.Call.Return(run)
 	return _c
 }
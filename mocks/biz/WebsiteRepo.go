@@ -3,6 +3,8 @@
 package biz
 
 import (
+	context "context"
+
 	biz "github.com/acepanel/panel/v3/internal/biz"
 	mock "github.com/stretchr/testify/mock"
 
@@ -26,6 +28,64 @@ func (_m *WebsiteRepo) EXPECT() *WebsiteRepo_Expecter {
 	return &WebsiteRepo_Expecter{mock: &_m.Mock}
 }
 
+// ConfigFiles provides a mock function with given fields: websiteID
+func (_m *WebsiteRepo) ConfigFiles(websiteID uint) (map[string]string, error) {
+	ret := _m.Called(websiteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfigFiles")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (map[string]string, error)); ok {
+		return rf(websiteID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) map[string]string); ok {
+		r0 = rf(websiteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(websiteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebsiteRepo_ConfigFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfigFiles'
+type WebsiteRepo_ConfigFiles_Call struct {
+	*mock.Call
+}
+
+// ConfigFiles is a helper method to define mock.On call
+//   - websiteID uint
+func (_e *WebsiteRepo_Expecter) ConfigFiles(websiteID interface{}) *WebsiteRepo_ConfigFiles_Call {
+	return &WebsiteRepo_ConfigFiles_Call{Call: _e.mock.On("ConfigFiles", websiteID)}
+}
+
+func (_c *WebsiteRepo_ConfigFiles_Call) Run(run func(websiteID uint)) *WebsiteRepo_ConfigFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_ConfigFiles_Call) Return(_a0 map[string]string, _a1 error) *WebsiteRepo_ConfigFiles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebsiteRepo_ConfigFiles_Call) RunAndReturn(run func(uint) (map[string]string, error)) *WebsiteRepo_ConfigFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Count provides a mock function with no fields
 func (_m *WebsiteRepo) Count() (int64, error) {
 	ret := _m.Called()
@@ -359,6 +419,65 @@ func (_c *WebsiteRepo_GetForDelete_Call) RunAndReturn(run func(uint) (*biz.Websi
 	return _c
 }
 
+// GetRevision provides a mock function with given fields: websiteID, revisionID
+func (_m *WebsiteRepo) GetRevision(websiteID uint, revisionID uint) (*biz.WebsiteRevision, error) {
+	ret := _m.Called(websiteID, revisionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *biz.WebsiteRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*biz.WebsiteRevision, error)); ok {
+		return rf(websiteID, revisionID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *biz.WebsiteRevision); ok {
+		r0 = rf(websiteID, revisionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.WebsiteRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(websiteID, revisionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebsiteRepo_GetRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevision'
+type WebsiteRepo_GetRevision_Call struct {
+	*mock.Call
+}
+
+// GetRevision is a helper method to define mock.On call
+//   - websiteID uint
+//   - revisionID uint
+func (_e *WebsiteRepo_Expecter) GetRevision(websiteID interface{}, revisionID interface{}) *WebsiteRepo_GetRevision_Call {
+	return &WebsiteRepo_GetRevision_Call{Call: _e.mock.On("GetRevision", websiteID, revisionID)}
+}
+
+func (_c *WebsiteRepo_GetRevision_Call) Run(run func(websiteID uint, revisionID uint)) *WebsiteRepo_GetRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_GetRevision_Call) Return(_a0 *biz.WebsiteRevision, _a1 error) *WebsiteRepo_GetRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebsiteRepo_GetRevision_Call) RunAndReturn(run func(uint, uint) (*biz.WebsiteRevision, error)) *WebsiteRepo_GetRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRewrites provides a mock function with no fields
 func (_m *WebsiteRepo) GetRewrites() (map[string]string, error) {
 	ret := _m.Called()
@@ -416,9 +535,9 @@ func (_c *WebsiteRepo_GetRewrites_Call) RunAndReturn(run func() (map[string]stri
 	return _c
 }
 
-// List provides a mock function with given fields: typ, page, limit
-func (_m *WebsiteRepo) List(typ string, page uint, limit uint) ([]*biz.Website, int64, error) {
-	ret := _m.Called(typ, page, limit)
+// List provides a mock function with given fields: ctx, typ, page, limit
+func (_m *WebsiteRepo) List(ctx context.Context, typ string, page uint, limit uint) ([]*biz.Website, int64, error) {
+	ret := _m.Called(ctx, typ, page, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -427,25 +546,25 @@ func (_m *WebsiteRepo) List(typ string, page uint, limit uint) ([]*biz.Website,
 	var r0 []*biz.Website
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(string, uint, uint) ([]*biz.Website, int64, error)); ok {
-		return rf(typ, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint, uint) ([]*biz.Website, int64, error)); ok {
+		return rf(ctx, typ, page, limit)
 	}
-	if rf, ok := ret.Get(0).(func(string, uint, uint) []*biz.Website); ok {
-		r0 = rf(typ, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint, uint) []*biz.Website); ok {
+		r0 = rf(ctx, typ, page, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*biz.Website)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, uint, uint) int64); ok {
-		r1 = rf(typ, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint, uint) int64); ok {
+		r1 = rf(ctx, typ, page, limit)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(string, uint, uint) error); ok {
-		r2 = rf(typ, page, limit)
+	if rf, ok := ret.Get(2).(func(context.Context, string, uint, uint) error); ok {
+		r2 = rf(ctx, typ, page, limit)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -459,16 +578,17 @@ type WebsiteRepo_List_Call struct {
 }
 
 // List is a helper method to define mock.On call
+//   - ctx context.Context
 //   - typ string
 //   - page uint
 //   - limit uint
-func (_e *WebsiteRepo_Expecter) List(typ interface{}, page interface{}, limit interface{}) *WebsiteRepo_List_Call {
-	return &WebsiteRepo_List_Call{Call: _e.mock.On("List", typ, page, limit)}
+func (_e *WebsiteRepo_Expecter) List(ctx interface{}, typ interface{}, page interface{}, limit interface{}) *WebsiteRepo_List_Call {
+	return &WebsiteRepo_List_Call{Call: _e.mock.On("List", ctx, typ, page, limit)}
 }
 
-func (_c *WebsiteRepo_List_Call) Run(run func(typ string, page uint, limit uint)) *WebsiteRepo_List_Call {
+func (_c *WebsiteRepo_List_Call) Run(run func(ctx context.Context, typ string, page uint, limit uint)) *WebsiteRepo_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(uint), args[2].(uint))
+		run(args[0].(context.Context), args[1].(string), args[2].(uint), args[3].(uint))
 	})
 	return _c
 }
@@ -478,7 +598,122 @@ func (_c *WebsiteRepo_List_Call) Return(_a0 []*biz.Website, _a1 int64, _a2 error
 	return _c
 }
 
-func (_c *WebsiteRepo_List_Call) RunAndReturn(run func(string, uint, uint) ([]*biz.Website, int64, error)) *WebsiteRepo_List_Call {
+func (_c *WebsiteRepo_List_Call) RunAndReturn(run func(context.Context, string, uint, uint) ([]*biz.Website, int64, error)) *WebsiteRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRevisions provides a mock function with given fields: websiteID, page, limit
+func (_m *WebsiteRepo) ListRevisions(websiteID uint, page uint, limit uint) ([]*biz.WebsiteRevision, int64, error) {
+	ret := _m.Called(websiteID, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRevisions")
+	}
+
+	var r0 []*biz.WebsiteRevision
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) ([]*biz.WebsiteRevision, int64, error)); ok {
+		return rf(websiteID, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) []*biz.WebsiteRevision); ok {
+		r0 = rf(websiteID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.WebsiteRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint, uint) int64); ok {
+		r1 = rf(websiteID, page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, uint, uint) error); ok {
+		r2 = rf(websiteID, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebsiteRepo_ListRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRevisions'
+type WebsiteRepo_ListRevisions_Call struct {
+	*mock.Call
+}
+
+// ListRevisions is a helper method to define mock.On call
+//   - websiteID uint
+//   - page uint
+//   - limit uint
+func (_e *WebsiteRepo_Expecter) ListRevisions(websiteID interface{}, page interface{}, limit interface{}) *WebsiteRepo_ListRevisions_Call {
+	return &WebsiteRepo_ListRevisions_Call{Call: _e.mock.On("ListRevisions", websiteID, page, limit)}
+}
+
+func (_c *WebsiteRepo_ListRevisions_Call) Run(run func(websiteID uint, page uint, limit uint)) *WebsiteRepo_ListRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_ListRevisions_Call) Return(_a0 []*biz.WebsiteRevision, _a1 int64, _a2 error) *WebsiteRepo_ListRevisions_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *WebsiteRepo_ListRevisions_Call) RunAndReturn(run func(uint, uint, uint) ([]*biz.WebsiteRevision, int64, error)) *WebsiteRepo_ListRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordRevision provides a mock function with given fields: websiteID, operatorID, action
+func (_m *WebsiteRepo) RecordRevision(websiteID uint, operatorID uint, action string) error {
+	ret := _m.Called(websiteID, operatorID, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordRevision")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, string) error); ok {
+		r0 = rf(websiteID, operatorID, action)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebsiteRepo_RecordRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordRevision'
+type WebsiteRepo_RecordRevision_Call struct {
+	*mock.Call
+}
+
+// RecordRevision is a helper method to define mock.On call
+//   - websiteID uint
+//   - operatorID uint
+//   - action string
+func (_e *WebsiteRepo_Expecter) RecordRevision(websiteID interface{}, operatorID interface{}, action interface{}) *WebsiteRepo_RecordRevision_Call {
+	return &WebsiteRepo_RecordRevision_Call{Call: _e.mock.On("RecordRevision", websiteID, operatorID, action)}
+}
+
+func (_c *WebsiteRepo_RecordRevision_Call) Run(run func(websiteID uint, operatorID uint, action string)) *WebsiteRepo_RecordRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_RecordRevision_Call) Return(_a0 error) *WebsiteRepo_RecordRevision_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebsiteRepo_RecordRevision_Call) RunAndReturn(run func(uint, uint, string) error) *WebsiteRepo_RecordRevision_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -621,6 +856,53 @@ func (_c *WebsiteRepo_ResetConfig_Call) RunAndReturn(run func(uint) error) *Webs
 	return _c
 }
 
+// RestoreRevision provides a mock function with given fields: websiteID, revisionID
+func (_m *WebsiteRepo) RestoreRevision(websiteID uint, revisionID uint) error {
+	ret := _m.Called(websiteID, revisionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRevision")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(websiteID, revisionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebsiteRepo_RestoreRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreRevision'
+type WebsiteRepo_RestoreRevision_Call struct {
+	*mock.Call
+}
+
+// RestoreRevision is a helper method to define mock.On call
+//   - websiteID uint
+//   - revisionID uint
+func (_e *WebsiteRepo_Expecter) RestoreRevision(websiteID interface{}, revisionID interface{}) *WebsiteRepo_RestoreRevision_Call {
+	return &WebsiteRepo_RestoreRevision_Call{Call: _e.mock.On("RestoreRevision", websiteID, revisionID)}
+}
+
+func (_c *WebsiteRepo_RestoreRevision_Call) Run(run func(websiteID uint, revisionID uint)) *WebsiteRepo_RestoreRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_RestoreRevision_Call) Return(_a0 error) *WebsiteRepo_RestoreRevision_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebsiteRepo_RestoreRevision_Call) RunAndReturn(run func(uint, uint) error) *WebsiteRepo_RestoreRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SwitchType provides a mock function with given fields: req
 func (_m *WebsiteRepo) SwitchType(req *request.WebsiteSwitchType) (*biz.Website, error) {
 	ret := _m.Called(req)
@@ -679,6 +961,54 @@ func (_c *WebsiteRepo_SwitchType_Call) RunAndReturn(run func(*request.WebsiteSwi
 	return _c
 }
 
+// SwitchUpstream provides a mock function with given fields: id, name, server
+func (_m *WebsiteRepo) SwitchUpstream(id uint, name string, server string) error {
+	ret := _m.Called(id, name, server)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwitchUpstream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string, string) error); ok {
+		r0 = rf(id, name, server)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebsiteRepo_SwitchUpstream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SwitchUpstream'
+type WebsiteRepo_SwitchUpstream_Call struct {
+	*mock.Call
+}
+
+// SwitchUpstream is a helper method to define mock.On call
+//   - id uint
+//   - name string
+//   - server string
+func (_e *WebsiteRepo_Expecter) SwitchUpstream(id interface{}, name interface{}, server interface{}) *WebsiteRepo_SwitchUpstream_Call {
+	return &WebsiteRepo_SwitchUpstream_Call{Call: _e.mock.On("SwitchUpstream", id, name, server)}
+}
+
+func (_c *WebsiteRepo_SwitchUpstream_Call) Run(run func(id uint, name string, server string)) *WebsiteRepo_SwitchUpstream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_SwitchUpstream_Call) Return(_a0 error) *WebsiteRepo_SwitchUpstream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebsiteRepo_SwitchUpstream_Call) RunAndReturn(run func(uint, string, string) error) *WebsiteRepo_SwitchUpstream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: req
 func (_m *WebsiteRepo) Update(req *request.WebsiteUpdate) (*biz.Website, error) {
 	ret := _m.Called(req)
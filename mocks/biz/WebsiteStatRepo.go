@@ -343,6 +343,52 @@ func (_c *WebsiteStatRepo_ClearURIsBefore_Call) RunAndReturn(run func(string) er
 	return _c
 }
 
+// ClearWAFLogsBefore provides a mock function with given fields: t
+func (_m *WebsiteStatRepo) ClearWAFLogsBefore(t time.Time) error {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearWAFLogsBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebsiteStatRepo_ClearWAFLogsBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearWAFLogsBefore'
+type WebsiteStatRepo_ClearWAFLogsBefore_Call struct {
+	*mock.Call
+}
+
+// ClearWAFLogsBefore is a helper method to define mock.On call
+//   - t time.Time
+func (_e *WebsiteStatRepo_Expecter) ClearWAFLogsBefore(t interface{}) *WebsiteStatRepo_ClearWAFLogsBefore_Call {
+	return &WebsiteStatRepo_ClearWAFLogsBefore_Call{Call: _e.mock.On("ClearWAFLogsBefore", t)}
+}
+
+func (_c *WebsiteStatRepo_ClearWAFLogsBefore_Call) Run(run func(t time.Time)) *WebsiteStatRepo_ClearWAFLogsBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *WebsiteStatRepo_ClearWAFLogsBefore_Call) Return(_a0 error) *WebsiteStatRepo_ClearWAFLogsBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebsiteStatRepo_ClearWAFLogsBefore_Call) RunAndReturn(run func(time.Time) error) *WebsiteStatRepo_ClearWAFLogsBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DailySeries provides a mock function with given fields: start, end, sites
 func (_m *WebsiteStatRepo) DailySeries(start string, end string, sites []string) ([]*biz.WebsiteStatSeries, error) {
 	ret := _m.Called(start, end, sites)
@@ -554,6 +600,52 @@ func (_c *WebsiteStatRepo_InsertErrors_Call) RunAndReturn(run func([]*biz.Websit
 	return _c
 }
 
+// InsertWAFLogs provides a mock function with given fields: logs
+func (_m *WebsiteStatRepo) InsertWAFLogs(logs []*biz.WebsiteWAFLog) error {
+	ret := _m.Called(logs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertWAFLogs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*biz.WebsiteWAFLog) error); ok {
+		r0 = rf(logs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebsiteStatRepo_InsertWAFLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertWAFLogs'
+type WebsiteStatRepo_InsertWAFLogs_Call struct {
+	*mock.Call
+}
+
+// InsertWAFLogs is a helper method to define mock.On call
+//   - logs []*biz.WebsiteWAFLog
+func (_e *WebsiteStatRepo_Expecter) InsertWAFLogs(logs interface{}) *WebsiteStatRepo_InsertWAFLogs_Call {
+	return &WebsiteStatRepo_InsertWAFLogs_Call{Call: _e.mock.On("InsertWAFLogs", logs)}
+}
+
+func (_c *WebsiteStatRepo_InsertWAFLogs_Call) Run(run func(logs []*biz.WebsiteWAFLog)) *WebsiteStatRepo_InsertWAFLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]*biz.WebsiteWAFLog))
+	})
+	return _c
+}
+
+func (_c *WebsiteStatRepo_InsertWAFLogs_Call) Return(_a0 error) *WebsiteStatRepo_InsertWAFLogs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebsiteStatRepo_InsertWAFLogs_Call) RunAndReturn(run func([]*biz.WebsiteWAFLog) error) *WebsiteStatRepo_InsertWAFLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListByDateRange provides a mock function with given fields: start, end, sites
 func (_m *WebsiteStatRepo) ListByDateRange(start string, end string, sites []string) ([]*biz.WebsiteStat, error) {
 	ret := _m.Called(start, end, sites)
@@ -744,6 +836,76 @@ func (_c *WebsiteStatRepo_ListSiteStats_Call) RunAndReturn(run func(string, stri
 	return _c
 }
 
+// ListWAFLogs provides a mock function with given fields: start, end, sites, rule, page, limit
+func (_m *WebsiteStatRepo) ListWAFLogs(start string, end string, sites []string, rule string, page uint, limit uint) ([]*biz.WebsiteWAFLog, uint, error) {
+	ret := _m.Called(start, end, sites, rule, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWAFLogs")
+	}
+
+	var r0 []*biz.WebsiteWAFLog
+	var r1 uint
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string, []string, string, uint, uint) ([]*biz.WebsiteWAFLog, uint, error)); ok {
+		return rf(start, end, sites, rule, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, []string, string, uint, uint) []*biz.WebsiteWAFLog); ok {
+		r0 = rf(start, end, sites, rule, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.WebsiteWAFLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, []string, string, uint, uint) uint); ok {
+		r1 = rf(start, end, sites, rule, page, limit)
+	} else {
+		r1 = ret.Get(1).(uint)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string, []string, string, uint, uint) error); ok {
+		r2 = rf(start, end, sites, rule, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebsiteStatRepo_ListWAFLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWAFLogs'
+type WebsiteStatRepo_ListWAFLogs_Call struct {
+	*mock.Call
+}
+
+// ListWAFLogs is a helper method to define mock.On call
+//   - start string
+//   - end string
+//   - sites []string
+//   - rule string
+//   - page uint
+//   - limit uint
+func (_e *WebsiteStatRepo_Expecter) ListWAFLogs(start interface{}, end interface{}, sites interface{}, rule interface{}, page interface{}, limit interface{}) *WebsiteStatRepo_ListWAFLogs_Call {
+	return &WebsiteStatRepo_ListWAFLogs_Call{Call: _e.mock.On("ListWAFLogs", start, end, sites, rule, page, limit)}
+}
+
+func (_c *WebsiteStatRepo_ListWAFLogs_Call) Run(run func(start string, end string, sites []string, rule string, page uint, limit uint)) *WebsiteStatRepo_ListWAFLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].([]string), args[3].(string), args[4].(uint), args[5].(uint))
+	})
+	return _c
+}
+
+func (_c *WebsiteStatRepo_ListWAFLogs_Call) Return(_a0 []*biz.WebsiteWAFLog, _a1 uint, _a2 error) *WebsiteStatRepo_ListWAFLogs_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *WebsiteStatRepo_ListWAFLogs_Call) RunAndReturn(run func(string, string, []string, string, uint, uint) ([]*biz.WebsiteWAFLog, uint, error)) *WebsiteStatRepo_ListWAFLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TopClients provides a mock function with given fields: start, end, sites, limit
 func (_m *WebsiteStatRepo) TopClients(start string, end string, sites []string, limit uint) ([]*biz.WebsiteStatClientRank, error) {
 	ret := _m.Called(start, end, sites, limit)
@@ -1137,6 +1299,66 @@ func (_c *WebsiteStatRepo_TopURIs_Call) RunAndReturn(run func(string, string, []
 	return _c
 }
 
+// TopWAFRules provides a mock function with given fields: start, end, sites
+func (_m *WebsiteStatRepo) TopWAFRules(start string, end string, sites []string) ([]*biz.WebsiteWAFRuleRank, error) {
+	ret := _m.Called(start, end, sites)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TopWAFRules")
+	}
+
+	var r0 []*biz.WebsiteWAFRuleRank
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, []string) ([]*biz.WebsiteWAFRuleRank, error)); ok {
+		return rf(start, end, sites)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, []string) []*biz.WebsiteWAFRuleRank); ok {
+		r0 = rf(start, end, sites)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.WebsiteWAFRuleRank)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, []string) error); ok {
+		r1 = rf(start, end, sites)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebsiteStatRepo_TopWAFRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TopWAFRules'
+type WebsiteStatRepo_TopWAFRules_Call struct {
+	*mock.Call
+}
+
+// TopWAFRules is a helper method to define mock.On call
+//   - start string
+//   - end string
+//   - sites []string
+func (_e *WebsiteStatRepo_Expecter) TopWAFRules(start interface{}, end interface{}, sites interface{}) *WebsiteStatRepo_TopWAFRules_Call {
+	return &WebsiteStatRepo_TopWAFRules_Call{Call: _e.mock.On("TopWAFRules", start, end, sites)}
+}
+
+func (_c *WebsiteStatRepo_TopWAFRules_Call) Run(run func(start string, end string, sites []string)) *WebsiteStatRepo_TopWAFRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *WebsiteStatRepo_TopWAFRules_Call) Return(_a0 []*biz.WebsiteWAFRuleRank, _a1 error) *WebsiteStatRepo_TopWAFRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebsiteStatRepo_TopWAFRules_Call) RunAndReturn(run func(string, string, []string) ([]*biz.WebsiteWAFRuleRank, error)) *WebsiteStatRepo_TopWAFRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Upsert provides a mock function with given fields: stats
 func (_m *WebsiteStatRepo) Upsert(stats []*biz.WebsiteStat) error {
 	ret := _m.Called(stats)
@@ -26,6 +26,7 @@ import (
 	"github.com/samber/lo"
 	"golang.org/x/net/publicsuffix"
 
+	"github.com/acepanel/panel/v3/pkg/dnsapi"
 	pkgos "github.com/acepanel/panel/v3/pkg/os"
 	"github.com/acepanel/panel/v3/pkg/shell"
 	"github.com/acepanel/panel/v3/pkg/systemctl"
@@ -403,15 +404,23 @@ func reloadWebServer(webServer string) error {
 type DnsType string
 
 const (
-	AliYun     DnsType = "aliyun"
-	Tencent    DnsType = "tencent"
-	Huawei     DnsType = "huawei"
-	Westcn     DnsType = "westcn"
-	CloudFlare DnsType = "cloudflare"
-	Gcore      DnsType = "gcore"
-	Porkbun    DnsType = "porkbun"
-	NameSilo   DnsType = "namesilo"
-	ClouDNS    DnsType = "cloudns"
+	AliYun       DnsType = "aliyun"
+	Tencent      DnsType = "tencent"
+	Huawei       DnsType = "huawei"
+	Westcn       DnsType = "westcn"
+	CloudFlare   DnsType = "cloudflare"
+	Gcore        DnsType = "gcore"
+	Porkbun      DnsType = "porkbun"
+	NameSilo     DnsType = "namesilo"
+	ClouDNS      DnsType = "cloudns"
+	RFC2136      DnsType = "rfc2136"
+	PowerDNS     DnsType = "powerdns"
+	Route53      DnsType = "route53"
+	DNSPod       DnsType = "dnspod"
+	GoDaddy      DnsType = "godaddy"
+	DigitalOcean DnsType = "digitalocean"
+	Hetzner      DnsType = "hetzner"
+	Hook         DnsType = "hook"
 )
 
 const defaultDNSServer = "8.8.8.8"
@@ -421,6 +430,10 @@ type DNSParam struct {
 	SK         string `form:"sk" json:"sk"`
 	DnsServer  string `form:"dns_server" json:"dns_server"`   // DNS 验证服务器
 	SkipVerify bool   `form:"skip_verify" json:"skip_verify"` // 跳过解析验证
+	Endpoint   string `form:"endpoint" json:"endpoint"`       // RFC2136 服务器、PowerDNS API 或 Hook 地址
+	Algorithm  string `form:"algorithm" json:"algorithm"`     // RFC2136 TSIG 算法
+	ZoneID     string `form:"zone_id" json:"zone_id"`         // Route53 托管区域 ID，可为空
+	Script     string `form:"script" json:"script"`           // Hook 脚本
 }
 
 type DNSProvider interface {
@@ -623,6 +636,49 @@ func (s *dnsSolver) getDNSProvider() (DNSProvider, error) {
 				AuthPassword: s.param.SK,
 			}
 		}
+	case RFC2136:
+		dns = &dnsapi.RFC2136{
+			Server:    s.param.Endpoint,
+			KeyName:   s.param.AK,
+			KeySecret: s.param.SK,
+			Algorithm: s.param.Algorithm,
+		}
+	case PowerDNS:
+		dns = &dnsapi.PowerDNS{
+			ServerURL: s.param.Endpoint,
+			APIKey:    s.param.AK,
+			ServerID:  s.param.SK,
+		}
+	case Route53:
+		dns = &dnsapi.Route53{
+			AccessKeyID:     s.param.AK,
+			SecretAccessKey: s.param.SK,
+			HostedZoneID:    s.param.ZoneID,
+		}
+	case DNSPod:
+		dns = &dnsapi.DNSPod{
+			ID:    s.param.AK,
+			Token: s.param.SK,
+		}
+	case GoDaddy:
+		dns = &dnsapi.GoDaddy{
+			APIKey:    s.param.AK,
+			APISecret: s.param.SK,
+		}
+	case DigitalOcean:
+		dns = &dnsapi.DigitalOcean{
+			APIToken: s.param.AK,
+		}
+	case Hetzner:
+		dns = &dnsapi.Hetzner{
+			APIToken: s.param.AK,
+		}
+	case Hook:
+		dns = &dnsapi.Hook{
+			URL:    s.param.Endpoint,
+			Token:  s.param.AK,
+			Script: s.param.Script,
+		}
 	default:
 		return nil, fmt.Errorf("unsupported DNS provider: %s", s.dns)
 	}
@@ -0,0 +1,311 @@
+// Package asciicast 以 asciicast v2 格式录制终端会话，文件使用 gzip 压缩
+// 格式说明见 https://docs.asciinema.org/manual/asciicast/v2/
+package asciicast
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	EventOutput = "o"
+	EventInput  = "i"
+	EventResize = "r"
+)
+
+// Header asciicast v2 文件头
+type Header struct {
+	Version   int               `json:"version"`
+	Width     uint              `json:"width"`
+	Height    uint              `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder 终端录制器，并发安全
+// nil 录制器的所有方法均为空操作，调用方无需判断是否开启录制
+type Recorder struct {
+	mu      sync.Mutex
+	file    *os.File
+	gz      *gzip.Writer
+	buf     *bufio.Writer
+	header  Header
+	started bool // 文件头是否已写入
+	closed  bool
+	input   bool
+	start   time.Time
+	end     time.Time
+	pending map[string][]byte // 各事件流末尾不完整的 UTF-8 字节，留到下次拼接
+	flushed time.Time         // 上次写入文件的时间
+	err     error
+	failed  chan struct{} // 录制出错后关闭
+}
+
+// flushInterval 缓冲数据写入文件的最长间隔，写入失败能及时发现
+const flushInterval = time.Second
+
+// Create 创建录制文件，input 为 false 时不记录输入
+// 文件头的宽高在首次输出前可被 Resize 覆盖，以便使用客户端的实际终端大小
+func Create(path string, header Header, input bool) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	header.Version = 2
+	header.Timestamp = now.Unix()
+	if header.Width == 0 || header.Height == 0 {
+		header.Width, header.Height = 80, 24
+	}
+
+	gz := gzip.NewWriter(file)
+	return &Recorder{
+		file:    file,
+		gz:      gz,
+		buf:     bufio.NewWriter(gz),
+		header:  header,
+		input:   input,
+		start:   now,
+		pending: make(map[string][]byte),
+		flushed: now,
+		failed:  make(chan struct{}),
+	}, nil
+}
+
+// Output 记录终端输出
+func (r *Recorder) Output(p []byte) {
+	if r == nil {
+		return
+	}
+	r.write(EventOutput, p)
+}
+
+// Input 记录终端输入
+func (r *Recorder) Input(p []byte) {
+	if r == nil || !r.input {
+		return
+	}
+	r.write(EventInput, p)
+}
+
+// Resize 记录终端大小变化
+func (r *Recorder) Resize(cols, rows uint) {
+	if r == nil || cols == 0 || rows == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.err != nil {
+		return
+	}
+	if !r.started {
+		r.header.Width, r.header.Height = cols, rows
+		return
+	}
+	r.event(EventResize, fmt.Sprintf("%dx%d", cols, rows))
+	r.check()
+}
+
+// Failed 录制出错（如磁盘写满）后关闭的通道，nil 录制器返回的通道永不关闭
+func (r *Recorder) Failed() <-chan struct{} {
+	if r == nil {
+		return nil
+	}
+	return r.failed
+}
+
+// Err 返回录制过程中的错误
+func (r *Recorder) Err() error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Duration 返回录制时长
+func (r *Recorder) Duration() time.Duration {
+	if r == nil {
+		return 0
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return r.end.Sub(r.start)
+	}
+	return time.Since(r.start)
+}
+
+// Close 写入剩余数据并关闭文件，可重复调用
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return r.err
+	}
+	r.closed = true
+	r.end = time.Now()
+
+	r.writeHeader()
+	for _, typ := range []string{EventOutput, EventInput} {
+		if rest := r.pending[typ]; len(rest) > 0 {
+			r.event(typ, string(rest))
+		}
+	}
+	if r.err == nil {
+		r.err = r.buf.Flush()
+	}
+	if err := r.gz.Close(); r.err == nil {
+		r.err = err
+	}
+	if err := r.file.Close(); r.err == nil {
+		r.err = err
+	}
+
+	return r.err
+}
+
+func (r *Recorder) write(typ string, p []byte) {
+	if len(p) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.err != nil {
+		return
+	}
+
+	// 读取缓冲区可能在多字节字符中间截断，不完整的部分留到下次
+	data := append(r.pending[typ], p...)
+	data, rest := splitUTF8(data)
+	r.pending[typ] = append([]byte(nil), rest...)
+	if len(data) == 0 {
+		return
+	}
+
+	r.writeHeader()
+	r.event(typ, string(data))
+	if r.err == nil && time.Since(r.flushed) >= flushInterval {
+		r.flush()
+	}
+	r.check()
+}
+
+// flush 将缓冲数据压缩写入文件
+func (r *Recorder) flush() {
+	r.flushed = time.Now()
+	if r.err = r.buf.Flush(); r.err == nil {
+		r.err = r.gz.Flush()
+	}
+}
+
+// check 首次出错时通知调用方
+func (r *Recorder) check() {
+	if r.err == nil {
+		return
+	}
+	select {
+	case <-r.failed:
+	default:
+		close(r.failed)
+	}
+}
+
+func (r *Recorder) writeHeader() {
+	if r.started || r.err != nil {
+		return
+	}
+	r.started = true
+
+	header, err := json.Marshal(r.header)
+	if err != nil {
+		r.err = err
+		return
+	}
+	_, r.err = r.buf.Write(append(header, '\n'))
+}
+
+func (r *Recorder) event(typ, data string) {
+	if r.err != nil {
+		return
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	elapsed := strconv.FormatFloat(time.Since(r.start).Seconds(), 'f', 6, 64)
+	line := make([]byte, 0, len(elapsed)+len(encoded)+10)
+	line = append(line, '[')
+	line = append(line, elapsed...)
+	line = append(line, `, "`...)
+	line = append(line, typ...)
+	line = append(line, `", `...)
+	line = append(line, encoded...)
+	line = append(line, "]\n"...)
+	_, r.err = r.buf.Write(line)
+}
+
+// splitUTF8 将末尾不完整的 UTF-8 字符拆分出来
+func splitUTF8(p []byte) ([]byte, []byte) {
+	for i := len(p) - 1; i >= 0 && i >= len(p)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(p[i]) {
+			if !utf8.FullRune(p[i:]) {
+				return p[:i], p[i:]
+			}
+			break
+		}
+	}
+
+	return p, nil
+}
+
+// Open 打开录制文件，返回解压后的 asciicast 内容
+func Open(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &reader{Reader: gz, file: file}, nil
+}
+
+type reader struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (r *reader) Close() error {
+	_ = r.Reader.Close()
+	return r.file.Close()
+}
@@ -0,0 +1,129 @@
+package asciicast
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AsciicastTestSuite struct {
+	suite.Suite
+}
+
+func TestAsciicastTestSuite(t *testing.T) {
+	suite.Run(t, &AsciicastTestSuite{})
+}
+
+// read 读取录制文件，返回文件头与事件列表
+func (s *AsciicastTestSuite) read(path string) (Header, [][]any) {
+	f, err := Open(path)
+	s.Require().NoError(err)
+	defer func(f io.ReadCloser) { _ = f.Close() }(f)
+
+	var header Header
+	events := make([][]any, 0)
+	scanner := bufio.NewScanner(f)
+	s.Require().True(scanner.Scan())
+	s.Require().NoError(json.Unmarshal(scanner.Bytes(), &header))
+	for scanner.Scan() {
+		var event []any
+		s.Require().NoError(json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	s.Require().NoError(scanner.Err())
+
+	return header, events
+}
+
+func (s *AsciicastTestSuite) TestRecord() {
+	path := filepath.Join(s.T().TempDir(), "sub", "test.cast.gz")
+	rec, err := Create(path, Header{Title: "bash", Env: map[string]string{"TERM": "xterm-256color"}}, true)
+	s.Require().NoError(err)
+
+	// 首次输出前的 resize 写入文件头
+	rec.Resize(120, 40)
+	rec.Output([]byte("$ "))
+	rec.Input([]byte("ls\r"))
+	rec.Resize(100, 30)
+	rec.Output([]byte("<a&b>\r\n"))
+	s.NoError(rec.Close())
+	s.NoError(rec.Close())
+	rec.Output([]byte("ignored"))
+
+	header, events := s.read(path)
+	s.Equal(2, header.Version)
+	s.Equal(uint(120), header.Width)
+	s.Equal(uint(40), header.Height)
+	s.Equal("bash", header.Title)
+	s.Equal("xterm-256color", header.Env["TERM"])
+	s.NotZero(header.Timestamp)
+
+	s.Require().Len(events, 4)
+	s.Equal([]any{"o", "$ "}, events[0][1:])
+	s.Equal([]any{"i", "ls\r"}, events[1][1:])
+	s.Equal([]any{"r", "100x30"}, events[2][1:])
+	s.Equal([]any{"o", "<a&b>\r\n"}, events[3][1:])
+	s.LessOrEqual(events[0][0].(float64), events[3][0].(float64))
+	s.Positive(rec.Duration())
+}
+
+func (s *AsciicastTestSuite) TestSplitUTF8() {
+	path := filepath.Join(s.T().TempDir(), "utf8.cast.gz")
+	rec, err := Create(path, Header{}, false)
+	s.Require().NoError(err)
+
+	// “你好” 被截断在字符中间
+	data := []byte("你好")
+	rec.Output(data[:4])
+	rec.Output(data[4:])
+	rec.Input([]byte("ignored"))
+	s.NoError(rec.Close())
+
+	header, events := s.read(path)
+	s.Equal(uint(80), header.Width)
+	s.Equal(uint(24), header.Height)
+	s.Require().Len(events, 2)
+	s.Equal("你", events[0][2])
+	s.Equal("好", events[1][2])
+}
+
+func (s *AsciicastTestSuite) TestNil() {
+	var rec *Recorder
+	rec.Output([]byte("x"))
+	rec.Input([]byte("x"))
+	rec.Resize(80, 24)
+	s.Zero(rec.Duration())
+	s.Nil(rec.Failed())
+	s.NoError(rec.Err())
+	s.NoError(rec.Close())
+}
+
+func (s *AsciicastTestSuite) TestWriteFailed() {
+	path := filepath.Join(s.T().TempDir(), "failed.cast.gz")
+	rec, err := Create(path, Header{}, false)
+	s.Require().NoError(err)
+
+	// 模拟磁盘写入失败，到达写入间隔后应立即通知
+	s.Require().NoError(rec.file.Close())
+	rec.Output([]byte("before"))
+	select {
+	case <-rec.Failed():
+		s.Fail("recorder should not fail before flushing")
+	default:
+	}
+
+	rec.flushed = time.Now().Add(-flushInterval)
+	rec.Output([]byte("after"))
+	select {
+	case <-rec.Failed():
+	default:
+		s.Fail("recorder should report the write error")
+	}
+	s.Error(rec.Err())
+	s.Error(rec.Close())
+}
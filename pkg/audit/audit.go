@@ -0,0 +1,683 @@
+// Package audit 实现防篡改的操作审计日志。
+//
+// 每条记录携带上一条记录的哈希并以面板密钥对自身内容做 HMAC-SHA256，形成哈希链，
+// 任何一条被修改、删除或插入都会在校验时暴露；链尾的序号与哈希另存于日志目录之外，
+// 删除最新的月份文件或截断末尾记录同样会被发现；按保留期清理最早的月份时同样在目录之外记录新的链首，
+// 未经清理删除最早的文件也会被发现。记录按月写入 JSON Lines 文件，
+// 面板进程与 CLI 进程通过文件锁串行追加，保证链不分叉。
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+const (
+	fileExt   = ".log"
+	fileMonth = "2006-01"
+	lockName  = "audit.lock"
+	queueSize = 1024
+)
+
+var hashKey = []byte(`,"hash":"`)
+
+// 校验失败原因
+const (
+	ReasonMalformed    = "malformed entry"
+	ReasonHashMismatch = "entry hash mismatch"
+	ReasonPrevMismatch = "previous hash mismatch"
+	ReasonSeqMismatch  = "sequence gap"
+	ReasonTruncated    = "entries missing after the recorded head"
+	ReasonStartMissing = "entries missing before the recorded start"
+)
+
+// Anchor 在日志目录之外保存链尾，用于发现末尾记录被删除
+type Anchor interface {
+	// Load 返回已记录的链尾序号与哈希，未记录时返回 0
+	Load() (seq uint64, hash string, err error)
+	// Store 记录链尾，seq 不大于已记录的序号时忽略
+	Store(seq uint64, hash string) error
+	// LoadStart 返回清理后保留的第一条记录的序号与哈希，从未清理时返回 0
+	LoadStart() (seq uint64, hash string, err error)
+	// StoreStart 记录链首，仅由 Prune 调用，seq 不大于已记录的序号时忽略
+	StoreStart(seq uint64, hash string) error
+}
+
+// Entry 审计记录，Hash 覆盖除自身外的全部字段（含上一条的 Hash）
+type Entry struct {
+	Seq        uint64         `json:"seq"`
+	Time       time.Time      `json:"time"`
+	Level      string         `json:"level"`
+	Msg        string         `json:"msg"`
+	Type       string         `json:"type,omitempty"`
+	OperatorID uint64         `json:"operator_id"`
+	Attrs      map[string]any `json:"attrs,omitempty"`
+	Prev       string         `json:"prev"`
+	Hash       string         `json:"hash,omitempty"`
+}
+
+// Filter 导出筛选条件，零值表示不限制
+type Filter struct {
+	Start      time.Time
+	End        time.Time
+	OperatorID uint64
+	Type       string
+}
+
+func (f Filter) match(e *Entry) bool {
+	if !f.Start.IsZero() && e.Time.Before(f.Start) {
+		return false
+	}
+	if !f.End.IsZero() && !e.Time.Before(f.End) {
+		return false
+	}
+	if f.OperatorID != 0 && e.OperatorID != f.OperatorID {
+		return false
+	}
+	if f.Type != "" && e.Type != f.Type {
+		return false
+	}
+	return true
+}
+
+// VerifyResult 哈希链校验结果
+type VerifyResult struct {
+	Valid    bool   `json:"valid"`
+	Entries  uint64 `json:"entries"`
+	FirstSeq uint64 `json:"first_seq"`
+	LastSeq  uint64 `json:"last_seq"`
+	Head     string `json:"head"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// ForwardStatus 转发统计
+type ForwardStatus struct {
+	Sent      uint64 `json:"sent"`
+	Failed    uint64 `json:"failed"`
+	Dropped   uint64 `json:"dropped"`
+	LastError string `json:"last_error"`
+}
+
+// Log 哈希链审计日志
+type Log struct {
+	dir  string
+	key  []byte
+	mu   sync.Mutex
+	lock *os.File
+	seq  uint64
+	head string
+	file string // 链尾所在文件及其大小，变化说明其他进程追加过
+	size int64
+
+	fmu       sync.RWMutex
+	forwarder Forwarder
+	queue     chan record
+	done      chan struct{}
+	closed    bool
+	sent      atomic.Uint64
+	failed    atomic.Uint64
+	dropped   atomic.Uint64
+	lastErr   atomic.Value
+
+	// 链尾异步写入 Anchor，避免日志写入等待数据库
+	anchor      Anchor
+	amu         sync.Mutex
+	pendingSeq  uint64
+	pendingHash string
+	mark        chan struct{}
+	anchorDone  chan struct{}
+}
+
+// Open 打开审计日志目录，不存在则创建；key 为 HMAC 密钥，anchor 为 nil 时不记录链尾
+func Open(dir string, key []byte, anchor Anchor) (*Log, error) {
+	if len(key) == 0 {
+		return nil, errors.New("audit key is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	lock, err := os.OpenFile(filepath.Join(dir, lockName), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Log{
+		dir:        dir,
+		key:        slices.Clone(key),
+		lock:       lock,
+		queue:      make(chan record, queueSize),
+		done:       make(chan struct{}),
+		anchor:     anchor,
+		mark:       make(chan struct{}, 1),
+		anchorDone: make(chan struct{}),
+	}
+	go l.forward()
+	go l.store()
+	return l, nil
+}
+
+// Dir 返回审计日志目录
+func (l *Log) Dir() string {
+	return l.dir
+}
+
+// Append 追加一条记录，填充 Seq、Prev 与 Hash
+func (l *Log) Append(e *Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := syscall.Flock(int(l.lock.Fd()), syscall.LOCK_EX); err != nil {
+		return err
+	}
+	defer func() { _ = syscall.Flock(int(l.lock.Fd()), syscall.LOCK_UN) }()
+
+	if err := l.sync(); err != nil {
+		return err
+	}
+
+	e.Seq = l.seq + 1
+	e.Prev = l.head
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	e.Hash = l.digest(data)
+	line := seal(data, e.Hash)
+
+	name := filepath.Join(l.dir, e.Time.Format(fileMonth)+fileExt)
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(line); err != nil {
+		_ = f.Close()
+		return err
+	}
+	stat, err := f.Stat()
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+
+	l.seq, l.head = e.Seq, e.Hash
+	l.file, l.size = name, stat.Size()
+	l.enqueue(record{entry: e, raw: line[:len(line)-1]})
+	l.advance(e.Seq, e.Hash)
+	return nil
+}
+
+// sync 在链尾被其他进程推进时重新读取链尾
+func (l *Log) sync() error {
+	files, err := l.files()
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		l.seq, l.head, l.file, l.size = 0, "", "", 0
+		return nil
+	}
+
+	latest := files[len(files)-1]
+	if stat, err := os.Stat(latest); err == nil && latest == l.file && stat.Size() == l.size {
+		return nil
+	}
+
+	for i := len(files) - 1; i >= 0; i-- {
+		line, size, err := lastLine(files[i])
+		if err != nil {
+			return err
+		}
+		if len(line) == 0 {
+			continue
+		}
+		var e Entry
+		if err = json.Unmarshal(line, &e); err != nil || e.Hash == "" {
+			// 链尾损坏时以原始内容哈希续链，损坏处留给校验报告
+			l.head = l.digest(line)
+		} else {
+			l.seq, l.head = e.Seq, e.Hash
+		}
+		l.file, l.size = files[i], size
+		return nil
+	}
+
+	l.seq, l.head, l.file, l.size = 0, "", "", 0
+	return nil
+}
+
+// files 按时间顺序返回全部审计文件
+func (l *Log) files() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(l.dir, "*"+fileExt))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// Verify 从头校验整条哈希链，并与日志目录外记录的链首、链尾核对
+func (l *Log) Verify() (*VerifyResult, error) {
+	var anchorSeq, startSeq uint64
+	var anchorHash, startHash string
+	if l.anchor != nil {
+		var err error
+		if anchorSeq, anchorHash, err = l.anchor.Load(); err != nil {
+			return nil, err
+		}
+		if startSeq, startHash, err = l.anchor.LoadStart(); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &VerifyResult{Valid: true}
+	err := l.scan(time.Time{}, time.Time{}, func(name string, line int, raw []byte, e *Entry) bool {
+		fail := func(reason string) bool {
+			result.Valid = false
+			result.File = filepath.Base(name)
+			result.Line = line
+			result.Reason = reason
+			return false
+		}
+
+		if e == nil {
+			return fail(ReasonMalformed)
+		}
+		if !l.sealed(raw, e.Hash) {
+			return fail(ReasonHashMismatch)
+		}
+		if result.Entries > 0 {
+			if e.Prev != result.Head {
+				return fail(ReasonPrevMismatch)
+			}
+			if e.Seq != result.LastSeq+1 {
+				return fail(ReasonSeqMismatch)
+			}
+		} else {
+			// 链首只能是第一条记录或不晚于清理时记录的链首，删除最早的文件会在此暴露
+			if (e.Seq != 1 || e.Prev != "") && e.Seq > startSeq {
+				return fail(ReasonStartMissing)
+			}
+			result.FirstSeq = e.Seq
+		}
+
+		// 清理中断或旧文件被放回时，链首之前的记录须接上记录的链首
+		if e.Seq == startSeq && e.Hash != startHash {
+			return fail(ReasonStartMissing)
+		}
+		// 截断后继续追加会产生同序号的新记录，哈希与记录的链尾不同
+		if e.Seq == anchorSeq && e.Hash != anchorHash {
+			return fail(ReasonTruncated)
+		}
+
+		result.Entries++
+		result.LastSeq = e.Seq
+		result.Head = e.Hash
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Valid && result.LastSeq < anchorSeq {
+		result.Valid = false
+		result.Reason = ReasonTruncated
+	}
+
+	return result, nil
+}
+
+// Prune 删除 before 之前已结束的月份文件，链尾所在的文件始终保留
+// 删除前先把保留下来的第一条记录写入 Anchor 作为新的链首；链已损坏时拒绝清理，避免抹掉篡改痕迹
+func (l *Log) Prune(before time.Time) (int, error) {
+	if l.anchor == nil {
+		return 0, errors.New("audit anchor is required to prune")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := syscall.Flock(int(l.lock.Fd()), syscall.LOCK_EX); err != nil {
+		return 0, err
+	}
+	defer func() { _ = syscall.Flock(int(l.lock.Fd()), syscall.LOCK_UN) }()
+
+	files, err := l.files()
+	if err != nil {
+		return 0, err
+	}
+	var expired []string
+	for _, name := range files[:max(len(files)-1, 0)] {
+		month, ok := fileMonthOf(name)
+		if !ok || month.AddDate(0, 1, 0).After(before) {
+			break
+		}
+		expired = append(expired, name)
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	result, err := l.Verify()
+	if err != nil {
+		return 0, err
+	}
+	if !result.Valid {
+		return 0, fmt.Errorf("audit log chain broken at %s:%d: %s", result.File, result.Line, result.Reason)
+	}
+
+	var start *Entry
+	if _, err = scanFile(files[len(expired)], func(_ string, _ int, _ []byte, e *Entry) bool {
+		start = e
+		return false
+	}); err != nil {
+		return 0, err
+	}
+	if start == nil {
+		return 0, fmt.Errorf("no entry to start the chain in %s", filepath.Base(files[len(expired)]))
+	}
+	if err = l.anchor.StoreStart(start.Seq, start.Hash); err != nil {
+		return 0, err
+	}
+
+	for i, name := range expired {
+		if err = os.Remove(name); err != nil {
+			return i, err
+		}
+	}
+
+	return len(expired), nil
+}
+
+// Read 按时间顺序遍历符合条件的记录，raw 为落盘的原始行（不含换行），持有密钥即可独立校验
+func (l *Log) Read(filter Filter, fn func(e *Entry, raw []byte) error) error {
+	var fnErr error
+	err := l.scan(filter.Start, filter.End, func(_ string, _ int, raw []byte, e *Entry) bool {
+		if e == nil || !filter.match(e) {
+			return true
+		}
+		fnErr = fn(e, raw)
+		return fnErr == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return fnErr
+}
+
+// scan 顺序读取审计文件，跳过与 [start, end) 不相交的月份；解析失败的行以 nil 传入
+func (l *Log) scan(start, end time.Time, fn func(name string, line int, raw []byte, e *Entry) bool) error {
+	files, err := l.files()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		if !inMonths(name, start, end) {
+			continue
+		}
+		next, err := scanFile(name, fn)
+		if err != nil {
+			return err
+		}
+		if !next {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func scanFile(name string, fn func(name string, line int, raw []byte, e *Entry) bool) (bool, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return false, err
+	}
+	defer func(f *os.File) { _ = f.Close() }(f)
+
+	reader := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		raw, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// 末尾没有换行说明另一进程正在写入，留待下次读取
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		raw = bytes.TrimRight(raw, "\r\n")
+		if len(raw) == 0 {
+			continue
+		}
+		var entry *Entry
+		if e := new(Entry); json.Unmarshal(raw, e) == nil && e.Hash != "" {
+			entry = e
+		}
+		if !fn(name, line, raw, entry) {
+			return false, nil
+		}
+	}
+}
+
+// inMonths 判断文件所属月份是否与 [start, end) 相交
+func inMonths(name string, start, end time.Time) bool {
+	month, ok := fileMonthOf(name)
+	if !ok {
+		return true
+	}
+	if !end.IsZero() && !month.Before(end) {
+		return false
+	}
+	if !start.IsZero() && !month.AddDate(0, 1, 0).After(start) {
+		return false
+	}
+	return true
+}
+
+// fileMonthOf 从文件名解析所属月份的第一天
+func fileMonthOf(name string) (time.Time, bool) {
+	month, err := time.ParseInLocation(fileMonth, strings.TrimSuffix(filepath.Base(name), fileExt), time.Local)
+	return month, err == nil
+}
+
+// SetForwarder 替换转发目标，nil 表示停止转发
+func (l *Log) SetForwarder(f Forwarder) {
+	l.fmu.Lock()
+	old := l.forwarder
+	l.forwarder = f
+	l.fmu.Unlock()
+
+	if old != nil {
+		_ = old.Close()
+	}
+}
+
+// ForwardStatus 返回转发统计
+func (l *Log) ForwardStatus() ForwardStatus {
+	status := ForwardStatus{
+		Sent:    l.sent.Load(),
+		Failed:  l.failed.Load(),
+		Dropped: l.dropped.Load(),
+	}
+	if err, ok := l.lastErr.Load().(string); ok {
+		status.LastError = err
+	}
+	return status
+}
+
+// record 待转发的记录，raw 为落盘原文，避免属性中的引用值在异步转发前被修改
+type record struct {
+	entry *Entry
+	raw   []byte
+}
+
+func (l *Log) enqueue(r record) {
+	l.fmu.RLock()
+	defer l.fmu.RUnlock()
+	if l.forwarder == nil || l.closed {
+		return
+	}
+
+	// 转发目标不可用时不阻塞日志写入，队列满直接丢弃，链上仍有完整记录
+	select {
+	case l.queue <- r:
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+// advance 更新待记录的链尾，由 store 异步写入 Anchor
+func (l *Log) advance(seq uint64, hash string) {
+	if l.anchor == nil {
+		return
+	}
+
+	l.amu.Lock()
+	l.pendingSeq, l.pendingHash = seq, hash
+	l.amu.Unlock()
+
+	l.fmu.RLock()
+	defer l.fmu.RUnlock()
+	if l.closed {
+		return
+	}
+	select {
+	case l.mark <- struct{}{}:
+	default:
+	}
+}
+
+// store 写入最新的链尾，连续追加时合并为一次写入
+func (l *Log) store() {
+	defer close(l.anchorDone)
+	for range l.mark {
+		l.amu.Lock()
+		seq, hash := l.pendingSeq, l.pendingHash
+		l.amu.Unlock()
+
+		// 同 Append 失败时一样不能走 slog，直接输出到标准错误
+		if err := l.anchor.Store(seq, hash); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "audit: failed to store chain head: %v\n", err)
+		}
+	}
+}
+
+func (l *Log) forward() {
+	defer close(l.done)
+	for r := range l.queue {
+		l.fmu.RLock()
+		if l.forwarder != nil {
+			if err := l.forwarder.Send(r.entry, r.raw); err != nil {
+				l.failed.Add(1)
+				l.lastErr.Store(err.Error())
+			} else {
+				l.sent.Add(1)
+			}
+		}
+		l.fmu.RUnlock()
+	}
+}
+
+// Close 发送完队列中的记录后关闭
+func (l *Log) Close() error {
+	l.fmu.Lock()
+	if l.closed {
+		l.fmu.Unlock()
+		return nil
+	}
+	l.closed = true
+	close(l.queue)
+	close(l.mark)
+	l.fmu.Unlock()
+
+	timeout := time.After(5 * time.Second)
+	for _, done := range []chan struct{}{l.done, l.anchorDone} {
+		select {
+		case <-done:
+		case <-timeout:
+		}
+	}
+
+	l.SetForwarder(nil)
+	return l.lock.Close()
+}
+
+func (l *Log) digest(data []byte) string {
+	mac := hmac.New(sha256.New, l.key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// seal 把哈希追加为 JSON 的最后一个字段
+func seal(data []byte, hash string) []byte {
+	line := make([]byte, 0, len(data)+len(hashKey)+len(hash)+3)
+	line = append(line, data[:len(data)-1]...)
+	line = append(line, hashKey...)
+	line = append(line, hash...)
+	line = append(line, '"', '}', '\n')
+	return line
+}
+
+// sealed 去掉末尾的哈希字段还原被哈希的原文并核对
+func (l *Log) sealed(raw []byte, hash string) bool {
+	i := bytes.LastIndex(raw, hashKey)
+	if i < 0 || !bytes.Equal(raw[i:], fmt.Appendf(nil, `%s%s"}`, hashKey, hash)) {
+		return false
+	}
+	data := append(slices.Clip(raw[:i]), '}')
+	return hmac.Equal([]byte(l.digest(data)), []byte(hash))
+}
+
+// lastLine 从文件末尾向前读取最后一个非空行，并返回文件大小
+func lastLine(name string) ([]byte, int64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func(f *os.File) { _ = f.Close() }(f)
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	const chunk = 4096
+	var buf []byte
+	for off := stat.Size(); off > 0; {
+		n := min(int64(chunk), off)
+		off -= n
+		b := make([]byte, n, int(n)+len(buf))
+		if _, err = f.ReadAt(b, off); err != nil {
+			return nil, 0, err
+		}
+		buf = append(b, buf...)
+
+		trimmed := bytes.TrimRight(buf, "\r\n")
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return trimmed[i+1:], stat.Size(), nil
+		}
+		if off == 0 {
+			return trimmed, stat.Size(), nil
+		}
+	}
+
+	return nil, stat.Size(), nil
+}
@@ -0,0 +1,415 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditTestSuite struct {
+	suite.Suite
+}
+
+func TestAuditTestSuite(t *testing.T) {
+	suite.Run(t, &AuditTestSuite{})
+}
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+// memAnchor 内存中的链首与链尾记录
+type memAnchor struct {
+	mu        sync.Mutex
+	seq       uint64
+	hash      string
+	startSeq  uint64
+	startHash string
+}
+
+func (a *memAnchor) Load() (uint64, string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.seq, a.hash, nil
+}
+
+func (a *memAnchor) Store(seq uint64, hash string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if seq > a.seq {
+		a.seq, a.hash = seq, hash
+	}
+	return nil
+}
+
+func (a *memAnchor) LoadStart() (uint64, string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.startSeq, a.startHash, nil
+}
+
+func (a *memAnchor) StoreStart(seq uint64, hash string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if seq > a.startSeq {
+		a.startSeq, a.startHash = seq, hash
+	}
+	return nil
+}
+
+func (s *AuditTestSuite) open(dir string) *Log {
+	return s.openAnchored(dir, nil)
+}
+
+func (s *AuditTestSuite) openAnchored(dir string, anchor Anchor) *Log {
+	l, err := Open(dir, testKey, anchor)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = l.Close() })
+	return l
+}
+
+func (s *AuditTestSuite) append(l *Log, at time.Time, operator uint64, msg string) *Entry {
+	e := &Entry{Time: at, Level: "INFO", Msg: msg, Type: "website", OperatorID: operator}
+	s.Require().NoError(l.Append(e))
+	return e
+}
+
+// lines 读取审计文件的全部行
+func (s *AuditTestSuite) lines(name string) []string {
+	content, err := os.ReadFile(name)
+	s.Require().NoError(err)
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
+
+func (s *AuditTestSuite) write(name string, lines []string) {
+	s.Require().NoError(os.WriteFile(name, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+}
+
+func (s *AuditTestSuite) TestChainAcrossMonths() {
+	dir := s.T().TempDir()
+	l := s.open(dir)
+
+	first := s.append(l, time.Date(2026, 9, 30, 23, 59, 0, 0, time.Local), 1, "website created")
+	second := s.append(l, time.Date(2026, 10, 1, 0, 1, 0, 0, time.Local), 2, "website deleted")
+	s.Equal(uint64(1), first.Seq)
+	s.Equal("", first.Prev)
+	s.Equal(first.Hash, second.Prev)
+	s.FileExists(filepath.Join(dir, "2026-09.log"))
+	s.FileExists(filepath.Join(dir, "2026-10.log"))
+
+	result, err := l.Verify()
+	s.Require().NoError(err)
+	s.True(result.Valid)
+	s.Equal(uint64(2), result.Entries)
+	s.Equal(second.Hash, result.Head)
+
+	// 重新打开后从文件恢复链尾
+	s.Require().NoError(l.Close())
+	l = s.open(dir)
+	third := s.append(l, time.Date(2026, 10, 2, 0, 0, 0, 0, time.Local), 1, "cert renewed")
+	s.Equal(uint64(3), third.Seq)
+	s.Equal(second.Hash, third.Prev)
+}
+
+func (s *AuditTestSuite) TestTamperDetected() {
+	at := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	build := func() (*Log, string) {
+		dir := s.T().TempDir()
+		l := s.open(dir)
+		for i := range 3 {
+			s.append(l, at.Add(time.Duration(i)*time.Minute), 1, "entry")
+		}
+		return l, filepath.Join(dir, "2026-10.log")
+	}
+	verify := func(l *Log) *VerifyResult {
+		result, err := l.Verify()
+		s.Require().NoError(err)
+		s.False(result.Valid)
+		return result
+	}
+
+	// 直接改内容
+	l, name := build()
+	lines := s.lines(name)
+	lines[1] = strings.Replace(lines[1], `"operator_id":1`, `"operator_id":2`, 1)
+	s.write(name, lines)
+	result := verify(l)
+	s.Equal(ReasonHashMismatch, result.Reason)
+	s.Equal(2, result.Line)
+
+	// 改内容并重算本条哈希，下一条的 prev 对不上
+	l, name = build()
+	lines = s.lines(name)
+	var e Entry
+	s.Require().NoError(json.Unmarshal([]byte(lines[1]), &e))
+	e.Msg, e.Hash = "forged", ""
+	data, err := json.Marshal(&e)
+	s.Require().NoError(err)
+	lines[1] = strings.TrimSuffix(string(seal(data, l.digest(data))), "\n")
+	s.write(name, lines)
+	result = verify(l)
+	s.Equal(ReasonPrevMismatch, result.Reason)
+	s.Equal(3, result.Line)
+
+	// 删除中间一条
+	l, name = build()
+	lines = s.lines(name)
+	s.write(name, []string{lines[0], lines[2]})
+	result = verify(l)
+	s.Equal(ReasonPrevMismatch, result.Reason)
+
+	// 不知道密钥时无法重算哈希
+	l, name = build()
+	lines = s.lines(name)
+	s.Require().NoError(json.Unmarshal([]byte(lines[2]), &e))
+	e.Msg, e.Hash = "forged", ""
+	data, err = json.Marshal(&e)
+	s.Require().NoError(err)
+	sum := sha256.Sum256(data)
+	lines[2] = strings.TrimSuffix(string(seal(data, hex.EncodeToString(sum[:]))), "\n")
+	s.write(name, lines)
+	result = verify(l)
+	s.Equal(ReasonHashMismatch, result.Reason)
+	s.Equal(3, result.Line)
+
+	// 非 JSON 行
+	l, name = build()
+	lines = s.lines(name)
+	s.write(name, append(lines, "garbage"))
+	result = verify(l)
+	s.Equal(ReasonMalformed, result.Reason)
+	s.Equal(4, result.Line)
+}
+
+func (s *AuditTestSuite) TestTruncationDetected() {
+	dir := s.T().TempDir()
+	anchor := &memAnchor{}
+	l := s.openAnchored(dir, anchor)
+
+	s.append(l, time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local), 1, "a")
+	s.append(l, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), 1, "b")
+	last := s.append(l, time.Date(2026, 10, 2, 0, 0, 0, 0, time.Local), 1, "c")
+	s.Eventually(func() bool {
+		seq, hash, _ := anchor.Load()
+		return seq == last.Seq && hash == last.Hash
+	}, 5*time.Second, 10*time.Millisecond)
+
+	result, err := l.Verify()
+	s.Require().NoError(err)
+	s.True(result.Valid, result.Reason)
+
+	// 截断最新文件的末尾记录
+	name := filepath.Join(dir, "2026-10.log")
+	lines := s.lines(name)
+	s.write(name, lines[:1])
+	result, err = l.Verify()
+	s.Require().NoError(err)
+	s.False(result.Valid)
+	s.Equal(ReasonTruncated, result.Reason)
+
+	// 截断后继续追加，同序号记录与链尾不符
+	s.append(l, time.Date(2026, 10, 3, 0, 0, 0, 0, time.Local), 1, "d")
+	result, err = l.Verify()
+	s.Require().NoError(err)
+	s.False(result.Valid)
+	s.Equal(ReasonTruncated, result.Reason)
+	s.Equal("2026-10.log", result.File)
+
+	// 删除整个最新月份文件
+	s.Require().NoError(os.Remove(name))
+	result, err = l.Verify()
+	s.Require().NoError(err)
+	s.False(result.Valid)
+	s.Equal(ReasonTruncated, result.Reason)
+}
+
+func (s *AuditTestSuite) TestOldestFileDeleted() {
+	dir := s.T().TempDir()
+	l := s.openAnchored(dir, &memAnchor{})
+	s.append(l, time.Date(2026, 8, 31, 0, 0, 0, 0, time.Local), 1, "a")
+	s.append(l, time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local), 1, "b")
+	s.append(l, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), 1, "c")
+
+	s.Require().NoError(os.Remove(filepath.Join(dir, "2026-08.log")))
+	result, err := l.Verify()
+	s.Require().NoError(err)
+	s.False(result.Valid)
+	s.Equal(ReasonStartMissing, result.Reason)
+	s.Equal("2026-09.log", result.File)
+	s.Equal(1, result.Line)
+}
+
+func (s *AuditTestSuite) TestPrune() {
+	dir := s.T().TempDir()
+	anchor := &memAnchor{}
+	l := s.openAnchored(dir, anchor)
+	s.append(l, time.Date(2026, 8, 31, 0, 0, 0, 0, time.Local), 1, "a")
+	start := s.append(l, time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local), 1, "b")
+	last := s.append(l, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), 1, "c")
+	s.Eventually(func() bool {
+		seq, _, _ := anchor.Load()
+		return seq == last.Seq
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// 九月尚未结束，只清理八月
+	n, err := l.Prune(time.Date(2026, 9, 15, 0, 0, 0, 0, time.Local))
+	s.Require().NoError(err)
+	s.Equal(1, n)
+	s.NoFileExists(filepath.Join(dir, "2026-08.log"))
+	seq, hash, _ := anchor.LoadStart()
+	s.Equal(start.Seq, seq)
+	s.Equal(start.Hash, hash)
+
+	result, err := l.Verify()
+	s.Require().NoError(err)
+	s.True(result.Valid, result.Reason)
+	s.Equal(start.Seq, result.FirstSeq)
+
+	// 链尾所在的文件始终保留
+	n, err = l.Prune(time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local))
+	s.Require().NoError(err)
+	s.Equal(1, n)
+	s.FileExists(filepath.Join(dir, "2026-10.log"))
+	result, err = l.Verify()
+	s.Require().NoError(err)
+	s.True(result.Valid, result.Reason)
+	s.Equal(last.Seq, result.FirstSeq)
+
+	// 未经清理删除链首所在的记录
+	s.append(l, time.Date(2026, 10, 2, 0, 0, 0, 0, time.Local), 1, "d")
+	name := filepath.Join(dir, "2026-10.log")
+	s.write(name, s.lines(name)[1:])
+	result, err = l.Verify()
+	s.Require().NoError(err)
+	s.False(result.Valid)
+	s.Equal(ReasonStartMissing, result.Reason)
+
+	// 链已损坏时拒绝清理
+	s.append(l, time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local), 1, "e")
+	_, err = l.Prune(time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local))
+	s.Error(err)
+	s.FileExists(name)
+}
+
+func (s *AuditTestSuite) TestConcurrentWriters() {
+	dir := s.T().TempDir()
+	panel, cli := s.open(dir), s.open(dir)
+
+	at := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	for i := range 6 {
+		l := panel
+		if i%3 == 0 {
+			l = cli
+		}
+		s.append(l, at.Add(time.Duration(i)*time.Second), 1, "entry")
+	}
+
+	result, err := panel.Verify()
+	s.Require().NoError(err)
+	s.True(result.Valid, result.Reason)
+	s.Equal(uint64(6), result.LastSeq)
+}
+
+func (s *AuditTestSuite) TestRead() {
+	l := s.open(s.T().TempDir())
+	s.append(l, time.Date(2026, 8, 15, 0, 0, 0, 0, time.Local), 1, "a")
+	s.append(l, time.Date(2026, 9, 10, 0, 0, 0, 0, time.Local), 2, "b")
+	s.append(l, time.Date(2026, 9, 20, 0, 0, 0, 0, time.Local), 1, "c")
+	s.append(l, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), 1, "d")
+
+	var msgs []string
+	s.Require().NoError(l.Read(Filter{
+		Start:      time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local),
+		End:        time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local),
+		OperatorID: 1,
+	}, func(e *Entry, raw []byte) error {
+		s.True(l.sealed(raw, e.Hash))
+		msgs = append(msgs, e.Msg)
+		return nil
+	}))
+	s.Equal([]string{"c"}, msgs)
+
+	stop := errors.New("stop")
+	s.ErrorIs(l.Read(Filter{}, func(*Entry, []byte) error { return stop }), stop)
+}
+
+func (s *AuditTestSuite) TestHandler() {
+	dir := s.T().TempDir()
+	l := s.open(dir)
+
+	var out bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&out, nil), l))
+	logger.Info("ignored", slog.String("key", "value"))
+	logger.With(slog.String("type", "user")).Info("user created",
+		slog.Uint64("operator_id", 7),
+		slog.Any("err", errors.New("boom")),
+		slog.Duration("took", time.Second),
+		slog.Group("target", slog.String("name", "alice")),
+	)
+
+	s.Equal(2, strings.Count(out.String(), "\n"))
+
+	var entries []*Entry
+	s.Require().NoError(l.Read(Filter{}, func(e *Entry, _ []byte) error {
+		entries = append(entries, e)
+		return nil
+	}))
+	s.Require().Len(entries, 1)
+	s.Equal("user created", entries[0].Msg)
+	s.Equal("user", entries[0].Type)
+	s.Equal(uint64(7), entries[0].OperatorID)
+	s.Equal("boom", entries[0].Attrs["err"])
+	s.Equal("1s", entries[0].Attrs["took"])
+	s.Equal(map[string]any{"name": "alice"}, entries[0].Attrs["target"])
+}
+
+func (s *AuditTestSuite) TestForwardSyslog() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer func(listener net.Listener) { _ = listener.Close() }(listener)
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func(conn net.Conn) { _ = conn.Close() }(conn)
+		reader := bufio.NewReader(conn)
+		length, _ := reader.ReadString(' ')
+		n, _ := strconv.Atoi(strings.TrimSpace(length))
+		buf := make([]byte, n)
+		_, _ = io.ReadFull(reader, buf)
+		received <- string(buf)
+	}()
+
+	l := s.open(s.T().TempDir())
+	forwarder, err := NewForwarder(ForwardConfig{Type: ForwardTypeSyslog, Network: "tcp", Address: listener.Addr().String()})
+	s.Require().NoError(err)
+	l.SetForwarder(forwarder)
+	e := s.append(l, time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC), 1, "website created")
+
+	select {
+	case msg := <-received:
+		s.True(strings.HasPrefix(msg, "<110>1 2026-10-01T08:00:00.000000Z "))
+		s.Contains(msg, " acepanel ")
+		s.Contains(msg, ` website - {"seq":1,`)
+		s.True(strings.HasSuffix(msg, `"hash":"`+e.Hash+`"}`))
+	case <-time.After(5 * time.Second):
+		s.Fail("syslog message not received")
+	}
+	s.Eventually(func() bool { return l.ForwardStatus().Sent == 1 }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,210 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	ForwardTypeSyslog = "syslog"
+	ForwardTypeHTTP   = "http"
+)
+
+const forwardTimeout = 10 * time.Second
+
+// facilityAudit RFC5424 中的 log audit 设施
+const facilityAudit = 13
+
+// Forwarder 审计记录转发目标，Send 由单个协程串行调用
+type Forwarder interface {
+	Send(e *Entry, raw []byte) error
+	Close() error
+}
+
+// ForwardConfig 转发配置
+type ForwardConfig struct {
+	Type     string            `json:"type"`     // syslog / http，空为不转发
+	Network  string            `json:"network"`  // syslog 传输协议 udp / tcp / tls
+	Address  string            `json:"address"`  // syslog 地址 host:port
+	Insecure bool              `json:"insecure"` // tls 跳过证书校验
+	URL      string            `json:"url"`      // http 接收地址
+	Headers  map[string]string `json:"headers"`  // http 附加请求头，如鉴权 token
+}
+
+// NewForwarder 按配置构造转发目标，未配置时返回 nil
+func NewForwarder(conf ForwardConfig) (Forwarder, error) {
+	switch conf.Type {
+	case "":
+		return nil, nil
+	case ForwardTypeSyslog:
+		return NewSyslog(conf.Network, conf.Address, conf.Insecure)
+	case ForwardTypeHTTP:
+		return NewHTTP(conf.URL, conf.Headers)
+	default:
+		return nil, fmt.Errorf("unsupported forward type: %s", conf.Type)
+	}
+}
+
+// Syslog 以 RFC5424 格式发送到远程 syslog，TCP/TLS 使用 octet-counting 分帧（RFC6587/RFC5425）
+type Syslog struct {
+	network  string
+	address  string
+	tls      *tls.Config
+	hostname string
+	conn     net.Conn
+}
+
+func NewSyslog(network, address string, insecure bool) (*Syslog, error) {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return nil, fmt.Errorf("invalid syslog address: %w", err)
+	}
+
+	s := &Syslog{network: network, address: address}
+	switch network {
+	case "udp", "tcp":
+	case "tls":
+		host, _, _ := net.SplitHostPort(address)
+		s.tls = &tls.Config{ServerName: host, InsecureSkipVerify: insecure}
+	default:
+		return nil, fmt.Errorf("unsupported syslog network: %s", network)
+	}
+
+	s.hostname, _ = os.Hostname()
+	if s.hostname == "" {
+		s.hostname = "-"
+	}
+
+	return s, nil
+}
+
+func (s *Syslog) Send(e *Entry, raw []byte) error {
+	msg := s.format(e, raw)
+	if err := s.write(msg); err != nil {
+		// 长连接可能已被对端关闭，重连重试一次
+		_ = s.Close()
+		return s.write(msg)
+	}
+	return nil
+}
+
+func (s *Syslog) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *Syslog) write(msg []byte) error {
+	if s.conn == nil {
+		dialer := &net.Dialer{Timeout: forwardTimeout}
+		var err error
+		if s.tls != nil {
+			s.conn, err = tls.DialWithDialer(dialer, "tcp", s.address, s.tls)
+		} else {
+			s.conn, err = dialer.Dial(s.network, s.address)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.conn.SetWriteDeadline(time.Now().Add(forwardTimeout)); err != nil {
+		return err
+	}
+	if s.network == "udp" {
+		_, err := s.conn.Write(msg)
+		return err
+	}
+	_, err := s.conn.Write(append(fmt.Appendf(nil, "%d ", len(msg)), msg...))
+	return err
+}
+
+// format 生成 RFC5424 消息，MSG 部分为落盘的 JSON 原文，便于接收端独立校验哈希
+func (s *Syslog) format(e *Entry, raw []byte) []byte {
+	msgID := "-"
+	if e.Type != "" {
+		msgID = e.Type
+	}
+
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "<%d>1 %s %s acepanel %d %s - ",
+		facilityAudit*8+severity(e.Level),
+		e.Time.Format("2006-01-02T15:04:05.000000Z07:00"),
+		s.hostname,
+		os.Getpid(),
+		msgID,
+	)
+	buf.Write(raw)
+	return buf.Bytes()
+}
+
+func severity(level string) int {
+	switch {
+	case strings.HasPrefix(level, "ERROR"):
+		return 3
+	case strings.HasPrefix(level, "WARN"):
+		return 4
+	case strings.HasPrefix(level, "DEBUG"):
+		return 7
+	default:
+		return 6
+	}
+}
+
+// HTTP 逐条以 JSON POST 到指定地址
+type HTTP struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func NewHTTP(url string, headers map[string]string) (*HTTP, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, errors.New("invalid http sink url")
+	}
+
+	return &HTTP{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{Timeout: forwardTimeout},
+	}, nil
+}
+
+func (h *HTTP) Send(_ *Entry, raw []byte) error {
+	req, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "AcePanel-Audit")
+	for k, v := range h.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func(body io.ReadCloser) { _ = body.Close() }(resp.Body)
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("http sink responded with %s", resp.Status)
+	}
+	return nil
+}
+
+func (h *HTTP) Close() error {
+	h.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+
+	"github.com/spf13/cast"
+)
+
+// Handler 包装 slog.Handler，带 operator_id 的操作日志额外写入审计链
+type Handler struct {
+	inner  slog.Handler
+	log    *Log
+	attrs  []scopedAttr
+	groups []string
+}
+
+type scopedAttr struct {
+	groups []string
+	attr   slog.Attr
+}
+
+// NewHandler 构造审计 Handler，log 为 nil 时仅透传
+func NewHandler(inner slog.Handler, log *Log) *Handler {
+	return &Handler{inner: inner, log: log}
+}
+
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	err := h.inner.Handle(ctx, r)
+	if h.log == nil {
+		return err
+	}
+
+	attrs := make(map[string]any)
+	for _, scoped := range h.attrs {
+		put(attrs, scoped.groups, scoped.attr)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		put(attrs, h.groups, a)
+		return true
+	})
+
+	raw, ok := attrs["operator_id"]
+	if !ok {
+		return err
+	}
+	operatorID, castErr := cast.ToUint64E(raw)
+	if castErr != nil {
+		return err
+	}
+	typ, _ := attrs["type"].(string)
+	delete(attrs, "operator_id")
+	delete(attrs, "type")
+	if len(attrs) == 0 {
+		attrs = nil
+	}
+
+	// 审计写入失败不能再走 slog，否则会递归，直接输出到标准错误由 systemd 收集
+	if auditErr := h.log.Append(&Entry{
+		Time:       r.Time,
+		Level:      r.Level.String(),
+		Msg:        r.Message,
+		Type:       typ,
+		OperatorID: operatorID,
+		Attrs:      attrs,
+	}); auditErr != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "audit: failed to append entry: %v\n", auditErr)
+	}
+
+	return err
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	clone := *h
+	clone.inner = h.inner.WithAttrs(attrs)
+	clone.attrs = slices.Clip(h.attrs)
+	for _, a := range attrs {
+		clone.attrs = append(clone.attrs, scopedAttr{groups: h.groups, attr: a})
+	}
+	return &clone
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.inner = h.inner.WithGroup(name)
+	clone.groups = append(slices.Clip(h.groups), name)
+	return &clone
+}
+
+// put 按分组把属性写入嵌套 map
+func put(m map[string]any, groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	for _, group := range groups {
+		sub, ok := m[group].(map[string]any)
+		if !ok {
+			sub = make(map[string]any)
+			m[group] = sub
+		}
+		m = sub
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		var sub []string
+		if a.Key != "" {
+			sub = []string{a.Key}
+		}
+		for _, child := range a.Value.Group() {
+			put(m, sub, child)
+		}
+		return
+	}
+
+	m[a.Key] = value(a.Value)
+}
+
+// value 转换为可稳定序列化的 JSON 值
+func value(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindAny:
+		x := v.Any()
+		if err, ok := x.(error); ok {
+			return err.Error()
+		}
+		if _, err := json.Marshal(x); err != nil {
+			return fmt.Sprintf("%+v", x)
+		}
+		return x
+	default:
+		return v.Any()
+	}
+}
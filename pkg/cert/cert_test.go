@@ -1,6 +1,11 @@
 package cert
 
 import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -27,3 +32,29 @@ func (s *CertTestSuite) TestGenerateSelfSignedRSA() {
 	s.NotNil(pem)
 	s.NotNil(key)
 }
+
+func (s *CertTestSuite) TestProbe() {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	addr := strings.TrimPrefix(server.URL, "https://")
+
+	// 测试证书不受系统信任
+	result, err := Probe(context.Background(), addr, "example.com", nil)
+	s.NoError(err)
+	s.Len(result.Chain, 1)
+	s.False(result.ChainValid)
+	s.NotEmpty(result.ChainError)
+	s.False(result.HostnameMismatch)
+	s.Equal(OCSPUnavailable, result.OCSPStatus)
+	s.Equal(server.Certificate().NotAfter, result.Leaf().NotAfter)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	result, err = Probe(context.Background(), addr, "mismatch.test", roots)
+	s.NoError(err)
+	s.True(result.ChainValid)
+	s.True(result.HostnameMismatch)
+
+	_, err = Probe(context.Background(), "127.0.0.1:1", "example.com", nil)
+	s.Error(err)
+}
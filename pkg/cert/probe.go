@@ -0,0 +1,182 @@
+package cert
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// OCSP 状态
+const (
+	OCSPGood        = "good"
+	OCSPRevoked     = "revoked"
+	OCSPUnknown     = "unknown"     // 响应方不认识该证书
+	OCSPUnavailable = "unavailable" // 证书未提供 OCSP 地址或查询失败
+)
+
+// ChainItem 证书链中的一张证书
+type ChainItem struct {
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	SerialNumber string    `json:"serial_number"`
+	NotBefore    time.Time `json:"not_before"`
+	NotAfter     time.Time `json:"not_after"`
+	SHA256       string    `json:"sha256"`
+}
+
+// ProbeResult 探测结果
+type ProbeResult struct {
+	Chain            []ChainItem `json:"chain"`             // 服务端发送的证书链，第一张为叶子证书
+	DNSNames         []string    `json:"dns_names"`         // 叶子证书包含的域名
+	ChainValid       bool        `json:"chain_valid"`       // 能否通过系统根证书验证
+	ChainError       string      `json:"chain_error"`       // 链验证失败原因
+	HostnameMismatch bool        `json:"hostname_mismatch"` // 证书与 SNI 不匹配
+	OCSPStatus       string      `json:"ocsp_status"`
+	OCSPStapled      bool        `json:"ocsp_stapled"` // OCSP 状态来自服务端装订
+	TLSVersion       string      `json:"tls_version"`
+}
+
+// Leaf 返回叶子证书信息
+func (r *ProbeResult) Leaf() ChainItem {
+	if len(r.Chain) == 0 {
+		return ChainItem{}
+	}
+	return r.Chain[0]
+}
+
+// Probe 连接 addr（host:port）并以 sni 发起 TLS 握手，检查服务端证书
+// 握手本身跳过验证，以便对过期、自签名等证书同样取得完整信息
+func Probe(ctx context.Context, addr, sni string, roots *x509.CertPool) (*ProbeResult, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: 10 * time.Second},
+		Config: &tls.Config{
+			ServerName:         sni,
+			InsecureSkipVerify: true,
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer func(conn net.Conn) { _ = conn.Close() }(conn)
+
+	state := conn.(*tls.Conn).ConnectionState()
+	certs := state.PeerCertificates
+	if len(certs) == 0 {
+		return nil, errors.New("server did not present a certificate")
+	}
+
+	result := &ProbeResult{
+		Chain:      make([]ChainItem, 0, len(certs)),
+		DNSNames:   certs[0].DNSNames,
+		TLSVersion: tls.VersionName(state.Version),
+	}
+	for _, item := range certs {
+		result.Chain = append(result.Chain, chainItem(item))
+	}
+
+	// 链验证不带域名，域名匹配单独判断
+	intermediates := x509.NewCertPool()
+	for _, item := range certs[1:] {
+		intermediates.AddCert(item)
+	}
+	chains, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+	if err != nil {
+		result.ChainError = err.Error()
+	} else {
+		result.ChainValid = true
+	}
+	if sni != "" {
+		result.HostnameMismatch = certs[0].VerifyHostname(sni) != nil
+	}
+
+	// 确定签发者：优先使用验证得到的链，其次是服务端发送的第二张证书
+	var issuer *x509.Certificate
+	if len(chains) > 0 && len(chains[0]) > 1 {
+		issuer = chains[0][1]
+	} else if len(certs) > 1 {
+		issuer = certs[1]
+	}
+	result.OCSPStatus, result.OCSPStapled = checkOCSP(ctx, certs[0], issuer, state.OCSPResponse)
+
+	return result, nil
+}
+
+// checkOCSP 优先使用服务端装订的 OCSP 响应，没有时向证书中的 OCSP 地址查询
+func checkOCSP(ctx context.Context, leaf, issuer *x509.Certificate, stapled []byte) (string, bool) {
+	if issuer == nil {
+		return OCSPUnavailable, false
+	}
+	if len(stapled) > 0 {
+		if resp, err := ocsp.ParseResponseForCert(stapled, leaf, issuer); err == nil {
+			return ocspStatus(resp.Status), true
+		}
+	}
+	if len(leaf.OCSPServer) == 0 {
+		return OCSPUnavailable, false
+	}
+
+	body, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return OCSPUnavailable, false
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, leaf.OCSPServer[0], bytes.NewReader(body))
+	if err != nil {
+		return OCSPUnavailable, false
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return OCSPUnavailable, false
+	}
+	defer func(Body io.ReadCloser) { _ = Body.Close() }(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return OCSPUnavailable, false
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return OCSPUnavailable, false
+	}
+	parsed, err := ocsp.ParseResponseForCert(raw, leaf, issuer)
+	if err != nil {
+		return OCSPUnavailable, false
+	}
+
+	return ocspStatus(parsed.Status), false
+}
+
+func ocspStatus(status int) string {
+	switch status {
+	case ocsp.Good:
+		return OCSPGood
+	case ocsp.Revoked:
+		return OCSPRevoked
+	default:
+		return OCSPUnknown
+	}
+}
+
+func chainItem(cert *x509.Certificate) ChainItem {
+	sum := sha256.Sum256(cert.Raw)
+	return ChainItem{
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: strings.ToUpper(cert.SerialNumber.Text(16)),
+		NotBefore:    cert.NotBefore,
+		NotAfter:     cert.NotAfter,
+		SHA256:       hex.EncodeToString(sum[:]),
+	}
+}
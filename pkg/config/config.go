@@ -26,6 +26,7 @@ type AppConfig struct {
 	Root             string `yaml:"root"`
 	APIEndpoint      string `yaml:"api_endpoint"`
 	DownloadEndpoint string `yaml:"download_endpoint"`
+	Firewall         string `yaml:"firewall"` // 防火墙后端，为空时自动检测
 }
 
 type HTTPConfig struct {
@@ -0,0 +1,151 @@
+// Package configtest 调用 Web 服务器与 PHP-FPM 自带的配置检查，并把输出解析为结构化错误
+package configtest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/acepanel/panel/v3/pkg/shell"
+)
+
+const (
+	ServerNginx  = "nginx"
+	ServerApache = "apache"
+	ServerPHPFPM = "php-fpm"
+)
+
+// Error 配置检查失败，File/Line/Directive 在无法从输出中解析时为空
+type Error struct {
+	Server    string `json:"server"`
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Directive string `json:"directive"`
+	Message   string `json:"message"`
+	Output    string `json:"output"`
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Server)
+	b.WriteString(" config test failed")
+	if e.File != "" {
+		_, _ = fmt.Fprintf(&b, " at %s:%d", e.File, e.Line)
+	}
+	if e.Directive != "" {
+		_, _ = fmt.Fprintf(&b, " (directive %q)", e.Directive)
+	}
+	if e.Message != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Message)
+	}
+	return b.String()
+}
+
+// Detail 供接口响应附带的结构化信息
+func (e *Error) Detail() any {
+	return e
+}
+
+// Nginx 执行 nginx -t
+func Nginx() error {
+	return run(ServerNginx, "nginx -t 2>&1")
+}
+
+// Apache 执行 apachectl configtest
+func Apache(root string) error {
+	return run(ServerApache, fmt.Sprintf("%s/server/apache/bin/apachectl configtest 2>&1", root))
+}
+
+// WebServer 按 Web 服务器类型执行对应检查
+func WebServer(root, server string) error {
+	if server == ServerApache {
+		return Apache(root)
+	}
+	return Nginx()
+}
+
+// PHPFPM 检查指定版本的 php-fpm.conf 与 php.ini
+func PHPFPM(root string, version uint) error {
+	prefix := fmt.Sprintf("%s/server/php/%d", root, version)
+	return run(ServerPHPFPM, fmt.Sprintf("%s/sbin/php-fpm -t -y %s/etc/php-fpm.conf -c %s/etc/php.ini 2>&1", prefix, prefix, prefix))
+}
+
+func run(server, cmd string) error {
+	out, err := shell.Execf(cmd)
+	if err == nil {
+		return nil
+	}
+	if strings.TrimSpace(out) == "" {
+		out = err.Error()
+	}
+	return Parse(server, out)
+}
+
+var (
+	nginxError      = regexp.MustCompile(`\[(?:emerg|alert|crit|error)\] (.+?) in (\S+):(\d+)`)
+	nginxFallback   = regexp.MustCompile(`\[(?:emerg|alert|crit|error)\] (.+)`)
+	nginxDirective  = regexp.MustCompile(`unknown directive "([^"]+)"|"([^"]+)" directive`)
+	apacheError     = regexp.MustCompile(`Syntax error on line (\d+) of ([^:]+):`)
+	apacheDirective = regexp.MustCompile(`^Invalid command '([^']+)'|^(\w+):`)
+	fpmError        = regexp.MustCompile(`ERROR: \[(\S+):(\d+)\] (.+)`)
+	fpmINIError     = regexp.MustCompile(`(?:PHP:\s+)?(.+?) in (\S+) on line (\d+)`)
+	fpmDirective    = regexp.MustCompile(`(?:unknown entry|invalid value for) '([^']+)'`)
+)
+
+// Parse 解析配置检查输出，取第一处错误
+func Parse(server, out string) *Error {
+	out = strings.TrimSpace(out)
+	e := &Error{Server: server, Output: out}
+
+	switch server {
+	case ServerNginx:
+		if m := nginxError.FindStringSubmatch(out); m != nil {
+			e.Message, e.File, e.Line = m[1], m[2], atoi(m[3])
+		} else if m = nginxFallback.FindStringSubmatch(out); m != nil {
+			e.Message = m[1]
+		}
+		if m := nginxDirective.FindStringSubmatch(e.Message); m != nil {
+			e.Directive = m[1] + m[2]
+		}
+	case ServerApache:
+		if m := apacheError.FindStringSubmatchIndex(out); m != nil {
+			e.Line, e.File = atoi(out[m[2]:m[3]]), out[m[4]:m[5]]
+			// 具体原因在下一行
+			rest := strings.TrimLeft(out[m[1]:], "\r\n")
+			e.Message, _, _ = strings.Cut(rest, "\n")
+			e.Message = strings.TrimSpace(e.Message)
+			if d := apacheDirective.FindStringSubmatch(e.Message); d != nil {
+				e.Directive = d[1] + d[2]
+			}
+		} else {
+			e.Message = lastLine(out)
+		}
+	case ServerPHPFPM:
+		if m := fpmError.FindStringSubmatch(out); m != nil {
+			e.File, e.Line, e.Message = m[1], atoi(m[2]), m[3]
+		} else if m = fpmINIError.FindStringSubmatch(out); m != nil {
+			e.Message, e.File, e.Line = m[1], m[2], atoi(m[3])
+		} else {
+			e.Message = lastLine(out)
+		}
+		if m := fpmDirective.FindStringSubmatch(e.Message); m != nil {
+			e.Directive = m[1]
+		}
+	default:
+		e.Message = lastLine(out)
+	}
+
+	return e
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func lastLine(out string) string {
+	lines := strings.Split(out, "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
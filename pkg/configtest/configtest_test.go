@@ -0,0 +1,65 @@
+package configtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigTestTestSuite struct {
+	suite.Suite
+}
+
+func TestConfigTestTestSuite(t *testing.T) {
+	suite.Run(t, &ConfigTestTestSuite{})
+}
+
+func (s *ConfigTestTestSuite) TestNginx() {
+	e := Parse(ServerNginx, `nginx: [emerg] unknown directive "proxy_passs" in /opt/ace/sites/example/config/site/010-proxy.conf:12
+nginx: configuration file /opt/ace/server/nginx/conf/nginx.conf test failed`)
+	s.Equal("/opt/ace/sites/example/config/site/010-proxy.conf", e.File)
+	s.Equal(12, e.Line)
+	s.Equal("proxy_passs", e.Directive)
+	s.Equal(`unknown directive "proxy_passs"`, e.Message)
+
+	e = Parse(ServerNginx, `nginx: [emerg] invalid number of arguments in "root" directive in /opt/ace/sites/example/config/nginx.conf:8`)
+	s.Equal("root", e.Directive)
+	s.Equal(8, e.Line)
+
+	e = Parse(ServerNginx, `nginx: [emerg] unexpected "}" in /opt/ace/sites/example/config/nginx.conf:30`)
+	s.Empty(e.Directive)
+	s.Equal(30, e.Line)
+
+	e = Parse(ServerNginx, `nginx: [emerg] cannot load certificate "/opt/ace/sites/example/config/fullchain.pem": PEM_read_bio_X509_AUX() failed`)
+	s.Empty(e.File)
+	s.Contains(e.Message, "cannot load certificate")
+	s.Contains(e.Error(), "nginx config test failed: cannot load certificate")
+}
+
+func (s *ConfigTestTestSuite) TestApache() {
+	e := Parse(ServerApache, `AH00526: Syntax error on line 12 of /opt/ace/sites/example/config/apache.conf:
+Invalid command 'ProxyPasss', perhaps misspelled or defined by a module not included in the server configuration`)
+	s.Equal("/opt/ace/sites/example/config/apache.conf", e.File)
+	s.Equal(12, e.Line)
+	s.Equal("ProxyPasss", e.Directive)
+	s.Contains(e.Message, "perhaps misspelled")
+
+	e = Parse(ServerApache, `AH00526: Syntax error on line 20 of /opt/ace/server/apache/conf/httpd.conf:
+SSLCertificateFile: file '/tmp/missing.pem' does not exist or is empty`)
+	s.Equal("SSLCertificateFile", e.Directive)
+	s.Equal(20, e.Line)
+}
+
+func (s *ConfigTestTestSuite) TestPHPFPM() {
+	e := Parse(ServerPHPFPM, `[17-Oct-2026 10:00:00] ERROR: [/opt/ace/server/php/84/etc/php-fpm.conf:25] unknown entry 'pm.max_childs'
+[17-Oct-2026 10:00:00] ERROR: failed to load configuration file '/opt/ace/server/php/84/etc/php-fpm.conf'
+[17-Oct-2026 10:00:00] ERROR: FPM initialization failed`)
+	s.Equal("/opt/ace/server/php/84/etc/php-fpm.conf", e.File)
+	s.Equal(25, e.Line)
+	s.Equal("pm.max_childs", e.Directive)
+
+	e = Parse(ServerPHPFPM, `PHP:  syntax error, unexpected '=' in /opt/ace/server/php/84/etc/php.ini on line 404`)
+	s.Equal("/opt/ace/server/php/84/etc/php.ini", e.File)
+	s.Equal(404, e.Line)
+	s.Equal("syntax error, unexpected '='", e.Message)
+}
@@ -0,0 +1,233 @@
+// Package conftx 为配置文件修改提供事务语义。
+//
+// Begin 在修改前把文件或整个目录快照到内存，配置校验或服务重载失败时
+// Rollback 把磁盘恢复到快照状态。目录通过先写临时目录再 rename 交换的方式整体恢复，
+// 避免恢复过程中服务读到一半新一半旧的配置。
+package conftx
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Tx 一组文件/目录的快照
+type Tx struct {
+	items []*item
+}
+
+type item struct {
+	path    string
+	exists  bool
+	entries []entry // 文件时只有一条，相对路径为空
+	mode    fs.FileMode
+	dir     bool
+}
+
+type entry struct {
+	rel  string
+	mode fs.FileMode
+	data []byte // 普通文件内容
+	link string // 符号链接目标
+}
+
+// Begin 快照给定路径，路径不存在时回滚会将其删除
+func Begin(paths ...string) (*Tx, error) {
+	tx := &Tx{}
+	for _, path := range paths {
+		it, err := snapshot(path)
+		if err != nil {
+			return nil, err
+		}
+		tx.items = append(tx.items, it)
+	}
+
+	return tx, nil
+}
+
+// Apply 快照 paths 后执行 mutate 与 verify，任一步失败都回滚并返回错误
+func Apply(paths []string, mutate, verify func() error) error {
+	tx, err := Begin(paths...)
+	if err != nil {
+		return err
+	}
+	if err = mutate(); err == nil {
+		err = verify()
+	}
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback failed: %w", rbErr))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Rollback 把全部路径恢复到快照状态
+func (tx *Tx) Rollback() error {
+	var err error
+	for i := len(tx.items) - 1; i >= 0; i-- {
+		err = errors.Join(err, tx.items[i].restore())
+	}
+	return err
+}
+
+func snapshot(path string) (*item, error) {
+	it := &item{path: path}
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return it, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	it.exists = true
+	it.mode = info.Mode()
+	if !info.IsDir() {
+		e, err := read(path, "", info)
+		if err != nil {
+			return nil, err
+		}
+		it.entries = []entry{e}
+		return it, nil
+	}
+
+	it.dir = true
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == path {
+			return nil
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		e, err := read(p, rel, info)
+		if err != nil {
+			return err
+		}
+		it.entries = append(it.entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return it, nil
+}
+
+func read(path, rel string, info fs.FileInfo) (entry, error) {
+	e := entry{rel: rel, mode: info.Mode()}
+	switch {
+	case info.Mode()&fs.ModeSymlink != 0:
+		link, err := os.Readlink(path)
+		if err != nil {
+			return e, err
+		}
+		e.link = link
+	case info.Mode().IsRegular():
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return e, err
+		}
+		e.data = data
+	}
+	return e, nil
+}
+
+func (it *item) restore() error {
+	if !it.exists {
+		return os.RemoveAll(it.path)
+	}
+
+	// 先在同目录写出完整副本再 rename，保证替换是原子的
+	tmp := fmt.Sprintf("%s.conftx-%d", it.path, time.Now().UnixNano())
+	if err := it.write(tmp); err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+
+	if !it.dir {
+		// rename 可直接覆盖文件，只有原路径被换成目录时才需要先删除
+		if info, err := os.Lstat(it.path); err == nil && info.IsDir() {
+			if err = os.RemoveAll(it.path); err != nil {
+				_ = os.RemoveAll(tmp)
+				return err
+			}
+		}
+		return os.Rename(tmp, it.path)
+	}
+
+	old := tmp + ".old"
+	if err := os.Rename(it.path, old); err != nil && !os.IsNotExist(err) {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, it.path); err != nil {
+		_ = os.Rename(old, it.path)
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+
+	return os.RemoveAll(old)
+}
+
+// write 把快照写出到 dst
+func (it *item) write(dst string) error {
+	if !it.dir {
+		return create(dst, it.entries[0])
+	}
+
+	if err := os.Mkdir(dst, 0o700); err != nil {
+		return err
+	}
+	// 目录权限最后设置，避免只读目录无法写入子项
+	var dirs []entry
+	for _, e := range it.entries {
+		target := filepath.Join(dst, e.rel)
+		if e.mode.IsDir() {
+			if err := os.Mkdir(target, 0o700); err != nil {
+				return err
+			}
+			dirs = append(dirs, e)
+			continue
+		}
+		if err := create(target, e); err != nil {
+			return err
+		}
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chmod(filepath.Join(dst, dirs[i].rel), dirs[i].mode.Perm()); err != nil {
+			return err
+		}
+	}
+
+	return os.Chmod(dst, it.mode.Perm())
+}
+
+func create(path string, e entry) error {
+	switch {
+	case e.mode&fs.ModeSymlink != 0:
+		return os.Symlink(e.link, path)
+	case e.mode.IsRegular():
+		if err := os.WriteFile(path, e.data, e.mode.Perm()); err != nil {
+			return err
+		}
+		// WriteFile 受 umask 影响，显式设置一次
+		return os.Chmod(path, e.mode.Perm())
+	default:
+		// 设备、管道等特殊文件不会出现在配置中，忽略
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package conftx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConftxTestSuite struct {
+	suite.Suite
+}
+
+func TestConftxTestSuite(t *testing.T) {
+	suite.Run(t, &ConftxTestSuite{})
+}
+
+func (s *ConftxTestSuite) write(name, content string) {
+	s.Require().NoError(os.MkdirAll(filepath.Dir(name), 0o755))
+	s.Require().NoError(os.WriteFile(name, []byte(content), 0o644))
+}
+
+func (s *ConftxTestSuite) read(name string) string {
+	content, err := os.ReadFile(name)
+	s.Require().NoError(err)
+	return string(content)
+}
+
+func (s *ConftxTestSuite) TestRollbackDir() {
+	dir := filepath.Join(s.T().TempDir(), "config")
+	s.write(filepath.Join(dir, "nginx.conf"), "server {}")
+	s.write(filepath.Join(dir, "site", "010-redirect.conf"), "return 301;")
+	s.Require().NoError(os.Symlink("nginx.conf", filepath.Join(dir, "link.conf")))
+	s.Require().NoError(os.Chmod(filepath.Join(dir, "nginx.conf"), 0o600))
+
+	bad := errors.New("nginx -t failed")
+	err := Apply([]string{dir}, func() error {
+		s.write(filepath.Join(dir, "nginx.conf"), "server { bad }")
+		s.write(filepath.Join(dir, "site", "020-new.conf"), "new")
+		return os.Remove(filepath.Join(dir, "site", "010-redirect.conf"))
+	}, func() error {
+		return bad
+	})
+	s.ErrorIs(err, bad)
+
+	s.Equal("server {}", s.read(filepath.Join(dir, "nginx.conf")))
+	s.Equal("return 301;", s.read(filepath.Join(dir, "site", "010-redirect.conf")))
+	s.NoFileExists(filepath.Join(dir, "site", "020-new.conf"))
+	link, err := os.Readlink(filepath.Join(dir, "link.conf"))
+	s.Require().NoError(err)
+	s.Equal("nginx.conf", link)
+	info, err := os.Stat(filepath.Join(dir, "nginx.conf"))
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	// 不应残留临时目录
+	entries, err := os.ReadDir(filepath.Dir(dir))
+	s.Require().NoError(err)
+	s.Len(entries, 1)
+}
+
+func (s *ConftxTestSuite) TestRollbackFile() {
+	root := s.T().TempDir()
+	existing := filepath.Join(root, "php.ini")
+	created := filepath.Join(root, "php-fpm.d", "www.conf")
+	s.write(existing, "memory_limit = 128M")
+
+	err := Apply([]string{existing, created}, func() error {
+		s.write(existing, "memory_limit = bad")
+		s.write(created, "pm = static")
+		return errors.New("mutate failed")
+	}, func() error {
+		s.Fail("verify should not run after mutate failed")
+		return nil
+	})
+	s.Error(err)
+
+	s.Equal("memory_limit = 128M", s.read(existing))
+	s.NoFileExists(created)
+}
+
+func (s *ConftxTestSuite) TestCommit() {
+	name := filepath.Join(s.T().TempDir(), "httpd.conf")
+	s.write(name, "old")
+
+	s.NoError(Apply([]string{name}, func() error {
+		s.write(name, "new")
+		return nil
+	}, func() error {
+		return nil
+	}))
+	s.Equal("new", s.read(name))
+}
@@ -1,10 +1,15 @@
 package db
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"resty.dev/v3"
 )
@@ -259,3 +264,221 @@ func (r *Elasticsearch) DocumentDelete(index, id string) error {
 	}
 	return nil
 }
+
+// esExportMeta 导出文件首行，记录索引的设置与映射
+type esExportMeta struct {
+	Index    string          `json:"index"`
+	Settings json.RawMessage `json:"settings"`
+	Mappings json.RawMessage `json:"mappings"`
+}
+
+// esExportDoc 导出文件中的文档行
+type esExportDoc struct {
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+}
+
+// esVolatileSettings 随索引创建自动生成或会阻止写入的设置，导入时不能带上
+var esVolatileSettings = []string{"uuid", "creation_date", "provided_name", "version", "routing", "resize", "history_uuid", "blocks", "verified_before_close"}
+
+// Export 以 scroll 方式导出索引为 NDJSON，首行为索引设置与映射，其后每行一个文档，返回文档数
+func (r *Elasticsearch) Export(index string, w io.Writer) (int, error) {
+	resp, err := r.client.R().Get("/" + index)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get index: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return 0, fmt.Errorf("failed to get index: %s", resp.String())
+	}
+	var info map[string]struct {
+		Settings struct {
+			Index map[string]json.RawMessage `json:"index"`
+		} `json:"settings"`
+		Mappings json.RawMessage `json:"mappings"`
+	}
+	if err = json.Unmarshal(resp.Bytes(), &info); err != nil {
+		return 0, fmt.Errorf("failed to parse index: %w", err)
+	}
+	detail, ok := info[index]
+	if !ok {
+		return 0, fmt.Errorf("index not found: %s", index)
+	}
+	for _, key := range esVolatileSettings {
+		delete(detail.Settings.Index, key)
+	}
+	settings, err := json.Marshal(map[string]any{"index": detail.Settings.Index})
+	if err != nil {
+		return 0, err
+	}
+
+	encoder := json.NewEncoder(w)
+	if err = encoder.Encode(esExportMeta{Index: index, Settings: settings, Mappings: detail.Mappings}); err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		ScrollID string `json:"_scroll_id"`
+		Hits     struct {
+			Hits []esExportDoc `json:"hits"`
+		} `json:"hits"`
+	}
+	resp, err = r.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetQueryParam("scroll", "5m").
+		SetBody(map[string]any{"size": 1000, "sort": []string{"_doc"}}).
+		Post("/" + index + "/_search")
+	count := 0
+	for {
+		if err != nil {
+			return count, fmt.Errorf("failed to scroll index: %w", err)
+		}
+		if resp.StatusCode() != 200 {
+			return count, fmt.Errorf("failed to scroll index: %s", resp.String())
+		}
+		result.Hits.Hits = nil
+		if err = json.Unmarshal(resp.Bytes(), &result); err != nil {
+			return count, fmt.Errorf("failed to parse scroll result: %w", err)
+		}
+		if len(result.Hits.Hits) == 0 {
+			break
+		}
+		for _, doc := range result.Hits.Hits {
+			if err = encoder.Encode(doc); err != nil {
+				return count, err
+			}
+		}
+		count += len(result.Hits.Hits)
+
+		resp, err = r.client.R().
+			SetHeader("Content-Type", "application/json").
+			SetBody(map[string]any{"scroll": "5m", "scroll_id": result.ScrollID}).
+			Post("/_search/scroll")
+	}
+
+	if result.ScrollID != "" {
+		_, _ = r.client.R().
+			SetHeader("Content-Type", "application/json").
+			SetBody(map[string]any{"scroll_id": result.ScrollID}).
+			Delete("/_search/scroll")
+	}
+
+	return count, nil
+}
+
+// Import 从 Export 的输出重建索引，已存在的同名索引会被删除，返回文档数
+func (r *Elasticsearch) Import(index string, reader io.Reader) (int, error) {
+	br := bufio.NewReaderSize(reader, 1<<20)
+	line, err := br.ReadBytes('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, err
+	}
+	var meta esExportMeta
+	if err = json.Unmarshal(line, &meta); err != nil || meta.Mappings == nil {
+		return 0, errors.New("invalid elasticsearch export: missing index metadata")
+	}
+
+	resp, err := r.client.R().Delete("/" + index)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete index: %w", err)
+	}
+	if resp.StatusCode() != 200 && resp.StatusCode() != 404 {
+		return 0, fmt.Errorf("failed to delete index: %s", resp.String())
+	}
+	resp, err = r.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]json.RawMessage{"settings": meta.Settings, "mappings": meta.Mappings}).
+		Put("/" + index)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create index: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return 0, fmt.Errorf("failed to create index: %s", resp.String())
+	}
+
+	var bulk bytes.Buffer
+	pending, count := 0, 0
+	flush := func() error {
+		if pending == 0 {
+			return nil
+		}
+		if err := r.bulk(index, bulk.Bytes()); err != nil {
+			return err
+		}
+		count += pending
+		pending = 0
+		bulk.Reset()
+		return nil
+	}
+
+	for {
+		line, err = br.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var doc esExportDoc
+			if jsonErr := json.Unmarshal(line, &doc); jsonErr != nil {
+				return count, fmt.Errorf("invalid elasticsearch export: %w", jsonErr)
+			}
+			action, _ := json.Marshal(map[string]map[string]string{"index": {"_id": doc.ID}})
+			bulk.Write(action)
+			bulk.WriteByte('\n')
+			bulk.Write(doc.Source)
+			bulk.WriteByte('\n')
+			pending++
+			// 按条数与体积分批，避免单次请求过大
+			if pending >= 1000 || bulk.Len() >= 8<<20 {
+				if err := flush(); err != nil {
+					return count, err
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+	}
+	if err = flush(); err != nil {
+		return count, err
+	}
+
+	_, _ = r.client.R().Post("/" + index + "/_refresh")
+	return count, nil
+}
+
+// bulk 提交一批 _bulk 请求，任一文档失败即返回错误
+func (r *Elasticsearch) bulk(index string, body []byte) error {
+	resp, err := r.client.R().
+		SetTimeout(5*time.Minute).
+		SetHeader("Content-Type", "application/x-ndjson").
+		SetBody(body).
+		Post("/" + index + "/_bulk")
+	if err != nil {
+		return fmt.Errorf("failed to import documents: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("failed to import documents: %s", resp.String())
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID    string          `json:"_id"`
+			Error json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err = json.Unmarshal(resp.Bytes(), &result); err != nil {
+		return fmt.Errorf("failed to parse bulk result: %w", err)
+	}
+	if !result.Errors {
+		return nil
+	}
+	for _, item := range result.Items {
+		for _, status := range item {
+			if len(status.Error) > 0 && string(status.Error) != "null" {
+				return fmt.Errorf("failed to import document %s: %s", status.ID, status.Error)
+			}
+		}
+	}
+
+	return errors.New("failed to import documents")
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// MongoDBPort 读取本地 MongoDB 端口
+func MongoDBPort(root string) uint {
+	return yamlPort(filepath.Join(root, "server/mongodb/mongod.conf"), "net.port", 27017)
+}
+
+// ElasticsearchPort 读取本地 Elasticsearch HTTP 端口
+func ElasticsearchPort(root string) uint {
+	return yamlPort(filepath.Join(root, "server/elasticsearch/config/elasticsearch.yml"), "http.port", 9200)
+}
+
+// yamlPort 读取 YAML 配置中的端口，兼容点号键（http.port: 9200）与嵌套写法
+func yamlPort(path, key string, fallback uint) uint {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fallback
+	}
+	var cfg map[string]any
+	if err = yaml.Unmarshal(content, &cfg); err != nil {
+		return fallback
+	}
+
+	value, ok := cfg[key]
+	if !ok {
+		var current any = cfg
+		for part := range strings.SplitSeq(key, ".") {
+			m, isMap := current.(map[string]any)
+			if !isMap {
+				return fallback
+			}
+			current = m[part]
+		}
+		value = current
+	}
+
+	var port uint64
+	switch v := value.(type) {
+	case int:
+		port = uint64(v)
+	case string:
+		port, _ = strconv.ParseUint(v, 10, 32)
+	}
+	if port == 0 || port > 65535 {
+		return fallback
+	}
+
+	return uint(port)
+}
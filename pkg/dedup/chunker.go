@@ -0,0 +1,113 @@
+package dedup
+
+import (
+	"errors"
+	"io"
+)
+
+// 分块大小，平均值决定去重粒度，上下限避免碎块与超大块
+const (
+	MinChunkSize = 256 << 10
+	AvgChunkSize = 1 << 20
+	MaxChunkSize = 4 << 20
+)
+
+// 归一化分块掩码（FastCDC）：未达平均大小时用更严格的掩码，超过后放宽，使块大小集中在平均值附近
+// 取高位以让判定依赖最近 64 字节，而非仅最后几个字节
+const (
+	maskStrict uint64 = 0xfffffc0000000000 // 高 22 位
+	maskLoose  uint64 = 0xffffc00000000000 // 高 18 位
+)
+
+// gear 滚动哈希表，由固定种子生成，改变会导致新旧快照无法互相去重
+var gear [256]uint64
+
+func init() {
+	// splitmix64
+	seed := uint64(0x41636550616e656c) // "AcePanel"
+	for i := range gear {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		gear[i] = z ^ (z >> 31)
+	}
+}
+
+// Chunker 基于内容的分块器，相同内容无论前方插入或删除多少数据都会切出相同的块
+type Chunker struct {
+	r   io.Reader
+	buf []byte
+	pos int // 缓冲区中下一个块的起点
+	end int // 缓冲区中有效数据的终点
+	eof bool
+}
+
+func NewChunker(r io.Reader) *Chunker {
+	return &Chunker{r: r, buf: make([]byte, 2*MaxChunkSize)}
+}
+
+// Next 返回下一个块，数据读完时返回 io.EOF
+// 返回的切片在下次调用 Next 前有效
+func (c *Chunker) Next() ([]byte, error) {
+	if err := c.fill(); err != nil {
+		return nil, err
+	}
+	if c.pos == c.end {
+		return nil, io.EOF
+	}
+
+	data := c.buf[c.pos:c.end]
+	n := cut(data)
+	c.pos += n
+
+	return data[:n], nil
+}
+
+// fill 保证缓冲区中至少有一个最大块的数据，或已读到末尾
+func (c *Chunker) fill() error {
+	if c.eof || c.end-c.pos >= MaxChunkSize {
+		return nil
+	}
+
+	// 剩余数据移到缓冲区开头
+	c.end = copy(c.buf, c.buf[c.pos:c.end])
+	c.pos = 0
+	for c.end < len(c.buf) && !c.eof {
+		n, err := c.r.Read(c.buf[c.end:])
+		c.end += n
+		if errors.Is(err, io.EOF) {
+			c.eof = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cut 返回 data 中第一个块的长度
+func cut(data []byte) int {
+	if len(data) <= MinChunkSize {
+		return len(data)
+	}
+	limit := min(len(data), MaxChunkSize)
+	normal := min(limit, AvgChunkSize)
+
+	var fp uint64
+	i := MinChunkSize
+	for ; i < normal; i++ {
+		fp = (fp << 1) + gear[data[i]]
+		if fp&maskStrict == 0 {
+			return i + 1
+		}
+	}
+	for ; i < limit; i++ {
+		fp = (fp << 1) + gear[data[i]]
+		if fp&maskLoose == 0 {
+			return i + 1
+		}
+	}
+
+	return limit
+}
@@ -0,0 +1,191 @@
+package dedup
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrWrongPassphrase 口令与仓库不匹配
+var ErrWrongPassphrase = errors.New("wrong passphrase for encrypted repository")
+
+// 加密仓库的密钥派生参数，写入仓库配置，调整后旧仓库仍按其配置中的参数派生
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// checkPlaintext 用于校验口令的已知明文
+const checkPlaintext = "acepanel-dedup"
+
+// config 仓库配置，只有加密仓库才有，不存在即为明文仓库
+type config struct {
+	Version int    `json:"version"`
+	Cipher  string `json:"cipher"`
+	KDF     string `json:"kdf"`
+	N       int    `json:"n"`
+	R       int    `json:"r"`
+	P       int    `json:"p"`
+	Salt    []byte `json:"salt"`
+	Check   []byte `json:"check"` // 用数据密钥加密的已知明文
+}
+
+// keys 加密仓库的密钥，数据块名用带密钥的 HMAC，避免通过块名确认明文内容
+type keys struct {
+	enc []byte // XChaCha20-Poly1305 数据密钥
+	id  []byte // 块名 HMAC 密钥
+}
+
+func deriveKeys(passphrase string, cfg *config) (*keys, error) {
+	derived, err := scrypt.Key([]byte(passphrase), cfg.Salt, cfg.N, cfg.R, cfg.P, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &keys{enc: derived[:32], id: derived[32:]}, nil
+}
+
+// openConfig 读取仓库配置并按口令派生密钥
+// 明文仓库传入口令时，仓库为空则生成新配置（首次备份时写入），否则报错，避免同一仓库混用
+func (r *Repository) openConfig(passphrase string) error {
+	reader, err := r.store.Get(r.configPath())
+	if err != nil {
+		if !r.store.Exists(r.configPath()) {
+			return r.newConfig(passphrase)
+		}
+		return err
+	}
+	defer func(reader io.ReadCloser) { _ = reader.Close() }(reader)
+
+	cfg := new(config)
+	if err = json.NewDecoder(reader).Decode(cfg); err != nil {
+		return fmt.Errorf("invalid repository config: %w", err)
+	}
+	if passphrase == "" {
+		return errors.New("repository is encrypted, passphrase required")
+	}
+	if cfg.Cipher != "xchacha20-poly1305" || cfg.KDF != "scrypt" {
+		return fmt.Errorf("unsupported repository encryption: %s/%s", cfg.Cipher, cfg.KDF)
+	}
+
+	r.keys, err = deriveKeys(passphrase, cfg)
+	if err != nil {
+		return err
+	}
+	check, err := r.open(cfg.Check)
+	if err != nil || string(check) != checkPlaintext {
+		return ErrWrongPassphrase
+	}
+
+	return nil
+}
+
+func (r *Repository) newConfig(passphrase string) error {
+	if passphrase == "" {
+		return nil
+	}
+
+	snapshots, err := r.Snapshots("")
+	if err != nil {
+		return err
+	}
+	if len(snapshots) > 0 {
+		return errors.New("repository already contains unencrypted snapshots")
+	}
+
+	cfg := &config{
+		Version: 1,
+		Cipher:  "xchacha20-poly1305",
+		KDF:     "scrypt",
+		N:       scryptN,
+		R:       scryptR,
+		P:       scryptP,
+		Salt:    make([]byte, 32),
+	}
+	if _, err = rand.Read(cfg.Salt); err != nil {
+		return err
+	}
+	if r.keys, err = deriveKeys(passphrase, cfg); err != nil {
+		return err
+	}
+	if cfg.Check, err = r.seal([]byte(checkPlaintext)); err != nil {
+		return err
+	}
+	r.pending = cfg
+
+	return nil
+}
+
+// writeConfig 写入待创建的仓库配置，必须先于任何加密数据写入
+func (r *Repository) writeConfig() error {
+	if r.pending == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(r.pending)
+	if err != nil {
+		return err
+	}
+	if err = r.store.Put(r.configPath(), bytes.NewReader(data)); err != nil {
+		return err
+	}
+	r.pending = nil
+
+	return nil
+}
+
+// chunkID 数据块名，明文仓库为内容的 SHA-256，加密仓库为 HMAC-SHA256
+func (r *Repository) chunkID(data []byte) string {
+	if r.keys == nil {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+	mac := hmac.New(sha256.New, r.keys.id)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// seal 加密数据，明文仓库原样返回，密文格式为 nonce || ciphertext
+func (r *Repository) seal(data []byte) ([]byte, error) {
+	if r.keys == nil {
+		return data, nil
+	}
+	aead, err := chacha20poly1305.NewX(r.keys.enc)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// open 解密 seal 的输出
+func (r *Repository) open(data []byte) ([]byte, error) {
+	if r.keys == nil {
+		return data, nil
+	}
+	aead, err := chacha20poly1305.NewX(r.keys.enc)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+}
+
+func (r *Repository) configPath() string {
+	return path.Join(r.dir, "config")
+}
@@ -0,0 +1,334 @@
+package dedup
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/acepanel/panel/v3/pkg/storage"
+)
+
+type DedupTestSuite struct {
+	suite.Suite
+	root  string // 待备份目录
+	store storage.Storage
+	repo  *Repository
+}
+
+func TestDedupTestSuite(t *testing.T) {
+	suite.Run(t, &DedupTestSuite{})
+}
+
+func (s *DedupTestSuite) SetupTest() {
+	s.root = s.T().TempDir()
+	store, err := storage.NewLocal(s.T().TempDir())
+	s.Require().NoError(err)
+	s.store = store
+	s.repo, err = Open(store, "dedup/website", "")
+	s.Require().NoError(err)
+}
+
+// random 生成可复现的随机数据，随机数据不可压缩且不会意外切出相同的块
+func random(seed uint64, size int) []byte {
+	data := make([]byte, size)
+	rng := rand.New(rand.NewPCG(seed, seed))
+	for i := range data {
+		data[i] = byte(rng.Uint32())
+	}
+	return data
+}
+
+func (s *DedupTestSuite) write(name string, data []byte) {
+	path := filepath.Join(s.root, name)
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0755))
+	s.Require().NoError(os.WriteFile(path, data, 0644))
+}
+
+func (s *DedupTestSuite) chunks(data []byte) []string {
+	chunker := NewChunker(bytes.NewReader(data))
+	var chunks []string
+	for {
+		chunk, err := chunker.Next()
+		if errors.Is(err, io.EOF) {
+			return chunks
+		}
+		s.Require().NoError(err)
+		chunks = append(chunks, string(chunk))
+	}
+}
+
+func (s *DedupTestSuite) TestChunkerBounds() {
+	data := random(1, 20<<20)
+	chunks := s.chunks(data)
+
+	var total int
+	for i, chunk := range chunks {
+		total += len(chunk)
+		s.LessOrEqual(len(chunk), MaxChunkSize)
+		if i < len(chunks)-1 {
+			s.GreaterOrEqual(len(chunk), MinChunkSize)
+		}
+	}
+	s.Equal(len(data), total)
+	s.Greater(len(chunks), 5)
+}
+
+func (s *DedupTestSuite) TestChunkerResynchronizes() {
+	data := random(2, 16<<20)
+	inserted := append(append(append([]byte{}, data[:100]...), []byte("inserted bytes")...), data[100:]...)
+
+	before := s.chunks(data)
+	after := s.chunks(inserted)
+
+	// 前部插入数据后，除受影响的首个块外其余块应保持不变
+	shared := 0
+	set := make(map[string]struct{}, len(before))
+	for _, chunk := range before {
+		set[chunk] = struct{}{}
+	}
+	for _, chunk := range after {
+		if _, ok := set[chunk]; ok {
+			shared++
+		}
+	}
+	s.GreaterOrEqual(shared, len(before)-2)
+}
+
+func (s *DedupTestSuite) TestBackupRestore() {
+	big := random(3, 6<<20)
+	s.write("index.php", []byte("<?php echo 'hello';"))
+	s.write("uploads/big.bin", big)
+	s.write("empty/.keep", nil)
+	s.Require().NoError(os.Symlink("index.php", filepath.Join(s.root, "link.php")))
+	mtime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.Local)
+	s.Require().NoError(os.Chtimes(filepath.Join(s.root, "index.php"), mtime, mtime))
+
+	snapshot, stats, err := s.repo.Backup("website", "site", "site_20260101000000", s.root)
+	s.Require().NoError(err)
+	s.Equal(3, snapshot.Files)
+	s.Equal(stats.Chunks, stats.NewChunks)
+	s.Equal(int64(len(big)+19), snapshot.Size)
+
+	dest := s.T().TempDir()
+	s.Require().NoError(s.repo.Restore("site_20260101000000.snap", dest))
+
+	content, err := os.ReadFile(filepath.Join(dest, "uploads/big.bin"))
+	s.Require().NoError(err)
+	s.Equal(big, content)
+	link, err := os.Readlink(filepath.Join(dest, "link.php"))
+	s.Require().NoError(err)
+	s.Equal("index.php", link)
+	info, err := os.Stat(filepath.Join(dest, "index.php"))
+	s.Require().NoError(err)
+	s.True(info.ModTime().Equal(mtime))
+	s.FileExists(filepath.Join(dest, "empty/.keep"))
+}
+
+func (s *DedupTestSuite) TestIncrementalUploadsOnlyChanges() {
+	s.write("a.bin", random(4, 8<<20))
+	s.write("b.bin", random(5, 8<<20))
+	_, first, err := s.repo.Backup("website", "site", "site_20260101000000", s.root)
+	s.Require().NoError(err)
+
+	// 未变化时不上传任何块
+	_, second, err := s.repo.Backup("website", "site", "site_20260102000000", s.root)
+	s.Require().NoError(err)
+	s.Zero(second.NewChunks)
+	s.Equal(first.Chunks, second.Chunks)
+
+	// 修改一个文件只上传受影响的少数块
+	s.write("c.txt", []byte("new file"))
+	_, third, err := s.repo.Backup("website", "site", "site_20260103000000", s.root)
+	s.Require().NoError(err)
+	s.Positive(third.NewChunks)
+	s.Less(third.NewChunks, first.NewChunks/2)
+}
+
+func (s *DedupTestSuite) TestPruneCollectsGarbage() {
+	s.write("a.bin", random(6, 4<<20))
+	_, _, err := s.repo.Backup("website", "site", "site_20260101000000", s.root)
+	s.Require().NoError(err)
+	time.Sleep(10 * time.Millisecond)
+
+	s.write("a.bin", random(7, 4<<20))
+	_, _, err = s.repo.Backup("website", "site", "site_20260102000000", s.root)
+	s.Require().NoError(err)
+	time.Sleep(10 * time.Millisecond)
+
+	// 前缀相同的其他目标不受影响
+	_, _, err = s.repo.Backup("website", "site_blog", "site_blog_20260101000000", s.root)
+	s.Require().NoError(err)
+
+	before, err := s.repo.chunkSet()
+	s.Require().NoError(err)
+
+	removed, err := s.repo.Prune("site", 1)
+	s.Require().NoError(err)
+	s.Equal([]string{"site_20260101000000"}, removed)
+
+	after, err := s.repo.chunkSet()
+	s.Require().NoError(err)
+	s.Less(len(after), len(before))
+
+	snapshots, err := s.repo.Snapshots("")
+	s.Require().NoError(err)
+	s.Len(snapshots, 2)
+
+	// 剩余快照仍可完整恢复
+	s.Require().NoError(s.repo.Restore("site_20260102000000", s.T().TempDir()))
+	s.Require().NoError(s.repo.Restore("site_blog_20260101000000", s.T().TempDir()))
+}
+
+func (s *DedupTestSuite) TestGCSkippedWhileLocked() {
+	s.write("a.bin", random(8, 1<<20))
+	_, _, err := s.repo.Backup("website", "site", "site_20260101000000", s.root)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Delete("site_20260101000000"))
+
+	unlock, err := s.repo.lock(false)
+	s.Require().NoError(err)
+	removed, err := s.repo.GC()
+	s.Require().NoError(err)
+	s.Zero(removed)
+
+	unlock()
+	removed, err = s.repo.GC()
+	s.Require().NoError(err)
+	s.Positive(removed)
+}
+
+func (s *DedupTestSuite) TestBackupWaitsForGC() {
+	retry := lockRetry
+	lockRetry = 10 * time.Millisecond
+	defer func() { lockRetry = retry }()
+
+	unlock, err := s.repo.lock(true)
+	s.Require().NoError(err)
+	_, err = s.repo.lock(true)
+	s.ErrorIs(err, ErrLocked)
+
+	s.write("a.bin", random(8, 1<<20))
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := s.repo.Backup("website", "site", "site_20260101000000", s.root)
+		done <- err
+	}()
+
+	// 清理持有排他锁期间备份不能开始上传
+	select {
+	case err = <-done:
+		s.Failf("backup finished while gc holds the lock", "%v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	chunks, err := s.repo.chunkSet()
+	s.Require().NoError(err)
+	s.Empty(chunks)
+
+	unlock()
+	s.Require().NoError(<-done)
+	s.Require().NoError(s.repo.Restore("site_20260101000000", s.T().TempDir()))
+}
+
+func (s *DedupTestSuite) TestRestoreDetectsCorruption() {
+	s.write("a.bin", random(9, 1<<20))
+	snapshot, _, err := s.repo.Backup("website", "site", "site_20260101000000", s.root)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.store.Put("dedup/website/chunks/"+snapshot.Chunks[0], bytes.NewReader([]byte("garbage"))))
+	s.ErrorContains(s.repo.Restore("site_20260101000000", s.T().TempDir()), "corrupted")
+}
+
+func (s *DedupTestSuite) TestVerify() {
+	s.write("a.bin", random(11, 1<<20))
+	snapshot, _, err := s.repo.Backup("website", "site", "site_20260101000000", s.root)
+	s.Require().NoError(err)
+
+	verified, err := s.repo.Verify("site_20260101000000.snap")
+	s.Require().NoError(err)
+	s.Equal(snapshot.Chunks, verified.Chunks)
+
+	s.Require().NoError(s.store.Put("dedup/website/chunks/"+snapshot.Chunks[0], bytes.NewReader([]byte("garbage"))))
+	_, err = s.repo.Verify("site_20260101000000")
+	s.ErrorContains(err, "corrupted")
+
+	s.Require().NoError(s.store.Delete("dedup/website/chunks/" + snapshot.Chunks[0]))
+	_, err = s.repo.Verify("site_20260101000000")
+	s.ErrorContains(err, "missing")
+}
+
+func (s *DedupTestSuite) TestEncryptedRepository() {
+	data := random(10, 2<<20)
+	s.write("secret.bin", data)
+
+	repo, err := Open(s.store, "dedup/path", "correct horse")
+	s.Require().NoError(err)
+	snapshot, _, err := repo.Backup("path", "/data", "data_20260101000000", s.root)
+	s.Require().NoError(err)
+	s.True(repo.Encrypted())
+
+	// 块名不是明文哈希，块内容无法解压
+	plain := s.chunks(data)
+	s.NotEqual(s.repo.chunkID([]byte(plain[0])), snapshot.Chunks[0])
+	reader, err := s.store.Get("dedup/path/snapshots/data_20260101000000.snap")
+	s.Require().NoError(err)
+	manifest, err := io.ReadAll(reader)
+	s.Require().NoError(reader.Close())
+	s.Require().NoError(err)
+	s.NotContains(string(manifest), "data_20260101000000")
+
+	// 重新打开后可恢复
+	reopened, err := Open(s.store, "dedup/path", "correct horse")
+	s.Require().NoError(err)
+	dest := s.T().TempDir()
+	s.Require().NoError(reopened.Restore("data_20260101000000", dest))
+	content, err := os.ReadFile(filepath.Join(dest, "secret.bin"))
+	s.Require().NoError(err)
+	s.Equal(data, content)
+
+	_, err = Open(s.store, "dedup/path", "wrong")
+	s.ErrorIs(err, ErrWrongPassphrase)
+	_, err = Open(s.store, "dedup/path", "")
+	s.ErrorContains(err, "passphrase required")
+}
+
+func (s *DedupTestSuite) TestEncryptionRejectsPlainRepository() {
+	s.write("a.bin", random(11, 1<<20))
+	_, _, err := s.repo.Backup("website", "site", "site_20260101000000", s.root)
+	s.Require().NoError(err)
+
+	_, err = Open(s.store, "dedup/website", "passphrase")
+	s.ErrorContains(err, "unencrypted snapshots")
+}
+
+func (s *DedupTestSuite) TestExtractRejectsEscapes() {
+	cases := map[string][]*tar.Header{
+		"parent path": {
+			{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+		"through symlink": {
+			{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/tmp", Mode: 0777},
+			{Name: "link/evil", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+	}
+
+	for name, headers := range cases {
+		s.Run(name, func() {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			for _, hdr := range headers {
+				s.Require().NoError(tw.WriteHeader(hdr))
+			}
+			s.Require().NoError(tw.Close())
+
+			s.ErrorContains(extractTree(&buf, s.T().TempDir()), "invalid path")
+		})
+	}
+}
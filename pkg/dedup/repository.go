@@ -0,0 +1,556 @@
+// Package dedup 实现基于内容分块的去重备份仓库
+//
+// 目录被打包为确定性的 tar 流后按内容切块，每个块以 SHA-256 命名、zstd 压缩后保存，
+// 快照清单只记录组成 tar 流的块序列。未变化的数据切出的块与上次相同，无需重复上传。
+// 仓库只依赖 storage.Storage 的基础操作，可存放在本地、S3、SFTP、WebDAV 等任意后端：
+//
+//	<dir>/chunks/<sha256>       数据块
+//	<dir>/snapshots/<name>.snap 快照清单（JSON）
+//	<dir>/locks/<kind>-<id>     仓库锁，备份持有共享锁，清理持有排他锁
+//	<dir>/config                加密仓库的配置，明文仓库没有
+//
+// 以口令打开的仓库对数据块与清单做 XChaCha20-Poly1305 加密，块名改为带密钥的 HMAC，
+// 存储端无法读取内容，也无法通过块名确认某段明文是否存在。
+package dedup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/libtnb/utils/str"
+
+	"github.com/acepanel/panel/v3/pkg/storage"
+)
+
+// SnapshotExt 快照清单扩展名
+const SnapshotExt = ".snap"
+
+// snapshotVersion 快照清单格式版本
+const snapshotVersion = 1
+
+// lockTTL 锁的有效期，备份期间定时刷新，超时未刷新的锁视为进程已异常退出
+const lockTTL = 6 * time.Hour
+
+// lockRefresh 锁刷新间隔
+const lockRefresh = 10 * time.Minute
+
+// lockWait 备份等待清理释放排他锁的最长时间
+const lockWait = 30 * time.Minute
+
+// lockRetry 备份等待排他锁释放时的重试间隔
+var lockRetry = 5 * time.Second
+
+// 锁文件名前缀，没有前缀的旧版锁按共享锁处理
+const (
+	lockShared    = "shared-"
+	lockExclusive = "exclusive-"
+)
+
+// ErrLocked 仓库被其他操作锁定
+var ErrLocked = errors.New("repository is locked by another operation")
+
+// timestampPattern 快照名称结尾的时间戳，与归档备份的命名一致
+var timestampPattern = regexp.MustCompile(`_\d{14}$`)
+
+// Snapshot 快照清单
+type Snapshot struct {
+	Version int       `json:"version"`
+	Name    string    `json:"name"`
+	Kind    string    `json:"kind"`   // 备份类型，如 website、path
+	Target  string    `json:"target"` // 备份对象，如网站名或目录
+	Time    time.Time `json:"time"`
+	Files   int       `json:"files"`  // 普通文件数
+	Size    int64     `json:"size"`   // 普通文件总大小
+	Chunks  []string  `json:"chunks"` // 按顺序组成 tar 流的数据块
+}
+
+// SnapshotInfo 快照列表项，只含存储元数据，不读取清单内容
+type SnapshotInfo struct {
+	Name string
+	Time time.Time
+}
+
+// Stats 一次备份的上传统计
+type Stats struct {
+	Chunks    int   // 快照引用的块数
+	NewChunks int   // 新上传的块数
+	NewSize   int64 // 新上传的压缩后大小
+}
+
+// Repository 去重备份仓库
+type Repository struct {
+	store   storage.Storage
+	dir     string
+	keys    *keys   // 加密仓库的密钥，明文仓库为 nil
+	pending *config // 尚未写入的新仓库配置
+}
+
+// Open 打开 store 中 dir 目录下的仓库，目录不存在时在首次备份时创建
+// passphrase 非空时以加密方式打开，口令错误返回 ErrWrongPassphrase
+func Open(store storage.Storage, dir, passphrase string) (*Repository, error) {
+	r := &Repository{store: store, dir: strings.Trim(dir, "/")}
+	if err := r.openConfig(passphrase); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Encrypted 仓库是否加密
+func (r *Repository) Encrypted() bool {
+	return r.keys != nil
+}
+
+// Backup 把 root 目录备份为名为 name 的快照，仅上传仓库中不存在的块
+// 清单在全部块上传后最后写入，中途失败不会留下不完整的快照
+func (r *Repository) Backup(kind, target, name, root string) (*Snapshot, *Stats, error) {
+	// 持有共享锁期间清理不会运行，多个备份可以同时进行
+	unlock, err := r.lock(false)
+	for deadline := time.Now().Add(lockWait); errors.Is(err, ErrLocked) && time.Now().Before(deadline); {
+		time.Sleep(lockRetry)
+		unlock, err = r.lock(false)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	if err = r.writeConfig(); err != nil {
+		return nil, nil, err
+	}
+	known, err := r.chunkSet()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func(encoder *zstd.Encoder) { _ = encoder.Close() }(encoder)
+
+	pr, pw := io.Pipe()
+	var tree *TreeStats
+	var treeErr error
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		tree, treeErr = writeTree(pw, root)
+		_ = pw.CloseWithError(treeErr)
+	})
+
+	snapshot := &Snapshot{
+		Version: snapshotVersion,
+		Name:    name,
+		Kind:    kind,
+		Target:  target,
+		Time:    time.Now(),
+		Chunks:  make([]string, 0),
+	}
+	stats := new(Stats)
+
+	chunker := NewChunker(pr)
+	for {
+		data, err := chunker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = pr.CloseWithError(err)
+			wg.Wait()
+			return nil, nil, err
+		}
+
+		id := r.chunkID(data)
+		snapshot.Chunks = append(snapshot.Chunks, id)
+		if _, ok := known[id]; ok {
+			continue
+		}
+
+		compressed, err := r.seal(encoder.EncodeAll(data, nil))
+		if err != nil {
+			_ = pr.CloseWithError(err)
+			wg.Wait()
+			return nil, nil, err
+		}
+		if err = r.store.Put(r.chunkPath(id), bytes.NewReader(compressed)); err != nil {
+			_ = pr.CloseWithError(err)
+			wg.Wait()
+			return nil, nil, fmt.Errorf("upload chunk %s: %w", id, err)
+		}
+		known[id] = struct{}{}
+		stats.NewChunks++
+		stats.NewSize += int64(len(compressed))
+	}
+	wg.Wait()
+	if treeErr != nil {
+		return nil, nil, treeErr
+	}
+
+	snapshot.Files = tree.Files
+	snapshot.Size = tree.Size
+	stats.Chunks = len(snapshot.Chunks)
+
+	manifest, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, nil, err
+	}
+	if manifest, err = r.seal(manifest); err != nil {
+		return nil, nil, err
+	}
+	if err = r.store.Put(r.snapshotPath(name), bytes.NewReader(manifest)); err != nil {
+		return nil, nil, err
+	}
+
+	return snapshot, stats, nil
+}
+
+// Restore 把快照还原到 dest 目录，逐块校验哈希
+func (r *Repository) Restore(name, dest string) error {
+	snapshot, err := r.Snapshot(name)
+	if err != nil {
+		return err
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+
+	pr, pw := io.Pipe()
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for _, id := range snapshot.Chunks {
+			data, err := r.readChunk(decoder, id)
+			if err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+			if _, err = pw.Write(data); err != nil {
+				return
+			}
+		}
+		_ = pw.Close()
+	})
+
+	err = extractTree(pr, dest)
+	_ = pr.CloseWithError(errors.New("restore aborted"))
+	wg.Wait()
+
+	return err
+}
+
+// Verify 读取快照引用的全部数据块并逐块校验哈希，不写出任何文件
+// 块名即块内容的哈希，快照清单本身就是校验清单
+func (r *Repository) Verify(name string) (*Snapshot, error) {
+	snapshot, err := r.Snapshot(name)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer decoder.Close()
+
+	checked := make(map[string]struct{}, len(snapshot.Chunks))
+	for _, id := range snapshot.Chunks {
+		if _, ok := checked[id]; ok {
+			continue
+		}
+		if _, err = r.readChunk(decoder, id); err != nil {
+			return nil, err
+		}
+		checked[id] = struct{}{}
+	}
+
+	return snapshot, nil
+}
+
+// Snapshot 读取快照清单
+func (r *Repository) Snapshot(name string) (*Snapshot, error) {
+	name = strings.TrimSuffix(name, SnapshotExt)
+	if !validName(name) {
+		return nil, fmt.Errorf("invalid snapshot name: %s", name)
+	}
+
+	reader, err := r.store.Get(r.snapshotPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %s not found: %w", name, err)
+	}
+	defer func(reader io.ReadCloser) { _ = reader.Close() }(reader)
+
+	manifest, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if manifest, err = r.open(manifest); err != nil {
+		return nil, fmt.Errorf("snapshot %s corrupted: %w", name, err)
+	}
+	snapshot := new(Snapshot)
+	if err = json.Unmarshal(manifest, snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", name, err)
+	}
+	if snapshot.Version > snapshotVersion {
+		return nil, fmt.Errorf("snapshot %s requires a newer version (format %d)", name, snapshot.Version)
+	}
+
+	return snapshot, nil
+}
+
+// Snapshots 列出名称以 prefix 开头的快照，按时间从新到旧排序
+func (r *Repository) Snapshots(prefix string) ([]SnapshotInfo, error) {
+	dir := path.Join(r.dir, "snapshots")
+	files, err := r.store.List(dir)
+	if err != nil {
+		// 仓库尚未创建；S3 列举不存在的前缀不会报错，能走到这里的都是目录型存储
+		if !r.store.Exists(dir) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	snapshots := make([]SnapshotInfo, 0, len(files))
+	for _, file := range files {
+		name, ok := strings.CutSuffix(file, SnapshotExt)
+		if !ok || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		modified, err := r.store.LastModified(path.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, SnapshotInfo{Name: name, Time: modified})
+	}
+
+	slices.SortFunc(snapshots, func(a, b SnapshotInfo) int {
+		return b.Time.Compare(a.Time)
+	})
+
+	return snapshots, nil
+}
+
+// Delete 删除快照清单，数据块需调用 GC 回收
+func (r *Repository) Delete(name string) error {
+	name = strings.TrimSuffix(name, SnapshotExt)
+	if !validName(name) {
+		return fmt.Errorf("invalid snapshot name: %s", name)
+	}
+
+	return r.store.Delete(r.snapshotPath(name))
+}
+
+// Prune 保留目标 target 最新的 keep 个快照，删除其余快照并回收不再引用的数据块
+// target 为快照名中时间戳之前的部分，返回被删除的快照名
+func (r *Repository) Prune(target string, keep uint) ([]string, error) {
+	snapshots, err := r.Snapshots(target + "_")
+	if err != nil {
+		return nil, err
+	}
+	// 精确匹配 <target>_<时间戳>，避免 site 误删 site_blog 的快照
+	snapshots = slices.DeleteFunc(snapshots, func(info SnapshotInfo) bool {
+		return strings.TrimSuffix(info.Name, timestampPattern.FindString(info.Name)) != target
+	})
+	if uint(len(snapshots)) <= keep {
+		return nil, nil
+	}
+
+	removed := make([]string, 0, len(snapshots)-int(keep))
+	for _, info := range snapshots[keep:] {
+		if err = r.Delete(info.Name); err != nil {
+			return removed, err
+		}
+		removed = append(removed, info.Name)
+	}
+
+	_, err = r.GC()
+	return removed, err
+}
+
+// GC 删除没有被任何快照引用的数据块，返回删除的块数
+// 标记与回收全程持有排他锁，有备份进行中时跳过，避免删掉其刚上传、清单尚未写入的块
+func (r *Repository) GC() (int, error) {
+	unlock, err := r.lock(true)
+	if errors.Is(err, ErrLocked) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	snapshots, err := r.Snapshots("")
+	if err != nil {
+		return 0, err
+	}
+	referenced := make(map[string]struct{})
+	for _, info := range snapshots {
+		// 任一清单读取失败都必须中止，否则其引用的块会被误删
+		snapshot, err := r.Snapshot(info.Name)
+		if err != nil {
+			return 0, err
+		}
+		for _, id := range snapshot.Chunks {
+			referenced[id] = struct{}{}
+		}
+	}
+
+	chunks, err := r.chunkSet()
+	if err != nil {
+		return 0, err
+	}
+	unused := make([]string, 0)
+	for id := range chunks {
+		if _, ok := referenced[id]; !ok {
+			unused = append(unused, r.chunkPath(id))
+		}
+	}
+	if len(unused) == 0 {
+		return 0, nil
+	}
+
+	return len(unused), r.store.Delete(unused...)
+}
+
+// readChunk 读取、解压并校验数据块
+func (r *Repository) readChunk(decoder *zstd.Decoder, id string) ([]byte, error) {
+	reader, err := r.store.Get(r.chunkPath(id))
+	if err != nil {
+		return nil, fmt.Errorf("chunk %s missing: %w", id, err)
+	}
+	compressed, err := io.ReadAll(reader)
+	_ = reader.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if compressed, err = r.open(compressed); err != nil {
+		return nil, fmt.Errorf("chunk %s corrupted: %w", id, err)
+	}
+	data, err := decoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("chunk %s corrupted: %w", id, err)
+	}
+	if r.chunkID(data) != id {
+		return nil, fmt.Errorf("chunk %s corrupted: checksum mismatch", id)
+	}
+
+	return data, nil
+}
+
+// chunkSet 列出仓库中已有的数据块
+func (r *Repository) chunkSet() (map[string]struct{}, error) {
+	dir := path.Join(r.dir, "chunks")
+	files, err := r.store.List(dir)
+	if err != nil {
+		if !r.store.Exists(dir) {
+			return make(map[string]struct{}), nil
+		}
+		return nil, err
+	}
+
+	chunks := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		if len(file) == sha256.Size*2 {
+			chunks[file] = struct{}{}
+		}
+	}
+
+	return chunks, nil
+}
+
+// lock 获取仓库锁并定时刷新，返回释放函数，与已有的锁冲突时返回 ErrLocked
+// 存储后端没有原子的独占创建，因此先写入自己的锁再检查冲突，
+// 同时加锁的双方至少有一方能看到对方并退出，不会同时持有冲突的锁
+func (r *Repository) lock(exclusive bool) (func(), error) {
+	name := lockShared + str.Random(16)
+	if exclusive {
+		name = lockExclusive + str.Random(16)
+	}
+	file := path.Join(r.dir, "locks", name)
+	refresh := func() error {
+		return r.store.Put(file, strings.NewReader(time.Now().Format(time.RFC3339)))
+	}
+	if err := refresh(); err != nil {
+		return nil, err
+	}
+	conflict, err := r.conflicting(name, exclusive)
+	if err != nil || conflict {
+		_ = r.store.Delete(file)
+		if err == nil {
+			err = ErrLocked
+		}
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		ticker := time.NewTicker(lockRefresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				_ = refresh()
+			}
+		}
+	})
+
+	return func() {
+		close(done)
+		wg.Wait()
+		_ = r.store.Delete(file)
+	}, nil
+}
+
+// conflicting 除 own 外是否有与之冲突的未过期锁，排他锁与任何锁冲突
+func (r *Repository) conflicting(own string, exclusive bool) (bool, error) {
+	dir := path.Join(r.dir, "locks")
+	files, err := r.store.List(dir)
+	if err != nil {
+		return false, err
+	}
+
+	for _, file := range files {
+		if file == own || (!exclusive && !strings.HasPrefix(file, lockExclusive)) {
+			continue
+		}
+		modified, err := r.store.LastModified(path.Join(dir, file))
+		if err != nil {
+			// 读取失败按锁有效处理，宁可少回收
+			return true, nil
+		}
+		if time.Since(modified) < lockTTL {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *Repository) chunkPath(id string) string {
+	return path.Join(r.dir, "chunks", id)
+}
+
+func (r *Repository) snapshotPath(name string) string {
+	return path.Join(r.dir, "snapshots", name+SnapshotExt)
+}
+
+// validName 快照名不能包含路径分隔符
+func validName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, `/\`) && name != "." && name != ".."
+}
@@ -0,0 +1,231 @@
+package dedup
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TreeStats 目录打包统计
+type TreeStats struct {
+	Files int   // 普通文件数
+	Size  int64 // 普通文件总大小
+}
+
+// writeTree 把目录以确定的顺序写成 tar 流
+// 同样的目录内容总是产生同样的字节，未变化的文件才能切出与上次相同的块
+func writeTree(w io.Writer, root string) (*TreeStats, error) {
+	stats := new(TreeStats)
+	tw := tar.NewWriter(w)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			// 遍历期间被删除的文件直接跳过
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		var link string
+		switch {
+		case info.Mode().IsRegular(), info.IsDir():
+		case info.Mode()&fs.ModeSymlink != 0:
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		default:
+			// 设备、管道、套接字等不备份
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		// 访问时间每次读取都会变化，只保留到秒的修改时间，保证未变化文件的头部字节稳定
+		hdr.ModTime = hdr.ModTime.Truncate(time.Second)
+		hdr.AccessTime = time.Time{}
+		hdr.ChangeTime = time.Time{}
+		hdr.Format = tar.FormatPAX
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		stats.Files++
+		stats.Size += hdr.Size
+
+		return copyFile(tw, path, hdr.Size)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, tw.Close()
+}
+
+// copyFile 按打包时的大小写入文件内容，备份期间被截断的文件以零补齐，保证 tar 流完整
+func copyFile(w io.Writer, path string, size int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func(f *os.File) { _ = f.Close() }(f)
+
+	n, err := io.CopyN(w, f, size)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if n < size {
+		_, err = io.CopyN(w, zeroReader{}, size-n)
+	}
+
+	return err
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// extractTree 把 tar 流还原到 dest，拒绝越出 dest 的路径
+func extractTree(r io.Reader, dest string) error {
+	dest = filepath.Clean(dest)
+	tr := tar.NewReader(r)
+
+	type dirTime struct {
+		path string
+		time time.Time
+	}
+	var dirs []dirTime
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSuffix(hdr.Name, "/")
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("invalid path in snapshot: %s", hdr.Name)
+		}
+		target := filepath.Join(dest, name)
+		// 经由符号链接写入会越出 dest，已存在的同名链接先删除
+		if err = checkParent(dest, target); err != nil {
+			return err
+		}
+		if info, err := os.Lstat(target); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+			if err = os.Remove(target); err != nil {
+				return err
+			}
+		}
+		mode := hdr.FileInfo().Mode()
+		mode = mode.Perm() | mode&(fs.ModeSetuid|fs.ModeSetgid|fs.ModeSticky)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			if err = os.Chmod(target, mode); err != nil {
+				return err
+			}
+			dirs = append(dirs, dirTime{path: target, time: hdr.ModTime})
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err = writeFile(target, tr, mode); err != nil {
+				return err
+			}
+			_ = os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+		case tar.TypeSymlink:
+			if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err = os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+
+		// 非 root 运行时无法还原属主，忽略错误
+		_ = os.Lchown(target, hdr.Uid, hdr.Gid)
+	}
+
+	// 目录时间最后设置，避免写入子项时被刷新
+	for _, dir := range dirs {
+		_ = os.Chtimes(dir.path, dir.time, dir.time)
+	}
+
+	return nil
+}
+
+// checkParent 确认 dest 与 target 之间的各级目录都不是符号链接
+func checkParent(dest, target string) error {
+	parent := filepath.Dir(target)
+	for parent != dest {
+		info, err := os.Lstat(parent)
+		if errors.Is(err, fs.ErrNotExist) {
+			parent = filepath.Dir(parent)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("invalid path in snapshot: %s is a symlink", parent)
+		}
+		parent = filepath.Dir(parent)
+	}
+
+	return nil
+}
+
+func writeFile(path string, r io.Reader, mode fs.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	// OpenFile 的权限受 umask 影响
+	return os.Chmod(path, mode)
+}
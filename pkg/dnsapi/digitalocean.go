@@ -0,0 +1,82 @@
+package dnsapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/libdns/libdns"
+)
+
+const digitalOceanEndpoint = "https://api.digitalocean.com/v2"
+
+// DigitalOcean 通过 DigitalOcean Domains API 修改记录
+type DigitalOcean struct {
+	APIToken string
+}
+
+type digitalOceanRecord struct {
+	ID   int    `json:"id,omitempty"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Data string `json:"data"`
+	TTL  int    `json:"ttl,omitempty"`
+}
+
+func (p *DigitalOcean) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rr := range rrs {
+		record := digitalOceanRecord{
+			Type: "TXT",
+			Name: relativeName(rr.Name, zone),
+			Data: rr.Data,
+			TTL:  max(int(rr.TTL/time.Second), 30),
+		}
+		if err = doJSON(ctx, http.MethodPost, p.recordsURL(zone), p.header(), record, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return toRecords(rrs), nil
+}
+
+func (p *DigitalOcean) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rr := range rrs {
+		var list struct {
+			Records []digitalOceanRecord `json:"domain_records"`
+		}
+		query := url.Values{"type": {"TXT"}, "name": {fqdn(rr.Name, zone)}, "per_page": {"200"}}
+		if err = doJSON(ctx, http.MethodGet, p.recordsURL(zone)+"?"+query.Encode(), p.header(), nil, &list); err != nil {
+			return nil, err
+		}
+		for _, item := range list.Records {
+			if item.Data != rr.Data {
+				continue
+			}
+			if err = doJSON(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", p.recordsURL(zone), item.ID), p.header(), nil, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return toRecords(rrs), nil
+}
+
+func (p *DigitalOcean) recordsURL(zone string) string {
+	return digitalOceanEndpoint + "/domains/" + url.PathEscape(trimZone(zone)) + "/records"
+}
+
+func (p *DigitalOcean) header() http.Header {
+	return http.Header{"Authorization": {"Bearer " + p.APIToken}}
+}
@@ -0,0 +1,108 @@
+// Package dnsapi 实现 libdns 兼容的 DNS 服务商客户端，仅支持 ACME DNS-01 所需的 TXT 记录增删
+package dnsapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/libdns/libdns"
+)
+
+// defaultTTL TXT 记录的默认 TTL，服务商有下限时各自调整
+const defaultTTL = 120 * time.Second
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// txtRecords 从记录列表中取出 TXT 记录
+func txtRecords(recs []libdns.Record) ([]libdns.RR, error) {
+	result := make([]libdns.RR, 0, len(recs))
+	for _, rec := range recs {
+		rr := rec.RR()
+		if rr.Type != "TXT" {
+			return nil, fmt.Errorf("unsupported record type %q, only TXT is supported", rr.Type)
+		}
+		if rr.TTL <= 0 {
+			rr.TTL = defaultTTL
+		}
+		result = append(result, rr)
+	}
+
+	return result, nil
+}
+
+// toRecords 将 RR 列表转为 libdns.Record 列表
+func toRecords(rrs []libdns.RR) []libdns.Record {
+	result := make([]libdns.Record, 0, len(rrs))
+	for _, rr := range rrs {
+		result = append(result, rr)
+	}
+
+	return result
+}
+
+// fqdn 返回记录的完整域名，不带末尾的点
+func fqdn(name, zone string) string {
+	return strings.TrimSuffix(libdns.AbsoluteName(name, zone), ".")
+}
+
+// relativeName 返回记录相对 zone 的名称，zone 顶点为 @
+func relativeName(name, zone string) string {
+	return libdns.RelativeName(libdns.AbsoluteName(name, dnsFQDN(zone)), dnsFQDN(zone))
+}
+
+// trimZone 去掉 zone 末尾的点
+func trimZone(zone string) string {
+	return strings.TrimSuffix(zone, ".")
+}
+
+// doJSON 发送 JSON 请求，状态码非 2xx 时返回错误，out 不为空时解析响应体
+func doJSON(ctx context.Context, method, url string, header http.Header, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "AcePanel")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) { _ = Body.Close() }(resp.Body)
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: unexpected status %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	if out != nil && len(raw) > 0 {
+		if err = json.Unmarshal(raw, out); err != nil {
+			return fmt.Errorf("%s %s: failed to decode response: %w", method, url, err)
+		}
+	}
+
+	return nil
+}
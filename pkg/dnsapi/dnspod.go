@@ -0,0 +1,128 @@
+package dnsapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libdns/libdns"
+)
+
+const dnspodEndpoint = "https://dnsapi.cn"
+
+// DNSPod 通过 DNSPod Token（ID,Token）API 修改记录
+type DNSPod struct {
+	ID    string
+	Token string
+}
+
+type dnspodStatus struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (p *DNSPod) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rr := range rrs {
+		params := url.Values{
+			"domain":      {trimZone(zone)},
+			"sub_domain":  {relativeName(rr.Name, zone)},
+			"record_type": {"TXT"},
+			"record_line": {"默认"},
+			"value":       {rr.Data},
+			"ttl":         {strconv.Itoa(max(int(rr.TTL/time.Second), 600))},
+		}
+		if err = p.do(ctx, "Record.Create", params, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return toRecords(rrs), nil
+}
+
+func (p *DNSPod) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rr := range rrs {
+		var list struct {
+			Records []struct {
+				ID    string `json:"id"`
+				Value string `json:"value"`
+			} `json:"records"`
+		}
+		params := url.Values{
+			"domain":      {trimZone(zone)},
+			"sub_domain":  {relativeName(rr.Name, zone)},
+			"record_type": {"TXT"},
+		}
+		if err = p.do(ctx, "Record.List", params, &list); err != nil {
+			return nil, err
+		}
+		for _, item := range list.Records {
+			if item.Value != rr.Data {
+				continue
+			}
+			if err = p.do(ctx, "Record.Remove", url.Values{"domain": {trimZone(zone)}, "record_id": {item.ID}}, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return toRecords(rrs), nil
+}
+
+// do 调用 DNSPod API，状态码非 1 时返回错误
+func (p *DNSPod) do(ctx context.Context, action string, params url.Values, out any) error {
+	params.Set("login_token", p.ID+","+p.Token)
+	params.Set("format", "json")
+	params.Set("lang", "en")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dnspodEndpoint+"/"+action, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "AcePanel")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) { _ = Body.Close() }(resp.Body)
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return err
+	}
+	var status struct {
+		Status dnspodStatus `json:"status"`
+	}
+	if err = json.Unmarshal(raw, &status); err != nil {
+		return fmt.Errorf("dnspod %s: failed to decode response: %w", action, err)
+	}
+	// 查询无记录时返回 10，视为空列表
+	if action == "Record.List" && status.Status.Code == "10" {
+		return nil
+	}
+	if status.Status.Code != "1" {
+		return fmt.Errorf("dnspod %s: %s (%s)", action, status.Status.Message, status.Status.Code)
+	}
+	if out != nil {
+		return json.Unmarshal(raw, out)
+	}
+
+	return nil
+}
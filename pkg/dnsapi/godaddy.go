@@ -0,0 +1,85 @@
+package dnsapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/libdns/libdns"
+)
+
+const godaddyEndpoint = "https://api.godaddy.com/v1"
+
+// GoDaddy 通过 GoDaddy Domains API 修改记录
+type GoDaddy struct {
+	APIKey    string
+	APISecret string
+}
+
+type godaddyRecord struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name,omitempty"`
+	Data string `json:"data"`
+	TTL  int    `json:"ttl"`
+}
+
+func (p *GoDaddy) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]godaddyRecord, 0, len(rrs))
+	for _, rr := range rrs {
+		records = append(records, godaddyRecord{
+			Type: "TXT",
+			Name: relativeName(rr.Name, zone),
+			Data: rr.Data,
+			TTL:  max(int(rr.TTL/time.Second), 600), // GoDaddy 要求 TTL 不小于 600
+		})
+	}
+	if err = doJSON(ctx, http.MethodPatch, godaddyEndpoint+"/domains/"+url.PathEscape(trimZone(zone))+"/records", p.header(), records, nil); err != nil {
+		return nil, err
+	}
+
+	return toRecords(rrs), nil
+}
+
+// DeleteRecords GoDaddy 只能按名称整体替换或删除，需保留同名下的其他 TXT 值
+func (p *GoDaddy) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rr := range rrs {
+		u := godaddyEndpoint + "/domains/" + url.PathEscape(trimZone(zone)) + "/records/TXT/" + url.PathEscape(relativeName(rr.Name, zone))
+		var current []godaddyRecord
+		if err = doJSON(ctx, http.MethodGet, u, p.header(), nil, &current); err != nil {
+			return nil, err
+		}
+		remaining := slices.DeleteFunc(slices.Clone(current), func(r godaddyRecord) bool { return r.Data == rr.Data })
+		if len(remaining) == len(current) {
+			continue
+		}
+		if len(remaining) == 0 {
+			err = doJSON(ctx, http.MethodDelete, u, p.header(), nil, nil)
+		} else {
+			for i := range remaining {
+				remaining[i].Type, remaining[i].Name = "", ""
+			}
+			err = doJSON(ctx, http.MethodPut, u, p.header(), remaining, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return toRecords(rrs), nil
+}
+
+func (p *GoDaddy) header() http.Header {
+	return http.Header{"Authorization": {"sso-key " + p.APIKey + ":" + p.APISecret}}
+}
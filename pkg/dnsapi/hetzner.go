@@ -0,0 +1,109 @@
+package dnsapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/libdns/libdns"
+)
+
+const hetznerEndpoint = "https://dns.hetzner.com/api/v1"
+
+// Hetzner 通过 Hetzner DNS API 修改记录
+type Hetzner struct {
+	APIToken string
+}
+
+type hetznerRecord struct {
+	ID     string `json:"id,omitempty"`
+	ZoneID string `json:"zone_id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	TTL    int    `json:"ttl,omitempty"`
+}
+
+func (p *Hetzner) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+	zoneID, err := p.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rr := range rrs {
+		record := hetznerRecord{
+			ZoneID: zoneID,
+			Type:   "TXT",
+			Name:   relativeName(rr.Name, zone),
+			Value:  rr.Data,
+			TTL:    int(rr.TTL / time.Second),
+		}
+		if err = doJSON(ctx, http.MethodPost, hetznerEndpoint+"/records", p.header(), record, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return toRecords(rrs), nil
+}
+
+func (p *Hetzner) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+	zoneID, err := p.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Records []hetznerRecord `json:"records"`
+	}
+	if err = doJSON(ctx, http.MethodGet, hetznerEndpoint+"/records?zone_id="+url.QueryEscape(zoneID), p.header(), nil, &list); err != nil {
+		return nil, err
+	}
+	for _, rr := range rrs {
+		name := relativeName(rr.Name, zone)
+		for _, item := range list.Records {
+			// 部分记录的值带引号返回
+			if item.Type != "TXT" || item.Name != name || strings.Trim(item.Value, `"`) != rr.Data {
+				continue
+			}
+			if err = doJSON(ctx, http.MethodDelete, hetznerEndpoint+"/records/"+url.PathEscape(item.ID), p.header(), nil, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return toRecords(rrs), nil
+}
+
+func (p *Hetzner) zoneID(ctx context.Context, zone string) (string, error) {
+	var resp struct {
+		Zones []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"zones"`
+	}
+	if err := doJSON(ctx, http.MethodGet, hetznerEndpoint+"/zones?name="+url.QueryEscape(trimZone(zone)), p.header(), nil, &resp); err != nil {
+		return "", err
+	}
+	for _, item := range resp.Zones {
+		if strings.EqualFold(item.Name, trimZone(zone)) {
+			return item.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("hetzner zone %s not found", zone)
+}
+
+func (p *Hetzner) header() http.Header {
+	return http.Header{"Auth-API-Token": {p.APIToken}}
+}
@@ -0,0 +1,109 @@
+package dnsapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libdns/libdns"
+
+	"github.com/acepanel/panel/v3/pkg/shell"
+)
+
+const (
+	HookActionPresent = "present"
+	HookActionCleanup = "cleanup"
+)
+
+// Hook 调用用户提供的 HTTP 地址或 Shell 脚本增删记录，用于对接任意 DNS
+//
+// HTTP 方式以 POST 发送 JSON：{"action","zone","name","fqdn","value","ttl"}，返回 2xx 视为成功；
+// 脚本方式通过环境变量 ACME_ACTION、ACME_ZONE、ACME_NAME、ACME_FQDN、ACME_VALUE、ACME_TTL 传参，退出码为 0 视为成功
+type Hook struct {
+	URL    string
+	Token  string // HTTP 方式的 Bearer Token，可为空
+	Script string
+}
+
+type HookPayload struct {
+	Action string `json:"action"`
+	Zone   string `json:"zone"`
+	Name   string `json:"name"`
+	FQDN   string `json:"fqdn"`
+	Value  string `json:"value"`
+	TTL    int    `json:"ttl"`
+}
+
+func (p *Hook) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	return p.call(ctx, HookActionPresent, zone, recs)
+}
+
+func (p *Hook) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	return p.call(ctx, HookActionCleanup, zone, recs)
+}
+
+func (p *Hook) call(ctx context.Context, action, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rr := range rrs {
+		payload := HookPayload{
+			Action: action,
+			Zone:   trimZone(zone),
+			Name:   relativeName(rr.Name, zone),
+			FQDN:   fqdn(rr.Name, dnsFQDN(zone)),
+			Value:  rr.Data,
+			TTL:    int(rr.TTL / time.Second),
+		}
+		switch {
+		case p.URL != "":
+			header := http.Header{}
+			if p.Token != "" {
+				header.Set("Authorization", "Bearer "+p.Token)
+			}
+			err = doJSON(ctx, http.MethodPost, p.URL, header, payload, nil)
+		case p.Script != "":
+			err = p.run(ctx, payload)
+		default:
+			err = errors.New("hook provider requires a URL or a script")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return toRecords(rrs), nil
+}
+
+// run 执行脚本，参数通过环境变量传入，避免拼接到命令中
+func (p *Hook) run(ctx context.Context, payload HookPayload) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "bash", "-c", p.Script)
+	shell.ApplyEnv(cmd,
+		"ACME_ACTION="+payload.Action,
+		"ACME_ZONE="+payload.Zone,
+		"ACME_NAME="+payload.Name,
+		"ACME_FQDN="+payload.FQDN,
+		"ACME_VALUE="+payload.Value,
+		"ACME_TTL="+strconv.Itoa(payload.TTL),
+	)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("hook script %s failed: %w, output: %s", payload.Action, err, strings.TrimSpace(output.String()))
+	}
+
+	return nil
+}
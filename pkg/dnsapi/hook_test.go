@@ -0,0 +1,77 @@
+package dnsapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libdns/libdns"
+	"github.com/stretchr/testify/suite"
+)
+
+type HookTestSuite struct {
+	suite.Suite
+}
+
+func TestHookTestSuite(t *testing.T) {
+	suite.Run(t, &HookTestSuite{})
+}
+
+func (s *HookTestSuite) TestHTTP() {
+	var payloads []HookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var payload HookPayload
+		s.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		payloads = append(payloads, payload)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	rec := libdns.TXT{Name: "_acme-challenge", Text: "token"}
+	p := &Hook{URL: server.URL, Token: "secret"}
+	_, err := p.AppendRecords(ctx, "example.com.", []libdns.Record{rec})
+	s.NoError(err)
+	_, err = p.DeleteRecords(ctx, "example.com.", []libdns.Record{rec})
+	s.NoError(err)
+
+	s.Equal([]HookPayload{
+		{Action: HookActionPresent, Zone: "example.com", Name: "_acme-challenge", FQDN: "_acme-challenge.example.com", Value: "token", TTL: 120},
+		{Action: HookActionCleanup, Zone: "example.com", Name: "_acme-challenge", FQDN: "_acme-challenge.example.com", Value: "token", TTL: 120},
+	}, payloads)
+
+	p.Token = "wrong"
+	_, err = p.AppendRecords(ctx, "example.com.", []libdns.Record{rec})
+	s.ErrorContains(err, "401")
+}
+
+func (s *HookTestSuite) TestScript() {
+	out := filepath.Join(s.T().TempDir(), "hook.log")
+	p := &Hook{Script: `echo "$ACME_ACTION $ACME_FQDN $ACME_VALUE" >> ` + out}
+
+	rec := libdns.TXT{Name: "_acme-challenge.www", Text: "token; rm -rf /"}
+	_, err := p.AppendRecords(context.Background(), "example.com.", []libdns.Record{rec})
+	s.NoError(err)
+	_, err = p.DeleteRecords(context.Background(), "example.com.", []libdns.Record{rec})
+	s.NoError(err)
+
+	raw, err := os.ReadFile(out)
+	s.NoError(err)
+	s.Equal("present _acme-challenge.www.example.com token; rm -rf /\ncleanup _acme-challenge.www.example.com token; rm -rf /\n", string(raw))
+
+	p.Script = "echo failed; exit 3"
+	_, err = p.AppendRecords(context.Background(), "example.com.", []libdns.Record{rec})
+	s.ErrorContains(err, "output: failed")
+}
+
+func (s *HookTestSuite) TestMissingTarget() {
+	_, err := (&Hook{}).AppendRecords(context.Background(), "example.com.", []libdns.Record{libdns.TXT{Name: "a", Text: "b"}})
+	s.Error(err)
+}
@@ -0,0 +1,108 @@
+package dnsapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libdns/libdns"
+)
+
+// PowerDNS 通过 PowerDNS Authoritative HTTP API 修改记录
+type PowerDNS struct {
+	ServerURL string // API 地址，如 http://127.0.0.1:8081
+	APIKey    string
+	ServerID  string // 默认 localhost
+}
+
+type pdnsRecord struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled"`
+}
+
+type pdnsRRSet struct {
+	Name       string       `json:"name"`
+	Type       string       `json:"type"`
+	TTL        int          `json:"ttl,omitempty"`
+	ChangeType string       `json:"changetype,omitempty"`
+	Records    []pdnsRecord `json:"records"`
+}
+
+func (p *PowerDNS) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	return p.change(ctx, zone, recs, false)
+}
+
+func (p *PowerDNS) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	return p.change(ctx, zone, recs, true)
+}
+
+// change PowerDNS 以 RRSet 为单位替换，需要先读取现有记录再合并
+func (p *PowerDNS) change(ctx context.Context, zone string, recs []libdns.Record, remove bool) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+
+	var current struct {
+		RRSets []pdnsRRSet `json:"rrsets"`
+	}
+	if err = doJSON(ctx, http.MethodGet, p.zoneURL(zone), p.header(), nil, &current); err != nil {
+		return nil, err
+	}
+
+	sets := make(map[string]*pdnsRRSet)
+	for _, rr := range rrs {
+		name := dnsFQDN(libdns.AbsoluteName(rr.Name, dnsFQDN(zone)))
+		set, ok := sets[name]
+		if !ok {
+			set = &pdnsRRSet{Name: name, Type: "TXT", TTL: int(rr.TTL / time.Second)}
+			for _, item := range current.RRSets {
+				if strings.EqualFold(item.Name, name) && item.Type == "TXT" {
+					set.Records = item.Records
+					if remove && item.TTL > 0 {
+						set.TTL = item.TTL
+					}
+				}
+			}
+			sets[name] = set
+		}
+		content := strconv.Quote(rr.Data)
+		if remove {
+			set.Records = slices.DeleteFunc(set.Records, func(r pdnsRecord) bool { return r.Content == content })
+		} else if !slices.ContainsFunc(set.Records, func(r pdnsRecord) bool { return r.Content == content }) {
+			set.Records = append(set.Records, pdnsRecord{Content: content})
+		}
+	}
+
+	patch := struct {
+		RRSets []pdnsRRSet `json:"rrsets"`
+	}{}
+	for _, set := range sets {
+		set.ChangeType = "REPLACE"
+		if len(set.Records) == 0 {
+			set.ChangeType = "DELETE"
+		}
+		patch.RRSets = append(patch.RRSets, *set)
+	}
+	if err = doJSON(ctx, http.MethodPatch, p.zoneURL(zone), p.header(), patch, nil); err != nil {
+		return nil, err
+	}
+
+	return toRecords(rrs), nil
+}
+
+func (p *PowerDNS) zoneURL(zone string) string {
+	serverID := p.ServerID
+	if serverID == "" {
+		serverID = "localhost"
+	}
+	return strings.TrimSuffix(p.ServerURL, "/") + "/api/v1/servers/" + url.PathEscape(serverID) + "/zones/" + url.PathEscape(dnsFQDN(zone))
+}
+
+func (p *PowerDNS) header() http.Header {
+	return http.Header{"X-API-Key": {p.APIKey}}
+}
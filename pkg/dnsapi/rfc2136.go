@@ -0,0 +1,297 @@
+package dnsapi
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/libdns/libdns"
+)
+
+// DNS 报文常量
+const (
+	dnsOpcodeUpdate = 5
+	dnsTypeSOA      = 6
+	dnsTypeTXT      = 16
+	dnsTypeTSIG     = 250
+	dnsClassIN      = 1
+	dnsClassNone    = 254
+	dnsClassAny     = 255
+	tsigFudge       = 300
+)
+
+var dnsRcodes = map[int]string{
+	1:  "FORMERR",
+	2:  "SERVFAIL",
+	3:  "NXDOMAIN",
+	4:  "NOTIMP",
+	5:  "REFUSED",
+	6:  "YXDOMAIN",
+	7:  "YXRRSET",
+	8:  "NXRRSET",
+	9:  "NOTAUTH",
+	10: "NOTZONE",
+}
+
+// tsigAlgorithms TSIG 算法名与对应的哈希函数
+var tsigAlgorithms = map[string]func() hash.Hash{
+	"hmac-md5.sig-alg.reg.int.": md5.New,
+	"hmac-sha1.":                sha1.New,
+	"hmac-sha224.":              sha256.New224,
+	"hmac-sha256.":              sha256.New,
+	"hmac-sha384.":              sha512.New384,
+	"hmac-sha512.":              sha512.New,
+}
+
+// RFC2136 通过 RFC 2136 动态更新修改记录，适用于 BIND、Knot、PowerDNS 等权威服务器
+type RFC2136 struct {
+	Server    string // 服务器地址，如 192.0.2.1:53，未指定端口时使用 53
+	KeyName   string // TSIG 密钥名，为空时不签名
+	KeySecret string // Base64 编码的 TSIG 密钥
+	Algorithm string // TSIG 算法，默认 hmac-sha256
+	Timeout   time.Duration
+}
+
+func (p *RFC2136) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.update(ctx, zone, rrs, false); err != nil {
+		return nil, err
+	}
+
+	return toRecords(rrs), nil
+}
+
+func (p *RFC2136) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.update(ctx, zone, rrs, true); err != nil {
+		return nil, err
+	}
+
+	return toRecords(rrs), nil
+}
+
+// update 构造并发送 UPDATE 报文，remove 为 true 时删除指定记录
+func (p *RFC2136) update(ctx context.Context, zone string, rrs []libdns.RR, remove bool) error {
+	msg, id, err := p.buildUpdate(zone, rrs, remove, time.Now())
+	if err != nil {
+		return err
+	}
+
+	server := p.Server
+	if _, _, err = net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		return err
+	}
+	defer func(conn net.Conn) { _ = conn.Close() }(conn)
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	if _, err = conn.Write(msg); err != nil {
+		return err
+	}
+	buf := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return fmt.Errorf("failed to read response from %s: %w", server, err)
+		}
+		if n < 12 || binary.BigEndian.Uint16(buf) != id {
+			continue // 丢弃不匹配的报文
+		}
+		if rcode := int(buf[3] & 0x0f); rcode != 0 {
+			name, ok := dnsRcodes[rcode]
+			if !ok {
+				name = fmt.Sprintf("RCODE%d", rcode)
+			}
+			return fmt.Errorf("dns update rejected by %s: %s", server, name)
+		}
+		return nil
+	}
+}
+
+// buildUpdate 构造 UPDATE 报文，配置了密钥时附加 TSIG 签名
+func (p *RFC2136) buildUpdate(zone string, rrs []libdns.RR, remove bool, now time.Time) ([]byte, uint16, error) {
+	var idBuf [2]byte
+	if _, err := rand.Read(idBuf[:]); err != nil {
+		return nil, 0, err
+	}
+	id := binary.BigEndian.Uint16(idBuf[:])
+
+	zone = dnsFQDN(zone)
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[0:], id)
+	binary.BigEndian.PutUint16(msg[2:], dnsOpcodeUpdate<<11)
+	binary.BigEndian.PutUint16(msg[4:], 1)                // ZOCOUNT
+	binary.BigEndian.PutUint16(msg[8:], uint16(len(rrs))) // UPCOUNT
+
+	var err error
+	// Zone 段
+	if msg, err = appendDNSName(msg, zone); err != nil {
+		return nil, 0, err
+	}
+	msg = binary.BigEndian.AppendUint16(msg, dnsTypeSOA)
+	msg = binary.BigEndian.AppendUint16(msg, dnsClassIN)
+
+	// Update 段，删除单条记录时 CLASS 为 NONE、TTL 为 0
+	for _, rr := range rrs {
+		if msg, err = appendDNSName(msg, dnsFQDN(libdns.AbsoluteName(rr.Name, zone))); err != nil {
+			return nil, 0, err
+		}
+		class, ttl := uint16(dnsClassIN), uint32(rr.TTL/time.Second)
+		if remove {
+			class, ttl = dnsClassNone, 0
+		}
+		msg = binary.BigEndian.AppendUint16(msg, dnsTypeTXT)
+		msg = binary.BigEndian.AppendUint16(msg, class)
+		msg = binary.BigEndian.AppendUint32(msg, ttl)
+		rdata := txtRData(rr.Data)
+		msg = binary.BigEndian.AppendUint16(msg, uint16(len(rdata)))
+		msg = append(msg, rdata...)
+	}
+
+	if p.KeyName == "" {
+		return msg, id, nil
+	}
+	if msg, err = p.sign(msg, id, now); err != nil {
+		return nil, 0, err
+	}
+
+	return msg, id, nil
+}
+
+// sign 按 RFC 8945 计算 TSIG 并追加到报文末尾
+func (p *RFC2136) sign(msg []byte, id uint16, now time.Time) ([]byte, error) {
+	algorithm := dnsFQDN(strings.ToLower(p.Algorithm))
+	if algorithm == "." {
+		algorithm = "hmac-sha256."
+	}
+	if algorithm == "hmac-md5." {
+		algorithm = "hmac-md5.sig-alg.reg.int."
+	}
+	newHash, ok := tsigAlgorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TSIG algorithm: %s", p.Algorithm)
+	}
+	secret, err := base64.StdEncoding.DecodeString(p.KeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TSIG secret: %w", err)
+	}
+	keyName := dnsFQDN(strings.ToLower(p.KeyName))
+
+	// TSIG 变量：密钥名、CLASS、TTL、算法名、签名时间、Fudge、错误码、其他数据长度
+	var vars []byte
+	if vars, err = appendDNSName(vars, keyName); err != nil {
+		return nil, err
+	}
+	vars = binary.BigEndian.AppendUint16(vars, dnsClassAny)
+	vars = binary.BigEndian.AppendUint32(vars, 0)
+	if vars, err = appendDNSName(vars, algorithm); err != nil {
+		return nil, err
+	}
+	timeSigned := appendUint48(nil, uint64(now.Unix()))
+	vars = append(vars, timeSigned...)
+	vars = binary.BigEndian.AppendUint16(vars, tsigFudge)
+	vars = binary.BigEndian.AppendUint16(vars, 0)
+	vars = binary.BigEndian.AppendUint16(vars, 0)
+
+	mac := hmac.New(newHash, secret)
+	mac.Write(msg)
+	mac.Write(vars)
+	sum := mac.Sum(nil)
+
+	var rdata []byte
+	if rdata, err = appendDNSName(rdata, algorithm); err != nil {
+		return nil, err
+	}
+	rdata = append(rdata, timeSigned...)
+	rdata = binary.BigEndian.AppendUint16(rdata, tsigFudge)
+	rdata = binary.BigEndian.AppendUint16(rdata, uint16(len(sum)))
+	rdata = append(rdata, sum...)
+	rdata = binary.BigEndian.AppendUint16(rdata, id)
+	rdata = binary.BigEndian.AppendUint16(rdata, 0) // Error
+	rdata = binary.BigEndian.AppendUint16(rdata, 0) // Other Len
+
+	if msg, err = appendDNSName(msg, keyName); err != nil {
+		return nil, err
+	}
+	msg = binary.BigEndian.AppendUint16(msg, dnsTypeTSIG)
+	msg = binary.BigEndian.AppendUint16(msg, dnsClassAny)
+	msg = binary.BigEndian.AppendUint32(msg, 0)
+	msg = binary.BigEndian.AppendUint16(msg, uint16(len(rdata)))
+	msg = append(msg, rdata...)
+	binary.BigEndian.PutUint16(msg[10:], binary.BigEndian.Uint16(msg[10:])+1) // ARCOUNT
+
+	return msg, nil
+}
+
+// dnsFQDN 补全名称末尾的点
+func dnsFQDN(name string) string {
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+// appendDNSName 以非压缩格式写入域名
+func appendDNSName(b []byte, name string) ([]byte, error) {
+	name = strings.TrimSuffix(name, ".")
+	if name != "" {
+		if len(name) > 253 {
+			return nil, fmt.Errorf("domain name too long: %s", name)
+		}
+		for label := range strings.SplitSeq(name, ".") {
+			if label == "" || len(label) > 63 {
+				return nil, fmt.Errorf("invalid domain name: %s", name)
+			}
+			b = append(b, byte(len(label)))
+			b = append(b, label...)
+		}
+	}
+
+	return append(b, 0), nil
+}
+
+// txtRData 将文本按 255 字节拆分为多个字符串
+func txtRData(text string) []byte {
+	b := make([]byte, 0, len(text)+len(text)/255+1)
+	for {
+		chunk := text[:min(len(text), 255)]
+		b = append(b, byte(len(chunk)))
+		b = append(b, chunk...)
+		text = text[len(chunk):]
+		if text == "" {
+			return b
+		}
+	}
+}
+
+func appendUint48(b []byte, v uint64) []byte {
+	return append(b, byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
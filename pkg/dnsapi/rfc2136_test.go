@@ -0,0 +1,219 @@
+package dnsapi
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libdns/libdns"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeDNSServer 本地 DNS 动态更新替身，校验 TSIG 并维护 TXT 记录
+type fakeDNSServer struct {
+	conn   net.PacketConn
+	secret []byte
+
+	mu      sync.Mutex
+	records map[string][]string // 完整域名 → TXT 值
+	zones   []string
+}
+
+func newFakeDNSServer(secret []byte) (*fakeDNSServer, error) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &fakeDNSServer{conn: conn, secret: secret, records: make(map[string][]string)}
+	go s.serve()
+
+	return s, nil
+}
+
+func (s *fakeDNSServer) serve() {
+	buf := make([]byte, 4096)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		msg := append([]byte(nil), buf[:n]...)
+		resp := append([]byte(nil), msg[:12]...)
+		binary.BigEndian.PutUint16(resp[2:], binary.BigEndian.Uint16(msg[2:])|0x8000|uint16(s.handle(msg)))
+		_, _ = s.conn.WriteTo(resp, addr)
+	}
+}
+
+// handle 处理 UPDATE 报文，返回 RCODE
+func (s *fakeDNSServer) handle(msg []byte) int {
+	if binary.BigEndian.Uint16(msg[2:])>>11&0x0f != dnsOpcodeUpdate {
+		return 4
+	}
+	updates := int(binary.BigEndian.Uint16(msg[8:]))
+	additional := int(binary.BigEndian.Uint16(msg[10:]))
+
+	zone, off := readDNSName(msg, 12)
+	off += 4
+
+	type change struct {
+		name, text string
+		remove     bool
+	}
+	changes := make([]change, 0, updates)
+	for range updates {
+		var name string
+		name, off = readDNSName(msg, off)
+		class := binary.BigEndian.Uint16(msg[off+2:])
+		rdlen := int(binary.BigEndian.Uint16(msg[off+8:]))
+		rdata := msg[off+10 : off+10+rdlen]
+		off += 10 + rdlen
+		var text strings.Builder
+		for i := 0; i < len(rdata); i += int(rdata[i]) + 1 {
+			text.Write(rdata[i+1 : i+1+int(rdata[i])])
+		}
+		changes = append(changes, change{name: name, text: text.String(), remove: class == dnsClassNone})
+	}
+
+	// 校验 TSIG：签名覆盖不含 TSIG 记录且 ARCOUNT 减一的报文
+	if additional != 1 {
+		return 9
+	}
+	tsigStart := off
+	keyName, off := readDNSName(msg, off)
+	off += 10
+	algorithm, off := readDNSName(msg, off)
+	timeSigned := msg[off : off+6]
+	fudge := msg[off+6 : off+8]
+	macSize := int(binary.BigEndian.Uint16(msg[off+8:]))
+	mac := msg[off+10 : off+10+macSize]
+	if algorithm != "hmac-sha256." {
+		return 9
+	}
+
+	signed := append([]byte(nil), msg[:tsigStart]...)
+	binary.BigEndian.PutUint16(signed[10:], 0)
+	vars, _ := appendDNSName(nil, keyName)
+	vars = binary.BigEndian.AppendUint16(vars, dnsClassAny)
+	vars = binary.BigEndian.AppendUint32(vars, 0)
+	vars, _ = appendDNSName(vars, algorithm)
+	vars = append(vars, timeSigned...)
+	vars = append(vars, fudge...)
+	vars = append(vars, 0, 0, 0, 0)
+	h := hmac.New(sha256.New, s.secret)
+	h.Write(signed)
+	h.Write(vars)
+	if !hmac.Equal(h.Sum(nil), mac) {
+		return 9
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.zones = append(s.zones, zone)
+	for _, c := range changes {
+		if c.remove {
+			values := s.records[c.name][:0]
+			for _, v := range s.records[c.name] {
+				if v != c.text {
+					values = append(values, v)
+				}
+			}
+			s.records[c.name] = values
+			continue
+		}
+		s.records[c.name] = append(s.records[c.name], c.text)
+	}
+
+	return 0
+}
+
+func (s *fakeDNSServer) txt(name string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.records[name]...)
+}
+
+func readDNSName(msg []byte, off int) (string, int) {
+	var labels []string
+	for msg[off] != 0 {
+		l := int(msg[off])
+		labels = append(labels, string(msg[off+1:off+1+l]))
+		off += l + 1
+	}
+	return strings.Join(labels, ".") + ".", off + 1
+}
+
+type RFC2136TestSuite struct {
+	suite.Suite
+	secret []byte
+	server *fakeDNSServer
+}
+
+func TestRFC2136TestSuite(t *testing.T) {
+	suite.Run(t, &RFC2136TestSuite{})
+}
+
+func (s *RFC2136TestSuite) SetupTest() {
+	s.secret = []byte("0123456789abcdef0123456789abcdef")
+	server, err := newFakeDNSServer(s.secret)
+	s.Require().NoError(err)
+	s.server = server
+}
+
+func (s *RFC2136TestSuite) TearDownTest() {
+	_ = s.server.conn.Close()
+}
+
+func (s *RFC2136TestSuite) provider(secret []byte) *RFC2136 {
+	return &RFC2136{
+		Server:    s.server.conn.LocalAddr().String(),
+		KeyName:   "acme-key",
+		KeySecret: base64.StdEncoding.EncodeToString(secret),
+		Timeout:   2 * time.Second,
+	}
+}
+
+func (s *RFC2136TestSuite) TestAppendAndDelete() {
+	ctx := context.Background()
+	p := s.provider(s.secret)
+	first := libdns.TXT{Name: "_acme-challenge", Text: "token-1"}
+	second := libdns.TXT{Name: "_acme-challenge", Text: "token-2"}
+
+	added, err := p.AppendRecords(ctx, "example.com.", []libdns.Record{first})
+	s.NoError(err)
+	s.Len(added, 1)
+	_, err = p.AppendRecords(ctx, "example.com.", []libdns.Record{second})
+	s.NoError(err)
+	s.Equal([]string{"token-1", "token-2"}, s.server.txt("_acme-challenge.example.com."))
+	s.Equal("example.com.", s.server.zones[0])
+
+	_, err = p.DeleteRecords(ctx, "example.com.", added)
+	s.NoError(err)
+	s.Equal([]string{"token-2"}, s.server.txt("_acme-challenge.example.com."))
+}
+
+func (s *RFC2136TestSuite) TestLongTXT() {
+	text := strings.Repeat("a", 300)
+	_, err := s.provider(s.secret).AppendRecords(context.Background(), "example.com", []libdns.Record{libdns.TXT{Name: "long", Text: text}})
+	s.NoError(err)
+	s.Equal([]string{text}, s.server.txt("long.example.com."))
+}
+
+func (s *RFC2136TestSuite) TestBadKey() {
+	_, err := s.provider([]byte("wrong-secret")).AppendRecords(context.Background(), "example.com.", []libdns.Record{libdns.TXT{Name: "_acme-challenge", Text: "token"}})
+	s.ErrorContains(err, "NOTAUTH")
+	s.Empty(s.server.txt("_acme-challenge.example.com."))
+}
+
+func (s *RFC2136TestSuite) TestUnsupportedAlgorithm() {
+	p := s.provider(s.secret)
+	p.Algorithm = "hmac-foo"
+	_, err := p.AppendRecords(context.Background(), "example.com.", []libdns.Record{libdns.TXT{Name: "_acme-challenge", Text: "token"}})
+	s.ErrorContains(err, "unsupported TSIG algorithm")
+}
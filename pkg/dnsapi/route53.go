@@ -0,0 +1,265 @@
+package dnsapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libdns/libdns"
+)
+
+const (
+	route53Endpoint = "https://route53.amazonaws.com/2013-04-01"
+	route53Region   = "us-east-1" // Route53 为全局服务，签名固定使用 us-east-1
+	route53Service  = "route53"
+)
+
+// Route53 通过 AWS Route53 API 修改记录
+type Route53 struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	HostedZoneID    string // 为空时按 zone 名称查找
+}
+
+type route53ResourceRecord struct {
+	Value string `xml:"Value"`
+}
+
+type route53RRSet struct {
+	Name            string                  `xml:"Name"`
+	Type            string                  `xml:"Type"`
+	TTL             int                     `xml:"TTL"`
+	ResourceRecords []route53ResourceRecord `xml:"ResourceRecords>ResourceRecord"`
+}
+
+type route53Change struct {
+	Action            string       `xml:"Action"`
+	ResourceRecordSet route53RRSet `xml:"ResourceRecordSet"`
+}
+
+type route53ChangeRequest struct {
+	XMLName xml.Name        `xml:"https://route53.amazonaws.com/doc/2013-04-01/ ChangeResourceRecordSetsRequest"`
+	Changes []route53Change `xml:"ChangeBatch>Changes>Change"`
+}
+
+func (p *Route53) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	return p.change(ctx, zone, recs, false)
+}
+
+func (p *Route53) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	return p.change(ctx, zone, recs, true)
+}
+
+// change Route53 以 RRSet 为单位修改，追加时 UPSERT 合并后的全部值，删空时 DELETE 原记录集
+func (p *Route53) change(ctx context.Context, zone string, recs []libdns.Record, remove bool) ([]libdns.Record, error) {
+	rrs, err := txtRecords(recs)
+	if err != nil {
+		return nil, err
+	}
+	zoneID, err := p.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	sets := make(map[string]*route53RRSet)
+	originals := make(map[string]route53RRSet)
+	names := make([]string, 0)
+	for _, rr := range rrs {
+		name := dnsFQDN(libdns.AbsoluteName(rr.Name, dnsFQDN(zone)))
+		set, ok := sets[name]
+		if !ok {
+			current, err := p.currentSet(ctx, zoneID, name)
+			if err != nil {
+				return nil, err
+			}
+			set = &route53RRSet{Name: name, Type: "TXT", TTL: int(rr.TTL / time.Second)}
+			if current != nil {
+				originals[name] = *current
+				set.ResourceRecords = slices.Clone(current.ResourceRecords)
+				if remove {
+					set.TTL = current.TTL
+				}
+			}
+			sets[name] = set
+			names = append(names, name)
+		}
+		value := strconv.Quote(rr.Data)
+		if remove {
+			set.ResourceRecords = slices.DeleteFunc(set.ResourceRecords, func(r route53ResourceRecord) bool { return r.Value == value })
+		} else if !slices.ContainsFunc(set.ResourceRecords, func(r route53ResourceRecord) bool { return r.Value == value }) {
+			set.ResourceRecords = append(set.ResourceRecords, route53ResourceRecord{Value: value})
+		}
+	}
+
+	req := route53ChangeRequest{}
+	for _, name := range names {
+		set := sets[name]
+		if len(set.ResourceRecords) > 0 {
+			req.Changes = append(req.Changes, route53Change{Action: "UPSERT", ResourceRecordSet: *set})
+			continue
+		}
+		if original, ok := originals[name]; ok {
+			req.Changes = append(req.Changes, route53Change{Action: "DELETE", ResourceRecordSet: original})
+		}
+	}
+	if len(req.Changes) > 0 {
+		body, err := xml.Marshal(req)
+		if err != nil {
+			return nil, err
+		}
+		if err = p.do(ctx, http.MethodPost, "/hostedzone/"+zoneID+"/rrset", nil, body, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return toRecords(rrs), nil
+}
+
+// zoneID 查找 zone 对应的托管区域 ID
+func (p *Route53) zoneID(ctx context.Context, zone string) (string, error) {
+	if p.HostedZoneID != "" {
+		return strings.TrimPrefix(p.HostedZoneID, "/hostedzone/"), nil
+	}
+
+	var resp struct {
+		HostedZones []struct {
+			ID   string `xml:"Id"`
+			Name string `xml:"Name"`
+		} `xml:"HostedZones>HostedZone"`
+	}
+	query := url.Values{"dnsname": {dnsFQDN(zone)}, "maxitems": {"1"}}
+	if err := p.do(ctx, http.MethodGet, "/hostedzonesbyname", query, nil, &resp); err != nil {
+		return "", err
+	}
+	for _, item := range resp.HostedZones {
+		if strings.EqualFold(item.Name, dnsFQDN(zone)) {
+			return strings.TrimPrefix(item.ID, "/hostedzone/"), nil
+		}
+	}
+
+	return "", fmt.Errorf("route53 hosted zone %s not found", zone)
+}
+
+// currentSet 读取名称下已有的 TXT 记录集
+func (p *Route53) currentSet(ctx context.Context, zoneID, name string) (*route53RRSet, error) {
+	var resp struct {
+		Sets []route53RRSet `xml:"ResourceRecordSets>ResourceRecordSet"`
+	}
+	query := url.Values{"name": {name}, "type": {"TXT"}, "maxitems": {"1"}}
+	if err := p.do(ctx, http.MethodGet, "/hostedzone/"+zoneID+"/rrset", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	for _, set := range resp.Sets {
+		if strings.EqualFold(set.Name, name) && set.Type == "TXT" {
+			return &set, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (p *Route53) do(ctx context.Context, method, path string, query url.Values, body []byte, out any) error {
+	u := route53Endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+	p.sign(req, body, time.Now().UTC())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) { _ = Body.Close() }(resp.Body)
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Code    string `xml:"Error>Code"`
+			Message string `xml:"Error>Message"`
+		}
+		if xml.Unmarshal(raw, &apiErr) == nil && apiErr.Code != "" {
+			return fmt.Errorf("route53 %s %s: %s: %s", method, path, apiErr.Code, apiErr.Message)
+		}
+		return fmt.Errorf("route53 %s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+	if out != nil {
+		return xml.Unmarshal(raw, out)
+	}
+
+	return nil
+}
+
+// sign 按 AWS Signature V4 为请求添加鉴权头
+func (p *Route53) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host, "x-amz-date": amzDate}
+	if ct := req.Header.Get("Content-Type"); ct != "" {
+		headers["content-type"] = ct
+	}
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var canonicalHeaders strings.Builder
+	for _, key := range keys {
+		canonicalHeaders.WriteString(key + ":" + strings.TrimSpace(headers[key]) + "\n")
+	}
+	signedHeaders := strings.Join(keys, ";")
+
+	// url.Values.Encode 已按键排序，将空格的 + 改为 %20 以符合 RFC 3986
+	query := strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20")
+	payload := sha256.Sum256(body)
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		query,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payload[:]),
+	}, "\n")
+
+	scope := date + "/" + route53Region + "/" + route53Service + "/aws4_request"
+	hashed := sha256.Sum256([]byte(canonical))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashed[:])
+
+	key := route53HMAC([]byte("AWS4"+p.SecretAccessKey), date)
+	key = route53HMAC(key, route53Region)
+	key = route53HMAC(key, route53Service)
+	key = route53HMAC(key, "aws4_request")
+	signature := hex.EncodeToString(route53HMAC(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		p.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func route53HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/coder/websocket"
 	"github.com/moby/moby/client"
+
+	"github.com/acepanel/panel/v3/pkg/asciicast"
 )
 
 // MessageResize 终端大小调整消息
@@ -26,10 +28,11 @@ type Turn struct {
 	client *client.Client
 	execID string
 	hijack client.ExecAttachResult
+	rec    *asciicast.Recorder
 }
 
-// NewTurn 创建容器终端转发器
-func NewTurn(ctx context.Context, ws *websocket.Conn, containerID string, command []string, sock string) (*Turn, error) {
+// NewTurn 创建容器终端转发器，rec 不为 nil 时录制会话
+func NewTurn(ctx context.Context, ws *websocket.Conn, containerID string, command []string, sock string, rec *asciicast.Recorder) (*Turn, error) {
 	apiClient, err := client.New(client.WithHost(sock))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create docker client: %w", err)
@@ -63,6 +66,7 @@ func NewTurn(ctx context.Context, ws *websocket.Conn, containerID string, comman
 		client: apiClient,
 		execID: execCreateResp.ID,
 		hijack: hijack,
+		rec:    rec,
 	}
 
 	return turn, nil
@@ -70,6 +74,7 @@ func NewTurn(ctx context.Context, ws *websocket.Conn, containerID string, comman
 
 // Write 实现 io.Writer 接口，将容器输出写入 WebSocket
 func (t *Turn) Write(p []byte) (n int, err error) {
+	t.rec.Output(p)
 	if err = t.ws.Write(t.ctx, websocket.MessageText, p); err != nil {
 		return 0, err
 	}
@@ -117,6 +122,7 @@ func (t *Turn) Handle(ctx context.Context) error {
 			// 判断是否是 resize 消息
 			if err = json.Unmarshal(data, &resize); err == nil {
 				if resize.Resize && resize.Columns > 0 && resize.Rows > 0 {
+					t.rec.Resize(resize.Columns, resize.Rows)
 					if _, err = t.client.ExecResize(ctx, t.execID, client.ExecResizeOptions{
 						Height: resize.Rows,
 						Width:  resize.Columns,
@@ -127,6 +133,7 @@ func (t *Turn) Handle(ctx context.Context) error {
 				continue
 			}
 
+			t.rec.Input(data)
 			if _, err = t.hijack.Conn.Write(data); err != nil {
 				return fmt.Errorf("failed to write to container stdin: %w", err)
 			}
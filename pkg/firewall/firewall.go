@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"sync/atomic"
 
 	"github.com/samber/lo"
 
@@ -35,18 +36,164 @@ type Firewall interface {
 	PingStatus() (bool, error)
 	// UpdatePingStatus 更新 Ping 状态
 	UpdatePingStatus(status bool) error
+
+	// SyncGeoRules 用给定规则替换面板管理的全部地区规则及其 IP 集合
+	SyncGeoRules(rules []GeoRule) error
+}
+
+// Backend 防火墙后端
+type Backend string
+
+const (
+	BackendAuto      Backend = ""          // 自动检测
+	BackendFirewalld Backend = "firewalld" // firewalld
+	BackendUFW       Backend = "ufw"       // ufw
+	BackendNftables  Backend = "nftables"  // 原生 nftables
+)
+
+var backend atomic.Value
+
+// SetBackend 设置 NewFirewall 使用的后端，空值表示自动检测
+func SetBackend(b Backend) {
+	backend.Store(b)
 }
 
-// NewFirewall 自动检测系统防火墙类型并返回对应实现
+// NewFirewall 返回设置中指定的防火墙实现，未指定时自动检测系统防火墙类型
 func NewFirewall() Firewall {
+	b, _ := backend.Load().(Backend)
+	return build(resolve(b))
+}
+
+// Switch 切换到后端 to 并设为 NewFirewall 使用的后端
+// 原后端运行中时停用原后端、启用新后端并导入原有的端口、富规则、转发与 Ping 设置，任一步失败都会还原
+// 地区规则的 IP 集合无法从原后端读出，由调用方传入 geo 在新后端重建
+// 新后端总会放行 SSH、80/443 与 ports，避免切换后网站和面板失联
+func Switch(to Backend, geo []GeoRule, ports ...uint) error {
+	current, _ := backend.Load().(Backend)
+	from, next := resolve(current), resolve(to)
+	if from != next {
+		if err := migrate(build(from), build(next), append([]uint{sshPort(), 80, 443}, ports...), geo); err != nil {
+			return err
+		}
+	}
+
+	SetBackend(to)
+	return nil
+}
+
+// migrate 由 to 接替 from，from 未运行时只在 to 中放行 ports 并写入地区规则
+func migrate(from, to Firewall, ports []uint, geo []GeoRule) error {
+	allow := func() error {
+		for _, port := range ports {
+			if err := to.Port(FireInfo{
+				Type:      TypeNormal,
+				PortStart: port,
+				PortEnd:   port,
+				Protocol:  ProtocolTCPUDP,
+				Strategy:  StrategyAccept,
+				Direction: DirectionIn,
+			}, OperationAdd); err != nil {
+				return err
+			}
+		}
+		return to.SyncGeoRules(geo)
+	}
+
+	if running, _ := from.Status(); !running {
+		// 新后端未运行时部分实现无法写入规则，忽略失败
+		if err := allow(); err != nil {
+			if running, _ = to.Status(); running {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// 需先读出规则再停用，firewalld 停止后无法列出
+	rules, err := from.ListRule()
+	if err != nil {
+		return err
+	}
+	forwards, err := from.ListForward()
+	if err != nil {
+		return err
+	}
+	ping, pingErr := from.PingStatus()
+
+	if err = from.Disable(); err != nil {
+		_ = from.Enable()
+		return err
+	}
+	rollback := func(err error) error {
+		_ = to.Disable()
+		if enableErr := from.Enable(); enableErr != nil {
+			return fmt.Errorf("%w, restore previous firewall: %v", err, enableErr)
+		}
+		return err
+	}
+	if err = to.Enable(); err != nil {
+		return rollback(err)
+	}
+	if err = allow(); err != nil {
+		return rollback(err)
+	}
+	for _, rule := range rules {
+		if rule.Type == TypeRich {
+			err = to.RichRules(rule, OperationAdd)
+		} else {
+			err = to.Port(rule, OperationAdd)
+		}
+		if err != nil {
+			return rollback(fmt.Errorf("import rule %s %d-%d: %w", rule.Protocol, rule.PortStart, rule.PortEnd, err))
+		}
+	}
+	for _, forward := range forwards {
+		if err = to.Forward(Forward{
+			Protocol:   forward.Protocol,
+			Port:       forward.Port,
+			TargetIP:   forward.TargetIP,
+			TargetPort: forward.TargetPort,
+		}, OperationAdd); err != nil {
+			return rollback(fmt.Errorf("import forward %s %d: %w", forward.Protocol, forward.Port, err))
+		}
+	}
+	if pingErr == nil {
+		if err = to.UpdatePingStatus(ping); err != nil {
+			return rollback(err)
+		}
+	}
+
+	return nil
+}
+
+// resolve 返回后端 b 实际使用的实现，自动检测时按 firewalld、ufw、nftables 的顺序探测
+func resolve(b Backend) Backend {
+	if b != BackendAuto {
+		return b
+	}
+
 	if _, err := shell.Execf("firewall-cmd --version"); err == nil {
-		return newFirewalld()
+		return BackendFirewalld
 	}
 	if _, err := shell.Execf("ufw version"); err == nil {
-		return newUFW()
+		return BackendUFW
+	}
+	if _, err := shell.Execf("nft --version"); err == nil {
+		return BackendNftables
 	}
 	// 默认 firewalld
-	return newFirewalld()
+	return BackendFirewalld
+}
+
+func build(b Backend) Firewall {
+	switch b {
+	case BackendUFW:
+		return newUFW()
+	case BackendNftables:
+		return newNftables()
+	default:
+		return newFirewalld()
+	}
 }
 
 // isLocalAddress 判断是否为本地地址
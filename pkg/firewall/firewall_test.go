@@ -1,6 +1,10 @@
 package firewall
 
 import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -132,6 +136,19 @@ func (s *FirewalldSuite) TestForwardRegex_IPv6Addr() {
 	s.Equal("::1", match[4])
 }
 
+// --- geo ---
+
+func (s *FirewalldSuite) TestBuildGeoRuleStr() {
+	rule := GeoRule{ID: 3, PortStart: 22, PortEnd: 22, Strategy: StrategyAccept}
+	s.Equal(`rule family="ipv4" source ipset="acepanel_geo_3" port port="22" protocol="tcp" accept`, s.fw.buildGeoRuleStr(rule, "ipv4", "tcp", false))
+	rule = GeoRule{ID: 3, Strategy: StrategyDrop}
+	s.Equal(`rule family="ipv6" source ipset="acepanel_geo6_3" drop`, s.fw.buildGeoRuleStr(rule, "ipv6", "", false))
+	// 仅允许模式额外丢弃集合外的来源
+	rule = GeoRule{ID: 4, PortStart: 8000, PortEnd: 9000, Strategy: StrategyAccept, Only: true}
+	s.Equal(`rule family="ipv4" source ipset="acepanel_geo_4" port port="8000-9000" protocol="udp" accept`, s.fw.buildGeoRuleStr(rule, "ipv4", "udp", false))
+	s.Equal(`rule family="ipv4" source not ipset="acepanel_geo_4" port port="8000-9000" protocol="udp" drop`, s.fw.buildGeoRuleStr(rule, "ipv4", "udp", true))
+}
+
 func TestFirewalldSuite(t *testing.T) {
 	suite.Run(t, new(FirewalldSuite))
 }
@@ -560,10 +577,426 @@ func (s *UFWSuite) TestParseRule_FullOutput() {
 	s.Equal(ProtocolTCP, r.Protocol)
 }
 
+// --- geo ---
+
+func (s *UFWSuite) TestBuildGeoRules() {
+	rules := []GeoRule{
+		{ID: 1, Networks: []netip.Prefix{netip.MustParsePrefix("1.0.1.0/24"), netip.MustParsePrefix("2001:db8::/32")}, PortStart: 22, PortEnd: 22, Protocol: ProtocolTCP, Strategy: StrategyAccept},
+		{ID: 2, Networks: []netip.Prefix{netip.MustParsePrefix("5.0.0.0/8")}, PortStart: 8000, PortEnd: 9000, Strategy: StrategyDrop},
+	}
+	s.Equal([]string{
+		"-A ufw-before-input -m set --match-set acepanel_geo_1 src -p tcp --dport 22 -j ACCEPT",
+		"-A ufw-before-input -m set --match-set acepanel_geo_2 src -p tcp --dport 8000:9000 -j DROP",
+		"-A ufw-before-input -m set --match-set acepanel_geo_2 src -p udp --dport 8000:9000 -j DROP",
+	}, s.fw.buildGeoRules(rules, "ipv4", "ufw-before-input"))
+	s.Equal([]string{
+		"-A ufw6-before-input -m set --match-set acepanel_geo6_1 src -p tcp --dport 22 -j ACCEPT",
+	}, s.fw.buildGeoRules(rules, "ipv6", "ufw6-before-input"))
+}
+
+func (s *UFWSuite) TestBuildGeoRules_Only() {
+	rules := []GeoRule{
+		{ID: 3, Networks: []netip.Prefix{netip.MustParsePrefix("1.0.1.0/24")}, PortStart: 22, PortEnd: 22, Protocol: ProtocolTCP, Strategy: StrategyAccept, Only: true},
+	}
+	s.Equal([]string{
+		"-A ufw-before-input -m set --match-set acepanel_geo_3 src -p tcp --dport 22 -j ACCEPT",
+		"-A ufw-before-input -m set ! --match-set acepanel_geo_3 src -p tcp --dport 22 -j DROP",
+	}, s.fw.buildGeoRules(rules, "ipv4", "ufw-before-input"))
+	// 没有 IPv6 网段时集合为空，IPv6 来源全部丢弃
+	s.Equal([]string{
+		"-A ufw6-before-input -m set --match-set acepanel_geo6_3 src -p tcp --dport 22 -j ACCEPT",
+		"-A ufw6-before-input -m set ! --match-set acepanel_geo6_3 src -p tcp --dport 22 -j DROP",
+	}, s.fw.buildGeoRules(rules, "ipv6", "ufw6-before-input"))
+}
+
+func (s *UFWSuite) TestReplaceGeoBlock() {
+	text := "*nat\nCOMMIT\n*filter\n:ufw-before-input - [0:0]\n# don't delete the 'COMMIT' line\nCOMMIT\n"
+
+	added, err := s.fw.replaceGeoBlock(text, []string{"-A ufw-before-input -m set --match-set acepanel_geo_1 src -j DROP"})
+	s.NoError(err)
+	s.Equal("*nat\nCOMMIT\n*filter\n:ufw-before-input - [0:0]\n# don't delete the 'COMMIT' line\n# BEGIN acepanel-geo\n-A ufw-before-input -m set --match-set acepanel_geo_1 src -j DROP\n# END acepanel-geo\nCOMMIT\n", added)
+
+	replaced, err := s.fw.replaceGeoBlock(added, []string{"-A ufw-before-input -m set --match-set acepanel_geo_2 src -j DROP"})
+	s.NoError(err)
+	s.NotContains(replaced, "acepanel_geo_1")
+	s.Contains(replaced, "acepanel_geo_2")
+
+	removed, err := s.fw.replaceGeoBlock(replaced, nil)
+	s.NoError(err)
+	s.Equal(text, removed)
+
+	_, err = s.fw.replaceGeoBlock("*nat\nCOMMIT\n", []string{"-A x"})
+	s.Error(err)
+}
+
 func TestUFWSuite(t *testing.T) {
 	suite.Run(t, new(UFWSuite))
 }
 
+// ===================== nftables 测试 =====================
+
+type NftablesSuite struct {
+	suite.Suite
+	fw *nftables
+}
+
+func (s *NftablesSuite) SetupTest() {
+	s.fw = newNftables()
+	s.fw.path = filepath.Join(s.T().TempDir(), "acepanel.nft")
+}
+
+func (s *NftablesSuite) TestParseRule_Normal() {
+	info := s.fw.parseRule("tcp dport 80 accept", "input")
+	s.Require().NotNil(info)
+	s.Equal(TypeNormal, info.Type)
+	s.Equal("ipv4", info.Family)
+	s.Equal(uint(80), info.PortStart)
+	s.Equal(uint(80), info.PortEnd)
+	s.Equal(ProtocolTCP, info.Protocol)
+	s.Equal(StrategyAccept, info.Strategy)
+	s.Equal(DirectionIn, info.Direction)
+}
+
+func (s *NftablesSuite) TestParseRule_SourceRange() {
+	info := s.fw.parseRule("ip saddr 10.0.0.0/8 udp dport 3000-4000 drop", "input")
+	s.Require().NotNil(info)
+	s.Equal(TypeRich, info.Type)
+	s.Equal("10.0.0.0/8", info.Address)
+	s.Equal(uint(3000), info.PortStart)
+	s.Equal(uint(4000), info.PortEnd)
+	s.Equal(ProtocolUDP, info.Protocol)
+	s.Equal(StrategyDrop, info.Strategy)
+}
+
+func (s *NftablesSuite) TestParseRule_OutputIPv6AllPorts() {
+	info := s.fw.parseRule("ip6 daddr 2001:db8::/32 meta l4proto tcp reject", "output")
+	s.Require().NotNil(info)
+	s.Equal("ipv6", info.Family)
+	s.Equal("2001:db8::/32", info.Address)
+	s.Equal(DirectionOut, info.Direction)
+	s.Equal(uint(1), info.PortStart)
+	s.Equal(uint(65535), info.PortEnd)
+	s.Equal(StrategyReject, info.Strategy)
+}
+
+func (s *NftablesSuite) TestParseRule_NoMatch() {
+	s.Nil(s.fw.parseRule("ct state established,related accept", "input"))
+	s.Nil(s.fw.parseRule("", "input"))
+}
+
+func (s *NftablesSuite) TestDefaultRuleset() {
+	rules, err := s.fw.ListRule()
+	s.NoError(err)
+	s.Require().Len(rules, 1)
+	s.Equal(ProtocolTCP, rules[0].Protocol)
+
+	ping, err := s.fw.PingStatus()
+	s.NoError(err)
+	s.True(ping)
+}
+
+func (s *NftablesSuite) TestPort_RoundTripMerged() {
+	s.NoError(s.fw.Port(FireInfo{PortStart: 8888, Protocol: ProtocolTCPUDP}, OperationAdd))
+	s.NoError(s.fw.Port(FireInfo{PortStart: 3000, PortEnd: 4000, Protocol: ProtocolTCP}, OperationAdd))
+
+	content, err := os.ReadFile(s.fw.path)
+	s.NoError(err)
+	s.Contains(string(content), "tcp dport 8888 accept")
+	s.Contains(string(content), "udp dport 8888 accept")
+	s.Contains(string(content), "tcp dport 3000-4000 accept")
+
+	rules, err := s.fw.ListRule()
+	s.NoError(err)
+	var found bool
+	for _, rule := range rules {
+		if rule.PortStart == 8888 {
+			found = true
+			s.Equal(ProtocolTCPUDP, rule.Protocol)
+			s.Equal(TypeNormal, rule.Type)
+		}
+	}
+	s.True(found)
+
+	s.NoError(s.fw.Port(FireInfo{PortStart: 8888, Protocol: ProtocolTCPUDP}, OperationRemove))
+	content, _ = os.ReadFile(s.fw.path)
+	s.NotContains(string(content), "dport 8888")
+}
+
+func (s *NftablesSuite) TestRichRules_RoundTrip() {
+	rule := FireInfo{Address: "192.168.1.0/24", PortStart: 22, Protocol: ProtocolTCPUDP, Strategy: StrategyDrop, Direction: DirectionIn}
+	s.NoError(s.fw.RichRules(rule, OperationAdd))
+	s.NoError(s.fw.RichRules(FireInfo{Family: "ipv6", Protocol: ProtocolTCP, Strategy: StrategyAccept}, OperationAdd))
+
+	content, err := os.ReadFile(s.fw.path)
+	s.NoError(err)
+	// 拒绝规则在放行规则之前
+	s.Less(strings.Index(string(content), "ip saddr 192.168.1.0/24 tcp dport 22 drop"), strings.Index(string(content), "tcp dport 22 accept"))
+	s.Contains(string(content), "meta nfproto ipv6 meta l4proto tcp accept")
+
+	rules, err := s.fw.ListRule()
+	s.NoError(err)
+	var rich []FireInfo
+	for _, item := range rules {
+		if item.Type == TypeRich {
+			rich = append(rich, item)
+		}
+	}
+	s.Require().Len(rich, 2)
+	s.Equal("ipv6", rich[0].Family)
+	s.Equal(uint(1), rich[0].PortStart)
+	s.Equal("192.168.1.0/24", rich[1].Address)
+	s.Equal(ProtocolTCPUDP, rich[1].Protocol)
+	s.Equal(StrategyDrop, rich[1].Strategy)
+}
+
+func (s *NftablesSuite) TestRichRules_Invalid() {
+	s.Error(s.fw.RichRules(FireInfo{PortStart: 80, Direction: DirectionOut}, OperationAdd))
+	s.Error(s.fw.RichRules(FireInfo{Address: "1.2.3.4", Strategy: StrategyMark}, OperationAdd))
+}
+
+func (s *NftablesSuite) TestForward_RoundTrip() {
+	s.NoError(s.fw.Forward(Forward{Protocol: ProtocolTCP, Port: 8080, TargetIP: "10.0.0.2", TargetPort: 80}, OperationAdd))
+	s.NoError(s.fw.Forward(Forward{Protocol: ProtocolUDP, Port: 5353, TargetPort: 53}, OperationAdd))
+	s.NoError(s.fw.Forward(Forward{Protocol: ProtocolTCP, Port: 9000, TargetIP: "fd00::2", TargetPort: 90}, OperationAdd))
+
+	content, err := os.ReadFile(s.fw.path)
+	s.NoError(err)
+	s.Contains(string(content), "meta l4proto tcp th dport 8080 dnat ip to 10.0.0.2:80")
+	s.Contains(string(content), "meta l4proto udp th dport 5353 redirect to :53")
+	s.Contains(string(content), "meta l4proto tcp th dport 9000 dnat ip6 to [fd00::2]:90")
+	s.Contains(string(content), "ip daddr 10.0.0.2 meta l4proto tcp th dport 80 masquerade")
+
+	forwards, err := s.fw.ListForward()
+	s.NoError(err)
+	s.Equal([]FireForwardInfo{
+		{Port: 5353, Protocol: ProtocolUDP, TargetIP: "127.0.0.1", TargetPort: 53},
+		{Port: 8080, Protocol: ProtocolTCP, TargetIP: "10.0.0.2", TargetPort: 80},
+		{Port: 9000, Protocol: ProtocolTCP, TargetIP: "fd00::2", TargetPort: 90},
+	}, forwards)
+
+	s.NoError(s.fw.Forward(Forward{Protocol: ProtocolTCP, Port: 8080, TargetIP: "10.0.0.2", TargetPort: 80}, OperationRemove))
+	forwards, err = s.fw.ListForward()
+	s.NoError(err)
+	s.Len(forwards, 2)
+}
+
+func (s *NftablesSuite) TestPingStatus() {
+	s.NoError(s.fw.UpdatePingStatus(false))
+	ping, err := s.fw.PingStatus()
+	s.NoError(err)
+	s.False(ping)
+
+	content, err := os.ReadFile(s.fw.path)
+	s.NoError(err)
+	s.Contains(string(content), "icmp type echo-request drop")
+	s.Contains(string(content), "icmpv6 type echo-request drop")
+}
+
+func (s *NftablesSuite) TestSyncGeoRules_RoundTrip() {
+	rules := []GeoRule{
+		{ID: 1, Networks: []netip.Prefix{netip.MustParsePrefix("1.0.1.0/24"), netip.MustParsePrefix("2001:db8::/32")}, PortStart: 22, Protocol: ProtocolTCPUDP, Strategy: StrategyAccept},
+		{ID: 2, Networks: []netip.Prefix{netip.MustParsePrefix("5.0.0.0/8"), netip.MustParsePrefix("6.6.6.6/32")}, Strategy: StrategyDrop},
+	}
+	s.NoError(s.fw.SyncGeoRules(rules))
+	// 修改其他规则后地区规则保持不变
+	s.NoError(s.fw.Port(FireInfo{PortStart: 8888, Protocol: ProtocolTCP}, OperationAdd))
+
+	content, err := os.ReadFile(s.fw.path)
+	s.NoError(err)
+	text := string(content)
+	s.Contains(text, "set acepanel_geo_1 {")
+	s.Contains(text, "set acepanel_geo6_1 {")
+	s.Contains(text, "elements = { 5.0.0.0/8, 6.6.6.6/32 }")
+	s.Contains(text, "ip saddr @acepanel_geo_1 udp dport 22 accept")
+	s.Contains(text, "ip6 saddr @acepanel_geo6_1 tcp dport 22 accept")
+	// 地区拒绝规则在所有放行规则之前
+	s.Less(strings.Index(text, "ip saddr @acepanel_geo_2 drop"), strings.Index(text, "tcp dport 8888 accept"))
+
+	ruleset := s.fw.parse(text)
+	s.Require().Len(ruleset.geo, 2)
+	s.Equal(GeoRule{ID: 1, Networks: rules[0].Networks, PortStart: 22, PortEnd: 22, Protocol: ProtocolTCPUDP, Strategy: StrategyAccept}, ruleset.geo[0])
+	s.Equal(rules[1], ruleset.geo[1])
+
+	// 地区规则不出现在普通规则列表中
+	list, err := s.fw.ListRule()
+	s.NoError(err)
+	for _, rule := range list {
+		s.NotContains(rule.Address, "@")
+	}
+
+	s.NoError(s.fw.SyncGeoRules(nil))
+	content, _ = os.ReadFile(s.fw.path)
+	s.NotContains(string(content), geoSetPrefix)
+}
+
+func (s *NftablesSuite) TestSyncGeoRules_Only() {
+	rules := []GeoRule{
+		{ID: 5, Networks: []netip.Prefix{netip.MustParsePrefix("1.0.1.0/24")}, PortStart: 22, Protocol: ProtocolTCP, Strategy: StrategyAccept, Only: true},
+	}
+	s.NoError(s.fw.SyncGeoRules(rules))
+	s.NoError(s.fw.Port(FireInfo{PortStart: 22, Protocol: ProtocolTCP}, OperationAdd))
+
+	content, err := os.ReadFile(s.fw.path)
+	s.NoError(err)
+	text := string(content)
+	// 没有 IPv6 网段时仍声明空集合
+	s.Contains(text, "set acepanel_geo6_5 {")
+	s.Contains(text, "ip saddr @acepanel_geo_5 tcp dport 22 accept")
+	s.Contains(text, "ip saddr != @acepanel_geo_5 tcp dport 22 drop")
+	s.Contains(text, "ip6 saddr != @acepanel_geo6_5 tcp dport 22 drop")
+	// 放行集合内来源后丢弃其余来源，且先于端口放行规则
+	s.Less(strings.Index(text, "ip saddr @acepanel_geo_5 tcp dport 22 accept"), strings.Index(text, "ip saddr != @acepanel_geo_5 tcp dport 22 drop"))
+	s.Less(strings.Index(text, "ip6 saddr != @acepanel_geo6_5 tcp dport 22 drop"), strings.Index(text, "\ttcp dport 22 accept"))
+
+	ruleset := s.fw.parse(text)
+	s.Require().Len(ruleset.geo, 1)
+	s.Equal(GeoRule{ID: 5, Networks: rules[0].Networks, PortStart: 22, PortEnd: 22, Protocol: ProtocolTCP, Strategy: StrategyAccept, Only: true}, ruleset.geo[0])
+}
+
+func (s *NftablesSuite) TestSyncGeoRules_Invalid() {
+	s.Error(s.fw.SyncGeoRules([]GeoRule{{ID: 1, Strategy: StrategyMark}}))
+	s.Error(s.fw.SyncGeoRules([]GeoRule{{ID: 1, PortStart: 90, PortEnd: 80, Strategy: StrategyDrop}}))
+	s.Error(s.fw.SyncGeoRules([]GeoRule{{ID: 1, Strategy: StrategyAccept, Only: true}}))
+}
+
+func TestNftablesSuite(t *testing.T) {
+	suite.Run(t, new(NftablesSuite))
+}
+
+// ===================== 切换后端测试 =====================
+
+// fakeFirewall 记录规则与启停状态的内存防火墙
+type fakeFirewall struct {
+	running    bool
+	enableErr  error
+	richErr    error
+	rules      []FireInfo
+	forwards   []FireForwardInfo
+	ping       bool
+	geo        []GeoRule
+	geoErr     error
+	enableRuns int
+}
+
+func (f *fakeFirewall) Status() (bool, error) { return f.running, nil }
+func (f *fakeFirewall) Enable() error {
+	f.enableRuns++
+	if f.enableErr != nil {
+		return f.enableErr
+	}
+	f.running = true
+	return nil
+}
+func (f *fakeFirewall) Disable() error                { f.running = false; return nil }
+func (f *fakeFirewall) ListRule() ([]FireInfo, error) { return f.rules, nil }
+func (f *fakeFirewall) Port(rule FireInfo, _ Operation) error {
+	f.rules = append(f.rules, rule)
+	return nil
+}
+func (f *fakeFirewall) RichRules(rule FireInfo, _ Operation) error {
+	if f.richErr != nil {
+		return f.richErr
+	}
+	f.rules = append(f.rules, rule)
+	return nil
+}
+func (f *fakeFirewall) ListForward() ([]FireForwardInfo, error) { return f.forwards, nil }
+func (f *fakeFirewall) Forward(rule Forward, _ Operation) error {
+	f.forwards = append(f.forwards, FireForwardInfo{Port: rule.Port, Protocol: rule.Protocol, TargetIP: rule.TargetIP, TargetPort: rule.TargetPort})
+	return nil
+}
+func (f *fakeFirewall) PingStatus() (bool, error)          { return f.ping, nil }
+func (f *fakeFirewall) UpdatePingStatus(status bool) error { f.ping = status; return nil }
+func (f *fakeFirewall) SyncGeoRules(rules []GeoRule) error {
+	if f.geoErr != nil {
+		return f.geoErr
+	}
+	f.geo = rules
+	return nil
+}
+
+func TestMigrateImportsRulesAndTakesOver(t *testing.T) {
+	from := &fakeFirewall{
+		running: true,
+		rules: []FireInfo{
+			{Type: TypeNormal, PortStart: 3306, PortEnd: 3306, Protocol: ProtocolTCP, Strategy: StrategyAccept, Direction: DirectionIn},
+			{Type: TypeRich, Family: "ipv4", Address: "10.0.0.0/8", PortStart: 22, PortEnd: 22, Protocol: ProtocolTCP, Strategy: StrategyDrop, Direction: DirectionIn},
+		},
+		forwards: []FireForwardInfo{{Port: 8080, Protocol: ProtocolTCP, TargetIP: "127.0.0.1", TargetPort: 80}},
+		ping:     false,
+	}
+	to := &fakeFirewall{ping: true}
+	geo := []GeoRule{{ID: 1, Networks: []netip.Prefix{netip.MustParsePrefix("1.0.1.0/24")}, Strategy: StrategyDrop}}
+
+	assert.NoError(t, migrate(from, to, []uint{22, 80, 443, 8888}, geo))
+	assert.False(t, from.running)
+	assert.True(t, to.running)
+	assert.False(t, to.ping)
+	assert.Equal(t, from.forwards, to.forwards)
+	assert.Equal(t, geo, to.geo)
+
+	ports := make(map[uint]bool)
+	for _, rule := range to.rules {
+		ports[rule.PortStart] = true
+	}
+	for _, port := range []uint{22, 80, 443, 8888, 3306} {
+		assert.True(t, ports[port], "port %d should be allowed", port)
+	}
+	assert.Contains(t, to.rules, from.rules[1])
+}
+
+func TestMigrateRollsBackOnFailure(t *testing.T) {
+	rich := FireInfo{Type: TypeRich, Family: "ipv4", Address: "10.0.0.1", Protocol: ProtocolTCP, Strategy: StrategyMark, Direction: DirectionIn}
+
+	// 新后端无法导入规则时恢复原后端
+	from := &fakeFirewall{running: true, rules: []FireInfo{rich}}
+	to := &fakeFirewall{richErr: assert.AnError}
+	assert.ErrorIs(t, migrate(from, to, []uint{80}, nil), assert.AnError)
+	assert.True(t, from.running)
+	assert.False(t, to.running)
+
+	// 新后端无法写入地区规则时恢复原后端
+	from = &fakeFirewall{running: true}
+	to = &fakeFirewall{geoErr: assert.AnError}
+	assert.ErrorIs(t, migrate(from, to, []uint{80}, []GeoRule{{ID: 1}}), assert.AnError)
+	assert.True(t, from.running)
+	assert.False(t, to.running)
+
+	// 新后端无法启用时恢复原后端
+	from = &fakeFirewall{running: true}
+	to = &fakeFirewall{enableErr: assert.AnError}
+	assert.ErrorIs(t, migrate(from, to, []uint{80}, nil), assert.AnError)
+	assert.True(t, from.running)
+	assert.Equal(t, 1, to.enableRuns)
+}
+
+func TestMigrateStoppedFirewall(t *testing.T) {
+	from := &fakeFirewall{rules: []FireInfo{{PortStart: 3306, PortEnd: 3306, Protocol: ProtocolTCP}}}
+	to := &fakeFirewall{}
+
+	// 原后端未运行时不启用新后端，只放行必要端口
+	assert.NoError(t, migrate(from, to, []uint{80, 443}, nil))
+	assert.False(t, to.running)
+	assert.Len(t, to.rules, 2)
+}
+
+// ===================== 地区规则测试 =====================
+
+func TestIPSetRestore(t *testing.T) {
+	content := ipsetRestore([]GeoRule{
+		{ID: 1, Networks: []netip.Prefix{netip.MustParsePrefix("1.0.1.0/24"), netip.MustParsePrefix("2001:db8::/32")}, Strategy: StrategyDrop},
+	})
+	assert.Equal(t, "create acepanel_geo_1 hash:net family inet maxelem 65536 -exist\nflush acepanel_geo_1\nadd acepanel_geo_1 1.0.1.0/24\n"+
+		"create acepanel_geo6_1 hash:net family inet6 maxelem 65536 -exist\nflush acepanel_geo6_1\nadd acepanel_geo6_1 2001:db8::/32\n", content)
+
+	// 仅允许模式为缺少网段的地址族创建空集合
+	content = ipsetRestore([]GeoRule{
+		{ID: 2, Networks: []netip.Prefix{netip.MustParsePrefix("1.0.1.0/24")}, PortStart: 22, Strategy: StrategyAccept, Only: true},
+	})
+	assert.Equal(t, "create acepanel_geo_2 hash:net family inet maxelem 65536 -exist\nflush acepanel_geo_2\nadd acepanel_geo_2 1.0.1.0/24\n"+
+		"create acepanel_geo6_2 hash:net family inet6 maxelem 65536 -exist\nflush acepanel_geo6_2\n", content)
+}
+
 // ===================== isLocalAddress 测试 =====================
 
 func TestIsLocalAddress(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strings"
@@ -182,7 +183,8 @@ func (r *firewalld) listRichRule() ([]FireInfo, error) {
 	var data []FireInfo
 	rules := strings.SplitSeq(out, "\n")
 	for rule := range rules {
-		if len(rule) == 0 {
+		// 地区规则由面板单独管理
+		if len(rule) == 0 || strings.Contains(rule, `ipset="`+geoSetPrefix) {
 			continue
 		}
 		if richRules, err := r.parseRichRule(rule); err == nil {
@@ -323,6 +325,87 @@ func (r *firewalld) UpdatePingStatus(status bool) error {
 	return err
 }
 
+func (r *firewalld) SyncGeoRules(rules []GeoRule) error {
+	rules, err := normalizeGeoRules(rules)
+	if err != nil {
+		return err
+	}
+
+	// 先移除引用旧集合的富规则，集合才能删除
+	out, err := shell.Execf("firewall-cmd --zone=public --permanent --list-rich-rules")
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, out)
+	}
+	for rule := range strings.SplitSeq(out, "\n") {
+		if strings.Contains(rule, `ipset="`+geoSetPrefix) {
+			_, _ = shell.Execf("firewall-cmd --zone=public --permanent --remove-rich-rule '%s'", strings.TrimSpace(rule))
+		}
+	}
+	out, err = shell.Execf("firewall-cmd --permanent --get-ipsets")
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, out)
+	}
+	for name := range strings.FieldsSeq(out) {
+		if strings.HasPrefix(name, geoSetPrefix) {
+			_, _ = shell.Execf("firewall-cmd --permanent --delete-ipset=%s", name)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "acepanel-geo")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	for _, rule := range rules {
+		networks := geoNetworks(rule)
+		for _, family := range geoFamilies(rule) {
+			name := geoSetName(rule.ID, family)
+			if out, err = shell.Execf("firewall-cmd --permanent --new-ipset=%s --type=hash:net --option=family=%s --option=maxelem=%d", name, ipsetFamily(family), geoSetSize(networks[family])); err != nil {
+				return fmt.Errorf("%v: %s", err, out)
+			}
+			if len(networks[family]) > 0 {
+				file := filepath.Join(dir, name)
+				if err = os.WriteFile(file, []byte(strings.Join(networks[family], "\n")+"\n"), 0600); err != nil {
+					return err
+				}
+				if out, err = shell.Execf("firewall-cmd --permanent --ipset=%s --add-entries-from-file=%s", name, file); err != nil {
+					return fmt.Errorf("%v: %s", err, out)
+				}
+			}
+			for _, protocol := range geoProtocols(rule) {
+				if out, err = shell.Execf("firewall-cmd --zone=public --permanent --add-rich-rule '%s'", r.buildGeoRuleStr(rule, family, protocol, false)); err != nil {
+					return fmt.Errorf("%v: %s", err, out)
+				}
+				// 仅允许模式丢弃集合外的来源，firewalld 中拒绝类富规则先于放行类匹配
+				if rule.Only {
+					if out, err = shell.Execf("firewall-cmd --zone=public --permanent --add-rich-rule '%s'", r.buildGeoRuleStr(rule, family, protocol, true)); err != nil {
+						return fmt.Errorf("%v: %s", err, out)
+					}
+				}
+			}
+		}
+	}
+
+	_, err = shell.Execf("firewall-cmd --reload")
+	return err
+}
+
+// buildGeoRuleStr 构建匹配地区 IP 集合的富规则字符串，negate 时生成丢弃集合外来源的规则
+func (r *firewalld) buildGeoRuleStr(rule GeoRule, family, protocol string, negate bool) string {
+	var sb strings.Builder
+	source, strategy := "source", string(rule.Strategy)
+	if negate {
+		source, strategy = "source not", string(StrategyDrop)
+	}
+	_, _ = fmt.Fprintf(&sb, `rule family="%s" %s ipset="%s" `, family, source, geoSetName(rule.ID, family))
+	if protocol != "" {
+		_, _ = fmt.Fprintf(&sb, `port port="%s" protocol="%s" `, geoPorts(rule, "-"), protocol)
+	}
+	sb.WriteString(strategy)
+	return sb.String()
+}
+
 func (r *firewalld) parseRichRule(line string) (FireInfo, error) {
 	if !r.richRuleRegex.MatchString(line) {
 		return FireInfo{}, errors.New("invalid rich rule format")
@@ -0,0 +1,131 @@
+package firewall
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// GeoRule 地区规则，网段编译为 IP 集合后按来源地址匹配
+type GeoRule struct {
+	ID        uint
+	Networks  []netip.Prefix
+	PortStart uint // 为 0 表示所有端口
+	PortEnd   uint
+	Protocol  Protocol
+	Strategy  Strategy
+	Only      bool // 仅允许集合内的来源访问端口，其余来源丢弃
+}
+
+// geoSetPrefix 面板管理的地区 IP 集合名称前缀
+const geoSetPrefix = "acepanel_geo"
+
+// geoSetName 返回规则对应的 IP 集合名称，IPv4/IPv6 分开存放
+func geoSetName(id uint, family string) string {
+	if family == "ipv6" {
+		return fmt.Sprintf("%s6_%d", geoSetPrefix, id)
+	}
+	return fmt.Sprintf("%s_%d", geoSetPrefix, id)
+}
+
+// geoNetworks 按地址族拆分规则的网段
+func geoNetworks(rule GeoRule) map[string][]string {
+	networks := make(map[string][]string)
+	for _, prefix := range rule.Networks {
+		if prefix.Addr().Is4() {
+			networks["ipv4"] = append(networks["ipv4"], prefix.String())
+		} else {
+			networks["ipv6"] = append(networks["ipv6"], prefix.String())
+		}
+	}
+	return networks
+}
+
+// geoFamilies 返回规则需要的地址族，仅允许模式下缺少网段的地址族也需要空集合来丢弃流量
+func geoFamilies(rule GeoRule) []string {
+	if rule.Only {
+		return []string{"ipv4", "ipv6"}
+	}
+	networks := geoNetworks(rule)
+	var families []string
+	for _, family := range []string{"ipv4", "ipv6"} {
+		if len(networks[family]) > 0 {
+			families = append(families, family)
+		}
+	}
+	return families
+}
+
+// geoProtocols 返回规则需要匹配的协议，所有端口时不区分协议
+func geoProtocols(rule GeoRule) []string {
+	if rule.PortStart == 0 {
+		return []string{""}
+	}
+	if rule.Protocol == "" {
+		return buildProtocols(ProtocolTCPUDP)
+	}
+	return buildProtocols(rule.Protocol)
+}
+
+// normalizeGeoRules 检查规则并补全端口范围
+func normalizeGeoRules(rules []GeoRule) ([]GeoRule, error) {
+	normalized := make([]GeoRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Only {
+			// 仅允许模式必须限定端口，否则会丢弃集合外的全部流量
+			if rule.PortStart == 0 {
+				return nil, fmt.Errorf("geo rule %d in only mode requires a port", rule.ID)
+			}
+			rule.Strategy = StrategyAccept
+		}
+		if rule.Strategy != StrategyAccept && rule.Strategy != StrategyDrop && rule.Strategy != StrategyReject {
+			return nil, fmt.Errorf("unsupported geo rule strategy: %s", rule.Strategy)
+		}
+		if rule.PortEnd == 0 {
+			rule.PortEnd = rule.PortStart
+		}
+		if rule.PortStart > rule.PortEnd || rule.PortEnd > 65535 {
+			return nil, fmt.Errorf("invalid port range: %d-%d", rule.PortStart, rule.PortEnd)
+		}
+		normalized = append(normalized, rule)
+	}
+	return normalized, nil
+}
+
+// geoPorts 返回端口或端口范围，sep 为范围分隔符
+func geoPorts(rule GeoRule, sep string) string {
+	if rule.PortStart == rule.PortEnd {
+		return fmt.Sprintf("%d", rule.PortStart)
+	}
+	return fmt.Sprintf("%d%s%d", rule.PortStart, sep, rule.PortEnd)
+}
+
+// ipsetRestore 生成 ipset restore 脚本，集合已存在时清空后重新填充
+func ipsetRestore(rules []GeoRule) string {
+	var sb strings.Builder
+	for _, rule := range rules {
+		networks := geoNetworks(rule)
+		for _, family := range geoFamilies(rule) {
+			name := geoSetName(rule.ID, family)
+			_, _ = fmt.Fprintf(&sb, "create %s hash:net family %s maxelem %d -exist\n", name, ipsetFamily(family), geoSetSize(networks[family]))
+			_, _ = fmt.Fprintf(&sb, "flush %s\n", name)
+			for _, network := range networks[family] {
+				_, _ = fmt.Fprintf(&sb, "add %s %s\n", name, network)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// ipsetFamily 返回 ipset 使用的地址族名称
+func ipsetFamily(family string) string {
+	if family == "ipv6" {
+		return "inet6"
+	}
+	return "inet"
+}
+
+// geoSetSize 返回集合容量，至少为 ipset 默认值
+func geoSetSize(networks []string) int {
+	return max(65536, len(networks)*2)
+}
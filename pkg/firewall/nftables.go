@@ -0,0 +1,715 @@
+package firewall
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/v3/pkg/shell"
+	"github.com/acepanel/panel/v3/pkg/systemctl"
+)
+
+const (
+	nftTable       = "inet acepanel"
+	nftRulesPath   = "/etc/acepanel/nftables.nft"
+	nftServiceName = "acepanel-nftables"
+	nftServicePath = "/etc/systemd/system/acepanel-nftables.service"
+	nftLocalStart  = "/etc/local.d/acepanel-nftables.start" // OpenRC local 服务
+)
+
+// nftables 直接管理 inet acepanel 表，规则文件是唯一数据源
+// 每次变更都重新生成整张表并用 nft -f 原子替换，不影响系统中的其他表
+type nftables struct {
+	path      string
+	chainRe   *regexp.Regexp
+	ruleRe    *regexp.Regexp
+	forwardRe *regexp.Regexp
+	pingRe    *regexp.Regexp
+	setRe     *regexp.Regexp
+	elementRe *regexp.Regexp
+	geoRe     *regexp.Regexp
+}
+
+// nftRuleset 规则文件的内容，规则按单一协议存储，列出时再合并
+type nftRuleset struct {
+	rules    []FireInfo
+	forwards []FireForwardInfo
+	geo      []GeoRule
+	ping     bool
+}
+
+func newNftables() *nftables {
+	return &nftables{
+		path:    nftRulesPath,
+		chainRe: regexp.MustCompile(`^chain (\w+) \{$`),
+		// ip saddr 192.168.1.0/24 tcp dport 80-90 accept
+		// meta nfproto ipv6 meta l4proto udp drop
+		ruleRe: regexp.MustCompile(`^(?:(ip6?) (saddr|daddr) (\S+) |meta nfproto (ipv4|ipv6) )?(?:(tcp|udp) dport (\d+)(?:-(\d+))?|meta l4proto (tcp|udp)) (accept|drop|reject)$`),
+		// meta l4proto tcp th dport 8080 dnat ip to 10.0.0.2:80
+		// meta l4proto tcp th dport 8080 redirect to :80
+		forwardRe: regexp.MustCompile(`^meta l4proto (tcp|udp) th dport (\d+) (?:dnat ip6? to \[?([^\]]+?)\]?:(\d+)|redirect to :(\d+))$`),
+		pingRe:    regexp.MustCompile(`^icmp type echo-request (accept|drop)$`),
+		setRe:     regexp.MustCompile(`^set ` + geoSetPrefix + `6?_(\d+) \{$`),
+		elementRe: regexp.MustCompile(`^elements = \{ (.+) \}$`),
+		// ip saddr @acepanel_geo_1 tcp dport 22 accept
+		// ip saddr != @acepanel_geo_1 tcp dport 22 drop
+		geoRe: regexp.MustCompile(`^ip6? saddr (!= )?@` + geoSetPrefix + `6?_(\d+) (?:(tcp|udp) dport (\d+)(?:-(\d+))? )?(accept|drop|reject)$`),
+	}
+}
+
+func (r *nftables) Status() (bool, error) {
+	if _, err := shell.Execf("nft list table %s", nftTable); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (r *nftables) Enable() error {
+	ruleset, err := r.load()
+	if err != nil {
+		return err
+	}
+	if err = r.write(ruleset); err != nil {
+		return err
+	}
+	if _, err = shell.Execf("nft -f '%s'", r.path); err != nil {
+		return err
+	}
+
+	// 开机加载，systemd 使用独立服务，OpenRC 使用 local 服务
+	if _, err = shell.Execf("systemctl --version"); err == nil {
+		unit := fmt.Sprintf(`[Unit]
+Description=AcePanel nftables firewall
+Wants=network-pre.target
+Before=network-pre.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/usr/sbin/nft -f %s
+ExecReload=/usr/sbin/nft -f %s
+ExecStop=/usr/sbin/nft delete table %s
+
+[Install]
+WantedBy=multi-user.target
+`, r.path, r.path, nftTable)
+		if err = os.WriteFile(nftServicePath, []byte(unit), 0644); err != nil {
+			return err
+		}
+		if err = systemctl.DaemonReload(); err != nil {
+			return err
+		}
+		if err = systemctl.Enable(nftServiceName); err != nil {
+			return err
+		}
+		return systemctl.Start(nftServiceName)
+	}
+	if info, statErr := os.Stat(filepath.Dir(nftLocalStart)); statErr == nil && info.IsDir() {
+		return os.WriteFile(nftLocalStart, []byte(fmt.Sprintf("#!/bin/sh\nnft -f %s\n", r.path)), 0755)
+	}
+
+	return nil
+}
+
+func (r *nftables) Disable() error {
+	if _, err := os.Stat(nftServicePath); err == nil {
+		_ = systemctl.Disable(nftServiceName)
+		_ = systemctl.Stop(nftServiceName)
+	}
+	if err := os.Remove(nftLocalStart); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if running, _ := r.Status(); running {
+		if _, err := shell.Execf("nft delete table %s", nftTable); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *nftables) ListRule() ([]FireInfo, error) {
+	ruleset, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	data := slices.Clone(ruleset.rules)
+	slices.SortStableFunc(data, func(a FireInfo, b FireInfo) int {
+		if a.PortStart != b.PortStart {
+			return cmp.Compare(a.PortStart, b.PortStart)
+		}
+		if a.PortEnd != b.PortEnd {
+			return cmp.Compare(a.PortEnd, b.PortEnd)
+		}
+		return strings.Compare(string(a.Protocol), string(b.Protocol))
+	})
+
+	return mergeRules(data), nil
+}
+
+func (r *nftables) Port(rule FireInfo, operation Operation) error {
+	if rule.PortEnd == 0 {
+		rule.PortEnd = rule.PortStart
+	}
+	if rule.PortStart > rule.PortEnd {
+		return fmt.Errorf("invalid port range: %d-%d", rule.PortStart, rule.PortEnd)
+	}
+	// 普通端口规则只支持 IPv4/IPv6 通用、入站、放行，其余使用富规则
+	if (rule.Family != "" && rule.Family != "ipv4") || rule.Direction == DirectionOut || rule.Address != "" || (rule.Strategy != "" && rule.Strategy != StrategyAccept) || rule.Type == TypeRich {
+		return r.RichRules(rule, operation)
+	}
+
+	rule.Type = TypeNormal
+	rule.Family = "ipv4"
+	rule.Strategy = StrategyAccept
+	rule.Direction = DirectionIn
+	return r.update(rule, operation)
+}
+
+func (r *nftables) RichRules(rule FireInfo, operation Operation) error {
+	// 出站规则下，必须指定具体的地址
+	if rule.Direction == DirectionOut && rule.Address == "" {
+		return errors.New("outbound rules must specify an address")
+	}
+	if rule.Strategy == StrategyMark {
+		return errors.New("nftables backend does not support mark strategy")
+	}
+
+	rule.Type = TypeRich
+	if rule.Family == "" {
+		rule.Family = "ipv4"
+	}
+	if strings.Contains(rule.Address, ":") {
+		rule.Family = "ipv6"
+	}
+	if rule.Direction == "" {
+		rule.Direction = DirectionIn
+	}
+	if rule.Strategy == "" {
+		rule.Strategy = StrategyAccept
+	}
+	// 未指定端口表示所有端口
+	if rule.PortStart == 0 && rule.PortEnd == 0 {
+		rule.PortStart, rule.PortEnd = 1, 65535
+	}
+	if rule.PortEnd == 0 {
+		rule.PortEnd = rule.PortStart
+	}
+
+	return r.update(rule, operation)
+}
+
+// update 按协议拆分后增删规则并应用
+func (r *nftables) update(rule FireInfo, operation Operation) error {
+	if rule.Protocol == "" {
+		rule.Protocol = ProtocolTCPUDP
+	}
+
+	ruleset, err := r.load()
+	if err != nil {
+		return err
+	}
+	for _, protocol := range buildProtocols(rule.Protocol) {
+		item := rule
+		item.Protocol = Protocol(protocol)
+		exists := slices.Contains(ruleset.rules, item)
+		switch {
+		case operation == OperationAdd && !exists:
+			ruleset.rules = append(ruleset.rules, item)
+		case operation == OperationRemove && exists:
+			ruleset.rules = slices.DeleteFunc(ruleset.rules, func(existing FireInfo) bool { return existing == item })
+		}
+	}
+
+	return r.save(ruleset)
+}
+
+func (r *nftables) ListForward() ([]FireForwardInfo, error) {
+	ruleset, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	data := slices.Clone(ruleset.forwards)
+	slices.SortFunc(data, func(a FireForwardInfo, b FireForwardInfo) int {
+		if a.Port != b.Port {
+			return cmp.Compare(a.Port, b.Port)
+		}
+		if a.TargetPort != b.TargetPort {
+			return cmp.Compare(a.TargetPort, b.TargetPort)
+		}
+		if a.Protocol != b.Protocol {
+			return strings.Compare(string(a.Protocol), string(b.Protocol))
+		}
+		return strings.Compare(a.TargetIP, b.TargetIP)
+	})
+
+	return data, nil
+}
+
+func (r *nftables) Forward(rule Forward, operation Operation) error {
+	if operation == OperationAdd {
+		// 启用 IP 转发
+		_, _ = shell.Execf("sysctl -w net.ipv4.ip_forward=1")
+		_, _ = shell.Execf("sysctl -w net.ipv6.conf.all.forwarding=1")
+		_ = os.WriteFile("/etc/sysctl.d/99-acepanel-forward.conf", []byte("net.ipv4.ip_forward=1\nnet.ipv6.conf.all.forwarding=1\n"), 0644)
+	}
+
+	ruleset, err := r.load()
+	if err != nil {
+		return err
+	}
+	targetIP := rule.TargetIP
+	if targetIP == "" || isLocalAddress(targetIP) {
+		targetIP = "127.0.0.1"
+	}
+	for _, protocol := range buildProtocols(rule.Protocol) {
+		item := FireForwardInfo{Port: rule.Port, Protocol: Protocol(protocol), TargetIP: targetIP, TargetPort: rule.TargetPort}
+		exists := slices.Contains(ruleset.forwards, item)
+		switch {
+		case operation == OperationAdd && !exists:
+			ruleset.forwards = append(ruleset.forwards, item)
+		case operation == OperationRemove && exists:
+			ruleset.forwards = slices.DeleteFunc(ruleset.forwards, func(existing FireForwardInfo) bool { return existing == item })
+		}
+	}
+
+	return r.save(ruleset)
+}
+
+func (r *nftables) PingStatus() (bool, error) {
+	ruleset, err := r.load()
+	if err != nil {
+		return true, nil
+	}
+	return ruleset.ping, nil
+}
+
+func (r *nftables) UpdatePingStatus(status bool) error {
+	ruleset, err := r.load()
+	if err != nil {
+		return err
+	}
+	ruleset.ping = status
+	return r.save(ruleset)
+}
+
+func (r *nftables) SyncGeoRules(rules []GeoRule) error {
+	rules, err := normalizeGeoRules(rules)
+	if err != nil {
+		return err
+	}
+
+	ruleset, err := r.load()
+	if err != nil {
+		return err
+	}
+	ruleset.geo = rules
+	return r.save(ruleset)
+}
+
+// load 读取规则文件，不存在时返回仅放行 SSH 的默认规则，避免启用后失联
+func (r *nftables) load() (*nftRuleset, error) {
+	content, err := os.ReadFile(r.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r.defaultRuleset(), nil
+		}
+		return nil, err
+	}
+
+	return r.parse(string(content)), nil
+}
+
+func (r *nftables) defaultRuleset() *nftRuleset {
+	port := sshPort()
+	return &nftRuleset{
+		rules: []FireInfo{{
+			Type:      TypeNormal,
+			Family:    "ipv4",
+			PortStart: port,
+			PortEnd:   port,
+			Protocol:  ProtocolTCP,
+			Strategy:  StrategyAccept,
+			Direction: DirectionIn,
+		}},
+		ping: true,
+	}
+}
+
+// sshPort 读取 sshd 监听端口，默认 22
+func sshPort() uint {
+	content, err := os.ReadFile("/etc/ssh/sshd_config")
+	if err != nil {
+		return 22
+	}
+	for line := range strings.SplitSeq(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.EqualFold(fields[0], "Port") {
+			if port := cast.ToUint(fields[1]); port > 0 && port <= 65535 {
+				return port
+			}
+		}
+	}
+	return 22
+}
+
+// parse 解析 render 生成的规则文件
+func (r *nftables) parse(content string) *nftRuleset {
+	ruleset := &nftRuleset{ping: true}
+	geo := make(map[uint]*GeoRule)
+	var geoOrder []uint
+	geoRule := func(id uint) *GeoRule {
+		if _, ok := geo[id]; !ok {
+			geo[id] = &GeoRule{ID: id}
+			geoOrder = append(geoOrder, id)
+		}
+		return geo[id]
+	}
+
+	chain := ""
+	var set *GeoRule
+	for line := range strings.SplitSeq(content, "\n") {
+		line = strings.TrimSpace(line)
+		if match := r.chainRe.FindStringSubmatch(line); match != nil {
+			chain = match[1]
+			continue
+		}
+		if match := r.setRe.FindStringSubmatch(line); match != nil {
+			set = geoRule(cast.ToUint(match[1]))
+			continue
+		}
+		if line == "}" {
+			chain, set = "", nil
+			continue
+		}
+
+		if set != nil {
+			if match := r.elementRe.FindStringSubmatch(line); match != nil {
+				for element := range strings.SplitSeq(match[1], ",") {
+					if prefix, err := parsePrefix(strings.TrimSpace(element)); err == nil {
+						set.Networks = append(set.Networks, prefix)
+					}
+				}
+			}
+			continue
+		}
+
+		switch chain {
+		case "input", "output":
+			if match := r.pingRe.FindStringSubmatch(line); match != nil && chain == "input" {
+				ruleset.ping = match[1] == "accept"
+				continue
+			}
+			if match := r.geoRe.FindStringSubmatch(line); match != nil && chain == "input" {
+				rule := geoRule(cast.ToUint(match[2]))
+				// 取反匹配的丢弃规则属于仅允许模式，策略以放行规则为准
+				if match[1] != "" {
+					rule.Only = true
+				} else {
+					rule.Strategy = Strategy(match[6])
+				}
+				if match[3] != "" {
+					rule.PortStart = cast.ToUint(match[4])
+					rule.PortEnd = rule.PortStart
+					if match[5] != "" {
+						rule.PortEnd = cast.ToUint(match[5])
+					}
+					if rule.Protocol != "" && rule.Protocol != Protocol(match[3]) {
+						rule.Protocol = ProtocolTCPUDP
+					} else {
+						rule.Protocol = Protocol(match[3])
+					}
+				}
+				continue
+			}
+			if info := r.parseRule(line, chain); info != nil {
+				ruleset.rules = append(ruleset.rules, *info)
+			}
+		case "prerouting":
+			match := r.forwardRe.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			info := FireForwardInfo{
+				Protocol: Protocol(match[1]),
+				Port:     cast.ToUint(match[2]),
+				TargetIP: match[3],
+			}
+			if match[5] != "" {
+				info.TargetIP = "127.0.0.1"
+				info.TargetPort = cast.ToUint(match[5])
+			} else {
+				info.TargetPort = cast.ToUint(match[4])
+			}
+			ruleset.forwards = append(ruleset.forwards, info)
+		}
+	}
+
+	for _, id := range geoOrder {
+		ruleset.geo = append(ruleset.geo, *geo[id])
+	}
+
+	return ruleset
+}
+
+// parsePrefix 解析网段或单个地址
+func parsePrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		return netip.ParsePrefix(s)
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// parseRule 解析 input/output 链中的单条规则
+func (r *nftables) parseRule(line, chain string) *FireInfo {
+	match := r.ruleRe.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	info := &FireInfo{
+		Type:      TypeNormal,
+		Family:    "ipv4",
+		Strategy:  Strategy(match[9]),
+		Direction: DirectionIn,
+	}
+	if chain == "output" {
+		info.Direction = DirectionOut
+	}
+	switch {
+	case match[1] != "":
+		info.Type = TypeRich
+		info.Address = match[3]
+		if match[1] == "ip6" {
+			info.Family = "ipv6"
+		}
+	case match[4] != "":
+		info.Type = TypeRich
+		info.Family = match[4]
+	}
+	if match[5] != "" {
+		info.Protocol = Protocol(match[5])
+		info.PortStart = cast.ToUint(match[6])
+		info.PortEnd = info.PortStart
+		if match[7] != "" {
+			info.PortEnd = cast.ToUint(match[7])
+		}
+	} else {
+		info.Protocol = Protocol(match[8])
+		info.PortStart, info.PortEnd = 1, 65535
+	}
+
+	return info
+}
+
+// render 生成规则文件，先声明再删除表，使整张表在一个事务内替换
+func (r *nftables) render(ruleset *nftRuleset) string {
+	var sb strings.Builder
+	sb.WriteString("#!/usr/sbin/nft -f\n# Managed by AcePanel, do not edit\n\n")
+	_, _ = fmt.Fprintf(&sb, "table %s\ndelete table %s\n\ntable %s {\n", nftTable, nftTable, nftTable)
+
+	// 地区规则的 IP 集合需在链之前声明
+	for _, rule := range ruleset.geo {
+		networks := geoNetworks(rule)
+		for _, family := range geoFamilies(rule) {
+			typ := "ipv4_addr"
+			if family == "ipv6" {
+				typ = "ipv6_addr"
+			}
+			_, _ = fmt.Fprintf(&sb, "\tset %s {\n\t\ttype %s\n\t\tflags interval\n\t\tauto-merge\n", geoSetName(rule.ID, family), typ)
+			if len(networks[family]) > 0 {
+				_, _ = fmt.Fprintf(&sb, "\t\telements = { %s }\n", strings.Join(networks[family], ", "))
+			}
+			sb.WriteString("\t}\n\n")
+		}
+	}
+
+	// 拒绝类规则优先于放行规则匹配
+	rules := slices.Clone(ruleset.rules)
+	slices.SortStableFunc(rules, func(a FireInfo, b FireInfo) int {
+		return cmp.Compare(strategyOrder(a.Strategy), strategyOrder(b.Strategy))
+	})
+
+	ping := "accept"
+	if !ruleset.ping {
+		ping = "drop"
+	}
+	sb.WriteString("\tchain input {\n")
+	sb.WriteString("\t\ttype filter hook input priority filter; policy drop;\n")
+	sb.WriteString("\t\tct state established,related accept\n")
+	sb.WriteString("\t\tct state invalid drop\n")
+	sb.WriteString("\t\tiif \"lo\" accept\n")
+	sb.WriteString("\t\tct status dnat accept\n")
+	_, _ = fmt.Fprintf(&sb, "\t\ticmp type echo-request %s\n", ping)
+	_, _ = fmt.Fprintf(&sb, "\t\ticmpv6 type echo-request %s\n", ping)
+	sb.WriteString("\t\tmeta l4proto { icmp, ipv6-icmp } accept\n")
+	for _, accept := range []bool{false, true} {
+		for _, rule := range ruleset.geo {
+			if !rule.Only && (rule.Strategy == StrategyAccept) == accept {
+				for _, line := range r.renderGeoRule(rule) {
+					_, _ = fmt.Fprintf(&sb, "\t\t%s\n", line)
+				}
+			}
+		}
+		for _, rule := range rules {
+			if rule.Direction != DirectionOut && (rule.Strategy == StrategyAccept) == accept {
+				_, _ = fmt.Fprintf(&sb, "\t\t%s\n", r.renderRule(rule))
+			}
+		}
+		// 仅允许模式的丢弃规则需在普通放行规则之前生效
+		if !accept {
+			for _, rule := range ruleset.geo {
+				if rule.Only {
+					for _, line := range r.renderGeoRule(rule) {
+						_, _ = fmt.Fprintf(&sb, "\t\t%s\n", line)
+					}
+				}
+			}
+		}
+	}
+	sb.WriteString("\t}\n\n")
+
+	sb.WriteString("\tchain output {\n")
+	sb.WriteString("\t\ttype filter hook output priority filter; policy accept;\n")
+	for _, rule := range rules {
+		if rule.Direction == DirectionOut {
+			_, _ = fmt.Fprintf(&sb, "\t\t%s\n", r.renderRule(rule))
+		}
+	}
+	sb.WriteString("\t}\n\n")
+
+	sb.WriteString("\tchain prerouting {\n")
+	sb.WriteString("\t\ttype nat hook prerouting priority dstnat; policy accept;\n")
+	for _, forward := range ruleset.forwards {
+		if isLocalAddress(forward.TargetIP) {
+			_, _ = fmt.Fprintf(&sb, "\t\tmeta l4proto %s th dport %d redirect to :%d\n", forward.Protocol, forward.Port, forward.TargetPort)
+		} else if strings.Contains(forward.TargetIP, ":") {
+			_, _ = fmt.Fprintf(&sb, "\t\tmeta l4proto %s th dport %d dnat ip6 to [%s]:%d\n", forward.Protocol, forward.Port, forward.TargetIP, forward.TargetPort)
+		} else {
+			_, _ = fmt.Fprintf(&sb, "\t\tmeta l4proto %s th dport %d dnat ip to %s:%d\n", forward.Protocol, forward.Port, forward.TargetIP, forward.TargetPort)
+		}
+	}
+	sb.WriteString("\t}\n\n")
+
+	sb.WriteString("\tchain postrouting {\n")
+	sb.WriteString("\t\ttype nat hook postrouting priority srcnat; policy accept;\n")
+	for _, forward := range ruleset.forwards {
+		if isLocalAddress(forward.TargetIP) {
+			continue
+		}
+		family := "ip"
+		if strings.Contains(forward.TargetIP, ":") {
+			family = "ip6"
+		}
+		_, _ = fmt.Fprintf(&sb, "\t\t%s daddr %s meta l4proto %s th dport %d masquerade\n", family, forward.TargetIP, forward.Protocol, forward.TargetPort)
+	}
+	sb.WriteString("\t}\n}\n")
+
+	return sb.String()
+}
+
+// renderRule 生成单条过滤规则，协议已按单一协议拆分
+func (r *nftables) renderRule(rule FireInfo) string {
+	var parts []string
+	switch {
+	case rule.Address != "":
+		family, field := "ip", "saddr"
+		if rule.Family == "ipv6" {
+			family = "ip6"
+		}
+		if rule.Direction == DirectionOut {
+			field = "daddr"
+		}
+		parts = append(parts, family, field, rule.Address)
+	case rule.Type == TypeRich:
+		parts = append(parts, "meta nfproto", rule.Family)
+	}
+
+	if rule.PortStart == 1 && rule.PortEnd == 65535 {
+		parts = append(parts, "meta l4proto", string(rule.Protocol))
+	} else if rule.PortStart == rule.PortEnd {
+		parts = append(parts, string(rule.Protocol), "dport", strconv.FormatUint(uint64(rule.PortStart), 10))
+	} else {
+		parts = append(parts, string(rule.Protocol), "dport", fmt.Sprintf("%d-%d", rule.PortStart, rule.PortEnd))
+	}
+	parts = append(parts, string(rule.Strategy))
+
+	return strings.Join(parts, " ")
+}
+
+// renderGeoRule 生成地区规则，按地址族和协议拆分，仅允许模式先放行集合内来源再丢弃其余来源
+func (r *nftables) renderGeoRule(rule GeoRule) []string {
+	var lines []string
+	for _, family := range geoFamilies(rule) {
+		match := "ip"
+		if family == "ipv6" {
+			match = "ip6"
+		}
+		set := "@" + geoSetName(rule.ID, family)
+		var drops []string
+		for _, protocol := range geoProtocols(rule) {
+			var port []string
+			if protocol != "" {
+				port = []string{protocol, "dport", geoPorts(rule, "-")}
+			}
+			lines = append(lines, strings.Join(slices.Concat([]string{match, "saddr", set}, port, []string{string(rule.Strategy)}), " "))
+			if rule.Only {
+				drops = append(drops, strings.Join(slices.Concat([]string{match, "saddr", "!=", set}, port, []string{"drop"}), " "))
+			}
+		}
+		lines = append(lines, drops...)
+	}
+	return lines
+}
+
+func strategyOrder(strategy Strategy) int {
+	if strategy == StrategyAccept {
+		return 1
+	}
+	return 0
+}
+
+// write 写入规则文件
+func (r *nftables) write(ruleset *nftRuleset) error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, []byte(r.render(ruleset)), 0600)
+}
+
+// save 写入规则文件，防火墙运行中时原子替换规则表，失败则回滚文件
+func (r *nftables) save(ruleset *nftRuleset) error {
+	previous, readErr := os.ReadFile(r.path)
+	if err := r.write(ruleset); err != nil {
+		return err
+	}
+	if running, _ := r.Status(); !running {
+		return nil
+	}
+
+	if out, err := shell.Execf("nft -f '%s'", r.path); err != nil {
+		if readErr == nil {
+			_ = os.WriteFile(r.path, previous, 0600)
+		} else {
+			_ = os.Remove(r.path)
+		}
+		return fmt.Errorf("%v: %s", err, out)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
@@ -13,6 +14,7 @@ import (
 	"github.com/spf13/cast"
 
 	"github.com/acepanel/panel/v3/pkg/shell"
+	"github.com/acepanel/panel/v3/pkg/systemctl"
 )
 
 type ufw struct {
@@ -605,3 +607,152 @@ func (r *ufw) UpdatePingStatus(status bool) error {
 	_, err = shell.Execf("ufw reload")
 	return err
 }
+
+const (
+	before6RulesPath  = "/etc/ufw/before6.rules"
+	ufwGeoSetPath     = "/etc/acepanel/geo.ipset"
+	ufwGeoServiceName = "acepanel-ipset"
+	ufwGeoServicePath = "/etc/systemd/system/acepanel-ipset.service"
+	geoMarkerBegin    = "# BEGIN acepanel-geo"
+	geoMarkerEnd      = "# END acepanel-geo"
+)
+
+// SyncGeoRules ufw 不支持 IP 集合，集合由 ipset 管理并在 ufw 启动前恢复，规则写入 before.rules
+func (r *ufw) SyncGeoRules(rules []GeoRule) error {
+	rules, err := normalizeGeoRules(rules)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(ufwGeoSetPath), 0755); err != nil {
+		return err
+	}
+	if err = os.WriteFile(ufwGeoSetPath, []byte(ipsetRestore(rules)), 0600); err != nil {
+		return err
+	}
+	if out, err := shell.Execf("ipset restore -exist -file '%s'", ufwGeoSetPath); err != nil {
+		return fmt.Errorf("%v: %s", err, out)
+	}
+
+	// 开机时在 ufw 加载规则前恢复集合，否则 before.rules 会加载失败
+	unit := fmt.Sprintf(`[Unit]
+Description=AcePanel geo ipsets
+Before=ufw.service
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/bin/sh -c 'ipset restore -exist -file %s'
+
+[Install]
+WantedBy=multi-user.target
+`, ufwGeoSetPath)
+	if err = os.WriteFile(ufwGeoServicePath, []byte(unit), 0644); err != nil {
+		return err
+	}
+	if err = systemctl.DaemonReload(); err != nil {
+		return err
+	}
+	if err = systemctl.Enable(ufwGeoServiceName); err != nil {
+		return err
+	}
+
+	for _, family := range []string{"ipv4", "ipv6"} {
+		path, chain := beforeRulesPath, "ufw-before-input"
+		if family == "ipv6" {
+			path, chain = before6RulesPath, "ufw6-before-input"
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", path, err)
+		}
+		text, err := r.replaceGeoBlock(string(content), r.buildGeoRules(rules, family, chain))
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		if err = os.WriteFile(path, []byte(text), 0644); err != nil {
+			return err
+		}
+	}
+
+	if _, err = shell.Execf("ufw reload"); err != nil {
+		return err
+	}
+
+	// 规则不再引用后销毁多余的集合
+	used := make(map[string]bool)
+	for _, rule := range rules {
+		for _, family := range geoFamilies(rule) {
+			used[geoSetName(rule.ID, family)] = true
+		}
+	}
+	if out, err := shell.Execf("ipset list -n"); err == nil {
+		for name := range strings.FieldsSeq(out) {
+			if strings.HasPrefix(name, geoSetPrefix) && !used[name] {
+				_, _ = shell.Execf("ipset destroy %s", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// buildGeoRules 生成指定地址族的 iptables 规则，仅允许模式先放行集合内来源再丢弃其余来源
+func (r *ufw) buildGeoRules(rules []GeoRule, family, chain string) []string {
+	var lines []string
+	for _, rule := range rules {
+		if !slices.Contains(geoFamilies(rule), family) {
+			continue
+		}
+		target := strings.ToUpper(string(rule.Strategy))
+		name := geoSetName(rule.ID, family)
+		for _, protocol := range geoProtocols(rule) {
+			port := ""
+			if protocol != "" {
+				port = fmt.Sprintf(" -p %s --dport %s", protocol, geoPorts(rule, ":"))
+			}
+			lines = append(lines, fmt.Sprintf("-A %s -m set --match-set %s src%s -j %s", chain, name, port, target))
+			if rule.Only {
+				lines = append(lines, fmt.Sprintf("-A %s -m set ! --match-set %s src%s -j DROP", chain, name, port))
+			}
+		}
+	}
+	return lines
+}
+
+// replaceGeoBlock 替换 *filter 段中的地区规则块，块放在 COMMIT 之前
+func (r *ufw) replaceGeoBlock(text string, rules []string) (string, error) {
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines)+len(rules)+2)
+	inBlock := false
+	for _, line := range lines {
+		switch strings.TrimSpace(line) {
+		case geoMarkerBegin:
+			inBlock = true
+			continue
+		case geoMarkerEnd:
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			result = append(result, line)
+		}
+	}
+	if len(rules) == 0 {
+		return strings.Join(result, "\n"), nil
+	}
+
+	filter := slices.IndexFunc(result, func(line string) bool { return strings.TrimSpace(line) == "*filter" })
+	if filter == -1 {
+		return "", errors.New("missing *filter section")
+	}
+	commit := slices.IndexFunc(result[filter:], func(line string) bool { return strings.TrimSpace(line) == "COMMIT" })
+	if commit == -1 {
+		return "", errors.New("*filter section without COMMIT")
+	}
+	commit += filter
+
+	block := append([]string{geoMarkerBegin}, rules...)
+	block = append(block, geoMarkerEnd)
+	return strings.Join(slices.Insert(result, commit, block...), "\n"), nil
+}
@@ -1,7 +1,11 @@
 package geoip
 
 import (
+	"cmp"
 	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
 
 	"github.com/acepanel/panel/v3/pkg/ipdb"
 )
@@ -106,3 +110,57 @@ func (g *GeoIP) Lookup(ip string) GeoResult {
 	}
 	return r
 }
+
+// Networks 返回国家/地区代码或名称与 country 匹配的全部网段，相邻网段已合并
+func (g *GeoIP) Networks(country string) ([]netip.Prefix, error) {
+	if g == nil || g.db == nil {
+		return nil, ipdb.ErrClosed
+	}
+
+	var prefixes []netip.Prefix
+	err := g.db.Networks("CN", func(prefix netip.Prefix, fields []string) bool {
+		if g.idxCountryCode >= 0 && strings.EqualFold(fields[g.idxCountryCode], country) {
+			prefixes = append(prefixes, prefix)
+		} else if g.idxCountry >= 0 && strings.EqualFold(fields[g.idxCountry], country) {
+			prefixes = append(prefixes, prefix)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergePrefixes(prefixes), nil
+}
+
+// mergePrefixes 去除被包含的网段并合并相邻的兄弟网段
+func mergePrefixes(prefixes []netip.Prefix) []netip.Prefix {
+	slices.SortFunc(prefixes, func(a, b netip.Prefix) int {
+		if c := a.Addr().Compare(b.Addr()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Bits(), b.Bits())
+	})
+
+	merged := make([]netip.Prefix, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		prefix = prefix.Masked()
+		if n := len(merged); n > 0 && merged[n-1].Overlaps(prefix) {
+			continue
+		}
+		merged = append(merged, prefix)
+		for n := len(merged); n >= 2; n = len(merged) {
+			a, b := merged[n-2], merged[n-1]
+			if a.Bits() != b.Bits() || a.Bits() == 0 || a.Addr().Is4() != b.Addr().Is4() {
+				break
+			}
+			parent, _ := a.Addr().Prefix(a.Bits() - 1)
+			if other, _ := b.Addr().Prefix(b.Bits() - 1); other != parent {
+				break
+			}
+			merged = append(merged[:n-2], parent)
+		}
+	}
+
+	return merged
+}
@@ -1,8 +1,11 @@
 package geoip
 
 import (
+	"net/netip"
+	"slices"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -71,3 +74,47 @@ func (s *GeoIPSuite) TestLookup_IPv6() {
 	r := s.g.Lookup("2001:4860:4860::8888")
 	s.T().Logf("2001:4860:4860::8888 -> %s(%s) %s %s ISP=%s", r.Country, r.CountryCode, r.Region, r.City, r.ISP)
 }
+
+// ========== Networks ==========
+
+func (s *GeoIPSuite) TestNetworks() {
+	r := s.g.Lookup("1.0.1.1")
+	s.Require().NotEmpty(r.Country)
+
+	prefixes, err := s.g.Networks(r.Country)
+	s.NoError(err)
+	s.NotEmpty(prefixes)
+	s.True(slices.ContainsFunc(prefixes, func(prefix netip.Prefix) bool {
+		return prefix.Contains(netip.MustParseAddr("1.0.1.1"))
+	}))
+	s.T().Logf("%s -> %d networks", r.Country, len(prefixes))
+}
+
+func (s *GeoIPSuite) TestNetworks_Unknown() {
+	prefixes, err := s.g.Networks("not-a-country")
+	s.NoError(err)
+	s.Empty(prefixes)
+}
+
+func (s *GeoIPSuite) TestNetworks_NilReceiver() {
+	var g *GeoIP
+	_, err := g.Networks("CN")
+	s.Error(err)
+}
+
+func TestMergePrefixes(t *testing.T) {
+	merged := mergePrefixes([]netip.Prefix{
+		netip.MustParsePrefix("10.0.1.0/24"),
+		netip.MustParsePrefix("10.0.0.0/24"),
+		netip.MustParsePrefix("10.0.2.0/23"),
+		netip.MustParsePrefix("10.0.3.128/25"),
+		netip.MustParsePrefix("192.168.1.0/24"),
+		netip.MustParsePrefix("2001:db8::/33"),
+		netip.MustParsePrefix("2001:db8:8000::/33"),
+	})
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/22"),
+		netip.MustParsePrefix("192.168.1.0/24"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}, merged)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/netip"
+	"slices"
 	"strings"
 	"sync"
 )
@@ -206,6 +207,81 @@ func (r *Reader) Find(ip, language string) ([]string, error) {
 	return result, nil
 }
 
+// Networks 遍历数据库中全部网段及其记录，fn 返回 false 时停止遍历
+// 指向同一记录的网段共享 fields 切片，调用方不应修改
+func (r *Reader) Networks(language string, fn func(prefix netip.Prefix, fields []string) bool) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return ErrClosed
+	}
+
+	langOff, ok := r.meta.Languages[language]
+	if !ok {
+		return ErrNoLanguage
+	}
+
+	records := make(map[int][]string)
+	var walkErr error
+	var walk func(node, depth int, ip []byte) bool
+	walk = func(node, depth int, ip []byte) bool {
+		if node == r.nodeCount {
+			return true
+		}
+		if node > r.nodeCount {
+			fields, ok := records[node]
+			if !ok {
+				record, err := r.resolve(node)
+				if err != nil {
+					walkErr = err
+					return false
+				}
+				all := strings.Split(record, "\t")
+				start := langOff * r.fieldLen
+				if start+r.fieldLen > len(all) {
+					walkErr = ErrInvalidFile
+					return false
+				}
+				fields = all[start : start+r.fieldLen]
+				records[node] = fields
+			}
+
+			var addr netip.Addr
+			if len(ip) == 4 {
+				addr = netip.AddrFrom4([4]byte(ip))
+			} else {
+				addr = netip.AddrFrom16([16]byte(ip))
+			}
+			return fn(netip.PrefixFrom(addr, depth), fields)
+		}
+		if depth >= len(ip)*8 {
+			return true
+		}
+		// IPv6 树中 IPv4 映射段已在 IPv4 遍历中输出
+		if len(ip) == 16 && depth == 96 && node == r.v4offset {
+			return true
+		}
+
+		for bit := range 2 {
+			next := slices.Clone(ip)
+			if bit == 1 {
+				next[depth>>3] |= 1 << (7 - uint(depth&7))
+			}
+			if !walk(r.readNode(node, bit), depth+1, next) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if r.meta.IPVersion&0x01 != 0 && walk(r.v4offset, 0, make([]byte, 4)) && r.meta.IPVersion&0x02 != 0 {
+		walk(0, 0, make([]byte, 16))
+	}
+
+	return walkErr
+}
+
 // Fields 返回字段名列表
 func (r *Reader) Fields() []string {
 	r.mu.RLock()
@@ -1,6 +1,7 @@
 package ipdb
 
 import (
+	"net/netip"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -74,6 +75,42 @@ func (s *ReaderSuite) TestFind_InvalidLanguage() {
 	s.ErrorIs(err, ErrNoLanguage)
 }
 
+// ========== Networks ==========
+
+func (s *ReaderSuite) TestNetworks() {
+	expected, err := s.r.Find("114.114.114.114", "CN")
+	s.Require().NoError(err)
+
+	addr := netip.MustParseAddr("114.114.114.114")
+	count, found := 0, false
+	err = s.r.Networks("CN", func(prefix netip.Prefix, fields []string) bool {
+		count++
+		if prefix.Contains(addr) {
+			found = true
+			s.Equal(expected, fields)
+		}
+		return true
+	})
+	s.NoError(err)
+	s.True(found)
+	s.Greater(count, 1)
+}
+
+func (s *ReaderSuite) TestNetworks_Stop() {
+	count := 0
+	err := s.r.Networks("CN", func(netip.Prefix, []string) bool {
+		count++
+		return false
+	})
+	s.NoError(err)
+	s.Equal(1, count)
+}
+
+func (s *ReaderSuite) TestNetworks_InvalidLanguage() {
+	err := s.r.Networks("INVALID", func(netip.Prefix, []string) bool { return true })
+	s.ErrorIs(err, ErrNoLanguage)
+}
+
 // ========== Reload ==========
 
 func (s *ReaderSuite) TestReload() {
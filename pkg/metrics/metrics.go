@@ -0,0 +1,126 @@
+// Package metrics 以 Prometheus 文本格式或 OpenMetrics 格式输出指标
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	ContentTypeText        = "text/plain; version=0.0.4; charset=utf-8"
+	ContentTypeOpenMetrics = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// 指标类型
+const (
+	TypeGauge   = "gauge"
+	TypeCounter = "counter"
+	TypeSummary = "summary"
+)
+
+// Writer 指标输出，同一指标族的样本需连续写入
+type Writer struct {
+	buf         bytes.Buffer
+	openMetrics bool
+	families    map[string]string // name -> type
+}
+
+// NewWriter 根据 Accept 请求头选择输出格式
+func NewWriter(accept string) *Writer {
+	return &Writer{
+		openMetrics: strings.Contains(accept, "application/openmetrics-text"),
+		families:    make(map[string]string),
+	}
+}
+
+// ContentType 返回当前输出格式对应的 Content-Type
+func (w *Writer) ContentType() string {
+	if w.openMetrics {
+		return ContentTypeOpenMetrics
+	}
+	return ContentTypeText
+}
+
+// Family 声明指标族，计数器名称不带 _total 后缀，同名指标族只声明一次
+func (w *Writer) Family(name, typ, help string) {
+	if _, ok := w.families[name]; ok {
+		return
+	}
+	w.families[name] = typ
+
+	// 文本格式中计数器的 HELP/TYPE 使用样本名
+	declared := name
+	if typ == TypeCounter && !w.openMetrics {
+		declared += "_total"
+	}
+	w.buf.WriteString("# HELP " + declared + " " + escapeHelp(help) + "\n")
+	w.buf.WriteString("# TYPE " + declared + " " + typ + "\n")
+}
+
+// Gauge 写入仪表样本，labels 为键值对
+func (w *Writer) Gauge(name string, value float64, labels ...string) {
+	w.sample(name, value, labels)
+}
+
+// Counter 写入计数器样本，自动追加 _total 后缀
+func (w *Writer) Counter(name string, value float64, labels ...string) {
+	w.sample(name+"_total", value, labels)
+}
+
+// Summary 写入不含分位数的摘要样本
+func (w *Writer) Summary(name string, sum, count float64, labels ...string) {
+	w.sample(name+"_sum", sum, labels)
+	w.sample(name+"_count", count, labels)
+}
+
+// Bytes 返回完整输出
+func (w *Writer) Bytes() []byte {
+	if w.openMetrics {
+		return append(bytes.Clone(w.buf.Bytes()), "# EOF\n"...)
+	}
+	return w.buf.Bytes()
+}
+
+func (w *Writer) sample(name string, value float64, labels []string) {
+	w.buf.WriteString(name)
+	if len(labels) > 1 {
+		w.buf.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				w.buf.WriteByte(',')
+			}
+			w.buf.WriteString(labels[i] + `="` + escapeLabel(labels[i+1]) + `"`)
+		}
+		w.buf.WriteByte('}')
+	}
+	w.buf.WriteByte(' ')
+	w.buf.WriteString(formatValue(value))
+	w.buf.WriteByte('\n')
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
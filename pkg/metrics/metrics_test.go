@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, &MetricsTestSuite{})
+}
+
+func (s *MetricsTestSuite) TestText() {
+	w := NewWriter("text/plain")
+	s.Equal(ContentTypeText, w.ContentType())
+
+	w.Family("ace_requests", TypeCounter, "Requests")
+	w.Counter("ace_requests", 3, "site", "a")
+	w.Family("ace_requests", TypeCounter, "Requests")
+	w.Counter("ace_requests", 1, "site", `b"\`+"\n")
+	w.Family("ace_load", TypeGauge, "Load\naverage")
+	w.Gauge("ace_load", 0.5)
+	w.Gauge("ace_load", math.Inf(1))
+	w.Family("ace_duration_seconds", TypeSummary, "Duration")
+	w.Summary("ace_duration_seconds", 1.25, 5, "site", "a", "class", "2xx")
+
+	s.Equal(`# HELP ace_requests_total Requests
+# TYPE ace_requests_total counter
+ace_requests_total{site="a"} 3
+ace_requests_total{site="b\"\\\n"} 1
+# HELP ace_load Load\naverage
+# TYPE ace_load gauge
+ace_load 0.5
+ace_load +Inf
+# HELP ace_duration_seconds Duration
+# TYPE ace_duration_seconds summary
+ace_duration_seconds_sum{site="a",class="2xx"} 1.25
+ace_duration_seconds_count{site="a",class="2xx"} 5
+`, string(w.Bytes()))
+}
+
+func (s *MetricsTestSuite) TestOpenMetrics() {
+	w := NewWriter("application/openmetrics-text;version=1.0.0,text/plain;q=0.5")
+	s.Equal(ContentTypeOpenMetrics, w.ContentType())
+
+	w.Family("ace_requests", TypeCounter, "Requests")
+	w.Counter("ace_requests", 1e12)
+
+	s.Equal(`# HELP ace_requests Requests
+# TYPE ace_requests counter
+ace_requests_total 1e+12
+# EOF
+`, string(w.Bytes()))
+}
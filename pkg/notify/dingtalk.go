@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// dingTalkMaxLength 钉钉文本消息上限
+const dingTalkMaxLength = 20000
+
+// DingTalkConfig 钉钉群机器人渠道配置
+type DingTalkConfig struct {
+	Webhook   string   `json:"webhook"`
+	Secret    string   `json:"secret"` // 加签密钥，安全设置为「加签」时必填
+	AtMobiles []string `json:"at_mobiles"`
+	AtAll     bool     `json:"at_all"`
+}
+
+type dingTalkNotifier struct {
+	conf   DingTalkConfig
+	client *http.Client
+}
+
+// NewDingTalk 构造钉钉通知器
+func NewDingTalk(config json.RawMessage) (Notifier, error) {
+	var conf DingTalkConfig
+	if err := json.Unmarshal(config, &conf); err != nil {
+		return nil, err
+	}
+	if err := validateURL(conf.Webhook); err != nil {
+		return nil, err
+	}
+
+	return &dingTalkNotifier{conf: conf, client: newHTTPClient(false)}, nil
+}
+
+func (d *dingTalkNotifier) Send(ctx context.Context, msg *Message) error {
+	target, err := d.signedURL(time.Now())
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"msgtype": "text",
+		"text": map[string]string{
+			"content": textMessage(msg, dingTalkMaxLength),
+		},
+		"at": map[string]any{
+			"atMobiles": d.conf.AtMobiles,
+			"isAtAll":   d.conf.AtAll,
+		},
+	}
+
+	data, err := postJSON(ctx, d.client, target, payload)
+	if err != nil {
+		return err
+	}
+
+	return robotError(data)
+}
+
+// signedURL 按钉钉加签规则在地址上追加 timestamp 与 sign
+// 签名为 HmacSHA256(secret, timestamp + "\n" + secret) 的 Base64
+func (d *dingTalkNotifier) signedURL(now time.Time) (string, error) {
+	if d.conf.Secret == "" {
+		return d.conf.Webhook, nil
+	}
+
+	u, err := url.Parse(d.conf.Webhook)
+	if err != nil {
+		return "", err
+	}
+
+	timestamp := strconv.FormatInt(now.UnixMilli(), 10)
+	mac := hmac.New(sha256.New, []byte(d.conf.Secret))
+	mac.Write([]byte(timestamp + "\n" + d.conf.Secret))
+
+	query := u.Query()
+	query.Set("timestamp", timestamp)
+	query.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// robotError 解析钉钉与企业微信机器人的通用响应
+func robotError(data []byte) error {
+	var resp struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	if resp.ErrCode != 0 {
+		return fmt.Errorf("robot api error %d: %s", resp.ErrCode, resp.ErrMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Discord embed 字段上限
+const (
+	discordTitleLength       = 256
+	discordDescriptionLength = 4096
+)
+
+// DiscordConfig Discord Webhook 渠道配置
+type DiscordConfig struct {
+	Webhook  string `json:"webhook"`
+	Username string `json:"username"`   // 覆盖显示名称
+	Avatar   string `json:"avatar_url"` // 覆盖头像地址
+}
+
+type discordNotifier struct {
+	conf   DiscordConfig
+	client *http.Client
+}
+
+// NewDiscord 构造 Discord 通知器
+func NewDiscord(config json.RawMessage) (Notifier, error) {
+	var conf DiscordConfig
+	if err := json.Unmarshal(config, &conf); err != nil {
+		return nil, err
+	}
+	if err := validateURL(conf.Webhook); err != nil {
+		return nil, err
+	}
+
+	return &discordNotifier{conf: conf, client: newHTTPClient(false)}, nil
+}
+
+func (d *discordNotifier) Send(ctx context.Context, msg *Message) error {
+	payload := map[string]any{
+		"embeds": []map[string]any{{
+			"title":       truncate(msg.Subject, discordTitleLength),
+			"description": truncate(PlainText(msg.Body), discordDescriptionLength),
+			"timestamp":   time.Now().Format(time.RFC3339),
+		}},
+		// 禁止正文中的 @everyone 等提及真正触发提醒
+		"allowed_mentions": map[string]any{"parse": []string{}},
+	}
+	if d.conf.Username != "" {
+		payload["username"] = d.conf.Username
+	}
+	if d.conf.Avatar != "" {
+		payload["avatar_url"] = d.conf.Avatar
+	}
+
+	// Discord 成功时返回 204
+	_, err := postJSON(ctx, d.client, d.conf.Webhook, payload)
+	return err
+}
@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// feishuMaxLength 飞书自定义机器人请求体上限为 20KB，预留 JSON 开销
+const feishuMaxLength = 18000
+
+// FeishuConfig 飞书/Lark 群机器人渠道配置
+type FeishuConfig struct {
+	Webhook string `json:"webhook"`
+	Secret  string `json:"secret"` // 签名校验密钥，安全设置启用签名校验时必填
+}
+
+type feishuNotifier struct {
+	conf   FeishuConfig
+	client *http.Client
+}
+
+// NewFeishu 构造飞书通知器
+func NewFeishu(config json.RawMessage) (Notifier, error) {
+	var conf FeishuConfig
+	if err := json.Unmarshal(config, &conf); err != nil {
+		return nil, err
+	}
+	if err := validateURL(conf.Webhook); err != nil {
+		return nil, err
+	}
+
+	return &feishuNotifier{conf: conf, client: newHTTPClient(false)}, nil
+}
+
+func (f *feishuNotifier) Send(ctx context.Context, msg *Message) error {
+	payload := map[string]any{
+		"msg_type": "text",
+		"content": map[string]string{
+			"text": textMessage(msg, feishuMaxLength),
+		},
+	}
+	if f.conf.Secret != "" {
+		timestamp := time.Now().Unix()
+		payload["timestamp"] = strconv.FormatInt(timestamp, 10)
+		payload["sign"] = feishuSign(f.conf.Secret, timestamp)
+	}
+
+	data, err := postJSON(ctx, f.client, f.conf.Webhook, payload)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err = json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	if resp.Code != 0 {
+		return fmt.Errorf("feishu api error %d: %s", resp.Code, resp.Msg)
+	}
+
+	return nil
+}
+
+// feishuSign 飞书签名，以 timestamp + "\n" + secret 为密钥对空串做 HmacSHA256 后 Base64
+func feishuSign(secret string, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(strconv.FormatInt(timestamp, 10)+"\n"+secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// httpTimeout 单次投递超时，机器人接口通常秒级返回
+const httpTimeout = 30 * time.Second
+
+// newHTTPClient 构造投递用的 HTTP 客户端
+func newHTTPClient(skipVerify bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: skipVerify, // nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	return &http.Client{
+		Timeout:   httpTimeout,
+		Transport: transport,
+	}
+}
+
+// doRequest 发送请求并返回响应体，非 2xx 状态码视为失败
+func doRequest(ctx context.Context, client *http.Client, method, target string, header http.Header, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", "AcePanel")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// 只读有限长度，避免异常服务端返回超大响应
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return data, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, truncate(strings.TrimSpace(string(data)), 256))
+	}
+
+	return data, nil
+}
+
+// postJSON 以 JSON 格式投递 payload
+func postJSON(ctx context.Context, client *http.Client, target string, payload any) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json; charset=utf-8")
+
+	return doRequest(ctx, client, http.MethodPost, target, header, bytes.NewReader(data))
+}
+
+// validateURL 校验渠道地址，只允许 http/https
+func validateURL(raw string) error {
+	if raw == "" {
+		return errors.New("webhook url is required")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported url scheme: %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("url host is required")
+	}
+
+	return nil
+}
+
+// PlainText 将 HTML 正文转换为纯文本，供不支持 HTML 的渠道使用
+// 段落与表格行各占一行，同一行的单元格以「: 」连接
+func PlainText(body string) string {
+	var sb strings.Builder
+	var cells int
+
+	tokenizer := html.NewTokenizer(strings.NewReader(body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return strings.TrimSpace(collapseBlankLines(sb.String()))
+		case html.TextToken:
+			// 源码中的换行与缩进不代表语义，统一折叠为单个空格
+			if text := strings.Join(strings.Fields(string(tokenizer.Text())), " "); text != "" {
+				sb.WriteString(text)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "br":
+				sb.WriteString("\n")
+			case "tr":
+				cells = 0
+			case "td", "th":
+				if cells > 0 {
+					sb.WriteString(": ")
+				}
+				cells++
+			case "li":
+				sb.WriteString("- ")
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "p", "div", "tr", "li", "h1", "h2", "h3", "h4", "table", "ul", "ol":
+				sb.WriteString("\n")
+			}
+		default:
+		}
+	}
+}
+
+// collapseBlankLines 合并连续空行
+func collapseBlankLines(s string) string {
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" && i > 0 && len(out) > 0 && out[len(out)-1] == "" {
+			continue
+		}
+		out = append(out, line)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// truncate 按字节上限截断字符串，不破坏 UTF-8 字符
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+
+	const ellipsis = "..."
+	cut := limit - len(ellipsis)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return s[:cut] + ellipsis
+}
+
+// textMessage 组合标题与纯文本正文，limit 为渠道允许的最大字节数
+func textMessage(msg *Message, limit int) string {
+	text := msg.Subject
+	if body := PlainText(msg.Body); body != "" {
+		text += "\n\n" + body
+	}
+
+	return truncate(text, limit)
+}
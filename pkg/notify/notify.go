@@ -9,13 +9,20 @@ import (
 
 // 通知渠道类型
 const (
-	TypeSMTP = "smtp"
+	TypeSMTP     = "smtp"
+	TypeWebhook  = "webhook"
+	TypeTelegram = "telegram"
+	TypeDingTalk = "dingtalk"
+	TypeFeishu   = "feishu"
+	TypeWeCom    = "wecom"
+	TypeSlack    = "slack"
+	TypeDiscord  = "discord"
 )
 
 // Message 通知消息
 type Message struct {
 	Subject string
-	Body    string // HTML 正文
+	Body    string // HTML 正文，不支持 HTML 的渠道会转为纯文本
 }
 
 // Notifier 通知渠道
@@ -28,6 +35,20 @@ func New(typ string, config json.RawMessage) (Notifier, error) {
 	switch typ {
 	case TypeSMTP:
 		return NewSMTP(config)
+	case TypeWebhook:
+		return NewWebhook(config)
+	case TypeTelegram:
+		return NewTelegram(config)
+	case TypeDingTalk:
+		return NewDingTalk(config)
+	case TypeFeishu:
+		return NewFeishu(config)
+	case TypeWeCom:
+		return NewWeCom(config)
+	case TypeSlack:
+		return NewSlack(config)
+	case TypeDiscord:
+		return NewDiscord(config)
 	default:
 		return nil, fmt.Errorf("unsupported notify channel type: %s", typ)
 	}
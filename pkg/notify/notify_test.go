@@ -0,0 +1,240 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// capturedRequest 本地替身服务收到的请求
+type capturedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+type NotifyTestSuite struct {
+	suite.Suite
+	server   *httptest.Server
+	requests chan capturedRequest
+	reply    string
+	status   int
+}
+
+func TestNotifyTestSuite(t *testing.T) {
+	suite.Run(t, &NotifyTestSuite{})
+}
+
+func (s *NotifyTestSuite) SetupTest() {
+	s.requests = make(chan capturedRequest, 1)
+	s.reply = `{}`
+	s.status = http.StatusOK
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.requests <- capturedRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Header: r.Header, Body: body}
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(s.reply))
+	}))
+}
+
+func (s *NotifyTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *NotifyTestSuite) message() *Message {
+	return &Message{
+		Subject: "[AcePanel] Test",
+		Body:    `<p>Hello &amp; welcome</p><table><tr><td>Channel</td><td>ops</td></tr></table>`,
+	}
+}
+
+func (s *NotifyTestSuite) send(typ string, config any) capturedRequest {
+	raw, err := json.Marshal(config)
+	s.Require().NoError(err)
+	notifier, err := New(typ, raw)
+	s.Require().NoError(err)
+	s.Require().NoError(notifier.Send(context.Background(), s.message()))
+
+	return <-s.requests
+}
+
+func (s *NotifyTestSuite) decode(data []byte) map[string]any {
+	var payload map[string]any
+	s.Require().NoError(json.Unmarshal(data, &payload))
+	return payload
+}
+
+func (s *NotifyTestSuite) TestPlainText() {
+	s.Equal("Hello & welcome\nChannel: ops", PlainText(s.message().Body))
+	s.Equal("a\nb", PlainText("a<br>b"))
+}
+
+func (s *NotifyTestSuite) TestTruncateKeepsUTF8() {
+	got := truncate("测试消息内容", 10)
+	s.LessOrEqual(len(got), 10)
+	s.Equal("测试...", got)
+}
+
+func (s *NotifyTestSuite) TestWebhookDefaultBody() {
+	req := s.send(TypeWebhook, WebhookConfig{URL: s.server.URL + "/hook", Headers: map[string]string{"X-Token": "secret"}})
+
+	s.Equal(http.MethodPost, req.Method)
+	s.Equal("/hook", req.Path)
+	s.Equal("secret", req.Header.Get("X-Token"))
+	s.Equal("application/json", req.Header.Get("Content-Type"))
+	payload := s.decode(req.Body)
+	s.Equal("[AcePanel] Test", payload["subject"])
+	s.Equal("Hello & welcome\nChannel: ops", payload["text"])
+}
+
+func (s *NotifyTestSuite) TestWebhookTemplateBody() {
+	req := s.send(TypeWebhook, WebhookConfig{
+		URL:    s.server.URL,
+		Method: "put",
+		Body:   `{"title":{{json .Subject}},"content":{{json .Text}}}`,
+	})
+
+	s.Equal(http.MethodPut, req.Method)
+	payload := s.decode(req.Body)
+	s.Equal("[AcePanel] Test", payload["title"])
+	s.Equal("Hello & welcome\nChannel: ops", payload["content"])
+}
+
+func (s *NotifyTestSuite) TestWebhookInvalidConfig() {
+	_, err := New(TypeWebhook, json.RawMessage(`{"url":"ftp://example.com"}`))
+	s.Error(err)
+	_, err = New(TypeWebhook, json.RawMessage(`{"url":"https://example.com","body":"{{.Subject"}`))
+	s.Error(err)
+	_, err = New(TypeWebhook, json.RawMessage(`{"url":"https://example.com","method":"DELETE"}`))
+	s.Error(err)
+}
+
+func (s *NotifyTestSuite) TestWebhookStatusError() {
+	s.status = http.StatusInternalServerError
+	notifier, err := New(TypeWebhook, json.RawMessage(`{"url":"`+s.server.URL+`"}`))
+	s.Require().NoError(err)
+	s.Error(notifier.Send(context.Background(), s.message()))
+	<-s.requests
+}
+
+func (s *NotifyTestSuite) TestTelegram() {
+	s.reply = `{"ok":true}`
+	req := s.send(TypeTelegram, TelegramConfig{BotToken: "123:abc", ChatID: "-100", APIURL: s.server.URL + "/", ThreadID: 7})
+
+	s.Equal("/bot123:abc/sendMessage", req.Path)
+	payload := s.decode(req.Body)
+	s.Equal("-100", payload["chat_id"])
+	s.Equal("HTML", payload["parse_mode"])
+	s.Equal(float64(7), payload["message_thread_id"])
+	s.Equal("<b>[AcePanel] Test</b>\n\nHello &amp; welcome\nChannel: ops", payload["text"])
+}
+
+func (s *NotifyTestSuite) TestTelegramAPIErrorHidesToken() {
+	s.status = http.StatusUnauthorized
+	s.reply = `{"ok":false,"description":"Unauthorized"}`
+	notifier, err := New(TypeTelegram, json.RawMessage(`{"bot_token":"123:secret","chat_id":"1","api_url":"`+s.server.URL+`"}`))
+	s.Require().NoError(err)
+	err = notifier.Send(context.Background(), s.message())
+	<-s.requests
+	s.Require().Error(err)
+	s.NotContains(err.Error(), "123:secret")
+}
+
+func (s *NotifyTestSuite) TestDingTalkSigned() {
+	s.reply = `{"errcode":0,"errmsg":"ok"}`
+	req := s.send(TypeDingTalk, DingTalkConfig{Webhook: s.server.URL + "/robot/send?access_token=t", Secret: "SECxxx", AtAll: true})
+
+	s.Equal("t", req.Query.Get("access_token"))
+	timestamp, err := strconv.ParseInt(req.Query.Get("timestamp"), 10, 64)
+	s.Require().NoError(err)
+	n := &dingTalkNotifier{conf: DingTalkConfig{Webhook: s.server.URL + "/robot/send?access_token=t", Secret: "SECxxx"}}
+	expected, err := n.signedURL(time.UnixMilli(timestamp))
+	s.Require().NoError(err)
+	u, _ := url.Parse(expected)
+	s.Equal(u.Query().Get("sign"), req.Query.Get("sign"))
+
+	payload := s.decode(req.Body)
+	s.Equal("text", payload["msgtype"])
+	s.Equal(true, payload["at"].(map[string]any)["isAtAll"])
+	s.Contains(payload["text"].(map[string]any)["content"], "[AcePanel] Test\n\nHello & welcome")
+}
+
+func (s *NotifyTestSuite) TestDingTalkAPIError() {
+	s.reply = `{"errcode":310000,"errmsg":"sign not match"}`
+	notifier, err := New(TypeDingTalk, json.RawMessage(`{"webhook":"`+s.server.URL+`"}`))
+	s.Require().NoError(err)
+	s.ErrorContains(notifier.Send(context.Background(), s.message()), "sign not match")
+	<-s.requests
+}
+
+func (s *NotifyTestSuite) TestFeishuSigned() {
+	s.reply = `{"code":0,"msg":"success"}`
+	req := s.send(TypeFeishu, FeishuConfig{Webhook: s.server.URL + "/open-apis/bot/v2/hook/x", Secret: "secret"})
+
+	payload := s.decode(req.Body)
+	s.Equal("text", payload["msg_type"])
+	timestamp, err := strconv.ParseInt(payload["timestamp"].(string), 10, 64)
+	s.Require().NoError(err)
+	s.Equal(feishuSign("secret", timestamp), payload["sign"])
+	s.Contains(payload["content"].(map[string]any)["text"], "Channel: ops")
+}
+
+func (s *NotifyTestSuite) TestFeishuAPIError() {
+	s.reply = `{"code":19021,"msg":"sign match fail"}`
+	notifier, err := New(TypeFeishu, json.RawMessage(`{"webhook":"`+s.server.URL+`"}`))
+	s.Require().NoError(err)
+	s.ErrorContains(notifier.Send(context.Background(), s.message()), "sign match fail")
+	<-s.requests
+}
+
+func (s *NotifyTestSuite) TestWeCom() {
+	s.reply = `{"errcode":0,"errmsg":"ok"}`
+	req := s.send(TypeWeCom, WeComConfig{Webhook: s.server.URL + "/cgi-bin/webhook/send?key=k", AtMobiles: []string{"13800000000"}, AtAll: true})
+
+	s.Equal("k", req.Query.Get("key"))
+	payload := s.decode(req.Body)
+	text := payload["text"].(map[string]any)
+	s.Equal([]any{"13800000000", "@all"}, text["mentioned_mobile_list"])
+	s.Contains(text["content"], "[AcePanel] Test")
+}
+
+func (s *NotifyTestSuite) TestWeComKeyOnly() {
+	notifier, err := NewWeCom(json.RawMessage(`{"key":"abc"}`))
+	s.Require().NoError(err)
+	s.Equal(wecomWebhook+"?key=abc", notifier.(*wecomNotifier).conf.Webhook)
+}
+
+func (s *NotifyTestSuite) TestSlack() {
+	s.reply = `ok`
+	req := s.send(TypeSlack, SlackConfig{Webhook: s.server.URL + "/services/T/B/X", Username: "AcePanel"})
+
+	payload := s.decode(req.Body)
+	s.Equal("AcePanel", payload["username"])
+	s.Equal("*[AcePanel] Test*\n\nHello &amp; welcome\nChannel: ops", payload["text"])
+}
+
+func (s *NotifyTestSuite) TestDiscord() {
+	s.status = http.StatusNoContent
+	s.reply = ``
+	req := s.send(TypeDiscord, DiscordConfig{Webhook: s.server.URL + "/api/webhooks/1/x"})
+
+	payload := s.decode(req.Body)
+	embed := payload["embeds"].([]any)[0].(map[string]any)
+	s.Equal("[AcePanel] Test", embed["title"])
+	s.Equal("Hello & welcome\nChannel: ops", embed["description"])
+}
+
+func (s *NotifyTestSuite) TestUnsupportedType() {
+	_, err := New("pager", json.RawMessage(`{}`))
+	s.Error(err)
+}
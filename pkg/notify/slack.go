@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// slackMaxLength Slack 单条消息文本上限
+const slackMaxLength = 40000
+
+// SlackConfig Slack Incoming Webhook 渠道配置
+type SlackConfig struct {
+	Webhook  string `json:"webhook"`
+	Channel  string `json:"channel"`  // 覆盖 Webhook 默认频道，新版应用可能忽略
+	Username string `json:"username"` // 覆盖显示名称
+}
+
+type slackNotifier struct {
+	conf   SlackConfig
+	client *http.Client
+}
+
+// NewSlack 构造 Slack 通知器
+func NewSlack(config json.RawMessage) (Notifier, error) {
+	var conf SlackConfig
+	if err := json.Unmarshal(config, &conf); err != nil {
+		return nil, err
+	}
+	if err := validateURL(conf.Webhook); err != nil {
+		return nil, err
+	}
+
+	return &slackNotifier{conf: conf, client: newHTTPClient(false)}, nil
+}
+
+func (s *slackNotifier) Send(ctx context.Context, msg *Message) error {
+	// mrkdwn 中 &、<、> 需转义，标题加粗；先截断再转义，避免切断转义序列
+	text := "*" + slackEscape(truncate(msg.Subject, 256)) + "*"
+	if body := PlainText(msg.Body); body != "" {
+		text += "\n\n" + slackEscape(truncate(body, slackMaxLength))
+	}
+
+	payload := map[string]any{
+		"text": text,
+	}
+	if s.conf.Channel != "" {
+		payload["channel"] = s.conf.Channel
+	}
+	if s.conf.Username != "" {
+		payload["username"] = s.conf.Username
+	}
+
+	// Slack 成功时返回纯文本 ok，失败时返回非 2xx 与错误码
+	_, err := postJSON(ctx, s.client, s.conf.Webhook, payload)
+	return err
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func slackEscape(s string) string {
+	return slackEscaper.Replace(s)
+}
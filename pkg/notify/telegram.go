@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+)
+
+// telegramAPI Telegram Bot API 默认地址
+const telegramAPI = "https://api.telegram.org"
+
+// telegramMaxLength sendMessage 文本上限
+const telegramMaxLength = 4096
+
+// TelegramConfig Telegram 机器人渠道配置
+type TelegramConfig struct {
+	BotToken string `json:"bot_token"`
+	ChatID   string `json:"chat_id"`
+	APIURL   string `json:"api_url"` // 自建 Bot API 或反代地址，为空使用官方地址
+	ThreadID int64  `json:"thread_id"`
+	Silent   bool   `json:"silent"`
+}
+
+type telegramNotifier struct {
+	conf   TelegramConfig
+	client *http.Client
+}
+
+// NewTelegram 构造 Telegram 通知器
+func NewTelegram(config json.RawMessage) (Notifier, error) {
+	var conf TelegramConfig
+	if err := json.Unmarshal(config, &conf); err != nil {
+		return nil, err
+	}
+	if conf.BotToken == "" || conf.ChatID == "" {
+		return nil, errors.New("telegram bot token and chat id are required")
+	}
+	if conf.APIURL == "" {
+		conf.APIURL = telegramAPI
+	}
+	if err := validateURL(conf.APIURL); err != nil {
+		return nil, err
+	}
+	conf.APIURL = strings.TrimRight(conf.APIURL, "/")
+
+	return &telegramNotifier{conf: conf, client: newHTTPClient(false)}, nil
+}
+
+func (t *telegramNotifier) Send(ctx context.Context, msg *Message) error {
+	// Telegram 只支持少量 HTML 标签，正文转为纯文本后转义，标题加粗
+	// 长度上限按解析实体后的文本计算，因此在转义前截断
+	subject := truncate(msg.Subject, 256)
+	text := "<b>" + html.EscapeString(subject) + "</b>"
+	if body := PlainText(msg.Body); body != "" {
+		text += "\n\n" + html.EscapeString(truncate(body, telegramMaxLength-len(subject)-2))
+	}
+
+	payload := map[string]any{
+		"chat_id":                  t.conf.ChatID,
+		"text":                     text,
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+		"disable_notification":     t.conf.Silent,
+	}
+	if t.conf.ThreadID > 0 {
+		payload["message_thread_id"] = t.conf.ThreadID
+	}
+
+	data, err := postJSON(ctx, t.client, fmt.Sprintf("%s/bot%s/sendMessage", t.conf.APIURL, t.conf.BotToken), payload)
+	if err != nil {
+		// 错误信息中可能带有完整请求地址，必须去掉 Bot Token
+		return errors.New(strings.ReplaceAll(err.Error(), t.conf.BotToken, "***"))
+	}
+
+	var resp struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	if err = json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	if !resp.OK {
+		return fmt.Errorf("telegram api error: %s", resp.Description)
+	}
+
+	return nil
+}
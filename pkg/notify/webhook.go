@@ -0,0 +1,124 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// WebhookConfig 通用 Webhook 渠道配置
+type WebhookConfig struct {
+	URL         string            `json:"url"`
+	Method      string            `json:"method"`       // 默认 POST
+	ContentType string            `json:"content_type"` // 默认 application/json
+	Headers     map[string]string `json:"headers"`
+	Body        string            `json:"body"` // 请求体模板，为空时发送默认 JSON
+	SkipVerify  bool              `json:"skip_verify"`
+}
+
+// WebhookData 请求体模板可用的变量
+type WebhookData struct {
+	Subject string // 标题
+	Body    string // HTML 正文
+	Text    string // 纯文本正文
+	Time    string // 发送时间
+}
+
+type webhookNotifier struct {
+	conf   WebhookConfig
+	tmpl   *template.Template
+	client *http.Client
+}
+
+// webhookFuncs 模板函数，json 用于把变量安全地嵌入 JSON 字符串字面量
+var webhookFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// NewWebhook 构造通用 Webhook 通知器
+func NewWebhook(config json.RawMessage) (Notifier, error) {
+	var conf WebhookConfig
+	if err := json.Unmarshal(config, &conf); err != nil {
+		return nil, err
+	}
+	if err := validateURL(conf.URL); err != nil {
+		return nil, err
+	}
+
+	conf.Method = strings.ToUpper(conf.Method)
+	if conf.Method == "" {
+		conf.Method = http.MethodPost
+	}
+	if !slices.Contains([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch}, conf.Method) {
+		return nil, fmt.Errorf("unsupported webhook method: %s", conf.Method)
+	}
+	if conf.ContentType == "" {
+		conf.ContentType = "application/json"
+	}
+	for key := range conf.Headers {
+		if strings.TrimSpace(key) == "" {
+			return nil, errors.New("webhook header name is required")
+		}
+	}
+
+	n := &webhookNotifier{conf: conf, client: newHTTPClient(conf.SkipVerify)}
+	if conf.Body != "" {
+		tmpl, err := template.New("webhook").Funcs(webhookFuncs).Parse(conf.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook body template: %w", err)
+		}
+		n.tmpl = tmpl
+	}
+
+	return n, nil
+}
+
+func (w *webhookNotifier) Send(ctx context.Context, msg *Message) error {
+	data := WebhookData{
+		Subject: msg.Subject,
+		Body:    msg.Body,
+		Text:    PlainText(msg.Body),
+		Time:    time.Now().Format(time.RFC3339),
+	}
+
+	var body bytes.Buffer
+	if w.tmpl != nil {
+		if err := w.tmpl.Execute(&body, data); err != nil {
+			return fmt.Errorf("render webhook body: %w", err)
+		}
+	} else {
+		if err := json.NewEncoder(&body).Encode(map[string]string{
+			"subject": data.Subject,
+			"body":    data.Body,
+			"text":    data.Text,
+			"time":    data.Time,
+		}); err != nil {
+			return err
+		}
+	}
+
+	header := make(http.Header)
+	header.Set("Content-Type", w.conf.ContentType)
+	for key, value := range w.conf.Headers {
+		header.Set(key, value)
+	}
+
+	// GET 请求不携带请求体
+	if w.conf.Method == http.MethodGet {
+		_, err := doRequest(ctx, w.client, w.conf.Method, w.conf.URL, header, nil)
+		return err
+	}
+
+	_, err := doRequest(ctx, w.client, w.conf.Method, w.conf.URL, header, &body)
+	return err
+}
@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// wecomWebhook 企业微信群机器人默认地址
+const wecomWebhook = "https://qyapi.weixin.qq.com/cgi-bin/webhook/send"
+
+// wecomMaxLength 企业微信文本消息上限
+const wecomMaxLength = 2048
+
+// WeComConfig 企业微信群机器人渠道配置
+// 企业微信以地址中的 key 鉴权，可直接填写完整地址，也可只填 key
+type WeComConfig struct {
+	Webhook   string   `json:"webhook"`
+	Key       string   `json:"key"`
+	AtMobiles []string `json:"at_mobiles"`
+	AtAll     bool     `json:"at_all"`
+}
+
+type wecomNotifier struct {
+	conf   WeComConfig
+	client *http.Client
+}
+
+// NewWeCom 构造企业微信通知器
+func NewWeCom(config json.RawMessage) (Notifier, error) {
+	var conf WeComConfig
+	if err := json.Unmarshal(config, &conf); err != nil {
+		return nil, err
+	}
+	if conf.Webhook == "" {
+		if conf.Key == "" {
+			return nil, errors.New("wecom webhook or key is required")
+		}
+		conf.Webhook = wecomWebhook + "?key=" + url.QueryEscape(conf.Key)
+	}
+	if err := validateURL(conf.Webhook); err != nil {
+		return nil, err
+	}
+
+	return &wecomNotifier{conf: conf, client: newHTTPClient(false)}, nil
+}
+
+func (w *wecomNotifier) Send(ctx context.Context, msg *Message) error {
+	mentions := slices.Clone(w.conf.AtMobiles)
+	if w.conf.AtAll {
+		mentions = append(mentions, "@all")
+	}
+
+	payload := map[string]any{
+		"msgtype": "text",
+		"text": map[string]any{
+			"content":               textMessage(msg, wecomMaxLength),
+			"mentioned_mobile_list": mentions,
+		},
+	}
+
+	data, err := postJSON(ctx, w.client, w.conf.Webhook, payload)
+	if err != nil {
+		return err
+	}
+
+	return robotError(data)
+}
@@ -20,6 +20,11 @@ func ApplyEnv(cmd *exec.Cmd, env ...string) {
 	cmd.Env = append(os.Environ(), append([]string{"LC_ALL=C"}, env...)...)
 }
 
+// Quote 以单引号转义参数，拼接进 shell 命令后按字面量处理
+func Quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
+
 // Exec 执行 shell 命令
 func Exec(shell string) (string, error) {
 	cmd := exec.Command("bash", "-c", shell)
@@ -45,17 +50,23 @@ func ExecWithLog(ctx context.Context, shell string, logFile string) error {
 	}
 	defer func(f *os.File) { _ = f.Close() }(f)
 
+	return ExecWithWriter(ctx, shell, f)
+}
+
+// ExecWithWriter 执行 shell 命令并将输出写入 w
+// ctx 取消时会杀死整个进程组
+func ExecWithWriter(ctx context.Context, shell string, w io.Writer) error {
 	cmd := exec.CommandContext(ctx, "bash", "-c", shell)
 	ApplyEnv(cmd)
-	cmd.Stdout = f
-	cmd.Stderr = f
+	cmd.Stdout = w
+	cmd.Stderr = w
 	// 命令会派生子进程（下载、压缩等），放入独立进程组以便取消时整组杀死
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 	cmd.Cancel = func() error {
 		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
 	}
 
-	if err = cmd.Run(); err != nil {
+	if err := cmd.Run(); err != nil {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/coder/websocket"
 	"github.com/creack/pty"
+
+	"github.com/acepanel/panel/v3/pkg/asciicast"
 )
 
 // MessageResize 终端大小调整消息
@@ -33,11 +35,12 @@ type Turn struct {
 	ws   *websocket.Conn
 	ptmx *os.File
 	cmd  *exec.Cmd
+	rec  *asciicast.Recorder
 }
 
 // NewPTYTurn 使用 PTY 执行命令，返回 Turn 用于流式读取输出
-// 调用方需要负责调用 Close() 和 Wait()
-func NewPTYTurn(ctx context.Context, ws *websocket.Conn, shell string, args ...any) (*Turn, error) {
+// 调用方需要负责调用 Close() 和 Wait()，rec 不为 nil 时录制会话
+func NewPTYTurn(ctx context.Context, ws *websocket.Conn, rec *asciicast.Recorder, shell string, args ...any) (*Turn, error) {
 	if !preCheckArg(args) {
 		return nil, errors.New("command contains illegal characters")
 	}
@@ -58,11 +61,13 @@ func NewPTYTurn(ctx context.Context, ws *websocket.Conn, shell string, args ...a
 		ws:   ws,
 		ptmx: ptmx,
 		cmd:  cmd,
+		rec:  rec,
 	}, nil
 }
 
 // Write 写入 PTY 输入
 func (t *Turn) Write(data []byte) (int, error) {
+	t.rec.Input(data)
 	return t.ptmx.Write(data)
 }
 
@@ -138,6 +143,7 @@ func (t *Turn) Pipe(ctx context.Context) error {
 				return nil
 			}
 			if n > 0 {
+				t.rec.Output(buf[:n])
 				if err = t.ws.Write(ctx, websocket.MessageBinary, buf[:n]); err != nil {
 					return fmt.Errorf("failed to write to ws: %w", err)
 				}
@@ -148,6 +154,7 @@ func (t *Turn) Pipe(ctx context.Context) error {
 
 // Resize 调整 PTY 窗口大小
 func (t *Turn) Resize(rows, cols uint16) error {
+	t.rec.Resize(uint(cols), uint(rows))
 	return pty.Setsize(t.ptmx, &pty.Winsize{
 		Rows: rows,
 		Cols: cols,
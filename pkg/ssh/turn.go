@@ -8,6 +8,8 @@ import (
 
 	"github.com/coder/websocket"
 	"golang.org/x/crypto/ssh"
+
+	"github.com/acepanel/panel/v3/pkg/asciicast"
 )
 
 type MessageResize struct {
@@ -25,9 +27,11 @@ type Turn struct {
 	stdin   io.WriteCloser
 	session *ssh.Session
 	ws      *websocket.Conn
+	rec     *asciicast.Recorder
 }
 
-func NewTurn(ctx context.Context, ws *websocket.Conn, client *ssh.Client) (*Turn, error) {
+// NewTurn 打开 SSH 交互会话，rec 不为 nil 时录制会话
+func NewTurn(ctx context.Context, ws *websocket.Conn, client *ssh.Client, rec *asciicast.Recorder) (*Turn, error) {
 	sess, err := client.NewSession()
 	if err != nil {
 		return nil, err
@@ -38,7 +42,7 @@ func NewTurn(ctx context.Context, ws *websocket.Conn, client *ssh.Client) (*Turn
 		return nil, err
 	}
 
-	turn := &Turn{ctx: ctx, stdin: stdin, session: sess, ws: ws}
+	turn := &Turn{ctx: ctx, stdin: stdin, session: sess, ws: ws, rec: rec}
 	sess.Stdout = turn
 	sess.Stderr = turn
 
@@ -58,6 +62,7 @@ func NewTurn(ctx context.Context, ws *websocket.Conn, client *ssh.Client) (*Turn
 }
 
 func (t *Turn) Write(p []byte) (n int, err error) {
+	t.rec.Output(p)
 	if err = t.ws.Write(t.ctx, websocket.MessageText, p); err != nil {
 		return 0, err
 	}
@@ -95,6 +100,7 @@ func (t *Turn) Handle(ctx context.Context) error {
 			// 判断是否是 resize 消息
 			if err = json.Unmarshal(data, &resize); err == nil {
 				if resize.Resize && resize.Columns > 0 && resize.Rows > 0 {
+					t.rec.Resize(uint(resize.Columns), uint(resize.Rows))
 					if err = t.session.WindowChange(resize.Rows, resize.Columns); err != nil {
 						return fmt.Errorf("change window size err: %v", err)
 					}
@@ -102,6 +108,7 @@ func (t *Turn) Handle(ctx context.Context) error {
 				continue
 			}
 
+			t.rec.Input(data)
 			if _, err = t.stdin.Write(data); err != nil {
 				return fmt.Errorf("writing ws message to stdin err: %v", err)
 			}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// ChecksumExt 校验清单扩展名，内容与 sha256sum 输出格式一致
+const ChecksumExt = ".sha256"
+
+// Checksum 写入时同步计算 SHA-256，上传完成后在同目录写入 file + ChecksumExt 清单
+// 清单记录的是存储中的实际内容（加密备份即为密文），下载后可直接比对
+type Checksum struct {
+	Storage
+}
+
+func NewChecksum(s Storage) Storage {
+	return &Checksum{Storage: s}
+}
+
+// Put 写入文件并生成校验清单
+func (c *Checksum) Put(file string, content io.Reader) error {
+	hash := sha256.New()
+	if err := c.Storage.Put(file, io.TeeReader(content, hash)); err != nil {
+		return err
+	}
+
+	line := fmt.Sprintf("%s  %s\n", hex.EncodeToString(hash.Sum(nil)), path.Base(file))
+	return c.Storage.Put(file+ChecksumExt, strings.NewReader(line))
+}
+
+// ReadChecksum 读取文件的校验清单，返回十六进制 SHA-256
+func ReadChecksum(s Storage, file string) (string, error) {
+	reader, err := s.Get(file + ChecksumExt)
+	if err != nil {
+		return "", err
+	}
+	defer func(reader io.ReadCloser) { _ = reader.Close() }(reader)
+
+	line, err := bufio.NewReader(io.LimitReader(reader, 1024)).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	sum, _, _ := strings.Cut(strings.TrimSpace(line), " ")
+	if _, err = hex.DecodeString(sum); err != nil || len(sum) != sha256.Size*2 {
+		return "", errors.New("invalid checksum manifest")
+	}
+
+	return strings.ToLower(sum), nil
+}
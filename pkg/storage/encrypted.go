@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"io"
+
+	"filippo.io/age"
+)
+
+// EncryptedExt 加密文件扩展名
+const EncryptedExt = ".age"
+
+// Encrypted 写入前用 age 流式加密的存储器，文件名追加 EncryptedExt
+// 只负责加密写入，其余操作原样透传，Get 返回的是密文
+type Encrypted struct {
+	Storage
+	recipients []age.Recipient
+}
+
+func NewEncrypted(s Storage, recipients ...age.Recipient) Storage {
+	return &Encrypted{Storage: s, recipients: recipients}
+}
+
+// Put 加密写入 file + EncryptedExt
+func (e *Encrypted) Put(file string, content io.Reader) error {
+	pr, pw := io.Pipe()
+	go func() {
+		w, err := age.Encrypt(pw, e.recipients...)
+		if err == nil {
+			if _, err = io.Copy(w, content); err == nil {
+				err = w.Close()
+			}
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	err := e.Storage.Put(file+EncryptedExt, pr)
+	// 上传失败时让加密协程退出
+	_ = pr.CloseWithError(io.ErrClosedPipe)
+
+	return err
+}
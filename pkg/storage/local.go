@@ -39,6 +39,11 @@ func (l *Local) Delete(files ...string) error {
 	return nil
 }
 
+// Get 读取文件内容
+func (l *Local) Get(file string) (io.ReadCloser, error) {
+	return os.Open(l.fullPath(file))
+}
+
 // Exists 检查文件是否存在
 func (l *Local) Exists(file string) bool {
 	fullPath := l.fullPath(file)
@@ -82,8 +87,12 @@ func (l *Local) Put(file string, content io.Reader) error {
 		return err
 	}
 
-	// 预检查空间
-	if err := l.preCheckPath(filepath.Dir(fullPath)); err != nil {
+	// 预检查空间；已知大小的内容（如去重备份的数据块）只比较剩余空间，避免每次写入都统计整个目录
+	if sized, ok := content.(interface{ Len() int }); ok {
+		if err := l.preCheckSize(int64(sized.Len())); err != nil {
+			return fmt.Errorf("pre check path failed: %w", err)
+		}
+	} else if err := l.preCheckPath(filepath.Dir(fullPath)); err != nil {
 		return fmt.Errorf("pre check path failed: %w", err)
 	}
 
@@ -143,3 +152,19 @@ func (l *Local) preCheckPath(path string) error {
 
 	return nil
 }
+
+func (l *Local) preCheckSize(size int64) error {
+	usage, err := disk.Usage(l.basePath)
+	if err != nil {
+		return err
+	}
+
+	if uint64(size) > usage.Free {
+		return errors.New("insufficient backup directory space")
+	}
+	if usage.InodesTotal > 0 && usage.InodesFree == 0 {
+		return errors.New("insufficient backup directory inode")
+	}
+
+	return nil
+}
@@ -65,6 +65,11 @@ func (s *S3) Delete(files ...string) error {
 	return s.client.Delete(keys...)
 }
 
+// Get 读取文件内容
+func (s *S3) Get(file string) (io.ReadCloser, error) {
+	return s.client.Get(s.getKey(file))
+}
+
 // Exists 检查文件是否存在
 func (s *S3) Exists(file string) bool {
 	_, err := s.client.Stat(s.getKey(file))
@@ -7,6 +7,7 @@ import (
 	"encoding/base64"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"net/http"
 	"slices"
 	"strconv"
@@ -56,6 +57,35 @@ func (c *S3) Stat(key string) (ObjectInfo, error) {
 	return info, nil
 }
 
+// Get 下载对象，流式返回响应体，由调用方关闭
+// 仅在拿到响应头之前的失败会重试，已开始读取的内容出错需调用方重新获取
+func (c *S3) Get(key string) (io.ReadCloser, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff(attempt))
+		}
+
+		req, err := http.NewRequest(http.MethodGet, c.objectURL(key), nil)
+		if err != nil {
+			return nil, err
+		}
+		c.signRequest(req)
+
+		res, err := c.httpClient().Do(req)
+		if err == nil {
+			if res.StatusCode == http.StatusOK {
+				return res.Body, nil
+			}
+			body, _ := io.ReadAll(res.Body)
+			_ = res.Body.Close()
+			err = &apiError{status: res.StatusCode, statusText: res.Status, body: body}
+		}
+		if attempt >= c.maxRetries || !isRetryable(err) {
+			return nil, err
+		}
+	}
+}
+
 // Delete 删除一个或多个对象，自动按每批 1000 个分批请求
 func (c *S3) Delete(keys ...string) error {
 	for batch := range slices.Chunk(keys, 1000) {
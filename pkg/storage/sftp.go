@@ -104,6 +104,34 @@ func (s *SFTP) Delete(files ...string) error {
 	return nil
 }
 
+// Get 读取文件内容，连接在关闭返回的 ReadCloser 时释放
+func (s *SFTP) Get(file string) (io.ReadCloser, error) {
+	client, cleanup, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	remoteFile, err := client.Open(s.getRemotePath(file))
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	return &sftpReadCloser{File: remoteFile, cleanup: cleanup}, nil
+}
+
+// sftpReadCloser 关闭文件的同时断开 SFTP 连接
+type sftpReadCloser struct {
+	*sftp.File
+	cleanup func()
+}
+
+func (f *sftpReadCloser) Close() error {
+	err := f.File.Close()
+	f.cleanup()
+	return err
+}
+
 // Exists 检查文件是否存在
 func (s *SFTP) Exists(file string) bool {
 	client, cleanup, err := s.connect()
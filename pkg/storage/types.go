@@ -8,6 +8,8 @@ import (
 type Storage interface {
 	// Delete deletes the given file(s).
 	Delete(file ...string) error
+	// Get opens the given file for reading, the caller must close it.
+	Get(file string) (io.ReadCloser, error)
 	// Exists determines if a file exists.
 	Exists(file string) bool
 	// LastModified gets the file's last modified time.
@@ -63,6 +63,11 @@ func (w *WebDav) Delete(files ...string) error {
 	return nil
 }
 
+// Get 读取文件内容
+func (w *WebDav) Get(file string) (io.ReadCloser, error) {
+	return w.client.ReadStream(w.fullPath(file))
+}
+
 // Exists 检查文件是否存在
 func (w *WebDav) Exists(file string) bool {
 	remotePath := w.fullPath(file)
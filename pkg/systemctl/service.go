@@ -63,6 +63,27 @@ func GetServiceInfo(name string) (*ServiceInfo, error) {
 	return info, nil
 }
 
+// User 获取服务运行的用户与用户组，未配置时为空，即以 root 运行
+func User(name string) (string, string, error) {
+	output, err := shell.Execf("systemctl show '%s' --property=User,Group --no-pager", name)
+	if err != nil {
+		return "", "", err
+	}
+
+	var user, group string
+	for line := range strings.SplitSeq(output, "\n") {
+		key, value, _ := strings.Cut(line, "=")
+		switch key {
+		case "User":
+			user = value
+		case "Group":
+			group = value
+		}
+	}
+
+	return user, group, nil
+}
+
 // Status 获取服务状态
 func Status(name string) (bool, error) {
 	output, _ := shell.Execf("systemctl is-active '%s'", name) // 不判断错误，因为 is-active 在服务未启用时会返回 3
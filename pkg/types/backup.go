@@ -21,11 +21,25 @@ type BackupStorageInfo struct {
 	PrivateKey string `json:"private_key"`                        // 私钥
 
 	Path string `json:"path"` // 路径
+
+	// 客户端加密，二选一
+	Passphrase string `json:"passphrase"` // 加密口令，面板可自动解密
+	PublicKey  string `json:"public_key"` // age 公钥，每行一个，解密需提供对应私钥
 }
 
 type BackupFile struct {
-	Name string    `json:"name"`
-	Path string    `json:"path"`
-	Size string    `json:"size"`
-	Time time.Time `json:"time"`
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	Size      string    `json:"size"`
+	Time      time.Time `json:"time"`
+	Encrypted bool      `json:"encrypted"`
+}
+
+// BackupVerify 备份校验结果
+type BackupVerify struct {
+	Name     string `json:"name"`
+	Size     string `json:"size"`
+	SHA256   string `json:"sha256"`   // 下载内容的实际哈希
+	Expected string `json:"expected"` // 校验清单记录的哈希，无清单时为空
+	Entries  int    `json:"entries"`  // 压缩包内的文件数
 }
@@ -24,3 +24,20 @@ type CertList struct {
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
 }
+
+// CertDeployConfig 部署目标配置，按类型使用其中部分字段
+type CertDeployConfig struct {
+	SSHID        uint   `json:"ssh_id"`                                                     // ssh：SSH 主机 ID
+	StorageID    uint   `json:"storage_id"`                                                 // s3：备份存储 ID
+	CertPath     string `json:"cert_path"`                                                  // ssh、s3、docker：证书路径
+	KeyPath      string `json:"key_path"`                                                   // ssh、s3、docker：私钥路径
+	Command      string `json:"command"`                                                    // ssh：部署后在远程执行的命令；docker：部署后在容器内执行的命令
+	URL          string `json:"url"`                                                        // panel：面板地址（含安全入口）
+	TokenID      uint   `json:"token_id"`                                                   // panel：访问令牌 ID
+	Token        string `json:"token"`                                                      // panel：访问令牌
+	RemoteCertID uint   `json:"remote_cert_id"`                                             // panel：远程证书 ID，为 0 时首次部署上传新证书
+	Container    string `json:"container"`                                                  // docker：容器名或 ID
+	Volume       string `json:"volume"`                                                     // docker：卷名，与容器二选一
+	Restart      bool   `json:"restart"`                                                    // docker：部署后重启容器
+	App          string `form:"app" json:"app" validate:"in:,pureftpd,gitea,minio,grafana"` // app：应用标识
+}
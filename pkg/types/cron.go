@@ -2,11 +2,13 @@ package types
 
 // CronConfig 计划任务结构化配置
 type CronConfig struct {
-	Type    string   `json:"type"`    // 子类型：backup 时为 website/mysql/postgres/path；cutoff 时为 website/container
-	Flock   bool     `json:"flock"`   // 进程锁
-	Targets []string `json:"targets"` // 目标列表
-	Storage uint     `json:"storage"` // 存储 ID（0=本地）
-	Keep    uint     `json:"keep"`    // 保留份数
+	Type        string   `json:"type"`         // 子类型：backup 时为 website/path/mysql/postgresql/clickhouse/redis/valkey/mongodb/elasticsearch；cutoff 时为 website/container
+	Flock       bool     `json:"flock"`        // 进程锁，已由 Overlap 取代，仅用于迁移旧配置
+	Targets     []string `json:"targets"`      // 目标列表
+	Storage     uint     `json:"storage"`      // 存储 ID（0=本地）
+	Keep        uint     `json:"keep"`         // 保留份数
+	Dedup       bool     `json:"dedup"`        // 去重快照备份，仅 website/path
+	TestRestore bool     `json:"test_restore"` // 备份后恢复到临时库测试，仅 mysql/postgresql
 	// URL 任务专用
 	URL      string            `json:"url"`
 	Method   string            `json:"method"`   // GET/POST/PUT/DELETE/PATCH/HEAD
@@ -15,4 +17,9 @@ type CronConfig struct {
 	Timeout  uint              `json:"timeout"`  // 超时时间（秒）
 	Insecure bool              `json:"insecure"` // 忽略证书校验
 	Retries  uint              `json:"retries"`  // 失败重试次数
+	// 执行控制
+	Overlap    string `json:"overlap"`     // 上次执行未结束时的策略：allow 并行 / skip 跳过 / queue 排队等待
+	MaxRuntime uint   `json:"max_runtime"` // 单次执行超时时间（秒），0 为不限制
+	OnSuccess  []uint `json:"on_success"`  // 执行成功后触发的任务 ID
+	OnFailure  []uint `json:"on_failure"`  // 执行失败后触发的任务 ID
 }
@@ -41,6 +41,7 @@ type DiskIO struct {
 }
 
 type MonitorDetail struct {
+	Resolution   uint           `json:"resolution"` // 数据分辨率，单位秒，0 为原始采样
 	Times        []string       `json:"times"`
 	Load         Load           `json:"load"`
 	CPU          CPU            `json:"cpu"`
@@ -0,0 +1,12 @@
+package types
+
+// ProbeConfig 可用性探测的 HTTP 配置，TCP 与 Ping 探测不使用
+type ProbeConfig struct {
+	Method         string            `json:"method" validate:"in:,GET,HEAD,POST,PUT,PATCH,DELETE,OPTIONS"` // 请求方法，默认 GET
+	Headers        map[string]string `json:"headers"`                                                      // 附加请求头
+	Body           string            `json:"body"`                                                         // 请求体
+	ExpectStatus   string            `json:"expect_status"`                                                // 期望状态码，如 200,301 或 200-399，为空时为 200-399
+	Keyword        string            `json:"keyword"`                                                      // 响应中需包含的关键字
+	IgnoreTLS      bool              `json:"ignore_tls"`                                                   // 忽略证书错误
+	FollowRedirect bool              `json:"follow_redirect"`                                              // 跟随跳转
+}
@@ -55,4 +55,22 @@ type ProjectDetail struct {
 	ProtectSystem   string   `json:"protect_system"`    // 保护系统 full/strict
 	ReadWritePaths  []string `json:"read_write_paths"`  // 读写路径
 	ReadOnlyPaths   []string `json:"read_only_paths"`   // 只读路径
+
+	// 蓝绿重启
+	BlueGreen ProjectBlueGreen `json:"blue_green"` // 蓝绿重启配置
+	Unit      string           `json:"unit"`       // 当前提供服务的 systemd unit，启停与日志均作用于此
+}
+
+// ProjectBlueGreen 蓝绿重启配置
+// 两个实例轮流在 BluePort 与 GreenPort 上运行，端口通过 PORT 环境变量传给项目
+type ProjectBlueGreen struct {
+	Enabled       bool   `json:"enabled"`
+	BluePort      uint   `json:"blue_port"`
+	GreenPort     uint   `json:"green_port"`
+	HealthCheck   string `json:"health_check"`   // 健康检查路径，如 /health，返回 2xx/3xx 视为就绪
+	HealthTimeout uint   `json:"health_timeout"` // 等待新实例就绪的秒数
+	DrainTimeout  uint   `json:"drain_timeout"`  // 切换后等待旧实例处理完请求的秒数
+	WebsiteID     uint   `json:"website_id"`     // 反向代理网站
+	Upstream      string `json:"upstream"`       // 网站中指向项目的上游，切换时整体改写为新实例
+	ActivePort    uint   `json:"active_port"`    // 当前提供服务的端口，0 表示仍由主 unit 提供
 }
@@ -54,6 +54,7 @@ type WebsiteSetting struct {
 	RateLimit   *types.RateLimit  `json:"rate_limit"`   // 限流限速配置
 	RealIP      *types.RealIP     `json:"real_ip"`      // 真实 IP 配置
 	BasicAuth   map[string]string `json:"basic_auth"`   // 基本认证配置
+	WAF         *types.WAF        `json:"waf"`          // Web 应用防火墙配置
 
 	// 自定义配置
 	CustomConfigs []WebsiteCustomConfig `json:"custom_configs"`
@@ -0,0 +1,259 @@
+// Package uptime 提供 HTTP、TCP 与 ICMP 可用性探测
+package uptime
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+// keywordLimit 关键字匹配时最多读取的响应体字节数
+const keywordLimit = 1 << 20
+
+// Result 单次探测结果
+type Result struct {
+	Success    bool          `json:"success"`
+	Latency    time.Duration `json:"latency"`     // 成功时为响应耗时，失败时为失败前耗时
+	StatusCode int           `json:"status_code"` // 仅 HTTP
+	Message    string        `json:"message"`     // 失败原因
+}
+
+func fail(start time.Time, err error) Result {
+	return Result{Latency: time.Since(start), Message: err.Error()}
+}
+
+// HTTP 请求 url 并按配置校验状态码与关键字
+func HTTP(ctx context.Context, url string, config types.ProbeConfig, timeout time.Duration) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := config.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	var body io.Reader
+	if config.Body != "" {
+		body = strings.NewReader(config.Body)
+	}
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fail(start, err)
+	}
+	req.Header.Set("User-Agent", "AcePanel-Uptime/1.0")
+	for key, value := range config.Headers {
+		if strings.EqualFold(key, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true // 每次都重新建连，耗时包含握手
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: config.IgnoreTLS}
+	client := &http.Client{Transport: transport}
+	if !config.FollowRedirect {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fail(start, err)
+	}
+	defer func(Body io.ReadCloser) { _ = Body.Close() }(resp.Body)
+
+	result := Result{StatusCode: resp.StatusCode}
+	if config.Keyword != "" {
+		content, err := io.ReadAll(io.LimitReader(resp.Body, keywordLimit))
+		if err != nil {
+			return fail(start, err)
+		}
+		if !bytes.Contains(content, []byte(config.Keyword)) {
+			result.Message = fmt.Sprintf("keyword %q not found in response", config.Keyword)
+		}
+	}
+	result.Latency = time.Since(start)
+
+	ok, err := MatchStatus(config.ExpectStatus, resp.StatusCode)
+	if err != nil {
+		result.Message = err.Error()
+	} else if !ok {
+		result.Message = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+	}
+	result.Success = result.Message == ""
+
+	return result
+}
+
+// MatchStatus 判断状态码是否符合期望，expect 形如 200,301 或 200-399，为空时为 200-399
+func MatchStatus(expect string, code int) (bool, error) {
+	if strings.TrimSpace(expect) == "" {
+		return code >= 200 && code < 400, nil
+	}
+
+	for _, item := range strings.Split(expect, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		low, high, found := strings.Cut(item, "-")
+		from, err := strconv.Atoi(strings.TrimSpace(low))
+		if err != nil {
+			return false, fmt.Errorf("invalid expected status: %s", item)
+		}
+		to := from
+		if found {
+			if to, err = strconv.Atoi(strings.TrimSpace(high)); err != nil {
+				return false, fmt.Errorf("invalid expected status: %s", item)
+			}
+		}
+		if code >= from && code <= to {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// TCP 检查 addr（host:port）能否建立连接
+func TCP(ctx context.Context, addr string, timeout time.Duration) Result {
+	start := time.Now()
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fail(start, err)
+	}
+	latency := time.Since(start)
+	_ = conn.Close()
+
+	return Result{Success: true, Latency: latency}
+}
+
+// pingSeq 回显序号，区分并发的探测
+var pingSeq atomic.Uint32
+
+// Ping 向 host 发送一次 ICMP 回显请求
+// 优先使用原始套接字，无权限时退回非特权的 UDP ICMP 套接字
+func Ping(ctx context.Context, host string, timeout time.Duration) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fail(start, err)
+	}
+	if len(addrs) == 0 {
+		return fail(start, fmt.Errorf("no address found for %s", host))
+	}
+	// 优先 IPv4
+	ip := addrs[0].IP
+	for _, addr := range addrs {
+		if addr.IP.To4() != nil {
+			ip = addr.IP
+			break
+		}
+	}
+
+	v4 := ip.To4() != nil
+	conn, privileged, err := listenICMP(v4)
+	if err != nil {
+		return fail(start, err)
+	}
+	defer func(conn *icmp.PacketConn) { _ = conn.Close() }(conn)
+
+	id := os.Getpid() & 0xffff
+	seq := int(pingSeq.Add(1) & 0xffff)
+	var typ icmp.Type = ipv4.ICMPTypeEcho
+	proto := 1
+	if !v4 {
+		typ = ipv6.ICMPTypeEchoRequest
+		proto = 58
+	}
+	msg := icmp.Message{Type: typ, Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("acepanel")}}
+	packet, err := msg.Marshal(nil)
+	if err != nil {
+		return fail(start, err)
+	}
+
+	var dst net.Addr = &net.IPAddr{IP: ip}
+	if !privileged {
+		dst = &net.UDPAddr{IP: ip}
+	}
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		return fail(start, err)
+	}
+
+	start = time.Now()
+	if _, err = conn.WriteTo(packet, dst); err != nil {
+		return fail(start, err)
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, peer, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				return fail(start, fmt.Errorf("ping %s timed out", ip))
+			}
+			return fail(start, err)
+		}
+		reply, err := icmp.ParseMessage(proto, buf[:n])
+		if err != nil {
+			continue
+		}
+		if reply.Type != ipv4.ICMPTypeEchoReply && reply.Type != ipv6.ICMPTypeEchoReply {
+			continue
+		}
+		echo, ok := reply.Body.(*icmp.Echo)
+		// 非特权套接字的 ID 由内核改写，只比较序号
+		if !ok || echo.Seq != seq || (privileged && echo.ID != id) || !peerIP(peer).Equal(ip) {
+			continue
+		}
+
+		return Result{Success: true, Latency: time.Since(start)}
+	}
+}
+
+func listenICMP(v4 bool) (*icmp.PacketConn, bool, error) {
+	network, udp, addr := "ip4:icmp", "udp4", "0.0.0.0"
+	if !v4 {
+		network, udp, addr = "ip6:ipv6-icmp", "udp6", "::"
+	}
+	if conn, err := icmp.ListenPacket(network, addr); err == nil {
+		return conn, true, nil
+	}
+	conn, err := icmp.ListenPacket(udp, addr)
+	return conn, false, err
+}
+
+func peerIP(addr net.Addr) net.IP {
+	switch item := addr.(type) {
+	case *net.IPAddr:
+		return item.IP
+	case *net.UDPAddr:
+		return item.IP
+	}
+	return nil
+}
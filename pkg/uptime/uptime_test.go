@@ -0,0 +1,110 @@
+package uptime
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/acepanel/panel/v3/pkg/types"
+)
+
+type UptimeTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+}
+
+func TestUptimeTestSuite(t *testing.T) {
+	suite.Run(t, &UptimeTestSuite{})
+}
+
+func (s *UptimeTestSuite) SetupSuite() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello " + r.Header.Get("X-Probe")))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	s.server = httptest.NewTLSServer(mux)
+}
+
+func (s *UptimeTestSuite) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *UptimeTestSuite) TestHTTP() {
+	ctx := context.Background()
+
+	result := HTTP(ctx, s.server.URL+"/ok", types.ProbeConfig{IgnoreTLS: true, Keyword: "hello acepanel", Headers: map[string]string{"X-Probe": "acepanel"}}, 5*time.Second)
+	s.True(result.Success, result.Message)
+	s.Equal(http.StatusOK, result.StatusCode)
+
+	result = HTTP(ctx, s.server.URL+"/ok", types.ProbeConfig{IgnoreTLS: true, Keyword: "missing"}, 5*time.Second)
+	s.False(result.Success)
+	s.Contains(result.Message, "missing")
+
+	// 自签名证书未忽略时失败
+	result = HTTP(ctx, s.server.URL+"/ok", types.ProbeConfig{}, 5*time.Second)
+	s.False(result.Success)
+
+	result = HTTP(ctx, s.server.URL+"/error", types.ProbeConfig{IgnoreTLS: true}, 5*time.Second)
+	s.False(result.Success)
+	s.Equal(http.StatusInternalServerError, result.StatusCode)
+
+	result = HTTP(ctx, s.server.URL+"/error", types.ProbeConfig{IgnoreTLS: true, ExpectStatus: "500"}, 5*time.Second)
+	s.True(result.Success)
+
+	result = HTTP(ctx, s.server.URL+"/redirect", types.ProbeConfig{IgnoreTLS: true, ExpectStatus: "200"}, 5*time.Second)
+	s.False(result.Success)
+	s.Equal(http.StatusFound, result.StatusCode)
+
+	result = HTTP(ctx, s.server.URL+"/redirect", types.ProbeConfig{IgnoreTLS: true, ExpectStatus: "200", FollowRedirect: true}, 5*time.Second)
+	s.True(result.Success, result.Message)
+}
+
+func (s *UptimeTestSuite) TestMatchStatus() {
+	cases := []struct {
+		expect string
+		code   int
+		ok     bool
+	}{
+		{"", 200, true},
+		{"", 302, true},
+		{"", 404, false},
+		{"200,301", 301, true},
+		{"200,301", 302, false},
+		{"200-299, 404", 404, true},
+		{"500-599", 200, false},
+	}
+	for _, c := range cases {
+		ok, err := MatchStatus(c.expect, c.code)
+		s.NoError(err)
+		s.Equal(c.ok, ok, "%s %d", c.expect, c.code)
+	}
+
+	_, err := MatchStatus("abc", 200)
+	s.Error(err)
+}
+
+func (s *UptimeTestSuite) TestTCP() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	addr := listener.Addr().String()
+
+	result := TCP(context.Background(), addr, 2*time.Second)
+	s.True(result.Success, result.Message)
+
+	// 关闭后端口不再可连
+	s.NoError(listener.Close())
+	result = TCP(context.Background(), addr, 2*time.Second)
+	s.False(result.Success)
+	s.NotEmpty(result.Message)
+}
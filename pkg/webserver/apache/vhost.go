@@ -536,6 +536,19 @@ func (v *baseVhost) ClearRealIP() error {
 	return nil
 }
 
+func (v *baseVhost) WAF() *types.WAF {
+	return nil
+}
+
+func (v *baseVhost) SetWAF(waf *types.WAF) error {
+	// Apache 需要 ModSecurity 等第三方模块，暂不支持
+	return errors.New("WAF is not supported on apache")
+}
+
+func (v *baseVhost) ClearWAF() error {
+	return nil
+}
+
 func (v *baseVhost) Redirects() []types.Redirect {
 	redirects, _ := parseRedirectFiles(filepath.Join(v.configDir, "site"))
 	return redirects
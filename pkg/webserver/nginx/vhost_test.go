@@ -448,6 +448,93 @@ func (s *VhostTestSuite) TestRedirect404() {
 	s.Contains(string(content), "@redirect_404")
 }
 
+func (s *VhostTestSuite) TestWAF() {
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.configDir, "shared"), 0755))
+	s.Nil(s.vhost.WAF())
+
+	waf := &types.WAF{
+		SQLi:     true,
+		BadUA:    true,
+		CC:       &types.WAFCC{Requests: 120, Window: 60},
+		IPAllow:  []string{"10.0.0.0/8"},
+		IPDeny:   []string{"192.0.2.1", " "},
+		URLAllow: []string{"^/api/webhook"},
+	}
+	s.NoError(s.vhost.SetWAF(waf))
+
+	got := s.vhost.WAF()
+	s.Require().NotNil(got)
+	s.Equal(types.WAFEngineNginx, got.Engine)
+	s.True(got.SQLi)
+	s.False(got.XSS)
+	s.Equal(120, got.CC.Requests)
+	s.Equal(60, got.CC.Block)
+	s.Equal([]string{"10.0.0.0/8"}, got.IPAllow)
+	s.Equal([]string{"192.0.2.1"}, got.IPDeny)
+
+	name := s.vhost.wafName()
+	shared := s.vhost.Config(wafSharedConf, types.ScopeShared)
+	s.Contains(shared, "10.0.0.0/8 allow;")
+	s.Contains(shared, "192.0.2.1 deny;")
+	s.Contains(shared, `"~*^/api/webhook" allow;`)
+	s.Contains(shared, " sqli;")
+	s.NotContains(shared, " xss;")
+	s.Contains(shared, " bad_ua;")
+	s.Contains(shared, "limit_req_zone $"+name+"_cc zone="+name+":10m rate=120r/m;")
+	s.Contains(shared, "log_format "+name+" escape=json")
+
+	site := s.vhost.Config(wafSiteConf, types.ScopeSite)
+	s.Contains(site, "if ($"+name+") {")
+	s.Contains(site, "limit_req zone="+name+" burst=120 nodelay;")
+	s.Contains(site, "if=$"+name+"_log;")
+	s.NoFileExists(filepath.Join(s.configDir, wafScript))
+
+	s.NoError(s.vhost.ClearWAF())
+	s.Nil(s.vhost.WAF())
+	s.Empty(s.vhost.Config(wafSharedConf, types.ScopeShared))
+}
+
+func (s *VhostTestSuite) TestWAFLua() {
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.configDir, "shared"), 0755))
+
+	s.NoError(s.vhost.SetWAF(&types.WAF{
+		Engine: types.WAFEngineLua,
+		XSS:    true,
+		CC:     &types.WAFCC{Requests: 10, Window: 1, Block: 300},
+	}))
+
+	name := s.vhost.wafName()
+	scriptPath := filepath.Join(s.configDir, wafScript)
+	script, err := os.ReadFile(scriptPath)
+	s.Require().NoError(err)
+	s.Contains(string(script), "var."+name+"_log = rule")
+	s.Contains(string(script), `"name":"xss"`)
+	s.Contains(string(script), `"block":300`)
+
+	s.Contains(s.vhost.Config(wafSharedConf, types.ScopeShared), "lua_shared_dict "+name+" 10m;")
+	site := s.vhost.Config(wafSiteConf, types.ScopeSite)
+	s.Contains(site, "access_by_lua_file "+scriptPath+";")
+	s.NotContains(site, "return 403")
+
+	// 切换回原生引擎时删除脚本
+	s.NoError(s.vhost.SetWAF(&types.WAF{XSS: true}))
+	s.NoFileExists(scriptPath)
+
+	s.NoError(s.vhost.SetWAF(&types.WAF{Engine: types.WAFEngineLua}))
+	s.NoError(s.vhost.ClearWAF())
+	s.NoFileExists(scriptPath)
+}
+
+func (s *VhostTestSuite) TestWAFInvalid() {
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.configDir, "shared"), 0755))
+
+	s.Error(s.vhost.SetWAF(&types.WAF{Engine: "modsecurity"}))
+	s.Error(s.vhost.SetWAF(&types.WAF{IPDeny: []string{"not-an-ip"}}))
+	s.Error(s.vhost.SetWAF(&types.WAF{URLDeny: []string{"("}}))
+	s.Error(s.vhost.SetWAF(&types.WAF{CC: &types.WAFCC{Requests: 10}}))
+	s.Nil(s.vhost.WAF())
+}
+
 // ProxyVhost 测试套件
 type ProxyVhostTestSuite struct {
 	suite.Suite
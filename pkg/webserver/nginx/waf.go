@@ -0,0 +1,313 @@
+package nginx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/acepanel/panel/v3/pkg/webserver/types"
+)
+
+// WAF 配置文件
+const (
+	wafSharedConf   = "020-waf.conf"
+	wafSiteConf     = "025-waf.conf"
+	wafScript       = "waf.lua"
+	wafConfigPrefix = "# config: "
+)
+
+// WAFStatSocket 拦截事件与网站统计共用 syslog 通道上报
+const WAFStatSocket = "/tmp/ace_stats.sock"
+
+// wafRule 内置规则，同一类别的任一正则命中即拦截
+type wafRule struct {
+	Name     string   `json:"name"`
+	Patterns []string `json:"patterns"`
+}
+
+// 规则同时用于 $request_uri（未解码）和 Lua 中解码后的内容，故同时匹配常见 URL 编码
+const (
+	wafSpace = `(?:[\s+]|%(?:09|0[a-d]|20|a0)|/\*.*?\*/)+` // 空白、编码空白及内联注释
+	wafBlank = `(?:\s|%20|\+)*`
+	wafQuote = "(?:'|\"|`|%2[27]|%60)"
+	wafParen = `(?:\(|%28)`
+	wafWord  = `(?:\b|%[0-9a-f]{2})` // 词边界，编码字符后的关键字同样视为词首
+)
+
+var wafSQLi = wafRule{Name: "sqli", Patterns: []string{
+	wafWord + `union` + wafSpace + `(?:all` + wafSpace + `|distinct` + wafSpace + `)?select\b`,
+	wafWord + `(?:sleep|benchmark|pg_sleep|extractvalue|updatexml)` + wafBlank + wafParen,
+	wafWord + `waitfor` + wafSpace + `delay\b`,
+	wafWord + `information_schema\b|` + wafWord + `load_file` + wafBlank + wafParen + `|` + wafWord + `into` + wafSpace + `(?:out|dump)file\b|` + wafWord + `xp_cmdshell\b`,
+	wafQuote + `(?:\s|%20|\+|\)|%29)*(?:and|or|xor)` + wafSpace + wafQuote + `?\w+` + wafQuote + `?` + wafBlank + `(?:=|%3d|<|%3c|>|%3e|` + wafWord + `like\b)`,
+	`(?:;|%3b)` + wafBlank + `(?:drop|truncate|alter)` + wafSpace + `(?:table|database)\b`,
+	wafQuote + `(?:\s|%20|\+|\)|%29)*(?:--|#|%23)`,
+}}
+
+var wafXSS = wafRule{Name: "xss", Patterns: []string{
+	`(?:<|%3c)` + wafBlank + `/?` + wafBlank + `(?:script|iframe|object|embed|svg|math|base)\b`,
+	wafWord + `(?:java|vb)script(?:\s|%0[9ad])*(?::|%3a)`,
+	wafWord + `on(?:error|load|unload|focus|blur|click|dblclick|toggle|mouse\w+|key\w+|pointer\w+|animation\w+)` + wafBlank + `(?:=|%3d)`,
+	wafWord + `(?:document\.(?:cookie|domain|write)|window\.location|string\.fromcharcode)\b`,
+	wafWord + `(?:alert|prompt|confirm|eval)` + wafBlank + `(?:\(|%28|` + "`" + `|%60)`,
+}}
+
+var wafPathTraversal = wafRule{Name: "path_traversal", Patterns: []string{
+	`(?:\.|%2e){2}(?:/|\\|%2f|%5c)`,
+	`/etc/(?:passwd|shadow|group|hosts)\b|/proc/(?:self|\d+)/|\bc:(?:\\|%5c)windows\b|\bboot\.ini\b`,
+	`(?:/|%2f)\.(?:git|svn|hg|env|htaccess|htpasswd|ds_store)\b`,
+}}
+
+var wafBadUA = wafRule{Name: "bad_ua", Patterns: []string{
+	`(?:sqlmap|nikto|nmap|masscan|zgrab|nuclei|acunetix|nessus|netsparker|openvas|appscan|wpscan|joomscan|dirbuster|gobuster|dirsearch|feroxbuster|ffuf|w3af|havij|hydra|fimap|zmeu|morfeus|jorgee|commix|xsstrike|arachni|whatweb|httrack)`,
+}}
+
+// wafRules 返回启用的 URI/请求体规则
+func wafRules(waf *types.WAF) []wafRule {
+	var rules []wafRule
+	if waf.SQLi {
+		rules = append(rules, wafSQLi)
+	}
+	if waf.XSS {
+		rules = append(rules, wafXSS)
+	}
+	if waf.PathTraversal {
+		rules = append(rules, wafPathTraversal)
+	}
+	return rules
+}
+
+func (v *baseVhost) WAF() *types.WAF {
+	content := v.Config(wafSiteConf, types.ScopeSite)
+	for line := range strings.SplitSeq(content, "\n") {
+		raw, ok := strings.CutPrefix(line, wafConfigPrefix)
+		if !ok {
+			continue
+		}
+		waf := new(types.WAF)
+		if err := json.Unmarshal([]byte(raw), waf); err != nil {
+			return nil
+		}
+		return waf
+	}
+
+	return nil
+}
+
+func (v *baseVhost) SetWAF(waf *types.WAF) error {
+	if err := normalizeWAF(waf); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(waf)
+	if err != nil {
+		return err
+	}
+
+	name := v.wafName()
+	shared := wafSharedCommon(name, v.siteName, waf)
+	site := wafConfigPrefix + string(raw) + "\n"
+	scriptPath := filepath.Join(v.configDir, wafScript)
+
+	switch waf.Engine {
+	case types.WAFEngineLua:
+		script, err := wafLuaScript(name, waf)
+		if err != nil {
+			return err
+		}
+		// 必须 0644，Nginx 在运行中以 www 用户读取
+		if err = os.WriteFile(scriptPath, []byte(script), 0644); err != nil {
+			return fmt.Errorf("failed to write waf script: %w", err)
+		}
+		if waf.CC != nil {
+			shared += fmt.Sprintf("lua_shared_dict %s 10m;\n", name)
+		}
+		site += fmt.Sprintf("set $%s_log \"\";\naccess_by_lua_file %s;\n", name, scriptPath)
+	default:
+		if err = os.Remove(scriptPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove waf script: %w", err)
+		}
+		shared += wafNginxShared(name, waf)
+		site += wafNginxSite(name, waf)
+	}
+
+	site += fmt.Sprintf("access_log syslog:server=unix:%s,nohostname,tag=%s %s if=$%s_log;\n", WAFStatSocket, name, name, name)
+
+	if err = v.SetConfig(wafSharedConf, types.ScopeShared, shared); err != nil {
+		return err
+	}
+	return v.SetConfig(wafSiteConf, types.ScopeSite, site)
+}
+
+func (v *baseVhost) ClearWAF() error {
+	if err := v.RemoveConfig(wafSharedConf, types.ScopeShared); err != nil {
+		return err
+	}
+	if err := v.RemoveConfig(wafSiteConf, types.ScopeSite); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(v.configDir, wafScript)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove waf script: %w", err)
+	}
+	return nil
+}
+
+// wafName 返回站点 WAF 变量名前缀，nginx 变量名只允许字母数字和下划线
+// 替换字符后 a.b 与 a_b 会重名，追加原站点名的短哈希区分
+func (v *baseVhost) wafName() string {
+	hash := sha256.Sum256([]byte(v.siteName))
+	return "ace_waf_" + strings.Map(func(char rune) rune {
+		if char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9' {
+			return char
+		}
+		return '_'
+	}, v.siteName) + "_" + hex.EncodeToString(hash[:4])
+}
+
+// normalizeWAF 校验 WAF 配置并补全默认值
+func normalizeWAF(waf *types.WAF) error {
+	if waf.Engine == "" {
+		waf.Engine = types.WAFEngineNginx
+	}
+	if waf.Engine != types.WAFEngineNginx && waf.Engine != types.WAFEngineLua {
+		return fmt.Errorf("unsupported waf engine: %s", waf.Engine)
+	}
+
+	// 去除前端动态输入产生的空行
+	for _, list := range []*[]string{&waf.IPAllow, &waf.IPDeny, &waf.URLAllow, &waf.URLDeny} {
+		*list = lo.Compact(lo.Map(*list, func(item string, _ int) string { return strings.TrimSpace(item) }))
+	}
+
+	if waf.CC != nil {
+		if waf.CC.Requests <= 0 || waf.CC.Window <= 0 {
+			return fmt.Errorf("invalid cc config: %d requests in %d seconds", waf.CC.Requests, waf.CC.Window)
+		}
+		if waf.CC.Block <= 0 {
+			waf.CC.Block = waf.CC.Window
+		}
+	}
+
+	for _, ip := range slices.Concat(waf.IPAllow, waf.IPDeny) {
+		if _, err := netip.ParsePrefix(ip); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(ip); err != nil {
+			return fmt.Errorf("invalid ip or cidr: %s", ip)
+		}
+	}
+	for _, pattern := range slices.Concat(waf.URLAllow, waf.URLDeny) {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid url pattern %s: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// wafSharedCommon 生成两种引擎共用的 http 级配置：IP 名单和拦截日志格式
+func wafSharedCommon(name, site string, waf *types.WAF) string {
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "geo $%s_ip {\n    default \"\";\n", name)
+	for _, ip := range waf.IPAllow {
+		_, _ = fmt.Fprintf(&sb, "    %s allow;\n", ip)
+	}
+	for _, ip := range waf.IPDeny {
+		_, _ = fmt.Fprintf(&sb, "    %s deny;\n", ip)
+	}
+	sb.WriteString("}\n")
+
+	_, _ = fmt.Fprintf(&sb, `log_format %s escape=json '{"site":"%s","waf":"$%s_log","uri":"$request_uri","method":"$request_method","ip":"$remote_addr","ua":"$http_user_agent","status":$status}';`+"\n", name, site, name)
+
+	return sb.String()
+}
+
+// wafNginxShared 生成原生 Nginx 引擎的 map 规则
+// 各检查结果拼接为 "IP:URL:规则:UA" 后按优先级取拦截原因，白名单优先于黑名单和规则
+func wafNginxShared(name string, waf *types.WAF) string {
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "map $request_uri $%s_url {\n    default \"\";\n", name)
+	for _, pattern := range waf.URLAllow {
+		_, _ = fmt.Fprintf(&sb, "    %s allow;\n", wafQuoteRegex(pattern))
+	}
+	for _, pattern := range waf.URLDeny {
+		_, _ = fmt.Fprintf(&sb, "    %s deny;\n", wafQuoteRegex(pattern))
+	}
+	sb.WriteString("}\n")
+
+	_, _ = fmt.Fprintf(&sb, "map $request_uri $%s_rule {\n    default \"\";\n", name)
+	for _, rule := range wafRules(waf) {
+		for _, pattern := range rule.Patterns {
+			_, _ = fmt.Fprintf(&sb, "    %s %s;\n", wafQuoteRegex(pattern), rule.Name)
+		}
+	}
+	sb.WriteString("}\n")
+
+	_, _ = fmt.Fprintf(&sb, "map $http_user_agent $%s_ua {\n    default \"\";\n", name)
+	if waf.BadUA {
+		for _, pattern := range wafBadUA.Patterns {
+			_, _ = fmt.Fprintf(&sb, "    %s %s;\n", wafQuoteRegex(pattern), wafBadUA.Name)
+		}
+	}
+	sb.WriteString("}\n")
+
+	_, _ = fmt.Fprintf(&sb, `map "$%[1]s_ip:$%[1]s_url:$%[1]s_rule:$%[1]s_ua" $%[1]s {
+    default "";
+    "~^allow:" "";
+    "~^[^:]*:allow:" "";
+    "~^deny:" ip_deny;
+    "~^[^:]*:deny:" url_deny;
+    "~^[^:]*:[^:]*:([^:]+):" $1;
+    "~:([^:]+)$" $1;
+}
+`, name)
+
+	if waf.CC != nil {
+		// 白名单不参与计数，空 key 不会被 limit_req 统计
+		_, _ = fmt.Fprintf(&sb, `map "$%[1]s_ip:$%[1]s_url" $%[1]s_cc {
+    default $binary_remote_addr;
+    "~^allow:" "";
+    "~^[^:]*:allow$" "";
+}
+limit_req_zone $%[1]s_cc zone=%[1]s:10m rate=%[2]dr/m;
+`, name, max(1, waf.CC.Requests*60/waf.CC.Window))
+	}
+
+	// 日志变量在 log 阶段求值，此时才能取到 limit_req 的结果
+	_, _ = fmt.Fprintf(&sb, `map "$%[1]s:$limit_req_status" $%[1]s_log {
+    default "";
+    "~^:REJECTED" cc;
+    "~^([^:]+):" $1;
+}
+`, name)
+
+	return sb.String()
+}
+
+// wafNginxSite 生成原生 Nginx 引擎的 server 级配置
+func wafNginxSite(name string, waf *types.WAF) string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "if ($%s) {\n    return 403;\n}\n", name)
+	if waf.CC != nil {
+		_, _ = fmt.Fprintf(&sb, "limit_req zone=%s burst=%d nodelay;\nlimit_req_status 429;\n", name, waf.CC.Requests)
+	}
+	return sb.String()
+}
+
+// wafQuoteRegex 将正则转为不区分大小写的 map 匹配串
+// nginx 会还原引号内的 \\、\" 以及 \t 等转义，故反斜杠需要整体转义
+func wafQuoteRegex(pattern string) string {
+	pattern = strings.ReplaceAll(pattern, `\`, `\\`)
+	pattern = strings.ReplaceAll(pattern, `"`, `\"`)
+	return `"~*` + pattern + `"`
+}
@@ -0,0 +1,140 @@
+package nginx
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/acepanel/panel/v3/pkg/webserver/types"
+)
+
+// wafLuaConfig 写入 Lua 脚本的规则数据
+type wafLuaConfig struct {
+	URLAllow []string     `json:"url_allow,omitempty"`
+	URLDeny  []string     `json:"url_deny,omitempty"`
+	UA       []string     `json:"ua,omitempty"`
+	Rules    []wafRule    `json:"rules,omitempty"`
+	CC       *types.WAFCC `json:"cc,omitempty"`
+}
+
+// wafLuaTemplate OpenResty 引擎脚本，规则在 worker 内首次执行时解析并缓存
+const wafLuaTemplate = `-- Auto-generated by AcePanel. DO NOT EDIT MANUALLY!
+local key = "{{name}}"
+local waf = package.loaded[key]
+if not waf then
+    waf = require("cjson.safe").decode({{config}}) or {}
+    package.loaded[key] = waf
+end
+
+local find = ngx.re.find
+local var = ngx.var
+
+local function match(patterns, subject)
+    if not patterns or not subject or subject == "" then
+        return false
+    end
+    for _, pattern in ipairs(patterns) do
+        if find(subject, pattern, "joi") then
+            return true
+        end
+    end
+    return false
+end
+
+local function block(rule, status)
+    var.{{name}}_log = rule
+    return ngx.exit(status)
+end
+
+local ip = var.{{name}}_ip
+if ip == "allow" then
+    return
+end
+if ip == "deny" then
+    return block("ip_deny", ngx.HTTP_FORBIDDEN)
+end
+
+local uri = var.request_uri
+if match(waf.url_allow, uri) then
+    return
+end
+if match(waf.url_deny, uri) then
+    return block("url_deny", ngx.HTTP_FORBIDDEN)
+end
+
+local cc = waf.cc
+if cc then
+    local dict = ngx.shared.{{name}}
+    local addr = var.binary_remote_addr
+    if dict:get("block:" .. addr) then
+        return block("cc", 429)
+    end
+    local count = dict:incr("count:" .. addr, 1, 0, cc.window)
+    if count and count > cc.requests then
+        dict:set("block:" .. addr, true, cc.block)
+        return block("cc", 429)
+    end
+end
+
+if match(waf.ua, var.http_user_agent) then
+    return block("bad_ua", ngx.HTTP_FORBIDDEN)
+end
+
+local rules = waf.rules
+if not rules then
+    return
+end
+
+local decoded = ngx.unescape_uri(uri)
+for _, rule in ipairs(rules) do
+    if match(rule.patterns, uri) or match(rule.patterns, decoded) then
+        return block(rule.name, ngx.HTTP_FORBIDDEN)
+    end
+end
+
+-- 仅检查已缓冲在内存中的请求体，超过 client_body_buffer_size 的上传不做检查
+local method = ngx.req.get_method()
+if method ~= "POST" and method ~= "PUT" and method ~= "PATCH" then
+    return
+end
+ngx.req.read_body()
+local body = ngx.req.get_body_data()
+if not body or #body > 65536 then
+    return
+end
+
+body = ngx.unescape_uri(body)
+for _, rule in ipairs(rules) do
+    if match(rule.patterns, body) then
+        return block(rule.name, ngx.HTTP_FORBIDDEN)
+    end
+end
+`
+
+// wafLuaScript 生成站点的 OpenResty WAF 脚本
+func wafLuaScript(name string, waf *types.WAF) (string, error) {
+	cfg := wafLuaConfig{
+		URLAllow: waf.URLAllow,
+		URLDeny:  waf.URLDeny,
+		Rules:    wafRules(waf),
+		CC:       waf.CC,
+	}
+	if waf.BadUA {
+		cfg.UA = wafBadUA.Patterns
+	}
+
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.NewReplacer("{{name}}", name, "{{config}}", luaLongString(string(raw))).Replace(wafLuaTemplate), nil
+}
+
+// luaLongString 将内容包装为 Lua 长字符串，自动选择不冲突的等号层级
+func luaLongString(s string) string {
+	level := ""
+	for strings.Contains(s, "]"+level+"]") {
+		level += "="
+	}
+	return "[" + level + "[" + s + "]" + level + "]"
+}
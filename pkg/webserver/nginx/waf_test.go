@@ -0,0 +1,124 @@
+package nginx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acepanel/panel/v3/pkg/webserver/types"
+)
+
+func matchRule(rule wafRule, subject string) bool {
+	for _, pattern := range rule.Patterns {
+		if regexp.MustCompile("(?i)" + pattern).MatchString(subject) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWAFRules(t *testing.T) {
+	tests := []struct {
+		rule    wafRule
+		blocked []string
+		allowed []string
+	}{
+		{
+			rule: wafSQLi,
+			blocked: []string{
+				"/item.php?id=1%20UNION%20ALL%20SELECT%201,2,3",
+				"/item.php?id=1+union/**/select+password+from+users",
+				"/item.php?id=1'%20or%20'1'='1",
+				"/item.php?id=1%27+OR+1%3d1--",
+				"/item.php?id=1;drop table users",
+				"/item.php?id=sleep(5)",
+				"/?q=information_schema.tables",
+			},
+			allowed: []string{
+				"/blog/select-the-best-union-rates",
+				"/search?q=rock+or+roll",
+				"/api/users?sort=name&order=desc",
+				"/products/sleepwear",
+			},
+		},
+		{
+			rule: wafXSS,
+			blocked: []string{
+				"/?q=<script>alert(1)</script>",
+				"/?q=%3Cscript%3E",
+				"/?q=%3csvg/onload=alert(1)%3e",
+				"/?u=javascript:alert(1)",
+				"/?q=x%22%20onerror%3dprompt(1)",
+				"/?q=document.cookie",
+			},
+			allowed: []string{
+				"/scripts/app.js",
+				"/javascript-tutorial",
+				"/?q=online+store",
+				"/embedded-systems",
+			},
+		},
+		{
+			rule: wafPathTraversal,
+			blocked: []string{
+				"/download?file=../../etc/passwd",
+				"/download?file=%2e%2e%2f%2e%2e%2fetc%2fpasswd",
+				"/static/..%5c..%5cwindows",
+				"/.git/config",
+				"/.env",
+				"/proc/self/environ",
+			},
+			allowed: []string{
+				"/static/app.min.js",
+				"/docs/v1.2/index.html",
+				"/environment",
+				"/blog/.well-known/security.txt",
+			},
+		},
+		{
+			rule:    wafBadUA,
+			blocked: []string{"sqlmap/1.7.2#stable (https://sqlmap.org)", "Mozilla/5.00 (Nikto/2.1.6)", "Nuclei - Open-source project"},
+			allowed: []string{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36", "curl/8.4.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule.Name, func(t *testing.T) {
+			for _, subject := range tt.blocked {
+				assert.True(t, matchRule(tt.rule, subject), subject)
+			}
+			for _, subject := range tt.allowed {
+				assert.False(t, matchRule(tt.rule, subject), subject)
+			}
+		})
+	}
+}
+
+func TestWAFName(t *testing.T) {
+	name := (&baseVhost{siteName: "a.b"}).wafName()
+	assert.Regexp(t, `^ace_waf_a_b_[0-9a-f]{8}$`, name)
+	assert.NotEqual(t, name, (&baseVhost{siteName: "a_b"}).wafName())
+	assert.Equal(t, name, (&baseVhost{siteName: "a.b"}).wafName())
+}
+
+func TestWAFQuoteRegex(t *testing.T) {
+	assert.Equal(t, `"~*\\bunion\\s\"x"`, wafQuoteRegex(`\bunion\s"x`))
+}
+
+func TestLuaLongString(t *testing.T) {
+	assert.Equal(t, "[[abc]]", luaLongString("abc"))
+	assert.Equal(t, "[=[a]]b]=]", luaLongString("a]]b"))
+	assert.Equal(t, "[==[a]]b]=]c]==]", luaLongString("a]]b]=]c"))
+}
+
+func TestWAFLuaScript(t *testing.T) {
+	script, err := wafLuaScript("ace_waf_example", &types.WAF{SQLi: true, URLDeny: []string{"^/admin"}})
+	require.NoError(t, err)
+	assert.Contains(t, script, `local key = "ace_waf_example"`)
+	assert.Contains(t, script, "var.ace_waf_example_ip")
+	assert.Contains(t, script, `"url_deny":["^/admin"]`)
+	assert.Contains(t, script, `"name":"sqli"`)
+	assert.NotContains(t, script, "{{")
+}
@@ -91,6 +91,13 @@ type Vhost interface {
 	// ClearRealIP 清除真实 IP 配置
 	ClearRealIP() error
 
+	// WAF 取 Web 应用防火墙配置
+	WAF() *WAF
+	// SetWAF 设置 Web 应用防火墙配置
+	SetWAF(waf *WAF) error
+	// ClearWAF 清除 Web 应用防火墙配置
+	ClearWAF() error
+
 	// Config 取指定名称的配置内容
 	Config(name string, scope ConfigScope) string
 	// SetConfig 设置指定名称的配置内容，自动添加生成标记注释
@@ -0,0 +1,28 @@
+package types
+
+// WAF 引擎
+const (
+	WAFEngineNginx = "nginx" // 原生 Nginx，规则编译为 map/geo 配置，仅检查 URI 和 User-Agent
+	WAFEngineLua   = "lua"   // OpenResty，规则由 Lua 执行，额外检查请求体
+)
+
+// WAF Web 应用防火墙配置
+type WAF struct {
+	Engine        string   `json:"engine"`         // 引擎，由面板按 Web 服务器自动选择
+	SQLi          bool     `json:"sqli"`           // SQL 注入
+	XSS           bool     `json:"xss"`            // 跨站脚本
+	PathTraversal bool     `json:"path_traversal"` // 目录穿越及敏感文件访问
+	BadUA         bool     `json:"bad_ua"`         // 扫描器等恶意 User-Agent
+	CC            *WAFCC   `json:"cc"`             // CC 防护，nil 表示不启用
+	IPAllow       []string `json:"ip_allow"`       // IP 白名单，命中后跳过全部检查
+	IPDeny        []string `json:"ip_deny"`        // IP 黑名单
+	URLAllow      []string `json:"url_allow"`      // URL 白名单（正则），命中后跳过规则检查
+	URLDeny       []string `json:"url_deny"`       // URL 黑名单（正则）
+}
+
+// WAFCC CC 防护配置
+type WAFCC struct {
+	Requests int `json:"requests"` // 周期内允许的最大请求数
+	Window   int `json:"window"`   // 统计周期，单位秒
+	Block    int `json:"block"`    // 超限后封禁时长，单位秒，仅 Lua 引擎支持
+}
@@ -15,9 +15,13 @@ type Aggregator struct {
 
 	// 错误日志缓冲
 	errBuf []*ErrorEntry
+	// WAF 拦截事件缓冲
+	wafBuf []*WAFEntry
+	// 站点累计计数（进程生命周期内只增不减，供指标导出）
+	totals map[string]*SiteTotals
 
 	// 可配置上限
-	ErrBufMaxSize int  // 错误缓冲最大条目数，默认 10000
+	ErrBufMaxSize int  // 错误缓冲最大条目数，默认 10000，同时用于 WAF 事件缓冲
 	UVMaxKeys     int  // 每站每天 UV 去重 set 上限，默认 1000000
 	IPMaxKeys     int  // 每站每天 IP 去重 set 上限，默认 500000
 	DetailMaxKeys int  // 详细统计（蜘蛛/客户端/IP/URI）每站每天最大条目数，默认 50000
@@ -84,6 +88,7 @@ type rtSlot struct {
 func NewAggregator() *Aggregator {
 	return &Aggregator{
 		days:          make(map[string]map[string]*siteDay),
+		totals:        make(map[string]*SiteTotals),
 		ErrBufMaxSize: 10000,
 		UVMaxKeys:     1000000,
 		IPMaxKeys:     500000,
@@ -131,6 +136,12 @@ func (a *Aggregator) Record(entry *LogEntry) {
 		sites[entry.Site] = sd
 	}
 
+	st, ok := a.totals[entry.Site]
+	if !ok {
+		st = &SiteTotals{}
+		a.totals[entry.Site] = st
+	}
+
 	// 确保小时桶已初始化
 	hb := sd.hours[hour]
 	if hb == nil {
@@ -146,6 +157,11 @@ func (a *Aggregator) Record(entry *LogEntry) {
 	sd.bandwidth += entry.Bytes
 	sd.bandwidthIn += entry.ReqLength
 
+	// 更新累计计数
+	st.Requests++
+	st.Bandwidth += entry.Bytes
+	st.BandwidthIn += entry.ReqLength
+
 	// 更新小时桶
 	hb.requests++
 	hb.bandwidth += entry.Bytes
@@ -158,6 +174,8 @@ func (a *Aggregator) Record(entry *LogEntry) {
 		sd.requestTimeCount++
 		hb.requestTimeSum += ms
 		hb.requestTimeCount++
+		st.RequestTimeSum += ms
+		st.RequestTimeCount++
 	}
 
 	// 状态码分组
@@ -165,15 +183,19 @@ func (a *Aggregator) Record(entry *LogEntry) {
 	case entry.Status >= 200 && entry.Status < 300:
 		sd.status2xx++
 		hb.status2xx++
+		st.Status2xx++
 	case entry.Status >= 300 && entry.Status < 400:
 		sd.status3xx++
 		hb.status3xx++
+		st.Status3xx++
 	case entry.Status >= 400 && entry.Status < 500:
 		sd.status4xx++
 		hb.status4xx++
+		st.Status4xx++
 	case entry.Status >= 500 && entry.Status < 600:
 		sd.status5xx++
 		hb.status5xx++
+		st.Status5xx++
 	}
 
 	// UV: IP+UA 去重（每日 + 每小时），超限后不再插入
@@ -196,6 +218,7 @@ func (a *Aggregator) Record(entry *LogEntry) {
 	if isPV {
 		sd.pv++
 		hb.pv++
+		st.PV++
 	}
 
 	// 蜘蛛检测
@@ -297,6 +320,18 @@ func (a *Aggregator) SiteStats() map[string]*SiteSnapshot {
 	return result
 }
 
+// Totals 返回各站点自进程启动以来的累计计数，不受刷盘和清空统计影响
+func (a *Aggregator) Totals() map[string]SiteTotals {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result := make(map[string]SiteTotals, len(a.totals))
+	for name, st := range a.totals {
+		result[name] = *st
+	}
+	return result
+}
+
 // DrainSnapshot 返回自上次 drain 以来的增量快照
 // 返回 commit 函数，调用方写库成功后必须调用 commit 来消费已快照的增量
 // 若写库失败不调用 commit，数据保留在内存中，下次 drain 会再次包含
@@ -398,6 +433,52 @@ func (a *Aggregator) DrainErrors() (entries []*ErrorEntry, commit func()) {
 	return
 }
 
+// RecordWAF 记录一条 WAF 拦截事件，拦截请求已由访问日志计入统计，此处不重复计数
+func (a *Aggregator) RecordWAF(entry *LogEntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if st, ok := a.totals[entry.Site]; ok {
+		st.WAFBlocked++
+	} else {
+		a.totals[entry.Site] = &SiteTotals{WAFBlocked: 1}
+	}
+
+	if len(a.wafBuf) >= a.ErrBufMaxSize {
+		return
+	}
+	a.wafBuf = append(a.wafBuf, &WAFEntry{
+		Site:   entry.Site,
+		Rule:   entry.WAF,
+		URI:    entry.URI,
+		Method: entry.Method,
+		IP:     entry.IP,
+		UA:     entry.UA,
+		Status: entry.Status,
+	})
+}
+
+// DrainWAF 取出 WAF 事件缓冲快照
+// 返回 commit 函数，写库成功后调用以移除已消费的条目
+func (a *Aggregator) DrainWAF() (entries []*WAFEntry, commit func()) {
+	a.mu.Lock()
+	n := len(a.wafBuf)
+	entries = make([]*WAFEntry, n)
+	copy(entries, a.wafBuf)
+	a.mu.Unlock()
+
+	commit = func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if n >= len(a.wafBuf) {
+			a.wafBuf = nil
+		} else {
+			a.wafBuf = a.wafBuf[n:]
+		}
+	}
+	return
+}
+
 // Reset 清空所有内存聚合数据（配合 DB 清空使用）
 func (a *Aggregator) Reset() {
 	a.mu.Lock()
@@ -405,6 +486,7 @@ func (a *Aggregator) Reset() {
 
 	a.days = make(map[string]map[string]*siteDay)
 	a.errBuf = nil
+	a.wafBuf = nil
 }
 
 // DrainDetailStats 导出详细统计增量（蜘蛛/客户端/IP/URI）
@@ -82,6 +82,7 @@ func ParseLogEntry(tag string, data []byte) (*LogEntry, error) {
 		ContentType: getString(v, "content_type"),
 		ReqLength:   uint64(getInt(v, "req_length")),
 		RequestTime: getFloat(v, "rt"),
+		WAF:         getString(v, "waf"),
 	}
 
 	// 仅 4xx/5xx 时才提取 body
@@ -13,6 +13,7 @@ type LogEntry struct {
 	ContentType string  // 响应 Content-Type（PV 判定用）
 	ReqLength   uint64  // 请求大小（入站流量）
 	RequestTime float64 // 请求耗时（秒）
+	WAF         string  // WAF 拦截规则，非空表示该条为拦截事件
 }
 
 // HourSnapshot 小时粒度快照
@@ -52,6 +53,21 @@ type SiteSnapshot struct {
 	Hours            [24]*HourSnapshot `json:"-"`
 }
 
+// SiteTotals 站点累计计数
+type SiteTotals struct {
+	PV               uint64
+	Requests         uint64
+	Bandwidth        uint64
+	BandwidthIn      uint64
+	RequestTimeSum   uint64 // 毫秒
+	RequestTimeCount uint64
+	Status2xx        uint64
+	Status3xx        uint64
+	Status4xx        uint64
+	Status5xx        uint64
+	WAFBlocked       uint64
+}
+
 // RealtimeStats 实时统计
 type RealtimeStats struct {
 	Bandwidth   float64 `json:"bandwidth"`    // 出站字节/秒
@@ -70,6 +86,17 @@ type ErrorEntry struct {
 	Status int
 }
 
+// WAFEntry WAF 拦截事件条目
+type WAFEntry struct {
+	Site   string
+	Rule   string
+	URI    string
+	Method string
+	IP     string
+	UA     string
+	Status int
+}
+
 // SiteDetailSnapshot 站点详细统计快照（用于 DrainDetailStats）
 type SiteDetailSnapshot struct {
 	Spiders map[string]uint64       // spider_name → requests
@@ -11,6 +11,10 @@ export default {
   configTune: (): any => http.Get('/apps/prometheus/config_tune'),
   // 保存配置调整参数
   saveConfigTune: (data: any): any => http.Post('/apps/prometheus/config_tune', data),
+  // 面板指标
+  panelMetrics: (): any => http.Get('/apps/prometheus/panel_metrics'),
+  updatePanelMetrics: (enabled: boolean, reset = false): any =>
+    http.Post('/apps/prometheus/panel_metrics', { enabled, reset }),
   // Alertmanager 配置
   alertmanagerConfig: (): any => http.Get('/apps/prometheus/alertmanager_config'),
   saveAlertmanagerConfig: (config: string): any =>
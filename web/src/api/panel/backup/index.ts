@@ -2,11 +2,11 @@ import { http } from '@/utils'
 
 export default {
   // 获取备份列表
-  list: (type: string, page: number, limit: number): any =>
-    http.Get(`/backup/${type}`, { params: { page, limit } }),
+  list: (type: string, page: number, limit: number, storage = 0): any =>
+    http.Get(`/backup/${type}`, { params: { page, limit, storage } }),
   // 创建备份
-  create: (type: string, target: string | null, storage: number): any =>
-    http.Post(`/backup/${type}`, { target, storage }),
+  create: (type: string, target: string | null, storage: number, dedup = false): any =>
+    http.Post(`/backup/${type}`, { target, storage, dedup }),
   // 上传备份
   upload: (type: string, formData: FormData): any => http.Post(`/backup/${type}/upload`, formData),
   // 删除备份
@@ -14,4 +14,7 @@ export default {
   // 恢复备份
   restore: (type: string, file: string, target: string | null): any =>
     http.Post(`/backup/${type}/restore`, { file, target }),
+  // 校验备份
+  verify: (type: string, file: string, storage = 0): any =>
+    http.Post(`/backup/${type}/verify`, { file, storage }),
 }
@@ -49,4 +49,31 @@ export default {
   // 部署
   deploy: (id: number, website_ids: number[], enable_https: boolean = false): any =>
     http.Post(`/cert/cert/${id}/deploy`, { id, website_ids, enable_https }),
+  // 部署目标列表
+  targets: (id: number): any => http.Get(`/cert/cert/${id}/targets`),
+  // 部署目标添加
+  targetCreate: (data: any): any => http.Post('/cert/target', data),
+  // 部署目标更新
+  targetUpdate: (id: number, data: any): any => http.Put(`/cert/target/${id}`, data),
+  // 部署目标删除
+  targetDelete: (id: number): any => http.Delete(`/cert/target/${id}`),
+  // 部署目标执行部署
+  targetRun: (id: number): any => http.Post(`/cert/target/${id}/run`, { id }),
+  // 部署目标历史
+  targetLogs: (id: number, page: number, limit: number): any =>
+    http.Get(`/cert/target/${id}/logs`, { params: { page, limit } }),
+  // 证书监控列表
+  monitors: (page: number, limit: number): any =>
+    http.Get('/cert/monitor', { params: { page, limit } }),
+  // 证书监控添加
+  monitorCreate: (data: any): any => http.Post('/cert/monitor', data),
+  // 证书监控更新
+  monitorUpdate: (id: number, data: any): any => http.Put(`/cert/monitor/${id}`, data),
+  // 证书监控删除
+  monitorDelete: (id: number): any => http.Delete(`/cert/monitor/${id}`),
+  // 证书监控立即检查
+  monitorCheck: (id: number): any => http.Post(`/cert/monitor/${id}/check`, { id }),
+  // 证书监控检查历史
+  monitorChecks: (id: number, page: number, limit: number): any =>
+    http.Get(`/cert/monitor/${id}/checks`, { params: { page, limit } }),
 }
@@ -13,4 +13,17 @@ export default {
   delete: (id: number): any => http.Delete(`/cron/${id}`),
   // 修改任务状态
   status: (id: number, status: boolean): any => http.Post('/cron/' + id + '/status', { status }),
+  // 立即执行
+  run: (id: number): any => http.Post(`/cron/${id}/run`),
+  // 执行记录
+  runs: (id: number, page: number, limit: number): any =>
+    http.Get(`/cron/${id}/runs`, { params: { page, limit } }),
+  // 执行统计
+  runStats: (id: number): any => http.Get(`/cron/${id}/runs/stats`),
+  // 单次执行输出
+  runOutput: (id: number, run: number): any => http.Get(`/cron/${id}/runs/${run}/output`),
+  // 获取执行记录保留设置
+  runSetting: (): any => http.Get('/cron/run_setting'),
+  // 保存执行记录保留设置
+  runSettingSave: (setting: any): any => http.Post('/cron/run_setting', setting),
 }
@@ -0,0 +1,25 @@
+import { http } from '@/utils'
+
+export default {
+  // 获取项目或网站的部署配置
+  target: (target: string, target_id: number): any =>
+    http.Get('/deployment', { params: { target, target_id } }),
+  // 获取部署配置
+  get: (id: number): any => http.Get(`/deployment/${id}`),
+  // 创建部署配置
+  create: (data: any): any => http.Post('/deployment', data),
+  // 更新部署配置
+  update: (id: number, data: any): any => http.Put(`/deployment/${id}`, data),
+  // 删除部署配置
+  delete: (id: number): any => http.Delete(`/deployment/${id}`),
+  // 重新生成部署密钥
+  resetKey: (id: number): any => http.Post(`/deployment/${id}/key`),
+  // 立即部署
+  deploy: (id: number): any => http.Post(`/deployment/${id}/deploy`),
+  // 获取版本列表
+  releases: (id: number, page: number, limit: number): any =>
+    http.Get(`/deployment/${id}/releases`, { params: { page, limit } }),
+  // 回滚到指定版本
+  rollback: (id: number, release: number): any =>
+    http.Post(`/deployment/${id}/rollback`, { release }),
+}
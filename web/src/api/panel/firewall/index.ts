@@ -22,6 +22,10 @@ export default {
   createIpRule: (rule: any): any => http.Post('/firewall/ip_rule', rule),
   // 删除防火墙IP规则
   deleteIpRule: (rule: any): any => http.Delete('/firewall/ip_rule', rule),
+  // 创建防火墙地区规则
+  createGeoRule: (rule: any): any => http.Post('/firewall/geo_rule', rule),
+  // 删除防火墙地区规则
+  deleteGeoRule: (id: number): any => http.Delete(`/firewall/geo_rule/${id}`),
   // 获取防火墙转发规则
   forwards: (page: number, limit: number): any =>
     http.Get('/firewall/forward', { params: { page, limit } }),
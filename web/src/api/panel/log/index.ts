@@ -8,4 +8,23 @@ export default {
   dates: (type: 'app' | 'db' | 'http'): any => http.Get('/log/dates', { params: { type } }),
   // 获取 SSH 登录日志
   ssh: (limit: number = 100): any => http.Get('/log/ssh', { params: { limit } }),
+  // 获取终端录像列表
+  terminals: (type: string, page: number, limit: number): any =>
+    http.Get('/log/terminal', { params: { type, page, limit } }),
+  // 删除终端录像
+  terminalDelete: (id: number): any => http.Delete(`/log/terminal/${id}`),
+  // 获取终端录像设置
+  terminalSetting: (): any => http.Get('/log/terminal/setting'),
+  // 保存终端录像设置
+  terminalSettingSave: (data: any): any => http.Post('/log/terminal/setting', data),
+  // 校验审计日志哈希链
+  auditVerify: (): any => http.Get('/log/audit/verify'),
+  // 获取审计日志设置
+  auditSetting: (): any => http.Get('/log/audit/setting'),
+  // 保存审计日志设置
+  auditSettingSave: (data: any): any => http.Post('/log/audit/setting', data),
+  // 获取审计日志转发设置
+  auditForward: (): any => http.Get('/log/audit/forward'),
+  // 保存审计日志转发设置
+  auditForwardSave: (data: any): any => http.Post('/log/audit/forward', data),
 }
@@ -0,0 +1,21 @@
+import { http } from '@/utils'
+
+export default {
+  // 探测列表
+  list: (page: number, limit: number): any =>
+    http.Get('/monitor/probe', { params: { page, limit } }),
+  // 新增探测
+  create: (data: any): any => http.Post('/monitor/probe', data),
+  // 更新探测
+  update: (id: number, data: any): any => http.Put(`/monitor/probe/${id}`, data),
+  // 删除探测
+  delete: (id: number): any => http.Delete(`/monitor/probe/${id}`),
+  // 立即探测
+  check: (id: number): any => http.Post(`/monitor/probe/${id}/check`, { id }),
+  // 探测统计
+  stats: (id: number, start: number, end: number): any =>
+    http.Get(`/monitor/probe/${id}/stats`, { params: { start, end } }),
+  // 探测记录
+  records: (id: number, page: number, limit: number): any =>
+    http.Get(`/monitor/probe/${id}/records`, { params: { page, limit } }),
+}
@@ -12,4 +12,6 @@ export default {
   update: (id: number, data: any): any => http.Put(`/project/${id}`, data),
   // 删除项目
   delete: (id: number): any => http.Delete(`/project/${id}`),
+  // 蓝绿重启
+  restart: (id: number): any => http.Post(`/project/${id}/restart`),
 }
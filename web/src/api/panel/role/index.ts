@@ -0,0 +1,18 @@
+import { http } from '@/utils'
+
+export default {
+  // 角色列表
+  list: (page: number, limit: number): any => http.Get('/roles', { params: { page, limit } }),
+  // 全部角色
+  all: (): any => http.Get('/roles/all'),
+  // 可分配的权限标签与资源范围类型
+  permissions: (): any => http.Get('/roles/permissions'),
+  // 获取角色
+  get: (id: number): any => http.Get(`/roles/${id}`),
+  // 创建角色
+  create: (data: any): any => http.Post('/roles', data),
+  // 更新角色
+  update: (id: number, data: any): any => http.Put(`/roles/${id}`, data),
+  // 删除角色
+  delete: (id: number): any => http.Delete(`/roles/${id}`),
+}
@@ -11,4 +11,12 @@ export default {
   delete: (id: number): any => http.Delete(`/task/${id}`),
   // 取消任务
   cancel: (id: number): any => http.Post(`/task/${id}/cancel`),
+  // 取消等待中的任务，resource 为空时取消全部
+  cancelWaiting: (resource = ''): any => http.Post('/task/cancel_waiting', { resource }),
+  // 调整任务优先级
+  priority: (id: number, priority: number): any => http.Post(`/task/${id}/priority`, { priority }),
+  // 获取队列设置
+  setting: (): any => http.Get('/task/setting'),
+  // 保存队列设置
+  settingSave: (data: any): any => http.Post('/task/setting', data),
 }
@@ -31,8 +31,11 @@ export default {
   // 获取用户列表
   list: (page: number, limit: number): any => http.Get(`/users`, { params: { page, limit } }),
   // 创建用户
-  create: (username: string, password: string, email: string): any =>
-    http.Post('/users', { username, password, email }),
+  create: (username: string, password: string, email: string, role_id: number, scopes = {}): any =>
+    http.Post('/users', { username, password, email, role_id, scopes }),
+  // 更新用户角色与资源范围
+  updateRole: (id: number, role_id: number, scopes: Record<string, number[]>): any =>
+    http.Post(`/users/${id}/role`, { role_id, scopes }),
   // 删除用户
   delete: (id: number): any => http.Delete(`/users/${id}`),
   // 更新用户用户名
@@ -53,13 +56,11 @@ export default {
   tokenList: (user_id: number, page: number, limit: number): any =>
     http.Get(`/user_tokens`, { params: { user_id, page, limit } }),
   // 创建用户Token
-  tokenCreate: (user_id: number, ips: string[], expired_at: number): any =>
-    http.Post('/user_tokens', { user_id, ips, expired_at }),
+  tokenCreate: (user_id: number, data: any): any => http.Post('/user_tokens', { user_id, ...data }),
   // 删除用户Token
   tokenDelete: (id: number): any => http.Delete(`/user_tokens/${id}`),
   // 更新用户Token
-  tokenUpdate: (id: number, ips: string[], expired_at: number): any =>
-    http.Put(`/user_tokens/${id}`, { ips, expired_at }),
+  tokenUpdate: (id: number, data: any): any => http.Put(`/user_tokens/${id}`, data),
   // 通行密钥
   passkeyEnabled: (): any => http.Get('/user/passkey/enabled'),
   passkeySupported: (): any => http.Get('/user_passkeys/supported'),
@@ -38,6 +38,15 @@ export default {
   // 签发证书
   obtainCert: (id: number, dns_id?: number): any =>
     http.Post(`/website/${id}/obtain_cert`, dns_id ? { dns_id } : {}),
+  // 配置版本列表
+  revisions: (id: number, page: number, limit: number): any =>
+    http.Get(`/website/${id}/revisions`, { params: { page, limit } }),
+  // 配置版本之间的差异，to 为 0 时与当前配置比较
+  revisionDiff: (id: number, from: number, to = 0): any =>
+    http.Get(`/website/${id}/revisions/diff`, { params: { from, to } }),
+  // 恢复配置版本
+  revisionRestore: (id: number, revision: number): any =>
+    http.Post(`/website/${id}/revisions/${revision}/restore`),
   // 统计概览
   statOverview: (start: string, end: string, sites?: string): any =>
     http.Get('/website/stat/overview', { params: { start, end, sites } }),
@@ -88,6 +97,15 @@ export default {
     limit?: number,
   ): any =>
     http.Get('/website/stat/errors', { params: { start, end, sites, status, page, limit } }),
+  // WAF 拦截统计
+  statWAF: (
+    start: string,
+    end: string,
+    sites?: string,
+    rule?: string,
+    page?: number,
+    limit?: number,
+  ): any => http.Get('/website/stat/waf', { params: { start, end, sites, rule, page, limit } }),
   // 清空统计
   statClear: (): any => http.Post('/website/stat/clear'),
   // 统计设置
@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Apps',
         icon: 'mdi:apps',
-        role: ['admin'],
+        role: ['admin', '应用'],
         requireAuth: true,
       },
     },
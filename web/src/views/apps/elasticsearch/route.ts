@@ -14,7 +14,7 @@ export default {
       component: () => import('./IndexView.vue'),
       meta: {
         title: 'ElasticSearch',
-        role: ['admin'],
+        role: ['admin', '应用'],
         requireAuth: true,
       },
     },
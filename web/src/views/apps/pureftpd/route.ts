@@ -14,7 +14,7 @@ export default {
       component: () => import('./IndexView.vue'),
       meta: {
         title: 'Pure-FTPd',
-        role: ['admin'],
+        role: ['admin', '应用'],
         requireAuth: true,
       },
     },
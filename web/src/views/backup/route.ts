@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Backup',
         icon: 'mdi:backup-outline',
-        role: ['admin'],
+        role: ['admin', '备份'],
         requireAuth: true,
       },
     },
@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Certificate',
         icon: 'mdi:certificate-outline',
-        role: ['admin'],
+        role: ['admin', '证书'],
         requireAuth: true,
       },
     },
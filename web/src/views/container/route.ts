@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Container',
         icon: 'mdi:layers-outline',
-        role: ['admin'],
+        role: ['admin', '容器'],
         requireAuth: true,
       },
     },
@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Database',
         icon: 'mdi:database-outline',
-        role: ['admin'],
+        role: ['admin', '数据库'],
         requireAuth: true,
       },
     },
@@ -16,7 +16,7 @@ export default {
       meta: {
         title: 'Go',
         icon: 'mdi:language-go',
-        role: ['admin'],
+        role: ['admin', '运行环境'],
         requireAuth: true,
       },
     },
@@ -28,7 +28,7 @@ export default {
       meta: {
         title: 'Java',
         icon: 'mdi:language-java',
-        role: ['admin'],
+        role: ['admin', '运行环境'],
         requireAuth: true,
       },
     },
@@ -40,7 +40,7 @@ export default {
       meta: {
         title: 'Node.js',
         icon: 'mdi:nodejs',
-        role: ['admin'],
+        role: ['admin', '运行环境'],
         requireAuth: true,
       },
     },
@@ -52,7 +52,7 @@ export default {
       meta: {
         title: 'PHP',
         icon: 'mdi:language-php',
-        role: ['admin'],
+        role: ['admin', '运行环境'],
         requireAuth: true,
       },
     },
@@ -64,7 +64,7 @@ export default {
       meta: {
         title: 'Python',
         icon: 'mdi:language-python',
-        role: ['admin'],
+        role: ['admin', '运行环境'],
         requireAuth: true,
       },
     },
@@ -76,7 +76,7 @@ export default {
       meta: {
         title: '.NET',
         icon: 'mdi:dot-net',
-        role: ['admin'],
+        role: ['admin', '运行环境'],
         requireAuth: true,
       },
     },
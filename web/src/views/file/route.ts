@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Files',
         icon: 'mdi:folder-open-outline',
-        role: ['admin'],
+        role: ['admin', '文件'],
         requireAuth: true,
       },
     },
@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Home',
         icon: 'mdi:house-outline',
-        role: ['admin'],
+        role: ['admin', '首页'],
         requireAuth: true,
       },
     },
@@ -29,7 +29,7 @@ export default {
       meta: {
         title: 'Update',
         icon: 'mdi:archive-arrow-up-outline',
-        role: ['admin'],
+        role: ['admin', '设置'],
         requireAuth: true,
       },
     },
@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Logs',
         icon: 'mdi:file-document-outline',
-        role: ['admin'],
+        role: ['admin', '日志'],
         requireAuth: true,
       },
     },
@@ -8,8 +8,16 @@ export interface MetricMeta {
   placeholder: string
 }
 
-// 状态类指标语义固定为「不在运行」，不需要运算符与阈值
-const statusMetrics = ['service', 'project', 'container', 'app', 'database']
+// 状态类指标语义固定为「不在运行」「异常」或「探测失败」，不需要运算符与阈值
+const statusMetrics = [
+  'service',
+  'project',
+  'container',
+  'app',
+  'database',
+  'cert_monitor_error',
+  'probe',
+]
 
 export function isStatusMetric(type: string) {
   return statusMetrics.includes(type)
@@ -149,6 +157,34 @@ export function useAlertMetrics() {
       target: 'optional',
       placeholder: $gettext('Website name, empty for all'),
     },
+    {
+      label: $gettext('External Certificate Remaining Days'),
+      value: 'cert_monitor',
+      unit: $gettext('days'),
+      target: 'optional',
+      placeholder: $gettext('Certificate monitor name, empty for all'),
+    },
+    {
+      label: $gettext('External Certificate Abnormal'),
+      value: 'cert_monitor_error',
+      unit: '',
+      target: 'optional',
+      placeholder: $gettext('Certificate monitor name, empty for all'),
+    },
+    {
+      label: $gettext('Probe Down'),
+      value: 'probe',
+      unit: '',
+      target: 'optional',
+      placeholder: $gettext('Probe name, empty for all'),
+    },
+    {
+      label: $gettext('Probe Response Time'),
+      value: 'probe_latency',
+      unit: 'ms',
+      target: 'optional',
+      placeholder: $gettext('Probe name, empty for all'),
+    },
   ])
 
   const operators = computed(() => [
@@ -170,8 +206,14 @@ export function useAlertMetrics() {
       placeholder: '',
     }
 
-  // conditionText 规则条件的可读文本，状态类固定为「不在运行」
+  // conditionText 规则条件的可读文本，状态类固定为「不在运行」「异常」或「探测失败」
   const conditionText = (rule: any) => {
+    if (rule.type === 'cert_monitor_error') {
+      return $gettext('abnormal')
+    }
+    if (rule.type === 'probe') {
+      return $gettext('down')
+    }
     if (isStatusMetric(rule.type)) {
       return $gettext('not running')
     }
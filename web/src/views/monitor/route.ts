@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Monitoring',
         icon: 'mdi:chart-line',
-        role: ['admin'],
+        role: ['admin', '监控'],
         requireAuth: true,
       },
     },
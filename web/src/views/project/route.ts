@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Project',
         icon: 'mdi:folder-multiple-outline',
-        role: ['admin'],
+        role: ['admin', '项目'],
         requireAuth: true,
       },
     },
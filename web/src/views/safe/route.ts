@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Security',
         icon: 'mdi:security',
-        role: ['admin'],
+        role: ['admin', '安全'],
         requireAuth: true,
       },
     },
@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Setting',
         icon: 'mdi:settings-outline',
-        role: ['admin'],
+        role: ['admin', '设置'],
         requireAuth: true,
       },
     },
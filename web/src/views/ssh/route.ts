@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Terminal',
         icon: 'mdi:console',
-        role: ['admin'],
+        role: ['admin', 'SSH'],
         requireAuth: true,
         keepAlive: true,
       },
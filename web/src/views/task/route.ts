@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Task',
         icon: 'mdi:timetable',
-        role: ['admin'],
+        role: ['admin', '任务'],
         requireAuth: true,
       },
     },
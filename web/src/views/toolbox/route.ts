@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Toolbox',
         icon: 'mdi:tools',
-        role: ['admin'],
+        role: ['admin', '工具箱'],
         requireAuth: true,
       },
     },
@@ -17,7 +17,7 @@ export default {
       meta: {
         title: 'Website',
         icon: 'mdi:web',
-        role: ['admin'],
+        role: ['admin', '网站'],
         requireAuth: true,
       },
     },